@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/models"
+	"github.com/marcus/td/internal/output"
+	"github.com/marcus/td/internal/query"
+	"github.com/marcus/td/internal/session"
+	"github.com/spf13/cobra"
+)
+
+var assignCmd = &cobra.Command{
+	Use:   "assign",
+	Short: "Distribute matching issues across sessions",
+	Long: `Hand out a batch of work: every non-closed issue matching --query is
+assigned to the sessions listed in --round-robin, one at a time in priority
+order (then oldest first), so each gets an even share. Sessions are given by
+ID or name; an assigned issue is offered to its session first by td next.
+
+Issues that already have an implementer are left alone unless --reassign is
+given. All assignments are applied in one transaction and logged, so td undo
+can revert them one issue at a time.
+
+Examples:
+  td assign --query "is(open) AND label(sprint-3)" --round-robin alice,bob,carol
+  td assign --query "type = bug" --round-robin alice,bob --reassign
+  td assign --query "is(open)" --round-robin alice,bob --dry-run`,
+	GroupID: "workflow",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		baseDir := getBaseDir()
+
+		queryStr, _ := cmd.Flags().GetString("query")
+		roundRobin, _ := cmd.Flags().GetString("round-robin")
+		refs := splitAssignees(roundRobin)
+		if len(refs) == 0 {
+			output.Error("--round-robin needs at least one session ID or name")
+			return fmt.Errorf("no assignees")
+		}
+		reassign, _ := cmd.Flags().GetBool("reassign")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		database, err := db.Open(baseDir)
+		if err != nil {
+			output.Error("%v", err)
+			return err
+		}
+		defer database.Close()
+
+		sess, err := session.GetOrCreate(database)
+		if err != nil {
+			output.Error("%v", err)
+			return err
+		}
+
+		assignees, err := resolveAssignees(database, refs)
+		if err != nil {
+			output.Error("%v", err)
+			return err
+		}
+
+		identities, _ := session.IdentitySessionIDs(database, sess)
+		matches, err := query.Execute(database, queryStr, sess.ID, query.ExecuteOptions{Identities: identities, Statuses: customStatuses()})
+		if err != nil {
+			output.Error("%v", err)
+			return err
+		}
+
+		plan, skipped := roundRobinAssignments(matches, assignees, reassign)
+		if !dryRun && len(plan) > 0 {
+			if _, err := database.AssignIssuesLogged(plan, sess.ID); err != nil {
+				output.Error("failed to assign: %v", err)
+				return err
+			}
+		}
+
+		distribution := make(map[string][]string, len(assignees))
+		for _, a := range assignees {
+			distribution[a.ID] = []string{}
+		}
+		for _, a := range plan {
+			distribution[a.To] = append(distribution[a.To], a.IssueID)
+		}
+
+		if jsonMode(cmd) {
+			if plan == nil {
+				plan = []db.IssueAssignment{}
+			}
+			return output.EmitResult("assign", map[string]any{
+				"dry_run":      dryRun,
+				"assignments":  plan,
+				"distribution": distribution,
+				"skipped":      skipped,
+			})
+		}
+
+		if len(plan) == 0 {
+			fmt.Println("No issues to assign match the query")
+		} else {
+			verb := "ASSIGNED"
+			if dryRun {
+				verb = "WOULD ASSIGN"
+			}
+			fmt.Printf("%s %d issue(s) across %d session(s):\n", verb, len(plan), len(assignees))
+			for _, a := range assignees {
+				ids := distribution[a.ID]
+				fmt.Printf("  %-28s %d  %s\n", a.Display(), len(ids), strings.Join(ids, ", "))
+			}
+		}
+		if len(skipped) > 0 {
+			fmt.Printf("Skipped %d already-assigned issue(s); use --reassign to include them\n", len(skipped))
+		}
+		return nil
+	},
+}
+
+// splitAssignees splits a comma-separated --round-robin list, dropping blanks.
+func splitAssignees(list string) []string {
+	var refs []string
+	for _, ref := range strings.Split(list, ",") {
+		if ref = strings.TrimSpace(ref); ref != "" {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// resolveAssignees resolves each session ID or name, keeping the given order
+// and dropping repeats of the same session.
+func resolveAssignees(database *db.DB, refs []string) ([]*session.Session, error) {
+	var assignees []*session.Session
+	seen := make(map[string]bool)
+	for _, ref := range refs {
+		s, err := session.Resolve(database, ref)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[s.ID] {
+			seen[s.ID] = true
+			assignees = append(assignees, s)
+		}
+	}
+	return assignees, nil
+}
+
+// roundRobinAssignments deals the non-closed issues out to assignees in turn,
+// highest priority first and oldest first within a priority, so no assignee
+// ends up with more than one issue over any other. Issues that already have
+// an implementer are returned as skipped unless reassign is set.
+func roundRobinAssignments(issues []models.Issue, assignees []*session.Session, reassign bool) ([]db.IssueAssignment, []string) {
+	var candidates []models.Issue
+	skipped := []string{}
+	for _, issue := range issues {
+		switch {
+		case issue.Status == models.StatusClosed:
+		case issue.ImplementerSession != "" && !reassign:
+			skipped = append(skipped, issue.ID)
+		default:
+			candidates = append(candidates, issue)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return a.ID < b.ID
+	})
+
+	var plan []db.IssueAssignment
+	for i, issue := range candidates {
+		plan = append(plan, db.IssueAssignment{
+			IssueID: issue.ID,
+			From:    issue.ImplementerSession,
+			To:      assignees[i%len(assignees)].ID,
+		})
+	}
+	return plan, skipped
+}
+
+func init() {
+	rootCmd.AddCommand(assignCmd)
+
+	assignCmd.Flags().String("query", "", "TDQ query selecting the issues to assign")
+	assignCmd.Flags().String("round-robin", "", "Comma-separated session IDs or names to deal the issues out to")
+	assignCmd.Flags().Bool("reassign", false, "Also reassign issues that already have an implementer")
+	assignCmd.Flags().Bool("dry-run", false, "Show the distribution without applying it")
+	_ = assignCmd.MarkFlagRequired("query")
+	_ = assignCmd.MarkFlagRequired("round-robin")
+}
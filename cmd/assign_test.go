@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/models"
+	"github.com/marcus/td/internal/session"
+)
+
+func runAssignCommand(t *testing.T, dir string, flags map[string]string) string {
+	t.Helper()
+	saveAndRestoreGlobals(t)
+	t.Setenv("TD_SESSION_ID", "ses_assign_cmd")
+	baseDirOverride = &dir
+
+	for _, name := range []string{"query", "round-robin"} {
+		_ = assignCmd.Flags().Set(name, "")
+	}
+	for _, name := range []string{"reassign", "dry-run"} {
+		_ = assignCmd.Flags().Set(name, "false")
+	}
+	for name, value := range flags {
+		if err := assignCmd.Flags().Set(name, value); err != nil {
+			t.Fatalf("set --%s: %v", name, err)
+		}
+	}
+
+	var runErr error
+	out := captureStdout(t, func() {
+		runErr = assignCmd.RunE(assignCmd, nil)
+	})
+	if runErr != nil {
+		t.Fatalf("assign returned error: %v\n%s", runErr, out)
+	}
+	return out
+}
+
+func TestRoundRobinAssignmentsEvenInPriorityOrder(t *testing.T) {
+	base := time.Now()
+	var issues []models.Issue
+	for i, p := range []models.Priority{models.PriorityP3, models.PriorityP1, models.PriorityP2, models.PriorityP1, models.PriorityP0, models.PriorityP2, models.PriorityP3} {
+		issues = append(issues, models.Issue{
+			ID:        fmt.Sprintf("td-%d", i),
+			Status:    models.StatusOpen,
+			Priority:  p,
+			CreatedAt: base.Add(time.Duration(i) * time.Minute),
+		})
+	}
+	issues = append(issues, models.Issue{ID: "td-closed", Status: models.StatusClosed, Priority: models.PriorityP0})
+	assignees := []*session.Session{{ID: "ses_a"}, {ID: "ses_b"}, {ID: "ses_c"}}
+
+	plan, skipped := roundRobinAssignments(issues, assignees, false)
+	if len(skipped) != 0 {
+		t.Errorf("skipped = %v, want none", skipped)
+	}
+	want := []struct{ id, to string }{
+		{"td-4", "ses_a"}, // P0
+		{"td-1", "ses_b"}, // P1, older
+		{"td-3", "ses_c"},
+		{"td-2", "ses_a"}, // P2
+		{"td-5", "ses_b"},
+		{"td-0", "ses_c"}, // P3
+		{"td-6", "ses_a"},
+	}
+	if len(plan) != len(want) {
+		t.Fatalf("plan = %+v, want %d assignments (closed issues left out)", plan, len(want))
+	}
+	counts := map[string]int{}
+	for i, w := range want {
+		if plan[i].IssueID != w.id || plan[i].To != w.to {
+			t.Errorf("assignment %d = %s -> %s, want %s -> %s", i, plan[i].IssueID, plan[i].To, w.id, w.to)
+		}
+		counts[plan[i].To]++
+	}
+	if counts["ses_a"] != 3 || counts["ses_b"] != 2 || counts["ses_c"] != 2 {
+		t.Errorf("distribution = %v, want 3/2/2", counts)
+	}
+}
+
+func TestAssignRoundRobinSkipsAssignedUnlessReassign(t *testing.T) {
+	dir := t.TempDir()
+	database, err := db.Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	now := time.Now()
+	for _, row := range []*db.SessionRow{
+		{ID: "ses_alice", Name: "alice", StartedAt: now, LastActivity: now},
+		{ID: "ses_bob", Name: "bob", StartedAt: now, LastActivity: now},
+		{ID: "ses_carol", Name: "carol", StartedAt: now, LastActivity: now},
+	} {
+		if err := database.UpsertSession(row); err != nil {
+			t.Fatalf("UpsertSession failed: %v", err)
+		}
+	}
+
+	var open []*models.Issue
+	for i := range 4 {
+		issue := &models.Issue{Title: fmt.Sprintf("Task %d", i), Status: models.StatusOpen, Labels: []string{"batch"}}
+		if err := database.CreateIssue(issue); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		open = append(open, issue)
+	}
+	taken := &models.Issue{Title: "Taken", Status: models.StatusInProgress, Labels: []string{"batch"}}
+	if err := database.CreateIssue(taken); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	taken.ImplementerSession = "ses_other"
+	if err := database.UpdateIssue(taken); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+
+	runAssignCommand(t, dir, map[string]string{
+		"query":       `label("batch")`,
+		"round-robin": "alice,bob,carol",
+	})
+
+	counts := map[string]int{}
+	for _, issue := range open {
+		got, err := database.GetIssue(issue.ID)
+		if err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		counts[got.ImplementerSession]++
+	}
+	if counts["ses_alice"] != 2 || counts["ses_bob"] != 1 || counts["ses_carol"] != 1 {
+		t.Errorf("distribution = %v, want alice 2, bob 1, carol 1", counts)
+	}
+	if got, _ := database.GetIssue(taken.ID); got.ImplementerSession != "ses_other" {
+		t.Errorf("already-assigned issue moved to %q without --reassign", got.ImplementerSession)
+	}
+
+	runAssignCommand(t, dir, map[string]string{
+		"query":       `label("batch")`,
+		"round-robin": "carol",
+		"reassign":    "true",
+	})
+	for _, issue := range append(open, taken) {
+		if got, _ := database.GetIssue(issue.ID); got.ImplementerSession != "ses_carol" {
+			t.Errorf("%s implementer = %q after --reassign, want ses_carol", issue.ID, got.ImplementerSession)
+		}
+	}
+}
@@ -50,6 +50,7 @@ var mutatingCommands = map[string]bool{
 	"reject":     true,
 	"log":        true,
 	"handoff":    true,
+	"label":      true,
 	"focus":      true,
 	"unfocus":    true,
 	"link":       true,
@@ -64,6 +65,7 @@ var mutatingCommands = map[string]bool{
 	"blocked-by": true,
 	"depends-on": true,
 	"epic":       true,
+	"group":      true,
 	"task":       true,
 	"ws":         true,
 	"monitor":    true,
@@ -135,6 +137,7 @@ func autoSyncOnce() int64 {
 	apiKey := syncconfig.GetAPIKey()
 	client := syncclient.New(serverURL, apiKey, deviceID)
 	client.HTTP.Timeout = autoSyncHTTPTimeout
+	client.PushBatchSize = syncconfig.GetPushBatchSize()
 
 	if err := autoSyncPush(database, client, syncState, deviceID); err != nil {
 		slog.Debug("autosync: push", "err", err)
@@ -191,10 +194,12 @@ func autoSyncAfterMutation() {
 	}
 }
 
-// pushBatchWithRetry pushes one batch, retrying transient failures with
-// exponential backoff until the shared deadline. Unauthorized errors are
-// terminal and returned immediately. Each attempt's HTTP timeout is clamped to
-// the time remaining so a single attempt cannot run far past the budget.
+// pushBatchWithRetry pushes the pending events, retrying transient failures
+// with exponential backoff until the shared deadline. Unauthorized and
+// oversized-event errors are terminal and returned immediately. A retry
+// resends batches that already landed; the server acks those as duplicates.
+// Each attempt's HTTP timeout is clamped to the time remaining so a single
+// attempt cannot run far past the budget.
 //
 // Note that when the server is slow (not fast-failing) the first attempt can
 // consume most of the budget, leaving room for at most one short retry — the
@@ -229,7 +234,7 @@ func pushBatchWithRetry(client *syncclient.Client, projectID string, req *synccl
 			return resp, nil
 		}
 		lastErr = err
-		if errors.Is(err, syncclient.ErrUnauthorized) {
+		if errors.Is(err, syncclient.ErrUnauthorized) || errors.Is(err, syncclient.ErrEventTooLarge) {
 			return nil, err
 		}
 
@@ -352,7 +357,7 @@ func autoSyncApplyPullBatch(database *db.DB, events []tdsync.Event, deviceID str
 }
 
 // autoSyncPush pushes pending events silently. Returns nil if nothing to push.
-// Batches events to stay within server limits (pushBatchSize from sync.go).
+// The client splits large pending sets into server-sized batches.
 func autoSyncPush(database *db.DB, client *syncclient.Client, state *db.SyncState, deviceID string) error {
 	sess, err := session.GetOrCreate(database)
 	if err != nil {
@@ -375,76 +380,66 @@ func autoSyncPush(database *db.DB, client *syncclient.Client, state *db.SyncStat
 		return nil
 	}
 
-	// Bound the total time spent retrying across all batches.
+	// Bound the total time spent retrying.
 	deadline := time.Now().Add(autoSyncPushBudget)
 
-	var allAcks []tdsync.Ack
-	var maxActionID int64
-	var allHistoryEntries []db.SyncHistoryEntry
-
-	// Push in batches to stay within server limits
-	for i := 0; i < len(events); i += pushBatchSize {
-		end := i + pushBatchSize
-		if end > len(events) {
-			end = len(events)
-		}
-		batch := events[i:end]
-
-		pushReq := &syncclient.PushRequest{
-			DeviceID:  deviceID,
-			SessionID: sess.ID,
-		}
-		for _, ev := range batch {
-			pushReq.Events = append(pushReq.Events, syncclient.EventInput{
-				ClientActionID:  ev.ClientActionID,
-				ActionType:      ev.ActionType,
-				EntityType:      ev.EntityType,
-				EntityID:        ev.EntityID,
-				Payload:         ev.Payload,
-				ClientTimestamp: ev.ClientTimestamp.Format(time.RFC3339),
-			})
-		}
+	pushReq := &syncclient.PushRequest{
+		DeviceID:  deviceID,
+		SessionID: sess.ID,
+	}
+	for _, ev := range events {
+		pushReq.Events = append(pushReq.Events, syncclient.EventInput{
+			ClientActionID:  ev.ClientActionID,
+			ActionType:      ev.ActionType,
+			EntityType:      ev.EntityType,
+			EntityID:        ev.EntityID,
+			Payload:         ev.Payload,
+			ClientTimestamp: ev.ClientTimestamp.Format(time.RFC3339),
+		})
+	}
 
-		pushResp, err := pushBatchWithRetry(client, state.ProjectID, pushReq, deadline)
-		if err != nil {
-			if errors.Is(err, syncclient.ErrUnauthorized) {
-				return fmt.Errorf("unauthorized")
-			}
-			return fmt.Errorf("push batch %d/%d: %w", i/pushBatchSize+1, (len(events)+pushBatchSize-1)/pushBatchSize, err)
+	pushResp, err := pushBatchWithRetry(client, state.ProjectID, pushReq, deadline)
+	if err != nil {
+		if errors.Is(err, syncclient.ErrUnauthorized) {
+			return fmt.Errorf("unauthorized")
 		}
+		return err
+	}
 
-		for _, a := range pushResp.Acks {
-			allAcks = append(allAcks, tdsync.Ack{ClientActionID: a.ClientActionID, ServerSeq: a.ServerSeq})
-			if a.ClientActionID > maxActionID {
-				maxActionID = a.ClientActionID
-			}
+	var allAcks []tdsync.Ack
+	var maxActionID int64
+	for _, a := range pushResp.Acks {
+		allAcks = append(allAcks, tdsync.Ack{ClientActionID: a.ClientActionID, ServerSeq: a.ServerSeq})
+		if a.ClientActionID > maxActionID {
+			maxActionID = a.ClientActionID
 		}
-		for _, r := range pushResp.Rejected {
-			if r.Reason == "duplicate" && r.ServerSeq > 0 {
-				allAcks = append(allAcks, tdsync.Ack{ClientActionID: r.ClientActionID, ServerSeq: r.ServerSeq})
-				if r.ClientActionID > maxActionID {
-					maxActionID = r.ClientActionID
-				}
+	}
+	for _, r := range pushResp.Rejected {
+		if r.Reason == "duplicate" && r.ServerSeq > 0 {
+			allAcks = append(allAcks, tdsync.Ack{ClientActionID: r.ClientActionID, ServerSeq: r.ServerSeq})
+			if r.ClientActionID > maxActionID {
+				maxActionID = r.ClientActionID
 			}
 		}
+	}
 
-		// Build history entries for this batch
-		ackMap := make(map[int64]int64)
-		for _, a := range pushResp.Acks {
-			ackMap[a.ClientActionID] = a.ServerSeq
-		}
-		for _, ev := range batch {
-			if seq, ok := ackMap[ev.ClientActionID]; ok {
-				allHistoryEntries = append(allHistoryEntries, db.SyncHistoryEntry{
-					Direction:  "push",
-					ActionType: ev.ActionType,
-					EntityType: ev.EntityType,
-					EntityID:   ev.EntityID,
-					ServerSeq:  seq,
-					DeviceID:   deviceID,
-					Timestamp:  time.Now(),
-				})
-			}
+	// Build history entries for accepted events
+	var allHistoryEntries []db.SyncHistoryEntry
+	ackMap := make(map[int64]int64)
+	for _, a := range pushResp.Acks {
+		ackMap[a.ClientActionID] = a.ServerSeq
+	}
+	for _, ev := range events {
+		if seq, ok := ackMap[ev.ClientActionID]; ok {
+			allHistoryEntries = append(allHistoryEntries, db.SyncHistoryEntry{
+				Direction:  "push",
+				ActionType: ev.ActionType,
+				EntityType: ev.EntityType,
+				EntityID:   ev.EntityID,
+				ServerSeq:  seq,
+				DeviceID:   deviceID,
+				Timestamp:  time.Now(),
+			})
 		}
 	}
 
@@ -14,6 +14,7 @@ import (
 	"github.com/marcus/td/internal/db"
 	"github.com/marcus/td/internal/session"
 	"github.com/marcus/td/internal/syncclient"
+	"github.com/marcus/td/internal/syncconfig"
 )
 
 // fakePushServer returns an httptest server that accepts push requests,
@@ -200,6 +201,45 @@ func TestAutoSyncPush_BatchesLargePayload(t *testing.T) {
 	}
 }
 
+func TestAutoSyncPush_ConfiguredBatchSize(t *testing.T) {
+	t.Setenv("TD_SYNC_PUSH_BATCH_SIZE", "300")
+	totalEvents := 2500
+	database := setupAutoSyncTestDB(t, totalEvents)
+
+	srv, rec := fakePushServer(t, 1000)
+	defer srv.Close()
+
+	client := syncclient.New(srv.URL, "test-key", "dev-test")
+	client.PushBatchSize = syncconfig.GetPushBatchSize()
+
+	state, err := database.GetSyncState()
+	if err != nil || state == nil {
+		t.Fatalf("get sync state: %v", err)
+	}
+	if err := autoSyncPush(database, client, state, "dev-test"); err != nil {
+		t.Fatalf("autoSyncPush: %v", err)
+	}
+
+	// 8 full batches of 300 plus the remaining 100
+	rec.mu.Lock()
+	batches := append([]int{}, rec.batchSizes...)
+	rec.mu.Unlock()
+	if len(batches) != 9 || batches[0] != 300 || batches[8] != 100 {
+		t.Fatalf("expected 8x300 + 100, got %v", batches)
+	}
+	if rec.totalPushed() != totalEvents {
+		t.Fatalf("expected %d total pushed, got %d", totalEvents, rec.totalPushed())
+	}
+
+	var unsynced int
+	if err := database.Conn().QueryRow(`SELECT COUNT(*) FROM action_log WHERE synced_at IS NULL AND undone = 0`).Scan(&unsynced); err != nil {
+		t.Fatalf("count unsynced: %v", err)
+	}
+	if unsynced != 0 {
+		t.Errorf("expected 0 unsynced events, got %d", unsynced)
+	}
+}
+
 func TestAutoSyncPush_SmallPayloadSingleBatch(t *testing.T) {
 	totalEvents := 100
 	database := setupAutoSyncTestDB(t, totalEvents)
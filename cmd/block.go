@@ -7,7 +7,6 @@ import (
 	"github.com/marcus/td/internal/models"
 	"github.com/marcus/td/internal/output"
 	"github.com/marcus/td/internal/session"
-	"github.com/marcus/td/internal/workflow"
 	"github.com/spf13/cobra"
 )
 
@@ -54,7 +53,7 @@ var blockCmd = &cobra.Command{
 			}
 
 			// Validate transition with state machine
-			sm := workflow.DefaultMachine()
+			sm := workflowMachine()
 			if !sm.IsValidTransition(issue.Status, models.StatusBlocked) {
 				emitWarn("cannot block %s: invalid transition from %s", issueID, issue.Status)
 				continue
@@ -152,7 +151,7 @@ Examples:
 			}
 
 			// Validate transition with state machine
-			sm := workflow.DefaultMachine()
+			sm := workflowMachine()
 			if !sm.IsValidTransition(issue.Status, models.StatusOpen) {
 				emitWarn("cannot reopen %s: invalid transition from %s", issueID, issue.Status)
 				skipped++
@@ -260,7 +259,7 @@ Examples:
 			}
 
 			// Validate transition with state machine
-			sm := workflow.DefaultMachine()
+			sm := workflowMachine()
 			if !sm.IsValidTransition(issue.Status, models.StatusOpen) {
 				emitWarn("cannot unblock %s: invalid transition from %s", issueID, issue.Status)
 				skipped++
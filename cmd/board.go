@@ -177,12 +177,13 @@ var boardShowCmd = &cobra.Command{
 		// Parse status filter
 		var statusFilter []models.Status
 		if statusStr, _ := cmd.Flags().GetStringArray("status"); len(statusStr) > 0 {
+			sm := workflowMachine()
 			for _, s := range statusStr {
 				for _, part := range strings.Split(s, ",") {
 					part = strings.TrimSpace(part)
 					if part != "" {
 						status := models.NormalizeStatus(part)
-						if models.IsValidStatus(status) {
+						if sm.HasStatus(status) {
 							statusFilter = append(statusFilter, status)
 						}
 					}
@@ -192,46 +193,14 @@ var boardShowCmd = &cobra.Command{
 
 		// Default: hide closed unless specified
 		if len(statusFilter) == 0 {
-			statusFilter = []models.Status{
-				models.StatusOpen,
-				models.StatusInProgress,
-				models.StatusBlocked,
-				models.StatusInReview,
-			}
+			statusFilter = defaultBoardStatuses
 		}
 
 		// Get issues for the board
-		var issues []models.BoardIssueView
-		if board.Query != "" {
-			// Execute TDQ query, then apply positions
-			queryResults, err := query.Execute(database, board.Query, sessionID, query.ExecuteOptions{})
-			if err != nil {
-				output.Error("Query error: %v", err)
-				return err
-			}
-			// Filter by status (query.Execute doesn't filter by status)
-			var filtered []models.Issue
-			statusSet := make(map[models.Status]bool)
-			for _, s := range statusFilter {
-				statusSet[s] = true
-			}
-			for _, issue := range queryResults {
-				if statusSet[issue.Status] {
-					filtered = append(filtered, issue)
-				}
-			}
-			issues, err = database.ApplyBoardPositions(board.ID, filtered)
-			if err != nil {
-				output.Error("%v", err)
-				return err
-			}
-		} else {
-			// Empty query - use GetBoardIssues which handles this case
-			issues, err = database.GetBoardIssues(board.ID, sessionID, statusFilter)
-			if err != nil {
-				output.Error("%v", err)
-				return err
-			}
+		issues, err := boardIssues(database, board, sessionID, statusFilter)
+		if err != nil {
+			output.Error("%v", err)
+			return err
 		}
 
 		asJSON := jsonMode(cmd)
@@ -440,6 +409,43 @@ var boardUnpositionCmd = &cobra.Command{
 	},
 }
 
+// defaultBoardStatuses is the status filter td board show uses when none is
+// given: everything but closed.
+var defaultBoardStatuses = []models.Status{
+	models.StatusOpen,
+	models.StatusInProgress,
+	models.StatusBlocked,
+	models.StatusInReview,
+}
+
+// boardIssues returns the board's issues with the given statuses in board
+// order: positioned issues first, then the rest in query order.
+func boardIssues(database *db.DB, board *models.Board, sessionID string, statusFilter []models.Status) ([]models.BoardIssueView, error) {
+	if board.Query == "" {
+		// Empty query - use GetBoardIssues which handles this case
+		return database.GetBoardIssues(board.ID, sessionID, statusFilter)
+	}
+
+	// Execute TDQ query, then apply positions
+	identities, _ := session.IdentitySessionIDsByID(database, sessionID)
+	queryResults, err := query.Execute(database, board.Query, sessionID, query.ExecuteOptions{Identities: identities, Statuses: customStatuses()})
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w", err)
+	}
+	// Filter by status (query.Execute doesn't filter by status)
+	var filtered []models.Issue
+	statusSet := make(map[models.Status]bool)
+	for _, s := range statusFilter {
+		statusSet[s] = true
+	}
+	for _, issue := range queryResults {
+		if statusSet[issue.Status] {
+			filtered = append(filtered, issue)
+		}
+	}
+	return database.ApplyBoardPositions(board.ID, filtered)
+}
+
 func getStatusIcon(status models.Status) string {
 	switch status {
 	case models.StatusOpen:
@@ -0,0 +1,221 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/marcus/td/internal/boardtemplate"
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/models"
+	"github.com/marcus/td/internal/output"
+	"github.com/marcus/td/internal/session"
+	"github.com/spf13/cobra"
+)
+
+var boardTemplateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Save and reapply sets of boards",
+	Long: `Save a project's boards as a named template and recreate them in any project.
+
+Templates hold each board's name, query and view mode, and are stored in
+~/.config/td/board_templates.json so they are shared across projects.
+Issue positions are not part of a template.`,
+}
+
+var boardTemplateSaveCmd = &cobra.Command{
+	Use:   "save <name> [board...]",
+	Short: "Save boards as a template",
+	Long: `Save boards as a template, replacing any template with the same name.
+
+With no boards given, every board except the builtin ones is saved.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		baseDir := getBaseDir()
+		name := args[0]
+
+		database, err := db.Open(baseDir)
+		if err != nil {
+			output.Error("%v", err)
+			return err
+		}
+		defer database.Close()
+
+		boards, err := templateBoards(database, args[1:])
+		if err != nil {
+			output.Error("%v", err)
+			return err
+		}
+		if len(boards) == 0 {
+			err := fmt.Errorf("no boards to save")
+			output.Error("%v", err)
+			return err
+		}
+
+		tmpl := boardtemplate.Template{Name: name, SavedAt: time.Now()}
+		for _, b := range boards {
+			tmpl.Boards = append(tmpl.Boards, boardtemplate.Board{Name: b.Name, Query: b.Query, ViewMode: b.ViewMode})
+		}
+		if err := boardtemplate.Put(tmpl); err != nil {
+			output.Error("%v", err)
+			return err
+		}
+
+		output.Success("Saved board template %s (%d boards)", name, len(tmpl.Boards))
+		return nil
+	},
+}
+
+var boardTemplateApplyCmd = &cobra.Command{
+	Use:   "apply <name>",
+	Short: "Create the boards in a template",
+	Long: `Create the boards in a template in this project.
+
+Boards whose name is already taken are left as they are and reported as
+skipped, so applying a template twice creates nothing the second time.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		baseDir := getBaseDir()
+
+		tmpl, err := boardtemplate.Get(args[0])
+		if err != nil {
+			output.Error("%v", err)
+			return err
+		}
+
+		database, err := db.Open(baseDir)
+		if err != nil {
+			output.Error("%v", err)
+			return err
+		}
+		defer database.Close()
+
+		sess, _ := session.GetOrCreate(database)
+		sessionID := ""
+		if sess != nil {
+			sessionID = sess.ID
+		}
+
+		created := 0
+		for _, tb := range tmpl.Boards {
+			if existing, _ := database.GetBoardByName(tb.Name); existing != nil {
+				output.Warning("Skipped board %s: a board with that name exists", tb.Name)
+				continue
+			}
+			board, err := applyTemplateBoard(database, tb, sessionID)
+			if err != nil {
+				output.Error("board %s: %v", tb.Name, err)
+				return err
+			}
+			output.Success("Created board %s (%s)", board.Name, board.ID)
+			created++
+		}
+
+		fmt.Printf("Applied template %s: %d of %d boards created\n", tmpl.Name, created, len(tmpl.Boards))
+		return nil
+	},
+}
+
+var boardTemplateListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List board templates",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		templates, err := boardtemplate.List()
+		if err != nil {
+			output.Error("%v", err)
+			return err
+		}
+
+		if jsonMode(cmd) {
+			data, _ := json.MarshalIndent(templates, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+
+		if len(templates) == 0 {
+			output.Info("No board templates found")
+			return nil
+		}
+		for _, t := range templates {
+			fmt.Printf("%s (%d boards)\n", t.Name, len(t.Boards))
+			for _, b := range t.Boards {
+				queryDisplay := ""
+				if b.Query != "" {
+					queryDisplay = fmt.Sprintf(" (%s)", b.Query)
+				}
+				fmt.Printf("  %s%s\n", b.Name, queryDisplay)
+			}
+		}
+		return nil
+	},
+}
+
+var boardTemplateDeleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Delete a board template",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := boardtemplate.Delete(args[0]); err != nil {
+			output.Error("%v", err)
+			return err
+		}
+		output.Success("Deleted board template %s", args[0])
+		return nil
+	},
+}
+
+// templateBoards resolves the boards a template is saved from: the given
+// refs in order, or every non-builtin board when there are none.
+func templateBoards(database *db.DB, refs []string) ([]models.Board, error) {
+	if len(refs) > 0 {
+		boards := make([]models.Board, 0, len(refs))
+		for _, ref := range refs {
+			board, err := database.ResolveBoardRef(ref)
+			if err != nil {
+				return nil, err
+			}
+			boards = append(boards, *board)
+		}
+		return boards, nil
+	}
+
+	all, err := database.ListBoards()
+	if err != nil {
+		return nil, err
+	}
+	var boards []models.Board
+	for _, b := range all {
+		if !b.IsBuiltin {
+			boards = append(boards, b)
+		}
+	}
+	return boards, nil
+}
+
+// applyTemplateBoard creates a board from a template entry, setting its view
+// mode the way td board edit does when it differs from the default.
+func applyTemplateBoard(database *db.DB, tb boardtemplate.Board, sessionID string) (*models.Board, error) {
+	board, err := database.CreateBoardLogged(tb.Name, tb.Query, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if tb.ViewMode == "" || tb.ViewMode == board.ViewMode {
+		return board, nil
+	}
+	if err := database.UpdateBoardViewMode(board.ID, tb.ViewMode); err != nil {
+		return nil, err
+	}
+	board.ViewMode = tb.ViewMode
+	if err := database.UpdateBoardLogged(board, sessionID); err != nil {
+		return nil, err
+	}
+	return board, nil
+}
+
+func init() {
+	boardCmd.AddCommand(boardTemplateCmd)
+	boardTemplateCmd.AddCommand(boardTemplateSaveCmd)
+	boardTemplateCmd.AddCommand(boardTemplateApplyCmd)
+	boardTemplateCmd.AddCommand(boardTemplateListCmd)
+	boardTemplateCmd.AddCommand(boardTemplateDeleteCmd)
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/marcus/td/internal/boardtemplate"
+	"github.com/marcus/td/internal/db"
+)
+
+func TestBoardTemplateSaveAndApply(t *testing.T) {
+	saveAndRestoreGlobals(t)
+	setJSONFlag(t, false)
+	t.Setenv("HOME", t.TempDir())
+
+	// Source project with two boards, one in backlog view
+	src := t.TempDir()
+	baseDirOverride = &src
+	srcDB, err := db.Initialize(src)
+	if err != nil {
+		t.Fatalf("Initialize source: %v", err)
+	}
+	defer srcDB.Close()
+
+	bugs, err := srcDB.CreateBoardLogged("Bugs", "type = bug", "")
+	if err != nil {
+		t.Fatalf("CreateBoardLogged: %v", err)
+	}
+	if err := srcDB.UpdateBoardViewMode(bugs.ID, "backlog"); err != nil {
+		t.Fatalf("UpdateBoardViewMode: %v", err)
+	}
+	if _, err := srcDB.CreateBoardLogged("Sprint", "labels ~ sprint", ""); err != nil {
+		t.Fatalf("CreateBoardLogged: %v", err)
+	}
+
+	runWithFlags(t, boardTemplateSaveCmd, []string{"team"}, nil)
+
+	tmpl, err := boardtemplate.Get("team")
+	if err != nil {
+		t.Fatalf("Get template: %v", err)
+	}
+	saved := map[string]boardtemplate.Board{}
+	for _, b := range tmpl.Boards {
+		saved[b.Name] = b
+	}
+	if len(saved) != 2 {
+		t.Fatalf("saved boards = %+v, want Bugs and Sprint without builtins", tmpl.Boards)
+	}
+	if b := saved["Bugs"]; b.Query != "type = bug" || b.ViewMode != "backlog" {
+		t.Errorf("saved Bugs = %+v", b)
+	}
+
+	// Target project gets the same boards
+	dst := t.TempDir()
+	baseDirOverride = &dst
+	dstDB, err := db.Initialize(dst)
+	if err != nil {
+		t.Fatalf("Initialize target: %v", err)
+	}
+	defer dstDB.Close()
+	if _, err := dstDB.CreateBoardLogged("Sprint", "status = open", ""); err != nil {
+		t.Fatalf("CreateBoardLogged: %v", err)
+	}
+
+	runWithFlags(t, boardTemplateApplyCmd, []string{"team"}, nil)
+
+	got, err := dstDB.GetBoardByName("Bugs")
+	if err != nil {
+		t.Fatalf("Bugs not created: %v", err)
+	}
+	if got.Query != "type = bug" || got.ViewMode != "backlog" {
+		t.Errorf("applied Bugs = query %q view %q, want type = bug in backlog", got.Query, got.ViewMode)
+	}
+	// An existing board with the same name is left alone
+	if sprint, _ := dstDB.GetBoardByName("Sprint"); sprint == nil || sprint.Query != "status = open" {
+		t.Errorf("existing Sprint board was changed: %+v", sprint)
+	}
+
+	// Applying again creates nothing new
+	before, _ := dstDB.ListBoards()
+	runWithFlags(t, boardTemplateApplyCmd, []string{"team"}, nil)
+	after, _ := dstDB.ListBoards()
+	if len(after) != len(before) {
+		t.Errorf("reapplying created boards: %d before, %d after", len(before), len(after))
+	}
+}
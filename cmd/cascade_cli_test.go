@@ -405,3 +405,157 @@ func TestCascadeCLIAlreadyClosed(t *testing.T) {
 		t.Errorf("Should not cascade to already-closed parent, got %d", cascaded)
 	}
 }
+
+// approveForTest closes an issue the way `td approve` does, stamping the
+// reviewer and recording the approval review.
+func approveForTest(t *testing.T, database *db.DB, issue *models.Issue, reviewer string) {
+	t.Helper()
+	now := time.Now()
+	issue.Status = models.StatusClosed
+	issue.ReviewerSession = reviewer
+	issue.ReviewedAt = &now
+	issue.ClosedAt = &now
+	if err := database.UpdateIssue(issue); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if _, err := database.CreateIssueReview(issue.ID, reviewer, "approved", "", "", false); err != nil {
+		t.Fatalf("CreateIssueReview failed: %v", err)
+	}
+}
+
+// TestCascadeApprovalUpEpicChain tests that approving the last child approves
+// each epic up the chain and logs it.
+func TestCascadeApprovalUpEpicChain(t *testing.T) {
+	database, err := db.Initialize(t.TempDir())
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	grandparent := &models.Issue{Title: "Epic: L1", Type: models.TypeEpic, Status: models.StatusInReview}
+	database.CreateIssue(grandparent)
+	parent := &models.Issue{Title: "Epic: L2", Type: models.TypeEpic, Status: models.StatusInReview, ParentID: grandparent.ID}
+	database.CreateIssue(parent)
+	first := &models.Issue{Title: "Task: first", Type: models.TypeTask, Status: models.StatusInReview, ParentID: parent.ID}
+	database.CreateIssue(first)
+	second := &models.Issue{Title: "Task: second", Type: models.TypeTask, Status: models.StatusInReview, ParentID: parent.ID}
+	database.CreateIssue(second)
+
+	approveForTest(t, database, first, "ses_reviewer_a")
+	if count, _ := database.CascadeUpParentApproval(first.ID, "ses_reviewer_a"); count != 0 {
+		t.Fatalf("expected no cascade while a sibling is unapproved, got %d", count)
+	}
+
+	approveForTest(t, database, second, "ses_reviewer_b")
+	count, ids := database.CascadeUpParentApproval(second.ID, "ses_reviewer_b")
+	if count != 2 || len(ids) != 2 || ids[0] != parent.ID || ids[1] != grandparent.ID {
+		t.Fatalf("expected [%s %s] approved, got %d %v", parent.ID, grandparent.ID, count, ids)
+	}
+
+	for _, id := range ids {
+		epic, _ := database.GetIssue(id)
+		if epic.Status != models.StatusClosed || epic.ReviewerSession != "ses_reviewer_b" || epic.ReviewedAt == nil {
+			t.Errorf("%s: status=%s reviewer=%q reviewed_at=%v, want approved by ses_reviewer_b",
+				id, epic.Status, epic.ReviewerSession, epic.ReviewedAt)
+		}
+		logs, _ := database.GetLogs(id, 10)
+		found := false
+		for _, l := range logs {
+			if l.Message == "Auto-approved (all children approved)" {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("%s: missing auto-approve log, got %+v", id, logs)
+		}
+		if review, _ := database.GetActiveApprovalReview(id); review == nil || review.ReviewerSession != "ses_reviewer_b" {
+			t.Errorf("%s: active approval review = %+v, want one by ses_reviewer_b", id, review)
+		}
+	}
+	if action, _ := database.GetLastAction("ses_reviewer_b"); action == nil || action.ActionType != models.ActionApprove {
+		t.Errorf("expected last action to be an approval, got %+v", action)
+	}
+}
+
+// TestCascadeApprovalNeedsActiveReview tests that a child stamped with a
+// reviewer but without an active approval review keeps the epic open.
+func TestCascadeApprovalNeedsActiveReview(t *testing.T) {
+	database, err := db.Initialize(t.TempDir())
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	epic := &models.Issue{Title: "Epic: stale review", Type: models.TypeEpic, Status: models.StatusOpen}
+	if err := database.CreateIssue(epic); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	approved := &models.Issue{Title: "Task: approved", Type: models.TypeTask, Status: models.StatusInReview, ParentID: epic.ID}
+	if err := database.CreateIssue(approved); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	stamped := &models.Issue{Title: "Task: changes requested", Type: models.TypeTask, Status: models.StatusInReview, ParentID: epic.ID}
+	if err := database.CreateIssue(stamped); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	now := time.Now()
+	stamped.Status = models.StatusClosed
+	stamped.ReviewerSession = "ses_reviewer"
+	stamped.ReviewedAt = &now
+	stamped.ClosedAt = &now
+	if err := database.UpdateIssue(stamped); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if _, err := database.CreateIssueReview(stamped.ID, "ses_reviewer", "changes_requested", "", "", false); err != nil {
+		t.Fatalf("CreateIssueReview failed: %v", err)
+	}
+
+	approveForTest(t, database, approved, "ses_reviewer")
+	if count, ids := database.CascadeUpParentApproval(approved.ID, "ses_reviewer"); count != 0 {
+		t.Fatalf("expected no approval cascade, got %d %v", count, ids)
+	}
+	if review, _ := database.GetActiveApprovalReview(epic.ID); review != nil {
+		t.Errorf("epic should have no approval review, got %+v", review)
+	}
+}
+
+// TestCascadeApprovalPartial tests that a child closed without review keeps
+// the epic from being approved, leaving it to the plain close cascade.
+func TestCascadeApprovalPartial(t *testing.T) {
+	database, err := db.Initialize(t.TempDir())
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	epic := &models.Issue{Title: "Epic: partial", Type: models.TypeEpic, Status: models.StatusOpen}
+	database.CreateIssue(epic)
+	reviewed := &models.Issue{Title: "Task: reviewed", Type: models.TypeTask, Status: models.StatusInReview, ParentID: epic.ID}
+	database.CreateIssue(reviewed)
+	adminClosed := &models.Issue{Title: "Task: admin closed", Type: models.TypeTask, Status: models.StatusOpen, ParentID: epic.ID}
+	database.CreateIssue(adminClosed)
+
+	now := time.Now()
+	adminClosed.Status = models.StatusClosed
+	adminClosed.ClosedAt = &now
+	database.UpdateIssue(adminClosed)
+
+	approveForTest(t, database, reviewed, "ses_reviewer")
+	if count, ids := database.CascadeUpParentApproval(reviewed.ID, "ses_reviewer"); count != 0 {
+		t.Fatalf("expected no approval cascade, got %d %v", count, ids)
+	}
+	got, _ := database.GetIssue(epic.ID)
+	if got.Status != models.StatusOpen || got.ReviewerSession != "" {
+		t.Fatalf("epic should be untouched, got status=%s reviewer=%q", got.Status, got.ReviewerSession)
+	}
+
+	// The close cascade still applies, without recording a reviewer.
+	if count, _ := database.CascadeUpParentStatus(reviewed.ID, models.StatusClosed, "ses_reviewer"); count != 1 {
+		t.Fatalf("expected close cascade, got %d", count)
+	}
+	got, _ = database.GetIssue(epic.ID)
+	if got.Status != models.StatusClosed || got.ReviewerSession != "" {
+		t.Errorf("epic should be closed without reviewer, got status=%s reviewer=%q", got.Status, got.ReviewerSession)
+	}
+}
@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/marcus/td/internal/config"
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/models"
+)
+
+// setReasonPolicy sets require_close_reason in the project config at dir.
+func setReasonPolicy(t *testing.T, dir string, required bool) {
+	t.Helper()
+	cfg, err := config.Load(dir)
+	if err != nil {
+		t.Fatalf("config.Load failed: %v", err)
+	}
+	cfg.RequireCloseReason = required
+	if err := config.Save(dir, cfg); err != nil {
+		t.Fatalf("config.Save failed: %v", err)
+	}
+}
+
+func setCmdReason(t *testing.T, reason string) {
+	t.Helper()
+	_ = closeCmd.Flags().Set("reason", reason)
+	_ = rejectCmd.Flags().Set("reason", reason)
+	t.Cleanup(func() {
+		_ = closeCmd.Flags().Set("reason", "")
+		_ = rejectCmd.Flags().Set("reason", "")
+	})
+}
+
+func TestCloseReasonPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		required bool
+		reason   string
+		wantErr  bool
+	}{
+		{name: "unenforced without reason", required: false, reason: "", wantErr: false},
+		{name: "enforced without reason", required: true, reason: "", wantErr: true},
+		{name: "enforced with blank reason", required: true, reason: "   ", wantErr: true},
+		{name: "enforced with reason", required: true, reason: "duplicate of td-abc", wantErr: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			saveAndRestoreGlobals(t)
+			t.Setenv("TD_SESSION_ID", "ses_close_reason_test")
+			dir := t.TempDir()
+			baseDirOverride = &dir
+
+			database, err := db.Initialize(dir)
+			if err != nil {
+				t.Fatalf("Initialize failed: %v", err)
+			}
+			defer database.Close()
+			setReasonPolicy(t, dir, tt.required)
+
+			issue := &models.Issue{Title: "Close reason policy task", Status: models.StatusOpen, Minor: true}
+			if err := database.CreateIssue(issue); err != nil {
+				t.Fatalf("CreateIssue failed: %v", err)
+			}
+
+			setJSONFlag(t, false)
+			setCmdReason(t, tt.reason)
+			var runErr error
+			captureStdout(t, func() {
+				runErr = closeCmd.RunE(closeCmd, []string{issue.ID})
+			})
+			if (runErr != nil) != tt.wantErr {
+				t.Fatalf("close error = %v, wantErr %v", runErr, tt.wantErr)
+			}
+
+			updated, err := database.GetIssue(issue.ID)
+			if err != nil {
+				t.Fatalf("GetIssue failed: %v", err)
+			}
+			want := models.StatusClosed
+			if tt.wantErr {
+				want = models.StatusOpen
+			}
+			if updated.Status != want {
+				t.Errorf("status = %s, want %s", updated.Status, want)
+			}
+		})
+	}
+}
+
+func TestRejectReasonPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		required bool
+		reason   string
+		wantErr  bool
+	}{
+		{name: "unenforced without reason", required: false, reason: "", wantErr: false},
+		{name: "enforced without reason", required: true, reason: "", wantErr: true},
+		{name: "enforced with reason", required: true, reason: "missing tests", wantErr: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			saveAndRestoreGlobals(t)
+			t.Setenv("TD_SESSION_ID", "ses_reject_reason_test")
+			dir := t.TempDir()
+			baseDirOverride = &dir
+
+			database, err := db.Initialize(dir)
+			if err != nil {
+				t.Fatalf("Initialize failed: %v", err)
+			}
+			defer database.Close()
+			setReasonPolicy(t, dir, tt.required)
+
+			issue := &models.Issue{Title: "Reject reason policy task", Status: models.StatusInReview, ImplementerSession: "ses_impl"}
+			if err := database.CreateIssue(issue); err != nil {
+				t.Fatalf("CreateIssue failed: %v", err)
+			}
+
+			setJSONFlag(t, false)
+			setCmdReason(t, tt.reason)
+			var runErr error
+			captureStdout(t, func() {
+				runErr = rejectCmd.RunE(rejectCmd, []string{issue.ID})
+			})
+			if (runErr != nil) != tt.wantErr {
+				t.Fatalf("reject error = %v, wantErr %v", runErr, tt.wantErr)
+			}
+
+			updated, err := database.GetIssue(issue.ID)
+			if err != nil {
+				t.Fatalf("GetIssue failed: %v", err)
+			}
+			want := models.StatusOpen
+			if tt.wantErr {
+				want = models.StatusInReview
+			}
+			if updated.Status != want {
+				t.Errorf("status = %s, want %s", updated.Status, want)
+			}
+		})
+	}
+}
+
+func TestUpdateStatusClosedReasonPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		required bool
+		comment  string
+		wantErr  bool
+	}{
+		{name: "unenforced without comment", required: false, comment: "", wantErr: false},
+		{name: "enforced without comment", required: true, comment: "", wantErr: true},
+		{name: "enforced with comment", required: true, comment: "fixed upstream", wantErr: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			saveAndRestoreGlobals(t)
+			t.Setenv("TD_SESSION_ID", "ses_update_reason_test")
+			dir := t.TempDir()
+			baseDirOverride = &dir
+
+			database, err := db.Initialize(dir)
+			if err != nil {
+				t.Fatalf("Initialize failed: %v", err)
+			}
+			defer database.Close()
+			setReasonPolicy(t, dir, tt.required)
+
+			issue := &models.Issue{Title: "Update close reason policy task", Status: models.StatusOpen}
+			if err := database.CreateIssue(issue); err != nil {
+				t.Fatalf("CreateIssue failed: %v", err)
+			}
+
+			setJSONFlag(t, false)
+			for flag, value := range map[string]string{"status": "closed", "comment": tt.comment} {
+				_ = updateCmd.Flags().Set(flag, value)
+			}
+			t.Cleanup(func() {
+				_ = updateCmd.Flags().Set("status", "")
+				_ = updateCmd.Flags().Set("comment", "")
+			})
+			var runErr error
+			captureStdout(t, func() {
+				runErr = updateCmd.RunE(updateCmd, []string{issue.ID})
+			})
+			if (runErr != nil) != tt.wantErr {
+				t.Fatalf("update error = %v, wantErr %v", runErr, tt.wantErr)
+			}
+
+			updated, err := database.GetIssue(issue.ID)
+			if err != nil {
+				t.Fatalf("GetIssue failed: %v", err)
+			}
+			want := models.StatusClosed
+			if tt.wantErr {
+				want = models.StatusOpen
+			}
+			if updated.Status != want {
+				t.Errorf("status = %s, want %s", updated.Status, want)
+			}
+		})
+	}
+}
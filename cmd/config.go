@@ -6,13 +6,21 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/marcus/td/internal/config"
 	"github.com/marcus/td/internal/features"
 	"github.com/marcus/td/internal/output"
+	"github.com/marcus/td/internal/query"
 	"github.com/marcus/td/internal/syncconfig"
 	"github.com/spf13/cobra"
 )
 
-// validConfigKeys lists the supported config keys for set/get.
+// projectConfigKeys are stored in the project's .todos/config.json rather
+// than the global sync config, and are always available.
+var projectConfigKeys = []string{
+	"default-query",
+}
+
+// validConfigKeys lists the supported sync config keys for set/get.
 var validConfigKeys = []string{
 	"sync.url",
 	"sync.enabled",
@@ -24,8 +32,18 @@ var validConfigKeys = []string{
 	"sync.snapshot_threshold",
 }
 
+// configKeys returns the keys set and get accept: the project keys, plus
+// the sync keys when sync commands are enabled.
+func configKeys() []string {
+	keys := append([]string{}, projectConfigKeys...)
+	if features.IsEnabledForProcess(features.SyncCLI.Name) {
+		keys = append(keys, validConfigKeys...)
+	}
+	return keys
+}
+
 func isValidConfigKey(key string) bool {
-	for _, k := range validConfigKeys {
+	for _, k := range configKeys() {
 		if k == key {
 			return true
 		}
@@ -62,10 +80,14 @@ var configSetCmd = &cobra.Command{
 
 		if !isValidConfigKey(key) {
 			output.Error("unknown config key: %s", key)
-			fmt.Println("Valid keys:", strings.Join(validConfigKeys, ", "))
+			fmt.Println("Valid keys:", strings.Join(configKeys(), ", "))
 			return fmt.Errorf("unknown config key: %s", key)
 		}
 
+		if key == "default-query" {
+			return setDefaultQuery(val)
+		}
+
 		cfg, err := syncconfig.LoadConfig()
 		if err != nil {
 			output.Error("load config: %v", err)
@@ -135,10 +157,20 @@ var configGetCmd = &cobra.Command{
 
 		if !isValidConfigKey(key) {
 			output.Error("unknown config key: %s", key)
-			fmt.Println("Valid keys:", strings.Join(validConfigKeys, ", "))
+			fmt.Println("Valid keys:", strings.Join(configKeys(), ", "))
 			return fmt.Errorf("unknown config key: %s", key)
 		}
 
+		if key == "default-query" {
+			val, err := config.GetDefaultQuery(getBaseDir())
+			if err != nil {
+				output.Error("load config: %v", err)
+				return err
+			}
+			fmt.Println(val)
+			return nil
+		}
+
 		cfg, err := syncconfig.LoadConfig()
 		if err != nil {
 			output.Error("load config: %v", err)
@@ -196,13 +228,23 @@ var configListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all config values",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cfg, err := syncconfig.LoadConfig()
+		defaultQuery, err := config.GetDefaultQuery(getBaseDir())
 		if err != nil {
 			output.Error("load config: %v", err)
 			return err
 		}
+		values := map[string]interface{}{"default-query": defaultQuery}
+
+		if features.IsEnabledForProcess(features.SyncCLI.Name) {
+			cfg, err := syncconfig.LoadConfig()
+			if err != nil {
+				output.Error("load config: %v", err)
+				return err
+			}
+			values["sync"] = cfg.Sync
+		}
 
-		data, err := json.MarshalIndent(cfg, "", "  ")
+		data, err := json.MarshalIndent(values, "", "  ")
 		if err != nil {
 			output.Error("marshal config: %v", err)
 			return err
@@ -217,5 +259,33 @@ func init() {
 	configCmd.AddCommand(configSetCmd)
 	configCmd.AddCommand(configGetCmd)
 	configCmd.AddCommand(configListCmd)
-	AddFeatureGatedCommand(features.SyncCLI.Name, configCmd)
+	// Always available for project keys; sync keys are gated in configKeys
+	rootCmd.AddCommand(configCmd)
+}
+
+// setDefaultQuery validates and stores the project's default query. An
+// empty query clears it.
+func setDefaultQuery(val string) error {
+	val = strings.TrimSpace(val)
+	if val != "" {
+		parsed, err := query.Parse(val)
+		if err != nil {
+			output.Error("invalid default-query: %v", err)
+			return err
+		}
+		if errs := parsed.ValidateWithStatuses(customStatuses()); len(errs) > 0 {
+			output.Error("invalid default-query: %v", errs[0])
+			return errs[0]
+		}
+	}
+	if err := config.SetDefaultQuery(getBaseDir(), val); err != nil {
+		output.Error("save config: %v", err)
+		return err
+	}
+	if val == "" {
+		output.Success("cleared default-query")
+		return nil
+	}
+	output.Success("set default-query = %s", val)
+	return nil
 }
@@ -19,10 +19,15 @@ var createCmd = &cobra.Command{
 	Use:     "create [title]",
 	Aliases: []string{"add", "new"},
 	Short:   "Create a new issue",
-	Long:    `Create a new issue with optional flags for type, priority, labels, and more.`,
+	Long: `Create a new issue with optional flags for type, priority, labels, and more.
+
+--parent and --depends-on must name existing issues. The issue is created
+together with its dependencies in one step, and starts out blocked if any
+dependency is not yet closed.`,
 	Example: "  td create \"Add user auth\" --type feature --priority P1\n" +
 		"  td create \"Rich markdown issue\" --description-file description.md --acceptance-file acceptance.md\n" +
-		"  cat acceptance.md | td create \"Import from stdin\" --acceptance-file -",
+		"  cat acceptance.md | td create \"Import from stdin\" --acceptance-file -\n" +
+		"  td create \"Wire up login form\" --parent td-a1b2 --depends-on td-c3d4 --depends-on td-e5f6",
 	GroupID: "core",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Route "td new task Title" → td create --type task "Title"
@@ -153,6 +158,13 @@ var createCmd = &cobra.Command{
 		}
 		if len(labelsArr) > 0 {
 			issue.Labels = mergeMultiValueFlag(labelsArr)
+			for _, label := range issue.Labels {
+				if models.IsReservedLabel(label) {
+					err := fmt.Errorf("label %q is reserved", label)
+					emitErr("%v", err)
+					return err
+				}
+			}
 		}
 
 		stdinUsed := false
@@ -187,13 +199,31 @@ var createCmd = &cobra.Command{
 		}
 
 		// Parent (supports --parent and --epic)
-		issue.ParentID, _ = cmd.Flags().GetString("parent")
-		if issue.ParentID == "" {
-			if epic, _ := cmd.Flags().GetString("epic"); epic != "" {
-				issue.ParentID = epic
+		parentRef, _ := cmd.Flags().GetString("parent")
+		if parentRef == "" {
+			parentRef, _ = cmd.Flags().GetString("epic")
+		}
+		if parentRef != "" {
+			issue.ParentID, err = resolveCreateParent(database, parentRef)
+			if err != nil {
+				emitErr("%v", err)
+				return err
 			}
 		}
 
+		// Dependencies (support repeated flags and comma-separated). They are
+		// checked up front so a bad ID fails the create instead of leaving an
+		// issue with half its dependencies.
+		dependsArr, _ := cmd.Flags().GetStringArray("depends-on")
+		dependsOn, openDeps, err := resolveCreateDependencies(database, mergeMultiValueFlag(dependsArr))
+		if err != nil {
+			emitErr("%v", err)
+			return err
+		}
+		if len(openDeps) > 0 {
+			issue.Status = models.StatusBlocked
+		}
+
 		// Minor (allows self-review)
 		issue.Minor, _ = cmd.Flags().GetBool("minor")
 
@@ -224,6 +254,10 @@ var createCmd = &cobra.Command{
 			return fmt.Errorf("failed to create session: %w", err)
 		}
 		issue.CreatorSession = sess.ID
+		issue.CreatedBy = sess.ID
+		if sess.Name != "" {
+			issue.CreatedBy = sess.Name
+		}
 
 		// Capture current git branch
 		gitState, _ := git.GetState()
@@ -231,8 +265,8 @@ var createCmd = &cobra.Command{
 			issue.CreatedBranch = gitState.Branch
 		}
 
-		// Create the issue (atomic create + action log)
-		if err := database.CreateIssueLogged(issue, sess.ID); err != nil {
+		// Create the issue with its dependencies (atomic create + action log)
+		if err := database.CreateIssueWithDependenciesLogged(issue, dependsOn, sess.ID); err != nil {
 			emitErr("failed to create issue: %v", err)
 			return err
 		}
@@ -242,15 +276,6 @@ var createCmd = &cobra.Command{
 			emitWarn("failed to record session history: %v", err)
 		}
 
-		// Handle dependencies (support repeated flags and comma-separated)
-		if dependsArr, _ := cmd.Flags().GetStringArray("depends-on"); len(dependsArr) > 0 {
-			for _, dep := range mergeMultiValueFlag(dependsArr) {
-				if err := database.AddDependencyLogged(issue.ID, dep, "depends_on", sess.ID); err != nil {
-					emitWarn("failed to add dependency %s: %v", dep, err)
-				}
-			}
-		}
-
 		if blocksArr, _ := cmd.Flags().GetStringArray("blocks"); len(blocksArr) > 0 {
 			for _, blocked := range mergeMultiValueFlag(blocksArr) {
 				if err := database.AddDependencyLogged(blocked, issue.ID, "depends_on", sess.ID); err != nil {
@@ -264,6 +289,9 @@ var createCmd = &cobra.Command{
 		}
 
 		fmt.Printf("CREATED %s\n", issue.ID)
+		if len(openDeps) > 0 {
+			fmt.Printf("BLOCKED by %s\n", strings.Join(openDeps, ", "))
+		}
 		return nil
 	},
 }
@@ -286,15 +314,78 @@ func init() {
 	createCmd.Flags().String("description-file", "", "Read description from file or - for stdin (preserves formatting)")
 	createCmd.Flags().String("acceptance", "", "Acceptance criteria")
 	createCmd.Flags().String("acceptance-file", "", "Read acceptance criteria from file or - for stdin (preserves formatting)")
-	createCmd.Flags().String("parent", "", "Parent issue ID")
+	createCmd.Flags().String("parent", "", "Parent issue ID (must exist)")
 	createCmd.Flags().String("epic", "", "Parent issue ID (alias for --parent)")
-	createCmd.Flags().StringArray("depends-on", nil, "Issues this depends on (repeatable, comma-separated)")
+	createCmd.Flags().StringArray("depends-on", nil, "Issues this depends on (repeatable, comma-separated); an open one blocks the new issue")
 	createCmd.Flags().StringArray("blocks", nil, "Issues this blocks (repeatable, comma-separated)")
 	createCmd.Flags().Bool("minor", false, "Mark as minor task (allows self-review)")
 	createCmd.Flags().String("defer", "", "Defer until date (e.g., +7d, monday, 2026-03-01)")
 	createCmd.Flags().String("due", "", "Due date (e.g., friday, +2w, 2026-03-15)")
 }
 
+// resolveCreateParent resolves a --parent value to the ID of a live issue.
+// The parent's own ancestor chain must end, so the new issue is never hung
+// under a parent_id cycle.
+func resolveCreateParent(database *db.DB, ref string) (string, error) {
+	parent, err := resolveLiveIssue(database, ref)
+	if err != nil {
+		return "", fmt.Errorf("invalid parent: %w", err)
+	}
+
+	seen := map[string]bool{parent.ID: true}
+	for ancestor := parent.ParentID; ancestor != ""; {
+		if seen[ancestor] {
+			return "", fmt.Errorf("invalid parent: %s is part of a parent cycle through %s", parent.ID, ancestor)
+		}
+		seen[ancestor] = true
+		next, err := database.GetIssue(ancestor)
+		if err != nil {
+			break
+		}
+		ancestor = next.ParentID
+	}
+	return parent.ID, nil
+}
+
+// resolveCreateDependencies resolves --depends-on values to live issue IDs,
+// dropping repeats, and also returns those not yet closed: a new issue that
+// depends on any of them starts out blocked.
+func resolveCreateDependencies(database *db.DB, refs []string) (ids, open []string, err error) {
+	seen := make(map[string]bool)
+	for _, ref := range refs {
+		dep, err := resolveLiveIssue(database, ref)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid dependency: %w", err)
+		}
+		if seen[dep.ID] {
+			continue
+		}
+		seen[dep.ID] = true
+		ids = append(ids, dep.ID)
+		if dep.Status != models.StatusClosed {
+			open = append(open, dep.ID)
+		}
+	}
+	return ids, open, nil
+}
+
+// resolveLiveIssue resolves a full or partial issue ID to an issue that has
+// not been deleted.
+func resolveLiveIssue(database *db.DB, ref string) (*models.Issue, error) {
+	id, err := database.ResolveIssueID(ref)
+	if err != nil {
+		return nil, err
+	}
+	issue, err := database.GetIssue(id)
+	if err != nil {
+		return nil, err
+	}
+	if issue.DeletedAt != nil {
+		return nil, fmt.Errorf("issue %s is deleted", issue.ID)
+	}
+	return issue, nil
+}
+
 // parseTypeFromTitle extracts type prefix from title (e.g., "epic: Title" → "epic", "Title")
 // Returns the extracted type (or empty Type) and the cleaned title
 func parseTypeFromTitle(title string) (models.Type, string) {
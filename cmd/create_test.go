@@ -10,6 +10,7 @@ import (
 	"github.com/marcus/td/internal/config"
 	"github.com/marcus/td/internal/db"
 	"github.com/marcus/td/internal/models"
+	"github.com/spf13/pflag"
 )
 
 // TestIsValidType tests type validation
@@ -1063,3 +1064,139 @@ func TestCreateRichTextConflictErrors(t *testing.T) {
 		t.Fatalf("expected 0 issues after conflict, got %d", len(issues))
 	}
 }
+
+// resetCreateLinkFlags clears create's --parent, --epic and --depends-on for
+// the test and again afterwards. The StringArray flag is cleared through
+// Replace, since Set would append.
+func resetCreateLinkFlags(t *testing.T) {
+	t.Helper()
+	reset := func() {
+		for _, name := range []string{"parent", "epic", "depends-on"} {
+			flag := createCmd.Flags().Lookup(name)
+			if slice, ok := flag.Value.(pflag.SliceValue); ok {
+				_ = slice.Replace(nil)
+			} else {
+				_ = flag.Value.Set("")
+			}
+			flag.Changed = false
+		}
+	}
+	reset()
+	t.Cleanup(reset)
+}
+
+func TestCreateWithParentFlag(t *testing.T) {
+	saveAndRestoreGlobals(t)
+	resetCreateLinkFlags(t)
+
+	dir := t.TempDir()
+	baseDirOverride = &dir
+	database, err := db.Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	parent := &models.Issue{Title: "Parent epic", Type: models.TypeEpic}
+	if err := database.CreateIssue(parent); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	if err := createCmd.Flags().Set("parent", parent.ID); err != nil {
+		t.Fatalf("Set parent failed: %v", err)
+	}
+	captureStdout(t, func() {
+		if err := createCmd.RunE(createCmd, []string{"Child created with parent"}); err != nil {
+			t.Fatalf("createCmd.RunE failed: %v", err)
+		}
+	})
+
+	children, err := database.ListIssues(db.ListIssuesOptions{ParentID: parent.ID})
+	if err != nil {
+		t.Fatalf("ListIssues failed: %v", err)
+	}
+	if len(children) != 1 || children[0].Title != "Child created with parent" {
+		t.Fatalf("expected the new issue under %s, got %+v", parent.ID, children)
+	}
+	if children[0].Status != models.StatusOpen {
+		t.Errorf("expected status open without dependencies, got %q", children[0].Status)
+	}
+}
+
+func TestCreateWithOpenDependencyIsBlocked(t *testing.T) {
+	saveAndRestoreGlobals(t)
+	resetCreateLinkFlags(t)
+
+	dir := t.TempDir()
+	baseDirOverride = &dir
+	database, err := db.Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	openDep := &models.Issue{Title: "Open prerequisite"}
+	closedDep := &models.Issue{Title: "Closed prerequisite", Status: models.StatusClosed}
+	for _, issue := range []*models.Issue{openDep, closedDep} {
+		if err := database.CreateIssue(issue); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	_ = createCmd.Flags().Set("depends-on", openDep.ID)
+	_ = createCmd.Flags().Set("depends-on", closedDep.ID)
+	out := captureStdout(t, func() {
+		if err := createCmd.RunE(createCmd, []string{"Dependent created blocked"}); err != nil {
+			t.Fatalf("createCmd.RunE failed: %v", err)
+		}
+	})
+	if !strings.Contains(out, "BLOCKED by "+openDep.ID) {
+		t.Errorf("expected a blocked note naming %s, got %q", openDep.ID, out)
+	}
+
+	id := strings.TrimSpace(strings.TrimPrefix(strings.SplitN(out, "\n", 2)[0], "CREATED "))
+	issue, err := database.GetIssue(id)
+	if err != nil {
+		t.Fatalf("GetIssue(%q) failed: %v", id, err)
+	}
+	if issue.Status != models.StatusBlocked {
+		t.Errorf("expected status blocked, got %q", issue.Status)
+	}
+	deps, err := database.GetDependencies(id)
+	if err != nil {
+		t.Fatalf("GetDependencies failed: %v", err)
+	}
+	if len(deps) != 2 {
+		t.Errorf("expected 2 dependencies, got %v", deps)
+	}
+}
+
+func TestCreateRejectsUnknownParentOrDependency(t *testing.T) {
+	for _, flag := range []string{"parent", "depends-on"} {
+		t.Run(flag, func(t *testing.T) {
+			saveAndRestoreGlobals(t)
+			resetCreateLinkFlags(t)
+
+			dir := t.TempDir()
+			baseDirOverride = &dir
+			database, err := db.Initialize(dir)
+			if err != nil {
+				t.Fatalf("Initialize failed: %v", err)
+			}
+			defer database.Close()
+
+			_ = createCmd.Flags().Set(flag, "td-nope99")
+			if err := createCmd.RunE(createCmd, []string{"Should not be created"}); err == nil {
+				t.Fatal("expected createCmd.RunE to fail")
+			}
+
+			issues, err := database.ListIssues(db.ListIssuesOptions{})
+			if err != nil {
+				t.Fatalf("ListIssues failed: %v", err)
+			}
+			if len(issues) != 0 {
+				t.Fatalf("expected no issue to be created, got %d", len(issues))
+			}
+		})
+	}
+}
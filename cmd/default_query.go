@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/marcus/td/internal/config"
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/query"
+	"github.com/marcus/td/internal/session"
+	"github.com/marcus/td/internal/workflow"
+	"github.com/spf13/cobra"
+)
+
+// withDefaultQuery narrows queryStr by the project's default-query, so the
+// default is the base and queryStr adds predicates to it. queryStr comes
+// back unchanged when no default is set or --all is given; an empty
+// queryStr gives the default alone.
+func withDefaultQuery(cmd *cobra.Command, baseDir, queryStr string) string {
+	if all, _ := cmd.Flags().GetBool("all"); all {
+		return queryStr
+	}
+	defaultQuery, err := config.GetDefaultQuery(baseDir)
+	if err != nil || defaultQuery == "" {
+		return queryStr
+	}
+	if queryStr == "" {
+		return defaultQuery
+	}
+	return "(" + defaultQuery + ") AND (" + queryStr + ")"
+}
+
+// defaultQueryIDs returns the IDs of the issues matching the default query,
+// for narrowing flag-based filters. ok is false when no default applies.
+func defaultQueryIDs(cmd *cobra.Command, database *db.DB, baseDir string) (ids []string, ok bool, err error) {
+	defaultQuery := withDefaultQuery(cmd, baseDir, "")
+	if defaultQuery == "" {
+		return nil, false, nil
+	}
+
+	sess, _ := session.GetOrCreate(database)
+	sessionID := ""
+	if sess != nil {
+		sessionID = sess.ID
+	}
+	identities, _ := session.IdentitySessionIDs(database, sess)
+
+	sm, _ := workflow.ForProject(baseDir)
+
+	matches, err := query.Execute(database, defaultQuery, sessionID, query.ExecuteOptions{Identities: identities, Statuses: sm.CustomStatuses()})
+	if err != nil {
+		return nil, true, err
+	}
+	ids = make([]string, 0, len(matches))
+	for _, issue := range matches {
+		ids = append(ids, issue.ID)
+	}
+	return ids, true, nil
+}
+
+// intersectIDs returns the requested IDs that are also allowed, or all of
+// allowed when none were requested.
+func intersectIDs(requested, allowed []string) []string {
+	if len(requested) == 0 {
+		return allowed
+	}
+	inAllowed := make(map[string]bool, len(allowed))
+	for _, id := range allowed {
+		inAllowed[id] = true
+	}
+	var kept []string
+	for _, id := range requested {
+		if normalized := db.NormalizeIssueID(strings.TrimSpace(id)); inAllowed[normalized] {
+			kept = append(kept, normalized)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/marcus/td/internal/config"
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// runWithFlags runs command with args and flags set, restoring the flags to
+// their defaults afterwards, and returns its stdout.
+func runWithFlags(t *testing.T, command *cobra.Command, args []string, flags map[string]string) string {
+	t.Helper()
+	defer func() {
+		for k := range flags {
+			_ = command.Flags().Set(k, command.Flags().Lookup(k).DefValue)
+		}
+	}()
+	for k, v := range flags {
+		if err := command.Flags().Set(k, v); err != nil {
+			t.Fatalf("set --%s=%s: %v", k, v, err)
+		}
+	}
+	return captureStdout(t, func() {
+		if err := command.RunE(command, args); err != nil {
+			t.Fatalf("%s %v %v: %v", command.Name(), args, flags, err)
+		}
+	})
+}
+
+func TestDefaultQueryAppliedToListAndQuery(t *testing.T) {
+	saveAndRestoreGlobals(t)
+	setJSONFlag(t, false)
+
+	dir := t.TempDir()
+	baseDirOverride = &dir
+	database, err := db.Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	create := func(title string, typ models.Type, priority models.Priority, label string, status models.Status) string {
+		issue := &models.Issue{Title: title, Type: typ, Priority: priority, Labels: []string{label}, Status: status}
+		if err := database.CreateIssue(issue); err != nil {
+			t.Fatalf("CreateIssue: %v", err)
+		}
+		return issue.ID
+	}
+	backendBug := create("Backend bug", models.TypeBug, models.PriorityP1, "backend", models.StatusOpen)
+	backendTask := create("Backend task", models.TypeTask, models.PriorityP2, "backend", models.StatusOpen)
+	frontendBug := create("Frontend bug", models.TypeBug, models.PriorityP1, "frontend", models.StatusOpen)
+	closedTask := create("Closed backend task", models.TypeTask, models.PriorityP2, "backend", models.StatusClosed)
+	all := []string{backendBug, backendTask, frontendBug, closedTask}
+
+	if err := setDefaultQuery("labels ~ (broken"); err == nil {
+		t.Fatal("expected an unparseable default-query to be rejected")
+	}
+	if q, _ := config.GetDefaultQuery(dir); q != "" {
+		t.Fatalf("rejected default-query was saved: %q", q)
+	}
+	if err := setDefaultQuery("labels ~ backend"); err != nil {
+		t.Fatalf("setDefaultQuery: %v", err)
+	}
+
+	expect := func(name, out string, want ...string) {
+		t.Helper()
+		wanted := map[string]bool{}
+		for _, id := range want {
+			wanted[id] = true
+		}
+		for _, id := range all {
+			if got := strings.Contains(out, id); got != wanted[id] {
+				t.Errorf("%s: %s listed = %v, want %v\n%s", name, id, got, wanted[id], out)
+			}
+		}
+	}
+
+	expect("list", runWithFlags(t, listCmd, nil, nil), backendBug, backendTask)
+	expect("list with query", runWithFlags(t, listCmd, []string{"type = bug"}, nil), backendBug)
+	expect("list with flag", runWithFlags(t, listCmd, nil, map[string]string{"priority": "P2"}), backendTask)
+	expect("list --all", runWithFlags(t, listCmd, nil, map[string]string{"all": "true"}), all...)
+
+	expect("query", runWithFlags(t, queryCmd, nil, nil), backendBug, backendTask, closedTask)
+	expect("query with expression", runWithFlags(t, queryCmd, []string{"type = bug"}, nil), backendBug)
+	expect("query --all", runWithFlags(t, queryCmd, []string{"type = bug"}, map[string]string{"all": "true"}), backendBug, frontendBug)
+
+	// Clearing it restores the unfiltered list
+	if err := setDefaultQuery(""); err != nil {
+		t.Fatalf("clear default-query: %v", err)
+	}
+	expect("list after clear", runWithFlags(t, listCmd, nil, nil), backendBug, backendTask, frontendBug)
+}
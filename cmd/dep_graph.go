@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/dependency"
+	"github.com/marcus/td/internal/output"
+	"github.com/marcus/td/internal/session"
+	"github.com/spf13/cobra"
+)
+
+var depGraphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Show the dependency graph or report cycles in it",
+	Long: `Show every depends_on link, or with --cycles scan all depends_on and
+parent links and report any cycles with the issues involved.
+
+--fix offers, for each dependency cycle, to remove its weakest link: a
+dependency on a closed issue first, then the dependency on the
+lowest-priority issue. Parent cycles are reported but must be broken with
+td update <issue> --parent.
+
+Examples:
+  td dep graph                 # list all dependency links
+  td dep graph --cycles        # report cycles
+  td dep graph --cycles --fix  # report cycles and offer to break them`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		baseDir := getBaseDir()
+
+		database, err := db.Open(baseDir)
+		if err != nil {
+			output.Error("%v", err)
+			return err
+		}
+		defer database.Close()
+
+		cycles, _ := cmd.Flags().GetBool("cycles")
+		fix, _ := cmd.Flags().GetBool("fix")
+		jsonOutput := jsonMode(cmd)
+
+		if fix && !cycles {
+			output.Error("--fix requires --cycles")
+			return fmt.Errorf("--fix requires --cycles")
+		}
+		if fix && jsonOutput {
+			output.Error("--fix is interactive and cannot be combined with --json")
+			return fmt.Errorf("--fix cannot be combined with --json")
+		}
+
+		if !cycles {
+			return showDependencyGraph(database, jsonOutput)
+		}
+
+		found, err := dependency.DetectCycles(database)
+		if err != nil {
+			output.Error("failed to scan dependencies: %v", err)
+			return err
+		}
+
+		if jsonOutput {
+			if found == nil {
+				found = []dependency.Cycle{}
+			}
+			return output.JSON(map[string]interface{}{"cycles": found})
+		}
+
+		printCycles(found)
+		if !fix || len(found) == 0 {
+			return nil
+		}
+
+		sess, err := session.GetOrCreate(database)
+		if err != nil {
+			output.Error("%v", err)
+			return err
+		}
+		removed := fixCycles(database, found, bufio.NewReader(os.Stdin), sess.ID)
+
+		remaining, err := dependency.DetectCycles(database)
+		if err != nil {
+			output.Error("failed to rescan dependencies: %v", err)
+			return err
+		}
+		fmt.Printf("\nRemoved %d dependencies, %d cycles remain\n", removed, len(remaining))
+		return nil
+	},
+}
+
+// showDependencyGraph lists every depends_on link.
+func showDependencyGraph(database *db.DB, jsonOutput bool) error {
+	deps, err := database.GetAllDependencies()
+	if err != nil {
+		output.Error("failed to read dependencies: %v", err)
+		return err
+	}
+
+	edges := []dependency.Edge{}
+	for from, tos := range deps {
+		for _, to := range tos {
+			edges = append(edges, dependency.Edge{From: from, To: to})
+		}
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].From != edges[j].From {
+			return edges[i].From < edges[j].From
+		}
+		return edges[i].To < edges[j].To
+	})
+
+	if jsonOutput {
+		return output.JSON(map[string]interface{}{"edges": edges})
+	}
+	if len(edges) == 0 {
+		fmt.Println("No dependencies")
+		return nil
+	}
+	for _, e := range edges {
+		fmt.Printf("%s -> %s\n", e.From, e.To)
+	}
+	fmt.Printf("\n%d dependencies\n", len(edges))
+	return nil
+}
+
+// printCycles writes each cycle with the links that form it.
+func printCycles(cycles []dependency.Cycle) {
+	if len(cycles) == 0 {
+		fmt.Println("No cycles found")
+		return
+	}
+	for _, c := range cycles {
+		label, arrow := "DEPENDENCY CYCLE", "depends on"
+		if c.Kind == dependency.CycleParent {
+			label, arrow = "PARENT CYCLE", "has parent"
+		}
+		fmt.Printf("%s: %s\n", label, strings.Join(c.IssueIDs, ", "))
+		for _, e := range c.Edges {
+			fmt.Printf("  %s %s %s\n", e.From, arrow, e.To)
+		}
+	}
+	fmt.Printf("\n%d cycles found\n", len(cycles))
+}
+
+// fixCycles offers to remove the weakest link of each dependency cycle,
+// reading y/N answers from reader. It returns the number of links removed.
+func fixCycles(database *db.DB, cycles []dependency.Cycle, reader *bufio.Reader, sessionID string) int {
+	removed := 0
+	for _, c := range cycles {
+		if c.Kind != dependency.CycleDependsOn {
+			fmt.Printf("\nParent cycle %s: break it with td update <issue> --parent\n", strings.Join(c.IssueIDs, ", "))
+			continue
+		}
+		edge, ok := dependency.WeakestEdge(database, c)
+		if !ok {
+			continue
+		}
+		answer := promptLine(reader, fmt.Sprintf("\nRemove %s depends on %s? [y/N]: ", edge.From, edge.To), "n")
+		if answer = strings.ToLower(answer); answer != "y" && answer != "yes" {
+			continue
+		}
+		if err := database.RemoveDependencyLogged(edge.From, edge.To, sessionID); err != nil {
+			output.Error("failed to remove dependency: %v", err)
+			continue
+		}
+		fmt.Printf("REMOVED: %s no longer depends on %s\n", edge.From, edge.To)
+		removed++
+	}
+	return removed
+}
+
+func init() {
+	depCmd.AddCommand(depGraphCmd)
+
+	depGraphCmd.Flags().Bool("cycles", false, "Report dependency and parent cycles")
+	depGraphCmd.Flags().Bool("fix", false, "Offer to remove the weakest link of each dependency cycle")
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/models"
+	"github.com/marcus/td/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var depTreeCmd = &cobra.Command{
+	Use:   "tree <issue>",
+	Short: "Show everything an issue depends on as a tree",
+	Long: `Show an indented tree of what an issue depends on, recursively, with each
+dependency's status so the blockers that are still open stand out.
+
+A dependency that leads back to an issue already on its branch is marked
+(cycle) and not expanded again. One that appears in several branches is
+expanded the first time and marked (see above) after that.
+
+Examples:
+  td dep tree td-abc1
+  td dep tree td-abc1 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		baseDir := getBaseDir()
+
+		database, err := db.Open(baseDir)
+		if err != nil {
+			output.Error("%v", err)
+			return err
+		}
+		defer database.Close()
+
+		issue, err := database.GetIssue(args[0])
+		if err != nil {
+			output.Error("%v", err)
+			return err
+		}
+
+		tree := buildDepTree(database, issue)
+		blocking, resolved := countDepTree(tree)
+
+		if jsonMode(cmd) {
+			return output.JSON(map[string]interface{}{
+				"issue":    issue,
+				"tree":     tree,
+				"blocking": blocking,
+				"resolved": resolved,
+			})
+		}
+
+		fmt.Println(output.IssueOneLiner(issue))
+		if len(tree.DependsOn) == 0 {
+			fmt.Println("No dependencies")
+			return nil
+		}
+		for _, line := range renderDepTree(tree.DependsOn, "") {
+			fmt.Println(line)
+		}
+		fmt.Printf("\n%d blocking, %d resolved\n", blocking, resolved)
+		return nil
+	},
+}
+
+// depTreeNode is an issue in a dependency tree with the issues it depends
+// on below it.
+type depTreeNode struct {
+	ID     string        `json:"id"`
+	Title  string        `json:"title"`
+	Status models.Status `json:"status"`
+	// Cycle marks a dependency on an issue already on this branch
+	Cycle bool `json:"cycle,omitempty"`
+	// Repeated marks an issue expanded earlier in the tree
+	Repeated  bool          `json:"repeated,omitempty"`
+	DependsOn []depTreeNode `json:"depends_on,omitempty"`
+}
+
+// buildDepTree returns the dependency tree rooted at issue. Dependencies are
+// listed in ID order; missing issues are skipped.
+func buildDepTree(database *db.DB, issue *models.Issue) depTreeNode {
+	root := depTreeNode{ID: issue.ID, Title: issue.Title, Status: issue.Status}
+	path := map[string]bool{issue.ID: true}
+	expanded := map[string]bool{issue.ID: true}
+	root.DependsOn = buildDepTreeChildren(database, issue.ID, path, expanded)
+	return root
+}
+
+func buildDepTreeChildren(database *db.DB, issueID string, path, expanded map[string]bool) []depTreeNode {
+	deps, err := database.GetDependencies(issueID)
+	if err != nil {
+		return nil
+	}
+	sort.Strings(deps)
+
+	var nodes []depTreeNode
+	for _, depID := range deps {
+		dep, err := database.GetIssue(depID)
+		if err != nil {
+			continue
+		}
+		node := depTreeNode{ID: dep.ID, Title: dep.Title, Status: dep.Status}
+		switch {
+		case path[dep.ID]:
+			node.Cycle = true
+		case expanded[dep.ID]:
+			node.Repeated = true
+		default:
+			expanded[dep.ID] = true
+			path[dep.ID] = true
+			node.DependsOn = buildDepTreeChildren(database, dep.ID, path, expanded)
+			delete(path, dep.ID)
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// countDepTree counts the distinct issues below the root that are still open
+// (blocking) and closed (resolved).
+func countDepTree(root depTreeNode) (blocking, resolved int) {
+	seen := map[string]bool{root.ID: true}
+	var walk func(nodes []depTreeNode)
+	walk = func(nodes []depTreeNode) {
+		for _, n := range nodes {
+			if seen[n.ID] {
+				continue
+			}
+			seen[n.ID] = true
+			if n.Status == models.StatusClosed {
+				resolved++
+			} else {
+				blocking++
+			}
+			walk(n.DependsOn)
+		}
+	}
+	walk(root.DependsOn)
+	return blocking, resolved
+}
+
+// renderDepTree renders nodes as indented tree lines below prefix.
+func renderDepTree(nodes []depTreeNode, prefix string) []string {
+	var lines []string
+	for i, n := range nodes {
+		connector, childPrefix := "├── ", prefix+"│   "
+		if i == len(nodes)-1 {
+			connector, childPrefix = "└── ", prefix+"    "
+		}
+
+		line := fmt.Sprintf("%s%s%s: %s %s", prefix, connector, n.ID, n.Title, output.FormatStatus(n.Status))
+		switch {
+		case n.Cycle:
+			line += " (cycle)"
+		case n.Repeated:
+			line += " (see above)"
+		case n.Status == models.StatusClosed:
+			line += " ✓"
+		}
+		lines = append(lines, line)
+		lines = append(lines, renderDepTree(n.DependsOn, childPrefix)...)
+	}
+	return lines
+}
+
+func init() {
+	depCmd.AddCommand(depTreeCmd)
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/models"
+)
+
+func TestBuildDepTree(t *testing.T) {
+	database, err := db.Initialize(t.TempDir())
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	newIssue := func(title string, status models.Status) *models.Issue {
+		issue := &models.Issue{Title: title, Status: status}
+		if err := database.CreateIssue(issue); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue
+	}
+	release := newIssue("Release", models.StatusOpen)
+	api := newIssue("API", models.StatusInProgress)
+	schema := newIssue("Schema", models.StatusClosed)
+	docs := newIssue("Docs", models.StatusOpen)
+	review := newIssue("Review", models.StatusBlocked)
+
+	// release -> api -> schema -> release (cycle), release -> docs,
+	// and review is reached from both api and docs
+	for _, link := range [][2]*models.Issue{
+		{release, api}, {api, schema}, {schema, release}, {release, docs}, {api, review}, {docs, review},
+	} {
+		if err := database.AddDependency(link[0].ID, link[1].ID, "depends_on"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+
+	find := func(nodes []depTreeNode, id string) depTreeNode {
+		t.Helper()
+		for _, n := range nodes {
+			if n.ID == id {
+				return n
+			}
+		}
+		t.Fatalf("%s not found among %+v", id, nodes)
+		return depTreeNode{}
+	}
+
+	tree := buildDepTree(database, release)
+	if len(tree.DependsOn) != 2 {
+		t.Fatalf("root has %d dependencies, want 2", len(tree.DependsOn))
+	}
+	apiNode := find(tree.DependsOn, api.ID)
+	if apiNode.Status != models.StatusInProgress || len(apiNode.DependsOn) != 2 {
+		t.Fatalf("api node = %+v", apiNode)
+	}
+	schemaNode := find(apiNode.DependsOn, schema.ID)
+	if schemaNode.Status != models.StatusClosed || len(schemaNode.DependsOn) != 1 {
+		t.Fatalf("schema node = %+v", schemaNode)
+	}
+	if back := schemaNode.DependsOn[0]; back.ID != release.ID || !back.Cycle || len(back.DependsOn) != 0 {
+		t.Errorf("schema -> release = %+v, want a cycle marker", back)
+	}
+
+	// review is expanded once and marked repeated the other time
+	docsNode := find(tree.DependsOn, docs.ID)
+	viaAPI, viaDocs := find(apiNode.DependsOn, review.ID), find(docsNode.DependsOn, review.ID)
+	if viaAPI.Repeated == viaDocs.Repeated {
+		t.Errorf("review repeated via api = %v, via docs = %v; want exactly one", viaAPI.Repeated, viaDocs.Repeated)
+	}
+	if viaAPI.Status != models.StatusBlocked || viaDocs.Status != models.StatusBlocked {
+		t.Errorf("review statuses = %s, %s; want blocked", viaAPI.Status, viaDocs.Status)
+	}
+
+	blocking, resolved := countDepTree(tree)
+	if blocking != 3 || resolved != 1 {
+		t.Errorf("counts = %d blocking, %d resolved; want 3, 1", blocking, resolved)
+	}
+
+	lines := renderDepTree(tree.DependsOn, "")
+	text := strings.Join(lines, "\n")
+	for _, want := range []string{
+		schema.ID + ": Schema [closed] ✓",
+		release.ID + ": Release [open] (cycle)",
+		review.ID + ": Review [blocked] (see above)",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("tree missing %q:\n%s", want, text)
+		}
+	}
+	if !strings.HasPrefix(lines[0], "├── ") {
+		t.Errorf("first line %q is not a top-level branch", lines[0])
+	}
+	for _, line := range lines[1:] {
+		if strings.Contains(line, schema.ID) && !strings.HasPrefix(line, "│   ") && !strings.HasPrefix(line, "    ") {
+			t.Errorf("schema line %q is not nested under api", line)
+		}
+	}
+}
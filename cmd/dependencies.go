@@ -204,9 +204,22 @@ var dependsOnCmd = &cobra.Command{
 }
 
 var criticalPathCmd = &cobra.Command{
-	Use:     "critical-path",
-	Short:   "Show the sequence of issues that unblocks the most work",
+	Use:   "critical-path [epic]",
+	Short: "Show the sequence of issues that unblocks the most work",
+	Long: `Without an epic, show the sequence of open issues that unblocks the most
+work, what can start now, and the biggest bottlenecks.
+
+With an epic, show the longest chain of dependencies among its open
+descendants, weighted by points: the work that decides how soon the epic
+can be done. Unestimated issues count as zero points. A dependency cycle is
+reported and the link closing it ignored.
+
+Examples:
+  td critical-path
+  td critical-path td-abc1
+  td critical-path td-abc1 --json`,
 	GroupID: "query",
+	Args:    cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		baseDir := getBaseDir()
 
@@ -217,6 +230,10 @@ var criticalPathCmd = &cobra.Command{
 		}
 		defer database.Close()
 
+		if len(args) == 1 {
+			return runEpicCriticalPath(cmd, database, args[0])
+		}
+
 		limit, _ := cmd.Flags().GetInt("limit")
 		if limit == 0 {
 			limit = 10
@@ -357,6 +374,49 @@ var criticalPathCmd = &cobra.Command{
 	},
 }
 
+// runEpicCriticalPath prints the longest points-weighted dependency chain
+// within an epic.
+func runEpicCriticalPath(cmd *cobra.Command, database *db.DB, epicID string) error {
+	epic, err := database.GetIssue(epicID)
+	if err != nil {
+		output.Error("%v", err)
+		return err
+	}
+
+	path, err := database.CriticalPath(epic.ID)
+	if err != nil {
+		output.Error("%v", err)
+		return err
+	}
+
+	if jsonMode(cmd) {
+		return output.JSON(map[string]interface{}{
+			"epic":   epic.ID,
+			"points": path.Points,
+			"cyclic": path.Cyclic,
+			"path":   path.Issues,
+		})
+	}
+
+	fmt.Println(output.IssueOneLiner(epic))
+	if len(path.Issues) == 0 {
+		fmt.Println("No open work under this issue")
+		return nil
+	}
+	fmt.Printf("CRITICAL PATH (%d points, %d issues):\n", path.Points, len(path.Issues))
+	for i, issue := range path.Issues {
+		estimate := "unestimated"
+		if issue.Points > 0 {
+			estimate = fmt.Sprintf("%d pts", issue.Points)
+		}
+		fmt.Printf("  %d. %s  %s  %s  (%s)\n", i+1, issue.ID, issue.Title, output.FormatStatus(issue.Status), estimate)
+	}
+	if path.Cyclic {
+		output.Warning("dependency cycle found; the link closing it was ignored (see td dep graph --cycles)")
+	}
+	return nil
+}
+
 // buildCriticalPathSequence builds the optimal sequence of issues to resolve
 // using a topological sort weighted by block counts
 func buildCriticalPathSequence(database *db.DB, issueMap map[string]*models.Issue, blockCounts map[string]int) []string {
@@ -435,6 +495,8 @@ Usage:
   td dep rm <issue> <depends-on>    Remove a dependency
   td dep <issue>                    Show what issue depends on
   td dep <issue> --blocking         Show what depends on issue
+  td dep tree <issue>               Show the full dependency tree with statuses
+  td dep graph --cycles             Report dependency and parent cycles
 
 Backward compatible:
   td dep <issue> <depends-on>       Same as 'td dep add'
@@ -494,7 +556,9 @@ var depAddCmd = &cobra.Command{
 	Long: `Add dependencies to an issue. Supports batch operations:
   td dep add td-abc td-xyz               # td-abc depends on td-xyz
   td dep add td-abc td-xyz1 td-xyz2      # td-abc depends on both td-xyz1 and td-xyz2
-  td dep add td-abc --depends-on td-xyz  # flag-based syntax also supported`,
+  td dep add td-abc --depends-on td-xyz  # flag-based syntax also supported
+
+An open issue that gains a dependency that isn't closed moves to blocked.`,
 	Args: cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		baseDir := getBaseDir()
@@ -625,6 +689,24 @@ func addDependency(database *db.DB, issueID, dependsOnID, sessionID string) erro
 	fmt.Printf("ADDED: %s depends on %s\n", issue.ID, depIssue.ID)
 	fmt.Printf("  %s: %s\n", issue.ID, issue.Title)
 	fmt.Printf("  └── now depends on: %s: %s\n", depIssue.ID, depIssue.Title)
+
+	// An open issue that gains an open dependency is blocked, the reverse of
+	// the auto-unblock when its last dependency closes
+	if issue.Status == models.StatusOpen && depIssue.Status != models.StatusClosed &&
+		workflowMachine().IsValidTransition(issue.Status, models.StatusBlocked) {
+		issue.Status = models.StatusBlocked
+		if err := database.UpdateIssueLogged(issue, sessionID, models.ActionBlock); err != nil {
+			output.Warning("failed to block %s: %v", issue.ID, err)
+			return nil
+		}
+		_ = database.AddLog(&models.Log{
+			IssueID:   issue.ID,
+			SessionID: sessionID,
+			Message:   fmt.Sprintf("Auto-blocked (depends on open %s)", depIssue.ID),
+			Type:      models.LogTypeBlocker,
+		})
+		fmt.Printf("  %s is now blocked\n", issue.ID)
+	}
 	return nil
 }
 
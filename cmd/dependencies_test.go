@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"bufio"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/marcus/td/internal/db"
@@ -394,6 +396,72 @@ func TestAddDependencySingle(t *testing.T) {
 	}
 }
 
+// TestAddDependencyBlocksOpenIssue tests that gaining an open dependency
+// blocks an open issue, and that undo reverses it
+func TestAddDependencyBlocksOpenIssue(t *testing.T) {
+	dir := t.TempDir()
+	database, err := db.Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	create := func(title string, status models.Status) *models.Issue {
+		t.Helper()
+		issue := &models.Issue{Title: title, Status: status}
+		if err := database.CreateIssue(issue); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue
+	}
+	blocker := create("Open blocker", models.StatusOpen)
+	done := create("Closed blocker", models.StatusClosed)
+	dependent := create("Dependent", models.StatusOpen)
+	started := create("Already started", models.StatusInProgress)
+	status := func(id string) models.Status {
+		t.Helper()
+		issue, err := database.GetIssue(id)
+		if err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		return issue.Status
+	}
+
+	// A closed dependency doesn't block
+	if err := addDependency(database, dependent.ID, done.ID, "ses_test"); err != nil {
+		t.Fatalf("addDependency failed: %v", err)
+	}
+	if got := status(dependent.ID); got != models.StatusOpen {
+		t.Fatalf("status after closed dependency = %s, want open", got)
+	}
+
+	// An open one does, as a logged block
+	if err := addDependency(database, dependent.ID, blocker.ID, "ses_test"); err != nil {
+		t.Fatalf("addDependency failed: %v", err)
+	}
+	if got := status(dependent.ID); got != models.StatusBlocked {
+		t.Fatalf("status after open dependency = %s, want blocked", got)
+	}
+	action, err := undoLastAction(database, "ses_test")
+	if err != nil {
+		t.Fatalf("undoLastAction failed: %v", err)
+	}
+	if action == nil || action.ActionType != models.ActionBlock {
+		t.Fatalf("undone action = %+v, want the block", action)
+	}
+	if got := status(dependent.ID); got != models.StatusOpen {
+		t.Errorf("status after undo = %s, want open", got)
+	}
+
+	// Work in progress is left alone
+	if err := addDependency(database, started.ID, blocker.ID, "ses_test"); err != nil {
+		t.Fatalf("addDependency failed: %v", err)
+	}
+	if got := status(started.ID); got != models.StatusInProgress {
+		t.Errorf("in-progress status after open dependency = %s, want in_progress", got)
+	}
+}
+
 // TestAddDependencyMultiple tests adding multiple dependencies to same issue
 func TestAddDependencyMultiple(t *testing.T) {
 	tests := []struct {
@@ -817,3 +885,63 @@ func TestAddDependencyComplexGraph(t *testing.T) {
 		})
 	}
 }
+
+// TestFixCyclesRemovesAcceptedEdges tests that --fix removes the weakest link
+// only when confirmed
+func TestFixCyclesRemovesAcceptedEdges(t *testing.T) {
+	dir := t.TempDir()
+	database, err := db.Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	newIssue := func(p models.Priority) string {
+		issue := &models.Issue{Title: "Issue", Status: models.StatusOpen, Priority: p}
+		if err := database.CreateIssue(issue); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue.ID
+	}
+	a, b := newIssue(models.PriorityP1), newIssue(models.PriorityP3)
+	c, d := newIssue(models.PriorityP1), newIssue(models.PriorityP1)
+	for _, edge := range [][2]string{{a, b}, {b, a}, {c, d}, {d, c}} {
+		if err := database.AddDependency(edge[0], edge[1], "depends_on"); err != nil {
+			t.Fatalf("AddDependency(%s, %s) failed: %v", edge[0], edge[1], err)
+		}
+	}
+
+	cycles, err := dependency.DetectCycles(database)
+	if err != nil || len(cycles) != 2 {
+		t.Fatalf("DetectCycles = %d cycles, %v; want 2", len(cycles), err)
+	}
+
+	// Accept the first fix, decline the second
+	answers := "y\nn\n"
+	if cycles[0].IssueIDs[0] != a && cycles[0].IssueIDs[0] != b {
+		answers = "n\ny\n"
+	}
+	var removed int
+	captureStdout(t, func() {
+		removed = fixCycles(database, cycles, bufio.NewReader(strings.NewReader(answers)), "ses_test")
+	})
+	if removed != 1 {
+		t.Fatalf("removed %d dependencies, want 1", removed)
+	}
+
+	// a -> b points at the P3 issue, so it is the link that went
+	if deps, err := database.GetDependencies(a); err != nil || len(deps) != 0 {
+		t.Errorf("%s still depends on %v (err %v)", a, deps, err)
+	}
+	if deps, err := database.GetDependencies(b); err != nil || len(deps) != 1 {
+		t.Errorf("%s dependencies = %v (err %v), want %s", b, deps, err, a)
+	}
+
+	remaining, err := dependency.DetectCycles(database)
+	if err != nil {
+		t.Fatalf("DetectCycles failed: %v", err)
+	}
+	if len(remaining) != 1 || remaining[0].IssueIDs[0] != min(c, d) {
+		t.Errorf("remaining cycles = %+v, want only the declined one", remaining)
+	}
+}
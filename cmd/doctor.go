@@ -12,16 +12,71 @@ import (
 )
 
 var doctorCmd = &cobra.Command{
-	Use:     "doctor",
-	Short:   "Run diagnostic checks for sync setup",
+	Use:   "doctor",
+	Short: "Run diagnostic checks for local database and sync setup",
+	Long: `Run diagnostic checks for local database health and, when sync is
+enabled, sync setup.
+
+Timestamps written by older versions of td may be stored in mixed formats
+(Go's time.Time.String(), SQLite's "YYYY-MM-DD HH:MM:SS", RFC3339 with a
+zone offset), which breaks ordering and time-range queries. Use
+--fix-timestamps to rewrite them to RFC3339 UTC; the sync server checks
+are skipped in that mode.`,
 	GroupID: "system",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		runDoctor()
+		fixTimestamps, _ := cmd.Flags().GetBool("fix-timestamps")
+		runDoctor(fixTimestamps)
 		return nil
 	},
 }
 
-func runDoctor() {
+func runDoctor(fixTimestamps bool) {
+	syncEnabled := features.IsEnabledForProcess(features.SyncCLI.Name)
+	if syncEnabled && !fixTimestamps {
+		runSyncServerChecks()
+	}
+
+	// 4. Local database
+	baseDir := getBaseDir()
+	database, err := db.Open(baseDir)
+	dbOK := err == nil
+	if dbOK {
+		defer database.Close()
+		fmt.Printf("Local database ......... OK\n")
+	} else {
+		fmt.Printf("Local database ......... FAIL (%v)\n", err)
+	}
+
+	if syncEnabled {
+		runSyncStateChecks(database, dbOK)
+	}
+
+	// 7. Timestamp formats
+	if !dbOK {
+		fmt.Printf("Timestamps ............. SKIP\n")
+	} else if fixTimestamps {
+		report, err := database.NormalizeTimestamps()
+		if err != nil {
+			fmt.Printf("Timestamps ............. FAIL (%v)\n", err)
+		} else if report.Unparseable > 0 {
+			fmt.Printf("Timestamps ............. FIXED (%d values, %d unparseable left as-is)\n", report.Rewritten, report.Unparseable)
+		} else {
+			fmt.Printf("Timestamps ............. FIXED (%d values)\n", report.Rewritten)
+		}
+	} else {
+		report, err := database.CheckTimestamps()
+		if err != nil {
+			fmt.Printf("Timestamps ............. FAIL (%v)\n", err)
+		} else if report.NonCanon > 0 {
+			fmt.Printf("Timestamps ............. WARN (%d non-RFC3339 values; run 'td doctor --fix-timestamps')\n", report.NonCanon)
+		} else {
+			fmt.Printf("Timestamps ............. OK\n")
+		}
+	}
+}
+
+// runSyncServerChecks checks auth config and talks to the sync server.
+func runSyncServerChecks() {
 	// 1. Auth config
 	auth, err := syncconfig.LoadAuth()
 	authOK := err == nil && auth != nil && auth.APIKey != ""
@@ -68,24 +123,15 @@ func runDoctor() {
 		}
 	}
 	_ = authValid // used for documentation; no dependent checks currently gate on this
+}
 
-	// 4. Local database
-	baseDir := getBaseDir()
-	database, err := db.Open(baseDir)
-	dbOK := err == nil
-	if dbOK {
-		defer database.Close()
-		fmt.Printf("Local database ......... OK\n")
-	} else {
-		fmt.Printf("Local database ......... FAIL (%v)\n", err)
-	}
-
+// runSyncStateChecks reports the local database's sync link and backlog.
+func runSyncStateChecks(database *db.DB, dbOK bool) {
 	// 5. Sync linked
-	var syncState *db.SyncState
 	if !dbOK {
 		fmt.Printf("Sync linked ............ SKIP\n")
 	} else {
-		syncState, err = database.GetSyncState()
+		syncState, err := database.GetSyncState()
 		if err != nil {
 			fmt.Printf("Sync linked ............ FAIL (%v)\n", err)
 		} else if syncState == nil {
@@ -111,5 +157,6 @@ func runDoctor() {
 }
 
 func init() {
-	AddFeatureGatedCommand(features.SyncCLI.Name, doctorCmd)
+	doctorCmd.Flags().Bool("fix-timestamps", false, "Rewrite non-RFC3339 timestamps to RFC3339 UTC")
+	rootCmd.AddCommand(doctorCmd)
 }
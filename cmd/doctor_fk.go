@@ -6,6 +6,7 @@ import (
 	"text/tabwriter"
 
 	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/features"
 	"github.com/spf13/cobra"
 )
 
@@ -43,5 +44,7 @@ var doctorFkCmd = &cobra.Command{
 }
 
 func init() {
-	doctorCmd.AddCommand(doctorFkCmd)
+	if features.IsEnabledForProcess(features.SyncCLI.Name) {
+		doctorCmd.AddCommand(doctorFkCmd)
+	}
 }
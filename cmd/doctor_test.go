@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/models"
+)
+
+func TestDoctorFixTimestampsSkipsServerChecks(t *testing.T) {
+	saveAndRestoreGlobals(t)
+	setJSONFlag(t, false)
+	dir := t.TempDir()
+	baseDirOverride = &dir
+	// Even with sync enabled, fixing timestamps must not touch the network
+	t.Setenv("TD_FEATURE_SYNC_CLI", "1")
+	t.Setenv("TD_SYNC_URL", "http://127.0.0.1:1")
+
+	database, err := db.Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	issue := &models.Issue{Title: "Old timestamp"}
+	if err := database.CreateIssue(issue); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if _, err := database.Conn().Exec(`UPDATE issues SET created_at = '2024-01-02 03:04:05' WHERE id = ?`, issue.ID); err != nil {
+		t.Fatalf("seed timestamp: %v", err)
+	}
+	database.Close()
+
+	t.Cleanup(func() {
+		rootCmd.SetArgs(nil)
+		_ = doctorCmd.Flags().Set("fix-timestamps", "false")
+	})
+	rootCmd.SetArgs([]string{"doctor", "--fix-timestamps"})
+	out := captureStdout(t, func() {
+		if err := rootCmd.Execute(); err != nil {
+			t.Errorf("td doctor failed: %v", err)
+		}
+	})
+
+	if !strings.Contains(out, "Timestamps ............. FIXED") {
+		t.Errorf("output = %q, want timestamps fixed", out)
+	}
+	for _, check := range []string{"Auth config", "Server reachable", "Auth valid"} {
+		if strings.Contains(out, check) {
+			t.Errorf("output = %q, should skip %s check", out, check)
+		}
+	}
+}
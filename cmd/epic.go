@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/marcus/td/internal/db"
 	"github.com/marcus/td/internal/models"
@@ -10,10 +12,180 @@ import (
 )
 
 var epicCmd = &cobra.Command{
-	Use:     "epic",
-	Short:   "Shortcuts for working with epics",
-	Long:    `Convenience commands for creating and viewing epics.`,
+	Use:   "epic [epic-id]",
+	Short: "Shortcuts for working with epics",
+	Long: `Convenience commands for creating and viewing epics.
+
+With an epic ID, prints a dashboard: progress across all descendants,
+direct children grouped by status, open blockers anywhere under the epic,
+and the most recent handoff on any descendant.
+
+Examples:
+  td epic td-abc1
+  td epic td-abc1 --json`,
 	GroupID: "core",
+	Args:    cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return cmd.Help()
+		}
+		return runEpicDashboard(cmd, args[0])
+	},
+}
+
+// epicBlocker is an open issue that some unfinished descendant depends on.
+type epicBlocker struct {
+	Issue  *models.Issue `json:"issue"`
+	Blocks []string      `json:"blocks"`
+}
+
+// epicDashboard is everything `td epic <id>` reports, shared by the text and
+// JSON renderings.
+type epicDashboard struct {
+	Epic     *models.Issue                     `json:"epic"`
+	Done     int                               `json:"done"`
+	Total    int                               `json:"total"`
+	Children map[models.Status][]*models.Issue `json:"children"`
+	Blockers []epicBlocker                     `json:"blockers"`
+	Handoff  *models.Handoff                   `json:"latest_handoff"`
+}
+
+// epicStatusOrder is the order child groups are printed in: active work
+// first, finished work last.
+var epicStatusOrder = []models.Status{
+	models.StatusInProgress,
+	models.StatusInReview,
+	models.StatusBlocked,
+	models.StatusOpen,
+	models.StatusClosed,
+}
+
+// buildEpicDashboard assembles the dashboard for epicID from the existing
+// child, dependency and handoff accessors.
+func buildEpicDashboard(database *db.DB, epicID string) (*epicDashboard, error) {
+	epic, err := database.GetIssue(epicID)
+	if err != nil {
+		return nil, err
+	}
+	d := &epicDashboard{
+		Epic:     epic,
+		Children: make(map[models.Status][]*models.Issue),
+		Blockers: []epicBlocker{},
+	}
+
+	children, err := database.GetDirectChildren(epic.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, child := range children {
+		d.Children[child.Status] = append(d.Children[child.Status], child)
+	}
+
+	descendants, err := database.GetDescendantIssues(epic.ID, nil)
+	if err != nil {
+		return nil, err
+	}
+	d.Total = len(descendants)
+	var unfinished []string
+	for _, issue := range descendants {
+		if issue.Status == models.StatusClosed {
+			d.Done++
+		} else {
+			unfinished = append(unfinished, issue.ID)
+		}
+		h, err := database.GetLatestHandoff(issue.ID)
+		if err == nil && h != nil && (d.Handoff == nil || h.Timestamp.After(d.Handoff.Timestamp)) {
+			d.Handoff = h
+		}
+	}
+
+	deps, err := database.GetBlockersForIssues(unfinished)
+	if err != nil {
+		return nil, err
+	}
+	blocks := make(map[string][]string)
+	for issueID, blockerIDs := range deps {
+		for _, blockerID := range blockerIDs {
+			blocks[blockerID] = append(blocks[blockerID], issueID)
+		}
+	}
+	for blockerID, blocked := range blocks {
+		blocker, err := database.GetIssue(blockerID)
+		if err != nil || blocker.Status == models.StatusClosed {
+			continue
+		}
+		sort.Strings(blocked)
+		d.Blockers = append(d.Blockers, epicBlocker{Issue: blocker, Blocks: blocked})
+	}
+	sort.Slice(d.Blockers, func(i, j int) bool {
+		return d.Blockers[i].Issue.ID < d.Blockers[j].Issue.ID
+	})
+	return d, nil
+}
+
+func runEpicDashboard(cmd *cobra.Command, epicID string) error {
+	isJSON := jsonMode(cmd)
+
+	database, err := db.Open(getBaseDir())
+	if err != nil {
+		output.Error("%v", err)
+		return err
+	}
+	defer database.Close()
+
+	d, err := buildEpicDashboard(database, epicID)
+	if err != nil {
+		if isJSON {
+			output.JSONError("not_found", err.Error())
+		} else {
+			output.Error("%v", err)
+		}
+		return err
+	}
+
+	if isJSON {
+		return output.JSON(d)
+	}
+
+	fmt.Printf("%s: %s %s\n", d.Epic.ID, d.Epic.Title, output.FormatStatus(d.Epic.Status))
+	pct := 0
+	if d.Total > 0 {
+		pct = d.Done * 100 / d.Total
+	}
+	fmt.Printf("Progress: %d/%d done (%d%%)\n", d.Done, d.Total, pct)
+
+	for _, status := range epicStatusOrder {
+		issues := d.Children[status]
+		if len(issues) == 0 {
+			continue
+		}
+		fmt.Print(output.SectionHeader(fmt.Sprintf("%s (%d)", status, len(issues))))
+		for _, issue := range issues {
+			fmt.Printf("  %s\n", output.IssueOneLiner(issue))
+		}
+	}
+
+	if len(d.Blockers) > 0 {
+		fmt.Print(output.SectionHeader("Open Blockers"))
+		for _, b := range d.Blockers {
+			fmt.Printf("  %s → blocks %s\n", output.IssueOneLiner(b.Issue), strings.Join(b.Blocks, ", "))
+		}
+	}
+
+	if h := d.Handoff; h != nil {
+		fmt.Print(output.SectionHeader("Latest Handoff"))
+		fmt.Printf("  %s (%s, %s)\n", h.IssueID, h.SessionID, output.FormatTimeAgo(h.Timestamp))
+		for _, line := range output.BulletList(h.Done, 2) {
+			fmt.Println(line)
+		}
+		if len(h.Remaining) > 0 {
+			fmt.Println("  Remaining:")
+			for _, line := range output.BulletList(h.Remaining, 4) {
+				fmt.Println(line)
+			}
+		}
+	}
+	return nil
 }
 
 var epicCreateCmd = &cobra.Command{
@@ -1,6 +1,13 @@
 package cmd
 
-import "testing"
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/models"
+)
 
 // TestEpicCmdExists tests that epic command exists
 func TestEpicCmdExists(t *testing.T) {
@@ -90,3 +97,101 @@ func TestEpicCreateHasHiddenTypeFlag(t *testing.T) {
 	// Reset
 	epicCreateCmd.Flags().Set("type", "")
 }
+
+// TestEpicDashboard seeds an epic with mixed-status children, an outside
+// blocker and a handoff, and checks both renderings of `td epic <id>`.
+func TestEpicDashboard(t *testing.T) {
+	saveAndRestoreGlobals(t)
+
+	dir := t.TempDir()
+	baseDir := dir
+	baseDirOverride = &baseDir
+
+	database, err := db.Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	epic := &models.Issue{Title: "Auth", Type: models.TypeEpic}
+	blocker := &models.Issue{Title: "Upstream API"}
+	for _, issue := range []*models.Issue{epic, blocker} {
+		if err := database.CreateIssue(issue); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	active := &models.Issue{Title: "Login form", ParentID: epic.ID, Status: models.StatusInProgress}
+	done := &models.Issue{Title: "Schema", ParentID: epic.ID, Status: models.StatusClosed}
+	waiting := &models.Issue{Title: "Token refresh", ParentID: epic.ID}
+	for _, issue := range []*models.Issue{active, done, waiting} {
+		if err := database.CreateIssue(issue); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	grandchild := &models.Issue{Title: "Validation", ParentID: active.ID, Status: models.StatusClosed}
+	if err := database.CreateIssue(grandchild); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := database.AddDependency(waiting.ID, blocker.ID, "depends_on"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+	if err := database.AddHandoff(&models.Handoff{IssueID: active.ID, SessionID: "ses-1", Done: []string{"form layout"}}); err != nil {
+		t.Fatalf("AddHandoff failed: %v", err)
+	}
+
+	out := captureStdout(t, func() {
+		if err := runEpicDashboard(epicCmd, epic.ID); err != nil {
+			t.Fatalf("runEpicDashboard failed: %v", err)
+		}
+	})
+	for _, want := range []string{
+		"Progress: 2/4 done (50%)",
+		"IN_PROGRESS (1)",
+		"CLOSED (1)",
+		"OPEN (1)",
+		blocker.ID + ` "Upstream API"`,
+		"blocks " + waiting.ID,
+		"LATEST HANDOFF",
+		"form layout",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("dashboard missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Validation") {
+		t.Errorf("grandchild listed among direct children:\n%s", out)
+	}
+
+	_ = epicCmd.Flags().Set("json", "true")
+	t.Cleanup(func() { _ = epicCmd.Flags().Set("json", "false") })
+	jsonOut := captureStdout(t, func() {
+		if err := runEpicDashboard(epicCmd, epic.ID); err != nil {
+			t.Fatalf("runEpicDashboard --json failed: %v", err)
+		}
+	})
+	var got struct {
+		Done     int                       `json:"done"`
+		Total    int                       `json:"total"`
+		Children map[string][]models.Issue `json:"children"`
+		Blockers []struct {
+			Issue  models.Issue `json:"issue"`
+			Blocks []string     `json:"blocks"`
+		} `json:"blockers"`
+		Handoff *models.Handoff `json:"latest_handoff"`
+	}
+	if err := json.Unmarshal([]byte(jsonOut), &got); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, jsonOut)
+	}
+	if got.Done != 2 || got.Total != 4 {
+		t.Errorf("progress = %d/%d, want 2/4", got.Done, got.Total)
+	}
+	if len(got.Children["in_progress"]) != 1 || len(got.Children["closed"]) != 1 || len(got.Children["open"]) != 1 {
+		t.Errorf("children = %v, want one each of in_progress/closed/open", got.Children)
+	}
+	if len(got.Blockers) != 1 || got.Blockers[0].Issue.ID != blocker.ID || len(got.Blockers[0].Blocks) != 1 || got.Blockers[0].Blocks[0] != waiting.ID {
+		t.Errorf("blockers = %+v, want %s blocking %s", got.Blockers, blocker.ID, waiting.ID)
+	}
+	if got.Handoff == nil || got.Handoff.IssueID != active.ID {
+		t.Errorf("latest_handoff = %+v, want handoff on %s", got.Handoff, active.ID)
+	}
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/models"
+	"github.com/marcus/td/internal/output"
+	"github.com/marcus/td/internal/session"
+	"github.com/spf13/cobra"
+)
+
+var groupCmd = &cobra.Command{
+	Use:   "group [issue-id...]",
+	Short: "Move several issues under one epic",
+	Long: `Move several issues under an existing parent (--parent) or a newly
+created epic (--new). Every issue is checked first; if any is missing or
+would end up under itself or one of its own descendants, nothing is moved
+and no epic is created.
+
+Once the issues are moved, the parent epic's status is cascaded once, so
+grouping finished work under a fresh epic closes (or submits) the epic too.
+
+Examples:
+  td group --parent td-epic1 td-a1 td-b2 td-c3
+  td group --new "Auth cleanup" td-a1 td-b2`,
+	GroupID: "core",
+	Args:    cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		baseDir := getBaseDir()
+		isJSON := jsonMode(cmd)
+
+		emitErr := func(format string, args ...interface{}) {
+			if !isJSON {
+				output.Error(format, args...)
+			}
+		}
+
+		parentID, _ := cmd.Flags().GetString("parent")
+		newTitle, _ := cmd.Flags().GetString("new")
+		if (parentID == "") == (newTitle == "") {
+			err := fmt.Errorf("specify exactly one of --parent or --new")
+			emitErr("%v", err)
+			return err
+		}
+
+		database, err := db.Open(baseDir)
+		if err != nil {
+			emitErr("%v", err)
+			return err
+		}
+		defer database.Close()
+
+		sess, err := session.GetOrCreate(database)
+		if err != nil {
+			emitErr("%v", err)
+			return err
+		}
+
+		var moved, cascaded []string
+		if newTitle != "" {
+			epic := &models.Issue{
+				Title:          newTitle,
+				Type:           models.TypeEpic,
+				CreatorSession: sess.ID,
+			}
+			if sess.Name != "" {
+				epic.CreatedBy = sess.Name
+			}
+			moved, cascaded, err = database.GroupUnderNewEpic(args, epic, sess.ID)
+			if err != nil {
+				emitErr("%v", err)
+				return err
+			}
+			parentID = epic.ID
+			if !isJSON {
+				fmt.Printf("CREATED %s: %s\n", epic.ID, epic.Title)
+			}
+		} else {
+			moved, cascaded, err = database.BulkReparent(args, parentID, sess.ID)
+			if err != nil {
+				emitErr("%v", err)
+				return err
+			}
+		}
+		parentID = db.NormalizeIssueID(parentID)
+
+		if isJSON {
+			return output.JSON(map[string]interface{}{
+				"parent_id": parentID,
+				"moved":     nonNilStrings(moved),
+				"cascaded":  nonNilStrings(cascaded),
+			})
+		}
+
+		if len(moved) == 0 {
+			fmt.Printf("All issues already under %s\n", parentID)
+			return nil
+		}
+		fmt.Printf("GROUPED %d under %s: %s\n", len(moved), parentID, strings.Join(moved, ", "))
+		for _, id := range cascaded {
+			if parent, err := database.GetIssue(id); err == nil {
+				fmt.Printf("  ↑ Parent %s auto-cascaded to %s\n", id, parent.Status)
+			}
+		}
+		return nil
+	},
+}
+
+// nonNilStrings keeps JSON output as [] rather than null for empty lists.
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+func init() {
+	rootCmd.AddCommand(groupCmd)
+
+	groupCmd.Flags().String("parent", "", "Existing parent issue ID")
+	groupCmd.Flags().String("new", "", "Create a new epic with this title and group under it")
+}
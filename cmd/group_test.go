@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/models"
+)
+
+func TestGroupCommandNewEpic(t *testing.T) {
+	saveAndRestoreGlobals(t)
+
+	dir := t.TempDir()
+	baseDir := dir
+	baseDirOverride = &baseDir
+
+	database, err := db.Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	a := &models.Issue{Title: "a"}
+	b := &models.Issue{Title: "b"}
+	for _, issue := range []*models.Issue{a, b} {
+		if err := database.CreateIssue(issue); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	_ = groupCmd.Flags().Set("new", "Cleanup")
+	t.Cleanup(func() { _ = groupCmd.Flags().Set("new", "") })
+	out := captureStdout(t, func() {
+		if err := groupCmd.RunE(groupCmd, []string{a.ID, b.ID}); err != nil {
+			t.Fatalf("groupCmd.RunE failed: %v", err)
+		}
+	})
+	if !strings.Contains(out, "GROUPED 2 under") {
+		t.Errorf("output = %q, want GROUPED line", out)
+	}
+
+	gotA, _ := database.GetIssue(a.ID)
+	parent, err := database.GetIssue(gotA.ParentID)
+	if err != nil {
+		t.Fatalf("parent of %s not found: %v", a.ID, err)
+	}
+	if parent.Type != models.TypeEpic || parent.Title != "Cleanup" {
+		t.Errorf("parent = %s %q, want new epic \"Cleanup\"", parent.Type, parent.Title)
+	}
+	if gotB, _ := database.GetIssue(b.ID); gotB.ParentID != parent.ID {
+		t.Errorf("%s parent = %q, want %s", b.ID, gotB.ParentID, parent.ID)
+	}
+}
@@ -32,7 +32,17 @@ Accepts YAML-like format via stdin:
 Or use flags with values, stdin (-), or file (@path):
   --done "item"          Single item
   --done @done.txt       Items from file (one per line)
-  echo "item" | td handoff ID --done -   Items from stdin`,
+  echo "item" | td handoff ID --done -   Items from stdin
+
+Use --latest to print the most recent handoff instead of recording one:
+  td handoff td-a1b2 --latest
+  td handoff td-a1b2 --latest --json
+
+Use --draft to print a handoff pre-filled from subtasks and logs since the
+last handoff, in the stdin format above, without recording it:
+  td handoff td-a1b2 --draft > handoff.txt
+  # edit handoff.txt
+  td handoff td-a1b2 < handoff.txt`,
 	GroupID: "workflow",
 	Args:    cobra.RangeArgs(0, 2),
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -59,7 +69,7 @@ Or use flags with values, stdin (-), or file (@path):
 			messageArg = args[1]
 		case 1:
 			// Check if the single arg is an issue ID or a message
-			if issueIDPattern.MatchString(args[0]) {
+			if isIssueIDArg(baseDir, args[0]) {
 				issueArg = args[0]
 			} else {
 				// Treat as message, infer issue from focus
@@ -104,6 +114,22 @@ Or use flags with values, stdin (-), or file (@path):
 			emitErr("%v", err)
 			return err
 		}
+		issueID = issue.ID
+
+		if latest, _ := cmd.Flags().GetBool("latest"); latest {
+			if messageArg != "" || handoffContentFlagsSet(cmd) {
+				emitErr("--latest cannot be combined with handoff content")
+				return fmt.Errorf("--latest cannot be combined with handoff content")
+			}
+			return printLatestHandoff(database, issueID, isJSON)
+		}
+		if draft, _ := cmd.Flags().GetBool("draft"); draft {
+			if messageArg != "" || handoffContentFlagsSet(cmd) {
+				emitErr("--draft cannot be combined with handoff content")
+				return fmt.Errorf("--draft cannot be combined with handoff content")
+			}
+			return printHandoffDraft(database, issueID, sess.ID, isJSON)
+		}
 
 		handoff := &models.Handoff{
 			IssueID:   issueID,
@@ -114,6 +140,8 @@ Or use flags with values, stdin (-), or file (@path):
 		done, _ := cmd.Flags().GetStringArray("done")
 		remaining, _ := cmd.Flags().GetStringArray("remaining")
 		decisions, _ := cmd.Flags().GetStringArray("decision")
+		decisionsAlias, _ := cmd.Flags().GetStringArray("decisions")
+		decisions = append(decisions, decisionsAlias...)
 		uncertain, _ := cmd.Flags().GetStringArray("uncertain")
 
 		var stdinUsed bool
@@ -276,6 +304,90 @@ Or use flags with values, stdin (-), or file (@path):
 	},
 }
 
+// handoffContentFlagsSet reports whether any flag that adds handoff content
+// was given.
+func handoffContentFlagsSet(cmd *cobra.Command) bool {
+	for _, name := range []string{"done", "remaining", "decision", "decisions", "uncertain", "note", "message"} {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// printLatestHandoff prints the most recent handoff for an issue.
+func printLatestHandoff(database *db.DB, issueID string, isJSON bool) error {
+	handoff, err := database.GetLatestHandoff(issueID)
+	if err != nil {
+		if isJSON {
+			output.JSONError(output.ErrCodeDatabaseError, err.Error())
+		} else {
+			output.Error("%v", err)
+		}
+		return err
+	}
+
+	if isJSON {
+		return output.JSON(map[string]any{
+			"id":      issueID,
+			"handoff": handoff,
+		})
+	}
+
+	if handoff == nil {
+		fmt.Printf("No handoff recorded for %s\n", issueID)
+		return nil
+	}
+	fmt.Printf("HANDOFF %s (%s, %s):\n", issueID, handoff.SessionID, output.FormatTimeAgo(handoff.Timestamp))
+	fmt.Print(output.FormatHandoff(handoff))
+	return nil
+}
+
+// printHandoffDraft prints a generated, unsaved handoff for an issue in the
+// format parseHandoffInput reads back.
+func printHandoffDraft(database *db.DB, issueID, sessionID string, isJSON bool) error {
+	draft, err := database.GenerateHandoffDraft(issueID, sessionID)
+	if err != nil {
+		if isJSON {
+			output.JSONError(output.ErrCodeDatabaseError, err.Error())
+		} else {
+			output.Error("%v", err)
+		}
+		return err
+	}
+
+	if isJSON {
+		return output.JSON(map[string]any{
+			"id":      issueID,
+			"handoff": draft,
+		})
+	}
+	fmt.Print(formatHandoffInput(draft))
+	return nil
+}
+
+// formatHandoffInput renders a handoff in the YAML-like stdin format. Every
+// section is written, empty or not, so the draft can be filled in.
+func formatHandoffInput(handoff *models.Handoff) string {
+	var sb strings.Builder
+	sections := []struct {
+		name  string
+		items []string
+	}{
+		{"done", handoff.Done},
+		{"remaining", handoff.Remaining},
+		{"decisions", handoff.Decisions},
+		{"uncertain", handoff.Uncertain},
+	}
+	for _, section := range sections {
+		sb.WriteString(section.name + ":\n")
+		for _, item := range section.items {
+			sb.WriteString("  - " + item + "\n")
+		}
+	}
+	return sb.String()
+}
+
 func parseHandoffInput(handoff *models.Handoff) {
 	scanner := bufio.NewScanner(os.Stdin)
 	currentSection := ""
@@ -315,7 +427,10 @@ func init() {
 	handoffCmd.Flags().StringArray("done", nil, "Completed item (repeatable)")
 	handoffCmd.Flags().StringArray("remaining", nil, "Remaining item (repeatable)")
 	handoffCmd.Flags().StringArray("decision", nil, "Decision made (repeatable)")
+	handoffCmd.Flags().StringArray("decisions", nil, "Alias for --decision")
 	handoffCmd.Flags().StringArray("uncertain", nil, "Uncertainty (repeatable)")
 	handoffCmd.Flags().StringP("note", "n", "", "Simple note for handoff (alternative to structured flags)")
 	handoffCmd.Flags().StringP("message", "m", "", "Simple message for handoff (alias for --note)")
+	handoffCmd.Flags().Bool("latest", false, "Print the most recent handoff instead of recording one")
+	handoffCmd.Flags().Bool("draft", false, "Print a pre-filled handoff to edit instead of recording one")
 }
@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/import/jira"
+	"github.com/marcus/td/internal/models"
+	"github.com/marcus/td/internal/output"
+	"github.com/marcus/td/internal/session"
+	"github.com/spf13/cobra"
+)
+
+var importJiraCmd = &cobra.Command{
+	Use:   "jira",
+	Short: "Import issues from a Jira JSON export",
+	Long: `Import a Jira backlog from the JSON of a Jira REST search
+(/rest/api/2/search or /rest/api/3/search), or a bare array of its issues.
+
+Mapping:
+  type      Epic -> epic, Bug -> bug, Story/Improvement -> feature,
+            everything else (Task, Sub-task, ...) -> task
+  status    Blocked -> blocked, In Review/Code Review/QA -> in_review,
+            otherwise by status category: To Do -> open,
+            In Progress -> in_progress, Done -> closed
+  priority  Highest/Blocker/Critical -> P0, High/Major -> P1, Medium -> P2,
+            Low/Minor -> P3, Lowest/Trivial -> P4
+  parent    subtask and epic children (parent or Epic Link) become children
+  links     "Blocks" links become dependencies
+
+Labels are kept, and each issue gets a jira:<KEY> label recording its Jira
+key. Issues whose key was already imported are skipped, so an export can be
+imported again after it grows. jira: labels are reserved for this: editing
+an issue keeps them, and they can't be added by hand or renamed.
+
+Examples:
+  td import jira --file export.json
+  td import jira --file export.json --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		baseDir := getBaseDir()
+		filePath, _ := cmd.Flags().GetString("file")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			output.Error("failed to read file: %v", err)
+			return err
+		}
+		issues, err := jira.Parse(data)
+		if err != nil {
+			output.Error("%v", err)
+			return err
+		}
+
+		database, err := db.Open(baseDir)
+		if err != nil {
+			output.Error("%v", err)
+			return err
+		}
+		defer database.Close()
+
+		sess, err := session.GetOrCreate(database)
+		if err != nil {
+			output.Error("%v", err)
+			return err
+		}
+
+		result, err := importJira(database, issues, sess.ID, dryRun)
+		if err != nil {
+			output.Error("%v", err)
+			return err
+		}
+
+		if jsonMode(cmd) {
+			return output.EmitResult("import_jira", map[string]any{
+				"dry_run":      dryRun,
+				"imported":     result.imported,
+				"skipped":      result.skipped,
+				"dependencies": result.dependencies,
+			})
+		}
+		verb := "Imported"
+		if dryRun {
+			verb = "Would import"
+		}
+		fmt.Printf("\n%s %d issue(s) from Jira, %d dependency link(s); %d already imported\n",
+			verb, len(result.imported), result.dependencies, len(result.skipped))
+		return nil
+	},
+}
+
+// jiraImportResult summarizes a Jira import. imported maps Jira keys to
+// the new td IDs (empty on a dry run).
+type jiraImportResult struct {
+	imported     map[string]string
+	skipped      []string
+	dependencies int
+}
+
+// importJira creates the mapped Jira issues, parents first, then links their
+// dependencies. Keys imported earlier are skipped but still resolve as
+// parents and dependency targets.
+func importJira(database *db.DB, issues []jira.Issue, sessionID string, dryRun bool) (*jiraImportResult, error) {
+	existing, err := importedJiraKeys(database)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &jiraImportResult{imported: make(map[string]string), skipped: []string{}}
+	ids := make(map[string]string, len(existing))
+	for key, id := range existing {
+		ids[key] = id
+	}
+
+	var created []jira.Issue
+	for _, ji := range issues {
+		if _, ok := existing[ji.SourceKey]; ok {
+			result.skipped = append(result.skipped, ji.SourceKey)
+			continue
+		}
+		issue := ji.Issue
+		issue.ParentID = ids[ji.ParentKey]
+		if dryRun {
+			fmt.Printf("[dry-run] Would import %s: %s (%s, %s, %s)\n", ji.SourceKey, issue.Title, issue.Type, issue.Status, issue.Priority)
+			result.imported[ji.SourceKey] = ""
+			created = append(created, ji)
+			continue
+		}
+
+		closedAt := issue.ClosedAt
+		if err := database.CreateIssueLogged(&issue, sessionID); err != nil {
+			return result, fmt.Errorf("failed to import %s: %w", ji.SourceKey, err)
+		}
+		if closedAt != nil {
+			// CreateIssueLogged doesn't store closed_at
+			issue.ClosedAt = closedAt
+			if err := database.UpdateIssueLogged(&issue, sessionID, models.ActionClose); err != nil {
+				output.Warning("failed to record close time for %s: %v", issue.ID, err)
+			}
+		}
+		if err := database.AddLog(&models.Log{
+			IssueID:   issue.ID,
+			SessionID: sessionID,
+			Message:   "Imported from Jira " + ji.SourceKey,
+			Type:      models.LogTypeProgress,
+		}); err != nil {
+			output.Warning("add log failed: %v", err)
+		}
+		ids[ji.SourceKey] = issue.ID
+		result.imported[ji.SourceKey] = issue.ID
+		created = append(created, ji)
+		fmt.Printf("IMPORTED %s (%s): %s\n", issue.ID, ji.SourceKey, issue.Title)
+	}
+
+	for _, ji := range created {
+		for _, depKey := range ji.DependsOn {
+			if dryRun {
+				result.dependencies++
+				continue
+			}
+			depID, ok := ids[depKey]
+			if !ok {
+				output.Warning("%s is blocked by %s, which is not in the export", ji.SourceKey, depKey)
+				continue
+			}
+			if err := database.AddDependencyLogged(ids[ji.SourceKey], depID, "depends_on", sessionID); err != nil {
+				output.Warning("failed to link %s to %s: %v", ji.SourceKey, depKey, err)
+				continue
+			}
+			result.dependencies++
+		}
+	}
+	return result, nil
+}
+
+// importedJiraKeys maps the Jira keys recorded on existing issues to their
+// td IDs.
+func importedJiraKeys(database *db.DB) (map[string]string, error) {
+	all, err := database.ListIssues(db.ListIssuesOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issues: %w", err)
+	}
+	keys := make(map[string]string)
+	for _, issue := range all {
+		for _, label := range issue.Labels {
+			if key, ok := strings.CutPrefix(label, jira.SourceLabelPrefix); ok {
+				keys[key] = issue.ID
+			}
+		}
+	}
+	return keys, nil
+}
+
+func init() {
+	importCmd.AddCommand(importJiraCmd)
+
+	importJiraCmd.Flags().String("file", "", "Jira JSON export to import")
+	importJiraCmd.Flags().Bool("dry-run", false, "Show what would be imported without changing anything")
+	_ = importJiraCmd.MarkFlagRequired("file")
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/import/jira"
+	"github.com/marcus/td/internal/models"
+)
+
+const jiraEpicExport = `{"issues": [
+	{"key": "WEB-2", "fields": {"summary": "Login form", "issuetype": {"name": "Story"},
+		"status": {"name": "Done", "statusCategory": {"key": "done"}}, "parent": {"key": "WEB-1"},
+		"issuelinks": [{"type": {"name": "Blocks"}, "inwardIssue": {"key": "WEB-3"}}]}},
+	{"key": "WEB-1", "fields": {"summary": "Authentication", "issuetype": {"name": "Epic"},
+		"status": {"name": "To Do", "statusCategory": {"key": "new"}}}},
+	{"key": "WEB-3", "fields": {"summary": "Session store", "issuetype": {"name": "Task"},
+		"status": {"name": "To Do", "statusCategory": {"key": "new"}}, "customfield_10014": "WEB-1"}}
+]}`
+
+func TestImportJiraCreatesHierarchyAndDependencies(t *testing.T) {
+	dir := t.TempDir()
+	database, err := db.Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	issues, err := jira.Parse([]byte(jiraEpicExport))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	var result *jiraImportResult
+	captureStdout(t, func() {
+		result, err = importJira(database, issues, "ses_import", false)
+	})
+	if err != nil {
+		t.Fatalf("importJira failed: %v", err)
+	}
+	if len(result.imported) != 3 || result.dependencies != 1 {
+		t.Fatalf("imported %v with %d dependencies, want 3 and 1", result.imported, result.dependencies)
+	}
+
+	epicID, storyID, taskID := result.imported["WEB-1"], result.imported["WEB-2"], result.imported["WEB-3"]
+	story, err := database.GetIssue(storyID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if story.ParentID != epicID {
+		t.Errorf("story parent = %q, want epic %q", story.ParentID, epicID)
+	}
+	if story.Status != models.StatusClosed || story.ClosedAt == nil {
+		t.Errorf("story status = %s closed_at = %v, want closed with a close time", story.Status, story.ClosedAt)
+	}
+	task, err := database.GetIssue(taskID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if task.ParentID != epicID {
+		t.Errorf("epic-linked task parent = %q, want %q", task.ParentID, epicID)
+	}
+	deps, err := database.GetDependencies(storyID)
+	if err != nil {
+		t.Fatalf("GetDependencies failed: %v", err)
+	}
+	if len(deps) != 1 || deps[0] != taskID {
+		t.Errorf("story depends on %v, want [%s]", deps, taskID)
+	}
+
+	// Importing the same export again skips every issue
+	captureStdout(t, func() {
+		result, err = importJira(database, issues, "ses_import", false)
+	})
+	if err != nil {
+		t.Fatalf("re-import failed: %v", err)
+	}
+	if len(result.imported) != 0 || len(result.skipped) != 3 {
+		t.Errorf("re-import imported %v, skipped %v; want everything skipped", result.imported, result.skipped)
+	}
+}
@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/marcus/td/internal/config"
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/output"
+	"github.com/marcus/td/internal/session"
+	"github.com/spf13/cobra"
+)
+
+var labelCmd = &cobra.Command{
+	Use:     "label",
+	Aliases: []string{"labels"},
+	Short:   "List and manage labels across the project",
+	Long: `List the labels in use with the number of issues carrying each, or
+manage them with a subcommand.
+
+Examples:
+  td labels
+  td label rename front-end frontend
+  td label color backend blue`,
+	GroupID: "workflow",
+	Args:    cobra.NoArgs,
+	RunE:    runLabelList,
+}
+
+var labelListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List labels in use with issue counts",
+	Args:    cobra.NoArgs,
+	RunE:    runLabelList,
+}
+
+// runLabelList prints every label on a non-deleted issue with its count.
+func runLabelList(cmd *cobra.Command, args []string) error {
+	baseDir := getBaseDir()
+
+	database, err := db.Open(baseDir)
+	if err != nil {
+		output.Error("%v", err)
+		return err
+	}
+	defer database.Close()
+
+	labels, err := database.ListAllLabels()
+	if err != nil {
+		output.Error("failed to list labels: %v", err)
+		return err
+	}
+
+	if jsonMode(cmd) {
+		return output.JSON(labels)
+	}
+
+	if len(labels) == 0 {
+		fmt.Println("No labels in use")
+		return nil
+	}
+	width := 0
+	for _, lc := range labels {
+		width = max(width, len(lc.Label))
+	}
+	for _, lc := range labels {
+		pad := width - len(lc.Label)
+		fmt.Printf("%s%*s  %d\n", output.FormatLabel(lc.Label), pad, "", lc.Count)
+	}
+	return nil
+}
+
+var labelRenameCmd = &cobra.Command{
+	Use:   "rename <old> <new>",
+	Short: "Rename a label on every issue, merging into an existing label",
+	Long: `Rename a label on every issue that carries it.
+
+If the new label already exists, the two are merged: issues that had both
+end up with the new label once.
+
+Examples:
+  td label rename front-end frontend`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		baseDir := getBaseDir()
+
+		database, err := db.Open(baseDir)
+		if err != nil {
+			output.Error("%v", err)
+			return err
+		}
+		defer database.Close()
+
+		sess, err := session.GetOrCreate(database)
+		if err != nil {
+			output.Error("%v", err)
+			return err
+		}
+
+		oldLabel, newLabel := args[0], args[1]
+		count, err := database.RenameLabel(oldLabel, newLabel, sess.ID)
+		if err != nil {
+			output.Error("failed to rename label: %v", err)
+			return err
+		}
+
+		if jsonMode(cmd) {
+			return output.JSON(map[string]interface{}{
+				"old":     oldLabel,
+				"new":     newLabel,
+				"updated": count,
+			})
+		}
+
+		noun := "issues"
+		if count == 1 {
+			noun = "issue"
+		}
+		fmt.Printf("RENAMED %s -> %s (%d %s updated)\n", oldLabel, newLabel, count, noun)
+		return nil
+	},
+}
+
+var labelColorCmd = &cobra.Command{
+	Use:   "color <label> [color]",
+	Short: "Show or set the color a label renders in",
+	Long: `Show or set the color a label renders in, in the CLI and the monitor.
+
+A color is a name (red, orange, yellow, green, teal, cyan, blue, purple,
+magenta, pink, brown, gray), a 256-color code (0-255) or a hex value
+(#rrggbb). Labels without a color get one derived from their name, so a
+label always looks the same. --clear returns a label to that default.
+
+Examples:
+  td label color backend          # show the current color
+  td label color backend blue
+  td label color urgent "#ff5f00"
+  td label color backend --clear`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		baseDir := getBaseDir()
+		label := args[0]
+		clearColor, _ := cmd.Flags().GetBool("clear")
+
+		if len(args) == 2 && clearColor {
+			err := fmt.Errorf("pass a color or --clear, not both")
+			output.Error("%v", err)
+			return err
+		}
+
+		if len(args) == 2 || clearColor {
+			color := ""
+			if !clearColor {
+				var err error
+				if color, err = output.NormalizeLabelColor(args[1]); err != nil {
+					output.Error("%v", err)
+					return err
+				}
+			}
+			if err := config.SetLabelColor(baseDir, label, color); err != nil {
+				output.Error("failed to save label color: %v", err)
+				return err
+			}
+			loadLabelColors()
+		}
+
+		colors, err := config.GetLabelColors(baseDir)
+		if err != nil {
+			output.Error("failed to read label colors: %v", err)
+			return err
+		}
+		_, explicit := colors[label]
+		color := output.LabelColor(label, colors)
+
+		if jsonMode(cmd) {
+			return output.JSON(map[string]interface{}{
+				"label":    label,
+				"color":    color,
+				"explicit": explicit,
+			})
+		}
+
+		source := "default"
+		if explicit {
+			source = "set"
+		}
+		switch {
+		case clearColor:
+			fmt.Printf("CLEARED %s -> %s (%s)\n", output.FormatLabel(label), color, source)
+		case len(args) == 2:
+			fmt.Printf("COLORED %s -> %s\n", output.FormatLabel(label), color)
+		default:
+			fmt.Printf("%s %s (%s)\n", output.FormatLabel(label), color, source)
+		}
+		return nil
+	},
+}
+
+// loadLabelColors hands the project's label colors to the output helpers.
+// A missing or unreadable config leaves every label on its default color.
+func loadLabelColors() {
+	colors, _ := config.GetLabelColors(getBaseDir())
+	output.SetLabelColors(colors)
+}
+
+func init() {
+	labelCmd.AddCommand(labelListCmd)
+	labelCmd.AddCommand(labelRenameCmd)
+	labelCmd.AddCommand(labelColorCmd)
+	labelColorCmd.Flags().Bool("clear", false, "Return the label to its default color")
+	rootCmd.AddCommand(labelCmd)
+}
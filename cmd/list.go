@@ -1,10 +1,14 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/marcus/td/internal/config"
 	"github.com/marcus/td/internal/db"
 	"github.com/marcus/td/internal/models"
 	"github.com/marcus/td/internal/output"
@@ -52,7 +56,19 @@ var listCmd = &cobra.Command{
 			queryStr = positionalQuery
 		}
 
+		if tree, _ := cmd.Flags().GetBool("tree"); tree {
+			if rootQuery, _ := cmd.Flags().GetString("query"); rootQuery != "" {
+				if queryStr != "" {
+					output.Error("cannot use --query with --filter or a positional query")
+					return fmt.Errorf("cannot use --query with --filter or a positional query")
+				}
+				queryStr = rootQuery
+			}
+			return runListTree(cmd, database, queryStr)
+		}
+
 		if queryStr != "" {
+			queryStr = withDefaultQuery(cmd, baseDir, queryStr)
 			// Use TDQ query engine
 			sess, _ := session.GetOrCreate(database)
 			sessionID := ""
@@ -64,10 +80,14 @@ var listCmd = &cobra.Command{
 			sortBy, _ := cmd.Flags().GetString("sort")
 			sortDesc, _ := cmd.Flags().GetBool("reverse")
 
+			identities, _ := session.IdentitySessionIDs(database, sess)
+
 			results, err := query.Execute(database, queryStr, sessionID, query.ExecuteOptions{
-				Limit:    limit,
-				SortBy:   sortBy,
-				SortDesc: sortDesc,
+				Limit:      limit,
+				SortBy:     sortBy,
+				SortDesc:   sortDesc,
+				Identities: identities,
+				Statuses:   customStatuses(),
 			})
 			if err != nil {
 				output.Error("Query error: %v", err)
@@ -120,8 +140,8 @@ var listCmd = &cobra.Command{
 							continue
 						}
 						status := models.NormalizeStatus(part)
-						if !models.IsValidStatus(status) {
-							output.Error("invalid status: %s (valid: open, in_progress, blocked, in_review, closed, all)", part)
+						if sm := workflowMachine(); !sm.HasStatus(status) {
+							output.Error("invalid status: %s (valid: %s, all)", part, joinStatuses(sm.Statuses()))
 							return fmt.Errorf("invalid status: %s", part)
 						}
 						opts.Status = append(opts.Status, status)
@@ -272,11 +292,26 @@ var listCmd = &cobra.Command{
 			opts.ExcludeDeferred = true
 		}
 
-		issues, err := database.ListIssues(opts)
+		// Narrow the flag filters to the default query, unless --all
+		defaultIDs, hasDefault, err := defaultQueryIDs(cmd, database, baseDir)
 		if err != nil {
-			output.Error("failed to list issues: %v", err)
+			output.Error("default-query: %v", err)
 			return err
 		}
+		if hasDefault {
+			opts.IDs = intersectIDs(opts.IDs, defaultIDs)
+		}
+
+		var issues []models.Issue
+		// An empty ID filter means no filter, so skip the lookup when the
+		// default query rules everything out
+		if !hasDefault || len(opts.IDs) > 0 {
+			issues, err = database.ListIssues(opts)
+			if err != nil {
+				output.Error("failed to list issues: %v", err)
+				return err
+			}
+		}
 
 		// Output format (supports --json, --long, --short, and --format)
 		format, _ := cmd.Flags().GetString("format")
@@ -601,34 +636,417 @@ var readyCmd = &cobra.Command{
 	},
 }
 
+// nextNothingClaimableExitCode is the exit status of `td next --claim` when
+// no open issue could be claimed, so scripts can tell "no work" from errors.
+const nextNothingClaimableExitCode = 2
+
 var nextCmd = &cobra.Command{
-	Use:     "next",
-	Short:   "Show highest-priority open issue",
+	Use:   "next",
+	Short: "Show highest-priority open issue",
+	Long: `Show the highest-priority open issue that has no open dependencies.
+Issues handed to this session (or its session name) with td reject
+--reassign come first.
+
+With --claim, atomically start the issue for the current session and print
+only its ID (or a JSON envelope with --json). Concurrent callers never claim
+the same issue. If nothing is claimable, nothing is printed and the command
+exits with status 2.
+
+A session may hold at most wip_limit in_progress issues (config.json,
+default 1; negative disables). At the limit, --claim refuses with an error
+naming the issues already held.
+
+With --dry-run, show the pick and why it ranks first (assignment, priority,
+rework, dependents) along with the runners-up, without changing anything.
+Combined with --claim it also reports whether the WIP limit would refuse.
+
+Examples:
+  td next                      # Show what to work on next
+  td next --claim              # Claim it and print the ID
+  td next --dry-run --json     # Explain the pick without claiming
+  td next --strategy unblock   # Prefer issues that unblock the most work
+  id=$(td next --claim) && echo "working on $id"`,
 	GroupID: "shortcuts",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		result, err := runListShortcut(db.ListIssuesOptions{
-			Status:             []models.Status{models.StatusOpen},
-			SortBy:             "priority",
-			Limit:              1,
-			ExcludeHasOpenDeps: true,
-		})
+		strategy, _ := cmd.Flags().GetString("strategy")
+		if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+			return runNextDryRun(cmd, strategy)
+		}
+		if claim, _ := cmd.Flags().GetBool("claim"); claim {
+			return runNextClaim(cmd, strategy)
+		}
+
+		database, err := db.Open(getBaseDir())
 		if err != nil {
+			output.Error("%v", err)
 			return err
 		}
+		defer database.Close()
 
-		if len(result.issues) == 0 {
+		candidates, err := nextCandidates(database, strategy)
+		if err != nil {
+			output.Error("%v", err)
+			return err
+		}
+		var mine []string
+		if sess, err := session.GetOrCreate(database); err == nil {
+			mine = callerSessionIDs(database, sess)
+			preferAssigned(candidates, mine)
+		}
+
+		if len(candidates) == 0 {
 			fmt.Println("No open issues")
 			return nil
 		}
 
-		issue := result.issues[0]
+		issue := candidates[0]
 		fmt.Println(output.FormatIssueShort(&issue))
+		if rework, _ := database.GetRejectedInProgressIssueIDs(); rework[issue.ID] {
+			fmt.Println("  Rework: rejected in review, see `td show " + issue.ID + "` for the reason")
+		}
+		if isAssignedTo(issue, mine) {
+			fmt.Println("  Reassigned to you on rejection")
+		}
 		fmt.Println()
 		fmt.Printf("Run `td start %s` to begin working on this issue.\n", issue.ID)
 		return nil
 	},
 }
 
+// runNextClaim claims the next open issue for the current session.
+func runNextClaim(cmd *cobra.Command, strategy string) error {
+	baseDir := getBaseDir()
+
+	database, err := db.Open(baseDir)
+	if err != nil {
+		output.Error("%v", err)
+		return err
+	}
+	defer database.Close()
+
+	sess, err := session.GetOrCreate(database)
+	if err != nil {
+		output.Error("%v", err)
+		return err
+	}
+
+	candidates, err := nextCandidates(database, strategy)
+	if err != nil {
+		output.Error("%v", err)
+		return err
+	}
+	preferAssigned(candidates, callerSessionIDs(database, sess))
+
+	limit, _ := config.GetWIPLimit(baseDir)
+	issue, err := database.ClaimNextIssue(candidates, sess.ID, limit)
+	var wipErr *db.WIPLimitError
+	if errors.As(err, &wipErr) {
+		output.Error("%v", err)
+		return err
+	}
+	if err != nil {
+		output.Error("failed to claim issue: %v", err)
+		return err
+	}
+	if issue == nil {
+		cmd.SilenceUsage = true
+		return &exitCodeError{code: nextNothingClaimableExitCode}
+	}
+
+	if err := database.RecordSessionAction(issue.ID, sess.ID, models.ActionSessionStarted); err != nil {
+		output.WarningErr("failed to record session history: %v", err)
+	}
+	database.AddLog(&models.Log{
+		IssueID:   issue.ID,
+		SessionID: sess.ID,
+		Message:   "Claimed via td next --claim",
+		Type:      models.LogTypeProgress,
+	})
+	_ = database.SetFocus(currentStateScope(baseDir, sess), issue.ID)
+
+	if jsonMode(cmd) {
+		return output.EmitIssue("claimed", issue, map[string]any{"session": sess.ID})
+	}
+	fmt.Println(issue.ID)
+	return nil
+}
+
+// nextDryRunRunnersUp is how many candidates after the pick a dry run lists.
+const nextDryRunRunnersUp = 3
+
+// nextRanking explains one candidate's place in the `td next` order.
+type nextRanking struct {
+	Rank       int          `json:"rank"`
+	Issue      models.Issue `json:"issue"`
+	Assigned   bool         `json:"assigned_to_you"`
+	Rework     bool         `json:"rework"`
+	Dependents int          `json:"dependents"`
+	Reasons    []string     `json:"reasons"`
+}
+
+// runNextDryRun shows what `td next` would pick and why, changing nothing.
+func runNextDryRun(cmd *cobra.Command, strategy string) error {
+	baseDir := getBaseDir()
+
+	database, err := db.Open(baseDir)
+	if err != nil {
+		output.Error("%v", err)
+		return err
+	}
+	defer database.Close()
+
+	candidates, err := nextCandidates(database, strategy)
+	if err != nil {
+		output.Error("%v", err)
+		return err
+	}
+	var mine []string
+	sess, sessErr := session.GetOrCreate(database)
+	if sessErr == nil {
+		mine = callerSessionIDs(database, sess)
+		preferAssigned(candidates, mine)
+	}
+
+	rework, err := database.GetRejectedInProgressIssueIDs()
+	if err != nil {
+		output.Error("failed to load rework issues: %v", err)
+		return err
+	}
+	dependents, err := database.GetOpenDependentCounts()
+	if err != nil {
+		output.Error("failed to load dependencies: %v", err)
+		return err
+	}
+
+	shown := min(len(candidates), 1+nextDryRunRunnersUp)
+	rankings := make([]nextRanking, 0, shown)
+	for i, issue := range candidates[:shown] {
+		r := nextRanking{
+			Rank:       i + 1,
+			Issue:      issue,
+			Assigned:   isAssignedTo(issue, mine),
+			Rework:     rework[issue.ID],
+			Dependents: dependents[issue.ID],
+		}
+		r.Reasons = nextReasons(r, strategy)
+		rankings = append(rankings, r)
+	}
+
+	// Only a claim is subject to the WIP limit
+	refusal := ""
+	if claim, _ := cmd.Flags().GetBool("claim"); claim && sessErr == nil {
+		if err := checkWIPLimit(database, baseDir, sess.ID); err != nil {
+			refusal = err.Error()
+		}
+	}
+
+	if jsonMode(cmd) {
+		result := map[string]any{
+			"dry_run":    true,
+			"strategy":   strategy,
+			"candidates": len(candidates),
+			"pick":       nil,
+			"runners_up": []nextRanking{},
+		}
+		if len(rankings) > 0 {
+			result["pick"] = rankings[0]
+			result["runners_up"] = rankings[1:]
+		}
+		if refusal != "" {
+			result["claim_refused"] = refusal
+		}
+		return output.JSON(result)
+	}
+
+	if len(rankings) == 0 {
+		fmt.Println("No open issues")
+		return nil
+	}
+	pick := rankings[0]
+	fmt.Printf("Dry run: td next would pick (strategy %s, %d candidates)\n", strategy, len(candidates))
+	fmt.Println(output.FormatIssueShort(&pick.Issue))
+	for _, reason := range pick.Reasons {
+		fmt.Printf("  - %s\n", reason)
+	}
+	if len(rankings) > 1 {
+		fmt.Println()
+		fmt.Println("Runners-up:")
+		for _, r := range rankings[1:] {
+			fmt.Printf("  %d. %s\n", r.Rank, output.FormatIssueShort(&r.Issue))
+		}
+	}
+	if refusal != "" {
+		fmt.Println()
+		fmt.Printf("--claim would be refused: %s\n", refusal)
+	}
+	fmt.Println()
+	fmt.Println("Nothing was changed.")
+	return nil
+}
+
+// nextReasons lists why a candidate sits where it does in the `td next`
+// order, most decisive first.
+func nextReasons(r nextRanking, strategy string) []string {
+	var reasons []string
+	if r.Assigned {
+		reasons = append(reasons, "handed to you (td assign or td reject --reassign), which goes ahead of everything else")
+	}
+	reasons = append(reasons, fmt.Sprintf("priority %s", r.Issue.Priority))
+	if r.Rework {
+		reasons = append(reasons, "rejected in review; rework goes first within its priority")
+	}
+	switch {
+	case strategy == nextStrategyUnblock:
+		reasons = append(reasons, fmt.Sprintf("unblock score %d: issues depending on it rank it within its priority", r.Dependents))
+	case r.Dependents > 0:
+		reasons = append(reasons, fmt.Sprintf("%d issue(s) depend on it (--strategy unblock would rank by this)", r.Dependents))
+	}
+	reasons = append(reasons, "ready: open with no open dependencies")
+	return reasons
+}
+
+// checkWIPLimit reports whether sessionID is at the configured WIP limit,
+// for previewing a claim; ClaimNextIssue enforces it.
+func checkWIPLimit(database *db.DB, baseDir, sessionID string) error {
+	limit, _ := config.GetWIPLimit(baseDir)
+	return database.CheckWIPLimit(sessionID, limit)
+}
+
+// Orderings for `td next` candidates
+const (
+	nextStrategyPriority = "priority"
+	nextStrategyUnblock  = "unblock"
+)
+
+// nextCandidates returns open issues without open dependencies in the order
+// `td next` offers them for the given strategy. Within a priority, issues
+// rejected in review come first so rework isn't left behind.
+func nextCandidates(database *db.DB, strategy string) ([]models.Issue, error) {
+	if strategy != nextStrategyPriority && strategy != nextStrategyUnblock {
+		return nil, fmt.Errorf("invalid strategy %q (use %s or %s)", strategy, nextStrategyPriority, nextStrategyUnblock)
+	}
+
+	issues, err := database.ListIssues(db.ListIssuesOptions{
+		Status:             []models.Status{models.StatusOpen},
+		SortBy:             "priority",
+		ExcludeHasOpenDeps: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issues: %w", err)
+	}
+	if strategy == nextStrategyUnblock {
+		dependents, err := database.GetOpenDependentCounts()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load dependencies: %w", err)
+		}
+		rankByDependents(issues, dependents)
+	}
+
+	rework, err := database.GetRejectedInProgressIssueIDs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load rework issues: %w", err)
+	}
+	preferRework(issues, rework)
+	return issues, nil
+}
+
+// preferRework moves rejected issues ahead of the others within each priority,
+// keeping the existing order otherwise.
+func preferRework(issues []models.Issue, rework map[string]bool) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Priority != issues[j].Priority {
+			return issues[i].Priority < issues[j].Priority
+		}
+		return rework[issues[i].ID] && !rework[issues[j].ID]
+	})
+}
+
+// callerSessionIDs returns sess's ID along with the other sessions sharing its
+// identity, so work handed to a session name follows the agent.
+func callerSessionIDs(database *db.DB, sess *session.Session) []string {
+	others, _ := session.IdentitySessionIDs(database, sess)
+	return append([]string{sess.ID}, others...)
+}
+
+// preferAssigned moves open issues handed to one of sessionIDs by
+// td reject --reassign or td assign ahead of all others, keeping the existing order
+// otherwise.
+func preferAssigned(issues []models.Issue, sessionIDs []string) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		return isAssignedTo(issues[i], sessionIDs) && !isAssignedTo(issues[j], sessionIDs)
+	})
+}
+
+func isAssignedTo(issue models.Issue, sessionIDs []string) bool {
+	return issue.ImplementerSession != "" && slices.Contains(sessionIDs, issue.ImplementerSession)
+}
+
+// runListTree prints the issue hierarchy for `td list --tree`. Roots are the
+// issues matching rootQuery, or the epics when no query is given.
+func runListTree(cmd *cobra.Command, database *db.DB, rootQuery string) error {
+	maxDepth, _ := cmd.Flags().GetInt("depth")
+	showAll, _ := cmd.Flags().GetBool("all")
+
+	var roots []models.Issue
+	var err error
+	if rootQuery != "" {
+		sess, _ := session.GetOrCreate(database)
+		sessionID := ""
+		if sess != nil {
+			sessionID = sess.ID
+		}
+		identities, _ := session.IdentitySessionIDs(database, sess)
+		roots, err = query.Execute(database, rootQuery, sessionID, query.ExecuteOptions{Identities: identities, Statuses: customStatuses()})
+		if err != nil {
+			output.Error("Query error: %v", err)
+			return err
+		}
+	} else {
+		opts := db.ListIssuesOptions{Type: []models.Type{models.TypeEpic}}
+		if !showAll {
+			opts.Status = []models.Status{
+				models.StatusOpen,
+				models.StatusInProgress,
+				models.StatusBlocked,
+				models.StatusInReview,
+			}
+		}
+		roots, err = database.ListIssues(opts)
+		if err != nil {
+			output.Error("%v", err)
+			return err
+		}
+	}
+
+	nodes, err := buildListTree(database, roots, maxDepth)
+	if err != nil {
+		output.Error("%v", err)
+		return err
+	}
+
+	if jsonMode(cmd) {
+		return output.JSON(nodes)
+	}
+	if len(nodes) == 0 {
+		fmt.Println("No issues found")
+		return nil
+	}
+	renderListTree(nodes, maxDepth)
+	return nil
+}
+
+// rankByDependents reorders priority-sorted issues so that, within the same
+// priority, issues that more open issues depend on come first. dependents is
+// the unblock score from GetOpenDependentCounts.
+func rankByDependents(issues []models.Issue, dependents map[string]int) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Priority != issues[j].Priority {
+			return issues[i].Priority < issues[j].Priority
+		}
+		return dependents[issues[i].ID] > dependents[issues[j].ID]
+	})
+}
+
 var deletedCmd = &cobra.Command{
 	Use:     "deleted",
 	Short:   "Show soft-deleted issues",
@@ -882,6 +1300,10 @@ func init() {
 	rootCmd.AddCommand(nextCmd)
 	rootCmd.AddCommand(deletedCmd)
 
+	nextCmd.Flags().String("strategy", nextStrategyPriority, "Candidate ordering: priority, or unblock (prefer issues more open issues depend on within a priority)")
+	nextCmd.Flags().Bool("claim", false, "Atomically start the next issue and print its ID (exit 2 if none)")
+	nextCmd.Flags().Bool("dry-run", false, "Show the pick and why it ranks first without claiming or changing anything")
+
 	listCmd.Flags().StringArrayP("id", "i", nil, "Filter by issue IDs")
 	listCmd.Flags().StringArrayP("status", "s", nil, "Status filter")
 	listCmd.Flags().StringArrayP("type", "t", nil, "Type filter")
@@ -906,7 +1328,7 @@ func init() {
 	listCmd.Flags().IntP("limit", "n", 50, "Limit results")
 	listCmd.Flags().Bool("long", false, "Detailed output")
 	listCmd.Flags().Bool("short", false, "Compact output (default)")
-	listCmd.Flags().BoolP("all", "a", false, "Include closed and deferred issues")
+	listCmd.Flags().BoolP("all", "a", false, "Include closed and deferred issues, and ignore default-query")
 
 	listCmd.Flags().Bool("deferred", false, "Show only currently deferred tasks")
 	listCmd.Flags().Bool("overdue", false, "Show tasks past their due date")
@@ -916,4 +1338,7 @@ func init() {
 	listCmd.Flags().String("format", "", "Output format (short, long, json)")
 	listCmd.Flags().Bool("no-pager", false, "Disable paging (no-op, td list does not page)")
 	listCmd.Flags().StringP("filter", "f", "", "TDQ query expression (e.g., 'status=open AND type=bug')")
+	listCmd.Flags().Bool("tree", false, "Show epics (or --query matches) with their descendants indented")
+	listCmd.Flags().String("query", "", "With --tree: TDQ expression selecting the root issues")
+	listCmd.Flags().Int("depth", 0, "With --tree: maximum depth below each root (0 = unlimited)")
 }
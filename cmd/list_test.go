@@ -1,10 +1,13 @@
 package cmd
 
 import (
+	"encoding/json"
+	"errors"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/marcus/td/internal/config"
 	"github.com/marcus/td/internal/db"
 	"github.com/marcus/td/internal/models"
 	"github.com/marcus/td/internal/session"
@@ -787,3 +790,262 @@ func TestResolveListIssueFilterIDDotUsesActiveWorkSessionEpic(t *testing.T) {
 		t.Fatalf("resolveListIssueFilterID returned %q, want epic root %q", got, epic.ID)
 	}
 }
+
+func TestNextCandidatesUnblockStrategy(t *testing.T) {
+	// Run with both creation orders so the result can't depend on how the
+	// database breaks priority ties.
+	for _, blockerFirst := range []bool{true, false} {
+		dir := t.TempDir()
+		database, err := db.Initialize(dir)
+		if err != nil {
+			t.Fatalf("Initialize failed: %v", err)
+		}
+
+		blocker := &models.Issue{Title: "Blocker", Status: models.StatusOpen, Priority: models.PriorityP1}
+		leaf := &models.Issue{Title: "Leaf", Status: models.StatusOpen, Priority: models.PriorityP1}
+		dependent1 := &models.Issue{Title: "Dependent 1", Status: models.StatusOpen, Priority: models.PriorityP1}
+		dependent2 := &models.Issue{Title: "Dependent 2", Status: models.StatusOpen, Priority: models.PriorityP3}
+		urgent := &models.Issue{Title: "Urgent leaf", Status: models.StatusOpen, Priority: models.PriorityP0}
+
+		order := []*models.Issue{blocker, leaf}
+		if !blockerFirst {
+			order = []*models.Issue{leaf, blocker}
+		}
+		order = append(order, dependent1, dependent2, urgent)
+		for _, issue := range order {
+			if err := database.CreateIssue(issue); err != nil {
+				t.Fatalf("CreateIssue failed: %v", err)
+			}
+		}
+		database.AddDependency(dependent1.ID, blocker.ID, "depends_on")
+		database.AddDependency(dependent2.ID, blocker.ID, "depends_on")
+
+		// More dependents on the leaf, but none of them still need it
+		for _, status := range []models.Status{models.StatusClosed, models.StatusClosed, models.StatusOpen} {
+			done := &models.Issue{Title: "Done dependent", Status: status, Priority: models.PriorityP2}
+			if err := database.CreateIssue(done); err != nil {
+				t.Fatalf("CreateIssue failed: %v", err)
+			}
+			database.AddDependency(done.ID, leaf.ID, "depends_on")
+			if status == models.StatusOpen {
+				if err := database.DeleteIssue(done.ID); err != nil {
+					t.Fatalf("DeleteIssue failed: %v", err)
+				}
+			}
+		}
+
+		got, err := nextCandidates(database, nextStrategyUnblock)
+		if err != nil {
+			t.Fatalf("nextCandidates failed: %v", err)
+		}
+		var ids []string
+		for _, issue := range got {
+			ids = append(ids, issue.ID)
+		}
+		// Priority still dominates; the blocker wins the P1 tie, as closed
+		// and deleted dependents don't count; dependents with open deps are
+		// not candidates at all.
+		want := []string{urgent.ID, blocker.ID, leaf.ID}
+		if strings.Join(ids, ",") != strings.Join(want, ",") {
+			t.Errorf("blockerFirst=%v: got %v, want %v", blockerFirst, ids, want)
+		}
+
+		database.Close()
+	}
+}
+
+func TestNextCandidatesRejectsUnknownStrategy(t *testing.T) {
+	database, err := db.Initialize(t.TempDir())
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	if _, err := nextCandidates(database, "random"); err == nil {
+		t.Error("expected error for unknown strategy")
+	}
+}
+
+// TestCheckWIPLimit covers the per-session WIP limit enforced by
+// `td next --claim`: refusal at the limit, claiming again after releasing an
+// issue, and the config override.
+func TestCheckWIPLimit(t *testing.T) {
+	dir := t.TempDir()
+	database, err := db.Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	const sessionID = "ses_wip"
+	for _, title := range []string{"First task", "Second task", "Third task"} {
+		if err := database.CreateIssue(&models.Issue{Title: title, Status: models.StatusOpen}); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	claim := func() *models.Issue {
+		t.Helper()
+		candidates, err := nextCandidates(database, nextStrategyPriority)
+		if err != nil {
+			t.Fatalf("nextCandidates failed: %v", err)
+		}
+		issue, err := database.ClaimNextIssue(candidates, sessionID, 0)
+		if err != nil || issue == nil {
+			t.Fatalf("ClaimNextIssue = %v, %v", issue, err)
+		}
+		return issue
+	}
+
+	if err := checkWIPLimit(database, dir, sessionID); err != nil {
+		t.Fatalf("checkWIPLimit with nothing claimed: %v", err)
+	}
+	first := claim()
+
+	// At the default limit of 1 the next claim is refused
+	err = checkWIPLimit(database, dir, sessionID)
+	var wipErr *db.WIPLimitError
+	if !errors.As(err, &wipErr) {
+		t.Fatalf("checkWIPLimit at limit = %v, want WIPLimitError", err)
+	}
+	if wipErr.Limit != config.DefaultWIPLimit || len(wipErr.Held) != 1 || wipErr.Held[0] != first.ID {
+		t.Errorf("WIPLimitError = %+v, want limit %d holding %s", wipErr, config.DefaultWIPLimit, first.ID)
+	}
+	if !strings.Contains(err.Error(), first.ID) {
+		t.Errorf("error %q should name the held issue", err)
+	}
+
+	// Other sessions are unaffected
+	if err := checkWIPLimit(database, dir, "ses_other"); err != nil {
+		t.Errorf("checkWIPLimit for another session: %v", err)
+	}
+
+	// Releasing the issue (td unstart) frees the slot
+	first.Status = models.StatusOpen
+	first.ImplementerSession = ""
+	if err := database.UpdateIssueLogged(first, sessionID, models.ActionReopen); err != nil {
+		t.Fatalf("UpdateIssueLogged failed: %v", err)
+	}
+	if err := checkWIPLimit(database, dir, sessionID); err != nil {
+		t.Fatalf("checkWIPLimit after release: %v", err)
+	}
+	claim()
+
+	// A higher configured limit allows a second claim; a negative one disables it
+	if err := config.Save(dir, &models.Config{WIPLimit: 2}); err != nil {
+		t.Fatalf("config.Save failed: %v", err)
+	}
+	if err := checkWIPLimit(database, dir, sessionID); err != nil {
+		t.Fatalf("checkWIPLimit with wip_limit=2 holding 1: %v", err)
+	}
+	claim()
+	if err := checkWIPLimit(database, dir, sessionID); !errors.As(err, &wipErr) {
+		t.Fatalf("checkWIPLimit with wip_limit=2 holding 2 = %v, want WIPLimitError", err)
+	}
+	if err := config.Save(dir, &models.Config{WIPLimit: -1}); err != nil {
+		t.Fatalf("config.Save failed: %v", err)
+	}
+	if err := checkWIPLimit(database, dir, sessionID); err != nil {
+		t.Errorf("checkWIPLimit with limit disabled: %v", err)
+	}
+}
+
+// TestNextDryRunChangesNothing checks that `td next --dry-run` explains the
+// pick without starting it, even alongside --claim.
+func TestNextDryRunChangesNothing(t *testing.T) {
+	saveAndRestoreGlobals(t)
+	setJSONFlag(t, false)
+	dir := t.TempDir()
+	baseDirOverride = &dir
+
+	database, err := db.Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	blocker := &models.Issue{Title: "Blocker", Status: models.StatusOpen, Priority: models.PriorityP1}
+	leaf := &models.Issue{Title: "Leaf", Status: models.StatusOpen, Priority: models.PriorityP1}
+	dependent := &models.Issue{Title: "Dependent", Status: models.StatusOpen, Priority: models.PriorityP2}
+	for _, issue := range []*models.Issue{leaf, blocker, dependent} {
+		if err := database.CreateIssue(issue); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	database.AddDependency(dependent.ID, blocker.ID, "depends_on")
+
+	snapshot := func() map[string]models.Status {
+		issues, err := database.ListIssues(db.ListIssuesOptions{})
+		if err != nil {
+			t.Fatalf("ListIssues failed: %v", err)
+		}
+		statuses := make(map[string]models.Status)
+		for _, issue := range issues {
+			statuses[issue.ID] = issue.Status
+		}
+		return statuses
+	}
+	before := snapshot()
+
+	out := runWithFlags(t, nextCmd, nil, map[string]string{"dry-run": "true", "strategy": nextStrategyUnblock})
+	if !strings.Contains(out, blocker.ID) || !strings.Contains(out, "unblock score 1") {
+		t.Errorf("dry run should pick %s for its dependent, got:\n%s", blocker.ID, out)
+	}
+	runWithFlags(t, nextCmd, nil, map[string]string{"dry-run": "true", "claim": "true"})
+
+	setJSONFlag(t, true)
+	out = runWithFlags(t, nextCmd, nil, map[string]string{"dry-run": "true", "strategy": nextStrategyUnblock})
+	var result struct {
+		DryRun     bool          `json:"dry_run"`
+		Candidates int           `json:"candidates"`
+		Pick       nextRanking   `json:"pick"`
+		RunnersUp  []nextRanking `json:"runners_up"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	if !result.DryRun || result.Candidates != 2 || result.Pick.Issue.ID != blocker.ID || result.Pick.Dependents != 1 {
+		t.Errorf("JSON result = %+v, want %s picked with 1 dependent of 2 candidates", result, blocker.ID)
+	}
+	if len(result.RunnersUp) != 1 || result.RunnersUp[0].Issue.ID != leaf.ID {
+		t.Errorf("runners_up = %+v, want only %s", result.RunnersUp, leaf.ID)
+	}
+
+	after := snapshot()
+	for id, status := range before {
+		if after[id] != status {
+			t.Errorf("%s status changed from %s to %s", id, status, after[id])
+		}
+	}
+}
+
+// TestListOutputPlainWhenNotTTY verifies that `td list` written to a pipe
+// carries no ANSI escape codes, in both table and --json output.
+func TestListOutputPlainWhenNotTTY(t *testing.T) {
+	saveAndRestoreGlobals(t)
+
+	dir := t.TempDir()
+	baseDir := dir
+	baseDirOverride = &baseDir
+
+	database, err := db.Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+	database.CreateIssue(&models.Issue{Title: "Colorful", Status: models.StatusBlocked, Priority: models.PriorityP0})
+
+	for _, asJSON := range []bool{false, true} {
+		setJSONFlag(t, asJSON)
+		out := captureStdout(t, func() {
+			if err := listCmd.RunE(listCmd, nil); err != nil {
+				t.Fatalf("listCmd.RunE failed: %v", err)
+			}
+		})
+		if !strings.Contains(out, "Colorful") {
+			t.Fatalf("json=%v: issue missing from output %q", asJSON, out)
+		}
+		if strings.Contains(out, "\x1b[") {
+			t.Errorf("json=%v: escape codes leaked into output %q", asJSON, out)
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"regexp"
 
+	"github.com/marcus/td/internal/config"
 	"github.com/marcus/td/internal/db"
 	"github.com/marcus/td/internal/models"
 	"github.com/marcus/td/internal/output"
@@ -19,6 +20,24 @@ import (
 // issueIDPattern matches valid issue IDs like "td-a1b2c3" or "td-a1b2c3d4"
 var issueIDPattern = regexp.MustCompile(`^td-[0-9a-f]{6,8}$`)
 
+// issueIDSuffixPattern matches what follows a configured id_prefix: a hex
+// suffix or a sequence number.
+var issueIDSuffixPattern = regexp.MustCompile(`^[0-9a-f]{3,8}$`)
+
+// isIssueIDArg reports whether a positional argument is an issue ID rather
+// than a message: either the default td- form or the project's id_prefix.
+func isIssueIDArg(baseDir, arg string) bool {
+	if issueIDPattern.MatchString(arg) {
+		return true
+	}
+	prefix, _, err := config.GetIDFormat(baseDir)
+	if err != nil {
+		return false
+	}
+	suffix, ok := strings.CutPrefix(arg, prefix)
+	return ok && issueIDSuffixPattern.MatchString(suffix)
+}
+
 var logCmd = &cobra.Command{
 	Use:   "log [issue-id] <message>",
 	Short: "Append a log entry to the current issue",
@@ -28,6 +47,8 @@ Syntax:
   td log <message>              # Log to focused issue
   td log <issue-id> <message>   # Log to specific issue
   td log --issue <id> <message> # Log to specific issue (flag syntax)
+  td log --follow               # Print new entries across all issues as they appear
+  td log -f --type blocker      # Follow only blockers (--issue narrows to one issue)
 
 Supports stdin input for multi-line messages or piped input:
   echo "message" | td log
@@ -39,6 +60,10 @@ Supports stdin input for multi-line messages or piped input:
 	GroupID: "workflow",
 	Args:    cobra.MaximumNArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if follow, _ := cmd.Flags().GetBool("follow"); follow {
+			return runLogFollow(cmd, args)
+		}
+
 		baseDir := getBaseDir()
 		isJSON := jsonMode(cmd)
 
@@ -68,10 +93,10 @@ Supports stdin input for multi-line messages or piped input:
 
 		if len(args) == 2 {
 			// Two args: detect which is issue ID regardless of order
-			if issueIDPattern.MatchString(args[0]) {
+			if isIssueIDArg(baseDir, args[0]) {
 				issueID = args[0]
 				message = args[1]
-			} else if issueIDPattern.MatchString(args[1]) {
+			} else if isIssueIDArg(baseDir, args[1]) {
 				issueID = args[1]
 				message = args[0]
 			} else {
@@ -82,7 +107,7 @@ Supports stdin input for multi-line messages or piped input:
 		} else if len(args) == 1 {
 			// One arg: check if it's an issue ID or message
 			// Issue IDs match pattern "td-[8 hex chars]", otherwise it's a message
-			if issueIDPattern.MatchString(args[0]) {
+			if isIssueIDArg(baseDir, args[0]) {
 				// It's an issue ID, get message from stdin
 				issueID = args[0]
 				// Check if stdin has data
@@ -210,4 +235,6 @@ func init() {
 	logCmd.Flags().Bool("hypothesis", false, "Mark as hypothesis")
 	logCmd.Flags().Bool("tried", false, "Mark as attempted approach")
 	logCmd.Flags().Bool("result", false, "Mark as result")
+	logCmd.Flags().BoolP("follow", "f", false, "Print recent entries across all issues, then new ones as they appear (Ctrl-C to stop)")
+	logCmd.Flags().IntP("lines", "n", 10, "With --follow, number of recent entries to show first (0 for only new ones)")
 }
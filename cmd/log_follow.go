@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/models"
+	"github.com/marcus/td/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// logFollowInterval is how often td log --follow polls for new entries.
+const logFollowInterval = 1 * time.Second
+
+// logFollower tracks the newest log td log --follow has seen, so each poll
+// returns only entries added since.
+type logFollower struct {
+	database *db.DB
+	filter   db.LogFilter
+	seq      int64
+}
+
+// start returns the newest lines matching logs, oldest first, and positions
+// the follower after the newest log. With lines <= 0 it returns nothing and
+// only later entries are followed.
+func (f *logFollower) start(lines int) ([]models.Log, error) {
+	if lines <= 0 {
+		// Skip history: start after the newest log of any kind
+		_, seq, err := f.database.GetLogsSince(0, db.LogFilter{}, 1)
+		f.seq = seq
+		return nil, err
+	}
+	logs, seq, err := f.database.GetLogsSince(0, f.filter, lines)
+	f.seq = seq
+	return logs, err
+}
+
+// poll returns the matching logs added since the previous call.
+func (f *logFollower) poll() ([]models.Log, error) {
+	logs, seq, err := f.database.GetLogsSince(f.seq, f.filter, 0)
+	if err != nil {
+		return nil, err
+	}
+	f.seq = seq
+	return logs, nil
+}
+
+// runLogFollow implements td log --follow: it prints recent log entries
+// across all issues, then new ones as they are added, until interrupted.
+func runLogFollow(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		err := fmt.Errorf("--follow takes no arguments; filter with --issue and --type")
+		output.Error("%v", err)
+		return err
+	}
+
+	filter := db.LogFilter{}
+	filter.IssueID, _ = cmd.Flags().GetString("issue")
+	if filter.IssueID == "" {
+		filter.IssueID, _ = cmd.Flags().GetString("task")
+	}
+	if typeStr, _ := cmd.Flags().GetString("type"); typeStr != "" {
+		filter.Type = models.LogType(typeStr)
+	}
+	lines, _ := cmd.Flags().GetInt("lines")
+
+	database, err := db.Open(getBaseDir())
+	if err != nil {
+		output.Error("%v", err)
+		return err
+	}
+	defer database.Close()
+
+	if filter.IssueID != "" {
+		if filter.IssueID, err = database.ResolveIssueID(filter.IssueID); err != nil {
+			output.Error("%v", err)
+			return err
+		}
+	}
+
+	printLog := printFollowedLog
+	if jsonMode(cmd) {
+		enc := json.NewEncoder(os.Stdout)
+		printLog = func(l models.Log) { _ = enc.Encode(l) }
+	}
+
+	f := &logFollower{database: database, filter: filter}
+	initial, err := f.start(lines)
+	if err != nil {
+		output.Error("query logs: %v", err)
+		return err
+	}
+	for _, l := range initial {
+		printLog(l)
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+
+	ticker := time.NewTicker(logFollowInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-sigCh:
+			fmt.Println() // clean line after ^C
+			return nil
+		case <-ticker.C:
+			logs, err := f.poll()
+			if err != nil {
+				slog.Debug("log follow: poll", "err", err)
+				continue
+			}
+			for _, l := range logs {
+				printLog(l)
+			}
+		}
+	}
+}
+
+// printFollowedLog prints one log line: time, issue, type when not progress,
+// and the message.
+func printFollowedLog(l models.Log) {
+	ts := dimStyle.Render(l.Timestamp.Local().Format("15:04:05"))
+	typeIndicator := ""
+	if l.Type != models.LogTypeProgress {
+		typeIndicator = fmt.Sprintf(" [%s]", l.Type)
+	}
+	issue := l.IssueID
+	if issue == "" {
+		issue = "-"
+	}
+	fmt.Printf("%s %s%s %s\n", ts, issue, typeIndicator, l.Message)
+}
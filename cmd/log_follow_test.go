@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/models"
+)
+
+func TestLogFollowerEmitsOnlyNewRows(t *testing.T) {
+	dir := t.TempDir()
+	database, err := db.Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	addLog := func(issueID, msg string, typ models.LogType) {
+		t.Helper()
+		if err := database.AddLog(&models.Log{IssueID: issueID, SessionID: "ses_test", Message: msg, Type: typ}); err != nil {
+			t.Fatalf("AddLog failed: %v", err)
+		}
+	}
+	messages := func(logs []models.Log) []string {
+		var out []string
+		for _, l := range logs {
+			out = append(out, l.Message)
+		}
+		return out
+	}
+
+	addLog("td-aaa111", "first", models.LogTypeProgress)
+	addLog("td-bbb222", "second", models.LogTypeBlocker)
+
+	all := &logFollower{database: database}
+	initial, err := all.start(1)
+	if err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+	if got := messages(initial); len(got) != 1 || got[0] != "second" {
+		t.Fatalf("start(1) = %v, want [second]", got)
+	}
+	blockers := &logFollower{database: database, filter: db.LogFilter{Type: models.LogTypeBlocker}}
+	if initial, _ := blockers.start(0); len(initial) != 0 {
+		t.Fatalf("start(0) = %v, want nothing", messages(initial))
+	}
+
+	if logs, _ := all.poll(); len(logs) != 0 {
+		t.Fatalf("poll with nothing new = %v", messages(logs))
+	}
+
+	addLog("td-aaa111", "third", models.LogTypeProgress)
+	addLog("td-bbb222", "fourth", models.LogTypeBlocker)
+
+	logs, err := all.poll()
+	if err != nil {
+		t.Fatalf("poll failed: %v", err)
+	}
+	if got := messages(logs); len(got) != 2 || got[0] != "third" || got[1] != "fourth" {
+		t.Fatalf("poll = %v, want [third fourth]", got)
+	}
+	if logs, _ := all.poll(); len(logs) != 0 {
+		t.Fatalf("second poll re-emitted %v", messages(logs))
+	}
+
+	// The filtered follower skipped the blocker logged before it started
+	logs, _ = blockers.poll()
+	if got := messages(logs); len(got) != 1 || got[0] != "fourth" {
+		t.Fatalf("blocker poll = %v, want [fourth]", got)
+	}
+}
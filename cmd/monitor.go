@@ -62,6 +62,7 @@ Mouse support:
 		}
 
 		model := monitor.NewModel(database, sess.ID, interval, versionStr, baseDir)
+		model.UndoFunc = undoLastAction
 
 		// Enable periodic auto-sync in monitor if authenticated and linked
 		syncInterval := time.Duration(0)
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/models"
+	"github.com/marcus/td/internal/output"
+	"github.com/marcus/td/internal/session"
+	"github.com/spf13/cobra"
+)
+
+var prioritySortCmd = &cobra.Command{
+	Use:   "priority-sort <board>",
+	Short: "Reassign priorities from a board's order",
+	Long: `Renumber priorities for the issues ranked on a board, top to bottom.
+
+Only issues with an explicit position on the board (td board move, or a drag
+in the monitor) are touched; unpositioned and closed issues keep their
+priority, and positions on other boards are never read. The ranked issues are
+split into equal, contiguous bands from --highest to --lowest, so the order is
+preserved: with 6 issues and P1..P3, the top two become P1, the next two P2
+and the last two P3.
+
+All changes are applied in one transaction and logged, so td undo can revert
+them one issue at a time.
+
+Examples:
+  td priority-sort sprint                     # P1..P3 from board order
+  td priority-sort sprint --highest P0        # top of the board becomes P0
+  td priority-sort sprint --dry-run           # show changes without applying`,
+	GroupID: "workflow",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		baseDir := getBaseDir()
+
+		highestStr, _ := cmd.Flags().GetString("highest")
+		lowestStr, _ := cmd.Flags().GetString("lowest")
+		highest := models.NormalizePriority(highestStr)
+		lowest := models.NormalizePriority(lowestStr)
+		if !models.IsValidPriority(highest) || !models.IsValidPriority(lowest) {
+			output.Error("invalid priority range %s..%s (use P0-P4)", highestStr, lowestStr)
+			return fmt.Errorf("invalid priority range")
+		}
+		if highest > lowest {
+			output.Error("--highest %s is lower than --lowest %s", highest, lowest)
+			return fmt.Errorf("invalid priority range")
+		}
+
+		database, err := db.Open(baseDir)
+		if err != nil {
+			output.Error("%v", err)
+			return err
+		}
+		defer database.Close()
+
+		board, err := database.ResolveBoardRef(args[0])
+		if err != nil {
+			output.Error("%v", err)
+			return err
+		}
+
+		sess, err := session.GetOrCreate(database)
+		if err != nil {
+			output.Error("%v", err)
+			return err
+		}
+
+		views, err := boardIssues(database, board, sess.ID, defaultBoardStatuses)
+		if err != nil {
+			output.Error("%v", err)
+			return err
+		}
+		changes := boardOrderPriorityChanges(views, highest, lowest)
+
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		if !dryRun {
+			changes, err = database.SetBoardPrioritiesLogged(board.ID, changes, sess.ID)
+			if err != nil {
+				output.Error("failed to reprioritize %s: %v", board.Name, err)
+				return err
+			}
+		} else {
+			pending := changes[:0]
+			for _, c := range changes {
+				if c.From != c.To {
+					pending = append(pending, c)
+				}
+			}
+			changes = pending
+		}
+
+		if jsonMode(cmd) {
+			if changes == nil {
+				changes = []db.PriorityChange{}
+			}
+			return output.EmitResult("priority_sort", map[string]any{
+				"board":   board.Name,
+				"dry_run": dryRun,
+				"changes": changes,
+			})
+		}
+
+		if len(changes) == 0 {
+			fmt.Printf("Priorities on %s already follow board order\n", board.Name)
+			return nil
+		}
+		verb := "REPRIORITIZED"
+		if dryRun {
+			verb = "WOULD REPRIORITIZE"
+		}
+		fmt.Printf("%s %d issue(s) on %s:\n", verb, len(changes), board.Name)
+		for _, c := range changes {
+			fmt.Printf("  %-12s %s -> %s\n", c.IssueID, c.From, c.To)
+		}
+		return nil
+	},
+}
+
+// boardOrderPriorityChanges maps the positioned issues in views, which are in
+// board order, onto equal contiguous priority bands from highest to lowest.
+// Unpositioned issues are left out.
+func boardOrderPriorityChanges(views []models.BoardIssueView, highest, lowest models.Priority) []db.PriorityChange {
+	var ranked []models.Issue
+	for _, v := range views {
+		if v.HasPosition {
+			ranked = append(ranked, v.Issue)
+		}
+	}
+
+	lo := int(highest[1] - '0')
+	levels := int(lowest[1]-'0') - lo + 1
+	changes := make([]db.PriorityChange, 0, len(ranked))
+	for i, issue := range ranked {
+		to := models.Priority(fmt.Sprintf("P%d", lo+i*levels/len(ranked)))
+		changes = append(changes, db.PriorityChange{IssueID: issue.ID, From: issue.Priority, To: to})
+	}
+	return changes
+}
+
+func init() {
+	rootCmd.AddCommand(prioritySortCmd)
+
+	prioritySortCmd.Flags().String("highest", "P1", "Priority for the top of the board")
+	prioritySortCmd.Flags().String("lowest", "P3", "Priority for the bottom of the board")
+	prioritySortCmd.Flags().Bool("dry-run", false, "Show the changes without applying them")
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/models"
+)
+
+func TestPrioritySortFollowsBoardOrder(t *testing.T) {
+	dir := t.TempDir()
+	database, err := db.Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	board, err := database.CreateBoard("sprint", "")
+	if err != nil {
+		t.Fatalf("CreateBoard failed: %v", err)
+	}
+	other, err := database.CreateBoard("other", "")
+	if err != nil {
+		t.Fatalf("CreateBoard failed: %v", err)
+	}
+
+	// Six ranked issues, created in reverse so board order differs from
+	// creation order, plus one issue ranked only on the other board.
+	ranked := make([]*models.Issue, 6)
+	for i := len(ranked) - 1; i >= 0; i-- {
+		ranked[i] = &models.Issue{Title: "Ranked", Priority: models.PriorityP4}
+		if err := database.CreateIssue(ranked[i]); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	for i, issue := range ranked {
+		if err := database.SetIssuePosition(board.ID, issue.ID, (i+1)*db.PositionGap); err != nil {
+			t.Fatalf("SetIssuePosition failed: %v", err)
+		}
+	}
+	unranked := &models.Issue{Title: "Other board only", Priority: models.PriorityP4}
+	if err := database.CreateIssue(unranked); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := database.SetIssuePosition(other.ID, unranked.ID, db.PositionGap); err != nil {
+		t.Fatalf("SetIssuePosition failed: %v", err)
+	}
+
+	views, err := boardIssues(database, board, "ses_test", defaultBoardStatuses)
+	if err != nil {
+		t.Fatalf("boardIssues failed: %v", err)
+	}
+	changes := boardOrderPriorityChanges(views, models.PriorityP1, models.PriorityP3)
+	applied, err := database.SetBoardPrioritiesLogged(board.ID, changes, "ses_test")
+	if err != nil {
+		t.Fatalf("SetBoardPrioritiesLogged failed: %v", err)
+	}
+	if len(applied) != len(ranked) {
+		t.Fatalf("expected %d changes, got %d", len(ranked), len(applied))
+	}
+
+	want := []models.Priority{
+		models.PriorityP1, models.PriorityP1,
+		models.PriorityP2, models.PriorityP2,
+		models.PriorityP3, models.PriorityP3,
+	}
+	for i, issue := range ranked {
+		got, _ := database.GetIssue(issue.ID)
+		if got.Priority != want[i] {
+			t.Errorf("rank %d (%s): expected %s, got %s", i+1, issue.ID, want[i], got.Priority)
+		}
+	}
+	if got, _ := database.GetIssue(unranked.ID); got.Priority != models.PriorityP4 {
+		t.Errorf("issue ranked only on another board changed to %s", got.Priority)
+	}
+
+	// Issues not ranked on the board are refused
+	_, err = database.SetBoardPrioritiesLogged(board.ID, []db.PriorityChange{
+		{IssueID: unranked.ID, From: models.PriorityP4, To: models.PriorityP0},
+	}, "ses_test")
+	if err == nil {
+		t.Error("expected an error for an issue without a position on the board")
+	}
+
+	// Running again is a no-op
+	views, _ = boardIssues(database, board, "ses_test", defaultBoardStatuses)
+	applied, err = database.SetBoardPrioritiesLogged(board.ID, boardOrderPriorityChanges(views, models.PriorityP1, models.PriorityP3), "ses_test")
+	if err != nil || len(applied) != 0 {
+		t.Errorf("expected no changes on rerun, got %v (err %v)", applied, err)
+	}
+}
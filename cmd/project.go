@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/marcus/td/internal/config"
 	"github.com/marcus/td/internal/db"
 	"github.com/marcus/td/internal/features"
 	"github.com/marcus/td/internal/output"
@@ -385,6 +386,85 @@ var syncProjectRoleCmd = &cobra.Command{
 	},
 }
 
+var syncProjectDefaultsCmd = &cobra.Command{
+	Use:   "defaults",
+	Short: "Show or set the project's default monitor query and board",
+	Long: `Show or set the default view team members open the monitor to.
+
+The defaults are stored on the sync server, fetched on every td sync, and
+applied by td monitor only when you have no saved filter or board of your own.
+
+Examples:
+  td sync-project defaults
+  td sync-project defaults --query 'status = open label("backend")' --board Sprint
+  td sync-project defaults --clear`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !syncconfig.IsAuthenticated() {
+			output.Error("not logged in (run: td auth login)")
+			return fmt.Errorf("not authenticated")
+		}
+
+		baseDir := getBaseDir()
+		database, err := db.Open(baseDir)
+		if err != nil {
+			output.Error("open database: %v", err)
+			return err
+		}
+		defer database.Close()
+
+		syncState, err := database.GetSyncState()
+		if err != nil || syncState == nil {
+			output.Error("project not linked (run: td sync-project link <id>)")
+			return fmt.Errorf("not linked")
+		}
+
+		client := syncclient.New(syncconfig.GetServerURL(), syncconfig.GetAPIKey(), "")
+		defaults, err := client.GetProjectDefaults(syncState.ProjectID)
+		if err != nil {
+			output.Error("get defaults: %v", err)
+			return err
+		}
+
+		clearAll, _ := cmd.Flags().GetBool("clear")
+		if clearAll || cmd.Flags().Changed("query") || cmd.Flags().Changed("board") {
+			next := *defaults
+			if clearAll {
+				next = syncclient.ProjectDefaults{}
+			}
+			if cmd.Flags().Changed("query") {
+				next.Query, _ = cmd.Flags().GetString("query")
+			}
+			if cmd.Flags().Changed("board") {
+				next.Board, _ = cmd.Flags().GetString("board")
+			}
+			defaults, err = client.SetProjectDefaults(syncState.ProjectID, next)
+			if err != nil {
+				output.Error("set defaults: %v", err)
+				return err
+			}
+		}
+
+		if err := config.SetProjectDefaults(baseDir, defaults.Query, defaults.Board); err != nil {
+			output.Warning("cache defaults locally: %v", err)
+		}
+
+		if jsonMode(cmd) {
+			return output.JSON(defaults)
+		}
+		query, board := defaults.Query, defaults.Board
+		if query == "" {
+			query = "(none)"
+		}
+		if board == "" {
+			board = "(none)"
+		}
+		fmt.Printf("Query: %s\n", query)
+		fmt.Printf("Board: %s\n", board)
+		return nil
+	},
+}
+
 var syncProjectJoinCmd = &cobra.Command{
 	Use:   "join [name-or-id]",
 	Short: "Join a remote sync project by name or ID",
@@ -481,6 +561,9 @@ func init() {
 	syncProjectCreateCmd.Flags().String("description", "", "Project description")
 	syncProjectLinkCmd.Flags().BoolP("force", "f", false, "Skip confirmation prompts")
 	syncProjectUnlinkCmd.Flags().BoolP("force", "f", false, "Skip confirmation prompts")
+	syncProjectDefaultsCmd.Flags().String("query", "", "Default TDQ query (empty to unset)")
+	syncProjectDefaultsCmd.Flags().String("board", "", "Default board ID or name (empty to unset)")
+	syncProjectDefaultsCmd.Flags().Bool("clear", false, "Remove both defaults")
 
 	syncProjectCmd.AddCommand(syncProjectCreateCmd)
 	syncProjectCmd.AddCommand(syncProjectJoinCmd)
@@ -491,5 +574,6 @@ func init() {
 	syncProjectCmd.AddCommand(syncProjectInviteCmd)
 	syncProjectCmd.AddCommand(syncProjectKickCmd)
 	syncProjectCmd.AddCommand(syncProjectRoleCmd)
+	syncProjectCmd.AddCommand(syncProjectDefaultsCmd)
 	AddFeatureGatedCommand(features.SyncCLI.Name, syncProjectCmd)
 }
@@ -1,11 +1,14 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/models"
 	"github.com/marcus/td/internal/output"
 	"github.com/marcus/td/internal/query"
 	"github.com/marcus/td/internal/session"
@@ -52,8 +55,11 @@ FIELDS:
   closed      closure date
   implementer session that started work
   reviewer    session that reviewed
+  created_by  session name (or ID) that filed the issue
   parent      direct parent issue ID
   epic        ancestor epic ID (recursive)
+  child_count       number of direct children
+  descendant_count  number of children, grandchildren, ...
 
 CROSS-ENTITY SEARCH:
   log.message ~ "text"     Search log messages
@@ -66,10 +72,20 @@ FUNCTIONS:
   has(field)             Field is not empty
   is(status)             Shorthand: is(open) = status = open
   any(field, v1, v2)     Field matches any value
-  blocks(id)             Issues that block given id
-  blocked_by(id)         Issues blocked by given id
+  blocks(id)             Issues id depends on (they block it)
+  blocked_by(id)         Issues that depend on id (it blocks them)
+  depends_on(id)         Same as blocked_by(id): issues that depend on id
+  dependency_of(id)      Same as blocks(id): issues id depends on
+  related_to(id)         Issues related to given id (non-blocking)
   descendant_of(id)      All children of epic (recursive)
+  is_ready()             Issues with no open dependencies
+  is_actionable()        is_ready() and no blocked or unstarted parent
   rework()               Issues rejected and awaiting rework
+  mine()                 Issues claimed by this session (or same-named sessions)
+  updated_by_session(id) Issues with any recorded action by session id
+  has_comments()         Issues with at least one comment
+  has_handoff()          Issues with at least one handoff
+  has_files()            Issues with linked files
 
 SPECIAL VALUES:
   @me                    Current session ID
@@ -88,6 +104,22 @@ EXAMPLES:
   td query "title ~ auth OR description ~ auth"
   td query "rework()"
 
+OUTPUT:
+  td query "is(open)" --columns id,title,status,assignee  Only these columns, in order
+  td query "type = bug" -o csv --columns id,priority      CSV with a header row
+  td query --fields                                       Fields you can filter on
+
+WATCH MODE:
+  td query "is(in_progress)" --watch                   Redraw as issues change
+  td query "is(open)" --watch --interval 5s -o count  Poll every 5 seconds
+
+DEFAULT QUERY:
+  td config set default-query "labels ~ backend"
+  td query                          Runs the default query
+  td query "type = bug"             Backend bugs: the default AND the expression
+  td query "type = bug" --all       All bugs, ignoring the default
+  td config set default-query ""    Clears it
+
 BOARDS:
   Save queries as reusable boards with td board:
     td board create "My Bugs" "type = bug AND implementer = @me"
@@ -109,21 +141,31 @@ BOARDS:
 			return nil
 		}
 
-		if len(args) == 0 {
+		explicit := ""
+		if len(args) > 0 {
+			explicit = args[0]
+		}
+		// The default query is the base; an explicit expression narrows it
+		queryStr := withDefaultQuery(cmd, getBaseDir(), explicit)
+		if queryStr == "" {
 			return cmd.Help()
 		}
 
-		queryStr := args[0]
-
 		// Parse and validate query first (for --explain)
 		parsedQuery, err := query.Parse(queryStr)
 		if err != nil {
 			output.Error("Parse error: %v", err)
+			var pe *query.ParseError
+			if errors.As(err, &pe) && pe.Snippet() != "" {
+				for _, line := range strings.Split(pe.Snippet(), "\n") {
+					fmt.Fprintln(os.Stderr, "  "+line)
+				}
+			}
 			printQuerySyntaxHelp()
 			return err
 		}
 
-		if errs := parsedQuery.Validate(); len(errs) > 0 {
+		if errs := parsedQuery.ValidateWithStatuses(customStatuses()); len(errs) > 0 {
 			output.Error("Validation errors:")
 			for _, e := range errs {
 				output.Error("  - %v", e)
@@ -135,6 +177,8 @@ BOARDS:
 		if explain, _ := cmd.Flags().GetBool("explain"); explain {
 			fmt.Printf("Query: %s\n", queryStr)
 			fmt.Printf("Parsed: %s\n", parsedQuery.String())
+			fmt.Println()
+			fmt.Print(parsedQuery.Explain())
 			return nil
 		}
 
@@ -161,10 +205,45 @@ BOARDS:
 			sortBy = strings.TrimPrefix(sortBy, "-")
 		}
 
+		identities, _ := session.IdentitySessionIDs(database, sess)
+
 		opts := query.ExecuteOptions{
-			Limit:    limit,
-			SortBy:   sortBy,
-			SortDesc: sortDesc,
+			Limit:      limit,
+			SortBy:     sortBy,
+			SortDesc:   sortDesc,
+			Identities: identities,
+			Statuses:   customStatuses(),
+		}
+
+		outputFormat, _ := cmd.Flags().GetString("output")
+
+		var fields []queryOutputField
+		if spec, _ := cmd.Flags().GetString("columns"); cmd.Flags().Changed("columns") {
+			if outputFormat == "ids" || outputFormat == "count" {
+				err := fmt.Errorf("--columns applies to table, csv and json output")
+				output.Error("%v", err)
+				return err
+			}
+			fields, err = parseQueryFields(spec)
+			if err != nil {
+				output.Error("%v", err)
+				return err
+			}
+		}
+
+		if watch, _ := cmd.Flags().GetBool("watch"); watch {
+			if outputFormat == "json" {
+				err := fmt.Errorf("--watch does not support --output json")
+				output.Error("%v", err)
+				return err
+			}
+			interval, _ := cmd.Flags().GetDuration("interval")
+			if interval <= 0 {
+				err := fmt.Errorf("--interval must be positive")
+				output.Error("%v", err)
+				return err
+			}
+			return runQueryWatch(database, queryStr, sessionID, opts, outputFormat, fields, interval)
 		}
 
 		results, err := query.Execute(database, queryStr, sessionID, opts)
@@ -174,28 +253,56 @@ BOARDS:
 		}
 
 		// Output
-		outputFormat, _ := cmd.Flags().GetString("output")
-		switch outputFormat {
-		case "json":
-			return output.JSON(results)
-		case "ids":
-			for _, issue := range results {
-				fmt.Println(issue.ID)
-			}
-		case "count":
-			fmt.Printf("%d\n", len(results))
-		default: // "table"
-			for _, issue := range results {
-				fmt.Println(output.FormatIssueShort(&issue))
+		if outputFormat == "json" {
+			if fields != nil {
+				data, err := queryFieldsJSON(results, fields)
+				if err != nil {
+					output.Error("%v", err)
+					return err
+				}
+				return output.JSON(data)
 			}
+			return output.JSON(results)
+		}
+		fmt.Print(formatQueryResults(results, outputFormat, fields))
+		return nil
+	},
+}
+
+// formatQueryResults renders query results in one of the plain-text output
+// formats (table, csv, ids, count). fields, when set, restricts table and
+// csv output to those columns.
+func formatQueryResults(results []models.Issue, outputFormat string, fields []queryOutputField) string {
+	if outputFormat == "csv" {
+		if fields == nil {
+			data, _ := output.FormatIssuesCSV(results)
+			return data
 		}
+		data, _ := formatQueryFieldsCSV(results, fields)
+		return data
+	}
+	if outputFormat == "table" && fields != nil {
+		return formatQueryFieldsTable(results, fields)
+	}
 
-		if len(results) == 0 && outputFormat != "count" {
-			fmt.Printf("No issues matching query\n")
+	var sb strings.Builder
+	switch outputFormat {
+	case "ids":
+		for _, issue := range results {
+			sb.WriteString(issue.ID + "\n")
+		}
+	case "count":
+		fmt.Fprintf(&sb, "%d\n", len(results))
+	default: // "table"
+		for _, issue := range results {
+			sb.WriteString(output.FormatIssueShort(&issue) + "\n")
 		}
+	}
 
-		return nil
-	},
+	if len(results) == 0 && outputFormat != "count" {
+		sb.WriteString("No issues matching query\n")
+	}
+	return sb.String()
 }
 
 func printQuerySyntaxHelp() {
@@ -221,6 +328,7 @@ func printQueryExamples() {
 		{"type = bug", "All bugs"},
 		{"priority <= P1", "High priority (P0 or P1)"},
 		{"points >= 5", "Large issues (5+ points)"},
+		{"type = epic AND descendant_count > 20", "Epics that have grown too large"},
 
 		// Text search
 		{`title ~ "auth"`, "Title contains 'auth'"},
@@ -242,6 +350,7 @@ func printQueryExamples() {
 		// Session queries
 		{"implementer = @me", "Issues I'm implementing"},
 		{"implementer = @me AND is(in_progress)", "My current work"},
+		{"mine() AND status = in_progress", "What am I working on"},
 		{"status = in_review AND implementer != @me", "Issues I can review"},
 
 		// Functions
@@ -251,6 +360,8 @@ func printQueryExamples() {
 		{"any(type, bug, feature)", "Bugs or features"},
 		{"descendant_of(td-epic1)", "All tasks in epic"},
 		{"rework()", "Issues rejected and awaiting rework"},
+		{"is(in_review) AND NOT has_handoff()", "In review with no handoff recorded"},
+		{"NOT has_files() AND NOT has_comments()", "Issues with no evidence of work"},
 
 		// Cross-entity queries
 		{"log.type = blocker", "Issues with blocker logs"},
@@ -292,8 +403,11 @@ func printQueryFields() {
 		{"labels", "string", "comma-separated"},
 		{"parent", "string", "issue ID (direct parent)"},
 		{"epic", "string", "issue ID (ancestor epic)"},
+		{"child_count", "number", "direct children"},
+		{"descendant_count", "number", "all descendants"},
 		{"implementer", "string", "session ID or @me"},
 		{"reviewer", "string", "session ID or @me"},
+		{"created_by", "string", "session name (or ID) that filed the issue"},
 		{"minor", "bool", "true, false"},
 		{"branch", "string", "git branch name"},
 		{"created", "date", "ISO or relative (-7d, today, etc.)"},
@@ -338,10 +452,14 @@ func printQueryFields() {
 func init() {
 	rootCmd.AddCommand(queryCmd)
 
-	queryCmd.Flags().StringP("output", "o", "table", "Output format: table, json, ids, count")
+	queryCmd.Flags().StringP("output", "o", "table", "Output format: table, csv, json, ids, count")
+	queryCmd.Flags().String("columns", "", "Comma-separated fields to print, in order (e.g. id,title,status,assignee); --fields lists searchable fields")
 	queryCmd.Flags().IntP("limit", "n", 50, "Limit results")
 	queryCmd.Flags().String("sort", "", "Sort by field (prefix with - for descending)")
-	queryCmd.Flags().Bool("explain", false, "Show query parsing without executing")
+	queryCmd.Flags().BoolP("all", "a", false, "Ignore the default-query config")
+	queryCmd.Flags().Bool("explain", false, "Validate the query and print its parse tree without executing")
 	queryCmd.Flags().Bool("examples", false, "Show query examples")
 	queryCmd.Flags().Bool("fields", false, "List all searchable fields")
+	queryCmd.Flags().Bool("watch", false, "Re-run the query when the database changes and redraw in place")
+	queryCmd.Flags().Duration("interval", 2*time.Second, "Polling interval for --watch")
 }
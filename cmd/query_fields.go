@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/marcus/td/internal/models"
+)
+
+// queryOutputField is a column td query --columns can print.
+type queryOutputField struct {
+	name  string
+	value func(issue *models.Issue) any
+}
+
+// queryOutputFields are the columns --columns accepts, in the order
+// --columns with an unknown name lists them.
+var queryOutputFields = []queryOutputField{
+	{"id", func(i *models.Issue) any { return i.ID }},
+	{"title", func(i *models.Issue) any { return i.Title }},
+	{"status", func(i *models.Issue) any { return string(i.Status) }},
+	{"type", func(i *models.Issue) any { return string(i.Type) }},
+	{"priority", func(i *models.Issue) any { return string(i.Priority) }},
+	{"points", func(i *models.Issue) any { return i.Points }},
+	{"labels", func(i *models.Issue) any {
+		if i.Labels == nil {
+			return []string{}
+		}
+		return i.Labels
+	}},
+	{"parent", func(i *models.Issue) any { return i.ParentID }},
+	{"implementer", func(i *models.Issue) any { return i.ImplementerSession }},
+	{"reviewer", func(i *models.Issue) any { return i.ReviewerSession }},
+	{"created_by", func(i *models.Issue) any { return i.CreatedBy }},
+	{"sprint", func(i *models.Issue) any { return i.Sprint }},
+	{"created", func(i *models.Issue) any { return formatQueryTime(&i.CreatedAt) }},
+	{"updated", func(i *models.Issue) any { return formatQueryTime(&i.UpdatedAt) }},
+	{"closed", func(i *models.Issue) any { return formatQueryTime(i.ClosedAt) }},
+	{"description", func(i *models.Issue) any { return i.Description }},
+}
+
+// queryFieldAliases maps alternate names accepted by --columns to their
+// column.
+var queryFieldAliases = map[string]string{
+	"assignee":  "implementer",
+	"parent_id": "parent",
+}
+
+func formatQueryTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// parseQueryFields resolves a comma-separated --columns value to columns,
+// keeping the requested order. An unknown name is an error that lists the
+// known ones.
+func parseQueryFields(spec string) ([]queryOutputField, error) {
+	var fields []queryOutputField
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if alias, ok := queryFieldAliases[name]; ok {
+			name = alias
+		}
+		field, ok := lookupQueryField(name)
+		if !ok {
+			known := make([]string, len(queryOutputFields))
+			for i, f := range queryOutputFields {
+				known[i] = f.name
+			}
+			return nil, fmt.Errorf("unknown field %q (known fields: %s)", name, strings.Join(known, ", "))
+		}
+		fields = append(fields, field)
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("--columns needs at least one field name")
+	}
+	return fields, nil
+}
+
+func lookupQueryField(name string) (queryOutputField, bool) {
+	for _, f := range queryOutputFields {
+		if f.name == name {
+			return f, true
+		}
+	}
+	return queryOutputField{}, false
+}
+
+// queryFieldText renders a field value for table and CSV output.
+func queryFieldText(v any) string {
+	switch v := v.(type) {
+	case []string:
+		return strings.Join(v, ",")
+	case int:
+		return strconv.Itoa(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// formatQueryFieldsTable renders one aligned row per issue with only the
+// selected fields.
+func formatQueryFieldsTable(results []models.Issue, fields []queryOutputField) string {
+	if len(results) == 0 {
+		return "No issues matching query\n"
+	}
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	for i := range results {
+		cols := make([]string, len(fields))
+		for j, f := range fields {
+			cols[j] = queryFieldText(f.value(&results[i]))
+		}
+		fmt.Fprintln(tw, strings.Join(cols, "\t"))
+	}
+	tw.Flush()
+	return buf.String()
+}
+
+// formatQueryFieldsCSV renders a header row of field names and one record
+// per issue.
+func formatQueryFieldsCSV(results []models.Issue, fields []queryOutputField) (string, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	header := make([]string, len(fields))
+	for i, f := range fields {
+		header[i] = f.name
+	}
+	if err := w.Write(header); err != nil {
+		return "", err
+	}
+	for i := range results {
+		record := make([]string, len(fields))
+		for j, f := range fields {
+			record[j] = queryFieldText(f.value(&results[i]))
+		}
+		if err := w.Write(record); err != nil {
+			return "", err
+		}
+	}
+	w.Flush()
+	return buf.String(), w.Error()
+}
+
+// queryFieldsJSON renders issues as JSON objects holding only the selected
+// fields, with keys in the requested order.
+func queryFieldsJSON(results []models.Issue, fields []queryOutputField) (json.RawMessage, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i := range results {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteByte('{')
+		for j, f := range fields {
+			if j > 0 {
+				buf.WriteByte(',')
+			}
+			key, _ := json.Marshal(f.name)
+			val, err := json.Marshal(f.value(&results[i]))
+			if err != nil {
+				return nil, err
+			}
+			buf.Write(key)
+			buf.WriteByte(':')
+			buf.Write(val)
+		}
+		buf.WriteByte('}')
+	}
+	buf.WriteByte(']')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/marcus/td/internal/models"
+)
+
+func TestQueryFieldsOutputOnlyRequestedFields(t *testing.T) {
+	results := []models.Issue{
+		{ID: "td-aaa", Title: "Crash on save", Status: models.StatusOpen, Type: models.TypeBug,
+			Priority: models.PriorityP1, ImplementerSession: "ses_1", Description: "secret"},
+	}
+	fields, err := parseQueryFields("status, id,assignee")
+	if err != nil {
+		t.Fatalf("parseQueryFields: %v", err)
+	}
+
+	table := formatQueryFieldsTable(results, fields)
+	if got := strings.Fields(table); strings.Join(got, " ") != "open td-aaa ses_1" {
+		t.Errorf("table = %q, want status, id, implementer in order", table)
+	}
+
+	csvOut, err := formatQueryFieldsCSV(results, fields)
+	if err != nil {
+		t.Fatalf("formatQueryFieldsCSV: %v", err)
+	}
+	if want := "status,id,implementer\nopen,td-aaa,ses_1\n"; csvOut != want {
+		t.Errorf("csv = %q, want %q", csvOut, want)
+	}
+
+	data, err := queryFieldsJSON(results, fields)
+	if err != nil {
+		t.Fatalf("queryFieldsJSON: %v", err)
+	}
+	if want := `[{"status":"open","id":"td-aaa","implementer":"ses_1"}]`; string(data) != want {
+		t.Errorf("json = %s, want %s", data, want)
+	}
+	for _, out := range []string{table, csvOut, string(data)} {
+		if strings.Contains(out, "Crash") || strings.Contains(out, "secret") {
+			t.Errorf("output includes unrequested fields: %q", out)
+		}
+	}
+}
+
+func TestParseQueryFieldsRejectsUnknown(t *testing.T) {
+	if _, err := parseQueryFields("id,nope"); err == nil || !strings.Contains(err.Error(), `"nope"`) {
+		t.Errorf("err = %v, want unknown field error naming nope", err)
+	}
+	if _, err := parseQueryFields(" , "); err == nil {
+		t.Error("expected an error for an empty field list")
+	}
+}
+
+func TestQueryDefaultColumnsUnchanged(t *testing.T) {
+	results := []models.Issue{{ID: "td-aaa", Title: "Crash on save", Status: models.StatusOpen, Type: models.TypeBug}}
+	if got := formatQueryResults(results, "table", nil); !strings.Contains(got, "td-aaa") || !strings.Contains(got, "Crash on save") {
+		t.Errorf("default table = %q", got)
+	}
+	if got := formatQueryResults(results, "csv", nil); !strings.HasPrefix(got, "id,title,status,type,priority") {
+		t.Errorf("default csv = %q", got)
+	}
+}
+
+// TestQueryFieldsFlagListsSearchableFields keeps `td query --fields` working
+// as a bare flag alongside --columns.
+func TestQueryFieldsFlagListsSearchableFields(t *testing.T) {
+	saveAndRestoreGlobals(t)
+	t.Cleanup(func() {
+		rootCmd.SetArgs(nil)
+		_ = queryCmd.Flags().Set("fields", "false")
+	})
+
+	rootCmd.SetArgs([]string{"query", "--fields"})
+	out := captureStdout(t, func() {
+		if err := rootCmd.Execute(); err != nil {
+			t.Errorf("td query --fields failed: %v", err)
+		}
+	})
+	if !strings.Contains(out, "TDQ Searchable Fields") {
+		t.Errorf("td query --fields output = %q, want the searchable field list", out)
+	}
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/output"
+	"github.com/marcus/td/internal/query"
+)
+
+// clearScreen moves the cursor home and clears the terminal.
+const clearScreen = "\033[H\033[2J"
+
+// queryWatch tracks what td query --watch last saw and drew, so polls that
+// find nothing new neither re-run the query nor redraw the screen.
+type queryWatch struct {
+	token  string // action_log change token at the last query run
+	frame  string // last rendered result body
+	primed bool   // false until the first frame is drawn
+}
+
+// tokenChanged records token and reports whether the database has changed
+// since the previous call. The first call always reports a change.
+func (w *queryWatch) tokenChanged(token string) bool {
+	if w.primed && token == w.token {
+		return false
+	}
+	w.token = token
+	return true
+}
+
+// frameChanged records frame and reports whether it differs from what is on
+// screen. A mutation that doesn't affect the results yields the same frame,
+// so nothing is redrawn.
+func (w *queryWatch) frameChanged(frame string) bool {
+	if w.primed && frame == w.frame {
+		return false
+	}
+	w.frame = frame
+	w.primed = true
+	return true
+}
+
+// runQueryWatch re-runs a query whenever the database changes, polling the
+// action_log change token every interval, until interrupted.
+func runQueryWatch(database *db.DB, queryStr, sessionID string, opts query.ExecuteOptions, outputFormat string, fields []queryOutputField, interval time.Duration) error {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var w queryWatch
+	poll := func() error {
+		token, err := database.GetChangeToken()
+		if err != nil {
+			slog.Debug("query watch: change token", "err", err)
+		}
+		if !w.tokenChanged(token) {
+			return nil
+		}
+		results, err := query.Execute(database, queryStr, sessionID, opts)
+		if err != nil {
+			return err
+		}
+		frame := formatQueryResults(results, outputFormat, fields)
+		if !w.frameChanged(frame) {
+			return nil
+		}
+		fmt.Print(clearScreen)
+		fmt.Println(dimStyle.Render(fmt.Sprintf("Every %s: td query %q  (%s)", interval, queryStr, time.Now().Format("15:04:05"))))
+		fmt.Println()
+		fmt.Print(frame)
+		return nil
+	}
+
+	if err := poll(); err != nil {
+		output.Error("Query error: %v", err)
+		return err
+	}
+	for {
+		select {
+		case <-sigCh:
+			fmt.Println() // clean line after ^C
+			return nil
+		case <-ticker.C:
+			if err := poll(); err != nil {
+				slog.Debug("query watch: poll", "err", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/models"
+	"github.com/marcus/td/internal/query"
+)
+
+// TestQueryWatchSkipsUnchangedRedraws walks the --watch change detection
+// through a quiet poll, an unrelated mutation and a relevant one.
+func TestQueryWatchSkipsUnchangedRedraws(t *testing.T) {
+	dir := t.TempDir()
+	database, err := db.Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	bug := &models.Issue{Title: "Crash on save", Type: models.TypeBug, Status: models.StatusOpen}
+	task := &models.Issue{Title: "Write docs", Type: models.TypeTask, Status: models.StatusOpen}
+	for _, issue := range []*models.Issue{bug, task} {
+		if err := database.CreateIssueLogged(issue, "ses_test"); err != nil {
+			t.Fatalf("CreateIssueLogged failed: %v", err)
+		}
+	}
+
+	var w queryWatch
+	// poll mirrors one tick of runQueryWatch and reports whether it would redraw.
+	poll := func() bool {
+		t.Helper()
+		token, err := database.GetChangeToken()
+		if err != nil {
+			t.Fatalf("GetChangeToken failed: %v", err)
+		}
+		if !w.tokenChanged(token) {
+			return false
+		}
+		results, err := query.Execute(database, "type = bug", "", query.ExecuteOptions{})
+		if err != nil {
+			t.Fatalf("Execute failed: %v", err)
+		}
+		return w.frameChanged(formatQueryResults(results, "table", nil))
+	}
+
+	if !poll() {
+		t.Fatal("first poll should draw")
+	}
+	if poll() {
+		t.Error("poll with no database changes should not redraw")
+	}
+
+	task.Title = "Write better docs"
+	if err := database.UpdateIssueLogged(task, "ses_test", models.ActionUpdate); err != nil {
+		t.Fatalf("UpdateIssueLogged failed: %v", err)
+	}
+	if poll() {
+		t.Error("change outside the result set should not redraw")
+	}
+
+	bug.Status = models.StatusInProgress
+	if err := database.UpdateIssueLogged(bug, "ses_test", models.ActionUpdate); err != nil {
+		t.Fatalf("UpdateIssueLogged failed: %v", err)
+	}
+	if !poll() {
+		t.Error("change to a matching issue should redraw")
+	}
+}
+
+// TestQueryRunsThroughRoot parses td query's flags the way the binary does,
+// so a shorthand clashing with a root persistent flag fails here.
+func TestQueryRunsThroughRoot(t *testing.T) {
+	saveAndRestoreGlobals(t)
+	setJSONFlag(t, false)
+	dir := t.TempDir()
+	baseDirOverride = &dir
+
+	database, err := db.Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+	bug := &models.Issue{Title: "Crash on save", Type: models.TypeBug, Status: models.StatusOpen}
+	if err := database.CreateIssue(bug); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	t.Cleanup(func() {
+		rootCmd.SetArgs(nil)
+		_ = queryCmd.Flags().Set("output", queryCmd.Flags().Lookup("output").DefValue)
+	})
+	rootCmd.SetArgs([]string{"query", "-w", dir, "type = bug", "-o", "ids"})
+	out := captureStdout(t, func() {
+		if err := rootCmd.Execute(); err != nil {
+			t.Errorf("td query failed: %v", err)
+		}
+	})
+	if !strings.Contains(out, bug.ID) {
+		t.Errorf("td query output = %q, want %s", out, bug.ID)
+	}
+}
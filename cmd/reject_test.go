@@ -6,14 +6,21 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/marcus/td/internal/db"
 	"github.com/marcus/td/internal/models"
+	"github.com/marcus/td/internal/query"
 	"github.com/marcus/td/internal/session"
 )
 
 func runRejectCommand(t *testing.T, dir string, args ...string) string {
 	t.Helper()
+	return runRejectCommandWithFlags(t, dir, nil, args...)
+}
+
+func runRejectCommandWithFlags(t *testing.T, dir string, flags map[string]string, args ...string) string {
+	t.Helper()
 
 	saveAndRestoreGlobals(t)
 	t.Setenv("TD_SESSION_ID", "ses_reject_cmd")
@@ -27,6 +34,15 @@ func runRejectCommand(t *testing.T, dir string, args ...string) string {
 	_ = rejectCmd.Flags().Set("message", "")
 	_ = rejectCmd.Flags().Set("note", "")
 	_ = rejectCmd.Flags().Set("notes", "")
+	_ = rejectCmd.Flags().Set("reassign", "")
+	for name, value := range flags {
+		_ = rejectCmd.Flags().Set(name, value)
+	}
+	defer func() {
+		for name := range flags {
+			_ = rejectCmd.Flags().Set(name, "")
+		}
+	}()
 
 	var output bytes.Buffer
 	oldStdout := os.Stdout
@@ -94,3 +110,184 @@ func TestRejectOpenIssueIsIdempotent(t *testing.T) {
 		t.Fatalf("implementer session = %q, want %q", updated.ImplementerSession, "ses_impl")
 	}
 }
+
+func TestRejectedIssueIsRequeuedAsRework(t *testing.T) {
+	dir := t.TempDir()
+
+	database, err := db.Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	// An older open issue of the same priority would normally come first
+	other := &models.Issue{Title: "Untouched", Status: models.StatusOpen, Priority: models.PriorityP2}
+	if err := database.CreateIssue(other); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	issue := &models.Issue{Title: "Needs another pass", Status: models.StatusOpen, Priority: models.PriorityP2}
+	if err := database.CreateIssue(issue); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	issue.Status = models.StatusInReview
+	issue.ImplementerSession = "ses_impl"
+	if err := database.UpdateIssue(issue); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+
+	out := runRejectCommand(t, dir, issue.ID)
+	if !strings.Contains(out, "REJECTED") {
+		t.Fatalf("expected reject output, got %s", out)
+	}
+
+	updated, err := database.GetIssue(issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if updated.Status != models.StatusOpen {
+		t.Fatalf("status = %s, want %s", updated.Status, models.StatusOpen)
+	}
+	if updated.ImplementerSession != "" {
+		t.Errorf("implementer session = %q, want cleared", updated.ImplementerSession)
+	}
+
+	candidates, err := nextCandidates(database, nextStrategyPriority)
+	if err != nil {
+		t.Fatalf("nextCandidates failed: %v", err)
+	}
+	if len(candidates) == 0 || candidates[0].ID != issue.ID {
+		t.Fatalf("td next should offer rejected issue %s first, got %v", issue.ID, candidates)
+	}
+
+	results, err := query.Execute(database, "rework()", "ses_other", query.ExecuteOptions{})
+	if err != nil {
+		t.Fatalf("rework() failed: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != issue.ID {
+		t.Errorf("rework() = %v, want only %s", results, issue.ID)
+	}
+}
+
+func TestRejectReassignHandsReworkToSession(t *testing.T) {
+	dir := t.TempDir()
+
+	database, err := db.Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	now := time.Now()
+	for _, row := range []*db.SessionRow{
+		{ID: "ses_alice_old", Name: "alice", StartedAt: now.Add(-2 * time.Hour), LastActivity: now.Add(-2 * time.Hour)},
+		{ID: "ses_alice", Name: "alice", StartedAt: now, LastActivity: now},
+		{ID: "ses_bob", Name: "bob", StartedAt: now, LastActivity: now},
+	} {
+		if err := database.UpsertSession(row); err != nil {
+			t.Fatalf("UpsertSession failed: %v", err)
+		}
+	}
+
+	// A higher-priority issue everyone else still gets first
+	urgent := &models.Issue{Title: "Urgent", Status: models.StatusOpen, Priority: models.PriorityP1}
+	if err := database.CreateIssue(urgent); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	issue := &models.Issue{Title: "Parser fix", Status: models.StatusOpen, Priority: models.PriorityP2}
+	if err := database.CreateIssue(issue); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	issue.Status = models.StatusInReview
+	issue.ImplementerSession = "ses_bob"
+	if err := database.UpdateIssue(issue); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+
+	out := runRejectCommandWithFlags(t, dir, map[string]string{
+		"reason":   "alice owns the parser",
+		"reassign": "alice",
+	}, issue.ID)
+	if !strings.Contains(out, "reassigned to ses_alice (alice)") {
+		t.Fatalf("expected reassign output, got %s", out)
+	}
+
+	updated, err := database.GetIssue(issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if updated.Status != models.StatusOpen {
+		t.Fatalf("status = %s, want %s", updated.Status, models.StatusOpen)
+	}
+	if updated.ImplementerSession != "ses_alice" {
+		t.Errorf("implementer session = %q, want most recent alice session", updated.ImplementerSession)
+	}
+
+	logs, err := database.GetLogs(issue.ID, 0)
+	if err != nil {
+		t.Fatalf("GetLogs failed: %v", err)
+	}
+	var messages []string
+	for _, l := range logs {
+		messages = append(messages, l.Message)
+	}
+	joined := strings.Join(messages, "\n")
+	if !strings.Contains(joined, "Rejected: alice owns the parser") || !strings.Contains(joined, "Reassigned to ses_alice (alice)") {
+		t.Errorf("expected reject and reassign logs, got %q", joined)
+	}
+
+	nextFor := func(sessionID string) string {
+		t.Helper()
+		candidates, err := nextCandidates(database, nextStrategyPriority)
+		if err != nil {
+			t.Fatalf("nextCandidates failed: %v", err)
+		}
+		sess, err := session.Resolve(database, sessionID)
+		if err != nil {
+			t.Fatalf("Resolve failed: %v", err)
+		}
+		preferAssigned(candidates, callerSessionIDs(database, sess))
+		return candidates[0].ID
+	}
+	if got := nextFor("ses_alice"); got != issue.ID {
+		t.Errorf("td next for alice = %s, want reassigned %s", got, issue.ID)
+	}
+	if got := nextFor("ses_alice_old"); got != issue.ID {
+		t.Errorf("td next for another alice session = %s, want reassigned %s", got, issue.ID)
+	}
+	if got := nextFor("ses_bob"); got != urgent.ID {
+		t.Errorf("td next for bob = %s, want %s by priority", got, urgent.ID)
+	}
+}
+
+func TestRejectReassignUnknownSession(t *testing.T) {
+	dir := t.TempDir()
+
+	database, err := db.Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	issue := &models.Issue{Title: "Review me", Status: models.StatusInReview}
+	if err := database.CreateIssue(issue); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	saveAndRestoreGlobals(t)
+	t.Setenv("TD_SESSION_ID", "ses_reject_cmd")
+	baseDirOverride = &dir
+	_ = rejectCmd.Flags().Set("json", "false")
+	_ = rejectCmd.Flags().Set("reassign", "nobody")
+	defer rejectCmd.Flags().Set("reassign", "")
+
+	if err := rejectCmd.RunE(rejectCmd, []string{issue.ID}); err == nil {
+		t.Fatal("expected error for unknown --reassign target")
+	}
+	updated, err := database.GetIssue(issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if updated.Status != models.StatusInReview {
+		t.Errorf("status = %s, want issue left in_review", updated.Status)
+	}
+}
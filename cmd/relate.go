@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/models"
+	"github.com/marcus/td/internal/output"
+	"github.com/marcus/td/internal/session"
+	"github.com/spf13/cobra"
+)
+
+var relateCmd = &cobra.Command{
+	Use:   "relate <issue> <related>...",
+	Short: "Link related issues without blocking either",
+	Long: `Link issues that are related but don't block each other.
+
+Relations are symmetric: relating A to B also relates B to A. Unlike
+dependencies they never affect is_ready(), td next or blocked status.
+
+Examples:
+  td relate td-abc td-xyz            # td-abc and td-xyz are related
+  td relate td-abc td-xyz td-def     # relate td-abc to both
+  td unrelate td-abc td-xyz          # remove the link again
+  td query "related_to(td-abc)"      # find everything related to td-abc`,
+	GroupID: "workflow",
+	Args:    cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		baseDir := getBaseDir()
+		isJSON := jsonMode(cmd)
+
+		database, err := db.Open(baseDir)
+		if err != nil {
+			output.Error("%v", err)
+			return err
+		}
+		defer database.Close()
+
+		sess, err := session.GetOrCreate(database)
+		if err != nil {
+			output.Error("%v", err)
+			return err
+		}
+
+		issue, err := database.GetIssue(args[0])
+		if err != nil {
+			output.Error("issue not found: %s", args[0])
+			return err
+		}
+
+		existing, err := database.GetRelated(issue.ID)
+		if err != nil {
+			output.Error("failed to get relations: %v", err)
+			return err
+		}
+
+		added := []string{}
+		for _, relatedID := range args[1:] {
+			related, err := database.GetIssue(relatedID)
+			if err != nil {
+				output.Error("issue not found: %s", relatedID)
+				return err
+			}
+			if related.ID == issue.ID {
+				output.Error("cannot relate %s to itself", issue.ID)
+				return fmt.Errorf("cannot relate an issue to itself")
+			}
+			if slices.Contains(existing, related.ID) {
+				if !isJSON {
+					output.Warning("%s is already related to %s", issue.ID, related.ID)
+				}
+				continue
+			}
+			if err := database.AddRelation(issue.ID, related.ID, sess.ID); err != nil {
+				output.Error("failed to relate %s to %s: %v", issue.ID, related.ID, err)
+				return err
+			}
+			existing = append(existing, related.ID)
+			added = append(added, related.ID)
+			if !isJSON {
+				fmt.Printf("RELATED: %s <-> %s: %s\n", issue.ID, related.ID, related.Title)
+			}
+		}
+
+		if isJSON {
+			return output.EmitResult("relation_added", map[string]any{
+				"id":      issue.ID,
+				"related": added,
+				"type":    models.RelationRelatesTo,
+			})
+		}
+		return nil
+	},
+}
+
+var unrelateCmd = &cobra.Command{
+	Use:   "unrelate <issue> <related>...",
+	Short: "Remove links made with td relate",
+	Long: `Remove the relates_to link between an issue and each of the others,
+whichever side the link was made from.
+
+Examples:
+  td unrelate td-abc td-xyz          # td-abc and td-xyz are no longer related`,
+	GroupID: "workflow",
+	Args:    cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		baseDir := getBaseDir()
+		isJSON := jsonMode(cmd)
+
+		database, err := db.Open(baseDir)
+		if err != nil {
+			output.Error("%v", err)
+			return err
+		}
+		defer database.Close()
+
+		sess, err := session.GetOrCreate(database)
+		if err != nil {
+			output.Error("%v", err)
+			return err
+		}
+
+		issue, err := database.GetIssue(args[0])
+		if err != nil {
+			output.Error("issue not found: %s", args[0])
+			return err
+		}
+
+		existing, err := database.GetRelated(issue.ID)
+		if err != nil {
+			output.Error("failed to get relations: %v", err)
+			return err
+		}
+
+		removed := []string{}
+		for _, relatedID := range args[1:] {
+			related, err := database.GetIssue(relatedID)
+			if err != nil {
+				output.Error("issue not found: %s", relatedID)
+				return err
+			}
+			if !slices.Contains(existing, related.ID) {
+				if !isJSON {
+					output.Warning("%s is not related to %s", issue.ID, related.ID)
+				}
+				continue
+			}
+			if err := database.RemoveRelation(issue.ID, related.ID, sess.ID); err != nil {
+				output.Error("failed to unrelate %s from %s: %v", issue.ID, related.ID, err)
+				return err
+			}
+			removed = append(removed, related.ID)
+			if !isJSON {
+				fmt.Printf("UNRELATED: %s <-> %s: %s\n", issue.ID, related.ID, related.Title)
+			}
+		}
+
+		if isJSON {
+			return output.EmitResult("relation_removed", map[string]any{
+				"id":      issue.ID,
+				"related": removed,
+				"type":    models.RelationRelatesTo,
+			})
+		}
+		return nil
+	},
+}
+
+var relatedCmd = &cobra.Command{
+	Use:   "related <issue>",
+	Short: "Suggest issues that link the same files",
+	Long: `List open issues that link any of the same files as <issue>, most shared
+files first. Issues touching the same files are likely to conflict.
+
+This is advisory only: nothing is linked. Use td relate or td dep add to
+record a relationship you agree with.
+
+Examples:
+  td link td-abc internal/db/*.go    # link files first
+  td related td-abc                  # issues sharing those files`,
+	GroupID: "workflow",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		baseDir := getBaseDir()
+
+		database, err := db.Open(baseDir)
+		if err != nil {
+			output.Error("%v", err)
+			return err
+		}
+		defer database.Close()
+
+		issue, err := database.GetIssue(args[0])
+		if err != nil {
+			output.Error("issue not found: %s", args[0])
+			return err
+		}
+
+		overlaps, err := database.SuggestRelatedByFiles(issue.ID)
+		if err != nil {
+			output.Error("failed to find file overlaps: %v", err)
+			return err
+		}
+		existing, err := database.GetRelated(issue.ID)
+		if err != nil {
+			output.Error("failed to get relations: %v", err)
+			return err
+		}
+
+		if jsonMode(cmd) {
+			type suggestion struct {
+				db.FileOverlap
+				Related bool `json:"related"`
+			}
+			suggestions := make([]suggestion, 0, len(overlaps))
+			for _, o := range overlaps {
+				suggestions = append(suggestions, suggestion{FileOverlap: o, Related: slices.Contains(existing, o.IssueID)})
+			}
+			return output.JSON(map[string]any{
+				"id":          issue.ID,
+				"suggestions": suggestions,
+			})
+		}
+
+		if len(overlaps) == 0 {
+			fmt.Printf("No open issues share linked files with %s\n", issue.ID)
+			return nil
+		}
+
+		fmt.Printf("SHARES FILES WITH %s (%d):\n", issue.ID, len(overlaps))
+		for _, o := range overlaps {
+			related := ""
+			if slices.Contains(existing, o.IssueID) {
+				related = "  (related)"
+			}
+			fmt.Printf("  %-12s %-12s %s%s\n", o.IssueID, o.Status, o.Title, related)
+			fmt.Printf("    %s\n", strings.Join(o.SharedFiles, ", "))
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(relateCmd)
+	rootCmd.AddCommand(unrelateCmd)
+	rootCmd.AddCommand(relatedCmd)
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/marcus/td/internal/db"
+	"github.com/spf13/cobra"
+)
+
+// issueArgsAnnotation marks the positional args of a command that are issue
+// IDs, so short IDs can be resolved before the command runs. The value is
+// "all", or a comma-separated list of arg indexes. An index suffixed with
+// "@n" only applies when at least n args are given, for commands like
+// `td log [issue-id] <message>` whose single arg may be the message.
+const issueArgsAnnotation = "td:issue-args"
+
+// markIssueArgs annotates cmd with the positions of its issue ID args.
+func markIssueArgs(cmd *cobra.Command, positions string) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[issueArgsAnnotation] = positions
+}
+
+// issueArgIndexes returns the indexes into args that cmd marks as issue IDs.
+func issueArgIndexes(cmd *cobra.Command, args []string) []int {
+	positions := cmd.Annotations[issueArgsAnnotation]
+	if positions == "" {
+		return nil
+	}
+	var indexes []int
+	if positions == "all" {
+		for i := range args {
+			indexes = append(indexes, i)
+		}
+		return indexes
+	}
+	for _, p := range strings.Split(positions, ",") {
+		p, minArgs, _ := strings.Cut(strings.TrimSpace(p), "@")
+		if n, err := strconv.Atoi(minArgs); err == nil && len(args) < n {
+			continue
+		}
+		if i, err := strconv.Atoi(p); err == nil && i < len(args) {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// resolveIssueArgs rewrites the issue ID args of cmd in place to the full IDs
+// they uniquely match, so `td show a3f` acts on td-a3f012. The rewritten
+// slice is the one cobra passes on to RunE. Args that match nothing are left
+// as typed for the command to report; an ambiguous arg fails the command
+// with the candidates listed.
+func resolveIssueArgs(cmd *cobra.Command, args []string) error {
+	indexes := issueArgIndexes(cmd, args)
+	if len(indexes) == 0 {
+		return nil
+	}
+
+	database, err := db.Open(getBaseDir())
+	if err != nil {
+		// Let the command report a missing database itself
+		return nil
+	}
+	defer database.Close()
+
+	for _, i := range indexes {
+		id, err := database.ResolveIssueID(args[i])
+		var ambiguous *db.AmbiguousIssueIDError
+		if errors.As(err, &ambiguous) {
+			cmd.SilenceUsage = true
+			return err
+		}
+		if err == nil {
+			args[i] = id
+		}
+	}
+	return nil
+}
+
+func init() {
+	for _, c := range []*cobra.Command{
+		showCmd, startCmd, unstartCmd, updateCmd, deleteCmd, restoreCmd,
+		reviewCmd, approveCmd, rejectCmd, closeCmd,
+		blockCmd, unblockCmd, reopenCmd, groupCmd,
+		depCmd, depAddCmd, depRmCmd, blockedByCmd, dependsOnCmd,
+		relateCmd, unrelateCmd, relatedCmd, wsTagCmd, wsUntagCmd,
+	} {
+		markIssueArgs(c, "all")
+	}
+	for _, c := range []*cobra.Command{
+		resumeCmd, focusCmd, treeCmd, epicCmd, filesCmd, linkCmd, unlinkCmd,
+		deferCmd, dueCmd, commentCmd, commentsCmd, commentsAddCmd, criticalPathCmd,
+	} {
+		markIssueArgs(c, "0")
+	}
+	markIssueArgs(depTreeCmd, "0")
+	markIssueArgs(handoffCmd, "0@2")
+	markIssueArgs(logCmd, "0@2")
+	markIssueArgs(boardMoveCmd, "1")
+	markIssueArgs(boardUnpositionCmd, "1")
+}
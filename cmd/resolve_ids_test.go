@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/models"
+	"github.com/spf13/cobra"
+)
+
+func TestResolveIssueArgsRewritesMarkedPositions(t *testing.T) {
+	saveAndRestoreGlobals(t)
+	dir := t.TempDir()
+	baseDir := dir
+	baseDirOverride = &baseDir
+
+	database, err := db.Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	issue := &models.Issue{Title: "Resolve me"}
+	if err := database.CreateIssue(issue); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	database.Close()
+	short := strings.TrimPrefix(issue.ID, "td-")[:4]
+
+	args := []string{"sprint", short, "3"}
+	if err := resolveIssueArgs(boardMoveCmd, args); err != nil {
+		t.Fatalf("resolveIssueArgs failed: %v", err)
+	}
+	if args[0] != "sprint" || args[1] != issue.ID || args[2] != "3" {
+		t.Errorf("args = %v, want only the issue arg resolved to %s", args, issue.ID)
+	}
+
+	// Unmatched IDs are left for the command to report
+	args = []string{short, "td-nothere"}
+	if err := resolveIssueArgs(showCmd, args); err != nil {
+		t.Fatalf("resolveIssueArgs failed: %v", err)
+	}
+	if args[0] != issue.ID || args[1] != "td-nothere" {
+		t.Errorf("args = %v", args)
+	}
+}
+
+func TestIssueArgsRespectMinimumArgCount(t *testing.T) {
+	// A lone arg to td log may be the message, so it is left alone
+	if got := issueArgIndexes(logCmd, []string{"a3f"}); len(got) != 0 {
+		t.Errorf("one arg: indexes = %v, want none", got)
+	}
+	if got := issueArgIndexes(logCmd, []string{"a3f", "message"}); !slices.Equal(got, []int{0}) {
+		t.Errorf("two args: indexes = %v, want [0]", got)
+	}
+}
+
+// issueArgToken matches a Use placeholder naming an issue or epic ID, such
+// as <issue>, [issue-id...] or [epic].
+var issueArgToken = regexp.MustCompile(`^[<\[](issue|epic)(-ids?)?(\.\.\.)?[>\]]$`)
+
+func TestCommandsWithIssueArgsAreMarked(t *testing.T) {
+	var walk func(c *cobra.Command)
+	walk = func(c *cobra.Command) {
+		for _, sub := range c.Commands() {
+			walk(sub)
+		}
+		fields := strings.Fields(c.Use)
+		for i, tok := range fields[min(1, len(fields)):] {
+			if !issueArgToken.MatchString(tok) {
+				continue
+			}
+			positions := c.Annotations[issueArgsAnnotation]
+			if positions == "" {
+				t.Errorf("%q takes an issue ID (%s) but has no markIssueArgs", c.CommandPath(), tok)
+				break
+			}
+			args := make([]string, len(fields))
+			if !slices.Contains(issueArgIndexes(c, args), i) {
+				t.Errorf("%q marks %q, which misses its issue ID arg %d (%s)", c.CommandPath(), positions, i, tok)
+			}
+			break
+		}
+	}
+	walk(rootCmd)
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/marcus/td/internal/config"
 	"github.com/marcus/td/internal/db"
 	"github.com/marcus/td/internal/models"
 	"github.com/marcus/td/internal/output"
@@ -96,7 +97,7 @@ func submitIssueForReview(database *db.DB, issue *models.Issue, sess *session.Se
 	fromStatus := issue.Status
 
 	// Validate transition with state machine
-	sm := workflow.DefaultMachine()
+	sm := workflowMachine()
 	ctx := &workflow.TransitionContext{
 		Issue:      issue,
 		FromStatus: issue.Status,
@@ -246,6 +247,10 @@ the close gate, so any session may close after a reviewer records approval.
 Under review_policy_mode=trusted, the implementer may instead approve+close
 their own work with 'td approve --self-review --reason "..."'.
 
+Refuses an issue that still depends on open issues or has unchecked
+acceptance criteria ("- [ ] ..."), listing what is outstanding. Pass
+--force to submit anyway.
+
 For epics/parent issues, automatically cascades to all open/in_progress
 descendants. Cascaded children don't require individual handoffs.
 
@@ -278,6 +283,8 @@ Supports bulk operations:
 			return err
 		}
 
+		force, _ := cmd.Flags().GetBool("force")
+
 		reviewed := 0
 		skipped := 0
 		for _, issueID := range args {
@@ -292,6 +299,16 @@ Supports bulk operations:
 				continue
 			}
 
+			if err := checkReviewReady(database, issue, force); err != nil {
+				if jsonOutput {
+					output.JSONError(output.ErrCodeConflict, err.Error())
+				} else {
+					output.Warning("%v", err)
+				}
+				skipped++
+				continue
+			}
+
 			// Handle --minor flag (read early: minor issues bypass review and
 			// don't need an auto-created handoff).
 			minor, _ := cmd.Flags().GetBool("minor")
@@ -402,6 +419,83 @@ Supports bulk operations:
 	},
 }
 
+// checkReviewReady refuses a review submission while the issue depends on
+// open issues or has unchecked acceptance criteria, unless force is set.
+func checkReviewReady(database *db.DB, issue *models.Issue, force bool) error {
+	if force {
+		return nil
+	}
+	open, err := database.GetOpenDependencies(issue.ID)
+	if err != nil {
+		return fmt.Errorf("cannot review %s: %w", issue.ID, err)
+	}
+	unchecked := uncheckedCriteria(issue.Acceptance)
+	if len(open) == 0 && len(unchecked) == 0 {
+		return nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "cannot review %s: work is outstanding (use --force to override)", issue.ID)
+	if len(open) > 0 {
+		fmt.Fprintf(&b, "\n  depends on open %s", strings.Join(open, ", "))
+	}
+	if len(unchecked) > 0 {
+		b.WriteString("\n  unchecked acceptance criteria:")
+		for _, c := range unchecked {
+			fmt.Fprintf(&b, "\n    - [ ] %s", c)
+		}
+	}
+	return errors.New(b.String())
+}
+
+// uncheckedCriteria returns the text of each unchecked Markdown task list
+// item ("- [ ] ...", also with "*", "+" or "1." markers) in acceptance.
+func uncheckedCriteria(acceptance string) []string {
+	var unchecked []string
+	for _, line := range strings.Split(acceptance, "\n") {
+		line = strings.TrimSpace(line)
+		marker, rest, ok := strings.Cut(line, " ")
+		if !ok || !isListMarker(marker) {
+			continue
+		}
+		if item, ok := strings.CutPrefix(strings.TrimSpace(rest), "[ ]"); ok {
+			unchecked = append(unchecked, strings.TrimSpace(item))
+		}
+	}
+	return unchecked
+}
+
+// isListMarker reports whether s is a Markdown bullet ("-", "*", "+") or
+// ordered list marker ("1.", "2)").
+func isListMarker(s string) bool {
+	switch s {
+	case "-", "*", "+":
+		return true
+	}
+	digits := strings.TrimRight(s, ".)")
+	if len(digits) != len(s)-1 || digits == "" {
+		return false
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// cascadeApprovalUp approves epic ancestors of issueID whose children are
+// all approved, printing each one.
+func cascadeApprovalUp(database *db.DB, issueID, sessionID string, jsonOutput bool) {
+	count, ids := database.CascadeUpParentApproval(issueID, sessionID)
+	if count == 0 || jsonOutput {
+		return
+	}
+	for _, id := range ids {
+		fmt.Printf("  ↑ Parent %s auto-approved (all children approved)\n", id)
+	}
+}
+
 func approvalReason(cmd *cobra.Command) string {
 	// Precedence: --reason > --message > --note > --notes > --comment
 	for _, flag := range []string{"reason", "message", "note", "notes", "comment"} {
@@ -413,6 +507,16 @@ func approvalReason(cmd *cobra.Command) string {
 	return ""
 }
 
+// checkReasonPolicy fails a close or reject that has no reason when the
+// project sets require_close_reason. flag names the option that supplies it.
+func checkReasonPolicy(baseDir, action, reason, flag string) error {
+	required, _ := config.GetRequireCloseReason(baseDir)
+	if required && strings.TrimSpace(reason) == "" {
+		return fmt.Errorf("a reason is required to %s issues in this project (require_close_reason): pass %s \"...\"", action, flag)
+	}
+	return nil
+}
+
 func describeStaleTransitionUpdate(database *db.DB, action, issueID string, err error, guidance func(*models.Issue) string) string {
 	var staleErr *db.StaleIssueStatusError
 	if !errors.As(err, &staleErr) {
@@ -609,7 +713,12 @@ Examples:
   td approve td-abc1 --self-review --reason "reviewed diff" # Trusted-mode self-review approve+close
 
 To surface issues reviewed by a sub-agent that you can close, use
-  td reviewable --include-approved`,
+  td reviewable --include-approved
+
+When the last child of an epic is closed, the epic is auto-closed. Set
+"cascade_approval": true in .todos/config.json to have the epic approved
+instead (reviewer recorded, logged as an approval) when every child was
+approved; this repeats up the epic chain.`,
 	GroupID: "workflow",
 	Args:    cobra.MinimumNArgs(0),
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -643,6 +752,10 @@ To surface issues reviewed by a sub-agent that you can close, use
 			output.Error("review_policy_mode: %v", err)
 			return err
 		}
+		cascadeApproval, err := config.GetCascadeApproval(baseDir)
+		if err != nil {
+			output.Warning("failed to read cascade_approval: %v", err)
+		}
 
 		// Record-only is only meaningful under delegated mode.
 		if recordOnly && mode != reviewpolicy.ModeDelegated {
@@ -764,7 +877,7 @@ To surface issues reviewed by a sub-agent that you can close, use
 			}
 
 			// Validate transition with state machine
-			sm := workflow.DefaultMachine()
+			sm := workflowMachine()
 			if issue.Status == models.StatusClosed {
 				message := describeReviewerNoop(database, "approve", issue)
 				if jsonOutput {
@@ -1029,6 +1142,9 @@ To surface issues reviewed by a sub-agent that you can close, use
 					fmt.Printf("APPROVED %s (closed by %s using review by %s)\n", issueID, sess.ID, activeApproval.ReviewerSession)
 				}
 
+				if cascadeApproval {
+					cascadeApprovalUp(database, issueID, sess.ID, jsonOutput)
+				}
 				if count, ids := database.CascadeUpParentStatus(issueID, models.StatusClosed, sess.ID); count > 0 {
 					if !jsonOutput {
 						for _, id := range ids {
@@ -1224,6 +1340,12 @@ To surface issues reviewed by a sub-agent that you can close, use
 				fmt.Printf("APPROVED %s (reviewer: %s)\n", issueID, sess.ID)
 			}
 
+			// Cascade approval up first (opt-in) so fully-approved epics are
+			// approved rather than merely auto-closed.
+			if cascadeApproval {
+				cascadeApprovalUp(database, issueID, sess.ID, jsonOutput)
+			}
+
 			// Cascade up: if all siblings are closed, update parent epic
 			if count, ids := database.CascadeUpParentStatus(issueID, models.StatusClosed, sess.ID); count > 0 {
 				if !jsonOutput {
@@ -1256,10 +1378,21 @@ var rejectCmd = &cobra.Command{
 	Use:   "reject [issue-id...]",
 	Short: "Reject and return to open",
 	Long: `Rejects the issue(s) and returns them to open status so they can be
-picked up again by td next.
+picked up again by td next. Rejected issues are flagged as rework: td next
+offers them ahead of other issues of the same priority and rework() matches
+them until they are submitted for review again. The reason is kept in the
+issue's log, and the rejection can be reverted with td undo.
+
+With --reassign, the rework is handed to a specific session (by ID or by
+session name) instead of going back to the pool: the issue is re-queued as
+its implementer, and td next run by that session offers it first.
 
 Supports bulk operations:
-  td reject td-abc1 td-abc2    # Reject multiple issues`,
+  td reject td-abc1 td-abc2    # Reject multiple issues
+
+Examples:
+  td reject td-abc1 -m "missing tests"
+  td reject td-abc1 --reassign alice -m "alice owns the parser"`,
 	GroupID: "workflow",
 	Args:    cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -1287,6 +1420,28 @@ Supports bulk operations:
 			return err
 		}
 
+		if err := checkReasonPolicy(baseDir, "reject", approvalReason(cmd), "--reason"); err != nil {
+			if jsonOutput {
+				output.JSONError(output.ErrCodeInvalidInput, err.Error())
+			} else {
+				output.Error("%v", err)
+			}
+			return err
+		}
+
+		var assignee *session.Session
+		if who, _ := cmd.Flags().GetString("reassign"); who != "" {
+			assignee, err = session.Resolve(database, who)
+			if err != nil {
+				if jsonOutput {
+					output.JSONError(output.ErrCodeNotFound, err.Error())
+				} else {
+					output.Error("%v", err)
+				}
+				return err
+			}
+		}
+
 		rejected := 0
 		skipped := 0
 		for _, issueID := range args {
@@ -1337,7 +1492,11 @@ Supports bulk operations:
 			issue.ImplementerSession = ""
 			issue.ReviewerSession = ""
 			issue.ReviewedAt = nil
+			issue.ClosedAt = nil
 			issue.ReviewRequestedBySession = ""
+			if assignee != nil {
+				issue.ImplementerSession = assignee.ID
+			}
 
 			if err := database.SupersedeActiveReviews(issueID); err != nil {
 				output.Warning("failed to supersede active reviews for %s: %v", issueID, err)
@@ -1368,6 +1527,16 @@ Supports bulk operations:
 			}); err != nil {
 				output.Warning("add log failed: %v", err)
 			}
+			if assignee != nil {
+				if err := database.AddLog(&models.Log{
+					IssueID:   issueID,
+					SessionID: sess.ID,
+					Message:   "Reassigned to " + assignee.Display(),
+					Type:      models.LogTypeProgress,
+				}); err != nil {
+					output.Warning("add log failed: %v", err)
+				}
+			}
 
 			if jsonOutput {
 				result := map[string]interface{}{
@@ -1379,7 +1548,12 @@ Supports bulk operations:
 				if reason != "" {
 					result["reason"] = reason
 				}
+				if assignee != nil {
+					result["reassigned_to"] = assignee.ID
+				}
 				output.JSON(result)
+			} else if assignee != nil {
+				fmt.Printf("REJECTED %s → open (reassigned to %s)\n", issueID, assignee.Display())
 			} else {
 				fmt.Printf("REJECTED %s → open\n", issueID)
 			}
@@ -1479,6 +1653,19 @@ Examples:
 		adminReason, _ := cmd.Flags().GetString("admin")
 		mode, _ := resolveReviewPolicyMode(baseDir)
 
+		closeReason := approvalReason(cmd)
+		if closeReason == "" {
+			closeReason = adminReason + selfCloseException
+		}
+		if err := checkReasonPolicy(baseDir, "close", closeReason, "--reason"); err != nil {
+			if isJSON {
+				output.JSONError(output.ErrCodeInvalidInput, err.Error())
+			} else {
+				output.Error("%v", err)
+			}
+			return err
+		}
+
 		closed := 0
 		skipped := 0
 		for _, issueID := range args {
@@ -1494,7 +1681,7 @@ Examples:
 			}
 
 			// Validate transition with state machine
-			sm := workflow.DefaultMachine()
+			sm := workflowMachine()
 			if !sm.IsValidTransition(issue.Status, models.StatusClosed) {
 				if isJSON {
 					output.JSONError(output.ErrCodeInvalidInput, fmt.Sprintf("cannot close %s: invalid transition from %s", issueID, issue.Status))
@@ -1716,6 +1903,7 @@ func init() {
 	reviewCmd.Flags().String("note", "", "Reason for submitting (alias for --reason)")
 	reviewCmd.Flags().String("notes", "", "Reason for submitting (alias for --reason)")
 	reviewCmd.Flags().Bool("minor", false, "Mark as minor task (allows self-review)")
+	reviewCmd.Flags().Bool("force", false, "Submit even with open dependencies or unchecked acceptance criteria")
 	approveCmd.Flags().StringP("reason", "m", "", "Reason for approval")
 	approveCmd.Flags().String("message", "", "Reason for approval (alias for --reason)")
 	approveCmd.Flags().StringP("comment", "c", "", "Reason for approval (alias for --message)")
@@ -1730,6 +1918,7 @@ func init() {
 	rejectCmd.Flags().String("message", "", "Reason for rejection (alias for --reason)")
 	rejectCmd.Flags().String("note", "", "Reason for rejection (alias for --reason)")
 	rejectCmd.Flags().String("notes", "", "Reason for rejection (alias for --reason)")
+	rejectCmd.Flags().String("reassign", "", "Hand the rework to this session (ID or name)")
 	closeCmd.Flags().StringP("reason", "m", "", "Reason for closing")
 	closeCmd.Flags().String("comment", "", "Reason for closing (alias for --reason)")
 	closeCmd.Flags().String("message", "", "Reason for closing (alias for --reason)")
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"io"
 	"os"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -1996,3 +1997,109 @@ func TestApproveAutoUnblockPartialDeps(t *testing.T) {
 		t.Errorf("dependent should remain blocked (A2 still open), got %s", updated.Status)
 	}
 }
+
+func TestUncheckedCriteria(t *testing.T) {
+	acceptance := `Done when:
+- [x] parser handles tabs
+- [ ] errors name the line
+* [ ]   docs updated
++ [X] changelog entry
+1. [ ] migration tested
+2) [ ] rollback tested
+-[ ] not a list item
+- plain bullet`
+	got := uncheckedCriteria(acceptance)
+	want := []string{"errors name the line", "docs updated", "migration tested", "rollback tested"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("uncheckedCriteria = %q, want %q", got, want)
+	}
+	if got := uncheckedCriteria(""); len(got) != 0 {
+		t.Errorf("uncheckedCriteria(\"\") = %q, want none", got)
+	}
+}
+
+// TestReviewRefusesOutstandingWork runs `td review` against issues with an
+// open dependency or unchecked acceptance criteria: refused without --force
+// and submitted with it.
+func TestReviewRefusesOutstandingWork(t *testing.T) {
+	saveAndRestoreGlobals(t)
+
+	dir := t.TempDir()
+	baseDir := dir
+	baseDirOverride = &baseDir
+
+	database, err := db.Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	create := func(issue *models.Issue) *models.Issue {
+		t.Helper()
+		if err := database.CreateIssue(issue); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue
+	}
+	review := func(id string, force bool) (models.Status, string) {
+		t.Helper()
+		_ = reviewCmd.Flags().Set("force", strconv.FormatBool(force))
+		t.Cleanup(func() { _ = reviewCmd.Flags().Set("force", "false") })
+		out := captureStdout(t, func() {
+			if err := reviewCmd.RunE(reviewCmd, []string{id}); err != nil {
+				t.Fatalf("reviewCmd.RunE failed: %v", err)
+			}
+		})
+		got, err := database.GetIssue(id)
+		if err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		return got.Status, out
+	}
+
+	dep := create(&models.Issue{Title: "Dependency", Status: models.StatusOpen})
+	waiting := create(&models.Issue{Title: "Waiting", Status: models.StatusInProgress})
+	if err := database.AddDependency(waiting.ID, dep.ID, "depends_on"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+	status, out := review(waiting.ID, false)
+	if status != models.StatusInProgress {
+		t.Errorf("review with open dependency: status = %s, want in_progress", status)
+	}
+	if !strings.Contains(out, "depends on open "+dep.ID) {
+		t.Errorf("refusal should name the open dependency, got %q", out)
+	}
+
+	unchecked := create(&models.Issue{
+		Title:      "Unchecked",
+		Status:     models.StatusInProgress,
+		Acceptance: "- [x] happy path\n- [ ] error path",
+	})
+	status, out = review(unchecked.ID, false)
+	if status != models.StatusInProgress {
+		t.Errorf("review with unchecked criteria: status = %s, want in_progress", status)
+	}
+	if !strings.Contains(out, "- [ ] error path") || strings.Contains(out, "happy path") {
+		t.Errorf("refusal should list only the unchecked criterion, got %q", out)
+	}
+
+	if status, _ := review(waiting.ID, true); status != models.StatusInReview {
+		t.Errorf("review --force with open dependency: status = %s, want in_review", status)
+	}
+	if status, _ := review(unchecked.ID, true); status != models.StatusInReview {
+		t.Errorf("review --force with unchecked criteria: status = %s, want in_review", status)
+	}
+
+	ready := create(&models.Issue{
+		Title:      "Ready",
+		Status:     models.StatusInProgress,
+		Acceptance: "- [x] done",
+	})
+	closedDep := create(&models.Issue{Title: "Closed dependency", Status: models.StatusClosed})
+	if err := database.AddDependency(ready.ID, closedDep.ID, "depends_on"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+	if status, _ := review(ready.ID, false); status != models.StatusInReview {
+		t.Errorf("review with closed dependency and checked criteria: status = %s, want in_review", status)
+	}
+}
@@ -2,6 +2,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
@@ -41,9 +42,12 @@ var rootCmd = &cobra.Command{
 	Long: `td - A minimalist local task and session management CLI designed for AI-assisted development workflows.
 
 Optimized for session continuity—capturing working state so new context windows can resume where previous ones stopped.`,
-	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 		cmdStartTime = time.Now()
 		runGatedSyncStartupHook(cmd)
+		loadLabelColors()
+		warnInvalidWorkflow()
+		return resolveIssueArgs(cmd, args)
 	},
 	PersistentPostRun: func(cmd *cobra.Command, args []string) {
 		// Capture executed command for analytics (logged in Execute() to avoid double logging)
@@ -67,6 +71,16 @@ func initLogFile() *os.File {
 	return f
 }
 
+// exitCodeError makes Execute exit with a specific status code without
+// printing an error message.
+type exitCodeError struct {
+	code int
+}
+
+func (e *exitCodeError) Error() string {
+	return fmt.Sprintf("exit status %d", e.code)
+}
+
 // Execute runs the root command
 func Execute() {
 	if f := initLogFile(); f != nil {
@@ -82,6 +96,13 @@ func Execute() {
 	logAnalytics(err)
 
 	if err != nil {
+		// Commands that report their outcome purely through the exit status
+		// (e.g. `td next --claim` with nothing to claim) exit quietly.
+		var exitErr *exitCodeError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.code)
+		}
+
 		args := os.Args[1:]
 
 		// Log agent error for analysis
@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/models"
+	"github.com/marcus/td/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// sessionReport summarizes what one session did, for auditing agent work.
+type sessionReport struct {
+	SessionID    string                `json:"session_id"`
+	Name         string                `json:"name,omitempty"`
+	Branch       string                `json:"branch,omitempty"`
+	AgentType    string                `json:"agent_type,omitempty"`
+	StartedAt    *time.Time            `json:"started_at,omitempty"`
+	LastActivity *time.Time            `json:"last_activity,omitempty"`
+	Issues       []sessionReportIssue  `json:"issues"`
+	Actions      []sessionReportAction `json:"actions"`
+	Logs         []models.Log          `json:"logs"`
+}
+
+// sessionReportIssue is an issue the session touched, with its current state.
+type sessionReportIssue struct {
+	ID      string        `json:"id"`
+	Title   string        `json:"title"`
+	Type    models.Type   `json:"type"`
+	Status  models.Status `json:"status"`
+	Deleted bool          `json:"deleted,omitempty"`
+	Actions []string      `json:"actions,omitempty"` // action types, oldest first
+}
+
+// sessionReportAction is one action_log entry written by the session.
+type sessionReportAction struct {
+	Timestamp  time.Time         `json:"timestamp"`
+	ActionType models.ActionType `json:"action_type"`
+	EntityType string            `json:"entity_type"`
+	EntityID   string            `json:"entity_id"`
+	Undone     bool              `json:"undone,omitempty"`
+}
+
+// isSessionIDArg reports whether a `td session` argument is a session ID to
+// report on rather than a name to set.
+func isSessionIDArg(arg string) bool {
+	return strings.HasPrefix(arg, "ses_")
+}
+
+// buildSessionReport collects the issues, actions and logs for sessionID.
+// Issues are listed in the order the session first touched them.
+func buildSessionReport(database *db.DB, sessionID string) (*sessionReport, error) {
+	report := &sessionReport{
+		SessionID: sessionID,
+		Issues:    []sessionReportIssue{},
+		Actions:   []sessionReportAction{},
+		Logs:      []models.Log{},
+	}
+
+	if row, err := database.GetSessionByID(sessionID); err != nil {
+		return nil, err
+	} else if row != nil {
+		report.Name = row.Name
+		report.Branch = row.Branch
+		report.AgentType = row.AgentType
+		if !row.StartedAt.IsZero() {
+			report.StartedAt = &row.StartedAt
+		}
+		if !row.LastActivity.IsZero() {
+			report.LastActivity = &row.LastActivity
+		}
+	}
+
+	actions, err := database.GetRecentActions(sessionID, 0)
+	if err != nil {
+		return nil, err
+	}
+	logs, err := database.GetLogsBySession(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	logged, err := database.GetIssueSessionLog(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var order []string
+	issueActions := make(map[string][]string)
+	touch := func(id string) {
+		if _, ok := issueActions[id]; !ok && id != "" {
+			issueActions[id] = nil
+			order = append(order, id)
+		}
+	}
+
+	// GetRecentActions is newest first
+	for i := len(actions) - 1; i >= 0; i-- {
+		a := actions[i]
+		report.Actions = append(report.Actions, sessionReportAction{
+			Timestamp:  a.Timestamp,
+			ActionType: a.ActionType,
+			EntityType: a.EntityType,
+			EntityID:   a.EntityID,
+			Undone:     a.Undone,
+		})
+		if a.EntityType == "issue" || a.EntityType == "issues" {
+			touch(a.EntityID)
+			issueActions[a.EntityID] = append(issueActions[a.EntityID], string(a.ActionType))
+		}
+	}
+	for _, l := range logs {
+		touch(l.IssueID)
+	}
+	for _, id := range logged {
+		touch(id)
+	}
+	report.Logs = append(report.Logs, logs...)
+
+	for _, id := range order {
+		entry := sessionReportIssue{ID: id, Actions: issueActions[id]}
+		if issue, err := database.GetIssue(id); err == nil {
+			entry.Title = issue.Title
+			entry.Type = issue.Type
+			entry.Status = issue.Status
+			entry.Deleted = issue.DeletedAt != nil
+		}
+		report.Issues = append(report.Issues, entry)
+	}
+
+	return report, nil
+}
+
+// runSessionReport prints the report for sessionID.
+func runSessionReport(cmd *cobra.Command, database *db.DB, sessionID string) error {
+	report, err := buildSessionReport(database, sessionID)
+	if err != nil {
+		output.Error("failed to build session report: %v", err)
+		return err
+	}
+
+	if jsonMode(cmd) {
+		return output.JSON(report)
+	}
+
+	if report.StartedAt == nil && len(report.Actions) == 0 && len(report.Logs) == 0 {
+		fmt.Printf("No activity recorded for session %s\n", sessionID)
+		return nil
+	}
+
+	header := report.SessionID
+	if report.Name != "" {
+		header += fmt.Sprintf(" %q", report.Name)
+	}
+	fmt.Printf("SESSION: %s\n", header)
+	if report.Branch != "" || report.AgentType != "" {
+		fmt.Printf("BRANCH: %s  AGENT: %s\n", report.Branch, report.AgentType)
+	}
+	if report.StartedAt != nil {
+		fmt.Printf("STARTED: %s\n", report.StartedAt.Format("2006-01-02 15:04"))
+	}
+	if report.LastActivity != nil {
+		fmt.Printf("LAST ACTIVITY: %s\n", report.LastActivity.Format("2006-01-02 15:04"))
+	}
+
+	fmt.Printf("\nISSUES TOUCHED (%d):\n", len(report.Issues))
+	for _, issue := range report.Issues {
+		status := string(issue.Status)
+		if issue.Deleted {
+			status = "deleted"
+		} else if status == "" {
+			status = "missing"
+		}
+		line := fmt.Sprintf("  %-12s %-12s %s", issue.ID, status, issue.Title)
+		if len(issue.Actions) > 0 {
+			line += fmt.Sprintf("  [%s]", strings.Join(issue.Actions, ", "))
+		}
+		fmt.Println(line)
+	}
+
+	fmt.Printf("\nACTIONS (%d):\n", len(report.Actions))
+	for _, a := range report.Actions {
+		undone := ""
+		if a.Undone {
+			undone = " (undone)"
+		}
+		fmt.Printf("  %s  %-16s %s %s%s\n", a.Timestamp.Local().Format("01-02 15:04"), a.ActionType, a.EntityType, a.EntityID, undone)
+	}
+
+	fmt.Printf("\nLOGS (%d):\n", len(report.Logs))
+	for _, l := range report.Logs {
+		fmt.Printf("  %s  %-12s [%s] %s\n", l.Timestamp.Local().Format("01-02 15:04"), l.IssueID, l.Type, l.Message)
+	}
+
+	return nil
+}
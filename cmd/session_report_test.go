@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/models"
+)
+
+func TestBuildSessionReport_CreatedAndClosedIssue(t *testing.T) {
+	database, err := db.Initialize(t.TempDir())
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	const sessionID = "ses_audit1"
+	issue := &models.Issue{Title: "Fix login redirect loop", Type: models.TypeBug}
+	if err := database.CreateIssueLogged(issue, sessionID); err != nil {
+		t.Fatalf("CreateIssueLogged: %v", err)
+	}
+	if err := database.AddLog(&models.Log{IssueID: issue.ID, SessionID: sessionID, Message: "Found the cookie bug", Type: models.LogTypeProgress}); err != nil {
+		t.Fatalf("AddLog: %v", err)
+	}
+	now := time.Now()
+	issue.Status = models.StatusClosed
+	issue.ClosedAt = &now
+	if err := database.UpdateIssueLogged(issue, sessionID, models.ActionClose); err != nil {
+		t.Fatalf("UpdateIssueLogged: %v", err)
+	}
+
+	// Another session's work must not leak into the report
+	other := &models.Issue{Title: "Unrelated work item"}
+	if err := database.CreateIssueLogged(other, "ses_other"); err != nil {
+		t.Fatalf("CreateIssueLogged: %v", err)
+	}
+
+	if !isSessionIDArg(sessionID) || isSessionIDArg("refactor-auth") {
+		t.Fatal("isSessionIDArg misclassified an argument")
+	}
+
+	report, err := buildSessionReport(database, sessionID)
+	if err != nil {
+		t.Fatalf("buildSessionReport: %v", err)
+	}
+
+	if len(report.Issues) != 1 {
+		t.Fatalf("Issues = %+v, want only %s", report.Issues, issue.ID)
+	}
+	got := report.Issues[0]
+	if got.ID != issue.ID || got.Status != models.StatusClosed || got.Title != issue.Title {
+		t.Errorf("issue entry = %+v, want %s closed", got, issue.ID)
+	}
+	if len(got.Actions) != 2 || got.Actions[0] != string(models.ActionCreate) || got.Actions[1] != string(models.ActionClose) {
+		t.Errorf("issue actions = %v, want [create close]", got.Actions)
+	}
+
+	if len(report.Actions) != 2 || report.Actions[0].ActionType != models.ActionCreate || report.Actions[1].ActionType != models.ActionClose {
+		t.Errorf("Actions = %+v, want create then close", report.Actions)
+	}
+	if len(report.Logs) != 1 || report.Logs[0].Message != "Found the cookie bug" {
+		t.Errorf("Logs = %+v, want the one progress log", report.Logs)
+	}
+}
@@ -181,6 +181,9 @@ Examples:
 				"updated_at":                  issue.UpdatedAt,
 				"minor":                       issue.Minor,
 			}
+			if issue.CreatedBy != "" {
+				result["created_by"] = issue.CreatedBy
+			}
 			if issue.ReviewedAt != nil {
 				result["reviewed_at"] = issue.ReviewedAt
 			}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/marcus/td/internal/db"
 	"github.com/marcus/td/internal/git"
@@ -18,6 +19,9 @@ var startCmd = &cobra.Command{
 	Short:   "Begin work on issue(s)",
 	Long: `Records current session as implementer and captures git state.
 
+Blocked issues are refused while any issue they depend on is still open, or
+when they were blocked by hand; pass --force to start them anyway.
+
 Examples:
   td start td-abc1                    # Start single issue
   td start td-abc1 td-abc2 td-abc3    # Start multiple issues`,
@@ -88,7 +92,7 @@ Examples:
 			}
 
 			// Validate transition with state machine
-			sm := workflow.DefaultMachine()
+			sm := workflowMachine()
 			ctx := &workflow.TransitionContext{
 				Issue:      issue,
 				FromStatus: issue.Status,
@@ -104,9 +108,8 @@ Examples:
 				continue
 			}
 
-			// Check if blocked without force (preserving existing behavior)
-			if issue.Status == models.StatusBlocked && !force {
-				emitWarn("cannot start blocked issue: %s (use --force to override)", issueID)
+			if err := checkBlockedStart(database, issue, force); err != nil {
+				emitWarn("%v", err)
 				skipped++
 				continue
 			}
@@ -217,3 +220,27 @@ func init() {
 	startCmd.Flags().String("reason", "", "Reason for starting work")
 	startCmd.Flags().Bool("force", false, "Force start even if blocked")
 }
+
+// checkBlockedStart refuses to move a blocked issue to in_progress unless
+// force is set or every issue it depends on is closed. Issues blocked by hand,
+// with no dependencies, always need force.
+func checkBlockedStart(database *db.DB, issue *models.Issue, force bool) error {
+	if issue.Status != models.StatusBlocked || force {
+		return nil
+	}
+	open, err := database.GetOpenDependencies(issue.ID)
+	if err != nil {
+		return fmt.Errorf("cannot start %s: %w", issue.ID, err)
+	}
+	if len(open) > 0 {
+		return fmt.Errorf("cannot start %s: depends on open %s (use --force to override)", issue.ID, strings.Join(open, ", "))
+	}
+	deps, err := database.GetDependencies(issue.ID)
+	if err != nil {
+		return fmt.Errorf("cannot start %s: %w", issue.ID, err)
+	}
+	if len(deps) == 0 {
+		return fmt.Errorf("cannot start blocked issue: %s (use --force to override)", issue.ID)
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"strconv"
 	"testing"
 
 	"github.com/marcus/td/internal/db"
@@ -385,3 +386,76 @@ func TestStartMixedValidAndInvalid(t *testing.T) {
 		t.Error("Valid issue should be started despite invalid issue")
 	}
 }
+
+// TestStartBlockedIssueWaitsOnDependencies runs `td start` against a blocked
+// issue whose dependency is still open: refused without --force, allowed
+// once the dependency closes, and allowed with --force regardless.
+func TestStartBlockedIssueWaitsOnDependencies(t *testing.T) {
+	saveAndRestoreGlobals(t)
+
+	dir := t.TempDir()
+	baseDir := dir
+	baseDirOverride = &baseDir
+
+	database, err := db.Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	newBlocked := func(title string) (*models.Issue, *models.Issue) {
+		t.Helper()
+		dep := &models.Issue{Title: title + " dependency", Status: models.StatusOpen}
+		issue := &models.Issue{Title: title, Status: models.StatusBlocked}
+		if err := database.CreateIssue(dep); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		if err := database.CreateIssue(issue); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		if err := database.AddDependency(issue.ID, dep.ID, "depends_on"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+		return issue, dep
+	}
+	start := func(id string, force bool) models.Status {
+		t.Helper()
+		_ = startCmd.Flags().Set("force", strconv.FormatBool(force))
+		t.Cleanup(func() { _ = startCmd.Flags().Set("force", "false") })
+		captureStdout(t, func() {
+			if err := startCmd.RunE(startCmd, []string{id}); err != nil {
+				t.Fatalf("startCmd.RunE failed: %v", err)
+			}
+		})
+		got, err := database.GetIssue(id)
+		if err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		return got.Status
+	}
+
+	refused, dep := newBlocked("Refused")
+	if status := start(refused.ID, false); status != models.StatusBlocked {
+		t.Fatalf("start with open dependency: status = %s, want blocked", status)
+	}
+	dep.Status = models.StatusClosed
+	if err := database.UpdateIssue(dep); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if status := start(refused.ID, false); status != models.StatusInProgress {
+		t.Errorf("start after dependency closed: status = %s, want in_progress", status)
+	}
+
+	forced, _ := newBlocked("Forced")
+	if status := start(forced.ID, true); status != models.StatusInProgress {
+		t.Errorf("start --force: status = %s, want in_progress", status)
+	}
+
+	manual := &models.Issue{Title: "Blocked by hand", Status: models.StatusBlocked}
+	if err := database.CreateIssue(manual); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if status := start(manual.ID, false); status != models.StatusBlocked {
+		t.Errorf("start manually blocked issue: status = %s, want blocked", status)
+	}
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -10,6 +11,7 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/marcus/td/internal/config"
 	"github.com/marcus/td/internal/db"
 	"github.com/marcus/td/internal/features"
 	"github.com/marcus/td/internal/output"
@@ -100,6 +102,7 @@ var syncCmd = &cobra.Command{
 		serverURL := syncconfig.GetServerURL()
 		apiKey := syncconfig.GetAPIKey()
 		client := syncclient.New(serverURL, apiKey, deviceID)
+		client.PushBatchSize = syncconfig.GetPushBatchSize()
 
 		if statusOnly {
 			return runSyncStatus(database, client, syncState)
@@ -136,10 +139,61 @@ var syncCmd = &cobra.Command{
 			}
 		}
 
+		if !pushOnly {
+			pullProjectDefaults(baseDir, client, syncState.ProjectID)
+			pullProjectSettings(baseDir, client, syncState.ProjectID)
+		}
+
 		return nil
 	},
 }
 
+// pullProjectDefaults caches the project's default monitor query and board
+// locally. Best-effort: servers without the endpoint or a failed request
+// leave the cached values unchanged.
+func pullProjectDefaults(baseDir string, client *syncclient.Client, projectID string) {
+	defaults, err := client.GetProjectDefaults(projectID)
+	if err != nil {
+		slog.Debug("sync: fetch project defaults", "err", err)
+		return
+	}
+	if err := config.SetProjectDefaults(baseDir, defaults.Query, defaults.Board); err != nil {
+		slog.Debug("sync: save project defaults", "err", err)
+	}
+}
+
+// pullProjectSettings applies the project's shared settings to the local
+// config. Best-effort, like pullProjectDefaults.
+func pullProjectSettings(baseDir string, client *syncclient.Client, projectID string) {
+	settings, err := client.GetProjectSettings(projectID)
+	if err != nil {
+		slog.Debug("sync: fetch project settings", "err", err)
+		return
+	}
+	if err := applyProjectSettings(baseDir, settings); err != nil {
+		slog.Debug("sync: save project settings", "err", err)
+	}
+}
+
+// applyProjectSettings caches synced project settings in .todos/config.json.
+func applyProjectSettings(baseDir string, settings *syncclient.ProjectSettings) error {
+	return config.ApplyProjectSettings(baseDir, settings.RequireCloseReason, settings.Workflow)
+}
+
+// applySnapshotSettings applies the project settings a bootstrap snapshot
+// carries, so a new client starts with them before its first settings pull.
+func applySnapshotSettings(database *db.DB) error {
+	raw, err := database.GetSnapshotProjectSettings()
+	if err != nil || raw == nil {
+		return err
+	}
+	var settings syncclient.ProjectSettings
+	if err := json.Unmarshal(raw, &settings); err != nil {
+		return fmt.Errorf("parse project settings: %w", err)
+	}
+	return applyProjectSettings(database.BaseDir(), &settings)
+}
+
 func runSyncStatus(database *db.DB, client *syncclient.Client, state *db.SyncState) error {
 	pending, err := database.CountPendingEvents()
 	if err != nil {
@@ -214,8 +268,19 @@ func runBootstrap(database *db.DB, client *syncclient.Client, state *db.SyncStat
 
 	dbPath := filepath.Join(database.BaseDir(), ".todos", "issues.db")
 	backupPath := dbPath + ".pre-snapshot-backup"
+	downloadPath := dbPath + ".snapshot-download"
 	baseDir := database.BaseDir()
 
+	// Stage the download next to the live DB and make sure SQLite can read
+	// all of it before touching anything the user already has.
+	if err := os.WriteFile(downloadPath, snapshot.Data, 0644); err != nil {
+		return nil, fmt.Errorf("stage snapshot: %w", err)
+	}
+	defer os.Remove(downloadPath)
+	if err := db.CheckIntegrity(downloadPath); err != nil {
+		return nil, fmt.Errorf("invalid snapshot: %w", err)
+	}
+
 	// Close current DB before overwriting
 	database.Close()
 
@@ -228,8 +293,8 @@ func runBootstrap(database *db.DB, client *syncclient.Client, state *db.SyncStat
 		return reopened, fmt.Errorf("backup db: %w", err)
 	}
 
-	// Write snapshot
-	if err := os.WriteFile(dbPath, snapshot.Data, 0644); err != nil {
+	// Swap in the verified snapshot
+	if err := os.Rename(downloadPath, dbPath); err != nil {
 		os.Rename(backupPath, dbPath)
 		reopened, reopenErr := db.Open(baseDir)
 		if reopenErr != nil {
@@ -265,6 +330,10 @@ func runBootstrap(database *db.DB, client *syncclient.Client, state *db.SyncStat
 		return reopened2, fmt.Errorf("update sync_state: %w", err)
 	}
 
+	if err := applySnapshotSettings(reopened); err != nil {
+		output.Warning("apply project settings from snapshot: %v", err)
+	}
+
 	fmt.Printf("Bootstrap complete (seq %d).\n", snapshot.SnapshotSeq)
 	return reopened, nil
 }
@@ -292,8 +361,6 @@ func copyFile(src, dst string) error {
 	return out.Sync()
 }
 
-const pushBatchSize = 500
-
 func filterEventsForSync(events []tdsync.Event, validator tdsync.EntityValidator) []tdsync.Event {
 	if validator == nil {
 		return events
@@ -338,85 +405,74 @@ func runPush(database *db.DB, client *syncclient.Client, state *db.SyncState, de
 		return nil
 	}
 
-	var allAcks []tdsync.Ack
-	var maxActionID int64
-	totalAccepted := 0
-	var allHistoryEntries []db.SyncHistoryEntry
-
-	// Push in batches to stay within server limits
-	for i := 0; i < len(events); i += pushBatchSize {
-		end := i + pushBatchSize
-		if end > len(events) {
-			end = len(events)
-		}
-		batch := events[i:end]
-
-		pushReq := &syncclient.PushRequest{
-			DeviceID:  deviceID,
-			SessionID: sess.ID,
-		}
-		for _, ev := range batch {
-			pushReq.Events = append(pushReq.Events, syncclient.EventInput{
-				ClientActionID:  ev.ClientActionID,
-				ActionType:      ev.ActionType,
-				EntityType:      ev.EntityType,
-				EntityID:        ev.EntityID,
-				Payload:         ev.Payload,
-				ClientTimestamp: ev.ClientTimestamp.Format(time.RFC3339),
-			})
-		}
+	pushReq := &syncclient.PushRequest{
+		DeviceID:  deviceID,
+		SessionID: sess.ID,
+	}
+	for _, ev := range events {
+		pushReq.Events = append(pushReq.Events, syncclient.EventInput{
+			ClientActionID:  ev.ClientActionID,
+			ActionType:      ev.ActionType,
+			EntityType:      ev.EntityType,
+			EntityID:        ev.EntityID,
+			Payload:         ev.Payload,
+			ClientTimestamp: ev.ClientTimestamp.Format(time.RFC3339),
+		})
+	}
 
-		pushResp, err := client.Push(state.ProjectID, pushReq)
-		if err != nil {
-			if errors.Is(err, syncclient.ErrUnauthorized) {
-				output.Error("unauthorized - re-login may be needed")
-			} else {
-				output.Error("push: %v", err)
-			}
-			return err
+	// The client splits this into server-sized batches
+	pushResp, err := client.Push(state.ProjectID, pushReq)
+	if err != nil {
+		if errors.Is(err, syncclient.ErrUnauthorized) {
+			output.Error("unauthorized - re-login may be needed")
+		} else {
+			output.Error("push: %v", err)
 		}
+		return err
+	}
+	totalAccepted := pushResp.Accepted
 
-		totalAccepted += pushResp.Accepted
-
-		for _, a := range pushResp.Acks {
+	var allAcks []tdsync.Ack
+	var maxActionID int64
+	for _, a := range pushResp.Acks {
+		allAcks = append(allAcks, tdsync.Ack{
+			ClientActionID: a.ClientActionID,
+			ServerSeq:      a.ServerSeq,
+		})
+		if a.ClientActionID > maxActionID {
+			maxActionID = a.ClientActionID
+		}
+	}
+	// Treat duplicate rejections as idempotent success — mark them synced too
+	for _, r := range pushResp.Rejected {
+		if r.Reason == "duplicate" && r.ServerSeq > 0 {
 			allAcks = append(allAcks, tdsync.Ack{
-				ClientActionID: a.ClientActionID,
-				ServerSeq:      a.ServerSeq,
+				ClientActionID: r.ClientActionID,
+				ServerSeq:      r.ServerSeq,
 			})
-			if a.ClientActionID > maxActionID {
-				maxActionID = a.ClientActionID
-			}
-		}
-		// Treat duplicate rejections as idempotent success — mark them synced too
-		for _, r := range pushResp.Rejected {
-			if r.Reason == "duplicate" && r.ServerSeq > 0 {
-				allAcks = append(allAcks, tdsync.Ack{
-					ClientActionID: r.ClientActionID,
-					ServerSeq:      r.ServerSeq,
-				})
-				if r.ClientActionID > maxActionID {
-					maxActionID = r.ClientActionID
-				}
+			if r.ClientActionID > maxActionID {
+				maxActionID = r.ClientActionID
 			}
 		}
+	}
 
-		// Build history entries for this batch
-		ackMap := make(map[int64]int64)
-		for _, a := range pushResp.Acks {
-			ackMap[a.ClientActionID] = a.ServerSeq
-		}
-		for _, ev := range batch {
-			if seq, ok := ackMap[ev.ClientActionID]; ok {
-				allHistoryEntries = append(allHistoryEntries, db.SyncHistoryEntry{
-					Direction:  "push",
-					ActionType: ev.ActionType,
-					EntityType: ev.EntityType,
-					EntityID:   ev.EntityID,
-					ServerSeq:  seq,
-					DeviceID:   deviceID,
-					Timestamp:  time.Now(),
-				})
-			}
+	// Build history entries for accepted events
+	var allHistoryEntries []db.SyncHistoryEntry
+	ackMap := make(map[int64]int64)
+	for _, a := range pushResp.Acks {
+		ackMap[a.ClientActionID] = a.ServerSeq
+	}
+	for _, ev := range events {
+		if seq, ok := ackMap[ev.ClientActionID]; ok {
+			allHistoryEntries = append(allHistoryEntries, db.SyncHistoryEntry{
+				Direction:  "push",
+				ActionType: ev.ActionType,
+				EntityType: ev.EntityType,
+				EntityID:   ev.EntityID,
+				ServerSeq:  seq,
+				DeviceID:   deviceID,
+				Timestamp:  time.Now(),
+			})
 		}
 	}
 
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/marcus/td/internal/api"
+	"github.com/marcus/td/internal/config"
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/models"
+	"github.com/marcus/td/internal/serverdb"
+	"github.com/marcus/td/internal/syncclient"
+)
+
+// TestProjectSettingsReachClient runs a sync server, sets project settings
+// on it, and checks that a new client picks them up when it bootstraps from
+// a snapshot and again when the settings change on a later sync, without
+// overwriting its local settings.
+func TestProjectSettingsReachClient(t *testing.T) {
+	t.Setenv("TD_SYNC_SNAPSHOT_THRESHOLD", "1")
+	serverDir := t.TempDir()
+
+	store, err := serverdb.Open(filepath.Join(serverDir, "server.db"))
+	if err != nil {
+		t.Fatalf("open server db: %v", err)
+	}
+	defer store.Close()
+	projectDir := filepath.Join(serverDir, "projects")
+	if err := os.MkdirAll(projectDir, 0755); err != nil {
+		t.Fatalf("create project dir: %v", err)
+	}
+	srv, err := api.NewServer(api.Config{
+		RateLimitAuth:  100000,
+		RateLimitPush:  100000,
+		RateLimitPull:  100000,
+		RateLimitOther: 100000,
+		ServerDBPath:   filepath.Join(serverDir, "server.db"),
+		ProjectDataDir: projectDir,
+	}, store)
+	if err != nil {
+		t.Fatalf("create server: %v", err)
+	}
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	user, err := store.CreateUser("settings-client@test.com")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	token, _, err := store.GenerateAPIKey(user.ID, "test", "sync", nil)
+	if err != nil {
+		t.Fatalf("generate api key: %v", err)
+	}
+	client := syncclient.New(ts.URL, token, "dev-settings")
+
+	project, err := client.CreateProject("settings", "")
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	if _, err := client.Push(project.ID, &syncclient.PushRequest{
+		DeviceID: "dev-other", SessionID: "ses-other",
+		Events: []syncclient.EventInput{{
+			ClientActionID: 1, ActionType: "create", EntityType: "issues", EntityID: "td-000001",
+			Payload:         json.RawMessage(`{"schema_version":1,"new_data":{"title":"one","status":"open"}}`),
+			ClientTimestamp: "2025-01-01T00:00:00Z",
+		}},
+	}); err != nil {
+		t.Fatalf("push: %v", err)
+	}
+
+	putSettings := func(body string) {
+		t.Helper()
+		req, err := http.NewRequest("PUT", ts.URL+"/v1/projects/"+project.ID+"/settings", bytes.NewBufferString(body))
+		if err != nil {
+			t.Fatalf("build request: %v", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("put settings: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("put settings: status %d", resp.StatusCode)
+		}
+	}
+	putSettings(`{"require_close_reason":true,"workflow":{"statuses":["qa"],"transitions":[{"from":"in_progress","to":"qa"}]}}`)
+
+	// A new client bootstraps from the snapshot
+	dir := t.TempDir()
+	database, err := db.Initialize(dir)
+	if err != nil {
+		t.Fatalf("init db: %v", err)
+	}
+	if err := database.SetSyncState(project.ID); err != nil {
+		t.Fatalf("set sync state: %v", err)
+	}
+	state, err := database.GetSyncState()
+	if err != nil {
+		t.Fatalf("get sync state: %v", err)
+	}
+	var bootstrapped *db.DB
+	captureStdout(t, func() {
+		bootstrapped, err = runBootstrap(database, client, state)
+	})
+	if err != nil {
+		t.Fatalf("bootstrap: %v", err)
+	}
+	bootstrapped.Close()
+
+	if required, _ := config.GetRequireCloseReason(dir); !required {
+		t.Error("require_close_reason not applied after bootstrap")
+	}
+	if wf, _ := config.GetWorkflow(dir); wf == nil || len(wf.Statuses) != 1 || wf.Statuses[0] != "qa" {
+		t.Fatalf("workflow after bootstrap = %+v", wf)
+	}
+
+	// Local settings win over the server's, and survive a later td sync
+	if err := config.SetDefaultQuery(dir, "labels ~ mine"); err != nil {
+		t.Fatalf("set default query: %v", err)
+	}
+	cfg, err := config.Load(dir)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	cfg.Workflow = &models.WorkflowConfig{Statuses: []models.Status{"local"}}
+	if err := config.Save(dir, cfg); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+	putSettings(`{"require_close_reason":false}`)
+
+	saveAndRestoreGlobals(t)
+	baseDirOverride = &dir
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("TD_SYNC_URL", ts.URL)
+	t.Setenv("TD_AUTH_KEY", token)
+	captureStdout(t, func() {
+		if err := syncCmd.RunE(syncCmd, nil); err != nil {
+			t.Errorf("td sync: %v", err)
+		}
+	})
+
+	if q, _ := config.GetDefaultQuery(dir); q != "labels ~ mine" {
+		t.Errorf("default query after sync = %q, want the local one", q)
+	}
+	if wf, _ := config.GetWorkflow(dir); wf == nil || len(wf.Statuses) != 1 || wf.Statuses[0] != "local" {
+		t.Errorf("workflow after sync = %+v, want the local one", wf)
+	}
+	if required, _ := config.GetRequireCloseReason(dir); required {
+		t.Error("require_close_reason change on the server did not reach the client")
+	}
+}
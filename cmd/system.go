@@ -7,9 +7,11 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/marcus/td/internal/config"
 	"github.com/marcus/td/internal/db"
 	"github.com/marcus/td/internal/models"
 	"github.com/marcus/td/internal/output"
@@ -223,8 +225,18 @@ var whoamiCmd = &cobra.Command{
 }
 
 var sessionNameCmd = &cobra.Command{
-	Use:     "session [name]",
-	Short:   "Name session, or --new at context start (not mid-work—bypasses review)",
+	Use:   "session [name | session-id]",
+	Short: "Name session, or --new at context start (not mid-work—bypasses review)",
+	Long: `Names the current session, or starts a new one with --new.
+
+Given a session ID (ses_...), prints a report of what that session did
+instead: issues touched with their current status, actions taken, and logs
+written. Use --json for machine-readable output.
+
+Examples:
+  td session refactor-auth      # Name the current session
+  td session --new              # Start a fresh session
+  td session ses_a1b2c3         # Audit what ses_a1b2c3 did`,
 	GroupID: "session",
 	Args:    cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -239,6 +251,10 @@ var sessionNameCmd = &cobra.Command{
 
 		newSession, _ := cmd.Flags().GetBool("new")
 
+		if !newSession && len(args) == 1 && isSessionIDArg(args[0]) {
+			return runSessionReport(cmd, database, args[0])
+		}
+
 		if newSession {
 			// Force create a new session
 			sess, err := session.ForceNewSession(database)
@@ -412,8 +428,27 @@ var sessionCleanupCmd = &cobra.Command{
 }
 
 var exportCmd = &cobra.Command{
-	Use:     "export",
-	Short:   "Export database",
+	Use:   "export",
+	Short: "Export database",
+	Long: `Export issues as JSON (with logs, handoffs, dependencies and files),
+Markdown or CSV, or the whole project as a SQLite file.
+
+--format sqlite writes a compacted, consistent copy of the database, safe to
+take while td is running. Sync links, sync history and per-session focus are
+left out, so the copy can be shared and opened as a standalone project. It
+needs --output and won't overwrite an existing file.
+
+--since exports only the issues changed after a cursor, read from the action
+log: an action sequence number, a timestamp (RFC3339 or YYYY-MM-DD), or
+"last" for the cursor recorded by the previous JSON export. Each JSON export
+records a new cursor. Apply a delta to another project with td import --merge.
+
+Examples:
+  td export -o backup.json                         # full export
+  td export --since last -o delta.json             # changes since last export
+  td export --since 2026-01-31 -o january.json     # changes after a date
+  td import --merge delta.json                     # apply a delta
+  td export --format sqlite -o copy.db             # portable database copy`,
 	GroupID: "system",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		baseDir := getBaseDir()
@@ -429,63 +464,79 @@ var exportCmd = &cobra.Command{
 		outputPath, _ := cmd.Flags().GetString("output")
 		includeAll, _ := cmd.Flags().GetBool("all")
 		renderMarkdown, _ := cmd.Flags().GetBool("render-markdown")
+		sinceStr, _ := cmd.Flags().GetString("since")
+		if outputPath == "" {
+			outputPath, _ = cmd.Flags().GetString("out")
+		}
 
-		opts := db.ListIssuesOptions{}
-		if includeAll {
-			opts.IncludeDeleted = true
+		if format == "sqlite" {
+			if outputPath == "" {
+				err := fmt.Errorf("--format sqlite needs --output")
+				output.Error("%v", err)
+				return err
+			}
+			if sinceStr != "" {
+				err := fmt.Errorf("--since is not supported with --format sqlite")
+				output.Error("%v", err)
+				return err
+			}
+			if err := database.ExportSQLite(outputPath); err != nil {
+				output.Error("failed to export database: %v", err)
+				return err
+			}
+			fmt.Printf("Exported to %s\n", outputPath)
+			return nil
 		}
 
-		issues, err := database.ListIssues(opts)
+		// Read the cursor before the issues so changes made during the
+		// export land in the next delta rather than being skipped.
+		cursor, err := database.GetActionSeq()
 		if err != nil {
-			output.Error("failed to list issues: %v", err)
+			output.Error("failed to read action log: %v", err)
 			return err
 		}
 
-		var data []byte
+		var issues []models.Issue
+		if sinceStr != "" {
+			issues, err = changedIssuesSince(database, baseDir, sinceStr)
+			if err != nil {
+				output.Error("%v", err)
+				return err
+			}
+		} else {
+			opts := db.ListIssuesOptions{}
+			if includeAll {
+				opts.IncludeDeleted = true
+			}
 
-		if format == "json" {
-			// Build full export with logs and handoffs
-			exportData := make([]map[string]interface{}, 0)
-			for _, issue := range issues {
-				logs, _ := database.GetLogs(issue.ID, 0)
-				handoffs, _ := database.GetHandoffs(issue.ID)
-				deps, _ := database.GetIssueDependencyRelations(issue.ID)
-				files, _ := database.GetLinkedFiles(issue.ID)
-
-				item := map[string]interface{}{
-					"issue":        issue,
-					"logs":         logs,
-					"handoffs":     handoffs,
-					"dependencies": deps,
-					"files":        files,
-				}
-				exportData = append(exportData, item)
+			issues, err = database.ListIssues(opts)
+			if err != nil {
+				output.Error("failed to list issues: %v", err)
+				return err
 			}
+		}
+
+		var data []byte
 
-			data, err = json.MarshalIndent(exportData, "", "  ")
+		if format == "json" {
+			data, err = exportIssuesJSON(database, issues)
 			if err != nil {
 				output.Error("failed to marshal: %v", err)
 				return err
 			}
+			if err := config.SetLastExportSeq(baseDir, cursor); err != nil {
+				output.Warning("failed to record export cursor: %v", err)
+			}
+		} else if format == "csv" {
+			csvData, err := output.FormatIssuesCSV(issues)
+			if err != nil {
+				output.Error("failed to format csv: %v", err)
+				return err
+			}
+			data = []byte(csvData)
 		} else {
 			// Markdown format
-			md := "# Issues Export\n\n"
-			for _, issue := range issues {
-				md += fmt.Sprintf("## %s: %s\n\n", issue.ID, issue.Title)
-				md += fmt.Sprintf("- Status: %s\n", issue.Status)
-				md += fmt.Sprintf("- Type: %s\n", issue.Type)
-				md += fmt.Sprintf("- Priority: %s\n", issue.Priority)
-				if issue.Points > 0 {
-					md += fmt.Sprintf("- Points: %d\n", issue.Points)
-				}
-				if len(issue.Labels) > 0 {
-					md += fmt.Sprintf("- Labels: %s\n", joinItems(issue.Labels))
-				}
-				if issue.Description != "" {
-					md += fmt.Sprintf("\n%s\n", issue.Description)
-				}
-				md += "\n"
-			}
+			md := output.FormatIssuesMarkdown(issues)
 			if renderMarkdown {
 				rendered, err := output.RenderMarkdown(md)
 				if err != nil {
@@ -511,6 +562,64 @@ var exportCmd = &cobra.Command{
 	},
 }
 
+// exportIssuesJSON builds the JSON export: each issue with its logs,
+// handoffs, dependencies and linked files.
+func exportIssuesJSON(database *db.DB, issues []models.Issue) ([]byte, error) {
+	exportData := make([]map[string]interface{}, 0)
+	for _, issue := range issues {
+		logs, _ := database.GetLogs(issue.ID, 0)
+		handoffs, _ := database.GetHandoffs(issue.ID)
+		deps, _ := database.GetIssueDependencyRelations(issue.ID)
+		files, _ := database.GetLinkedFiles(issue.ID)
+
+		item := map[string]interface{}{
+			"issue":        issue,
+			"logs":         logs,
+			"handoffs":     handoffs,
+			"dependencies": deps,
+			"files":        files,
+		}
+		exportData = append(exportData, item)
+	}
+	return json.MarshalIndent(exportData, "", "  ")
+}
+
+// changedIssuesSince returns the issues changed after the --since cursor,
+// deleted ones included so deletions carry over to the merge target.
+func changedIssuesSince(database *db.DB, baseDir, since string) ([]models.Issue, error) {
+	var afterSeq int64
+	var afterTime time.Time
+	if since == "last" {
+		cfg, err := config.Load(baseDir)
+		if err != nil {
+			return nil, err
+		}
+		afterSeq = cfg.LastExportSeq
+	} else if seq, err := strconv.ParseInt(since, 10, 64); err == nil && seq >= 0 {
+		afterSeq = seq
+	} else if t, err := time.Parse(time.RFC3339, since); err == nil {
+		afterTime = t
+	} else if t, err := time.ParseInLocation("2006-01-02", since, time.Local); err == nil {
+		afterTime = t
+	} else {
+		return nil, fmt.Errorf("invalid --since %q: use a sequence number, a timestamp (RFC3339 or YYYY-MM-DD) or \"last\"", since)
+	}
+
+	ids, err := database.GetIssueIDsChangedSince(afterSeq, afterTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read action log: %w", err)
+	}
+	issues := make([]models.Issue, 0, len(ids))
+	for _, id := range ids {
+		issue, err := database.GetIssue(id)
+		if err != nil {
+			continue // purged since; nothing left to export
+		}
+		issues = append(issues, *issue)
+	}
+	return issues, nil
+}
+
 var importCmd = &cobra.Command{
 	Use:     "import [file]",
 	Short:   "Import issues",
@@ -529,6 +638,7 @@ var importCmd = &cobra.Command{
 		filePath := args[0]
 		dryRun, _ := cmd.Flags().GetBool("dry-run")
 		force, _ := cmd.Flags().GetBool("force")
+		merge, _ := cmd.Flags().GetBool("merge")
 		format, _ := cmd.Flags().GetString("format")
 
 		// Auto-detect format from extension if not specified
@@ -555,7 +665,7 @@ var importCmd = &cobra.Command{
 			}
 			imported, importErr = importMarkdown(database, string(data), dryRun, force, sess.ID)
 		} else {
-			imported, importErr = importJSON(database, data, dryRun, force)
+			imported, importErr = importJSONWithMode(database, data, dryRun, force, merge)
 		}
 
 		if importErr != nil {
@@ -630,6 +740,13 @@ func (e *exportedItem) UnmarshalJSON(data []byte) error {
 
 // importJSON imports issues from JSON format
 func importJSON(database *db.DB, data []byte, dryRun, force bool) (int, error) {
+	return importJSONWithMode(database, data, dryRun, force, false)
+}
+
+// importJSONWithMode imports issues from JSON format. With merge, existing
+// issues are replaced by the incoming copy unless the local one was updated
+// more recently, so a delta from td export --since can be applied repeatedly.
+func importJSONWithMode(database *db.DB, data []byte, dryRun, force, merge bool) (int, error) {
 	var items []exportedItem
 	if err := json.Unmarshal(data, &items); err != nil {
 		return 0, fmt.Errorf("failed to parse JSON: %v", err)
@@ -651,7 +768,11 @@ func importJSON(database *db.DB, data []byte, dryRun, force bool) (int, error) {
 		// Check if issue with same ID exists
 		existing, _ := database.GetIssue(issue.ID)
 
-		if existing != nil && !force {
+		if existing != nil && merge && !force && existing.UpdatedAt.After(issue.UpdatedAt) {
+			fmt.Printf("KEPT %s: local copy is newer\n", issue.ID)
+			continue
+		}
+		if existing != nil && !force && !merge {
 			output.Warning("skipping '%s' - already exists (use --force to overwrite)", issue.ID)
 			continue
 		}
@@ -675,7 +796,9 @@ func importJSON(database *db.DB, data []byte, dryRun, force bool) (int, error) {
 			}
 			continue
 		}
-		if replace {
+		if replace && merge {
+			fmt.Printf("MERGED %s: %s\n", issue.ID, issue.Title)
+		} else if replace {
 			fmt.Printf("OVERWRITTEN %s: %s\n", issue.ID, issue.Title)
 		} else {
 			fmt.Printf("IMPORTED %s: %s\n", issue.ID, issue.Title)
@@ -915,14 +1038,18 @@ func init() {
 	rootCmd.AddCommand(importCmd)
 	rootCmd.AddCommand(upgradeCmd)
 
-	exportCmd.Flags().String("format", "json", "Export format: json or md")
+	exportCmd.Flags().String("format", "json", "Export format: json, md, csv, or sqlite")
 	exportCmd.Flags().StringP("output", "o", "", "Output file (default: stdout)")
+	exportCmd.Flags().String("out", "", "Alias for --output")
+	exportCmd.Flags().MarkHidden("out")
 	exportCmd.Flags().Bool("all", false, "Include closed/deleted")
 	exportCmd.Flags().BoolP("render-markdown", "m", false, "Render markdown output for humans")
+	exportCmd.Flags().String("since", "", "Only export issues changed after a cursor: action seq, timestamp, or \"last\"")
 
 	importCmd.Flags().String("format", "json", "Import format: json or md")
 	importCmd.Flags().Bool("dry-run", false, "Preview changes")
 	importCmd.Flags().Bool("force", false, "Overwrite existing")
+	importCmd.Flags().Bool("merge", false, "Apply a delta: overwrite existing issues unless the local copy is newer")
 
 	sessionNameCmd.Flags().Bool("new", false, "Force create a new session")
 
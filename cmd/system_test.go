@@ -2,9 +2,11 @@ package cmd
 
 import (
 	"encoding/json"
+	"strconv"
 	"testing"
 	"time"
 
+	"github.com/marcus/td/internal/config"
 	"github.com/marcus/td/internal/db"
 	"github.com/marcus/td/internal/models"
 )
@@ -981,3 +983,114 @@ func TestImportJSON_DependencyRelationTypePreserved(t *testing.T) {
 		t.Errorf("td-b relation: got %s, want blocks", typeMap["td-b"])
 	}
 }
+
+func TestExportSinceMergeRoundTrip(t *testing.T) {
+	srcDir := t.TempDir()
+	srcDB, err := db.Initialize(srcDir)
+	if err != nil {
+		t.Fatalf("Initialize source DB: %v", err)
+	}
+	defer srcDB.Close()
+	const sess = "ses_export"
+
+	changed := &models.Issue{Title: "Changes after the base export", Status: models.StatusOpen}
+	untouched := &models.Issue{Title: "Unchanged after the base export", Status: models.StatusOpen}
+	for _, issue := range []*models.Issue{changed, untouched} {
+		if err := srcDB.CreateIssueLogged(issue, sess); err != nil {
+			t.Fatalf("CreateIssueLogged: %v", err)
+		}
+	}
+
+	// Base: full export into a fresh project
+	base, err := exportIssuesJSON(srcDB, []models.Issue{*changed, *untouched})
+	if err != nil {
+		t.Fatalf("exportIssuesJSON: %v", err)
+	}
+	cursor, err := srcDB.GetActionSeq()
+	if err != nil {
+		t.Fatalf("GetActionSeq: %v", err)
+	}
+	dstDB, err := db.Initialize(t.TempDir())
+	if err != nil {
+		t.Fatalf("Initialize dest DB: %v", err)
+	}
+	defer dstDB.Close()
+	if n, err := importJSON(dstDB, base, false, false); err != nil || n != 2 {
+		t.Fatalf("base import: imported %d, err %v", n, err)
+	}
+
+	// Changes after the cursor: an edit, a dependency and a new issue
+	time.Sleep(10 * time.Millisecond) // distinct updated_at for merge ordering
+	changed.Title = "Changed title"
+	changed.Priority = models.PriorityP0
+	if err := srcDB.UpdateIssueLogged(changed, sess, models.ActionUpdate); err != nil {
+		t.Fatalf("UpdateIssueLogged: %v", err)
+	}
+	added := &models.Issue{Title: "Created after the base export", Status: models.StatusOpen}
+	if err := srcDB.CreateIssueLogged(added, sess); err != nil {
+		t.Fatalf("CreateIssueLogged: %v", err)
+	}
+	if err := srcDB.AddDependencyLogged(changed.ID, added.ID, "depends_on", sess); err != nil {
+		t.Fatalf("AddDependencyLogged: %v", err)
+	}
+
+	issues, err := changedIssuesSince(srcDB, srcDir, strconv.FormatInt(cursor, 10))
+	if err != nil {
+		t.Fatalf("changedIssuesSince: %v", err)
+	}
+	var ids []string
+	for _, issue := range issues {
+		ids = append(ids, issue.ID)
+	}
+	if len(ids) != 2 || ids[0] != changed.ID || ids[1] != added.ID {
+		t.Fatalf("delta issues: got %v, want [%s %s]", ids, changed.ID, added.ID)
+	}
+
+	delta, err := exportIssuesJSON(srcDB, issues)
+	if err != nil {
+		t.Fatalf("exportIssuesJSON delta: %v", err)
+	}
+	if n, err := importJSONWithMode(dstDB, delta, false, false, true); err != nil || n != 2 {
+		t.Fatalf("merge: imported %d, err %v", n, err)
+	}
+
+	got, err := dstDB.GetIssue(changed.ID)
+	if err != nil {
+		t.Fatalf("GetIssue merged: %v", err)
+	}
+	if got.Title != "Changed title" || got.Priority != models.PriorityP0 {
+		t.Errorf("merged issue: got %q %s, want the edited copy", got.Title, got.Priority)
+	}
+	if deps, _ := dstDB.GetDependencies(changed.ID); len(deps) != 1 || deps[0] != added.ID {
+		t.Errorf("merged dependencies: got %v, want [%s]", deps, added.ID)
+	}
+	if _, err := dstDB.GetIssue(added.ID); err != nil {
+		t.Errorf("new issue missing after merge: %v", err)
+	}
+	if got, _ := dstDB.GetIssue(untouched.ID); got == nil || got.Title != untouched.Title {
+		t.Error("issue outside the delta should be left alone")
+	}
+
+	// A newer local edit survives re-applying the same delta
+	time.Sleep(10 * time.Millisecond)
+	local, _ := dstDB.GetIssue(changed.ID)
+	local.Title = "Edited locally"
+	if err := dstDB.UpdateIssueLogged(local, sess, models.ActionUpdate); err != nil {
+		t.Fatalf("UpdateIssueLogged dest: %v", err)
+	}
+	if _, err := importJSONWithMode(dstDB, delta, false, false, true); err != nil {
+		t.Fatalf("re-merge: %v", err)
+	}
+	if got, _ := dstDB.GetIssue(changed.ID); got.Title != "Edited locally" {
+		t.Errorf("re-merge overwrote a newer local edit: %q", got.Title)
+	}
+
+	// "last" picks up from the recorded cursor
+	seq, _ := srcDB.GetActionSeq()
+	if err := config.SetLastExportSeq(srcDir, seq); err != nil {
+		t.Fatalf("SetLastExportSeq: %v", err)
+	}
+	if issues, err := changedIssuesSince(srcDB, srcDir, "last"); err != nil || len(issues) != 0 {
+		t.Errorf("since last: got %d issues (err %v), want none", len(issues), err)
+	}
+}
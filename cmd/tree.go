@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/marcus/td/internal/db"
 	"github.com/marcus/td/internal/models"
@@ -118,51 +120,170 @@ func buildTree(database *db.DB, issueID string, depth int, maxDepth int) map[str
 	return node
 }
 
-var commentCmd = &cobra.Command{
-	Use:     "comment [issue-id] \"text\"",
-	Short:   "Add a comment to an issue (alias for 'comments add')",
-	GroupID: "workflow",
-	Args:    cobra.ExactArgs(2),
-	RunE: func(cmd *cobra.Command, args []string) error {
-		baseDir := getBaseDir()
+// listTreeNode is one issue in the `td list --tree` hierarchy.
+type listTreeNode struct {
+	ID       string          `json:"id"`
+	Title    string          `json:"title"`
+	Type     models.Type     `json:"type"`
+	Status   models.Status   `json:"status"`
+	Priority models.Priority `json:"priority"`
+	Children []listTreeNode  `json:"children,omitempty"`
+}
 
-		database, err := db.Open(baseDir)
+// buildListTree builds the descendant hierarchy under each root, descending at
+// most maxDepth levels (0 = unlimited). A child that is already one of its own
+// ancestors is skipped, so a parent_id cycle can't recurse forever.
+func buildListTree(database *db.DB, roots []models.Issue, maxDepth int) ([]listTreeNode, error) {
+	nodes := make([]listTreeNode, 0, len(roots))
+	for i := range roots {
+		node := newListTreeNode(&roots[i])
+		children, err := listTreeChildren(database, node.ID, 0, maxDepth, map[string]bool{node.ID: true})
 		if err != nil {
-			output.Error("%v", err)
-			return err
+			return nil, err
 		}
-		defer database.Close()
+		node.Children = children
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
 
-		sess, err := session.GetOrCreate(database)
-		if err != nil {
-			output.Error("%v", err)
-			return err
-		}
+func listTreeChildren(database *db.DB, parentID string, depth, maxDepth int, ancestors map[string]bool) ([]listTreeNode, error) {
+	if maxDepth > 0 && depth >= maxDepth {
+		return nil, nil
+	}
 
-		issueID := args[0]
-		text := args[1]
+	children, err := database.GetDirectChildren(parentID)
+	if err != nil {
+		return nil, fmt.Errorf("get children of %s: %w", parentID, err)
+	}
+	sort.SliceStable(children, func(i, j int) bool {
+		if children[i].Priority != children[j].Priority {
+			return children[i].Priority < children[j].Priority
+		}
+		return children[i].CreatedAt.Before(children[j].CreatedAt)
+	})
 
-		// Verify issue exists
-		_, err = database.GetIssue(issueID)
+	nodes := make([]listTreeNode, 0, len(children))
+	for _, child := range children {
+		if ancestors[child.ID] {
+			continue
+		}
+		ancestors[child.ID] = true
+		node := newListTreeNode(child)
+		node.Children, err = listTreeChildren(database, child.ID, depth+1, maxDepth, ancestors)
+		delete(ancestors, child.ID)
 		if err != nil {
-			output.Error("%v", err)
-			return err
+			return nil, err
 		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
 
-		comment := &models.Comment{
-			IssueID:   issueID,
-			SessionID: sess.ID,
-			Text:      text,
-		}
+func newListTreeNode(issue *models.Issue) listTreeNode {
+	return listTreeNode{
+		ID:       issue.ID,
+		Title:    issue.Title,
+		Type:     issue.Type,
+		Status:   issue.Status,
+		Priority: issue.Priority,
+	}
+}
 
-		if err := database.AddComment(comment); err != nil {
-			output.Error("failed to add comment: %v", err)
-			return err
+// toOutputTreeNodes converts list tree nodes for output.RenderTree.
+func toOutputTreeNodes(nodes []listTreeNode) []output.TreeNode {
+	out := make([]output.TreeNode, 0, len(nodes))
+	for _, n := range nodes {
+		out = append(out, output.TreeNode{
+			ID:       n.ID,
+			Title:    n.Title,
+			Type:     n.Type,
+			Status:   n.Status,
+			Priority: n.Priority,
+			Children: toOutputTreeNodes(n.Children),
+		})
+	}
+	return out
+}
+
+// renderListTree prints each root on its own line with its descendants
+// indented beneath it.
+func renderListTree(nodes []listTreeNode, maxDepth int) {
+	for _, root := range nodes {
+		fmt.Printf("%s %s: %s %s %s\n", root.Type, root.ID, root.Title,
+			output.FormatPriority(root.Priority), output.FormatStatus(root.Status))
+		children := output.RenderTree(output.TreeNode{Children: toOutputTreeNodes(root.Children)}, output.TreeRenderOptions{
+			MaxDepth:     maxDepth,
+			ShowStatus:   true,
+			ShowType:     true,
+			ShowPriority: true,
+		})
+		if children != "" {
+			fmt.Println(children)
 		}
+	}
+}
 
-		fmt.Printf("COMMENT ADDED %s\n", issueID)
-		return nil
-	},
+var commentCmd = &cobra.Command{
+	Use:   "comment [issue-id] \"text\"",
+	Short: "Add a comment to an issue (alias for 'comments add')",
+	Long: `Adds a comment to an issue. Use --reply-to with a comment ID (shown by
+'td comments') to reply to that comment; replies are listed under it.
+
+Examples:
+  td comment td-abc1 "Looks good apart from the retry loop"
+  td comment td-abc1 "Fixed in the latest push" --reply-to cm-1a2b3c4d`,
+	GroupID: "workflow",
+	Args:    cobra.ExactArgs(2),
+	RunE:    runAddComment,
+}
+
+// runAddComment implements `td comment` and `td comments add`.
+func runAddComment(cmd *cobra.Command, args []string) error {
+	baseDir := getBaseDir()
+
+	database, err := db.Open(baseDir)
+	if err != nil {
+		output.Error("%v", err)
+		return err
+	}
+	defer database.Close()
+
+	sess, err := session.GetOrCreate(database)
+	if err != nil {
+		output.Error("%v", err)
+		return err
+	}
+
+	issueID := args[0]
+	text := args[1]
+	replyTo, _ := cmd.Flags().GetString("reply-to")
+
+	// Verify issue exists
+	issue, err := database.GetIssue(issueID)
+	if err != nil {
+		output.Error("%v", err)
+		return err
+	}
+
+	comment := &models.Comment{
+		IssueID:         issue.ID,
+		SessionID:       sess.ID,
+		Text:            text,
+		ParentCommentID: replyTo,
+	}
+
+	if err := database.AddComment(comment); err != nil {
+		output.Error("failed to add comment: %v", err)
+		return err
+	}
+
+	if replyTo != "" {
+		fmt.Printf("REPLY ADDED %s (%s, reply to %s)\n", issue.ID, comment.ID, replyTo)
+	} else {
+		fmt.Printf("COMMENT ADDED %s (%s)\n", issue.ID, comment.ID)
+	}
+	return nil
 }
 
 var commentsCmd = &cobra.Command{
@@ -195,8 +316,9 @@ var commentsCmd = &cobra.Command{
 			return err
 		}
 
-		for _, c := range comments {
-			fmt.Printf("[%s] (%s) %s\n", c.CreatedAt.Format("2006-01-02 15:04"), c.SessionID, c.Text)
+		for _, c := range models.ThreadComments(comments) {
+			fmt.Printf("%s[%s] (%s) %s: %s\n", strings.Repeat("  ", c.Depth),
+				c.CreatedAt.Format("2006-01-02 15:04"), c.SessionID, c.ID, c.Text)
 		}
 
 		if len(comments) == 0 {
@@ -211,46 +333,7 @@ var commentsAddCmd = &cobra.Command{
 	Use:   "add [issue-id] \"text\"",
 	Short: "Add a comment to an issue",
 	Args:  cobra.ExactArgs(2),
-	RunE: func(cmd *cobra.Command, args []string) error {
-		baseDir := getBaseDir()
-
-		database, err := db.Open(baseDir)
-		if err != nil {
-			output.Error("%v", err)
-			return err
-		}
-		defer database.Close()
-
-		sess, err := session.GetOrCreate(database)
-		if err != nil {
-			output.Error("%v", err)
-			return err
-		}
-
-		issueID := args[0]
-		text := args[1]
-
-		// Verify issue exists
-		_, err = database.GetIssue(issueID)
-		if err != nil {
-			output.Error("%v", err)
-			return err
-		}
-
-		comment := &models.Comment{
-			IssueID:   issueID,
-			SessionID: sess.ID,
-			Text:      text,
-		}
-
-		if err := database.AddComment(comment); err != nil {
-			output.Error("failed to add comment: %v", err)
-			return err
-		}
-
-		fmt.Printf("COMMENT ADDED %s\n", issueID)
-		return nil
-	},
+	RunE:  runAddComment,
 }
 
 func init() {
@@ -261,4 +344,6 @@ func init() {
 	commentsCmd.AddCommand(commentsAddCmd)
 
 	treeCmd.Flags().Int("depth", 0, "Max depth (0=unlimited)")
+	commentCmd.Flags().String("reply-to", "", "Comment ID to reply to")
+	commentsAddCmd.Flags().String("reply-to", "", "Comment ID to reply to")
 }
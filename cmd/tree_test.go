@@ -502,3 +502,62 @@ func TestTreeLargeHierarchy(t *testing.T) {
 		t.Error("Root issue not found")
 	}
 }
+
+// TestBuildListTree tests hierarchy assembly for td list --tree
+func TestBuildListTree(t *testing.T) {
+	dir := t.TempDir()
+	database, err := db.Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	epic := &models.Issue{Title: "Epic", Type: models.TypeEpic, Priority: models.PriorityP1}
+	database.CreateIssue(epic)
+	low := &models.Issue{Title: "Low", Type: models.TypeTask, Priority: models.PriorityP3, ParentID: epic.ID}
+	database.CreateIssue(low)
+	high := &models.Issue{Title: "High", Type: models.TypeTask, Priority: models.PriorityP0, ParentID: epic.ID}
+	database.CreateIssue(high)
+	grandchild := &models.Issue{Title: "Grandchild", Type: models.TypeBug, Priority: models.PriorityP2, ParentID: high.ID}
+	database.CreateIssue(grandchild)
+
+	nodes, err := buildListTree(database, []models.Issue{*epic}, 0)
+	if err != nil {
+		t.Fatalf("buildListTree failed: %v", err)
+	}
+	if len(nodes) != 1 || nodes[0].ID != epic.ID {
+		t.Fatalf("roots = %+v, want just %s", nodes, epic.ID)
+	}
+	children := nodes[0].Children
+	if len(children) != 2 || children[0].ID != high.ID || children[1].ID != low.ID {
+		t.Fatalf("children = %+v, want [%s %s] ordered by priority", children, high.ID, low.ID)
+	}
+	if len(children[0].Children) != 1 || children[0].Children[0].ID != grandchild.ID {
+		t.Errorf("grandchildren = %+v, want [%s]", children[0].Children, grandchild.ID)
+	}
+	if children[0].Children[0].Priority != models.PriorityP2 {
+		t.Errorf("grandchild priority = %s, want P2", children[0].Children[0].Priority)
+	}
+
+	// --depth 1 stops at direct children
+	nodes, err = buildListTree(database, []models.Issue{*epic}, 1)
+	if err != nil {
+		t.Fatalf("buildListTree depth 1 failed: %v", err)
+	}
+	if len(nodes[0].Children) != 2 || len(nodes[0].Children[0].Children) != 0 {
+		t.Errorf("depth 1 tree = %+v, want children without grandchildren", nodes[0].Children)
+	}
+
+	// A parent_id cycle must not recurse forever
+	epic.ParentID = grandchild.ID
+	if err := database.UpdateIssue(epic); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	nodes, err = buildListTree(database, []models.Issue{*epic}, 0)
+	if err != nil {
+		t.Fatalf("buildListTree with cycle failed: %v", err)
+	}
+	if got := nodes[0].Children[0].Children[0].Children; len(got) != 0 {
+		t.Errorf("cycle back to root should be cut, got %+v", got)
+	}
+}
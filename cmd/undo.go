@@ -77,10 +77,9 @@ Use 'td undo --list' to see recent undoable actions.`,
 			return nil
 		}
 
-		// Get last undoable action
-		action, err := database.GetLastAction(sess.ID)
+		action, err := undoLastAction(database, sess.ID)
 		if err != nil {
-			output.Error("failed to get last action: %v", err)
+			output.Error("%v", err)
 			return err
 		}
 
@@ -89,23 +88,32 @@ Use 'td undo --list' to see recent undoable actions.`,
 			return nil
 		}
 
-		// Perform the undo
-		if err := performUndo(database, action, sess.ID); err != nil {
-			output.Error("failed to undo: %v", err)
-			return err
-		}
-
-		// Mark action as undone
-		if err := database.MarkActionUndone(action.ID); err != nil {
-			output.Error("failed to mark action undone: %v", err)
-			return err
-		}
-
 		fmt.Printf("UNDONE: %s %s %s\n", action.ActionType, action.EntityType, action.EntityID)
 		return nil
 	},
 }
 
+// undoLastAction reverts the session's most recent undoable action and marks
+// it undone. It returns the reverted action, or nil when there is nothing to
+// undo. The monitor's undo key calls this too (see monitor.Model.UndoFunc).
+func undoLastAction(database *db.DB, sessionID string) (*models.ActionLog, error) {
+	action, err := database.GetLastAction(sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last action: %w", err)
+	}
+	if action == nil {
+		return nil, nil
+	}
+
+	if err := performUndo(database, action, sessionID); err != nil {
+		return nil, fmt.Errorf("failed to undo: %w", err)
+	}
+	if err := database.MarkActionUndone(action.ID); err != nil {
+		return nil, fmt.Errorf("failed to mark action undone: %w", err)
+	}
+	return action, nil
+}
+
 func performUndo(database *db.DB, action *models.ActionLog, sessionID string) error {
 	switch action.EntityType {
 	case "issue":
@@ -172,15 +180,31 @@ func undoIssueAction(database *db.DB, action *models.ActionLog, sessionID string
 }
 
 func undoDependencyAction(database *db.DB, action *models.ActionLog, sessionID string) error {
-	// Parse the dependency info from entity_id (format: "issueID:dependsOnID")
+	// Parse the dependency row; removals only carry it in previous_data
 	var depInfo struct {
-		IssueID     string `json:"issue_id"`
-		DependsOnID string `json:"depends_on_id"`
+		IssueID      string `json:"issue_id"`
+		DependsOnID  string `json:"depends_on_id"`
+		RelationType string `json:"relation_type"`
 	}
-	if err := json.Unmarshal([]byte(action.NewData), &depInfo); err != nil {
+	data := action.NewData
+	if data == "" {
+		data = action.PreviousData
+	}
+	if err := json.Unmarshal([]byte(data), &depInfo); err != nil {
 		return fmt.Errorf("failed to parse dependency data: %w", err)
 	}
 
+	if depInfo.RelationType == models.RelationRelatesTo {
+		switch action.ActionType {
+		case models.ActionAddDep:
+			return database.RemoveRelation(depInfo.IssueID, depInfo.DependsOnID, sessionID)
+		case models.ActionRemoveDep:
+			return database.AddRelation(depInfo.IssueID, depInfo.DependsOnID, sessionID)
+		default:
+			return fmt.Errorf("cannot undo relation action: %s", action.ActionType)
+		}
+	}
+
 	switch action.ActionType {
 	case models.ActionAddDep:
 		// Use logged variant to generate sync event
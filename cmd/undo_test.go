@@ -741,3 +741,48 @@ func TestUndoBoardSetPosition(t *testing.T) {
 		t.Errorf("Position should be removed: got %d, want 0", pos)
 	}
 }
+
+// TestUndoLastActionRevertsAndMarksUndone covers the shared helper behind
+// td undo and the monitor's undo key.
+func TestUndoLastActionRevertsAndMarksUndone(t *testing.T) {
+	dir := t.TempDir()
+	database, err := db.Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	if action, err := undoLastAction(database, "ses_undo"); err != nil || action != nil {
+		t.Fatalf("undoLastAction on empty session = %v, %v; want nil, nil", action, err)
+	}
+
+	issue := &models.Issue{Title: "Undo me", Status: models.StatusOpen}
+	if err := database.CreateIssueLogged(issue, "ses_undo"); err != nil {
+		t.Fatalf("CreateIssueLogged failed: %v", err)
+	}
+
+	action, err := undoLastAction(database, "ses_undo")
+	if err != nil {
+		t.Fatalf("undoLastAction failed: %v", err)
+	}
+	if action == nil || action.ActionType != models.ActionCreate || action.EntityID != issue.ID {
+		t.Fatalf("undoLastAction returned %+v, want create of %s", action, issue.ID)
+	}
+	if retrieved, _ := database.GetIssue(issue.ID); retrieved != nil && retrieved.DeletedAt == nil {
+		t.Error("issue should be deleted after undoing its create")
+	}
+
+	actions, err := database.GetRecentActions("ses_undo", 10)
+	if err != nil {
+		t.Fatalf("GetRecentActions failed: %v", err)
+	}
+	var marked bool
+	for _, a := range actions {
+		if a.ID == action.ID {
+			marked = a.Undone
+		}
+	}
+	if !marked {
+		t.Error("reverted action should be marked undone")
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"github.com/marcus/td/internal/models"
 	"github.com/marcus/td/internal/output"
 	"github.com/marcus/td/internal/session"
-	"github.com/marcus/td/internal/workflow"
 	"github.com/spf13/cobra"
 )
 
@@ -66,7 +65,7 @@ Examples:
 			}
 
 			// Validate transition with state machine
-			sm := workflow.DefaultMachine()
+			sm := workflowMachine()
 			if !sm.IsValidTransition(issue.Status, models.StatusOpen) {
 				emitWarn("cannot unstart %s: invalid transition from %s", issueID, issue.Status)
 				skipped++
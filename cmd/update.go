@@ -8,7 +8,6 @@ import (
 	"github.com/marcus/td/internal/models"
 	"github.com/marcus/td/internal/output"
 	"github.com/marcus/td/internal/session"
-	"github.com/marcus/td/internal/workflow"
 	"github.com/spf13/cobra"
 )
 
@@ -52,6 +51,19 @@ var updateCmd = &cobra.Command{
 			return err
 		}
 
+		// Closing through --status is held to the same reason policy as
+		// td close; the comment serves as the reason.
+		if status, _ := cmd.Flags().GetString("status"); models.NormalizeStatus(status) == models.StatusClosed {
+			reason, _ := cmd.Flags().GetString("comment")
+			if reason == "" {
+				reason, _ = cmd.Flags().GetString("note")
+			}
+			if err := checkReasonPolicy(baseDir, "close", reason, "--comment"); err != nil {
+				emitErr("%v", err)
+				return err
+			}
+		}
+
 		for _, issueID := range args {
 			issue, err := database.GetIssue(issueID)
 			if err != nil {
@@ -183,16 +195,23 @@ var updateCmd = &cobra.Command{
 			// Handle --status flag for convenience
 			if status, _ := cmd.Flags().GetString("status"); status != "" {
 				newStatus := models.NormalizeStatus(status)
-				if !models.IsValidStatus(newStatus) {
-					emitErr("invalid status: %s (valid: open, in_progress, in_review, blocked, closed)", status)
+				sm := workflowMachine()
+				if !sm.HasStatus(newStatus) {
+					emitErr("invalid status: %s (valid: %s)", status, joinStatuses(sm.Statuses()))
 					continue
 				}
 				// Validate transition with state machine
-				sm := workflow.DefaultMachine()
 				if !sm.IsValidTransition(issue.Status, newStatus) {
 					emitWarn("cannot update %s: invalid transition from %s to %s", issueID, issue.Status, newStatus)
 					continue
 				}
+				if newStatus == models.StatusInProgress {
+					force, _ := cmd.Flags().GetBool("force")
+					if err := checkBlockedStart(database, issue, force); err != nil {
+						emitWarn("%v", err)
+						continue
+					}
+				}
 				oldStatus := issue.Status
 				issue.Status = newStatus
 
@@ -298,7 +317,8 @@ func init() {
 	updateCmd.Flags().StringArray("depends-on", nil, "Replace dependencies (repeatable, comma-separated)")
 	updateCmd.Flags().StringArray("blocks", nil, "Replace blocked issues (repeatable, comma-separated)")
 	updateCmd.Flags().Bool("append", false, "Append to text fields instead of replacing")
-	updateCmd.Flags().String("status", "", "New status (open, in_progress, in_review, blocked, closed)")
+	updateCmd.Flags().String("status", "", "New status (open, in_progress, in_review, blocked, closed, or a custom workflow status)")
+	updateCmd.Flags().Bool("force", false, "Allow --status in_progress on a blocked issue")
 	updateCmd.Flags().StringP("comment", "m", "", "Add a comment to the updated issue(s)")
 	updateCmd.Flags().StringP("note", "c", "", "Alias for --comment")
 	updateCmd.Flags().MarkHidden("note")
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/marcus/td/internal/config"
 	"github.com/marcus/td/internal/db"
 	"github.com/marcus/td/internal/models"
 )
@@ -676,3 +677,55 @@ func TestUpdateRichTextConflictErrors(t *testing.T) {
 		t.Fatalf("expected description to remain unchanged, got %q", updated.Description)
 	}
 }
+
+// TestUpdateStatusCustomWorkflow tests --status against a workflow config
+// that adds a qa status after review
+func TestUpdateStatusCustomWorkflow(t *testing.T) {
+	saveAndRestoreGlobals(t)
+	dir := t.TempDir()
+	baseDirOverride = &dir
+
+	database, err := db.Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	if err := config.Save(dir, &models.Config{Workflow: &models.WorkflowConfig{
+		Statuses: []models.Status{"qa"},
+		Transitions: []models.WorkflowTransition{
+			{From: models.StatusInReview, To: "qa"},
+			{From: "qa", To: models.StatusClosed},
+		},
+	}}); err != nil {
+		t.Fatalf("Save config: %v", err)
+	}
+	if got := customStatuses(); len(got) != 1 || got[0] != "qa" {
+		t.Fatalf("custom statuses = %v, want [qa]", got)
+	}
+
+	reviewed := &models.Issue{Title: "Reviewed", Status: models.StatusInReview}
+	open := &models.Issue{Title: "Still open", Status: models.StatusOpen}
+	for _, issue := range []*models.Issue{reviewed, open} {
+		if err := database.CreateIssue(issue); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	if err := updateCmd.Flags().Set("status", "qa"); err != nil {
+		t.Fatalf("set status flag: %v", err)
+	}
+	t.Cleanup(func() { _ = updateCmd.Flags().Set("status", "") })
+	captureStdout(t, func() {
+		_ = updateCmd.RunE(updateCmd, []string{reviewed.ID, open.ID})
+	})
+
+	// in_review → qa is configured
+	if got, _ := database.GetIssue(reviewed.ID); got.Status != "qa" {
+		t.Errorf("in_review → qa: status = %q, want qa", got.Status)
+	}
+	// open → qa is not
+	if got, _ := database.GetIssue(open.ID); got.Status != models.StatusOpen {
+		t.Errorf("open → qa should be rejected, status = %q", got.Status)
+	}
+}
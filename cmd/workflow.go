@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/marcus/td/internal/config"
 	"github.com/marcus/td/internal/models"
+	"github.com/marcus/td/internal/output"
 	"github.com/marcus/td/internal/workflow"
 	"github.com/spf13/cobra"
 )
@@ -14,7 +16,19 @@ var workflowCmd = &cobra.Command{
 	Short: "Show issue status workflow",
 	Long: `Displays the issue status workflow state machine.
 
-Shows all valid status transitions and any guards applied.`,
+Shows all valid status transitions and any guards applied.
+
+Projects can add statuses and transitions under "workflow" in
+.todos/config.json; they are listed alongside the built-in ones:
+
+  "workflow": {
+    "statuses": ["qa"],
+    "transitions": [
+      {"from": "in_review", "to": "qa"},
+      {"from": "qa", "to": "closed"},
+      {"from": "qa", "to": "in_progress"}
+    ]
+  }`,
 	GroupID: "system",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		showMermaid, _ := cmd.Flags().GetBool("mermaid")
@@ -31,7 +45,7 @@ Shows all valid status transitions and any guards applied.`,
 }
 
 func printWorkflow() error {
-	sm := workflow.DefaultMachine()
+	sm := workflowMachine()
 
 	fmt.Println("ISSUE STATUS WORKFLOW")
 	fmt.Println("=====================")
@@ -42,11 +56,14 @@ func printWorkflow() error {
 	for _, s := range workflow.AllStatuses() {
 		fmt.Printf("  • %s\n", s)
 	}
+	for _, s := range sm.CustomStatuses() {
+		fmt.Printf("  • %s (custom)\n", s)
+	}
 	fmt.Println()
 
 	// Show transitions by source
 	fmt.Println("TRANSITIONS:")
-	for _, from := range workflow.AllStatuses() {
+	for _, from := range sm.Statuses() {
 		allowed := sm.GetAllowedTransitions(from)
 		if len(allowed) > 0 {
 			fmt.Printf("  %s →\n", from)
@@ -84,13 +101,13 @@ func printWorkflow() error {
 }
 
 func printMermaidDiagram() error {
-	sm := workflow.DefaultMachine()
+	sm := workflowMachine()
 
 	fmt.Println("```mermaid")
 	fmt.Println("stateDiagram-v2")
 
 	// Show transitions
-	for _, from := range workflow.AllStatuses() {
+	for _, from := range sm.Statuses() {
 		for _, to := range sm.GetAllowedTransitions(from) {
 			name := workflow.TransitionName(from, to)
 			fmt.Printf("    %s --> %s: %s\n", from, to, name)
@@ -102,7 +119,7 @@ func printMermaidDiagram() error {
 }
 
 func printDotDiagram() error {
-	sm := workflow.DefaultMachine()
+	sm := workflowMachine()
 
 	fmt.Println("digraph workflow {")
 	fmt.Println("    rankdir=LR;")
@@ -115,10 +132,13 @@ func printDotDiagram() error {
 	fmt.Printf("    %s [style=filled,fillcolor=lightpink];\n", models.StatusBlocked)
 	fmt.Printf("    %s [style=filled,fillcolor=lightorange];\n", models.StatusInReview)
 	fmt.Printf("    %s [style=filled,fillcolor=lightgreen];\n", models.StatusClosed)
+	for _, s := range sm.CustomStatuses() {
+		fmt.Printf("    %s [style=filled,fillcolor=lightgrey];\n", s)
+	}
 	fmt.Println()
 
 	// Transitions
-	for _, from := range workflow.AllStatuses() {
+	for _, from := range sm.Statuses() {
 		for _, to := range sm.GetAllowedTransitions(from) {
 			name := workflow.TransitionName(from, to)
 			fmt.Printf("    %s -> %s [label=\"%s\"];\n", from, to, name)
@@ -129,6 +149,39 @@ func printDotDiagram() error {
 	return nil
 }
 
+// workflowMachine returns the project's state machine: the built-in
+// workflow plus the custom statuses and transitions from config. An invalid
+// workflow config falls back to the built-in workflow (see warnInvalidWorkflow).
+func workflowMachine() *workflow.StateMachine {
+	sm, _ := workflow.ForProject(getBaseDir())
+	return sm
+}
+
+// joinStatuses lists statuses comma-separated for error messages.
+func joinStatuses(statuses []models.Status) string {
+	names := make([]string, len(statuses))
+	for i, s := range statuses {
+		names[i] = string(s)
+	}
+	return strings.Join(names, ", ")
+}
+
+// customStatuses returns the project's custom workflow statuses, which
+// --status flags and TDQ accept alongside the built-in ones.
+func customStatuses() []models.Status {
+	return workflowMachine().CustomStatuses()
+}
+
+// warnInvalidWorkflow warns that a workflow config failing validation is
+// ignored; an unreadable config is left to the commands that need it.
+func warnInvalidWorkflow() {
+	if wf, err := config.GetWorkflow(getBaseDir()); err == nil && wf != nil {
+		if err := workflow.ValidateConfig(wf); err != nil {
+			output.WarningErr("ignoring workflow config: %v", err)
+		}
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(workflowCmd)
 
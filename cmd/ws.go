@@ -14,7 +14,6 @@ import (
 	"github.com/marcus/td/internal/models"
 	"github.com/marcus/td/internal/output"
 	"github.com/marcus/td/internal/session"
-	"github.com/marcus/td/internal/workflow"
 	"github.com/spf13/cobra"
 )
 
@@ -135,7 +134,7 @@ var wsTagCmd = &cobra.Command{
 			noStart, _ := cmd.Flags().GetBool("no-start")
 			if !noStart && issue.Status == models.StatusOpen {
 				// Validate transition with state machine
-				sm := workflow.DefaultMachine()
+				sm := workflowMachine()
 				if !sm.IsValidTransition(issue.Status, models.StatusInProgress) {
 					output.Warning("cannot auto-start %s: invalid transition from %s", issueID, issue.Status)
 					continue
@@ -78,6 +78,7 @@ type serverConfigResponse struct {
 	CORSOrigins             []string         `json:"cors_origins"`
 	AuthEventRetention      string           `json:"auth_event_retention"`
 	RateLimitEventRetention string           `json:"rate_limit_event_retention"`
+	SyncEventRetention      string           `json:"sync_event_retention"`
 }
 
 type rateLimitsConfig struct {
@@ -108,6 +109,7 @@ func (s *Server) handleAdminServerConfig(w http.ResponseWriter, r *http.Request)
 		CORSOrigins:             origins,
 		AuthEventRetention:      formatDaysDuration(s.config.AuthEventRetention),
 		RateLimitEventRetention: formatDaysDuration(s.config.RateLimitEventRetention),
+		SyncEventRetention:      formatDaysDuration(s.config.SyncEventRetention),
 	})
 }
 
@@ -65,8 +65,7 @@ func (s *Server) handleAdminSnapshotMeta(w http.ResponseWriter, r *http.Request)
 			if e.IsDir() || !strings.HasSuffix(e.Name(), ".db") || strings.Contains(e.Name(), ".tmp") {
 				continue
 			}
-			name := strings.TrimSuffix(e.Name(), ".db")
-			if seq, err := strconv.ParseInt(name, 10, 64); err == nil && seq > snapshotSeq {
+			if seq, ok := snapshotCacheSeq(e.Name()); ok && seq > snapshotSeq {
 				snapshotSeq = seq
 				snapshotPath = filepath.Join(cacheDir, e.Name())
 			}
@@ -206,40 +205,11 @@ func (s *Server) handleAdminSnapshotQuery(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	// Find cached snapshot
-	cacheDir := filepath.Join(s.config.ProjectDataDir, "snapshots", projectID)
-	snapshotSeq := int64(0)
-	snapshotPath := ""
-
-	entries, err := os.ReadDir(cacheDir)
-	if err == nil {
-		for _, e := range entries {
-			if e.IsDir() || !strings.HasSuffix(e.Name(), ".db") || strings.Contains(e.Name(), ".tmp") {
-				continue
-			}
-			name := strings.TrimSuffix(e.Name(), ".db")
-			if seq, err := strconv.ParseInt(name, 10, 64); err == nil && seq > snapshotSeq {
-				snapshotSeq = seq
-				snapshotPath = filepath.Join(cacheDir, e.Name())
-			}
-		}
-	}
-
-	// If no cached snapshot or snapshot is too stale, build a fresh one
-	if snapshotPath == "" || (headSeq-snapshotSeq) > snapshotStalenessThreshold {
-		newPath, newSeq, err := s.buildAndCacheSnapshot(projectID, eventsDB, headSeq, cacheDir)
-		if err != nil {
-			slog.Error("snapshot query: build snapshot", "project", projectID, "err", err)
-			if snapshotPath == "" {
-				writeError(w, http.StatusInternalServerError, ErrCodeInternal, "failed to build snapshot")
-				return
-			}
-			// Fall through with stale snapshot if rebuild failed
-			slog.Warn("snapshot query: using stale snapshot", "project", projectID, "staleness", headSeq-snapshotSeq)
-		} else {
-			snapshotPath = newPath
-			snapshotSeq = newSeq
-		}
+	snapshotPath, snapshotSeq, err := s.currentSnapshot(projectID, eventsDB, headSeq)
+	if err != nil {
+		slog.Error("snapshot query: build snapshot", "project", projectID, "err", err)
+		writeError(w, http.StatusInternalServerError, ErrCodeInternal, "failed to build snapshot")
+		return
 	}
 
 	// Open snapshot read-only
@@ -301,6 +271,44 @@ func (s *Server) handleAdminSnapshotQuery(w http.ResponseWriter, r *http.Request
 	})
 }
 
+// currentSnapshot returns the path and seq of the newest cached snapshot for a
+// project, building a fresh one when none is cached or the cached one is more
+// than snapshotStalenessThreshold events behind headSeq. If a rebuild fails
+// the stale snapshot is used; an error means no snapshot is available.
+func (s *Server) currentSnapshot(projectID string, eventsDB *sql.DB, headSeq int64) (string, int64, error) {
+	cacheDir := filepath.Join(s.config.ProjectDataDir, "snapshots", projectID)
+	snapshotSeq := int64(0)
+	snapshotPath := ""
+
+	entries, err := os.ReadDir(cacheDir)
+	if err == nil {
+		for _, e := range entries {
+			if e.IsDir() || !strings.HasSuffix(e.Name(), ".db") || strings.Contains(e.Name(), ".tmp") {
+				continue
+			}
+			if seq, ok := snapshotCacheSeq(e.Name()); ok && seq > snapshotSeq {
+				snapshotSeq = seq
+				snapshotPath = filepath.Join(cacheDir, e.Name())
+			}
+		}
+	}
+
+	if snapshotPath != "" && (headSeq-snapshotSeq) <= snapshotStalenessThreshold {
+		return snapshotPath, snapshotSeq, nil
+	}
+
+	newPath, newSeq, err := s.buildAndCacheSnapshot(projectID, eventsDB, headSeq, cacheDir)
+	if err != nil {
+		if snapshotPath == "" {
+			return "", 0, err
+		}
+		// Fall through with stale snapshot if rebuild failed
+		slog.Warn("snapshot: using stale snapshot", "project", projectID, "staleness", headSeq-snapshotSeq, "err", err)
+		return snapshotPath, snapshotSeq, nil
+	}
+	return newPath, newSeq, nil
+}
+
 // buildAndCacheSnapshot builds a new snapshot and caches it, returning the path and seq.
 func (s *Server) buildAndCacheSnapshot(projectID string, eventsDB *sql.DB, headSeq int64, cacheDir string) (string, int64, error) {
 	tmpFile, err := os.CreateTemp("", "td-snapshot-*.db")
@@ -311,7 +319,7 @@ func (s *Server) buildAndCacheSnapshot(projectID string, eventsDB *sql.DB, headS
 	tmpFile.Close()
 	defer os.Remove(tmpPath)
 
-	if err := buildSnapshot(eventsDB, tmpPath, headSeq); err != nil {
+	if err := buildSnapshot(eventsDB, s.snapshotBase(projectID), tmpPath, headSeq); err != nil {
 		return "", 0, fmt.Errorf("build: %w", err)
 	}
 
@@ -329,7 +337,7 @@ func (s *Server) buildAndCacheSnapshot(projectID string, eventsDB *sql.DB, headS
 		return "", 0, fmt.Errorf("rename: %w", err)
 	}
 
-	cleanSnapshotCache(cacheDir, headSeq)
+	cleanSnapshotCache(cacheDir, filepath.Base(cachePath))
 	slog.Info("snapshot query: cached", "project", projectID, "seq", headSeq)
 	return cachePath, headSeq, nil
 }
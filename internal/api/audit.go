@@ -0,0 +1,178 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	tdevents "github.com/marcus/td/internal/events"
+	tdsync "github.com/marcus/td/internal/sync"
+)
+
+// initEventAuthors creates the table recording which user pushed each event.
+// It lives beside events in the project's events.db; events pushed before it
+// existed simply have no row.
+func initEventAuthors(db *sql.DB) error {
+	if _, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS event_authors (
+			server_seq INTEGER PRIMARY KEY,
+			user_id    TEXT NOT NULL,
+			key_id     TEXT NOT NULL DEFAULT ''
+		)
+	`); err != nil {
+		return fmt.Errorf("create event_authors: %w", err)
+	}
+	return nil
+}
+
+// recordEventAuthors attributes newly accepted events to the pushing user and
+// API key, in the same transaction as the insert.
+func recordEventAuthors(tx *sql.Tx, acks []tdsync.Ack, user *AuthUser) error {
+	if user == nil || len(acks) == 0 {
+		return nil
+	}
+	stmt, err := tx.Prepare(`INSERT OR IGNORE INTO event_authors (server_seq, user_id, key_id) VALUES (?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	for _, a := range acks {
+		if _, err := stmt.Exec(a.ServerSeq, user.UserID, user.KeyID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// auditEvent is one pushed event with its device, session and user.
+type auditEvent struct {
+	ServerSeq       int64  `json:"server_seq"`
+	DeviceID        string `json:"device_id"`
+	SessionID       string `json:"session_id"`
+	UserID          string `json:"user_id,omitempty"`
+	UserEmail       string `json:"user_email,omitempty"`
+	KeyID           string `json:"key_id,omitempty"`
+	ActionType      string `json:"action_type"`
+	EntityType      string `json:"entity_type"`
+	EntityID        string `json:"entity_id"`
+	ClientTimestamp string `json:"client_timestamp"`
+	ServerTimestamp string `json:"server_timestamp"`
+}
+
+// auditResponse is the paginated response for GET /v1/projects/{id}/audit.
+type auditResponse struct {
+	Data    []auditEvent `json:"data"`
+	HasMore bool         `json:"has_more"`
+}
+
+// handleProjectAudit handles GET /v1/projects/{id}/audit. It lists events in
+// server_seq order, each attributed to the user whose API key pushed it.
+// Events pushed before attribution was recorded have no user. Query
+// parameters match the admin event listing: limit, after_seq, entity_type,
+// from and to (server timestamps).
+func (s *Server) handleProjectAudit(w http.ResponseWriter, r *http.Request) {
+	projectID := r.PathValue("id")
+
+	db, err := s.dbPool.Get(projectID)
+	if err != nil {
+		logFor(r.Context()).Error("audit: get db", "project", projectID, "err", err)
+		writeError(w, http.StatusInternalServerError, ErrCodeInternal, "failed to open project database")
+		return
+	}
+
+	q := r.URL.Query()
+
+	limit := defaultEventLimit
+	if v := q.Get("limit"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			limit = n
+		}
+	}
+	if limit > maxEventLimit {
+		limit = maxEventLimit
+	}
+
+	afterSeq := int64(0)
+	if v := q.Get("after_seq"); v != "" {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			afterSeq = n
+		}
+	}
+
+	query := `SELECT e.server_seq, e.device_id, e.session_id, COALESCE(a.user_id, ''), COALESCE(a.key_id, ''),
+		e.action_type, e.entity_type, e.entity_id, e.client_timestamp, e.server_timestamp
+		FROM events e LEFT JOIN event_authors a ON a.server_seq = e.server_seq
+		WHERE e.server_seq > ?`
+	args := []any{afterSeq}
+
+	if v := q.Get("entity_type"); v != "" {
+		if !isValidEntityType(v) {
+			writeError(w, http.StatusBadRequest, ErrCodeBadRequest, "invalid entity_type: "+v)
+			return
+		}
+		normalized, _ := tdevents.NormalizeEntityType(v)
+		query += " AND e.entity_type = ?"
+		args = append(args, string(normalized))
+	}
+	if v := q.Get("from"); v != "" {
+		query += " AND e.server_timestamp >= ?"
+		args = append(args, v)
+	}
+	if v := q.Get("to"); v != "" {
+		query += " AND e.server_timestamp <= ?"
+		args = append(args, v)
+	}
+
+	query += " ORDER BY e.server_seq ASC LIMIT ?"
+	args = append(args, limit+1) // Fetch one extra to determine has_more
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		logFor(r.Context()).Error("audit: query", "project", projectID, "err", err)
+		writeError(w, http.StatusInternalServerError, ErrCodeInternal, "failed to query events")
+		return
+	}
+	defer rows.Close()
+
+	events := []auditEvent{}
+	for rows.Next() {
+		var ev auditEvent
+		if err := rows.Scan(&ev.ServerSeq, &ev.DeviceID, &ev.SessionID, &ev.UserID, &ev.KeyID,
+			&ev.ActionType, &ev.EntityType, &ev.EntityID, &ev.ClientTimestamp, &ev.ServerTimestamp); err != nil {
+			logFor(r.Context()).Error("audit: scan", "project", projectID, "err", err)
+			writeError(w, http.StatusInternalServerError, ErrCodeInternal, "failed to read events")
+			return
+		}
+		events = append(events, ev)
+	}
+	if err := rows.Err(); err != nil {
+		logFor(r.Context()).Error("audit: iterate", "project", projectID, "err", err)
+		writeError(w, http.StatusInternalServerError, ErrCodeInternal, "failed to read events")
+		return
+	}
+
+	hasMore := len(events) > limit
+	if hasMore {
+		events = events[:limit]
+	}
+
+	// Users live in server.db, so resolve emails here rather than in the join
+	emails := make(map[string]string)
+	for i := range events {
+		id := events[i].UserID
+		if id == "" {
+			continue
+		}
+		email, ok := emails[id]
+		if !ok {
+			if u, err := s.store.GetUserByID(id); err == nil && u != nil {
+				email = u.Email
+			}
+			emails[id] = email
+		}
+		events[i].UserEmail = email
+	}
+
+	writeJSON(w, http.StatusOK, auditResponse{Data: events, HasMore: hasMore})
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestProjectAuditAttributesEventsToPushers(t *testing.T) {
+	srv, store := newTestServer(t)
+	_, ownerToken := createTestUser(t, store, "owner@test.com")
+	_, writerToken := createTestUser(t, store, "writer@test.com")
+	_, readerToken := createTestUser(t, store, "reader@test.com")
+	owner, _ := store.GetUserByEmail("owner@test.com")
+	writer, _ := store.GetUserByEmail("writer@test.com")
+	reader, _ := store.GetUserByEmail("reader@test.com")
+
+	w := doRequest(srv, "POST", "/v1/projects", ownerToken, CreateProjectRequest{Name: "audit-test"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: expected 201, got %d", w.Code)
+	}
+	var project ProjectResponse
+	_ = json.NewDecoder(w.Body).Decode(&project)
+
+	for _, m := range []AddMemberRequest{{UserID: writer.ID, Role: "writer"}, {UserID: reader.ID, Role: "reader"}} {
+		w = doRequest(srv, "POST", fmt.Sprintf("/v1/projects/%s/members", project.ID), ownerToken, m)
+		if w.Code != http.StatusCreated {
+			t.Fatalf("add %s: expected 201, got %d", m.Role, w.Code)
+		}
+	}
+
+	push := func(token, device, entityType, entityID string) {
+		t.Helper()
+		w := doRequest(srv, "POST", fmt.Sprintf("/v1/projects/%s/sync/push", project.ID), token, PushRequest{
+			DeviceID:  device,
+			SessionID: "sess-" + device,
+			Events: []EventInput{{
+				ClientActionID:  1,
+				ActionType:      "create",
+				EntityType:      entityType,
+				EntityID:        entityID,
+				Payload:         json.RawMessage(`{"title":"audit"}`),
+				ClientTimestamp: "2025-01-01T00:00:00Z",
+			}},
+		})
+		if w.Code != http.StatusOK {
+			t.Fatalf("push as %s: expected 200, got %d: %s", device, w.Code, w.Body.String())
+		}
+	}
+	push(ownerToken, "dev-owner", "issues", "i_owner_001")
+	push(writerToken, "dev-writer", "issues", "i_writer_001")
+	push(writerToken, "dev-writer-2", "logs", "l_writer_001")
+
+	auditPath := fmt.Sprintf("/v1/projects/%s/audit", project.ID)
+	w = doRequest(srv, "GET", auditPath, ownerToken, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("audit: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp auditResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp.Data) != 3 || resp.HasMore {
+		t.Fatalf("expected 3 events without more, got %d (has_more=%v)", len(resp.Data), resp.HasMore)
+	}
+	want := []struct{ userID, email, device string }{
+		{owner.ID, "owner@test.com", "dev-owner"},
+		{writer.ID, "writer@test.com", "dev-writer"},
+		{writer.ID, "writer@test.com", "dev-writer-2"},
+	}
+	for i, ev := range resp.Data {
+		if ev.UserID != want[i].userID || ev.UserEmail != want[i].email || ev.DeviceID != want[i].device {
+			t.Errorf("event %d: got user %s <%s> device %s, want %s <%s> device %s",
+				i, ev.UserID, ev.UserEmail, ev.DeviceID, want[i].userID, want[i].email, want[i].device)
+		}
+		if ev.KeyID == "" {
+			t.Errorf("event %d: expected the pushing key ID", i)
+		}
+	}
+
+	// Filter by entity type and paginate
+	w = doRequest(srv, "GET", auditPath+"?entity_type=issues&limit=1", writerToken, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("filtered audit: expected 200, got %d", w.Code)
+	}
+	resp = auditResponse{}
+	_ = json.NewDecoder(w.Body).Decode(&resp)
+	if len(resp.Data) != 1 || !resp.HasMore || resp.Data[0].EntityID != "i_owner_001" {
+		t.Fatalf("expected first issue event with more to come, got %+v", resp)
+	}
+	w = doRequest(srv, "GET", fmt.Sprintf("%s?entity_type=issues&after_seq=%d", auditPath, resp.Data[0].ServerSeq), writerToken, nil)
+	resp = auditResponse{}
+	_ = json.NewDecoder(w.Body).Decode(&resp)
+	if len(resp.Data) != 1 || resp.HasMore || resp.Data[0].UserEmail != "writer@test.com" {
+		t.Fatalf("expected the writer's issue event last, got %+v", resp)
+	}
+
+	// Readers can pull events but not the audit log
+	w = doRequest(srv, "GET", auditPath, readerToken, nil)
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("reader audit: expected 403, got %d", w.Code)
+	}
+}
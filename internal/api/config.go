@@ -23,11 +23,19 @@ type Config struct {
 	RateLimitPull  int // /sync/pull per API key per minute (default: 120)
 	RateLimitOther int // all other per API key per minute (default: 300)
 
+	MaxEventPayloadBytes int           // max payload size of one pushed event (default: 1 MiB)
+	MaxClockSkew         time.Duration // how far ahead of server time a client_timestamp may be (default: 10m)
+
+	SnapshotURLSecret string        // HMAC key for signed snapshot URLs; random per process when empty
+	SnapshotURLTTL    time.Duration // lifetime of a signed snapshot URL (default: 5m)
+	SnapshotURLBase   string        // origin signed snapshot URLs point at, e.g. a CDN (default: BaseURL)
+
 	TrustedProxies     []string // trusted proxy IPs; when empty, X-Forwarded-For is ignored
 	CORSAllowedOrigins []string // allowed origins for admin CORS; empty = disabled
 
 	AuthEventRetention      time.Duration // retention period for auth events (default: 90 days)
 	RateLimitEventRetention time.Duration // retention period for rate limit events (default: 30 days)
+	SyncEventRetention      time.Duration // age after which sync events may be pruned behind a base snapshot (default: 0, never)
 
 	EmailProvider           string // "cloudflare", "memory", "log"; default "log" for dev
 	CloudflareAccountID     string
@@ -38,6 +46,8 @@ type Config struct {
 	AuthWebCallbackURL      string // e.g. https://watch.haplab.com/home/login/complete
 	AuthEmailBaseURL        string // e.g. https://sync.haplab.com (for link generation)
 
+	MetricsEnabled bool // When true, exposes Prometheus-format metrics at GET /metrics
+
 	LegacyDeviceAuth bool // When true, enables /v1/auth/login/start, /v1/auth/login/poll, GET/POST /auth/verify
 
 	// DevEmailInspect, when true, allows GET /internal/dev/last-email to return
@@ -65,6 +75,10 @@ func LoadConfig() Config {
 		RateLimitPull:  120,
 		RateLimitOther: 300,
 
+		MaxEventPayloadBytes: defMaxEventPayloadBytes,
+		MaxClockSkew:         defMaxClockSkew,
+		SnapshotURLTTL:       defSnapshotURLTTL,
+
 		AuthEventRetention:      90 * 24 * time.Hour,
 		RateLimitEventRetention: 30 * 24 * time.Hour,
 	}
@@ -117,6 +131,29 @@ func LoadConfig() Config {
 		}
 	}
 
+	if v := os.Getenv("SYNC_MAX_EVENT_PAYLOAD_BYTES"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			cfg.MaxEventPayloadBytes = n
+		}
+	}
+	if v := os.Getenv("SYNC_MAX_CLOCK_SKEW"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			cfg.MaxClockSkew = d
+		}
+	}
+
+	if v := os.Getenv("SYNC_SNAPSHOT_URL_SECRET"); v != "" {
+		cfg.SnapshotURLSecret = v
+	}
+	if v := os.Getenv("SYNC_SNAPSHOT_URL_TTL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			cfg.SnapshotURLTTL = d
+		}
+	}
+	if v := os.Getenv("SYNC_SNAPSHOT_URL_BASE"); v != "" {
+		cfg.SnapshotURLBase = v
+	}
+
 	if v := os.Getenv("SYNC_AUTH_EVENT_RETENTION"); v != "" {
 		if d := parseDaysDuration(v); d > 0 {
 			cfg.AuthEventRetention = d
@@ -127,6 +164,11 @@ func LoadConfig() Config {
 			cfg.RateLimitEventRetention = d
 		}
 	}
+	if v := os.Getenv("SYNC_EVENT_RETENTION"); v != "" {
+		if d := parseDaysDuration(v); d > 0 {
+			cfg.SyncEventRetention = d
+		}
+	}
 
 	if v := os.Getenv("SYNC_TRUSTED_PROXIES"); v != "" {
 		for _, p := range strings.Split(v, ",") {
@@ -173,6 +215,9 @@ func LoadConfig() Config {
 		cfg.AuthEmailBaseURL = v
 	}
 
+	if v := os.Getenv("SYNC_METRICS_ENABLED"); v == "true" || v == "1" {
+		cfg.MetricsEnabled = true
+	}
 	if v := os.Getenv("SYNC_LEGACY_DEVICE_AUTH"); v == "true" || v == "1" {
 		cfg.LegacyDeviceAuth = true
 	}
@@ -124,6 +124,10 @@ func openProjectDB(dbPath string) (*sql.DB, error) {
 		db.Close()
 		return nil, fmt.Errorf("init event log: %w", err)
 	}
+	if err := initEventAuthors(db); err != nil {
+		db.Close()
+		return nil, err
+	}
 
 	return db, nil
 }
@@ -26,6 +26,7 @@ const (
 	ErrCodeSnapshotUnavailable = "snapshot_unavailable"
 	ErrCodeExportTooLarge      = "export_too_large"
 	ErrCodeInvalidQuery        = "invalid_query"
+	ErrCodeCursorExpired       = "cursor_expired"
 )
 
 // APIError represents a structured error returned by the API.
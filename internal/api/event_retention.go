@@ -0,0 +1,213 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	tdsync "github.com/marcus/td/internal/sync"
+)
+
+// eventPruneInterval is how often the retention loop walks the projects.
+const eventPruneInterval = 1 * time.Hour
+
+// snapshotBase is a snapshot DB covering every event through Seq. Snapshot
+// builds start from it instead of an empty DB, which is what lets the events
+// it covers be pruned. The zero value means "replay from the beginning".
+type snapshotBase struct {
+	Path string
+	Seq  int64
+}
+
+// snapshotBaseDir is where a project's base snapshot lives. It sits inside
+// the snapshot cache dir, which the cache eviction skips subdirectories of.
+func (s *Server) snapshotBaseDir(projectID string) string {
+	return projectSnapshotBaseDir(s.config.ProjectDataDir, projectID)
+}
+
+// snapshotBase returns the newest base snapshot for a project, or the zero
+// value if its event log has never been pruned.
+func (s *Server) snapshotBase(projectID string) snapshotBase {
+	return findSnapshotBase(s.snapshotBaseDir(projectID))
+}
+
+// projectSnapshotBaseDir is snapshotBaseDir for callers that only know the
+// project data dir, such as the live project DB pool.
+func projectSnapshotBaseDir(dataDir, projectID string) string {
+	return filepath.Join(dataDir, "snapshots", projectID, "base")
+}
+
+// findSnapshotBase returns the newest base snapshot in dir.
+func findSnapshotBase(dir string) snapshotBase {
+	var base snapshotBase
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return base
+	}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".db") || strings.Contains(e.Name(), ".tmp") {
+			continue
+		}
+		seq, err := strconv.ParseInt(strings.TrimSuffix(e.Name(), ".db"), 10, 64)
+		if err == nil && seq > base.Seq {
+			base = snapshotBase{Path: filepath.Join(dir, e.Name()), Seq: seq}
+		}
+	}
+	return base
+}
+
+// startEventPruner launches the retention loop when SyncEventRetention is
+// set. The goroutine exits when ctx is cancelled.
+func (s *Server) startEventPruner(ctx context.Context) {
+	if s.config.SyncEventRetention <= 0 {
+		return
+	}
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				slog.Error("event pruner panic", "panic", r)
+			}
+		}()
+		ticker := time.NewTicker(eventPruneInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.pruneEventsOnce(ctx, time.Now())
+			}
+		}
+	}()
+}
+
+// pruneEventsOnce runs one retention pass over every project.
+func (s *Server) pruneEventsOnce(ctx context.Context, now time.Time) {
+	ids, err := s.store.ListProjectIDs()
+	if err != nil {
+		slog.Error("event pruner: list projects", "err", err)
+		return
+	}
+	for _, projectID := range ids {
+		if ctx.Err() != nil {
+			return
+		}
+		n, err := s.pruneProjectEvents(projectID, now)
+		if err != nil {
+			slog.Warn("event pruner", "project", projectID, "err", err)
+			continue
+		}
+		if n > 0 {
+			slog.Info("pruned sync events", "project", projectID, "count", n)
+		}
+	}
+}
+
+// pruneProjectEvents prunes a project's event log through its retention
+// watermark. It first writes a base snapshot at the watermark, so snapshots
+// and live DB bootstraps stay complete, then deletes the events it covers
+// along with their event_authors rows; pull cursors below the watermark get
+// 410 Gone from then on. Returns the number of events deleted.
+func (s *Server) pruneProjectEvents(projectID string, now time.Time) (int64, error) {
+	eventsDB, err := s.dbPool.Get(projectID)
+	if err != nil {
+		return 0, fmt.Errorf("open project db: %w", err)
+	}
+
+	watermark, err := s.retentionWatermark(projectID, eventsDB, now)
+	if err != nil {
+		return 0, err
+	}
+	base := s.snapshotBase(projectID)
+	if watermark <= base.Seq {
+		return 0, nil
+	}
+
+	baseDir := s.snapshotBaseDir(projectID)
+	if err := os.MkdirAll(baseDir, 0o755); err != nil {
+		return 0, fmt.Errorf("create base snapshot dir: %w", err)
+	}
+	basePath := filepath.Join(baseDir, fmt.Sprintf("%d.db", watermark))
+	tmpPath := basePath + fmt.Sprintf(".tmp.%d", os.Getpid())
+	if err := buildSnapshot(eventsDB, base, tmpPath, watermark); err != nil {
+		os.Remove(tmpPath)
+		return 0, fmt.Errorf("build base snapshot: %w", err)
+	}
+	if err := os.Rename(tmpPath, basePath); err != nil {
+		os.Remove(tmpPath)
+		return 0, fmt.Errorf("save base snapshot: %w", err)
+	}
+
+	tx, err := eventsDB.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("begin prune tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	n, err := tdsync.PruneEventsThrough(tx, watermark)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := tx.Exec(`DELETE FROM event_authors WHERE server_seq <= ?`, watermark); err != nil {
+		return 0, fmt.Errorf("prune event authors: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit prune: %w", err)
+	}
+
+	if base.Path != "" {
+		if err := os.Remove(base.Path); err != nil {
+			slog.Warn("remove old base snapshot", "path", base.Path, "err", err)
+		}
+	}
+	return n, nil
+}
+
+// retentionWatermark returns the highest server_seq that may be pruned: the
+// newest event older than SyncEventRetention, held back by the cursor of
+// every client that synced within that window and by the live project DB's
+// applied cursor. A project without a live DB yet is not held back: its
+// bootstrap starts from the base snapshot. The newest event is always kept
+// so the log's head seq stays readable. Returns 0 when nothing may be pruned.
+func (s *Server) retentionWatermark(projectID string, eventsDB *sql.DB, now time.Time) (int64, error) {
+	cutoff := now.Add(-s.config.SyncEventRetention).UTC()
+
+	var head, aged int64
+	if err := eventsDB.QueryRow(`SELECT COALESCE(MAX(server_seq), 0) FROM events`).Scan(&head); err != nil {
+		return 0, fmt.Errorf("query head seq: %w", err)
+	}
+	if err := eventsDB.QueryRow(
+		`SELECT COALESCE(MAX(server_seq), 0) FROM events WHERE server_timestamp < ?`,
+		cutoff.Format("2006-01-02 15:04:05"),
+	).Scan(&aged); err != nil {
+		return 0, fmt.Errorf("query aged events: %w", err)
+	}
+	watermark := min(aged, head-1)
+
+	cursors, err := s.store.ListSyncCursorsForProject(projectID)
+	if err != nil {
+		return 0, err
+	}
+	for _, c := range cursors {
+		if c.LastSyncAt != nil && c.LastSyncAt.After(cutoff) {
+			watermark = min(watermark, c.LastEventID)
+		}
+	}
+
+	if s.projectLivePool != nil {
+		_, applied, err := s.readProjectLag(context.Background(), projectID)
+		switch {
+		case err == nil:
+			watermark = min(watermark, applied)
+		case !errors.Is(err, errLagMissingDB):
+			return 0, fmt.Errorf("read applied cursor: %w", err)
+		}
+	}
+	return max(watermark, 0), nil
+}
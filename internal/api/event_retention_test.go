@@ -0,0 +1,369 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPruneProjectEventsKeepsSnapshotComplete(t *testing.T) {
+	srv, store := newTestServerWithConfig(t, func(c *Config) {
+		c.SyncEventRetention = 24 * time.Hour
+	})
+	_, token := createTestUser(t, store, "retention@test.com")
+
+	w := doRequest(srv, "POST", "/v1/projects", token, CreateProjectRequest{Name: "retention"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create project: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var project ProjectResponse
+	_ = json.NewDecoder(w.Body).Decode(&project)
+
+	pushBody := PushRequest{DeviceID: "dev1", SessionID: "sess1"}
+	for i := int64(1); i <= 4; i++ {
+		pushBody.Events = append(pushBody.Events, EventInput{
+			ClientActionID:  i,
+			ActionType:      "create",
+			EntityType:      "issues",
+			EntityID:        fmt.Sprintf("i_%03d", i),
+			Payload:         json.RawMessage(fmt.Sprintf(`{"schema_version":1,"new_data":{"title":"issue %d","status":"open"}}`, i)),
+			ClientTimestamp: "2025-01-01T00:00:00Z",
+		})
+	}
+	pushBody.Events = append(pushBody.Events, EventInput{
+		ClientActionID:  5,
+		ActionType:      "update",
+		EntityType:      "issues",
+		EntityID:        "i_001",
+		Payload:         json.RawMessage(`{"schema_version":1,"new_data":{"title":"updated","status":"closed"}}`),
+		ClientTimestamp: "2025-01-01T00:00:01Z",
+	})
+	w = doRequest(srv, "POST", fmt.Sprintf("/v1/projects/%s/sync/push", project.ID), token, pushBody)
+	if w.Code != http.StatusOK {
+		t.Fatalf("push: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	eventsDB, err := srv.dbPool.Get(project.ID)
+	if err != nil {
+		t.Fatalf("get project db: %v", err)
+	}
+	// Age everything but the update past the retention window
+	if _, err := eventsDB.Exec(`UPDATE events SET server_timestamp = '2000-01-01 00:00:00' WHERE server_seq <= 4`); err != nil {
+		t.Fatalf("age events: %v", err)
+	}
+
+	// An active client at seq 2 holds the watermark back
+	if err := store.UpsertSyncCursor(project.ID, "dev2", 2); err != nil {
+		t.Fatalf("upsert cursor: %v", err)
+	}
+	n, err := srv.pruneProjectEvents(project.ID, time.Now())
+	if err != nil {
+		t.Fatalf("prune: %v", err)
+	}
+	if n != 2 {
+		t.Fatalf("first prune: deleted %d events, want 2", n)
+	}
+
+	// Once it catches up, the aged events go too and the base moves forward
+	if err := store.UpsertSyncCursor(project.ID, "dev2", 5); err != nil {
+		t.Fatalf("upsert cursor: %v", err)
+	}
+	if n, err = srv.pruneProjectEvents(project.ID, time.Now()); err != nil {
+		t.Fatalf("prune: %v", err)
+	}
+	if n != 2 {
+		t.Fatalf("second prune: deleted %d events, want 2", n)
+	}
+	if base := srv.snapshotBase(project.ID); base.Seq != 4 {
+		t.Fatalf("base snapshot seq = %d, want 4", base.Seq)
+	}
+	bases, _ := filepath.Glob(filepath.Join(srv.snapshotBaseDir(project.ID), "*.db"))
+	if len(bases) != 1 {
+		t.Fatalf("expected the old base snapshot to be removed, found %v", bases)
+	}
+
+	// Nothing new is old enough, so another pass is a no-op
+	if n, err = srv.pruneProjectEvents(project.ID, time.Now()); err != nil || n != 0 {
+		t.Fatalf("idle prune: n=%d err=%v", n, err)
+	}
+
+	// The snapshot still reflects every event, pruned or not
+	w = doRequest(srv, "GET", fmt.Sprintf("/v1/projects/%s/sync/snapshot", project.ID), token, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("snapshot: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("X-Snapshot-Seq"); got != "5" {
+		t.Fatalf("X-Snapshot-Seq = %q, want 5", got)
+	}
+	snapPath := filepath.Join(t.TempDir(), "snapshot.db")
+	if err := os.WriteFile(snapPath, w.Body.Bytes(), 0644); err != nil {
+		t.Fatalf("write snapshot: %v", err)
+	}
+	snap, err := openSnapshotDB(snapPath)
+	if err != nil {
+		t.Fatalf("open snapshot: %v", err)
+	}
+	defer snap.Close()
+	var count int
+	if err := snap.QueryRow(`SELECT COUNT(*) FROM issues`).Scan(&count); err != nil {
+		t.Fatalf("count issues: %v", err)
+	}
+	if count != 4 {
+		t.Fatalf("snapshot has %d issues, want 4", count)
+	}
+	var title, status string
+	if err := snap.QueryRow(`SELECT title, status FROM issues WHERE id = 'i_001'`).Scan(&title, &status); err != nil {
+		t.Fatalf("query i_001: %v", err)
+	}
+	if title != "updated" || status != "closed" {
+		t.Fatalf("i_001 = %q/%q, want updated/closed", title, status)
+	}
+
+	// A cursor below the watermark must re-bootstrap; one at it pulls on
+	w = doRequest(srv, "GET", fmt.Sprintf("/v1/projects/%s/sync/pull?after_server_seq=2", project.ID), token, nil)
+	if w.Code != http.StatusGone {
+		t.Fatalf("stale cursor: expected 410, got %d: %s", w.Code, w.Body.String())
+	}
+	w = doRequest(srv, "GET", fmt.Sprintf("/v1/projects/%s/sync/pull?after_server_seq=4", project.ID), token, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("current cursor: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var pull PullResponse
+	_ = json.NewDecoder(w.Body).Decode(&pull)
+	if len(pull.Events) != 1 || pull.Events[0].ServerSeq != 5 {
+		t.Fatalf("current cursor events mismatch: %+v", pull.Events)
+	}
+
+	// A full export starts after the pruned range and says so
+	w = doRequest(srv, "GET", fmt.Sprintf("/v1/projects/%s/export", project.ID), token, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("export: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("X-Pruned-Through-Seq"); got != "4" {
+		t.Fatalf("X-Pruned-Through-Seq = %q, want 4", got)
+	}
+	var exported PullEvent
+	if err := json.Unmarshal(w.Body.Bytes(), &exported); err != nil || exported.ServerSeq != 5 {
+		t.Fatalf("export = %q, want only seq 5 (err %v)", w.Body.String(), err)
+	}
+	w = doRequest(srv, "GET", fmt.Sprintf("/v1/projects/%s/export?after_server_seq=2", project.ID), token, nil)
+	if w.Code != http.StatusGone {
+		t.Fatalf("stale export cursor: expected 410, got %d", w.Code)
+	}
+}
+
+func TestRetentionWatermarkIgnoresIdleClients(t *testing.T) {
+	srv, store := newTestServerWithConfig(t, func(c *Config) {
+		c.SyncEventRetention = time.Hour
+	})
+	_, token := createTestUser(t, store, "retention-idle@test.com")
+
+	w := doRequest(srv, "POST", "/v1/projects", token, CreateProjectRequest{Name: "retention-idle"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create project: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var project ProjectResponse
+	_ = json.NewDecoder(w.Body).Decode(&project)
+
+	pushBody := PushRequest{DeviceID: "dev1", SessionID: "sess1"}
+	for i := int64(1); i <= 3; i++ {
+		pushBody.Events = append(pushBody.Events, EventInput{
+			ClientActionID:  i,
+			ActionType:      "create",
+			EntityType:      "issues",
+			EntityID:        fmt.Sprintf("i_%03d", i),
+			Payload:         json.RawMessage(`{"schema_version":1,"new_data":{"title":"t","status":"open"}}`),
+			ClientTimestamp: "2025-01-01T00:00:00Z",
+		})
+	}
+	w = doRequest(srv, "POST", fmt.Sprintf("/v1/projects/%s/sync/push", project.ID), token, pushBody)
+	if w.Code != http.StatusOK {
+		t.Fatalf("push: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	eventsDB, err := srv.dbPool.Get(project.ID)
+	if err != nil {
+		t.Fatalf("get project db: %v", err)
+	}
+
+	// Fresh events are never pruned
+	if wm, err := srv.retentionWatermark(project.ID, eventsDB, time.Now()); err != nil || wm != 0 {
+		t.Fatalf("fresh events: watermark=%d err=%v, want 0", wm, err)
+	}
+
+	// A client that synced at seq 1 holds the log back while it is active,
+	// but not once it has been idle longer than the retention window
+	if err := store.UpsertSyncCursor(project.ID, "dev2", 1); err != nil {
+		t.Fatalf("upsert cursor: %v", err)
+	}
+	later := time.Now().Add(30 * time.Minute)
+	if _, err := eventsDB.Exec(`UPDATE events SET server_timestamp = '2000-01-01 00:00:00'`); err != nil {
+		t.Fatalf("age events: %v", err)
+	}
+	if wm, err := srv.retentionWatermark(project.ID, eventsDB, later); err != nil || wm != 1 {
+		t.Fatalf("active client: watermark=%d err=%v, want 1", wm, err)
+	}
+	muchLater := time.Now().Add(3 * time.Hour)
+	if wm, err := srv.retentionWatermark(project.ID, eventsDB, muchLater); err != nil || wm != 2 {
+		t.Fatalf("idle client: watermark=%d err=%v, want 2 (head is kept)", wm, err)
+	}
+}
+
+func TestSettingsChangeKeepsBaseSnapshot(t *testing.T) {
+	srv, store := newTestServerWithConfig(t, func(c *Config) {
+		c.SyncEventRetention = time.Hour
+	})
+	_, token := createTestUser(t, store, "retention-settings@test.com")
+
+	w := doRequest(srv, "POST", "/v1/projects", token, CreateProjectRequest{Name: "retention-settings"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create project: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var project ProjectResponse
+	_ = json.NewDecoder(w.Body).Decode(&project)
+
+	pushBody := PushRequest{DeviceID: "dev1", SessionID: "sess1"}
+	for i := int64(1); i <= 3; i++ {
+		pushBody.Events = append(pushBody.Events, EventInput{
+			ClientActionID:  i,
+			ActionType:      "create",
+			EntityType:      "issues",
+			EntityID:        fmt.Sprintf("i_%03d", i),
+			Payload:         json.RawMessage(`{"schema_version":1,"new_data":{"title":"t","status":"open"}}`),
+			ClientTimestamp: "2025-01-01T00:00:00Z",
+		})
+	}
+	w = doRequest(srv, "POST", fmt.Sprintf("/v1/projects/%s/sync/push", project.ID), token, pushBody)
+	if w.Code != http.StatusOK {
+		t.Fatalf("push: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	eventsDB, err := srv.dbPool.Get(project.ID)
+	if err != nil {
+		t.Fatalf("get project db: %v", err)
+	}
+	if _, err := eventsDB.Exec(`UPDATE events SET server_timestamp = '2000-01-01 00:00:00'`); err != nil {
+		t.Fatalf("age events: %v", err)
+	}
+	if n, err := srv.pruneProjectEvents(project.ID, time.Now()); err != nil || n != 2 {
+		t.Fatalf("prune: n=%d err=%v, want 2", n, err)
+	}
+
+	snapshotPath := fmt.Sprintf("/v1/projects/%s/sync/snapshot", project.ID)
+	if w = doRequest(srv, "GET", snapshotPath, token, nil); w.Code != http.StatusOK {
+		t.Fatalf("snapshot: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = doRequest(srv, "PUT", fmt.Sprintf("/v1/projects/%s/settings", project.ID), token, json.RawMessage(`{"require_close_reason":true}`))
+	if w.Code != http.StatusOK {
+		t.Fatalf("put settings: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if base := srv.snapshotBase(project.ID); base.Seq != 2 {
+		t.Fatalf("base snapshot seq after settings change = %d, want 2", base.Seq)
+	}
+
+	// The rebuilt snapshot still has the pruned issues and the new settings
+	w = doRequest(srv, "GET", snapshotPath, token, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("snapshot after settings change: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	snapPath := filepath.Join(t.TempDir(), "snapshot.db")
+	if err := os.WriteFile(snapPath, w.Body.Bytes(), 0644); err != nil {
+		t.Fatalf("write snapshot: %v", err)
+	}
+	snap, err := openSnapshotDB(snapPath)
+	if err != nil {
+		t.Fatalf("open snapshot: %v", err)
+	}
+	defer snap.Close()
+	var count int
+	if err := snap.QueryRow(`SELECT COUNT(*) FROM issues`).Scan(&count); err != nil {
+		t.Fatalf("count issues: %v", err)
+	}
+	if count != 3 {
+		t.Fatalf("snapshot has %d issues, want 3", count)
+	}
+	var settings string
+	if err := snap.QueryRow(`SELECT settings FROM project_settings WHERE id = 1`).Scan(&settings); err != nil {
+		t.Fatalf("read project_settings: %v", err)
+	}
+	if settings != `{"require_close_reason":true}` {
+		t.Fatalf("snapshot settings = %s", settings)
+	}
+}
+
+func TestPruneBeforeLiveDBBootstrap(t *testing.T) {
+	srv, store := newTestServerWithConfig(t, func(c *Config) {
+		c.SyncEventRetention = time.Hour
+	})
+	_, token := createTestUser(t, store, "retention-live@test.com")
+
+	w := doRequest(srv, "POST", "/v1/projects", token, CreateProjectRequest{Name: "retention-live"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create project: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var project ProjectResponse
+	_ = json.NewDecoder(w.Body).Decode(&project)
+
+	pushBody := PushRequest{DeviceID: "dev1", SessionID: "sess1"}
+	for i := int64(1); i <= 3; i++ {
+		pushBody.Events = append(pushBody.Events, EventInput{
+			ClientActionID:  i,
+			ActionType:      "create",
+			EntityType:      "issues",
+			EntityID:        fmt.Sprintf("i_%03d", i),
+			Payload:         json.RawMessage(`{"schema_version":1,"new_data":{"title":"t","status":"open"}}`),
+			ClientTimestamp: "2025-01-01T00:00:00Z",
+		})
+	}
+	pushPath := fmt.Sprintf("/v1/projects/%s/sync/push", project.ID)
+	w = doRequest(srv, "POST", pushPath, token, pushBody)
+	if w.Code != http.StatusOK {
+		t.Fatalf("push: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	eventsDB, err := srv.dbPool.Get(project.ID)
+	if err != nil {
+		t.Fatalf("get project db: %v", err)
+	}
+	if _, err := eventsDB.Exec(`UPDATE events SET server_timestamp = '2000-01-01 00:00:00'`); err != nil {
+		t.Fatalf("age events: %v", err)
+	}
+
+	// No live DB exists yet, so it doesn't hold the watermark back
+	if n, err := srv.pruneProjectEvents(project.ID, time.Now()); err != nil || n != 2 {
+		t.Fatalf("prune: n=%d err=%v, want 2", n, err)
+	}
+	var authors int
+	if err := eventsDB.QueryRow(`SELECT COUNT(*) FROM event_authors WHERE server_seq <= 2`).Scan(&authors); err != nil {
+		t.Fatalf("count event authors: %v", err)
+	}
+	if authors != 0 {
+		t.Fatalf("event_authors kept %d pruned rows, want 0", authors)
+	}
+
+	// The live DB bootstraps from the base snapshot plus the remaining event
+	liveDB, err := srv.projectLivePool.Acquire(project.ID)
+	if err != nil {
+		t.Fatalf("acquire live db: %v", err)
+	}
+	defer srv.projectLivePool.Release(project.ID)
+	if got := countIssues(t, liveDB); got != 3 {
+		t.Fatalf("live db has %d issues, want 3", got)
+	}
+	if got := queryAppliedCursor(t, liveDB); got != 3 {
+		t.Fatalf("applied cursor = %d, want 3", got)
+	}
+
+	// Retrying a pruned push is still a duplicate, not a new event
+	w = doRequest(srv, "POST", pushPath, token, PushRequest{DeviceID: "dev1", SessionID: "sess1", Events: pushBody.Events[:1]})
+	if w.Code != http.StatusOK {
+		t.Fatalf("re-push: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp PushResponse
+	_ = json.NewDecoder(w.Body).Decode(&resp)
+	if resp.Accepted != 0 || len(resp.Rejected) != 1 || resp.Rejected[0].Reason != "duplicate" || resp.Rejected[0].ServerSeq == 0 {
+		t.Fatalf("re-push response = %+v, want one duplicate with a server_seq", resp)
+	}
+}
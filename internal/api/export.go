@@ -0,0 +1,110 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	tdsync "github.com/marcus/td/internal/sync"
+)
+
+// exportBatchSize is how many events are read per query while streaming an
+// export.
+const exportBatchSize = 1000
+
+// handleProjectExport handles GET /v1/projects/{id}/export. It streams the
+// project's full event log as newline-delimited JSON, one PullEvent per line
+// in server_seq order, for analytics tools that want history rather than the
+// current state the snapshot holds.
+//
+// after_server_seq resumes an interrupted export. Without it the export
+// starts at the oldest retained event and X-Pruned-Through-Seq reports any
+// history lost to retention; an explicit cursor inside the pruned range gets
+// 410 Gone, as with pull.
+func (s *Server) handleProjectExport(w http.ResponseWriter, r *http.Request) {
+	projectID := r.PathValue("id")
+
+	afterSeq := int64(0)
+	explicitCursor := false
+	if v := r.URL.Query().Get("after_server_seq"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, "bad_request", "invalid after_server_seq")
+			return
+		}
+		afterSeq = n
+		explicitCursor = true
+	}
+
+	db, err := s.dbPool.Get(projectID)
+	if err != nil {
+		logFor(r.Context()).Error("get project db", "project", projectID, "err", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "failed to open project database")
+		return
+	}
+
+	// One read transaction keeps the export consistent with a single point
+	// in the log even if events are pushed or pruned while it streams.
+	tx, err := db.Begin()
+	if err != nil {
+		logFor(r.Context()).Error("begin tx", "err", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "database error")
+		return
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	prunedThrough, err := tdsync.PrunedThroughSeq(tx)
+	if err != nil {
+		logFor(r.Context()).Error("read retention boundary", "err", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "failed to query events")
+		return
+	}
+	if afterSeq < prunedThrough {
+		if explicitCursor {
+			writeError(w, http.StatusGone, ErrCodeCursorExpired, fmt.Sprintf(
+				"after_server_seq %d is below the oldest retained event (%d)", afterSeq, prunedThrough+1))
+			return
+		}
+		afterSeq = prunedThrough
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("X-Pruned-Through-Seq", strconv.FormatInt(prunedThrough, 10))
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+
+	enc := json.NewEncoder(w)
+	for {
+		result, err := tdsync.GetEventsSince(tx, afterSeq, exportBatchSize, "")
+		if err != nil {
+			// Headers are sent; a truncated stream is all the client can see
+			logFor(r.Context()).Error("export events", "project", projectID, "after_seq", afterSeq, "err", err)
+			return
+		}
+		for _, ev := range result.Events {
+			if err := enc.Encode(PullEvent{
+				ServerSeq:       ev.ServerSeq,
+				DeviceID:        ev.DeviceID,
+				SessionID:       ev.SessionID,
+				ClientActionID:  ev.ClientActionID,
+				ActionType:      ev.ActionType,
+				EntityType:      ev.EntityType,
+				EntityID:        ev.EntityID,
+				Payload:         ev.Payload,
+				ClientTimestamp: ev.ClientTimestamp.Format(time.RFC3339Nano),
+			}); err != nil {
+				logFor(r.Context()).Warn("export write", "project", projectID, "err", err)
+				return
+			}
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		if !result.HasMore {
+			return
+		}
+		afterSeq = result.LastServerSeq
+	}
+}
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestProjectExportStreamsOrderedNDJSON(t *testing.T) {
+	srv, store := newTestServer(t)
+	_, ownerToken := createTestUser(t, store, "owner@test.com")
+	_, readerToken := createTestUser(t, store, "reader@test.com")
+	reader, _ := store.GetUserByEmail("reader@test.com")
+
+	w := doRequest(srv, "POST", "/v1/projects", ownerToken, CreateProjectRequest{Name: "export-test"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create project: expected 201, got %d", w.Code)
+	}
+	var project ProjectResponse
+	_ = json.NewDecoder(w.Body).Decode(&project)
+
+	// Enough events to span more than one export batch
+	total := exportBatchSize + 200
+	for start := 0; start < total; start += maxPushBatch {
+		var events []EventInput
+		for i := start; i < total && i < start+maxPushBatch; i++ {
+			events = append(events, EventInput{
+				ClientActionID:  int64(i + 1),
+				ActionType:      "create",
+				EntityType:      "issues",
+				EntityID:        fmt.Sprintf("i_%05d", i+1),
+				Payload:         json.RawMessage(`{"schema_version":1,"new_data":{"title":"t"}}`),
+				ClientTimestamp: "2025-01-01T00:00:00Z",
+			})
+		}
+		w = doRequest(srv, "POST", fmt.Sprintf("/v1/projects/%s/sync/push", project.ID), ownerToken, PushRequest{
+			DeviceID: "dev1", SessionID: "sess1", Events: events,
+		})
+		if w.Code != http.StatusOK {
+			t.Fatalf("push: expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+	}
+
+	readExport := func(query string) []PullEvent {
+		t.Helper()
+		w := doRequest(srv, "GET", fmt.Sprintf("/v1/projects/%s/export%s", project.ID, query), ownerToken, nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("export%s: expected 200, got %d: %s", query, w.Code, w.Body.String())
+		}
+		if ct := w.Header().Get("Content-Type"); ct != "application/x-ndjson" {
+			t.Errorf("Content-Type = %q, want application/x-ndjson", ct)
+		}
+		var events []PullEvent
+		scanner := bufio.NewScanner(bytes.NewReader(w.Body.Bytes()))
+		scanner.Buffer(make([]byte, 0, 64<<10), 1<<20)
+		for scanner.Scan() {
+			var ev PullEvent
+			if err := json.Unmarshal(scanner.Bytes(), &ev); err != nil {
+				t.Fatalf("line %d is not a JSON object: %v: %q", len(events)+1, err, scanner.Text())
+			}
+			events = append(events, ev)
+		}
+		if err := scanner.Err(); err != nil {
+			t.Fatalf("scan export: %v", err)
+		}
+		return events
+	}
+
+	events := readExport("")
+	if len(events) != total {
+		t.Fatalf("exported %d events, want %d", len(events), total)
+	}
+	for i, ev := range events {
+		if ev.ServerSeq != int64(i+1) {
+			t.Fatalf("event %d has server_seq %d, want %d", i, ev.ServerSeq, i+1)
+		}
+	}
+	if events[0].EntityID != "i_00001" || events[0].DeviceID != "dev1" || len(events[0].Payload) == 0 {
+		t.Errorf("first event = %+v", events[0])
+	}
+
+	// Resuming picks up after the cursor
+	resumed := readExport(fmt.Sprintf("?after_server_seq=%d", total-5))
+	if len(resumed) != 5 || resumed[0].ServerSeq != int64(total-4) {
+		t.Fatalf("resumed export = %d events starting at %d, want 5 starting at %d", len(resumed), resumed[0].ServerSeq, total-4)
+	}
+
+	w = doRequest(srv, "GET", fmt.Sprintf("/v1/projects/%s/export?after_server_seq=x", project.ID), ownerToken, nil)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("bad cursor: expected 400, got %d", w.Code)
+	}
+
+	// Readers can pull but not export
+	w = doRequest(srv, "POST", fmt.Sprintf("/v1/projects/%s/members", project.ID), ownerToken, AddMemberRequest{
+		UserID: reader.ID, Role: "reader",
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("add reader: expected 201, got %d", w.Code)
+	}
+	w = doRequest(srv, "GET", fmt.Sprintf("/v1/projects/%s/export", project.ID), readerToken, nil)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("reader export: expected 403, got %d", w.Code)
+	}
+}
@@ -0,0 +1,542 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/models"
+)
+
+// GraphQLRequest is the JSON body for POST /v1/projects/{id}/graphql.
+type GraphQLRequest struct {
+	Query         string         `json:"query"`
+	Variables     map[string]any `json:"variables,omitempty"`
+	OperationName string         `json:"operationName,omitempty"` // accepted for client compatibility; one operation per request
+}
+
+// GraphQLError is one entry of a GraphQL response's errors list.
+type GraphQLError struct {
+	Message string `json:"message"`
+}
+
+// GraphQLResponse is the JSON response for POST /v1/projects/{id}/graphql.
+type GraphQLResponse struct {
+	Data        *gqlObject     `json:"data,omitempty"`
+	Errors      []GraphQLError `json:"errors,omitempty"`
+	SnapshotSeq int64          `json:"snapshot_seq,omitempty"`
+}
+
+// gqlMaxDepth bounds how deeply selections may nest (e.g. children of
+// children), so a single request can't fan out across the whole project.
+const gqlMaxDepth = 8
+
+// Scalar fields are the JSON fields of the models, so GraphQL selections use
+// the same snake_case names as the REST and CLI JSON output.
+var (
+	gqlIssueScalars = jsonFieldNames(reflect.TypeOf(models.Issue{}))
+	gqlLogScalars   = jsonFieldNames(reflect.TypeOf(models.Log{}))
+)
+
+// handleGraphQL handles POST /v1/projects/{id}/graphql. It is read-only and
+// resolves against the project's current-state snapshot, so results can lag
+// the event log by up to snapshotStalenessThreshold events.
+//
+// Schema:
+//
+//	type Query {
+//	  issues(status: [String], type: [String], parent: String, limit: Int): [Issue]
+//	  issue(id: String!): Issue
+//	}
+//	type Issue {
+//	  <every JSON field of an issue: id, title, status, type, parent_id, ...>
+//	  parent: Issue
+//	  children(status: [String], type: [String]): [Issue]
+//	  dependencies: [Issue]
+//	  logs(limit: Int): [Log]
+//	}
+//	type Log { id, issue_id, session_id, work_session_id, message, type, timestamp }
+func (s *Server) handleGraphQL(w http.ResponseWriter, r *http.Request) {
+	projectID := r.PathValue("id")
+
+	var req GraphQLRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, GraphQLResponse{Errors: []GraphQLError{{Message: "invalid json body"}}})
+		return
+	}
+	doc, err := parseGraphQL(req.Query)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, GraphQLResponse{Errors: []GraphQLError{{Message: "parse error: " + err.Error()}}})
+		return
+	}
+
+	eventsDB, err := s.dbPool.Get(projectID)
+	if err != nil {
+		writeError(w, http.StatusNotFound, ErrCodeSnapshotUnavailable, "no events for project")
+		return
+	}
+	var headSeq int64
+	if err := eventsDB.QueryRow(`SELECT COALESCE(MAX(server_seq), 0) FROM events`).Scan(&headSeq); err != nil {
+		logFor(r.Context()).Error("graphql: head seq", "err", err)
+		writeError(w, http.StatusInternalServerError, ErrCodeInternal, "database error")
+		return
+	}
+	if headSeq == 0 {
+		writeError(w, http.StatusNotFound, ErrCodeSnapshotUnavailable, "no events for project")
+		return
+	}
+
+	snapshotPath, snapshotSeq, err := s.currentSnapshot(projectID, eventsDB, headSeq)
+	if err != nil {
+		slog.Error("graphql: build snapshot", "project", projectID, "err", err)
+		writeError(w, http.StatusInternalServerError, ErrCodeInternal, "failed to build snapshot")
+		return
+	}
+	snapDB, err := sql.Open("sqlite", snapshotPath+"?mode=ro")
+	if err != nil {
+		slog.Error("graphql: open snapshot", "err", err)
+		writeError(w, http.StatusInternalServerError, ErrCodeInternal, "failed to open snapshot")
+		return
+	}
+	defer snapDB.Close()
+
+	vars := make(map[string]any, len(doc.Defaults)+len(req.Variables))
+	for k, v := range doc.Defaults {
+		vars[k] = v
+	}
+	for k, v := range req.Variables {
+		vars[k] = v
+	}
+	exec := &gqlExecutor{src: NewSnapshotQuerySource(snapDB), vars: vars}
+	data, err := exec.query(doc.Selection)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, GraphQLResponse{Errors: []GraphQLError{{Message: err.Error()}}, SnapshotSeq: snapshotSeq})
+		return
+	}
+	writeJSON(w, http.StatusOK, GraphQLResponse{Data: data, SnapshotSeq: snapshotSeq})
+}
+
+// gqlObject is a JSON object that keeps its keys in selection order, as the
+// GraphQL spec requires of response maps.
+type gqlObject struct {
+	keys []string
+	vals []any
+}
+
+func (o *gqlObject) set(key string, val any) {
+	o.keys = append(o.keys, key)
+	o.vals = append(o.vals, val)
+}
+
+// MarshalJSON writes the object with keys in insertion order.
+func (o *gqlObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, k := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		kb, _ := json.Marshal(k)
+		buf.Write(kb)
+		buf.WriteByte(':')
+		vb, err := json.Marshal(o.vals[i])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(vb)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// gqlExecutor resolves a parsed selection against a snapshot.
+type gqlExecutor struct {
+	src  *SnapshotQuerySource
+	vars map[string]any
+}
+
+func (e *gqlExecutor) query(sel []*gqlField) (*gqlObject, error) {
+	out := &gqlObject{}
+	for _, f := range sel {
+		switch f.Name {
+		case "__typename":
+			out.set(f.Alias, "Query")
+		case "issues":
+			if err := checkArgs(f, "status", "type", "parent", "limit"); err != nil {
+				return nil, err
+			}
+			if err := requireSelection(f, "[Issue]"); err != nil {
+				return nil, err
+			}
+			opts, err := e.issueFilter(f)
+			if err != nil {
+				return nil, err
+			}
+			if opts.ParentID, err = e.stringArg(f, "parent"); err != nil {
+				return nil, err
+			}
+			if opts.Limit, err = e.intArg(f, "limit"); err != nil {
+				return nil, err
+			}
+			issues, err := e.src.ListIssues(opts)
+			if err != nil {
+				return nil, fmt.Errorf("issues: %w", err)
+			}
+			list, err := e.issueList(issues, f.Selection, 1)
+			if err != nil {
+				return nil, err
+			}
+			out.set(f.Alias, list)
+		case "issue":
+			if err := checkArgs(f, "id"); err != nil {
+				return nil, err
+			}
+			if err := requireSelection(f, "Issue"); err != nil {
+				return nil, err
+			}
+			id, err := e.stringArg(f, "id")
+			if err != nil {
+				return nil, err
+			}
+			if id == "" {
+				return nil, fmt.Errorf(`field "issue" requires argument "id"`)
+			}
+			issue, err := e.src.GetIssue(id)
+			if err != nil || issue == nil || issue.DeletedAt != nil {
+				out.set(f.Alias, nil)
+				continue
+			}
+			obj, err := e.issue(issue, f.Selection, 1)
+			if err != nil {
+				return nil, err
+			}
+			out.set(f.Alias, obj)
+		default:
+			return nil, fmt.Errorf("unknown field %q on type Query", f.Name)
+		}
+	}
+	return out, nil
+}
+
+func (e *gqlExecutor) issueList(issues []models.Issue, sel []*gqlField, depth int) ([]*gqlObject, error) {
+	list := make([]*gqlObject, 0, len(issues))
+	for i := range issues {
+		obj, err := e.issue(&issues[i], sel, depth)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, obj)
+	}
+	return list, nil
+}
+
+func (e *gqlExecutor) issue(issue *models.Issue, sel []*gqlField, depth int) (*gqlObject, error) {
+	if depth > gqlMaxDepth {
+		return nil, fmt.Errorf("query exceeds maximum depth of %d", gqlMaxDepth)
+	}
+	var scalars map[string]json.RawMessage
+	out := &gqlObject{}
+	for _, f := range sel {
+		switch f.Name {
+		case "__typename":
+			out.set(f.Alias, "Issue")
+		case "parent":
+			if err := requireSelection(f, "Issue"); err != nil {
+				return nil, err
+			}
+			if issue.ParentID == "" {
+				out.set(f.Alias, nil)
+				continue
+			}
+			parent, err := e.src.GetIssue(issue.ParentID)
+			if err != nil || parent == nil || parent.DeletedAt != nil {
+				out.set(f.Alias, nil)
+				continue
+			}
+			obj, err := e.issue(parent, f.Selection, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			out.set(f.Alias, obj)
+		case "children":
+			if err := checkArgs(f, "status", "type"); err != nil {
+				return nil, err
+			}
+			if err := requireSelection(f, "[Issue]"); err != nil {
+				return nil, err
+			}
+			opts, err := e.issueFilter(f)
+			if err != nil {
+				return nil, err
+			}
+			opts.ParentID = issue.ID
+			children, err := e.src.ListIssues(opts)
+			if err != nil {
+				return nil, fmt.Errorf("children of %s: %w", issue.ID, err)
+			}
+			list, err := e.issueList(children, f.Selection, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			out.set(f.Alias, list)
+		case "dependencies":
+			if err := requireSelection(f, "[Issue]"); err != nil {
+				return nil, err
+			}
+			ids, err := e.src.GetDependencies(issue.ID)
+			if err != nil {
+				return nil, fmt.Errorf("dependencies of %s: %w", issue.ID, err)
+			}
+			list := make([]*gqlObject, 0, len(ids))
+			for _, id := range ids {
+				dep, err := e.src.GetIssue(id)
+				if err != nil || dep == nil || dep.DeletedAt != nil {
+					continue
+				}
+				obj, err := e.issue(dep, f.Selection, depth+1)
+				if err != nil {
+					return nil, err
+				}
+				list = append(list, obj)
+			}
+			out.set(f.Alias, list)
+		case "logs":
+			if err := checkArgs(f, "limit"); err != nil {
+				return nil, err
+			}
+			if err := requireSelection(f, "[Log]"); err != nil {
+				return nil, err
+			}
+			limit, err := e.intArg(f, "limit")
+			if err != nil {
+				return nil, err
+			}
+			logs, err := e.src.GetLogs(issue.ID, limit)
+			if err != nil {
+				return nil, fmt.Errorf("logs of %s: %w", issue.ID, err)
+			}
+			list := make([]*gqlObject, 0, len(logs))
+			for i := range logs {
+				obj, err := scalarObject(&logs[i], "Log", gqlLogScalars, f.Selection)
+				if err != nil {
+					return nil, err
+				}
+				list = append(list, obj)
+			}
+			out.set(f.Alias, list)
+		default:
+			if !gqlIssueScalars[f.Name] {
+				return nil, fmt.Errorf("unknown field %q on type Issue", f.Name)
+			}
+			if err := requireLeaf(f, "Issue"); err != nil {
+				return nil, err
+			}
+			if scalars == nil {
+				var err error
+				if scalars, err = jsonFields(issue); err != nil {
+					return nil, err
+				}
+			}
+			out.set(f.Alias, rawOrNull(scalars[f.Name]))
+		}
+	}
+	return out, nil
+}
+
+// scalarObject resolves a selection over a type that only has scalar fields.
+func scalarObject(v any, typeName string, fields map[string]bool, sel []*gqlField) (*gqlObject, error) {
+	scalars, err := jsonFields(v)
+	if err != nil {
+		return nil, err
+	}
+	out := &gqlObject{}
+	for _, f := range sel {
+		if f.Name == "__typename" {
+			out.set(f.Alias, typeName)
+			continue
+		}
+		if !fields[f.Name] {
+			return nil, fmt.Errorf("unknown field %q on type %s", f.Name, typeName)
+		}
+		if err := requireLeaf(f, typeName); err != nil {
+			return nil, err
+		}
+		out.set(f.Alias, rawOrNull(scalars[f.Name]))
+	}
+	return out, nil
+}
+
+// issueFilter builds list options from the status and type arguments.
+func (e *gqlExecutor) issueFilter(f *gqlField) (db.ListIssuesOptions, error) {
+	var opts db.ListIssuesOptions
+	statuses, err := e.stringListArg(f, "status")
+	if err != nil {
+		return opts, err
+	}
+	for _, st := range statuses {
+		status := models.NormalizeStatus(st)
+		if !models.IsValidStatus(status) {
+			return opts, fmt.Errorf("invalid status %q", st)
+		}
+		opts.Status = append(opts.Status, status)
+	}
+	types, err := e.stringListArg(f, "type")
+	if err != nil {
+		return opts, err
+	}
+	for _, t := range types {
+		typ := models.NormalizeType(t)
+		if !models.IsValidType(typ) {
+			return opts, fmt.Errorf("invalid type %q", t)
+		}
+		opts.Type = append(opts.Type, typ)
+	}
+	return opts, nil
+}
+
+// resolve substitutes variables in an argument value.
+func (e *gqlExecutor) resolve(v any) any {
+	switch v := v.(type) {
+	case gqlVar:
+		return e.vars[string(v)]
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = e.resolve(item)
+		}
+		return out
+	}
+	return v
+}
+
+func (e *gqlExecutor) stringArg(f *gqlField, name string) (string, error) {
+	v := e.resolve(f.Args[name])
+	if v == nil {
+		return "", nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("argument %q of %q must be a string", name, f.Name)
+	}
+	return s, nil
+}
+
+// stringListArg accepts a list of strings or a single string, which GraphQL
+// input coercion treats as a one-element list.
+func (e *gqlExecutor) stringListArg(f *gqlField, name string) ([]string, error) {
+	switch v := e.resolve(f.Args[name]).(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{v}, nil
+	case []any:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("argument %q of %q must be a list of strings", name, f.Name)
+			}
+			out = append(out, s)
+		}
+		return out, nil
+	}
+	return nil, fmt.Errorf("argument %q of %q must be a list of strings", name, f.Name)
+}
+
+func (e *gqlExecutor) intArg(f *gqlField, name string) (int, error) {
+	switch v := e.resolve(f.Args[name]).(type) {
+	case nil:
+		return 0, nil
+	case int:
+		if v < 0 {
+			return 0, fmt.Errorf("argument %q of %q must not be negative", name, f.Name)
+		}
+		return v, nil
+	case float64: // JSON-decoded variables
+		if v < 0 || v != float64(int(v)) {
+			return 0, fmt.Errorf("argument %q of %q must be a non-negative integer", name, f.Name)
+		}
+		return int(v), nil
+	}
+	return 0, fmt.Errorf("argument %q of %q must be an integer", name, f.Name)
+}
+
+func checkArgs(f *gqlField, allowed ...string) error {
+	for name := range f.Args {
+		ok := false
+		for _, a := range allowed {
+			if name == a {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return fmt.Errorf("unknown argument %q on field %q", name, f.Name)
+		}
+	}
+	return nil
+}
+
+func requireSelection(f *gqlField, typeName string) error {
+	if f.Selection == nil {
+		return fmt.Errorf("field %q of type %s must have a selection of subfields", f.Name, typeName)
+	}
+	return nil
+}
+
+func requireLeaf(f *gqlField, typeName string) error {
+	if f.Selection != nil {
+		return fmt.Errorf("field %q on type %s is a scalar and cannot have a selection", f.Name, typeName)
+	}
+	if len(f.Args) > 0 {
+		return fmt.Errorf("field %q on type %s takes no arguments", f.Name, typeName)
+	}
+	return nil
+}
+
+// jsonFields marshals v and returns its top-level JSON fields.
+func jsonFields(v any) (map[string]json.RawMessage, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// rawOrNull returns raw, or nil (JSON null) for fields omitted as empty.
+func rawOrNull(raw json.RawMessage) any {
+	if raw == nil {
+		return nil
+	}
+	return raw
+}
+
+// jsonFieldNames returns the JSON field names declared by a struct type.
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		names[name] = true
+	}
+	return names
+}
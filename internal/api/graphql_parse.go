@@ -0,0 +1,414 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// This file implements the subset of the GraphQL query language the
+// read-only /graphql endpoint needs: a single anonymous or named query with
+// optional variable definitions, fields with aliases and arguments, and
+// nested selection sets. Fragments, directives, mutations and subscriptions
+// are rejected with a clear error rather than silently ignored.
+
+// gqlField is one field in a selection set.
+type gqlField struct {
+	Alias     string // response key; equals Name when no alias is given
+	Name      string
+	Args      map[string]any // string, int, float64, bool, nil, []any or gqlVar
+	Selection []*gqlField    // nil for leaf fields
+}
+
+// gqlVar is a reference to an operation variable ($name) in an argument.
+type gqlVar string
+
+// gqlDocument is a parsed query operation.
+type gqlDocument struct {
+	Selection []*gqlField
+	Defaults  map[string]any // variable defaults from the operation signature
+}
+
+type gqlTokenKind int
+
+const (
+	gqlEOF gqlTokenKind = iota
+	gqlPunct
+	gqlName
+	gqlInt
+	gqlFloat
+	gqlString
+)
+
+type gqlToken struct {
+	kind gqlTokenKind
+	text string
+	pos  int
+}
+
+// gqlLex splits src into tokens, dropping whitespace, commas and comments.
+func gqlLex(src string) ([]gqlToken, error) {
+	var toks []gqlToken
+	i := 0
+	for i < len(src) {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			i++
+		case c == '#':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(src[i:], "..."):
+			toks = append(toks, gqlToken{gqlPunct, "...", i})
+			i += 3
+		case strings.ContainsRune("{}()[]:$!=@", rune(c)):
+			toks = append(toks, gqlToken{gqlPunct, string(c), i})
+			i++
+		case c == '_' || isASCIILetter(c):
+			start := i
+			for i < len(src) && (src[i] == '_' || isASCIILetter(src[i]) || isASCIIDigit(src[i])) {
+				i++
+			}
+			toks = append(toks, gqlToken{gqlName, src[start:i], start})
+		case c == '-' || isASCIIDigit(c):
+			start := i
+			i++
+			kind := gqlInt
+			for i < len(src) && (isASCIIDigit(src[i]) || src[i] == '.' || src[i] == 'e' || src[i] == 'E' ||
+				((src[i] == '+' || src[i] == '-') && (src[i-1] == 'e' || src[i-1] == 'E'))) {
+				if !isASCIIDigit(src[i]) {
+					kind = gqlFloat
+				}
+				i++
+			}
+			toks = append(toks, gqlToken{kind, src[start:i], start})
+		case c == '"':
+			s, n, err := gqlLexString(src[i:])
+			if err != nil {
+				return nil, fmt.Errorf("%v at offset %d", err, i)
+			}
+			toks = append(toks, gqlToken{gqlString, s, i})
+			i += n
+		default:
+			r, _ := utf8.DecodeRuneInString(src[i:])
+			return nil, fmt.Errorf("unexpected character %q at offset %d", r, i)
+		}
+	}
+	return append(toks, gqlToken{gqlEOF, "", len(src)}), nil
+}
+
+// gqlLexString reads a double-quoted string literal at the start of src and
+// returns its value and the number of bytes consumed. Block strings are not
+// supported.
+func gqlLexString(src string) (string, int, error) {
+	if strings.HasPrefix(src, `"""`) {
+		return "", 0, fmt.Errorf("block strings are not supported")
+	}
+	var sb strings.Builder
+	for i := 1; i < len(src); i++ {
+		c := src[i]
+		switch c {
+		case '"':
+			return sb.String(), i + 1, nil
+		case '\n':
+			return "", 0, fmt.Errorf("unterminated string")
+		case '\\':
+			if i+1 >= len(src) {
+				return "", 0, fmt.Errorf("unterminated string")
+			}
+			i++
+			switch src[i] {
+			case '"', '\\', '/':
+				sb.WriteByte(src[i])
+			case 'b':
+				sb.WriteByte('\b')
+			case 'f':
+				sb.WriteByte('\f')
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			case 'u':
+				if i+4 >= len(src) {
+					return "", 0, fmt.Errorf("invalid unicode escape")
+				}
+				code, err := strconv.ParseUint(src[i+1:i+5], 16, 32)
+				if err != nil {
+					return "", 0, fmt.Errorf("invalid unicode escape")
+				}
+				sb.WriteRune(rune(code))
+				i += 4
+			default:
+				return "", 0, fmt.Errorf("invalid escape \\%c", src[i])
+			}
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated string")
+}
+
+func isASCIILetter(c byte) bool { return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') }
+func isASCIIDigit(c byte) bool  { return c >= '0' && c <= '9' }
+
+type gqlParser struct {
+	toks []gqlToken
+	pos  int
+}
+
+// parseGraphQL parses a query document containing a single operation.
+func parseGraphQL(src string) (*gqlDocument, error) {
+	toks, err := gqlLex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &gqlParser{toks: toks}
+	doc := &gqlDocument{Defaults: map[string]any{}}
+
+	if t := p.peek(); t.kind == gqlName {
+		switch t.text {
+		case "query":
+			p.next()
+			if p.peek().kind == gqlName {
+				p.next() // operation name
+			}
+			if p.peekPunct("(") {
+				if err := p.parseVariableDefs(doc.Defaults); err != nil {
+					return nil, err
+				}
+			}
+		case "mutation", "subscription":
+			return nil, fmt.Errorf("%s operations are not supported; this endpoint is read-only", t.text)
+		case "fragment":
+			return nil, fmt.Errorf("fragments are not supported")
+		default:
+			return nil, p.errorf(t, "unexpected %q", t.text)
+		}
+	}
+
+	doc.Selection, err = p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != gqlEOF {
+		return nil, p.errorf(t, "only one operation per request is supported")
+	}
+	return doc, nil
+}
+
+func (p *gqlParser) peek() gqlToken { return p.toks[p.pos] }
+
+func (p *gqlParser) next() gqlToken {
+	t := p.toks[p.pos]
+	if t.kind != gqlEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *gqlParser) peekPunct(s string) bool {
+	t := p.peek()
+	return t.kind == gqlPunct && t.text == s
+}
+
+func (p *gqlParser) expectPunct(s string) error {
+	t := p.next()
+	if t.kind != gqlPunct || t.text != s {
+		return p.errorf(t, "expected %q", s)
+	}
+	return nil
+}
+
+func (p *gqlParser) expectName() (string, error) {
+	t := p.next()
+	if t.kind != gqlName {
+		return "", p.errorf(t, "expected name")
+	}
+	return t.text, nil
+}
+
+func (p *gqlParser) errorf(t gqlToken, format string, args ...any) error {
+	if t.kind == gqlEOF {
+		return fmt.Errorf(format+" at end of query", args...)
+	}
+	return fmt.Errorf(format+" at offset %d", append(args, t.pos)...)
+}
+
+// parseVariableDefs parses ($a: Type = default, ...). Types are accepted but
+// not enforced; argument coercion happens in the resolvers.
+func (p *gqlParser) parseVariableDefs(defaults map[string]any) error {
+	if err := p.expectPunct("("); err != nil {
+		return err
+	}
+	for !p.peekPunct(")") {
+		if err := p.expectPunct("$"); err != nil {
+			return err
+		}
+		name, err := p.expectName()
+		if err != nil {
+			return err
+		}
+		if err := p.expectPunct(":"); err != nil {
+			return err
+		}
+		if err := p.skipType(); err != nil {
+			return err
+		}
+		if p.peekPunct("=") {
+			p.next()
+			v, err := p.parseValue(true)
+			if err != nil {
+				return err
+			}
+			defaults[name] = v
+		}
+	}
+	return p.expectPunct(")")
+}
+
+func (p *gqlParser) skipType() error {
+	if p.peekPunct("[") {
+		p.next()
+		if err := p.skipType(); err != nil {
+			return err
+		}
+		if err := p.expectPunct("]"); err != nil {
+			return err
+		}
+	} else if _, err := p.expectName(); err != nil {
+		return err
+	}
+	if p.peekPunct("!") {
+		p.next()
+	}
+	return nil
+}
+
+func (p *gqlParser) parseSelectionSet() ([]*gqlField, error) {
+	if err := p.expectPunct("{"); err != nil {
+		return nil, err
+	}
+	var fields []*gqlField
+	for !p.peekPunct("}") {
+		if p.peekPunct("...") {
+			return nil, p.errorf(p.peek(), "fragments are not supported")
+		}
+		f, err := p.parseField()
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, f)
+	}
+	p.next()
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("empty selection set")
+	}
+	return fields, nil
+}
+
+func (p *gqlParser) parseField() (*gqlField, error) {
+	name, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+	f := &gqlField{Alias: name, Name: name}
+	if p.peekPunct(":") {
+		p.next()
+		if f.Name, err = p.expectName(); err != nil {
+			return nil, err
+		}
+	}
+	if p.peekPunct("(") {
+		p.next()
+		f.Args = map[string]any{}
+		for !p.peekPunct(")") {
+			argName, err := p.expectName()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectPunct(":"); err != nil {
+				return nil, err
+			}
+			v, err := p.parseValue(false)
+			if err != nil {
+				return nil, err
+			}
+			f.Args[argName] = v
+		}
+		p.next()
+	}
+	if p.peekPunct("@") {
+		return nil, p.errorf(p.peek(), "directives are not supported")
+	}
+	if p.peekPunct("{") {
+		if f.Selection, err = p.parseSelectionSet(); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+// parseValue parses an argument value. Enum values are returned as strings.
+// Variables are not allowed in constant positions (variable defaults).
+func (p *gqlParser) parseValue(constant bool) (any, error) {
+	t := p.next()
+	switch t.kind {
+	case gqlString:
+		return t.text, nil
+	case gqlInt:
+		n, err := strconv.Atoi(t.text)
+		if err != nil {
+			return nil, p.errorf(t, "invalid int %q", t.text)
+		}
+		return n, nil
+	case gqlFloat:
+		f, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, p.errorf(t, "invalid float %q", t.text)
+		}
+		return f, nil
+	case gqlName:
+		switch t.text {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		}
+		return t.text, nil
+	case gqlPunct:
+		switch t.text {
+		case "$":
+			if constant {
+				return nil, p.errorf(t, "variables are not allowed here")
+			}
+			name, err := p.expectName()
+			if err != nil {
+				return nil, err
+			}
+			return gqlVar(name), nil
+		case "[":
+			list := []any{}
+			for !p.peekPunct("]") {
+				if p.peek().kind == gqlEOF {
+					return nil, p.errorf(p.peek(), "unterminated list")
+				}
+				v, err := p.parseValue(constant)
+				if err != nil {
+					return nil, err
+				}
+				list = append(list, v)
+			}
+			p.next()
+			return list, nil
+		case "{":
+			return nil, p.errorf(t, "object values are not supported")
+		}
+	}
+	return nil, p.errorf(t, "expected value")
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func pushGraphQLFixture(t *testing.T, srv *Server, token, projectID string) {
+	t.Helper()
+	ev := func(seq int64, entityType, id, data string) EventInput {
+		return EventInput{
+			ClientActionID: seq, ActionType: "create", EntityType: entityType, EntityID: id,
+			Payload:         json.RawMessage(`{"schema_version":1,"new_data":` + data + `}`),
+			ClientTimestamp: fmt.Sprintf("2025-01-01T00:00:%02dZ", seq),
+		}
+	}
+	w := doRequest(srv, "POST", fmt.Sprintf("/v1/projects/%s/sync/push", projectID), token, PushRequest{
+		DeviceID: "dev1", SessionID: "sess1",
+		Events: []EventInput{
+			ev(1, "issues", "td-epic1", `{"title":"Epic","status":"open","type":"epic","priority":"P1"}`),
+			ev(2, "issues", "td-child1", `{"title":"Child one","status":"open","type":"task","priority":"P2","parent_id":"td-epic1"}`),
+			ev(3, "issues", "td-child2", `{"title":"Child two","status":"closed","type":"bug","priority":"P2","parent_id":"td-epic1"}`),
+			ev(4, "issues", "td-solo1", `{"title":"Solo","status":"open","type":"task","priority":"P3"}`),
+			ev(5, "issue_dependencies", "dep-1", `{"issue_id":"td-child1","depends_on_id":"td-solo1","relation_type":"depends_on"}`),
+			ev(6, "logs", "lg-1", `{"issue_id":"td-child1","session_id":"sess1","message":"started","type":"progress","timestamp":"2025-01-01T00:00:06Z"}`),
+		},
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("push: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestGraphQLIssuesWithChildren(t *testing.T) {
+	srv, store := newTestServer(t)
+	_, token := createTestUser(t, store, "gql@test.com")
+
+	w := doRequest(srv, "POST", "/v1/projects", token, CreateProjectRequest{Name: "gql"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: expected 201, got %d", w.Code)
+	}
+	var project ProjectResponse
+	_ = json.NewDecoder(w.Body).Decode(&project)
+	pushGraphQLFixture(t, srv, token, project.ID)
+
+	query := `query Epics($open: [String] = [open]) {
+		epics: issues(type: epic) {
+			id
+			title
+			children(status: $open) {
+				id
+				status
+				dependencies { id }
+				logs { message }
+			}
+		}
+	}`
+	w = doRequest(srv, "POST", fmt.Sprintf("/v1/projects/%s/graphql", project.ID), token, GraphQLRequest{Query: query})
+	if w.Code != http.StatusOK {
+		t.Fatalf("graphql: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Data   map[string]any `json:"data"`
+		Errors []GraphQLError `json:"errors"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors: %+v", resp.Errors)
+	}
+	want := map[string]any{
+		"epics": []any{
+			map[string]any{
+				"id":    "td-epic1",
+				"title": "Epic",
+				"children": []any{
+					map[string]any{
+						"id":           "td-child1",
+						"status":       "open",
+						"dependencies": []any{map[string]any{"id": "td-solo1"}},
+						"logs":         []any{map[string]any{"message": "started"}},
+					},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(resp.Data, want) {
+		got, _ := json.MarshalIndent(resp.Data, "", "  ")
+		t.Fatalf("unexpected data:\n%s", got)
+	}
+
+	// Keys come back in selection order.
+	if want := `{"data":{"epics":[{"id":"td-epic1","title":"Epic","children":[`; len(w.Body.String()) < len(want) || w.Body.String()[:len(want)] != want {
+		t.Errorf("response keys out of selection order: %s", w.Body.String())
+	}
+}
+
+func TestGraphQLErrors(t *testing.T) {
+	srv, store := newTestServer(t)
+	_, token := createTestUser(t, store, "gql-err@test.com")
+
+	w := doRequest(srv, "POST", "/v1/projects", token, CreateProjectRequest{Name: "gql-err"})
+	var project ProjectResponse
+	_ = json.NewDecoder(w.Body).Decode(&project)
+	pushGraphQLFixture(t, srv, token, project.ID)
+	path := fmt.Sprintf("/v1/projects/%s/graphql", project.ID)
+
+	for _, q := range []string{
+		`mutation { issues { id } }`,
+		`{ issues { nope } }`,
+		`{ issues }`,
+		`{ issues(status: bogus) { id } }`,
+		`{ issues { id ...F } }`,
+		`{ issues { id`,
+	} {
+		w := doRequest(srv, "POST", path, token, GraphQLRequest{Query: q})
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d: %s", q, w.Code, w.Body.String())
+			continue
+		}
+		var resp GraphQLResponse
+		_ = json.NewDecoder(w.Body).Decode(&resp)
+		if len(resp.Errors) == 0 {
+			t.Errorf("%s: expected errors in response", q)
+		}
+	}
+}
@@ -34,6 +34,7 @@ type InvitationResponse struct {
 	CreatedAt  string  `json:"created_at"`
 	ExpiresAt  string  `json:"expires_at"`
 	AcceptedAt *string `json:"accepted_at,omitempty"`
+	AutoAccept bool    `json:"auto_accept,omitempty"`
 }
 
 func handleInvitationError(w http.ResponseWriter, err error) bool {
@@ -68,6 +69,7 @@ func invitationResponse(inv *serverdb.Invitation) InvitationResponse {
 		CreatedAt:  inv.CreatedAt.Format(time.RFC3339),
 		ExpiresAt:  inv.ExpiresAt.Format(time.RFC3339),
 		AcceptedAt: acceptedAt,
+		AutoAccept: inv.AutoAccept,
 	}
 }
 
@@ -0,0 +1,130 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+// Invite statuses returned by POST /v1/projects/{id}/invites.
+const (
+	inviteStatusMember  = "member"
+	inviteStatusPending = "pending"
+)
+
+// CreateInviteRequest is the JSON body for POST /v1/projects/{id}/invites.
+type CreateInviteRequest struct {
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
+
+// InviteResponse reports the outcome of an email invite. Status is "member"
+// when the email belonged to an existing user who was added right away, or
+// "pending" when the invite waits for that email to register.
+type InviteResponse struct {
+	Status string              `json:"status"`
+	Member *MemberResponse     `json:"member,omitempty"`
+	Invite *InvitationResponse `json:"invite,omitempty"`
+}
+
+// handleCreateInvite handles POST /v1/projects/{id}/invites.
+func (s *Server) handleCreateInvite(w http.ResponseWriter, r *http.Request) {
+	projectID := r.PathValue("id")
+	user := getUserFromContext(r.Context())
+	actor := getActingUserFromContext(r.Context())
+
+	var req CreateInviteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "invalid json body")
+		return
+	}
+
+	addr, err := mail.ParseAddress(strings.TrimSpace(req.Email))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "valid email is required")
+		return
+	}
+	req.Email = strings.ToLower(addr.Address)
+	if !validateInvitationRole(req.Role) {
+		writeError(w, http.StatusBadRequest, "bad_request", "valid role is required")
+		return
+	}
+
+	invitedBy := user.UserID
+	if actor != nil && actor.UserID != "" {
+		invitedBy = actor.UserID
+	}
+
+	existing, err := s.store.GetUserByEmail(req.Email)
+	if err != nil {
+		logFor(r.Context()).Error("lookup user by email", "err", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "failed to look up user")
+		return
+	}
+
+	if existing == nil {
+		inv, err := s.store.CreatePendingInvite(projectID, req.Email, req.Role, invitedBy, time.Now().UTC().Add(invitationTTL))
+		if err != nil {
+			logFor(r.Context()).Error("create pending invite", "err", err)
+			writeError(w, http.StatusInternalServerError, "internal_error", "failed to create invite")
+			return
+		}
+		resp := invitationResponse(inv)
+		writeJSON(w, http.StatusCreated, InviteResponse{Status: inviteStatusPending, Invite: &resp})
+		return
+	}
+
+	membership, err := s.store.GetMembership(projectID, existing.ID)
+	if err != nil {
+		logFor(r.Context()).Error("get membership", "err", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "failed to check membership")
+		return
+	}
+	if membership != nil {
+		writeError(w, http.StatusConflict, "conflict", "user is already a member")
+		return
+	}
+
+	m, err := s.store.AddMember(projectID, existing.ID, req.Role, invitedBy)
+	if err != nil {
+		logFor(r.Context()).Error("add member", "err", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "failed to add member")
+		return
+	}
+	writeJSON(w, http.StatusCreated, InviteResponse{
+		Status: inviteStatusMember,
+		Member: &MemberResponse{
+			ProjectID: m.ProjectID,
+			UserID:    m.UserID,
+			Role:      m.Role,
+			InvitedBy: m.InvitedBy,
+			CreatedAt: m.CreatedAt.Format(time.RFC3339),
+		},
+	})
+}
+
+// handleListInvites handles GET /v1/projects/{id}/invites.
+func (s *Server) handleListInvites(w http.ResponseWriter, r *http.Request) {
+	invites, err := s.store.ListPendingInvites(r.PathValue("id"))
+	if err != nil {
+		logFor(r.Context()).Error("list pending invites", "err", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "failed to list invites")
+		return
+	}
+	writeJSON(w, http.StatusOK, invitationResponses(invites))
+}
+
+// handleDeleteInvite handles DELETE /v1/projects/{id}/invites/{inviteID}.
+func (s *Server) handleDeleteInvite(w http.ResponseWriter, r *http.Request) {
+	if err := s.store.DeletePendingInvite(r.PathValue("id"), r.PathValue("inviteID")); err != nil {
+		if handleInvitationError(w, err) {
+			return
+		}
+		logFor(r.Context()).Error("delete invite", "err", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "failed to delete invite")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
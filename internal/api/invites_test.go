@@ -0,0 +1,134 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/marcus/td/internal/serverdb"
+)
+
+func TestInviteExistingUserAddsMember(t *testing.T) {
+	srv, store := newTestServer(t)
+	_, ownerToken := createTestUser(t, store, "owner-invites@example.com")
+	userID, _ := createTestUser(t, store, "existing-invites@example.com")
+	project := createProjectForInvitationTest(t, srv, ownerToken)
+
+	w := doRequest(srv, "POST", fmt.Sprintf("/v1/projects/%s/invites", project.ID), ownerToken, CreateInviteRequest{
+		Email: "Existing-Invites@Example.com",
+		Role:  serverdb.RoleWriter,
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("invite existing user: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp InviteResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode invite: %v", err)
+	}
+	if resp.Status != inviteStatusMember || resp.Member == nil || resp.Member.UserID != userID {
+		t.Fatalf("invite response mismatch: %#v", resp)
+	}
+
+	m, err := store.GetMembership(project.ID, userID)
+	if err != nil {
+		t.Fatalf("get membership: %v", err)
+	}
+	if m == nil || m.Role != serverdb.RoleWriter {
+		t.Fatalf("membership mismatch: %#v", m)
+	}
+
+	w = doRequest(srv, "POST", fmt.Sprintf("/v1/projects/%s/invites", project.ID), ownerToken, CreateInviteRequest{
+		Email: "existing-invites@example.com",
+		Role:  serverdb.RoleReader,
+	})
+	if w.Code != http.StatusConflict {
+		t.Fatalf("re-invite member: expected 409, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestInviteUnregisteredEmailPendingUntilSignup(t *testing.T) {
+	srv, store := newTestServer(t)
+	_, ownerToken := createTestUser(t, store, "owner-pending-invites@example.com")
+	project := createProjectForInvitationTest(t, srv, ownerToken)
+
+	w := doRequest(srv, "POST", fmt.Sprintf("/v1/projects/%s/invites", project.ID), ownerToken, CreateInviteRequest{
+		Email: "newcomer@example.com",
+		Role:  serverdb.RoleReader,
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("invite new email: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp InviteResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode invite: %v", err)
+	}
+	if resp.Status != inviteStatusPending || resp.Invite == nil || resp.Invite.Email != "newcomer@example.com" {
+		t.Fatalf("invite response mismatch: %#v", resp)
+	}
+
+	w = doRequest(srv, "GET", fmt.Sprintf("/v1/projects/%s/invites", project.ID), ownerToken, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("list invites: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var pending []InvitationResponse
+	if err := json.NewDecoder(w.Body).Decode(&pending); err != nil {
+		t.Fatalf("decode invites: %v", err)
+	}
+	if len(pending) != 1 || pending[0].ID != resp.Invite.ID {
+		t.Fatalf("pending invites mismatch: %#v", pending)
+	}
+
+	// Registering the invited email claims the invite
+	user, err := store.CreateUser("newcomer@example.com")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	m, err := store.GetMembership(project.ID, user.ID)
+	if err != nil {
+		t.Fatalf("get membership: %v", err)
+	}
+	if m == nil || m.Role != serverdb.RoleReader {
+		t.Fatalf("membership after signup mismatch: %#v", m)
+	}
+
+	invites, err := store.ListPendingInvites(project.ID)
+	if err != nil {
+		t.Fatalf("list pending invites: %v", err)
+	}
+	if len(invites) != 0 {
+		t.Fatalf("expected no pending invites after signup, got %d", len(invites))
+	}
+}
+
+func TestDeleteInviteAndOwnerRequired(t *testing.T) {
+	srv, store := newTestServer(t)
+	_, ownerToken := createTestUser(t, store, "owner-delete-invites@example.com")
+	writerID, writerToken := createTestUser(t, store, "writer-delete-invites@example.com")
+	project := createProjectForInvitationTest(t, srv, ownerToken)
+	if _, err := store.AddMember(project.ID, writerID, serverdb.RoleWriter, ""); err != nil {
+		t.Fatalf("add writer: %v", err)
+	}
+
+	w := doRequest(srv, "POST", fmt.Sprintf("/v1/projects/%s/invites", project.ID), writerToken, CreateInviteRequest{
+		Email: "someone@example.com",
+		Role:  serverdb.RoleReader,
+	})
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("writer invite: expected 403, got %d: %s", w.Code, w.Body.String())
+	}
+
+	inv, err := store.CreatePendingInvite(project.ID, "someone@example.com", serverdb.RoleReader, writerID, time.Now().Add(invitationTTL))
+	if err != nil {
+		t.Fatalf("create pending invite: %v", err)
+	}
+	w = doRequest(srv, "DELETE", fmt.Sprintf("/v1/projects/%s/invites/%s", project.ID, inv.ID), ownerToken, nil)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("delete invite: expected 204, got %d: %s", w.Code, w.Body.String())
+	}
+	w = doRequest(srv, "DELETE", fmt.Sprintf("/v1/projects/%s/invites/%s", project.ID, inv.ID), ownerToken, nil)
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("delete missing invite: expected 404, got %d: %s", w.Code, w.Body.String())
+	}
+}
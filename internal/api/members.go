@@ -2,9 +2,18 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/marcus/td/internal/serverdb"
 )
 
+// maxBulkMembers caps the entries in one bulk member import.
+const maxBulkMembers = 500
+
 // AddMemberRequest is the JSON body for POST /v1/projects/{id}/members.
 type AddMemberRequest struct {
 	UserID string `json:"user_id"`
@@ -21,6 +30,32 @@ type MemberResponse struct {
 	CreatedAt string `json:"created_at"`
 }
 
+// BulkAddMembersRequest is the JSON body for POST /v1/projects/{id}/members/bulk.
+type BulkAddMembersRequest struct {
+	Members []AddMemberRequest `json:"members"`
+}
+
+// BulkMemberResult is the outcome of one entry in a bulk member import.
+// Status is added, invited, skipped or error; Reason explains the last two.
+type BulkMemberResult struct {
+	Index    int    `json:"index"`
+	UserID   string `json:"user_id,omitempty"`
+	Email    string `json:"email,omitempty"`
+	Role     string `json:"role"`
+	Status   string `json:"status"`
+	InviteID string `json:"invite_id,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// BulkAddMembersResponse is the response for POST /v1/projects/{id}/members/bulk.
+type BulkAddMembersResponse struct {
+	Results []BulkMemberResult `json:"results"`
+	Added   int                `json:"added"`
+	Invited int                `json:"invited"`
+	Skipped int                `json:"skipped"`
+	Errors  int                `json:"errors"`
+}
+
 // UpdateMemberRequest is the JSON body for PATCH /v1/projects/{id}/members/{userID}.
 type UpdateMemberRequest struct {
 	Role string `json:"role"`
@@ -87,6 +122,85 @@ func (s *Server) handleAddMember(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// handleBulkAddMembers handles POST /v1/projects/{id}/members/bulk. Every
+// entry is imported in one transaction and gets its own result; emails
+// without an account get a pending invite, as POST /invites does.
+func (s *Server) handleBulkAddMembers(w http.ResponseWriter, r *http.Request) {
+	projectID := r.PathValue("id")
+	user := getUserFromContext(r.Context())
+	actor := getActingUserFromContext(r.Context())
+
+	var req BulkAddMembersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "invalid json body")
+		return
+	}
+	if len(req.Members) == 0 {
+		writeError(w, http.StatusBadRequest, "bad_request", "members is required")
+		return
+	}
+	if len(req.Members) > maxBulkMembers {
+		writeError(w, http.StatusBadRequest, "bad_request", fmt.Sprintf("at most %d members per request", maxBulkMembers))
+		return
+	}
+
+	invitedBy := user.UserID
+	if actor != nil && actor.UserID != "" {
+		invitedBy = actor.UserID
+	}
+
+	results := make([]BulkMemberResult, len(req.Members))
+	var entries []serverdb.BulkMemberEntry
+	var entryIdx []int
+	for i, m := range req.Members {
+		res := &results[i]
+		*res = BulkMemberResult{Index: i, UserID: m.UserID, Email: strings.TrimSpace(m.Email), Role: m.Role}
+		if m.UserID == "" && res.Email == "" {
+			res.Status, res.Reason = serverdb.BulkMemberError, "user_id or email is required"
+			continue
+		}
+		if m.UserID == "" {
+			addr, err := mail.ParseAddress(res.Email)
+			if err != nil {
+				res.Status, res.Reason = serverdb.BulkMemberError, "invalid email"
+				continue
+			}
+			res.Email = strings.ToLower(addr.Address)
+		}
+		entries = append(entries, serverdb.BulkMemberEntry{UserID: m.UserID, Email: res.Email, Role: m.Role})
+		entryIdx = append(entryIdx, i)
+	}
+
+	imported, err := s.store.BulkAddMembers(projectID, invitedBy, entries, time.Now().UTC().Add(invitationTTL))
+	if err != nil {
+		logFor(r.Context()).Error("bulk add members", "err", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "failed to import members")
+		return
+	}
+	for j, out := range imported {
+		res := &results[entryIdx[j]]
+		res.Status, res.Reason, res.InviteID = out.Status, out.Reason, out.InviteID
+		if out.UserID != "" {
+			res.UserID = out.UserID
+		}
+	}
+
+	resp := BulkAddMembersResponse{Results: results}
+	for _, res := range results {
+		switch res.Status {
+		case serverdb.BulkMemberAdded:
+			resp.Added++
+		case serverdb.BulkMemberInvited:
+			resp.Invited++
+		case serverdb.BulkMemberSkipped:
+			resp.Skipped++
+		default:
+			resp.Errors++
+		}
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
 // handleListMembers handles GET /v1/projects/{id}/members.
 func (s *Server) handleListMembers(w http.ResponseWriter, r *http.Request) {
 	projectID := r.PathValue("id")
@@ -1,33 +1,52 @@
 package api
 
 import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
 	"sync/atomic"
 	"time"
 )
 
 // Metrics collects in-memory server metrics using atomic counters.
 type Metrics struct {
-	startTime    time.Time
-	requests     atomic.Int64
-	serverErrors atomic.Int64
-	clientErrors atomic.Int64
-	pushEvents   atomic.Int64
-	pullRequests atomic.Int64
+	startTime      time.Time
+	requests       atomic.Int64
+	serverErrors   atomic.Int64
+	clientErrors   atomic.Int64
+	rateLimitHits  atomic.Int64
+	pushRequests   atomic.Int64
+	pushEvents     atomic.Int64
+	pushRejected   atomic.Int64
+	pushDuplicates atomic.Int64
+	pullRequests   atomic.Int64
+	snapshotHits   atomic.Int64
+	snapshotMisses atomic.Int64
+
+	projectMu     sync.Mutex
+	projectEvents map[string]int64 // accepted push events per project ID
 }
 
 // MetricsSnapshot is a point-in-time view of server metrics.
 type MetricsSnapshot struct {
-	UptimeSeconds      float64 `json:"uptime_seconds"`
-	Requests           int64   `json:"requests"`
-	ServerErrors       int64   `json:"server_errors"`
-	ClientErrors       int64   `json:"client_errors"`
-	PushEventsAccepted int64   `json:"push_events_accepted"`
-	PullRequests       int64   `json:"pull_requests"`
+	UptimeSeconds       float64 `json:"uptime_seconds"`
+	Requests            int64   `json:"requests"`
+	ServerErrors        int64   `json:"server_errors"`
+	ClientErrors        int64   `json:"client_errors"`
+	RateLimitHits       int64   `json:"rate_limit_hits"`
+	PushRequests        int64   `json:"push_requests"`
+	PushEventsAccepted  int64   `json:"push_events_accepted"`
+	PushEventsRejected  int64   `json:"push_events_rejected"`
+	PushEventsDuplicate int64   `json:"push_events_duplicate"`
+	PullRequests        int64   `json:"pull_requests"`
+	SnapshotCacheHits   int64   `json:"snapshot_cache_hits"`
+	SnapshotCacheMisses int64   `json:"snapshot_cache_misses"`
 }
 
 // NewMetrics creates a new Metrics instance with the current time as start.
 func NewMetrics() *Metrics {
-	return &Metrics{startTime: time.Now()}
+	return &Metrics{startTime: time.Now(), projectEvents: make(map[string]int64)}
 }
 
 // RecordRequest increments the total request counter.
@@ -45,24 +64,115 @@ func (m *Metrics) RecordClientError() {
 	m.clientErrors.Add(1)
 }
 
+// RecordRateLimitHit increments the rate-limited (429) response counter.
+func (m *Metrics) RecordRateLimitHit() {
+	m.rateLimitHits.Add(1)
+}
+
+// RecordPushRequest increments the push request counter.
+func (m *Metrics) RecordPushRequest() {
+	m.pushRequests.Add(1)
+}
+
 // RecordPushEvents adds n to the accepted push events counter.
 func (m *Metrics) RecordPushEvents(n int64) {
 	m.pushEvents.Add(n)
 }
 
+// RecordPushRejections adds to the rejected and duplicate push event counters.
+// Duplicates are retries of already-stored events and are counted separately
+// from other rejections.
+func (m *Metrics) RecordPushRejections(rejected, duplicates int64) {
+	m.pushRejected.Add(rejected)
+	m.pushDuplicates.Add(duplicates)
+}
+
+// RecordProjectEvents adds n to the accepted event counter for a project.
+func (m *Metrics) RecordProjectEvents(projectID string, n int64) {
+	m.projectMu.Lock()
+	m.projectEvents[projectID] += n
+	m.projectMu.Unlock()
+}
+
 // RecordPullRequest increments the pull request counter.
 func (m *Metrics) RecordPullRequest() {
 	m.pullRequests.Add(1)
 }
 
+// RecordSnapshotCacheHit increments the snapshot cache hit counter.
+func (m *Metrics) RecordSnapshotCacheHit() {
+	m.snapshotHits.Add(1)
+}
+
+// RecordSnapshotCacheMiss increments the snapshot cache miss counter.
+func (m *Metrics) RecordSnapshotCacheMiss() {
+	m.snapshotMisses.Add(1)
+}
+
 // Snapshot returns a point-in-time copy of the metrics.
 func (m *Metrics) Snapshot() MetricsSnapshot {
 	return MetricsSnapshot{
-		UptimeSeconds:      time.Since(m.startTime).Seconds(),
-		Requests:           m.requests.Load(),
-		ServerErrors:       m.serverErrors.Load(),
-		ClientErrors:       m.clientErrors.Load(),
-		PushEventsAccepted: m.pushEvents.Load(),
-		PullRequests:       m.pullRequests.Load(),
+		UptimeSeconds:       time.Since(m.startTime).Seconds(),
+		Requests:            m.requests.Load(),
+		ServerErrors:        m.serverErrors.Load(),
+		ClientErrors:        m.clientErrors.Load(),
+		RateLimitHits:       m.rateLimitHits.Load(),
+		PushRequests:        m.pushRequests.Load(),
+		PushEventsAccepted:  m.pushEvents.Load(),
+		PushEventsRejected:  m.pushRejected.Load(),
+		PushEventsDuplicate: m.pushDuplicates.Load(),
+		PullRequests:        m.pullRequests.Load(),
+		SnapshotCacheHits:   m.snapshotHits.Load(),
+		SnapshotCacheMisses: m.snapshotMisses.Load(),
+	}
+}
+
+// WritePrometheus writes the metrics in the Prometheus text exposition format.
+func (m *Metrics) WritePrometheus(w io.Writer) error {
+	snap := m.Snapshot()
+
+	metrics := []struct {
+		name, kind, help string
+		value            float64
+	}{
+		{"td_uptime_seconds", "gauge", "Seconds since the server started.", snap.UptimeSeconds},
+		{"td_http_requests_total", "counter", "HTTP requests handled.", float64(snap.Requests)},
+		{"td_http_server_errors_total", "counter", "HTTP responses with a 5xx status.", float64(snap.ServerErrors)},
+		{"td_http_client_errors_total", "counter", "HTTP responses with a 4xx status.", float64(snap.ClientErrors)},
+		{"td_rate_limit_hits_total", "counter", "Requests rejected by rate limiting.", float64(snap.RateLimitHits)},
+		{"td_sync_push_requests_total", "counter", "Sync push requests.", float64(snap.PushRequests)},
+		{"td_sync_push_events_accepted_total", "counter", "Pushed events accepted.", float64(snap.PushEventsAccepted)},
+		{"td_sync_push_events_rejected_total", "counter", "Pushed events rejected as invalid.", float64(snap.PushEventsRejected)},
+		{"td_sync_push_events_duplicate_total", "counter", "Pushed events rejected as duplicates.", float64(snap.PushEventsDuplicate)},
+		{"td_sync_pull_requests_total", "counter", "Sync pull requests.", float64(snap.PullRequests)},
+		{"td_snapshot_cache_hits_total", "counter", "Snapshot requests served from the cache.", float64(snap.SnapshotCacheHits)},
+		{"td_snapshot_cache_misses_total", "counter", "Snapshot requests that required a build.", float64(snap.SnapshotCacheMisses)},
+	}
+	for _, mt := range metrics {
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n%s %g\n", mt.name, mt.help, mt.name, mt.kind, mt.name, mt.value); err != nil {
+			return err
+		}
+	}
+
+	m.projectMu.Lock()
+	projects := make([]string, 0, len(m.projectEvents))
+	for id := range m.projectEvents {
+		projects = append(projects, id)
+	}
+	sort.Strings(projects)
+	counts := make([]int64, len(projects))
+	for i, id := range projects {
+		counts[i] = m.projectEvents[id]
+	}
+	m.projectMu.Unlock()
+
+	if _, err := fmt.Fprint(w, "# HELP td_project_events_accepted_total Pushed events accepted per project.\n# TYPE td_project_events_accepted_total counter\n"); err != nil {
+		return err
+	}
+	for i, id := range projects {
+		if _, err := fmt.Fprintf(w, "td_project_events_accepted_total{project=%q} %d\n", id, counts[i]); err != nil {
+			return err
+		}
 	}
+	return nil
 }
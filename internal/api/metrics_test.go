@@ -0,0 +1,90 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestPrometheusMetricsAfterPushes(t *testing.T) {
+	srv, store := newTestServerWithConfig(t, func(cfg *Config) {
+		cfg.MetricsEnabled = true
+	})
+	_, token := createTestUser(t, store, "metrics@test.com")
+
+	w := doRequest(srv, "POST", "/v1/projects", token, CreateProjectRequest{Name: "metrics-test"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create project: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var project ProjectResponse
+	_ = json.NewDecoder(w.Body).Decode(&project)
+
+	pushBody := PushRequest{
+		DeviceID:  "dev1",
+		SessionID: "sess1",
+		Events: []EventInput{
+			{ClientActionID: 1, ActionType: "create", EntityType: "issues", EntityID: "i_001", Payload: json.RawMessage(`{"title":"a"}`), ClientTimestamp: "2025-01-01T00:00:00Z"},
+			{ClientActionID: 2, ActionType: "create", EntityType: "issues", EntityID: "i_002", Payload: json.RawMessage(`{"title":"b"}`), ClientTimestamp: "2025-01-01T00:00:01Z"},
+		},
+	}
+	pushPath := fmt.Sprintf("/v1/projects/%s/sync/push", project.ID)
+	for i := 0; i < 2; i++ { // second push is all duplicates
+		w = doRequest(srv, "POST", pushPath, token, pushBody)
+		if w.Code != http.StatusOK {
+			t.Fatalf("push %d: expected 200, got %d: %s", i, w.Code, w.Body.String())
+		}
+	}
+	w = doRequest(srv, "GET", fmt.Sprintf("/v1/projects/%s/sync/pull?after_server_seq=0", project.ID), token, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("pull: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = doRequest(srv, "GET", "/metrics", "", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("metrics: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	body := w.Body.String()
+	for _, want := range []string{
+		"# TYPE td_sync_push_requests_total counter",
+		"td_sync_push_requests_total 2\n",
+		"td_sync_push_events_accepted_total 2\n",
+		"td_sync_push_events_duplicate_total 2\n",
+		"td_sync_push_events_rejected_total 0\n",
+		"td_sync_pull_requests_total 1\n",
+		"td_rate_limit_hits_total 0\n",
+		"td_snapshot_cache_hits_total 0\n",
+		"td_snapshot_cache_misses_total 0\n",
+		fmt.Sprintf("td_project_events_accepted_total{project=%q} 2\n", project.ID),
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics output missing %q\n%s", want, body)
+		}
+	}
+}
+
+func TestPrometheusMetricsDisabledByDefault(t *testing.T) {
+	srv, _ := newTestServer(t)
+
+	w := doRequest(srv, "GET", "/metrics", "", nil)
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 when metrics are disabled, got %d", w.Code)
+	}
+}
+
+func TestRateLimitHitsCounted(t *testing.T) {
+	srv, store := newTestServerWithConfig(t, func(cfg *Config) {
+		cfg.MetricsEnabled = true
+		cfg.RateLimitOther = 1
+	})
+	_, token := createTestUser(t, store, "ratelimited@test.com")
+
+	for i := 0; i < 3; i++ {
+		doRequest(srv, "GET", "/v1/projects", token, nil)
+	}
+
+	if got := srv.metrics.Snapshot().RateLimitHits; got != 2 {
+		t.Fatalf("rate limit hits = %d, want 2", got)
+	}
+}
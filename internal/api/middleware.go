@@ -72,6 +72,9 @@ func metricsMiddleware(m *Metrics) func(http.Handler) http.Handler {
 			case sc.code >= 400:
 				m.RecordClientError()
 			}
+			if sc.code == http.StatusTooManyRequests {
+				m.RecordRateLimitHit()
+			}
 		})
 	}
 }
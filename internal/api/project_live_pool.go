@@ -25,10 +25,12 @@ import (
 // performs a one-time bootstrap:
 //  1. Initialize a fresh td schema in a temporary directory via
 //     `tddb.Initialize` (this guarantees the schema matches the td CLI
-//     exactly, including all migrations).
+//     exactly, including all migrations). If the event log has been pruned,
+//     the newest base snapshot is copied in first and migrated instead.
 //  2. Copy the resulting issues.db file to `project.db`.
-//  3. Re-open `project.db` and replay every event in `events.db` (in
-//     `server_seq` order) into it via `tdsync.ApplyRemoteEvents`.
+//  3. Re-open `project.db` and replay every event in `events.db` after the
+//     base snapshot (in `server_seq` order) into it via
+//     `tdsync.ApplyRemoteEvents`.
 //  4. Record the highest applied `server_seq` in a small `applied_events`
 //     bookkeeping table inside project.db.
 //
@@ -226,6 +228,18 @@ func (p *ProjectLivePool) openOrBootstrap(projectID string) (*tddb.DB, error) {
 	}
 	defer os.RemoveAll(tmpDir)
 
+	// Events covered by the base snapshot may have been pruned, so start
+	// from it rather than from an empty DB.
+	base := findSnapshotBase(projectSnapshotBaseDir(p.baseDir, projectID))
+	if base.Path != "" {
+		if err := os.MkdirAll(filepath.Join(tmpDir, ".todos"), 0o755); err != nil {
+			return nil, fmt.Errorf("mkdir init dir: %w", err)
+		}
+		if err := copyFile(base.Path, filepath.Join(tmpDir, ".todos", "issues.db")); err != nil {
+			return nil, fmt.Errorf("copy base snapshot: %w", err)
+		}
+	}
+
 	tdb, err := tddb.Initialize(tmpDir)
 	if err != nil {
 		return nil, fmt.Errorf("initialize td schema: %w", err)
@@ -256,7 +270,7 @@ func (p *ProjectLivePool) openOrBootstrap(projectID string) (*tddb.DB, error) {
 		return nil, fmt.Errorf("create applied_events: %w", err)
 	}
 
-	if err := p.replayEvents(projectID, db); err != nil {
+	if err := p.replayEvents(projectID, db, base.Seq); err != nil {
 		_ = db.Close()
 		return nil, fmt.Errorf("replay events.db: %w", err)
 	}
@@ -285,19 +299,20 @@ func openExistingProjectDB(projectDir string) (*tddb.DB, error) {
 	return tddb.NewWithConn(conn, projectDir), nil
 }
 
-// replayEvents reads every row from the project's events.db (if it exists)
-// in server_seq order and applies it to the freshly-initialized project.db
-// using the same machinery buildSnapshot uses (tdsync.ApplyRemoteEvents).
-// On completion, the highest applied server_seq is recorded in
-// applied_events as the bootstrap cursor.
+// replayEvents reads every row after fromSeq from the project's events.db (if
+// it exists) in server_seq order and applies it to the freshly-initialized
+// project.db using the same machinery buildSnapshot uses
+// (tdsync.ApplyRemoteEvents). fromSeq is the base snapshot's seq, or 0. On
+// completion, the highest applied server_seq is recorded in applied_events
+// as the bootstrap cursor.
 //
 // This is the one-time bootstrap path. Ongoing event application post-bootstrap
 // happens in Stream 3 and is out of scope here.
-func (p *ProjectLivePool) replayEvents(projectID string, projectDB *tddb.DB) error {
+func (p *ProjectLivePool) replayEvents(projectID string, projectDB *tddb.DB, fromSeq int64) error {
 	eventsPath := p.eventsDBPath(projectID)
 	if _, err := os.Stat(eventsPath); errors.Is(err, os.ErrNotExist) {
 		// Brand-new project with no events yet — nothing to replay.
-		return nil
+		return recordAppliedCursor(projectDB, fromSeq)
 	} else if err != nil {
 		return fmt.Errorf("stat events.db: %w", err)
 	}
@@ -310,14 +325,23 @@ func (p *ProjectLivePool) replayEvents(projectID string, projectDB *tddb.DB) err
 
 	validator := func(t string) bool { return isValidEntityType(t) }
 	const batchSize = 1000
-	afterSeq := int64(0)
-	highest := int64(0)
+	afterSeq := fromSeq
+	highest := fromSeq
 
 	for {
 		readTx, err := eventsDB.Begin()
 		if err != nil {
 			return fmt.Errorf("begin events read tx: %w", err)
 		}
+		pruned, err := tdsync.PrunedThroughSeq(readTx)
+		if err != nil {
+			_ = readTx.Rollback()
+			return err
+		}
+		if afterSeq < pruned {
+			_ = readTx.Rollback()
+			return fmt.Errorf("events through %d were pruned but replay starts at %d", pruned, afterSeq)
+		}
 		result, err := tdsync.GetEventsSince(readTx, afterSeq, batchSize, "")
 		_ = readTx.Rollback()
 		if err != nil {
@@ -351,13 +375,20 @@ func (p *ProjectLivePool) replayEvents(projectID string, projectDB *tddb.DB) err
 		}
 	}
 
-	if highest > 0 {
-		if _, err := projectDB.Conn().Exec(
-			`INSERT OR REPLACE INTO applied_events(server_seq, applied_at) VALUES (?, datetime('now'))`,
-			highest,
-		); err != nil {
-			return fmt.Errorf("record applied cursor: %w", err)
-		}
+	return recordAppliedCursor(projectDB, highest)
+}
+
+// recordAppliedCursor stores seq as the bootstrap cursor in applied_events.
+// A zero seq means nothing was applied and records nothing.
+func recordAppliedCursor(projectDB *tddb.DB, seq int64) error {
+	if seq <= 0 {
+		return nil
+	}
+	if _, err := projectDB.Conn().Exec(
+		`INSERT OR REPLACE INTO applied_events(server_seq, applied_at) VALUES (?, datetime('now'))`,
+		seq,
+	); err != nil {
+		return fmt.Errorf("record applied cursor: %w", err)
 	}
 	return nil
 }
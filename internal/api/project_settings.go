@@ -0,0 +1,136 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	tddb "github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/models"
+	"github.com/marcus/td/internal/workflow"
+)
+
+// maxProjectSettingsBytes bounds the size of a project's settings object.
+const maxProjectSettingsBytes = 64 << 10
+
+// ProjectSettings is the body for GET and PUT /v1/projects/{id}/settings:
+// project-level config every client should share. Omitted fields are unset.
+// The default query is not one of them: it is per-user config, and the
+// project-wide default view lives in /v1/projects/{id}/defaults.
+type ProjectSettings struct {
+	RequireCloseReason *bool                  `json:"require_close_reason,omitempty"`
+	Workflow           *models.WorkflowConfig `json:"workflow,omitempty"`
+}
+
+// parseProjectSettings decodes and validates a settings object. Unknown
+// fields are rejected so a typo can't be silently synced to every client.
+func parseProjectSettings(raw []byte) (*ProjectSettings, error) {
+	if len(raw) > maxProjectSettingsBytes {
+		return nil, fmt.Errorf("settings exceed %d bytes", maxProjectSettingsBytes)
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	var settings ProjectSettings
+	if err := dec.Decode(&settings); err != nil {
+		return nil, fmt.Errorf("invalid settings: %w", err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("invalid settings: trailing data after object")
+	}
+
+	if err := workflow.ValidateConfig(settings.Workflow); err != nil {
+		return nil, fmt.Errorf("invalid workflow: %w", err)
+	}
+	return &settings, nil
+}
+
+// handleGetProjectSettings handles GET /v1/projects/{id}/settings.
+func (s *Server) handleGetProjectSettings(w http.ResponseWriter, r *http.Request) {
+	projectID := r.PathValue("id")
+
+	raw, err := s.store.GetProjectSettings(projectID)
+	if err != nil {
+		logFor(r.Context()).Error("get project settings", "err", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "failed to get project settings")
+		return
+	}
+	if raw == nil {
+		writeError(w, http.StatusNotFound, "not_found", "project not found")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(raw)
+}
+
+// handlePutProjectSettings handles PUT /v1/projects/{id}/settings. The body
+// replaces the whole settings object. Cached snapshots are dropped so new
+// clients bootstrap with the new settings.
+func (s *Server) handlePutProjectSettings(w http.ResponseWriter, r *http.Request) {
+	projectID := r.PathValue("id")
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxProjectSettingsBytes+1))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "failed to read body")
+		return
+	}
+	settings, err := parseProjectSettings(body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", err.Error())
+		return
+	}
+	raw, err := json.Marshal(settings)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal_error", "failed to encode settings")
+		return
+	}
+
+	current, err := s.store.GetProject(projectID, false)
+	if err != nil {
+		logFor(r.Context()).Error("get project for settings", "err", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "failed to get project")
+		return
+	}
+	if current == nil {
+		writeError(w, http.StatusNotFound, "not_found", "project not found")
+		return
+	}
+
+	if err := s.store.SetProjectSettings(projectID, raw); err != nil {
+		logFor(r.Context()).Error("set project settings", "err", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "failed to set project settings")
+		return
+	}
+	// Snapshots built with the old settings are never served again. Only
+	// cached files go; base snapshots of a pruned event log live in a
+	// subdirectory and are the only record of the pruned history.
+	cleanSnapshotCache(s.snapshotCacheDir(projectID), "")
+
+	writeJSON(w, http.StatusOK, settings)
+}
+
+// writeSnapshotSettings stores the project's settings in the snapshot at
+// path, in a single-row project_settings table, so a client bootstrapping
+// from it starts with them.
+func writeSnapshotSettings(raw json.RawMessage, path string) error {
+	conn, err := tddb.OpenSQLite(path, tddb.OpenOptions{DisableForeignKeys: true})
+	if err != nil {
+		return fmt.Errorf("open snapshot db: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Exec(`CREATE TABLE IF NOT EXISTS project_settings (
+		id INTEGER PRIMARY KEY CHECK (id = 1),
+		settings TEXT NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("create project_settings: %w", err)
+	}
+	if _, err := conn.Exec(`INSERT OR REPLACE INTO project_settings (id, settings) VALUES (1, ?)`, string(raw)); err != nil {
+		return fmt.Errorf("write project_settings: %w", err)
+	}
+	return nil
+}
@@ -3,7 +3,9 @@ package api
 import (
 	"encoding/json"
 	"net/http"
+	"strings"
 
+	"github.com/marcus/td/internal/query"
 	"github.com/marcus/td/internal/serverdb"
 )
 
@@ -180,6 +182,70 @@ func (s *Server) handleDeleteProject(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// ProjectDefaultsBody is the JSON body for GET and PUT
+// /v1/projects/{id}/defaults. Board is a board ID or name.
+type ProjectDefaultsBody struct {
+	Query string `json:"query"`
+	Board string `json:"board"`
+}
+
+// handleGetProjectDefaults handles GET /v1/projects/{id}/defaults.
+func (s *Server) handleGetProjectDefaults(w http.ResponseWriter, r *http.Request) {
+	projectID := r.PathValue("id")
+
+	defaults, err := s.store.GetProjectDefaults(projectID)
+	if err != nil {
+		logFor(r.Context()).Error("get project defaults", "err", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "failed to get project defaults")
+		return
+	}
+	if defaults == nil {
+		writeError(w, http.StatusNotFound, "not_found", "project not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ProjectDefaultsBody{Query: defaults.Query, Board: defaults.Board})
+}
+
+// handlePutProjectDefaults handles PUT /v1/projects/{id}/defaults. The
+// query must parse as TDQ so every client can apply it.
+func (s *Server) handlePutProjectDefaults(w http.ResponseWriter, r *http.Request) {
+	projectID := r.PathValue("id")
+
+	var req ProjectDefaultsBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "invalid json body")
+		return
+	}
+	req.Query = strings.TrimSpace(req.Query)
+	req.Board = strings.TrimSpace(req.Board)
+	if req.Query != "" {
+		if _, err := query.Parse(req.Query); err != nil {
+			writeError(w, http.StatusBadRequest, "bad_request", "invalid query: "+err.Error())
+			return
+		}
+	}
+
+	current, err := s.store.GetProject(projectID, false)
+	if err != nil {
+		logFor(r.Context()).Error("get project for defaults", "err", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "failed to get project")
+		return
+	}
+	if current == nil {
+		writeError(w, http.StatusNotFound, "not_found", "project not found")
+		return
+	}
+
+	if err := s.store.SetProjectDefaults(projectID, serverdb.ProjectDefaults{Query: req.Query, Board: req.Board}); err != nil {
+		logFor(r.Context()).Error("set project defaults", "err", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "failed to set project defaults")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, req)
+}
+
 func projectToResponse(p *serverdb.Project) ProjectResponse {
 	resp := ProjectResponse{
 		ID:          p.ID,
@@ -5,6 +5,7 @@ import (
 	"log/slog"
 	"net"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -53,20 +54,53 @@ func (rl *RateLimiter) Stop() {
 
 // Allow checks if the key is within the rate limit (limit per 1-minute window).
 func (rl *RateLimiter) Allow(key string, limit int) bool {
+	allowed, _ := rl.Take(key, limit)
+	return allowed
+}
+
+// rateLimitState is a key's standing in its current window after a request.
+type rateLimitState struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time // when the window ends and the count starts over
+}
+
+// Take counts a request against key like Allow and also reports the key's
+// state afterwards, so responses can tell clients how close they are.
+func (rl *RateLimiter) Take(key string, limit int) (bool, rateLimitState) {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
 	now := time.Now()
 	b, ok := rl.buckets[key]
 	if !ok || now.Sub(b.windowAt) >= time.Minute {
-		rl.buckets[key] = &bucket{count: 1, windowAt: now}
-		return true
+		b = &bucket{count: 1, windowAt: now}
+		rl.buckets[key] = b
+		return true, b.state(limit)
 	}
 	if b.count >= limit {
-		return false
+		return false, b.state(limit)
 	}
 	b.count++
-	return true
+	return true, b.state(limit)
+}
+
+func (b *bucket) state(limit int) rateLimitState {
+	return rateLimitState{
+		Limit:     limit,
+		Remaining: max(limit-b.count, 0),
+		Reset:     b.windowAt.Add(time.Minute),
+	}
+}
+
+// setRateLimitHeaders reports a key's rate limit state on a response:
+// X-RateLimit-Limit and X-RateLimit-Remaining are requests per window,
+// X-RateLimit-Reset is when the window ends, in Unix seconds.
+func setRateLimitHeaders(w http.ResponseWriter, st rateLimitState) {
+	h := w.Header()
+	h.Set("X-RateLimit-Limit", strconv.Itoa(st.Limit))
+	h.Set("X-RateLimit-Remaining", strconv.Itoa(st.Remaining))
+	h.Set("X-RateLimit-Reset", strconv.FormatInt(st.Reset.Unix(), 10))
 }
 
 func (rl *RateLimiter) cleanup() {
@@ -112,7 +146,9 @@ func authRateLimitMiddleware(rl *RateLimiter, limit, pollLimit int, store *serve
 					key = "ip:authpoll:" + host
 					effLimit = pollLimit
 				}
-				if !rl.Allow(key, effLimit) {
+				allowed, st := rl.Take(key, effLimit)
+				setRateLimitHeaders(w, st)
+				if !allowed {
 					if err := store.InsertRateLimitEvent("", host, "auth"); err != nil {
 						slog.Error("log rate limit event", "err", err)
 					}
@@ -136,7 +172,9 @@ func (s *Server) withRateLimit(handler http.HandlerFunc, limit int) http.Handler
 			return
 		}
 		key := fmt.Sprintf("key:%s:%d", user.KeyID, limit)
-		if !s.rateLimiter.Allow(key, limit) {
+		allowed, st := s.rateLimiter.Take(key, limit)
+		setRateLimitHeaders(w, st)
+		if !allowed {
 			ip := clientIP(r, s.config.TrustedProxies)
 			endpointClass := classifyEndpoint(r.URL.Path)
 			if err := s.store.InsertRateLimitEvent(user.KeyID, ip, endpointClass); err != nil {
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"path/filepath"
+	"strconv"
 	"testing"
 	"time"
 
@@ -208,6 +209,17 @@ func TestWithRateLimitIntegration(t *testing.T) {
 		if w.Code != http.StatusOK {
 			t.Fatalf("push %d: expected 200, got %d: %s", i+1, w.Code, w.Body.String())
 		}
+		// Every accepted push reports one fewer request left in the window
+		if got, want := w.Header().Get("X-RateLimit-Remaining"), strconv.Itoa(rateLimitPush-i-1); got != want {
+			t.Fatalf("push %d: X-RateLimit-Remaining = %q, want %q", i+1, got, want)
+		}
+		if got := w.Header().Get("X-RateLimit-Limit"); got != strconv.Itoa(rateLimitPush) {
+			t.Fatalf("push %d: X-RateLimit-Limit = %q, want %d", i+1, got, rateLimitPush)
+		}
+		reset, err := strconv.ParseInt(w.Header().Get("X-RateLimit-Reset"), 10, 64)
+		if err != nil || reset < time.Now().Unix() || reset > time.Now().Add(time.Minute).Unix() {
+			t.Fatalf("push %d: X-RateLimit-Reset = %q, want a time within the next minute", i+1, w.Header().Get("X-RateLimit-Reset"))
+		}
 	}
 
 	// Next push should be rate limited
@@ -229,6 +241,9 @@ func TestWithRateLimitIntegration(t *testing.T) {
 	if w.Code != http.StatusTooManyRequests {
 		t.Fatalf("expected 429, got %d: %s", w.Code, w.Body.String())
 	}
+	if got := w.Header().Get("X-RateLimit-Remaining"); got != "0" {
+		t.Fatalf("429: X-RateLimit-Remaining = %q, want 0", got)
+	}
 }
 
 func TestClientIPNoTrustedProxy(t *testing.T) {
@@ -6,6 +6,8 @@ import (
 	"log/slog"
 	"net"
 	"net/http"
+	"os"
+	"runtime/debug"
 	"time"
 
 	"github.com/marcus/td/internal/email"
@@ -23,6 +25,7 @@ type Server struct {
 	metrics         *Metrics
 	rateLimiter     *RateLimiter
 	snapshotGroup   singleflight.Group
+	snapshotURLKey  []byte // HMAC key for signed snapshot URLs
 	cancel          context.CancelFunc
 	startTime       time.Time
 	emailSender     email.EmailSender
@@ -64,6 +67,12 @@ func NewServer(cfg Config, store *serverdb.ServerDB) (*Server, error) {
 		pingInterval:    defaultPingInterval,
 	}
 
+	key, err := newSnapshotURLKey(cfg.SnapshotURLSecret)
+	if err != nil {
+		return nil, err
+	}
+	s.snapshotURLKey = key
+
 	sender, err := email.NewEmailSender(buildEmailConfig(cfg))
 	if err != nil {
 		return nil, fmt.Errorf("create email sender: %w", err)
@@ -81,6 +90,12 @@ func NewServer(cfg Config, store *serverdb.ServerDB) (*Server, error) {
 	return s, nil
 }
 
+// Handler returns the HTTP handler with all routes and middleware, for
+// serving the API from a caller-provided listener such as httptest.
+func (s *Server) Handler() http.Handler {
+	return s.http.Handler
+}
+
 // Start begins listening for HTTP requests (non-blocking).
 func (s *Server) Start() error {
 	ln, err := net.Listen("tcp", s.config.ListenAddr)
@@ -192,6 +207,9 @@ func (s *Server) Start() error {
 	// down with the rest of the periodic tasks.
 	s.startLagSampler(ctx)
 
+	// Prune sync events past SyncEventRetention behind a base snapshot
+	s.startEventPruner(ctx)
+
 	return nil
 }
 
@@ -215,7 +233,11 @@ func (s *Server) routes() http.Handler {
 
 	// Health & metrics
 	mux.HandleFunc("GET /healthz", s.handleHealth)
+	mux.HandleFunc("GET /readyz", s.handleReady)
 	mux.HandleFunc("GET /metricz", s.handleMetrics)
+	if s.config.MetricsEnabled {
+		mux.HandleFunc("GET /metrics", s.handlePrometheusMetrics)
+	}
 
 	// Auth (public)
 	if s.config.LegacyDeviceAuth {
@@ -256,29 +278,46 @@ func (s *Server) routes() http.Handler {
 	mux.HandleFunc("GET /v1/projects/{id}", s.requireProjectAuth(serverdb.RoleReader, s.withRateLimit(s.handleGetProject, s.config.RateLimitOther)))
 	mux.HandleFunc("PATCH /v1/projects/{id}", s.requireProjectAuth(serverdb.RoleWriter, s.withRateLimit(s.handleUpdateProject, s.config.RateLimitOther)))
 	mux.HandleFunc("DELETE /v1/projects/{id}", s.requireProjectAuth(serverdb.RoleOwner, s.withRateLimit(s.handleDeleteProject, s.config.RateLimitOther)))
+	mux.HandleFunc("GET /v1/projects/{id}/defaults", s.requireProjectAuth(serverdb.RoleReader, s.withRateLimit(s.handleGetProjectDefaults, s.config.RateLimitOther)))
+	mux.HandleFunc("PUT /v1/projects/{id}/defaults", s.requireProjectAuth(serverdb.RoleWriter, s.withRateLimit(s.handlePutProjectDefaults, s.config.RateLimitOther)))
+	mux.HandleFunc("GET /v1/projects/{id}/settings", s.requireProjectAuth(serverdb.RoleReader, s.withRateLimit(s.handleGetProjectSettings, s.config.RateLimitOther)))
+	mux.HandleFunc("PUT /v1/projects/{id}/settings", s.requireProjectAuth(serverdb.RoleOwner, s.withRateLimit(s.handlePutProjectSettings, s.config.RateLimitOther)))
 
 	// Invitations
 	mux.HandleFunc("POST /v1/projects/{id}/invitations", s.requireProjectAuth(serverdb.RoleOwner, s.withRateLimit(s.handleCreateInvitation, s.config.RateLimitOther)))
 	mux.HandleFunc("GET /v1/projects/{id}/invitations", s.requireProjectAuth(serverdb.RoleOwner, s.withRateLimit(s.handleListProjectInvitations, s.config.RateLimitOther)))
 	mux.HandleFunc("DELETE /v1/projects/{id}/invitations/{invitationID}", s.requireProjectAuth(serverdb.RoleOwner, s.withRateLimit(s.handleDeleteInvitation, s.config.RateLimitOther)))
+	mux.HandleFunc("POST /v1/projects/{id}/invites", s.requireProjectAuth(serverdb.RoleOwner, s.withRateLimit(s.handleCreateInvite, s.config.RateLimitOther)))
+	mux.HandleFunc("GET /v1/projects/{id}/invites", s.requireProjectAuth(serverdb.RoleOwner, s.withRateLimit(s.handleListInvites, s.config.RateLimitOther)))
+	mux.HandleFunc("DELETE /v1/projects/{id}/invites/{inviteID}", s.requireProjectAuth(serverdb.RoleOwner, s.withRateLimit(s.handleDeleteInvite, s.config.RateLimitOther)))
 	mux.HandleFunc("GET /v1/invitations", s.requireAuth(s.withRateLimit(s.handleListOwnInvitations, s.config.RateLimitOther)))
 	mux.HandleFunc("POST /v1/invitations/{invitationID}/accept", s.requireAuth(s.withRateLimit(s.handleAcceptInvitation, s.config.RateLimitOther)))
 	mux.HandleFunc("POST /v1/invitations/{invitationID}/decline", s.requireAuth(s.withRateLimit(s.handleDeclineInvitation, s.config.RateLimitOther)))
 
 	// Members
 	mux.HandleFunc("POST /v1/projects/{id}/members", s.requireProjectAuth(serverdb.RoleOwner, s.withRateLimit(s.handleAddMember, s.config.RateLimitOther)))
+	mux.HandleFunc("POST /v1/projects/{id}/members/bulk", s.requireProjectAuth(serverdb.RoleOwner, s.withRateLimit(s.handleBulkAddMembers, s.config.RateLimitOther)))
 	mux.HandleFunc("GET /v1/projects/{id}/members", s.requireProjectAuth(serverdb.RoleReader, s.withRateLimit(s.handleListMembers, s.config.RateLimitOther)))
 	mux.HandleFunc("PATCH /v1/projects/{id}/members/{userID}", s.requireProjectAuth(serverdb.RoleOwner, s.withRateLimit(s.handleUpdateMember, s.config.RateLimitOther)))
 	mux.HandleFunc("DELETE /v1/projects/{id}/members/{userID}", s.requireProjectAuth(serverdb.RoleOwner, s.withRateLimit(s.handleRemoveMember, s.config.RateLimitOther)))
 
+	// Audit log: who pushed which events
+	mux.HandleFunc("GET /v1/projects/{id}/audit", s.requireProjectAuth(serverdb.RoleWriter, s.withRateLimit(s.handleProjectAudit, s.config.RateLimitOther)))
+	mux.HandleFunc("GET /v1/projects/{id}/export", s.requireProjectAuth(serverdb.RoleWriter, s.withRateLimit(s.handleProjectExport, s.config.RateLimitOther)))
+
 	// Realtime SSE — no rate limit wrapper; it's a long-lived stream.
 	mux.HandleFunc("GET /v1/projects/{id}/events", s.requireProjectAuth(serverdb.RoleReader, s.handleProjectEvents))
 
 	// Sync
+	mux.HandleFunc("POST /v1/sync/push", s.requireAuth(s.requireProjectScope(s.withRateLimit(s.handleBatchPush, s.config.RateLimitPush))))
 	mux.HandleFunc("POST /v1/projects/{id}/sync/push", s.requireProjectAuth(serverdb.RoleWriter, s.withRateLimit(s.handleSyncPush, s.config.RateLimitPush)))
 	mux.HandleFunc("GET /v1/projects/{id}/sync/pull", s.requireProjectAuth(serverdb.RoleReader, s.withRateLimit(s.handleSyncPull, s.config.RateLimitPull)))
 	mux.HandleFunc("GET /v1/projects/{id}/sync/status", s.requireProjectAuth(serverdb.RoleReader, s.withRateLimit(s.handleSyncStatus, s.config.RateLimitOther)))
+	mux.HandleFunc("POST /v1/projects/{id}/graphql", s.requireProjectAuth(serverdb.RoleReader, s.withRateLimit(s.handleGraphQL, s.config.RateLimitOther)))
 	mux.HandleFunc("GET /v1/projects/{id}/sync/snapshot", s.requireProjectAuth(serverdb.RoleReader, s.withRateLimit(s.handleSyncSnapshot, s.config.RateLimitOther)))
+	mux.HandleFunc("POST /v1/projects/{id}/sync/snapshot/url", s.requireProjectAuth(serverdb.RoleReader, s.withRateLimit(s.handleSnapshotURL, s.config.RateLimitOther)))
+	// Signed snapshot download: no bearer token, the URL's signature authorizes it
+	mux.HandleFunc("GET /v1/snapshots/{id}/{seq}", s.handleSignedSnapshot)
 
 	// Perch-shape REST routes (S2.3) — wraps td-serve handlers against per-project
 	// project.db. See internal/api/project_routes.go and plan §6 for details.
@@ -321,7 +360,90 @@ func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
 }
 
+// readinessResponse is the JSON response for GET /readyz. Checks maps each
+// check to "ok" or the reason it failed.
+type readinessResponse struct {
+	Status        string            `json:"status"`
+	Version       string            `json:"version"`
+	UptimeSeconds float64           `json:"uptime_seconds"`
+	Checks        map[string]string `json:"checks"`
+}
+
+// handleReady runs the deeper checks /healthz skips: the server DB answers a
+// query and ProjectDataDir accepts writes. It returns 503 when either fails,
+// so load balancers stop routing to an instance that can't serve syncs.
+func (s *Server) handleReady(w http.ResponseWriter, r *http.Request) {
+	resp := readinessResponse{
+		Status:        "ok",
+		Version:       serverVersion(),
+		UptimeSeconds: time.Since(s.startTime).Seconds(),
+		Checks:        map[string]string{"db": "ok", "data_dir": "ok"},
+	}
+	if _, err := s.store.CountProjects(); err != nil {
+		slog.Warn("readiness: server db", "err", err)
+		resp.Checks["db"] = "query failed"
+	}
+	if err := checkDirWritable(s.config.ProjectDataDir); err != nil {
+		slog.Warn("readiness: data dir", "dir", s.config.ProjectDataDir, "err", err)
+		resp.Checks["data_dir"] = "not writable"
+	}
+
+	code := http.StatusOK
+	for _, result := range resp.Checks {
+		if result != "ok" {
+			resp.Status = "error"
+			code = http.StatusServiceUnavailable
+		}
+	}
+	writeJSON(w, code, resp)
+}
+
+// checkDirWritable creates dir if needed and writes and removes a probe
+// file in it.
+func checkDirWritable(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(dir, ".readyz-*")
+	if err != nil {
+		return err
+	}
+	name := f.Name()
+	_, err = f.Write([]byte("ok"))
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if rerr := os.Remove(name); err == nil {
+		err = rerr
+	}
+	return err
+}
+
+// serverVersion reports the module version the binary was built from, with
+// the VCS revision when the build recorded one.
+func serverVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	v := info.Main.Version
+	for _, setting := range info.Settings {
+		if setting.Key == "vcs.revision" && len(setting.Value) >= 12 {
+			v += "+" + setting.Value[:12]
+		}
+	}
+	return v
+}
+
 // handleMetrics returns a snapshot of server metrics.
 func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, s.metrics.Snapshot())
 }
+
+// handlePrometheusMetrics serves server metrics in the Prometheus text format.
+func (s *Server) handlePrometheusMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	if err := s.metrics.WritePrometheus(w); err != nil {
+		logFor(r.Context()).Warn("write metrics", "err", err)
+	}
+}
@@ -2,7 +2,9 @@ package api
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -10,9 +12,12 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/marcus/td/internal/serverdb"
+	tdsync "github.com/marcus/td/internal/sync"
 	_ "modernc.org/sqlite"
 )
 
@@ -149,6 +154,61 @@ func TestHealthEndpoint(t *testing.T) {
 	}
 }
 
+func TestReadyEndpoint(t *testing.T) {
+	srv, _ := newTestServer(t)
+
+	w := doRequest(srv, "GET", "/readyz", "", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp readinessResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Status != "ok" || resp.Checks["db"] != "ok" || resp.Checks["data_dir"] != "ok" {
+		t.Fatalf("expected all checks ok, got %+v", resp)
+	}
+	if resp.Version == "" {
+		t.Fatal("expected a version")
+	}
+
+	// The write probe must not leave anything behind
+	entries, _ := os.ReadDir(srv.config.ProjectDataDir)
+	if len(entries) != 0 {
+		t.Fatalf("readiness probe left files in the data dir: %v", entries)
+	}
+}
+
+func TestReadyEndpointUnwritableDataDir(t *testing.T) {
+	// A regular file where the data dir should be can't be written into,
+	// even when the tests run as root
+	notADir := filepath.Join(t.TempDir(), "projects")
+	if err := os.WriteFile(notADir, nil, 0644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	srv, _ := newTestServerWithConfig(t, func(c *Config) {
+		c.ProjectDataDir = notADir
+	})
+
+	w := doRequest(srv, "GET", "/readyz", "", nil)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp readinessResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Status != "error" || resp.Checks["data_dir"] != "not writable" || resp.Checks["db"] != "ok" {
+		t.Fatalf("unexpected readiness: %+v", resp)
+	}
+
+	// /healthz stays cheap and doesn't look at the data dir
+	if w := doRequest(srv, "GET", "/healthz", "", nil); w.Code != http.StatusOK {
+		t.Fatalf("healthz: expected 200, got %d", w.Code)
+	}
+}
+
 func TestPushRequiresAuth(t *testing.T) {
 	srv, _ := newTestServer(t)
 
@@ -628,6 +688,208 @@ func TestListProjects(t *testing.T) {
 	}
 }
 
+func TestProjectDefaults(t *testing.T) {
+	srv, store := newTestServer(t)
+	_, ownerToken := createTestUser(t, store, "defaults-owner@test.com")
+	readerID, readerToken := createTestUser(t, store, "defaults-reader@test.com")
+
+	w := doRequest(srv, "POST", "/v1/projects", ownerToken, CreateProjectRequest{Name: "defaults"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: expected 201, got %d", w.Code)
+	}
+	var project ProjectResponse
+	_ = json.NewDecoder(w.Body).Decode(&project)
+	path := fmt.Sprintf("/v1/projects/%s/defaults", project.ID)
+
+	w = doRequest(srv, "POST", fmt.Sprintf("/v1/projects/%s/members", project.ID), ownerToken, AddMemberRequest{
+		UserID: readerID, Role: "reader",
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("add reader: expected 201, got %d", w.Code)
+	}
+
+	// No defaults yet
+	w = doRequest(srv, "GET", path, readerToken, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("get empty: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var got ProjectDefaultsBody
+	_ = json.NewDecoder(w.Body).Decode(&got)
+	if got != (ProjectDefaultsBody{}) {
+		t.Fatalf("expected empty defaults, got %+v", got)
+	}
+
+	want := ProjectDefaultsBody{Query: `status = open label("backend")`, Board: "Sprint"}
+	w = doRequest(srv, "PUT", path, ownerToken, want)
+	if w.Code != http.StatusOK {
+		t.Fatalf("put: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// Persisted and visible to every member
+	w = doRequest(srv, "GET", path, readerToken, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("get: expected 200, got %d", w.Code)
+	}
+	_ = json.NewDecoder(w.Body).Decode(&got)
+	if got != want {
+		t.Fatalf("defaults = %+v, want %+v", got, want)
+	}
+	stored, err := store.GetProjectDefaults(project.ID)
+	if err != nil || stored == nil || stored.Query != want.Query || stored.Board != want.Board {
+		t.Fatalf("stored defaults = %+v (err %v), want %+v", stored, err, want)
+	}
+
+	// Readers can't change them
+	w = doRequest(srv, "PUT", path, readerToken, ProjectDefaultsBody{Query: "type = bug"})
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("reader put: expected 403, got %d", w.Code)
+	}
+
+	// Queries must be valid TDQ
+	w = doRequest(srv, "PUT", path, ownerToken, ProjectDefaultsBody{Query: "status = = open"})
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("invalid query: expected 400, got %d", w.Code)
+	}
+}
+
+func TestProjectSettings(t *testing.T) {
+	srv, store := newTestServer(t)
+	_, ownerToken := createTestUser(t, store, "settings-owner@test.com")
+	readerID, readerToken := createTestUser(t, store, "settings-reader@test.com")
+	writerID, writerToken := createTestUser(t, store, "settings-writer@test.com")
+
+	w := doRequest(srv, "POST", "/v1/projects", ownerToken, CreateProjectRequest{Name: "settings"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: expected 201, got %d", w.Code)
+	}
+	var project ProjectResponse
+	_ = json.NewDecoder(w.Body).Decode(&project)
+	path := fmt.Sprintf("/v1/projects/%s/settings", project.ID)
+
+	for id, role := range map[string]string{readerID: "reader", writerID: "writer"} {
+		w = doRequest(srv, "POST", fmt.Sprintf("/v1/projects/%s/members", project.ID), ownerToken, AddMemberRequest{
+			UserID: id, Role: role,
+		})
+		if w.Code != http.StatusCreated {
+			t.Fatalf("add %s: expected 201, got %d", role, w.Code)
+		}
+	}
+
+	// No settings yet
+	w = doRequest(srv, "GET", path, readerToken, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("get empty: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if body := strings.TrimSpace(w.Body.String()); body != "{}" {
+		t.Fatalf("expected empty settings, got %s", body)
+	}
+
+	want := json.RawMessage(`{"require_close_reason":true,"workflow":{"statuses":["qa"],"transitions":[{"from":"in_progress","to":"qa"}]}}`)
+	w = doRequest(srv, "PUT", path, ownerToken, want)
+	if w.Code != http.StatusOK {
+		t.Fatalf("put: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// Persisted and visible to every member
+	w = doRequest(srv, "GET", path, readerToken, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("get: expected 200, got %d", w.Code)
+	}
+	var got ProjectSettings
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.RequireCloseReason == nil || !*got.RequireCloseReason ||
+		got.Workflow == nil || len(got.Workflow.Statuses) != 1 || got.Workflow.Statuses[0] != "qa" {
+		t.Fatalf("settings = %+v", got)
+	}
+
+	// Only the owner can change them
+	for name, token := range map[string]string{"reader": readerToken, "writer": writerToken} {
+		w = doRequest(srv, "PUT", path, token, json.RawMessage(`{}`))
+		if w.Code != http.StatusForbidden {
+			t.Fatalf("%s put: expected 403, got %d", name, w.Code)
+		}
+	}
+
+	// Writes are validated
+	for name, body := range map[string]string{
+		"default query":    `{"default_query":"status = open"}`,
+		"unknown key":      `{"require_close_reasn":true}`,
+		"invalid workflow": `{"workflow":{"transitions":[{"from":"open","to":"nowhere"}]}}`,
+		"not an object":    `[1,2]`,
+	} {
+		w = doRequest(srv, "PUT", path, ownerToken, json.RawMessage(body))
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected 400, got %d", name, w.Code)
+		}
+	}
+	w = doRequest(srv, "GET", path, readerToken, nil)
+	got = ProjectSettings{}
+	_ = json.NewDecoder(w.Body).Decode(&got)
+	if got.RequireCloseReason == nil || got.Workflow == nil {
+		t.Fatalf("rejected write changed settings: %+v", got)
+	}
+}
+
+func TestSnapshotIncludesProjectSettings(t *testing.T) {
+	srv, store := newTestServer(t)
+	_, token := createTestUser(t, store, "settings-snap@test.com")
+
+	w := doRequest(srv, "POST", "/v1/projects", token, CreateProjectRequest{Name: "settings-snap"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: expected 201, got %d", w.Code)
+	}
+	var project ProjectResponse
+	_ = json.NewDecoder(w.Body).Decode(&project)
+
+	w = doRequest(srv, "POST", fmt.Sprintf("/v1/projects/%s/sync/push", project.ID), token, PushRequest{
+		DeviceID: "dev1", SessionID: "sess1",
+		Events: []EventInput{
+			{ClientActionID: 1, ActionType: "create", EntityType: "issues", EntityID: "i_001",
+				Payload: json.RawMessage(`{"schema_version":1,"new_data":{"title":"one","status":"open"}}`), ClientTimestamp: "2025-01-01T00:00:00Z"},
+		},
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("push: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	readSettings := func() string {
+		t.Helper()
+		w := doRequest(srv, "GET", fmt.Sprintf("/v1/projects/%s/sync/snapshot", project.ID), token, nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("snapshot: expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		path := filepath.Join(t.TempDir(), "snapshot.db")
+		if err := os.WriteFile(path, w.Body.Bytes(), 0o644); err != nil {
+			t.Fatalf("write snapshot: %v", err)
+		}
+		conn, err := sql.Open("sqlite", path)
+		if err != nil {
+			t.Fatalf("open snapshot: %v", err)
+		}
+		defer conn.Close()
+		var settings string
+		if err := conn.QueryRow(`SELECT settings FROM project_settings WHERE id = 1`).Scan(&settings); err != nil {
+			t.Fatalf("read project_settings: %v", err)
+		}
+		return settings
+	}
+
+	if got := readSettings(); got != "{}" {
+		t.Fatalf("initial snapshot settings = %s, want {}", got)
+	}
+
+	// Changing settings drops the cached snapshot so the next one carries them
+	w = doRequest(srv, "PUT", fmt.Sprintf("/v1/projects/%s/settings", project.ID), token, json.RawMessage(`{"require_close_reason":true}`))
+	if w.Code != http.StatusOK {
+		t.Fatalf("put: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := readSettings(); got != `{"require_close_reason":true}` {
+		t.Fatalf("snapshot settings = %s", got)
+	}
+}
+
 func TestAddMember(t *testing.T) {
 	srv, store := newTestServer(t)
 	_, token1 := createTestUser(t, store, "owner@test.com")
@@ -688,6 +950,102 @@ func TestMemberRoleEnforcement(t *testing.T) {
 	}
 }
 
+func TestBulkAddMembers(t *testing.T) {
+	srv, store := newTestServer(t)
+	_, ownerToken := createTestUser(t, store, "owner@test.com")
+	writerID, writerToken := createTestUser(t, store, "writer@test.com")
+	readerID, _ := createTestUser(t, store, "reader@test.com")
+	_, _ = createTestUser(t, store, "byemail@test.com")
+
+	w := doRequest(srv, "POST", "/v1/projects", ownerToken, CreateProjectRequest{Name: "bulk-members"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: expected 201, got %d", w.Code)
+	}
+	var project ProjectResponse
+	_ = json.NewDecoder(w.Body).Decode(&project)
+	path := fmt.Sprintf("/v1/projects/%s/members/bulk", project.ID)
+
+	w = doRequest(srv, "POST", fmt.Sprintf("/v1/projects/%s/members", project.ID), ownerToken, AddMemberRequest{
+		UserID: writerID, Role: "writer",
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("add writer: expected 201, got %d", w.Code)
+	}
+
+	w = doRequest(srv, "POST", path, ownerToken, BulkAddMembersRequest{Members: []AddMemberRequest{
+		{UserID: readerID, Role: "reader"},
+		{Email: "ByEmail@test.com", Role: "writer"},
+		{UserID: readerID, Role: "writer"},
+		{UserID: writerID, Role: "reader"},
+		{Email: "newcomer@test.com", Role: "reader"},
+		{Email: "someone@test.com", Role: "admin"},
+		{Email: "not-an-email", Role: "reader"},
+		{UserID: "u_missing", Role: "reader"},
+	}})
+	if w.Code != http.StatusOK {
+		t.Fatalf("bulk add: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp BulkAddMembersResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	want := []struct{ status, reason string }{
+		{"added", ""},
+		{"added", ""},
+		{"skipped", "duplicate entry"},
+		{"skipped", "already a member"},
+		{"invited", ""},
+		{"error", `invalid role: "admin"`},
+		{"error", "invalid email"},
+		{"error", "user not found"},
+	}
+	if len(resp.Results) != len(want) {
+		t.Fatalf("expected %d results, got %d", len(want), len(resp.Results))
+	}
+	for i, wr := range want {
+		got := resp.Results[i]
+		if got.Index != i || got.Status != wr.status || got.Reason != wr.reason {
+			t.Errorf("result %d: got %+v, want status %q reason %q", i, got, wr.status, wr.reason)
+		}
+	}
+	if resp.Added != 2 || resp.Invited != 1 || resp.Skipped != 2 || resp.Errors != 3 {
+		t.Errorf("counts: got added=%d invited=%d skipped=%d errors=%d", resp.Added, resp.Invited, resp.Skipped, resp.Errors)
+	}
+	if resp.Results[4].InviteID == "" {
+		t.Error("expected an invite ID for the unknown email")
+	}
+
+	members, err := store.ListMembers(project.ID)
+	if err != nil {
+		t.Fatalf("list members: %v", err)
+	}
+	roles := map[string]string{}
+	for _, m := range members {
+		roles[m.UserID] = m.Role
+	}
+	if len(members) != 4 || roles[readerID] != "reader" || roles[writerID] != "writer" {
+		t.Fatalf("unexpected members after import: %+v", roles)
+	}
+
+	// Re-importing the unknown email is skipped while its invite is pending
+	w = doRequest(srv, "POST", path, ownerToken, BulkAddMembersRequest{Members: []AddMemberRequest{
+		{Email: "newcomer@test.com", Role: "reader"},
+	}})
+	_ = json.NewDecoder(w.Body).Decode(&resp)
+	if len(resp.Results) != 1 || resp.Results[0].Reason != "already invited" {
+		t.Fatalf("re-invite: got %+v", resp.Results)
+	}
+
+	// Only owners may import
+	w = doRequest(srv, "POST", path, writerToken, BulkAddMembersRequest{Members: []AddMemberRequest{
+		{Email: "other@test.com", Role: "reader"},
+	}})
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("writer bulk add: expected 403, got %d", w.Code)
+	}
+}
+
 func TestListMembers(t *testing.T) {
 	srv, store := newTestServer(t)
 	_, token1 := createTestUser(t, store, "owner@test.com")
@@ -1470,9 +1828,198 @@ func TestSnapshotCaching(t *testing.T) {
 
 	// Verify cache file exists on disk
 	cacheDir := filepath.Join(srv.config.ProjectDataDir, "snapshots", project.ID)
-	cachePath := filepath.Join(cacheDir, seq1+".db")
-	if _, err := os.Stat(cachePath); os.IsNotExist(err) {
-		t.Fatalf("cache file not found at %s", cachePath)
+	cached, _ := filepath.Glob(filepath.Join(cacheDir, seq1+"-*.db"))
+	if len(cached) != 1 {
+		t.Fatalf("expected one cache file for seq %s in %s, found %v", seq1, cacheDir, cached)
+	}
+}
+
+func TestSnapshotRangeRequest(t *testing.T) {
+	srv, store := newTestServer(t)
+	_, token := createTestUser(t, store, "snap-range@test.com")
+
+	w := doRequest(srv, "POST", "/v1/projects", token, CreateProjectRequest{Name: "snap-range"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: expected 201, got %d", w.Code)
+	}
+	var project ProjectResponse
+	_ = json.NewDecoder(w.Body).Decode(&project)
+
+	w = doRequest(srv, "POST", fmt.Sprintf("/v1/projects/%s/sync/push", project.ID), token, PushRequest{
+		DeviceID: "dev1", SessionID: "sess1",
+		Events: []EventInput{
+			{ClientActionID: 1, ActionType: "create", EntityType: "issues", EntityID: "i_001",
+				Payload: json.RawMessage(`{"schema_version":1,"new_data":{"title":"range-test","status":"open"}}`), ClientTimestamp: "2025-01-01T00:00:00Z"},
+		},
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("push: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	snapshotPath := fmt.Sprintf("/v1/projects/%s/sync/snapshot", project.ID)
+	full := doRequest(srv, "GET", snapshotPath, token, nil)
+	if full.Code != http.StatusOK {
+		t.Fatalf("full snapshot: expected 200, got %d: %s", full.Code, full.Body.String())
+	}
+	fullBody := full.Body.Bytes()
+	if len(fullBody) < 200 {
+		t.Fatalf("snapshot unexpectedly small: %d bytes", len(fullBody))
+	}
+
+	// Resume from byte 100, as a client would after an interrupted download
+	req := httptest.NewRequest("GET", snapshotPath, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Range", "bytes=100-")
+	partial := httptest.NewRecorder()
+	srv.routes().ServeHTTP(partial, req)
+
+	if partial.Code != http.StatusPartialContent {
+		t.Fatalf("range: expected 206, got %d: %s", partial.Code, partial.Body.String())
+	}
+	wantRange := fmt.Sprintf("bytes 100-%d/%d", len(fullBody)-1, len(fullBody))
+	if got := partial.Header().Get("Content-Range"); got != wantRange {
+		t.Fatalf("Content-Range: got %q, want %q", got, wantRange)
+	}
+	if got := partial.Header().Get("X-Snapshot-Seq"); got != full.Header().Get("X-Snapshot-Seq") {
+		t.Fatalf("X-Snapshot-Seq: got %q, want %q", got, full.Header().Get("X-Snapshot-Seq"))
+	}
+	if !bytes.Equal(partial.Body.Bytes(), fullBody[100:]) {
+		t.Fatalf("partial content does not match bytes 100- of the full snapshot")
+	}
+}
+
+func TestSnapshotChecksumHeader(t *testing.T) {
+	srv, store := newTestServer(t)
+	_, token := createTestUser(t, store, "snap-sha@test.com")
+
+	w := doRequest(srv, "POST", "/v1/projects", token, CreateProjectRequest{Name: "snap-sha"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: expected 201, got %d", w.Code)
+	}
+	var project ProjectResponse
+	_ = json.NewDecoder(w.Body).Decode(&project)
+
+	w = doRequest(srv, "POST", fmt.Sprintf("/v1/projects/%s/sync/push", project.ID), token, PushRequest{
+		DeviceID: "dev1", SessionID: "sess1",
+		Events: []EventInput{
+			{ClientActionID: 1, ActionType: "create", EntityType: "issues", EntityID: "i_001",
+				Payload: json.RawMessage(`{"schema_version":1,"new_data":{"title":"sha-test","status":"open"}}`), ClientTimestamp: "2025-01-01T00:00:00Z"},
+		},
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("push: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	snapshotPath := fmt.Sprintf("/v1/projects/%s/sync/snapshot", project.ID)
+	full := doRequest(srv, "GET", snapshotPath, token, nil)
+	if full.Code != http.StatusOK {
+		t.Fatalf("snapshot: expected 200, got %d: %s", full.Code, full.Body.String())
+	}
+	sum := sha256.Sum256(full.Body.Bytes())
+	want := hex.EncodeToString(sum[:])
+	if got := full.Header().Get("X-Snapshot-SHA256"); got != want {
+		t.Fatalf("X-Snapshot-SHA256 = %q, want %q", got, want)
+	}
+
+	// A resumed download carries the digest of the whole file, not the range
+	req := httptest.NewRequest("GET", snapshotPath, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Range", "bytes=100-")
+	partial := httptest.NewRecorder()
+	srv.routes().ServeHTTP(partial, req)
+	if partial.Code != http.StatusPartialContent {
+		t.Fatalf("range: expected 206, got %d", partial.Code)
+	}
+	if got := partial.Header().Get("X-Snapshot-SHA256"); got != want {
+		t.Fatalf("206 X-Snapshot-SHA256 = %q, want full-file digest %q", got, want)
+	}
+}
+
+func TestSnapshotConditionalRequest(t *testing.T) {
+	srv, store := newTestServer(t)
+	_, token := createTestUser(t, store, "snap-etag@test.com")
+
+	w := doRequest(srv, "POST", "/v1/projects", token, CreateProjectRequest{Name: "snap-etag"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: expected 201, got %d", w.Code)
+	}
+	var project ProjectResponse
+	_ = json.NewDecoder(w.Body).Decode(&project)
+
+	push := func(actionID int64) {
+		t.Helper()
+		w := doRequest(srv, "POST", fmt.Sprintf("/v1/projects/%s/sync/push", project.ID), token, PushRequest{
+			DeviceID: "dev1", SessionID: "sess1",
+			Events: []EventInput{
+				{ClientActionID: actionID, ActionType: "create", EntityType: "issues", EntityID: fmt.Sprintf("i_%03d", actionID),
+					Payload: json.RawMessage(`{"schema_version":1,"new_data":{"title":"etag-test","status":"open"}}`), ClientTimestamp: "2025-01-01T00:00:00Z"},
+			},
+		})
+		if w.Code != http.StatusOK {
+			t.Fatalf("push: expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+	}
+	snapshotPath := fmt.Sprintf("/v1/projects/%s/sync/snapshot", project.ID)
+	getSnapshot := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", snapshotPath, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		srv.routes().ServeHTTP(w, req)
+		return w
+	}
+
+	push(1)
+	first := getSnapshot("")
+	if first.Code != http.StatusOK {
+		t.Fatalf("snapshot: expected 200, got %d: %s", first.Code, first.Body.String())
+	}
+	etag := first.Header().Get("ETag")
+	if !strings.HasPrefix(etag, `"`+first.Header().Get("X-Snapshot-Seq")+`-`) {
+		t.Fatalf("ETag = %q, want X-Snapshot-Seq %q plus a settings digest", etag, first.Header().Get("X-Snapshot-Seq"))
+	}
+
+	// Matching tag: nothing to download
+	notModified := getSnapshot(etag)
+	if notModified.Code != http.StatusNotModified {
+		t.Fatalf("matching If-None-Match: expected 304, got %d", notModified.Code)
+	}
+	if notModified.Body.Len() != 0 {
+		t.Fatalf("304 response should have no body, got %d bytes", notModified.Body.Len())
+	}
+	if got := notModified.Header().Get("ETag"); got != etag {
+		t.Fatalf("304 ETag = %q, want %q", got, etag)
+	}
+
+	// Different tag: full download
+	if w := getSnapshot(`"999999"`); w.Code != http.StatusOK {
+		t.Fatalf("non-matching If-None-Match: expected 200, got %d", w.Code)
+	}
+
+	// New events make the old tag stale
+	push(2)
+	updated := getSnapshot(etag)
+	if updated.Code != http.StatusOK {
+		t.Fatalf("stale If-None-Match: expected 200, got %d", updated.Code)
+	}
+	if updated.Header().Get("ETag") == etag {
+		t.Fatalf("ETag did not change after new events: %q", etag)
+	}
+
+	// So does a settings change, even though the seq stays the same
+	etag = updated.Header().Get("ETag")
+	w = doRequest(srv, "PUT", fmt.Sprintf("/v1/projects/%s/settings", project.ID), token, json.RawMessage(`{"require_close_reason":true}`))
+	if w.Code != http.StatusOK {
+		t.Fatalf("put settings: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	changed := getSnapshot(etag)
+	if changed.Code != http.StatusOK {
+		t.Fatalf("If-None-Match after settings change: expected 200, got %d", changed.Code)
+	}
+	if changed.Header().Get("ETag") == etag || changed.Header().Get("X-Snapshot-Seq") != updated.Header().Get("X-Snapshot-Seq") {
+		t.Fatalf("after settings change: ETag %q (was %q), seq %q", changed.Header().Get("ETag"), etag, changed.Header().Get("X-Snapshot-Seq"))
 	}
 }
 
@@ -1535,6 +2082,126 @@ func TestSyncStatus(t *testing.T) {
 	}
 }
 
+func TestSyncStatusEntityTypeBreakdown(t *testing.T) {
+	srv, store := newTestServer(t)
+	_, token := createTestUser(t, store, "status-types@test.com")
+
+	w := doRequest(srv, "POST", "/v1/projects", token, CreateProjectRequest{Name: "status-types"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: expected 201, got %d", w.Code)
+	}
+	var project ProjectResponse
+	_ = json.NewDecoder(w.Body).Decode(&project)
+
+	w = doRequest(srv, "POST", fmt.Sprintf("/v1/projects/%s/sync/push", project.ID), token, PushRequest{
+		DeviceID: "dev1", SessionID: "sess1",
+		Events: []EventInput{
+			{ClientActionID: 1, ActionType: "create", EntityType: "issues", EntityID: "i_001", Payload: json.RawMessage(`{}`), ClientTimestamp: "2025-01-01T00:00:00Z"},
+			{ClientActionID: 2, ActionType: "create", EntityType: "issues", EntityID: "i_002", Payload: json.RawMessage(`{}`), ClientTimestamp: "2025-01-01T00:00:01Z"},
+			{ClientActionID: 3, ActionType: "create", EntityType: "logs", EntityID: "l_001", Payload: json.RawMessage(`{}`), ClientTimestamp: "2025-01-01T00:00:02Z"},
+			{ClientActionID: 4, ActionType: "create", EntityType: "boards", EntityID: "b_001", Payload: json.RawMessage(`{}`), ClientTimestamp: "2025-01-01T00:00:03Z"},
+			{ClientActionID: 5, ActionType: "update", EntityType: "issues", EntityID: "i_001", Payload: json.RawMessage(`{}`), ClientTimestamp: "2025-01-01T00:00:04Z"},
+			{ClientActionID: 6, ActionType: "create", EntityType: "comments", EntityID: "c_001", Payload: json.RawMessage(`{}`), ClientTimestamp: "2025-01-01T00:00:05Z"},
+		},
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("push: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var push PushResponse
+	_ = json.NewDecoder(w.Body).Decode(&push)
+	seqOf := make(map[int64]int64)
+	for _, a := range push.Acks {
+		seqOf[a.ClientActionID] = a.ServerSeq
+	}
+
+	w = doRequest(srv, "GET", fmt.Sprintf("/v1/projects/%s/sync/status", project.ID), token, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status: expected 200, got %d", w.Code)
+	}
+	var status SyncStatusResponse
+	_ = json.NewDecoder(w.Body).Decode(&status)
+
+	want := map[string]EntityTypeStatus{
+		"issues":   {EventCount: 3, LastServerSeq: seqOf[5]},
+		"logs":     {EventCount: 1, LastServerSeq: seqOf[3]},
+		"boards":   {EventCount: 1, LastServerSeq: seqOf[4]},
+		"comments": {EventCount: 1, LastServerSeq: seqOf[6]},
+	}
+	if len(status.EntityTypes) != len(want) {
+		t.Fatalf("entity_types = %v, want %v", status.EntityTypes, want)
+	}
+	for et, st := range want {
+		if got := status.EntityTypes[et]; got != st {
+			t.Errorf("entity_types[%s] = %+v, want %+v", et, got, st)
+		}
+	}
+}
+
+func TestSyncStatusDeviceBehindBy(t *testing.T) {
+	srv, store := newTestServer(t)
+	userID, token := createTestUser(t, store, "status-behind@test.com")
+
+	w := doRequest(srv, "POST", "/v1/projects", token, CreateProjectRequest{Name: "status-behind"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: expected 201, got %d", w.Code)
+	}
+	var project ProjectResponse
+	_ = json.NewDecoder(w.Body).Decode(&project)
+
+	// dev1 pushes 5 events
+	var events []EventInput
+	for i := int64(1); i <= 5; i++ {
+		events = append(events, EventInput{ClientActionID: i, ActionType: "create", EntityType: "issues",
+			EntityID: fmt.Sprintf("i_%03d", i), Payload: json.RawMessage(`{}`), ClientTimestamp: "2025-01-01T00:00:00Z"})
+	}
+	w = doRequest(srv, "POST", fmt.Sprintf("/v1/projects/%s/sync/push", project.ID), token, PushRequest{
+		DeviceID: "dev1", SessionID: "sess1", Events: events,
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("push: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// dev2 pulls only the first 2
+	w = doRequest(srv, "GET", fmt.Sprintf("/v1/projects/%s/sync/pull?exclude_client=dev2&limit=2", project.ID), token, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("pull: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = doRequest(srv, "GET", fmt.Sprintf("/v1/projects/%s/sync/status", project.ID), token, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status: expected 200, got %d", w.Code)
+	}
+	var status SyncStatusResponse
+	_ = json.NewDecoder(w.Body).Decode(&status)
+
+	// dev1 only pushed, so it has no pull position to report
+	if len(status.Devices) != 1 {
+		t.Fatalf("devices = %+v, want only dev2", status.Devices)
+	}
+	dev := status.Devices[0]
+	if dev.DeviceID != "dev2" || dev.UserID != userID {
+		t.Errorf("device = %s/%s, want dev2/%s", dev.DeviceID, dev.UserID, userID)
+	}
+	if dev.LastPulledSeq != 2 || dev.BehindBy != 3 {
+		t.Errorf("last_pulled_seq=%d behind_by=%d, want 2 and 3", dev.LastPulledSeq, dev.BehindBy)
+	}
+	if dev.LastPulledAt == "" {
+		t.Error("last_pulled_at not set")
+	}
+
+	// Pulling the rest catches dev2 up
+	w = doRequest(srv, "GET", fmt.Sprintf("/v1/projects/%s/sync/pull?exclude_client=dev2&after_server_seq=2", project.ID), token, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("pull: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	w = doRequest(srv, "GET", fmt.Sprintf("/v1/projects/%s/sync/status", project.ID), token, nil)
+	status = SyncStatusResponse{}
+	_ = json.NewDecoder(w.Body).Decode(&status)
+	if len(status.Devices) != 1 || status.Devices[0].BehindBy != 0 {
+		t.Errorf("after full pull devices = %+v, want dev2 behind by 0", status.Devices)
+	}
+}
+
 func TestPushRejectsOversizedBatch(t *testing.T) {
 	srv, store := newTestServer(t)
 	_, token := createTestUser(t, store, "oversize@test.com")
@@ -1582,6 +2249,120 @@ func TestPushRejectsOversizedBatch(t *testing.T) {
 	}
 }
 
+func TestPushRejectsOversizedAndNonObjectPayloads(t *testing.T) {
+	srv, store := newTestServerWithConfig(t, func(cfg *Config) {
+		cfg.MaxEventPayloadBytes = 64
+	})
+	_, token := createTestUser(t, store, "payload@test.com")
+
+	w := doRequest(srv, "POST", "/v1/projects", token, CreateProjectRequest{Name: "payload-test"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create project: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var project ProjectResponse
+	_ = json.NewDecoder(w.Body).Decode(&project)
+
+	event := func(id int64, payload string) EventInput {
+		return EventInput{
+			ClientActionID:  id,
+			ActionType:      "create",
+			EntityType:      "issues",
+			EntityID:        fmt.Sprintf("i_payload_%d", id),
+			Payload:         json.RawMessage(payload),
+			ClientTimestamp: "2025-01-01T00:00:00Z",
+		}
+	}
+	w = doRequest(srv, "POST", fmt.Sprintf("/v1/projects/%s/sync/push", project.ID), token, PushRequest{
+		DeviceID:  "dev-payload",
+		SessionID: "sess-payload",
+		Events: []EventInput{
+			event(1, `{"title":"ok"}`),
+			event(2, `{"title":"`+strings.Repeat("x", 100)+`"}`),
+			event(3, `"{\"title\": \"a string, not an object\"}"`),
+			event(4, `{"title":"also ok"}`),
+		},
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("push: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp PushResponse
+	_ = json.NewDecoder(w.Body).Decode(&resp)
+	if resp.Accepted != 2 || len(resp.Acks) != 2 {
+		t.Fatalf("expected 2 accepted, got %+v", resp)
+	}
+	if resp.Acks[0].ClientActionID != 1 || resp.Acks[1].ClientActionID != 4 {
+		t.Errorf("acks = %+v, want actions 1 and 4", resp.Acks)
+	}
+	reasons := map[int64]string{}
+	for _, rj := range resp.Rejected {
+		reasons[rj.ClientActionID] = rj.Reason
+	}
+	if len(reasons) != 2 {
+		t.Fatalf("rejected = %+v, want actions 2 and 3", resp.Rejected)
+	}
+	if !strings.Contains(reasons[2], "payload too large") || !strings.Contains(reasons[2], "max 64") {
+		t.Errorf("oversized reason = %q", reasons[2])
+	}
+	if !strings.Contains(reasons[3], "expected a JSON object") {
+		t.Errorf("non-object reason = %q", reasons[3])
+	}
+}
+
+func TestPushRejectsClockSkewedTimestamps(t *testing.T) {
+	srv, store := newTestServer(t)
+	_, token := createTestUser(t, store, "skew@test.com")
+
+	w := doRequest(srv, "POST", "/v1/projects", token, CreateProjectRequest{Name: "skew-test"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create project: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var project ProjectResponse
+	_ = json.NewDecoder(w.Body).Decode(&project)
+
+	event := func(id int64, ts time.Time) EventInput {
+		return EventInput{
+			ClientActionID:  id,
+			ActionType:      "create",
+			EntityType:      "issues",
+			EntityID:        fmt.Sprintf("i_skew_%d", id),
+			Payload:         json.RawMessage(`{"title":"skew"}`),
+			ClientTimestamp: ts.UTC().Format(time.RFC3339),
+		}
+	}
+	now := time.Now()
+	w = doRequest(srv, "POST", fmt.Sprintf("/v1/projects/%s/sync/push", project.ID), token, PushRequest{
+		DeviceID:  "dev-skew",
+		SessionID: "sess-skew",
+		Events: []EventInput{
+			event(1, now.AddDate(1, 0, 0)),       // a year ahead
+			event(2, now.AddDate(-1, 0, 0)),      // a year behind is fine
+			event(3, now.Add(time.Minute)),       // within the skew window
+			event(4, now.Add(defMaxClockSkew*2)), // just past it
+		},
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("push: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp PushResponse
+	_ = json.NewDecoder(w.Body).Decode(&resp)
+	if resp.Accepted != 2 || len(resp.Acks) != 2 {
+		t.Fatalf("expected 2 accepted, got %+v", resp)
+	}
+	if resp.Acks[0].ClientActionID != 2 || resp.Acks[1].ClientActionID != 3 {
+		t.Errorf("acks = %+v, want actions 2 and 3", resp.Acks)
+	}
+	if len(resp.Rejected) != 2 {
+		t.Fatalf("rejected = %+v, want actions 1 and 4", resp.Rejected)
+	}
+	for _, rj := range resp.Rejected {
+		if rj.Reason != "clock_skew" {
+			t.Errorf("action %d reason = %q, want clock_skew", rj.ClientActionID, rj.Reason)
+		}
+	}
+}
+
 func TestPushBatchedClientSimulation(t *testing.T) {
 	// Simulates client-side batching: 1500 events pushed in 3 batches of 500.
 	// Verifies acks accumulate correctly and all events are pullable.
@@ -1599,7 +2380,7 @@ func TestPushBatchedClientSimulation(t *testing.T) {
 	pullURL := fmt.Sprintf("/v1/projects/%s/sync/pull", project.ID)
 
 	totalEvents := 1500
-	batchSize := 500 // matches pushBatchSize in cmd/sync.go
+	batchSize := 500 // matches syncclient.DefaultPushBatchSize
 
 	// Build all events
 	allEvents := make([]EventInput, totalEvents)
@@ -1691,3 +2472,141 @@ func TestPushBatchedClientSimulation(t *testing.T) {
 		t.Fatalf("expected %d pulled events, got %d", totalEvents, len(allPulled))
 	}
 }
+
+func TestBatchPushPartialAccess(t *testing.T) {
+	srv, store := newTestServer(t)
+	_, token := createTestUser(t, store, "batch-push@test.com")
+	_, otherToken := createTestUser(t, store, "batch-push-other@test.com")
+
+	createProject := func(token, name string) ProjectResponse {
+		t.Helper()
+		w := doRequest(srv, "POST", "/v1/projects", token, CreateProjectRequest{Name: name})
+		if w.Code != http.StatusCreated {
+			t.Fatalf("create project: expected 201, got %d: %s", w.Code, w.Body.String())
+		}
+		var project ProjectResponse
+		_ = json.NewDecoder(w.Body).Decode(&project)
+		return project
+	}
+	own := createProject(token, "batch-own")
+	foreign := createProject(otherToken, "batch-foreign")
+
+	event := EventInput{
+		ClientActionID:  1,
+		ActionType:      "create",
+		EntityType:      "issues",
+		EntityID:        "i_001",
+		Payload:         json.RawMessage(`{"title":"test"}`),
+		ClientTimestamp: "2025-01-01T00:00:00Z",
+	}
+	body := BatchPushRequest{
+		DeviceID:  "dev1",
+		SessionID: "sess1",
+		Projects: []BatchPushProject{
+			{ProjectID: own.ID, Events: []EventInput{event}},
+			{ProjectID: foreign.ID, Events: []EventInput{event}},
+		},
+	}
+
+	w := doRequest(srv, "POST", "/v1/sync/push", token, body)
+	if w.Code != http.StatusMultiStatus {
+		t.Fatalf("expected 207, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp BatchPushResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp.Results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(resp.Results))
+	}
+
+	ok := resp.Results[0]
+	if ok.ProjectID != own.ID || ok.Status != http.StatusOK || ok.PushResponse == nil || ok.Accepted != 1 || len(ok.Acks) != 1 {
+		t.Fatalf("own project result mismatch: %+v", ok)
+	}
+	denied := resp.Results[1]
+	if denied.ProjectID != foreign.ID || denied.Status != http.StatusForbidden || denied.Error == nil || denied.PushResponse != nil {
+		t.Fatalf("foreign project result mismatch: %+v", denied)
+	}
+
+	// Re-pushing the same event is reported as a duplicate, as with the
+	// single-project endpoint.
+	body.Projects = body.Projects[:1]
+	w = doRequest(srv, "POST", "/v1/sync/push", token, body)
+	if w.Code != http.StatusOK {
+		t.Fatalf("repush: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	resp = BatchPushResponse{}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode repush: %v", err)
+	}
+	dup := resp.Results[0]
+	if dup.Accepted != 0 || len(dup.Rejected) != 1 || dup.Rejected[0].Reason != "duplicate" {
+		t.Fatalf("repush result mismatch: %+v", dup.PushResponse)
+	}
+}
+
+func TestPullBelowRetentionBoundaryGone(t *testing.T) {
+	srv, store := newTestServer(t)
+	_, token := createTestUser(t, store, "pull-gone@test.com")
+
+	w := doRequest(srv, "POST", "/v1/projects", token, CreateProjectRequest{Name: "pull-gone"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create project: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var project ProjectResponse
+	_ = json.NewDecoder(w.Body).Decode(&project)
+
+	pushBody := PushRequest{DeviceID: "dev1", SessionID: "sess1"}
+	for i := int64(1); i <= 4; i++ {
+		pushBody.Events = append(pushBody.Events, EventInput{
+			ClientActionID:  i,
+			ActionType:      "create",
+			EntityType:      "issues",
+			EntityID:        fmt.Sprintf("i_%03d", i),
+			Payload:         json.RawMessage(`{"title":"test"}`),
+			ClientTimestamp: "2025-01-01T00:00:00Z",
+		})
+	}
+	w = doRequest(srv, "POST", fmt.Sprintf("/v1/projects/%s/sync/push", project.ID), token, pushBody)
+	if w.Code != http.StatusOK {
+		t.Fatalf("push: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	db, err := srv.dbPool.Get(project.ID)
+	if err != nil {
+		t.Fatalf("get project db: %v", err)
+	}
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	if _, err := tdsync.PruneEventsThrough(tx, 2); err != nil {
+		t.Fatalf("prune: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+
+	// A cursor inside the pruned range must re-bootstrap
+	w = doRequest(srv, "GET", fmt.Sprintf("/v1/projects/%s/sync/pull?after_server_seq=1", project.ID), token, nil)
+	if w.Code != http.StatusGone {
+		t.Fatalf("stale cursor: expected 410, got %d: %s", w.Code, w.Body.String())
+	}
+	var errResp ErrorResponse
+	_ = json.NewDecoder(w.Body).Decode(&errResp)
+	if errResp.Error.Code != ErrCodeCursorExpired || !strings.Contains(errResp.Error.Message, "/sync/snapshot") {
+		t.Fatalf("stale cursor error mismatch: %+v", errResp.Error)
+	}
+
+	// A cursor at the boundary still pulls incrementally
+	w = doRequest(srv, "GET", fmt.Sprintf("/v1/projects/%s/sync/pull?after_server_seq=2", project.ID), token, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("boundary cursor: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var pullResp PullResponse
+	_ = json.NewDecoder(w.Body).Decode(&pullResp)
+	if len(pullResp.Events) != 2 || pullResp.Events[0].ServerSeq != 3 {
+		t.Fatalf("boundary cursor events mismatch: %+v", pullResp.Events)
+	}
+}
@@ -29,7 +29,7 @@ var _ query.QuerySource = (*SnapshotQuerySource)(nil)
 const issueColumns = `id, title, description, status, type, priority, points, labels, parent_id, acceptance, sprint,
        implementer_session, creator_session, reviewer_session, review_requested_by_session, closed_by_session,
        created_at, updated_at, reviewed_at, closed_at, deleted_at, minor, created_branch,
-       defer_until, due_date, defer_count`
+       defer_until, due_date, defer_count, created_by`
 
 // scanIssue scans a single issue row using the standard column order.
 func scanIssue(scanner interface{ Scan(dest ...any) error }) (models.Issue, error) {
@@ -38,7 +38,7 @@ func scanIssue(scanner interface{ Scan(dest ...any) error }) (models.Issue, erro
 	var description, labels sql.NullString
 	var closedAt, deletedAt, reviewedAt sql.NullTime
 	var parentID, acceptance, sprint sql.NullString
-	var implSession, creatorSession, reviewerSession sql.NullString
+	var implSession, creatorSession, createdBy, reviewerSession sql.NullString
 	var reviewRequestedBy, closedBy sql.NullString
 	var createdBranch sql.NullString
 	var pointsNull sql.NullInt64
@@ -49,7 +49,7 @@ func scanIssue(scanner interface{ Scan(dest ...any) error }) (models.Issue, erro
 		&pointsNull, &labels, &parentID, &acceptance, &sprint,
 		&implSession, &creatorSession, &reviewerSession, &reviewRequestedBy, &closedBy,
 		&issue.CreatedAt, &issue.UpdatedAt, &reviewedAt, &closedAt, &deletedAt, &issue.Minor, &createdBranch,
-		&deferUntil, &dueDate, &issue.DeferCount,
+		&deferUntil, &dueDate, &issue.DeferCount, &createdBy,
 	)
 	if err != nil {
 		return issue, err
@@ -74,6 +74,7 @@ func scanIssue(scanner interface{ Scan(dest ...any) error }) (models.Issue, erro
 	issue.Sprint = sprint.String
 	issue.ImplementerSession = implSession.String
 	issue.CreatorSession = creatorSession.String
+	issue.CreatedBy = createdBy.String
 	issue.ReviewerSession = reviewerSession.String
 	issue.ReviewRequestedBySession = reviewRequestedBy.String
 	issue.ClosedBySession = closedBy.String
@@ -460,8 +461,34 @@ func (s *SnapshotQuerySource) GetDependencies(issueID string) ([]string, error)
 	return deps, nil
 }
 
+// GetRelated returns IDs of issues linked to issueID by a relates_to
+// relation, in either direction.
+func (s *SnapshotQuerySource) GetRelated(issueID string) ([]string, error) {
+	rows, err := s.db.Query(`
+		SELECT depends_on_id FROM issue_dependencies WHERE issue_id = ? AND relation_type = 'relates_to'
+		UNION
+		SELECT issue_id FROM issue_dependencies WHERE depends_on_id = ? AND relation_type = 'relates_to'
+		ORDER BY 1
+	`, issueID, issueID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var related []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		related = append(related, id)
+	}
+	return related, nil
+}
+
 // GetRejectedInProgressIssueIDs returns IDs of open or in_progress issues that have a
-// recent reject action without a subsequent review action (needs rework).
+// reject action with no later review or approval (needs rework). Undone actions
+// are ignored, matching db.GetRejectedInProgressIssueIDs.
 // Rejected issues are reset to open; they may then be picked up (in_progress).
 func (s *SnapshotQuerySource) GetRejectedInProgressIssueIDs() (map[string]bool, error) {
 	rows, err := s.db.Query(`
@@ -472,7 +499,9 @@ func (s *SnapshotQuerySource) GetRejectedInProgressIssueIDs() (map[string]bool,
 			WHERE al.entity_id = i.id AND al.action_type = 'reject' AND al.undone = 0
 			  AND NOT EXISTS (
 				SELECT 1 FROM action_log al2
-				WHERE al2.entity_id = i.id AND al2.action_type = 'review'
+				WHERE al2.entity_id = i.id
+				  AND al2.action_type IN ('review', 'approve', 'review_approve', 'close_after_review')
+				  AND al2.undone = 0
 				  AND al2.timestamp > al.timestamp
 			  )
 		  )
@@ -519,6 +548,92 @@ func (s *SnapshotQuerySource) GetIssuesWithOpenDeps() (map[string]bool, error) {
 	return result, nil
 }
 
+// GetIssuesWithComments returns issue IDs that have at least one comment.
+func (s *SnapshotQuerySource) GetIssuesWithComments() (map[string]bool, error) {
+	return s.issueIDsWithRowsIn("comments")
+}
+
+// GetIssuesWithHandoffs returns issue IDs that have at least one handoff.
+func (s *SnapshotQuerySource) GetIssuesWithHandoffs() (map[string]bool, error) {
+	return s.issueIDsWithRowsIn("handoffs")
+}
+
+// GetIssuesWithFiles returns issue IDs that have at least one linked file.
+func (s *SnapshotQuerySource) GetIssuesWithFiles() (map[string]bool, error) {
+	return s.issueIDsWithRowsIn("issue_files")
+}
+
+// GetIssuesUpdatedBySession returns non-deleted issue IDs with at least one
+// action_log entry by sessionID, undone actions excluded.
+func (s *SnapshotQuerySource) GetIssuesUpdatedBySession(sessionID string) (map[string]bool, error) {
+	rows, err := s.db.Query(`
+		SELECT i.id FROM issues i
+		WHERE i.deleted_at IS NULL
+		  AND EXISTS (
+			SELECT 1 FROM action_log al
+			WHERE al.entity_id = i.id AND al.session_id = ? AND al.undone = 0
+		  )
+	`, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[string]bool)
+	for rows.Next() {
+		var issueID string
+		if err := rows.Scan(&issueID); err != nil {
+			return nil, err
+		}
+		result[issueID] = true
+	}
+	return result, nil
+}
+
+// issueIDsWithRowsIn returns non-deleted issue IDs referenced by at least one
+// row of table. table must be a trusted constant, never user input.
+func (s *SnapshotQuerySource) issueIDsWithRowsIn(table string) (map[string]bool, error) {
+	rows, err := s.db.Query(fmt.Sprintf(`
+		SELECT i.id FROM issues i
+		WHERE i.deleted_at IS NULL
+		  AND EXISTS (SELECT 1 FROM %s t WHERE t.issue_id = i.id)
+	`, table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[string]bool)
+	for rows.Next() {
+		var issueID string
+		if err := rows.Scan(&issueID); err != nil {
+			return nil, err
+		}
+		result[issueID] = true
+	}
+	return result, nil
+}
+
+// GetParentIDs returns parent_id keyed by issue ID for every non-deleted
+// issue that has a parent.
+func (s *SnapshotQuerySource) GetParentIDs() (map[string]string, error) {
+	rows, err := s.db.Query(`SELECT id, parent_id FROM issues WHERE parent_id IS NOT NULL AND parent_id != '' AND deleted_at IS NULL`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	parents := make(map[string]string)
+	for rows.Next() {
+		var id, parentID string
+		if err := rows.Scan(&id, &parentID); err != nil {
+			return nil, err
+		}
+		parents[id] = parentID
+	}
+	return parents, rows.Err()
+}
+
 // getDescendants returns all descendant issue IDs of a parent (BFS).
 func (s *SnapshotQuerySource) getDescendants(parentID string) ([]string, error) {
 	var descendants []string
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/models"
+)
+
+// TestSnapshotReworkParity asserts that the snapshot-backed rework() lookup
+// returns the same issues as the live DB, covering the approval and undo
+// cases where the two queries previously disagreed.
+func TestSnapshotReworkParity(t *testing.T) {
+	dir := t.TempDir()
+	database, err := db.Initialize(dir)
+	if err != nil {
+		t.Fatalf("db init: %v", err)
+	}
+
+	seed := func(title string) string {
+		iss := &models.Issue{Title: title, Type: models.TypeTask, Status: models.StatusOpen}
+		if err := database.CreateIssue(iss); err != nil {
+			t.Fatalf("create %s: %v", title, err)
+		}
+		return iss.ID
+	}
+	logAction := func(action models.ActionType, issueID string) *models.ActionLog {
+		entry := &models.ActionLog{SessionID: "ses-reviewer", ActionType: action, EntityType: "issue", EntityID: issueID}
+		if err := database.LogAction(entry); err != nil {
+			t.Fatalf("log %s on %s: %v", action, issueID, err)
+		}
+		return entry
+	}
+
+	// A — rejected, nothing since (rework)
+	// B — rejected, resubmitted (not rework)
+	// C — rejected, then approved (not rework)
+	// D — rejected, resubmit undone (rework)
+	aID := seed("A rejected")
+	bID := seed("B resubmitted")
+	cID := seed("C approved")
+	dID := seed("D resubmit undone")
+	for _, id := range []string{aID, bID, cID, dID} {
+		logAction(models.ActionReject, id)
+	}
+	logAction(models.ActionReview, bID)
+	logAction(models.ActionApprove, cID)
+	undone := logAction(models.ActionReview, dID)
+	if err := database.MarkActionUndone(undone.ID); err != nil {
+		t.Fatalf("mark undone: %v", err)
+	}
+
+	live, err := database.GetRejectedInProgressIssueIDs()
+	if err != nil {
+		t.Fatalf("live rework: %v", err)
+	}
+	want := map[string]bool{aID: true, dID: true}
+	if !equalSets(live, want) {
+		t.Fatalf("live rework = %v, want %v", sortedKeys(live), sortedKeys(want))
+	}
+
+	// Flush WAL so the snapshot connection sees the seeded rows.
+	if err := database.Close(); err != nil {
+		t.Fatalf("close live db: %v", err)
+	}
+
+	sqlDB, err := db.OpenSQLite(filepath.Join(dir, ".todos", "issues.db"), db.OpenOptions{})
+	if err != nil {
+		t.Fatalf("open snapshot sql.DB: %v", err)
+	}
+	defer sqlDB.Close()
+
+	snap, err := NewSnapshotQuerySource(sqlDB).GetRejectedInProgressIssueIDs()
+	if err != nil {
+		t.Fatalf("snapshot rework: %v", err)
+	}
+	if !equalSets(live, snap) {
+		t.Fatalf("snapshot/live rework mismatch:\n  live=%v\n  snap=%v", sortedKeys(live), sortedKeys(snap))
+	}
+}
@@ -0,0 +1,166 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defSnapshotURLTTL is how long a signed snapshot URL stays valid.
+const defSnapshotURLTTL = 5 * time.Minute
+
+var (
+	errSnapshotURLInvalid = errors.New("invalid snapshot signature")
+	errSnapshotURLExpired = errors.New("snapshot URL expired")
+)
+
+// SnapshotURLResponse is the response for POST /v1/projects/{id}/sync/snapshot/url.
+type SnapshotURLResponse struct {
+	URL         string `json:"url"`
+	ExpiresAt   string `json:"expires_at"`
+	SnapshotSeq int64  `json:"snapshot_seq"`
+}
+
+// newSnapshotURLKey returns the HMAC key for signed snapshot URLs. Without
+// a configured secret a random key is used, so URLs only verify against the
+// process that issued them.
+func newSnapshotURLKey(secret string) ([]byte, error) {
+	if secret != "" {
+		return []byte(secret), nil
+	}
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("generate snapshot url key: %w", err)
+	}
+	return key, nil
+}
+
+// signSnapshot returns the signature authorizing a download of the
+// snapshot built at seq until the unix time expires.
+func signSnapshot(key []byte, projectID string, seq, expires int64) string {
+	mac := hmac.New(sha256.New, key)
+	fmt.Fprintf(mac, "%s\n%d\n%d", projectID, seq, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// verifySnapshotSignature checks sig against the signed fields and that
+// the URL has not expired at now.
+func verifySnapshotSignature(key []byte, projectID string, seq, expires int64, sig string, now time.Time) error {
+	got, err := hex.DecodeString(sig)
+	if err != nil {
+		return errSnapshotURLInvalid
+	}
+	want, _ := hex.DecodeString(signSnapshot(key, projectID, seq, expires))
+	if !hmac.Equal(got, want) {
+		return errSnapshotURLInvalid
+	}
+	if now.Unix() >= expires {
+		return errSnapshotURLExpired
+	}
+	return nil
+}
+
+// handleSnapshotURL handles POST /v1/projects/{id}/sync/snapshot/url.
+// Builds (or reuses) the cached snapshot for the latest seq and returns a
+// short-lived signed URL for it that needs no bearer token, so the download
+// can go through a CDN.
+func (s *Server) handleSnapshotURL(w http.ResponseWriter, r *http.Request) {
+	projectID := r.PathValue("id")
+
+	eventsDB, err := s.dbPool.Get(projectID)
+	if err != nil {
+		logFor(r.Context()).Error("get project db", "project", projectID, "err", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "failed to open project database")
+		return
+	}
+
+	var lastSeq int64
+	if err := eventsDB.QueryRow(`SELECT COALESCE(MAX(server_seq), 0) FROM events`).Scan(&lastSeq); err != nil {
+		logFor(r.Context()).Error("query max seq", "err", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "database error")
+		return
+	}
+	if lastSeq == 0 {
+		writeError(w, http.StatusNotFound, "no_events", "no events to snapshot")
+		return
+	}
+
+	// The signed handler only serves cached snapshots, so build this one first
+	if _, _, err := s.snapshotFile(projectID, eventsDB, lastSeq); err != nil {
+		logFor(r.Context()).Error("build snapshot", "project", projectID, "err", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "failed to build snapshot")
+		return
+	}
+
+	ttl := s.config.SnapshotURLTTL
+	if ttl <= 0 {
+		ttl = defSnapshotURLTTL
+	}
+	expiresAt := time.Now().Add(ttl)
+	expires := expiresAt.Unix()
+
+	base := s.config.SnapshotURLBase
+	if base == "" {
+		base = s.config.BaseURL
+	}
+	q := url.Values{}
+	q.Set("expires", strconv.FormatInt(expires, 10))
+	q.Set("sig", signSnapshot(s.snapshotURLKey, projectID, lastSeq, expires))
+	u := fmt.Sprintf("%s/v1/snapshots/%s/%d?%s", strings.TrimSuffix(base, "/"), url.PathEscape(projectID), lastSeq, q.Encode())
+
+	writeJSON(w, http.StatusOK, SnapshotURLResponse{
+		URL:         u,
+		ExpiresAt:   expiresAt.UTC().Format(time.RFC3339),
+		SnapshotSeq: lastSeq,
+	})
+}
+
+// handleSignedSnapshot handles GET /v1/snapshots/{id}/{seq}. It takes no
+// bearer token: the expires and sig query parameters issued by
+// handleSnapshotURL authorize the download.
+func (s *Server) handleSignedSnapshot(w http.ResponseWriter, r *http.Request) {
+	projectID := r.PathValue("id")
+	seq, err := strconv.ParseInt(r.PathValue("seq"), 10, 64)
+	if err != nil || seq <= 0 {
+		writeError(w, http.StatusBadRequest, "bad_request", "invalid snapshot seq")
+		return
+	}
+	expires, err := strconv.ParseInt(r.URL.Query().Get("expires"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusForbidden, "invalid_signature", errSnapshotURLInvalid.Error())
+		return
+	}
+
+	switch err := verifySnapshotSignature(s.snapshotURLKey, projectID, seq, expires, r.URL.Query().Get("sig"), time.Now()); {
+	case errors.Is(err, errSnapshotURLExpired):
+		writeError(w, http.StatusGone, "expired", err.Error())
+		return
+	case err != nil:
+		writeError(w, http.StatusForbidden, "invalid_signature", err.Error())
+		return
+	}
+
+	// Newer pushes and settings changes evict older cached snapshots; the
+	// client asks for a new URL
+	_, key, err := s.snapshotSettings(projectID, seq)
+	if err != nil {
+		logFor(r.Context()).Error("get project settings", "project", projectID, "err", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "database error")
+		return
+	}
+	path := s.snapshotCachePath(projectID, key)
+	if _, err := os.Stat(path); err != nil {
+		writeError(w, http.StatusNotFound, "not_found", "snapshot is no longer cached; request a new URL")
+		return
+	}
+	serveSnapshotFile(w, r, path, seq, key)
+}
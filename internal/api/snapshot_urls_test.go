@@ -0,0 +1,152 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSignSnapshotVerifies(t *testing.T) {
+	key := []byte("secret")
+	now := time.Unix(1_700_000_000, 0)
+	expires := now.Add(time.Minute).Unix()
+	sig := signSnapshot(key, "p_1", 42, expires)
+
+	if err := verifySnapshotSignature(key, "p_1", 42, expires, sig, now); err != nil {
+		t.Fatalf("valid signature rejected: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		key     []byte
+		project string
+		seq     int64
+		expires int64
+		sig     string
+	}{
+		{"other key", []byte("other"), "p_1", 42, expires, sig},
+		{"other project", key, "p_2", 42, expires, sig},
+		{"other seq", key, "p_1", 43, expires, sig},
+		{"extended expiry", key, "p_1", 42, expires + 3600, sig},
+		{"not hex", key, "p_1", 42, expires, "zz"},
+		{"empty", key, "p_1", 42, expires, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifySnapshotSignature(tt.key, tt.project, tt.seq, tt.expires, tt.sig, now)
+			if err != errSnapshotURLInvalid {
+				t.Errorf("got %v, want %v", err, errSnapshotURLInvalid)
+			}
+		})
+	}
+}
+
+func TestSignSnapshotExpiry(t *testing.T) {
+	key := []byte("secret")
+	now := time.Unix(1_700_000_000, 0)
+	expires := now.Add(time.Minute).Unix()
+	sig := signSnapshot(key, "p_1", 1, expires)
+
+	if err := verifySnapshotSignature(key, "p_1", 1, expires, sig, now.Add(59*time.Second)); err != nil {
+		t.Fatalf("before expiry: %v", err)
+	}
+	if err := verifySnapshotSignature(key, "p_1", 1, expires, sig, now.Add(time.Minute)); err != errSnapshotURLExpired {
+		t.Fatalf("at expiry: got %v, want %v", err, errSnapshotURLExpired)
+	}
+}
+
+func TestSignedSnapshotDownload(t *testing.T) {
+	srv, store := newTestServerWithConfig(t, func(cfg *Config) {
+		cfg.BaseURL = "https://sync.example.com"
+		cfg.SnapshotURLBase = "https://cdn.example.com/"
+	})
+	_, token := createTestUser(t, store, "signed@test.com")
+
+	w := doRequest(srv, "POST", "/v1/projects", token, CreateProjectRequest{Name: "signed"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: expected 201, got %d", w.Code)
+	}
+	var project ProjectResponse
+	_ = json.NewDecoder(w.Body).Decode(&project)
+	urlPath := fmt.Sprintf("/v1/projects/%s/sync/snapshot/url", project.ID)
+
+	// No events, nothing to sign
+	w = doRequest(srv, "POST", urlPath, token, nil)
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("empty project: expected 404, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = doRequest(srv, "POST", fmt.Sprintf("/v1/projects/%s/sync/push", project.ID), token, PushRequest{
+		DeviceID: "dev1", SessionID: "sess1",
+		Events: []EventInput{
+			{ClientActionID: 1, ActionType: "create", EntityType: "issues", EntityID: "i_001",
+				Payload: json.RawMessage(`{"schema_version":1,"new_data":{"title":"one","status":"open"}}`), ClientTimestamp: "2025-01-01T00:00:00Z"},
+		},
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("push: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// Signing requires project access
+	if w = doRequest(srv, "POST", urlPath, "", nil); w.Code != http.StatusUnauthorized {
+		t.Fatalf("unauthenticated sign: expected 401, got %d", w.Code)
+	}
+
+	w = doRequest(srv, "POST", urlPath, token, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("sign: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp SnapshotURLResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !strings.HasPrefix(resp.URL, "https://cdn.example.com/v1/snapshots/"+project.ID+"/") {
+		t.Fatalf("url %q does not point at the CDN base", resp.URL)
+	}
+	if resp.SnapshotSeq < 1 || resp.ExpiresAt == "" {
+		t.Fatalf("response = %+v", resp)
+	}
+
+	signed, err := url.Parse(resp.URL)
+	if err != nil {
+		t.Fatalf("parse url: %v", err)
+	}
+
+	// Downloads without a bearer token
+	w = doRequest(srv, "GET", signed.RequestURI(), "", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("signed download: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("X-Snapshot-Seq"); got != strconv.FormatInt(resp.SnapshotSeq, 10) {
+		t.Fatalf("X-Snapshot-Seq = %q, want %d", got, resp.SnapshotSeq)
+	}
+	signedBody := w.Body.Bytes()
+
+	w = doRequest(srv, "GET", fmt.Sprintf("/v1/projects/%s/sync/snapshot", project.ID), token, nil)
+	if w.Code != http.StatusOK || string(w.Body.Bytes()) != string(signedBody) {
+		t.Fatalf("signed download differs from the authenticated snapshot")
+	}
+
+	// Tampered signature
+	q := signed.Query()
+	q.Set("sig", strings.Repeat("0", 64))
+	w = doRequest(srv, "GET", signed.Path+"?"+q.Encode(), "", nil)
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("tampered signature: expected 403, got %d", w.Code)
+	}
+
+	// A correctly signed but expired URL
+	expired := time.Now().Add(-time.Second).Unix()
+	q = url.Values{}
+	q.Set("expires", strconv.FormatInt(expired, 10))
+	q.Set("sig", signSnapshot(srv.snapshotURLKey, project.ID, resp.SnapshotSeq, expired))
+	w = doRequest(srv, "GET", signed.Path+"?"+q.Encode(), "", nil)
+	if w.Code != http.StatusGone {
+		t.Fatalf("expired url: expected 410, got %d: %s", w.Code, w.Body.String())
+	}
+}
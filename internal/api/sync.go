@@ -1,7 +1,10 @@
 package api
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -46,6 +49,9 @@ const (
 	maxPushBatch = 1000
 	maxPullLimit = 10000
 	defPullLimit = 1000
+
+	defMaxEventPayloadBytes = 1 << 20
+	defMaxClockSkew         = 10 * time.Minute
 )
 
 // PushResponse is the JSON response for a push request.
@@ -93,10 +99,36 @@ type SyncStatusResponse struct {
 	EventCount    int64  `json:"event_count"`
 	LastServerSeq int64  `json:"last_server_seq"`
 	LastEventTime string `json:"last_event_time,omitempty"`
+
+	// EntityTypes breaks the counts down by entity type (issues, logs,
+	// comments, boards, ...). Types with no events are omitted.
+	EntityTypes map[string]EntityTypeStatus `json:"entity_types,omitempty"`
+
+	// Devices lists each device that has pulled, with how far behind the
+	// head its local DB is.
+	Devices []DeviceSyncStatus `json:"devices,omitempty"`
+}
+
+// DeviceSyncStatus is one device's pull position in SyncStatusResponse.
+// BehindBy counts events after LastPulledSeq that the device has yet to
+// pull; its own pushed events are not counted.
+type DeviceSyncStatus struct {
+	DeviceID      string `json:"device_id"`
+	UserID        string `json:"user_id,omitempty"`
+	LastPulledSeq int64  `json:"last_pulled_seq"`
+	LastPulledAt  string `json:"last_pulled_at,omitempty"`
+	BehindBy      int64  `json:"behind_by"`
+}
+
+// EntityTypeStatus is the per-entity-type part of SyncStatusResponse.
+type EntityTypeStatus struct {
+	EventCount    int64 `json:"event_count"`
+	LastServerSeq int64 `json:"last_server_seq"`
 }
 
 // handleSyncPush handles POST /v1/projects/{id}/sync/push.
 func (s *Server) handleSyncPush(w http.ResponseWriter, r *http.Request) {
+	s.metrics.RecordPushRequest()
 	projectID := r.PathValue("id")
 
 	var req PushRequest
@@ -122,23 +154,102 @@ func (s *Server) handleSyncPush(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	inputs, rejected := s.screenPushEvents(req.Events)
+	events, err := convertPushEvents(req.DeviceID, req.SessionID, inputs)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", err.Error())
+		return
+	}
+
+	var resp PushResponse
+	if len(events) > 0 {
+		var msg string
+		resp, msg, err = s.pushEvents(r, projectID, req.DeviceID, events)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "internal_error", msg)
+			return
+		}
+	}
+	resp.Rejected = append(rejected, resp.Rejected...)
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// isJSONObjectOrNull reports whether a valid JSON value is an object or null.
+func isJSONObjectOrNull(raw json.RawMessage) bool {
+	trimmed := bytes.TrimSpace(raw)
+	return bytes.HasPrefix(trimmed, []byte("{")) || bytes.Equal(trimmed, []byte("null"))
+}
+
+// screenPushEvents rejects events whose payload is too large or is not a
+// JSON object, so one bad event can't fail the batch or bloat snapshot
+// replay, and events dated further in the future than the allowed clock
+// skew, so a badly-set client clock can't poison event ordering. It returns
+// the events that passed and a rejection for each that didn't. An omitted
+// payload is allowed, and so is any past timestamp.
+func (s *Server) screenPushEvents(inputs []EventInput) ([]EventInput, []RejectResponse) {
+	limit := s.config.MaxEventPayloadBytes
+	if limit <= 0 {
+		limit = defMaxEventPayloadBytes
+	}
+	maxSkew := s.config.MaxClockSkew
+	if maxSkew <= 0 {
+		maxSkew = defMaxClockSkew
+	}
+	latest := time.Now().Add(maxSkew)
+
+	kept := inputs[:0:0]
+	var rejected []RejectResponse
+	for _, ev := range inputs {
+		reason := ""
+		switch {
+		case len(ev.Payload) > limit:
+			reason = fmt.Sprintf("payload too large: %d bytes exceeds max %d", len(ev.Payload), limit)
+		case len(ev.Payload) > 0 && !json.Valid(ev.Payload):
+			reason = "malformed payload: invalid JSON"
+		case len(ev.Payload) > 0 && !isJSONObjectOrNull(ev.Payload):
+			reason = "malformed payload: expected a JSON object"
+		case isAfter(ev.ClientTimestamp, latest):
+			reason = "clock_skew"
+			slog.Warn("reject clock-skewed event", "aid", ev.ClientActionID, "client_timestamp", ev.ClientTimestamp, "max_skew", maxSkew)
+		}
+		if reason != "" {
+			rejected = append(rejected, RejectResponse{ClientActionID: ev.ClientActionID, Reason: reason})
+			continue
+		}
+		kept = append(kept, ev)
+	}
+	if len(rejected) > 0 {
+		s.metrics.RecordPushRejections(int64(len(rejected)), 0)
+	}
+	return kept, rejected
+}
+
+// isAfter reports whether the RFC 3339 timestamp ts is later than t.
+// Unparseable timestamps report false and are left to convertPushEvents.
+func isAfter(ts string, t time.Time) bool {
+	parsed, err := time.Parse(time.RFC3339Nano, ts)
+	return err == nil && parsed.After(t)
+}
+
+// convertPushEvents validates pushed events and converts them to sync events
+// with canonical entity and action types.
+func convertPushEvents(deviceID, sessionID string, inputs []EventInput) ([]tdsync.Event, error) {
 	// Validate entity types
-	for _, ev := range req.Events {
+	for _, ev := range inputs {
 		if !isValidEntityType(ev.EntityType) {
-			writeError(w, http.StatusBadRequest, "bad_request", fmt.Sprintf("invalid entity_type: %s", ev.EntityType))
-			return
+			return nil, fmt.Errorf("invalid entity_type: %s", ev.EntityType)
 		}
 	}
 
 	// Convert to sync.Event with normalization
-	events := make([]tdsync.Event, len(req.Events))
-	for i, ev := range req.Events {
+	events := make([]tdsync.Event, len(inputs))
+	for i, ev := range inputs {
 		ts, err := time.Parse(time.RFC3339, ev.ClientTimestamp)
 		if err != nil {
 			ts, err = time.Parse(time.RFC3339Nano, ev.ClientTimestamp)
 			if err != nil {
-				writeError(w, http.StatusBadRequest, "bad_request", fmt.Sprintf("invalid timestamp for action %d", ev.ClientActionID))
-				return
+				return nil, fmt.Errorf("invalid timestamp for action %d", ev.ClientActionID)
 			}
 		}
 
@@ -153,8 +264,8 @@ func (s *Server) handleSyncPush(w http.ResponseWriter, r *http.Request) {
 
 		events[i] = tdsync.Event{
 			ClientActionID:  ev.ClientActionID,
-			DeviceID:        req.DeviceID,
-			SessionID:       req.SessionID,
+			DeviceID:        deviceID,
+			SessionID:       sessionID,
 			ActionType:      string(canonicalAction),
 			EntityType:      string(canonicalEntity),
 			EntityID:        ev.EntityID,
@@ -162,33 +273,39 @@ func (s *Server) handleSyncPush(w http.ResponseWriter, r *http.Request) {
 			ClientTimestamp: ts,
 		}
 	}
+	return events, nil
+}
 
+// pushEvents stores events in a project's event log and applies the accepted
+// ones to its live state. On failure it returns a client-facing message along
+// with the error; the error has already been logged.
+func (s *Server) pushEvents(r *http.Request, projectID, deviceID string, events []tdsync.Event) (PushResponse, string, error) {
 	db, err := s.dbPool.Get(projectID)
 	if err != nil {
 		logFor(r.Context()).Error("get project db", "project", projectID, "err", err)
-		writeError(w, http.StatusInternalServerError, "internal_error", "failed to open project database")
-		return
+		return PushResponse{}, "failed to open project database", err
 	}
 
 	tx, err := db.Begin()
 	if err != nil {
 		logFor(r.Context()).Error("begin tx", "err", err)
-		writeError(w, http.StatusInternalServerError, "internal_error", "database error")
-		return
+		return PushResponse{}, "database error", err
 	}
 	defer func() { _ = tx.Rollback() }()
 
 	result, err := tdsync.InsertServerEvents(tx, events)
 	if err != nil {
 		logFor(r.Context()).Error("insert events", "err", err)
-		writeError(w, http.StatusInternalServerError, "internal_error", "failed to insert events")
-		return
+		return PushResponse{}, "failed to insert events", err
+	}
+	if err := recordEventAuthors(tx, result.Acks, getUserFromContext(r.Context())); err != nil {
+		logFor(r.Context()).Error("record event authors", "err", err)
+		return PushResponse{}, "failed to insert events", err
 	}
 
 	if err := tx.Commit(); err != nil {
 		logFor(r.Context()).Error("commit tx", "err", err)
-		writeError(w, http.StatusInternalServerError, "internal_error", "failed to commit")
-		return
+		return PushResponse{}, "failed to commit", err
 	}
 
 	// Apply accepted events to project.db (live state). Same machinery the
@@ -202,8 +319,7 @@ func (s *Server) handleSyncPush(w http.ResponseWriter, r *http.Request) {
 	if s.projectLivePool != nil && result.Accepted > 0 {
 		if err := applyAcceptedEventsToProjectDB(s.projectLivePool, projectID, events, result); err != nil {
 			logFor(r.Context()).Error("apply push to project.db", "project", projectID, "err", err)
-			writeError(w, http.StatusInternalServerError, "internal_error", "failed to apply events to project state")
-			return
+			return PushResponse{}, "failed to apply events to project state", err
 		}
 	}
 
@@ -230,12 +346,22 @@ func (s *Server) handleSyncPush(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 	if maxSeq > 0 {
-		if err := s.store.UpsertSyncCursor(projectID, req.DeviceID, maxSeq); err != nil {
-			logFor(r.Context()).Warn("upsert sync cursor on push", "project", projectID, "device", req.DeviceID, "err", err)
+		if err := s.store.UpsertSyncCursor(projectID, deviceID, maxSeq); err != nil {
+			logFor(r.Context()).Warn("upsert sync cursor on push", "project", projectID, "device", deviceID, "err", err)
 		}
 	}
 
 	s.metrics.RecordPushEvents(int64(result.Accepted))
+	if result.Accepted > 0 {
+		s.metrics.RecordProjectEvents(projectID, int64(result.Accepted))
+	}
+	var duplicates int64
+	for _, rj := range result.Rejected {
+		if rj.Reason == "duplicate" {
+			duplicates++
+		}
+	}
+	s.metrics.RecordPushRejections(int64(len(result.Rejected))-duplicates, duplicates)
 
 	resp := PushResponse{Accepted: result.Accepted}
 	for _, a := range result.Acks {
@@ -244,15 +370,15 @@ func (s *Server) handleSyncPush(w http.ResponseWriter, r *http.Request) {
 			ServerSeq:      a.ServerSeq,
 		})
 	}
-	for _, r := range result.Rejected {
+	for _, rj := range result.Rejected {
 		resp.Rejected = append(resp.Rejected, RejectResponse{
-			ClientActionID: r.ClientActionID,
-			Reason:         r.Reason,
-			ServerSeq:      r.ServerSeq,
+			ClientActionID: rj.ClientActionID,
+			Reason:         rj.Reason,
+			ServerSeq:      rj.ServerSeq,
 		})
 	}
 
-	writeJSON(w, http.StatusOK, resp)
+	return resp, "", nil
 }
 
 // handleSyncPull handles GET /v1/projects/{id}/sync/pull.
@@ -298,6 +424,22 @@ func (s *Server) handleSyncPull(w http.ResponseWriter, r *http.Request) {
 	}
 	defer func() { _ = tx.Rollback() }()
 
+	// Events at or below the retention boundary have been pruned, so a cursor
+	// inside that range would silently skip them. Send the client to the
+	// snapshot endpoint to re-bootstrap instead.
+	prunedThrough, err := tdsync.PrunedThroughSeq(tx)
+	if err != nil {
+		logFor(r.Context()).Error("read retention boundary", "err", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "failed to query events")
+		return
+	}
+	if afterSeq < prunedThrough {
+		writeError(w, http.StatusGone, ErrCodeCursorExpired, fmt.Sprintf(
+			"after_server_seq %d is below the oldest retained event (%d); re-bootstrap from /v1/projects/%s/sync/snapshot",
+			afterSeq, prunedThrough+1, projectID))
+		return
+	}
+
 	excludeClient := r.URL.Query().Get("exclude_client")
 	result, err := tdsync.GetEventsSince(tx, afterSeq, limit, excludeClient)
 	if err != nil {
@@ -315,7 +457,11 @@ func (s *Server) handleSyncPull(w http.ResponseWriter, r *http.Request) {
 	// highest server_seq observed by the pull (may be > returned events if
 	// we excluded the caller's own writes).
 	if excludeClient != "" && result.LastServerSeq > 0 {
-		if err := s.store.UpsertSyncCursor(projectID, excludeClient, result.LastServerSeq); err != nil {
+		var userID string
+		if user := getUserFromContext(r.Context()); user != nil {
+			userID = user.UserID
+		}
+		if err := s.store.RecordSyncPull(projectID, excludeClient, userID, result.LastServerSeq); err != nil {
 			logFor(r.Context()).Warn("upsert sync cursor on pull", "project", projectID, "device", excludeClient, "err", err)
 		}
 	}
@@ -374,12 +520,63 @@ func (s *Server) handleSyncStatus(w http.ResponseWriter, r *http.Request) {
 		if err == nil {
 			resp.LastEventTime = ts
 		}
+
+		rows, err := db.Query(`SELECT entity_type, COUNT(*), MAX(server_seq) FROM events GROUP BY entity_type`)
+		if err != nil {
+			logFor(r.Context()).Error("query entity type counts", "err", err)
+			writeError(w, http.StatusInternalServerError, "internal_error", "database error")
+			return
+		}
+		defer rows.Close()
+		resp.EntityTypes = make(map[string]EntityTypeStatus)
+		for rows.Next() {
+			var entityType string
+			var st EntityTypeStatus
+			if err := rows.Scan(&entityType, &st.EventCount, &st.LastServerSeq); err != nil {
+				logFor(r.Context()).Error("scan entity type counts", "err", err)
+				writeError(w, http.StatusInternalServerError, "internal_error", "database error")
+				return
+			}
+			resp.EntityTypes[entityType] = st
+		}
+		if err := rows.Err(); err != nil {
+			logFor(r.Context()).Error("iterate entity type counts", "err", err)
+			writeError(w, http.StatusInternalServerError, "internal_error", "database error")
+			return
+		}
+	}
+
+	cursors, err := s.store.ListSyncCursorsForProject(projectID)
+	if err != nil {
+		logFor(r.Context()).Error("list sync cursors", "err", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "database error")
+		return
+	}
+	for _, c := range cursors {
+		if c.LastPulledAt == nil {
+			continue // pushed but never pulled
+		}
+		dev := DeviceSyncStatus{
+			DeviceID:      c.ClientID,
+			UserID:        c.UserID,
+			LastPulledSeq: c.LastPulledSeq,
+			LastPulledAt:  c.LastPulledAt.UTC().Format(time.RFC3339),
+		}
+		err := db.QueryRow(`SELECT COUNT(*) FROM events WHERE server_seq > ? AND device_id != ?`,
+			c.LastPulledSeq, c.ClientID).Scan(&dev.BehindBy)
+		if err != nil {
+			logFor(r.Context()).Error("count events behind", "device", c.ClientID, "err", err)
+			writeError(w, http.StatusInternalServerError, "internal_error", "database error")
+			return
+		}
+		resp.Devices = append(resp.Devices, dev)
 	}
 	writeJSON(w, http.StatusOK, resp)
 }
 
 // handleSyncSnapshot handles GET /v1/projects/{id}/sync/snapshot.
-// Builds a snapshot database by replaying all events, then streams it to the client.
+// Builds a snapshot database by replaying events onto the project's base
+// snapshot (or an empty DB), then streams it to the client.
 // Caches built snapshots keyed by lastSeq to avoid rebuilding on every request.
 func (s *Server) handleSyncSnapshot(w http.ResponseWriter, r *http.Request) {
 	projectID := r.PathValue("id")
@@ -404,20 +601,97 @@ func (s *Server) handleSyncSnapshot(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Check snapshot cache
-	cacheDir := filepath.Join(s.config.ProjectDataDir, "snapshots", projectID)
-	cachePath := filepath.Join(cacheDir, fmt.Sprintf("%d.db", lastSeq))
+	settings, key, err := s.snapshotSettings(projectID, lastSeq)
+	if err != nil {
+		logFor(r.Context()).Error("get project settings", "project", projectID, "err", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "database error")
+		return
+	}
+
+	// The snapshot for a given key never changes, so a client already holding
+	// it can skip the download (and the server a possible rebuild).
+	if etagMatches(r.Header.Get("If-None-Match"), snapshotETag(key)) {
+		w.Header().Set("ETag", snapshotETag(key))
+		w.Header().Set("X-Snapshot-Seq", strconv.FormatInt(lastSeq, 10))
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	servePath, err := s.buildSnapshotFile(projectID, eventsDB, lastSeq, settings, key)
+	if err != nil {
+		logFor(r.Context()).Error("build snapshot", "project", projectID, "err", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "failed to build snapshot")
+		return
+	}
+	serveSnapshotFile(w, r, servePath, lastSeq, key)
+}
+
+// snapshotSettings returns the project settings a snapshot built now embeds,
+// and the key naming that snapshot: its seq plus a digest of the settings, so
+// a settings change yields a new ETag and cache file even at the same seq.
+func (s *Server) snapshotSettings(projectID string, seq int64) (json.RawMessage, string, error) {
+	settings, err := s.store.GetProjectSettings(projectID)
+	if err != nil {
+		return nil, "", err
+	}
+	if settings == nil {
+		settings = json.RawMessage("{}")
+	}
+	sum := sha256.Sum256(settings)
+	return settings, fmt.Sprintf("%d-%s", seq, hex.EncodeToString(sum[:4])), nil
+}
+
+// snapshotCacheDir returns the directory holding a project's cached snapshots.
+func (s *Server) snapshotCacheDir(projectID string) string {
+	return filepath.Join(s.config.ProjectDataDir, "snapshots", projectID)
+}
+
+// snapshotCacheSeq returns the seq a cached snapshot file was built at. Sync
+// snapshots are named <seq>-<settings digest>.db and admin query snapshots
+// <seq>.db.
+func snapshotCacheSeq(name string) (int64, bool) {
+	name = strings.TrimSuffix(name, ".db")
+	if i := strings.IndexByte(name, '-'); i >= 0 {
+		name = name[:i]
+	}
+	seq, err := strconv.ParseInt(name, 10, 64)
+	return seq, err == nil
+}
+
+// snapshotCachePath returns where the snapshot with the given key is cached.
+func (s *Server) snapshotCachePath(projectID, key string) string {
+	return filepath.Join(s.snapshotCacheDir(projectID), key+".db")
+}
+
+// snapshotFile returns the path and key of the snapshot built at lastSeq with
+// the project's current settings, building and caching it first if needed.
+func (s *Server) snapshotFile(projectID string, eventsDB *sql.DB, lastSeq int64) (string, string, error) {
+	settings, key, err := s.snapshotSettings(projectID, lastSeq)
+	if err != nil {
+		return "", "", fmt.Errorf("get project settings: %w", err)
+	}
+	path, err := s.buildSnapshotFile(projectID, eventsDB, lastSeq, settings, key)
+	return path, key, err
+}
+
+// buildSnapshotFile returns the path of the snapshot with the given key,
+// building it at lastSeq with settings and caching it first if needed.
+func (s *Server) buildSnapshotFile(projectID string, eventsDB *sql.DB, lastSeq int64, settings json.RawMessage, key string) (string, error) {
+	cachePath := s.snapshotCachePath(projectID, key)
+	cacheDir := filepath.Dir(cachePath)
 
 	if _, err := os.Stat(cachePath); err == nil {
 		// Cache hit — serve directly
 		slog.Info("snapshot cache hit", "project", projectID, "seq", lastSeq)
-		serveSnapshotFile(w, r, cachePath, lastSeq)
-		return
+		s.metrics.RecordSnapshotCacheHit()
+		return cachePath, nil
 	}
 
+	s.metrics.RecordSnapshotCacheMiss()
+
 	// Cache miss — use singleflight to deduplicate concurrent builds for the same snapshot.
 	// Without this, two concurrent requests race on file renames and one gets a 500.
-	sfKey := fmt.Sprintf("%s:%d", projectID, lastSeq)
+	sfKey := projectID + ":" + key
 	result, err, _ := s.snapshotGroup.Do(sfKey, func() (any, error) {
 		// Double-check cache inside singleflight (another request may have just cached it)
 		if _, err := os.Stat(cachePath); err == nil {
@@ -431,10 +705,14 @@ func (s *Server) handleSyncSnapshot(w http.ResponseWriter, r *http.Request) {
 		tmpPath := tmpFile.Name()
 		tmpFile.Close()
 
-		if err := buildSnapshot(eventsDB, tmpPath, lastSeq); err != nil {
+		if err := buildSnapshot(eventsDB, s.snapshotBase(projectID), tmpPath, lastSeq); err != nil {
 			os.Remove(tmpPath)
 			return "", fmt.Errorf("build snapshot: %w", err)
 		}
+		if err := writeSnapshotSettings(settings, tmpPath); err != nil {
+			os.Remove(tmpPath)
+			return "", fmt.Errorf("add settings to snapshot: %w", err)
+		}
 
 		// Cache the built snapshot using atomic write-and-rename.
 		// Clean up tmpPath if it's not the final serve path (copyFile may rename it away).
@@ -456,7 +734,7 @@ func (s *Server) handleSyncSnapshot(w http.ResponseWriter, r *http.Request) {
 				if err := os.Rename(tmpCachePath, cachePath); err != nil {
 					slog.Warn("snapshot cache rename failed", "err", err)
 				} else {
-					cleanSnapshotCache(cacheDir, lastSeq)
+					cleanSnapshotCache(cacheDir, filepath.Base(cachePath))
 					slog.Info("snapshot cached", "project", projectID, "seq", lastSeq)
 					servePath = cachePath
 				}
@@ -467,19 +745,22 @@ func (s *Server) handleSyncSnapshot(w http.ResponseWriter, r *http.Request) {
 		return servePath, nil
 	})
 	if err != nil {
-		logFor(r.Context()).Error("build snapshot", "project", projectID, "err", err)
-		writeError(w, http.StatusInternalServerError, "internal_error", "failed to build snapshot")
-		return
+		return "", err
 	}
 
 	// Note: if caching failed entirely, servePath points to a temp file that won't
 	// be cleaned up here. With singleflight, multiple callers share the same path,
 	// so no single caller can safely delete it. The OS temp directory handles cleanup.
-	serveSnapshotFile(w, r, result.(string), lastSeq)
+	return result.(string), nil
 }
 
 // serveSnapshotFile streams a snapshot .db file as an HTTP response.
-func serveSnapshotFile(w http.ResponseWriter, r *http.Request, path string, seq int64) {
+// Range requests are honored (206 with Content-Range) so clients can resume
+// an interrupted download; X-Snapshot-Seq and the ETag let them verify that
+// the resumed bytes belong to the same snapshot. X-Snapshot-SHA256 is the
+// digest of the whole file, even on a 206, so the client can check the
+// reassembled download before swapping it in.
+func serveSnapshotFile(w http.ResponseWriter, r *http.Request, path string, seq int64, key string) {
 	f, err := os.Open(path)
 	if err != nil {
 		logFor(r.Context()).Error("open snapshot", "err", err)
@@ -488,31 +769,55 @@ func serveSnapshotFile(w http.ResponseWriter, r *http.Request, path string, seq
 	}
 	defer f.Close()
 
-	stat, err := f.Stat()
-	if err != nil {
-		logFor(r.Context()).Error("stat snapshot", "err", err)
-		writeError(w, http.StatusInternalServerError, "internal_error", "failed to stat snapshot")
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		logFor(r.Context()).Error("hash snapshot", "err", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "failed to read snapshot")
+		return
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		logFor(r.Context()).Error("rewind snapshot", "err", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "failed to read snapshot")
 		return
 	}
+
 	w.Header().Set("Content-Type", "application/x-sqlite3")
 	w.Header().Set("X-Snapshot-Seq", strconv.FormatInt(seq, 10))
-	w.Header().Set("Content-Length", strconv.FormatInt(stat.Size(), 10))
-	w.WriteHeader(http.StatusOK)
-	_, _ = io.Copy(w, f)
+	w.Header().Set("X-Snapshot-SHA256", hex.EncodeToString(h.Sum(nil)))
+	w.Header().Set("ETag", snapshotETag(key))
+	http.ServeContent(w, r, filepath.Base(path), time.Time{}, f)
+}
+
+// snapshotETag returns the entity tag for the snapshot with the given key.
+func snapshotETag(key string) string {
+	return `"` + key + `"`
 }
 
-// cleanSnapshotCache removes cached .db files that don't match the current seq.
-func cleanSnapshotCache(cacheDir string, currentSeq int64) {
+// etagMatches reports whether an If-None-Match header value matches etag.
+// Weak validators are compared by their opaque tag.
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// cleanSnapshotCache removes cached .db files other than keepName. Pass ""
+// to remove them all. Subdirectories, such as the base snapshots kept by
+// event retention, are left alone.
+func cleanSnapshotCache(cacheDir, keepName string) {
 	entries, err := os.ReadDir(cacheDir)
 	if err != nil {
 		return
 	}
-	currentName := fmt.Sprintf("%d.db", currentSeq)
 	for _, e := range entries {
 		if e.IsDir() || !strings.HasSuffix(e.Name(), ".db") {
 			continue
 		}
-		if e.Name() != currentName {
+		if e.Name() != keepName {
 			old := filepath.Join(cacheDir, e.Name())
 			if err := os.Remove(old); err != nil {
 				slog.Warn("snapshot cache cleanup failed", "file", old, "err", err)
@@ -531,6 +836,12 @@ func copyFile(src, dst string) error {
 		return nil
 	}
 	// Rename failed (cross-device); do an atomic byte copy
+	return copyFileContents(src, dst)
+}
+
+// copyFileContents copies src to dst via a temp file + rename, leaving src
+// in place.
+func copyFileContents(src, dst string) error {
 	in, err := os.Open(src)
 	if err != nil {
 		return err
@@ -555,8 +866,9 @@ func copyFile(src, dst string) error {
 	return os.Rename(tmpDst, dst)
 }
 
-// buildSnapshot replays events from the events DB into a new snapshot DB.
-func buildSnapshot(eventsDB *sql.DB, snapshotPath string, upToSeq int64) error {
+// buildSnapshot replays events from the events DB into a new snapshot DB,
+// starting from base when the log has been pruned.
+func buildSnapshot(eventsDB *sql.DB, base snapshotBase, snapshotPath string, upToSeq int64) error {
 	// Create temp dir for Initialize (it creates .todos/issues.db inside)
 	tmpDir, err := os.MkdirTemp("", "td-snapshot-*")
 	if err != nil {
@@ -564,6 +876,16 @@ func buildSnapshot(eventsDB *sql.DB, snapshotPath string, upToSeq int64) error {
 	}
 	defer os.RemoveAll(tmpDir)
 
+	if base.Path != "" {
+		// Initialize migrates the copied base up to the current schema
+		if err := os.MkdirAll(filepath.Join(tmpDir, ".todos"), 0o755); err != nil {
+			return fmt.Errorf("create snapshot dir: %w", err)
+		}
+		if err := copyFileContents(base.Path, filepath.Join(tmpDir, ".todos", "issues.db")); err != nil {
+			return fmt.Errorf("copy base snapshot: %w", err)
+		}
+	}
+
 	// Initialize with full schema + all migrations
 	tdb, err := tddb.Initialize(tmpDir)
 	if err != nil {
@@ -586,7 +908,7 @@ func buildSnapshot(eventsDB *sql.DB, snapshotPath string, upToSeq int64) error {
 	defer snapDB.Close()
 
 	validator := func(t string) bool { return isValidEntityType(t) }
-	afterSeq := int64(0)
+	afterSeq := base.Seq
 	batchSize := 1000
 
 	for {
@@ -595,7 +917,15 @@ func buildSnapshot(eventsDB *sql.DB, snapshotPath string, upToSeq int64) error {
 			return fmt.Errorf("begin event read tx: %w", err)
 		}
 
-		result, err := tdsync.GetEventsSince(tx, afterSeq, batchSize, "")
+		// A prune that overtook this build removed events it still needs
+		prunedThrough, err := tdsync.PrunedThroughSeq(tx)
+		if err == nil && afterSeq < prunedThrough {
+			err = fmt.Errorf("events through %d were pruned during the build", prunedThrough)
+		}
+		var result tdsync.PullResult
+		if err == nil {
+			result, err = tdsync.GetEventsSince(tx, afterSeq, batchSize, "")
+		}
 		_ = tx.Rollback() // read-only
 
 		if err != nil {
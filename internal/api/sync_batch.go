@@ -0,0 +1,133 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// BatchPushRequest is the JSON body for POST /v1/sync/push. It carries events
+// for several projects so a client syncing many projects needs one request
+// instead of one per project.
+type BatchPushRequest struct {
+	DeviceID  string             `json:"device_id"`
+	SessionID string             `json:"session_id"`
+	Projects  []BatchPushProject `json:"projects"`
+}
+
+// BatchPushProject is one project's events within a batched push.
+type BatchPushProject struct {
+	ProjectID string       `json:"project_id"`
+	Events    []EventInput `json:"events"`
+}
+
+// BatchPushResponse is the JSON response for a batched push. Results are in
+// request order, one per project.
+type BatchPushResponse struct {
+	Results []BatchPushResult `json:"results"`
+}
+
+// BatchPushResult is the outcome of pushing one project's events. Status is
+// the HTTP status the single-project push endpoint would have returned; acks
+// and rejections are present on success, Error otherwise.
+type BatchPushResult struct {
+	ProjectID string `json:"project_id"`
+	Status    int    `json:"status"`
+	*PushResponse
+	Error *APIError `json:"error,omitempty"`
+}
+
+// handleBatchPush handles POST /v1/sync/push. Membership is checked per
+// project, so a project the caller cannot write to fails on its own without
+// affecting the others. The response is 200 when every project succeeded and
+// 207 Multi-Status otherwise.
+func (s *Server) handleBatchPush(w http.ResponseWriter, r *http.Request) {
+	s.metrics.RecordPushRequest()
+	user := getUserFromContext(r.Context())
+
+	var req BatchPushRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "invalid json body")
+		return
+	}
+
+	if req.DeviceID == "" {
+		writeError(w, http.StatusBadRequest, "bad_request", "device_id is required")
+		return
+	}
+	if req.SessionID == "" {
+		writeError(w, http.StatusBadRequest, "bad_request", "session_id is required")
+		return
+	}
+	if len(req.Projects) == 0 {
+		writeError(w, http.StatusBadRequest, "bad_request", "projects array is empty")
+		return
+	}
+
+	total := 0
+	seen := make(map[string]bool, len(req.Projects))
+	for _, p := range req.Projects {
+		if p.ProjectID == "" {
+			writeError(w, http.StatusBadRequest, "bad_request", "project_id is required")
+			return
+		}
+		if seen[p.ProjectID] {
+			writeError(w, http.StatusBadRequest, "bad_request", fmt.Sprintf("duplicate project_id: %s", p.ProjectID))
+			return
+		}
+		seen[p.ProjectID] = true
+		total += len(p.Events)
+	}
+	if total > maxPushBatch {
+		writeError(w, http.StatusBadRequest, "bad_request", fmt.Sprintf("batch size %d exceeds max %d", total, maxPushBatch))
+		return
+	}
+
+	resp := BatchPushResponse{Results: make([]BatchPushResult, 0, len(req.Projects))}
+	status := http.StatusOK
+	for _, p := range req.Projects {
+		result := s.pushProjectBatch(r, user.UserID, req.DeviceID, req.SessionID, p)
+		if result.Status != http.StatusOK {
+			status = http.StatusMultiStatus
+		}
+		resp.Results = append(resp.Results, result)
+	}
+
+	writeJSON(w, status, resp)
+}
+
+// pushProjectBatch authorizes and pushes a single project's share of a
+// batched push.
+func (s *Server) pushProjectBatch(r *http.Request, userID, deviceID, sessionID string, p BatchPushProject) BatchPushResult {
+	fail := func(status int, code, message string) BatchPushResult {
+		return BatchPushResult{
+			ProjectID: p.ProjectID,
+			Status:    status,
+			Error:     &APIError{Code: code, Message: message},
+		}
+	}
+
+	if err := s.store.CanPushEvents(p.ProjectID, userID); err != nil {
+		return fail(http.StatusForbidden, "forbidden", err.Error())
+	}
+	if len(p.Events) == 0 {
+		return fail(http.StatusBadRequest, "bad_request", "events array is empty")
+	}
+
+	inputs, rejected := s.screenPushEvents(p.Events)
+	events, err := convertPushEvents(deviceID, sessionID, inputs)
+	if err != nil {
+		return fail(http.StatusBadRequest, "bad_request", err.Error())
+	}
+
+	var pushed PushResponse
+	if len(events) > 0 {
+		var msg string
+		pushed, msg, err = s.pushEvents(r, p.ProjectID, deviceID, events)
+		if err != nil {
+			return fail(http.StatusInternalServerError, "internal_error", msg)
+		}
+	}
+	pushed.Rejected = append(rejected, pushed.Rejected...)
+	return BatchPushResult{ProjectID: p.ProjectID, Status: http.StatusOK, PushResponse: &pushed}
+}
@@ -1,8 +1,10 @@
 package api
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -110,3 +112,29 @@ func TestSnapshotCacheRenameFailureStillServable(t *testing.T) {
 		t.Errorf("content mismatch at servePath")
 	}
 }
+
+// An HTTP push can't carry invalid JSON in a payload (the request body
+// wouldn't decode), so screenPushEvents is called directly here.
+func TestScreenPushEventsRejectsInvalidJSON(t *testing.T) {
+	srv, _ := newTestServer(t)
+	inputs := []EventInput{
+		{ClientActionID: 1, Payload: json.RawMessage(`{"title":"ok"}`)},
+		{ClientActionID: 2, Payload: json.RawMessage(`{"title": "truncated`)},
+		{ClientActionID: 3, Payload: json.RawMessage(`[1, 2]`)},
+		{ClientActionID: 4},
+	}
+
+	kept, rejected := srv.screenPushEvents(inputs)
+	if len(kept) != 2 || kept[0].ClientActionID != 1 || kept[1].ClientActionID != 4 {
+		t.Errorf("kept = %+v, want actions 1 and 4", kept)
+	}
+	if len(rejected) != 2 {
+		t.Fatalf("rejected = %+v, want actions 2 and 3", rejected)
+	}
+	if rejected[0].ClientActionID != 2 || !strings.Contains(rejected[0].Reason, "invalid JSON") {
+		t.Errorf("invalid JSON rejection = %+v", rejected[0])
+	}
+	if rejected[1].ClientActionID != 3 || !strings.Contains(rejected[1].Reason, "expected a JSON object") {
+		t.Errorf("non-object rejection = %+v", rejected[1])
+	}
+}
@@ -0,0 +1,135 @@
+// Package boardtemplate stores named sets of boards that can be recreated in
+// any project. Templates live in ~/.config/td/board_templates.json so a
+// template saved in one project can be applied in another.
+package boardtemplate
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/marcus/td/internal/syncconfig"
+)
+
+const templatesFile = "board_templates.json"
+
+// Board is one board in a template: everything needed to recreate it, but
+// not its ID or issue positions, which belong to the project it came from.
+type Board struct {
+	Name     string `json:"name"`
+	Query    string `json:"query,omitempty"`
+	ViewMode string `json:"view_mode,omitempty"`
+}
+
+// Template is a named set of boards.
+type Template struct {
+	Name    string    `json:"name"`
+	Boards  []Board   `json:"boards"`
+	SavedAt time.Time `json:"saved_at"`
+}
+
+// Load reads all templates, keyed by name. Returns an empty map if the file
+// does not exist.
+func Load() (map[string]Template, error) {
+	dir, err := syncconfig.ConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filepath.Join(dir, templatesFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]Template{}, nil
+		}
+		return nil, err
+	}
+
+	var templates map[string]Template
+	if err := json.Unmarshal(data, &templates); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", templatesFile, err)
+	}
+	if templates == nil {
+		templates = map[string]Template{}
+	}
+	return templates, nil
+}
+
+// List returns all templates sorted by name.
+func List() ([]Template, error) {
+	templates, err := Load()
+	if err != nil {
+		return nil, err
+	}
+	list := make([]Template, 0, len(templates))
+	for _, t := range templates {
+		list = append(list, t)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list, nil
+}
+
+// Get returns the named template.
+func Get(name string) (*Template, error) {
+	templates, err := Load()
+	if err != nil {
+		return nil, err
+	}
+	t, ok := templates[name]
+	if !ok {
+		return nil, fmt.Errorf("board template not found: %s", name)
+	}
+	return &t, nil
+}
+
+// Put saves t, replacing any template with the same name.
+func Put(t Template) error {
+	templates, err := Load()
+	if err != nil {
+		return err
+	}
+	templates[t.Name] = t
+	return save(templates)
+}
+
+// Delete removes the named template.
+func Delete(name string) error {
+	templates, err := Load()
+	if err != nil {
+		return err
+	}
+	if _, ok := templates[name]; !ok {
+		return fmt.Errorf("board template not found: %s", name)
+	}
+	delete(templates, name)
+	return save(templates)
+}
+
+// save writes templates using an atomic temp file + rename.
+func save(templates map[string]Template) error {
+	dir, err := syncconfig.ConfigDir()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(templates, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, "board_templates-*.json.tmp")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpName)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+	return os.Rename(tmpName, filepath.Join(dir, templatesFile))
+}
@@ -4,8 +4,10 @@ package config
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"syscall"
 
 	"github.com/marcus/td/internal/models"
@@ -20,6 +22,20 @@ const (
 	DefaultTitleMaxLength = 200
 )
 
+// Issue ID formats
+const (
+	DefaultIDPrefix = "td-"
+	IDFormatHex     = "hex" // random hex suffix (default)
+	IDFormatSeq     = "seq" // zero-padded sequence per prefix
+)
+
+// idPrefixPattern is the accepted shape of a configured ID prefix.
+var idPrefixPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*-$`)
+
+// DefaultWIPLimit is the number of in_progress issues a session may hold
+// before `td next --claim` refuses to claim more.
+const DefaultWIPLimit = 1
+
 // Load reads the config from disk
 func Load(baseDir string) (*models.Config, error) {
 	configPath := filepath.Join(baseDir, configFile)
@@ -195,9 +211,11 @@ func SetPaneHeights(baseDir string, heights [3]float64) error {
 // FilterState holds the current filter/search state for the monitor
 type FilterState struct {
 	SearchQuery   string
-	SortMode      string // "priority", "created", "updated"
+	SortMode      string // "priority", "created", "updated", "stale"
 	TypeFilter    string // "", "epic", "task", "bug", "feature", "chore"
+	LabelFilter   string // "" or a single label name
 	IncludeClosed bool
+	QueryPreset   string // "" or a monitor query preset name
 }
 
 // GetFilterState returns the saved filter state
@@ -210,7 +228,9 @@ func GetFilterState(baseDir string) (*FilterState, error) {
 		SearchQuery:   cfg.SearchQuery,
 		SortMode:      cfg.SortMode,
 		TypeFilter:    cfg.TypeFilter,
+		LabelFilter:   cfg.LabelFilter,
 		IncludeClosed: cfg.IncludeClosed,
+		QueryPreset:   cfg.QueryPreset,
 	}, nil
 }
 
@@ -224,7 +244,70 @@ func SetFilterState(baseDir string, state *FilterState) error {
 		cfg.SearchQuery = state.SearchQuery
 		cfg.SortMode = state.SortMode
 		cfg.TypeFilter = state.TypeFilter
+		cfg.LabelFilter = state.LabelFilter
 		cfg.IncludeClosed = state.IncludeClosed
+		cfg.QueryPreset = state.QueryPreset
+		return Save(baseDir, cfg)
+	})
+}
+
+// GetProjectDefaults returns the cached project-wide default query and board.
+func GetProjectDefaults(baseDir string) (query, board string, err error) {
+	cfg, err := Load(baseDir)
+	if err != nil {
+		return "", "", err
+	}
+	return cfg.ProjectDefaultQuery, cfg.ProjectDefaultBoard, nil
+}
+
+// SetProjectDefaults caches the project-wide default query and board fetched
+// from the sync server.
+func SetProjectDefaults(baseDir, query, board string) error {
+	return withConfigLock(baseDir, func() error {
+		cfg, err := Load(baseDir)
+		if err != nil {
+			return err
+		}
+		cfg.ProjectDefaultQuery = query
+		cfg.ProjectDefaultBoard = board
+		return Save(baseDir, cfg)
+	})
+}
+
+// ApplyProjectSettings caches the project settings shared through the sync
+// server. They are kept apart from the local settings, which take
+// precedence (see GetRequireCloseReason and GetWorkflow); nil clears a
+// setting the server no longer has.
+func ApplyProjectSettings(baseDir string, requireCloseReason *bool, workflow *models.WorkflowConfig) error {
+	return withConfigLock(baseDir, func() error {
+		cfg, err := Load(baseDir)
+		if err != nil {
+			return err
+		}
+		cfg.ProjectRequireCloseReason = requireCloseReason
+		cfg.ProjectWorkflow = workflow
+		return Save(baseDir, cfg)
+	})
+}
+
+// GetDefaultQuery returns the TDQ expression td list and td query start
+// from, or "" when none is set.
+func GetDefaultQuery(baseDir string) (string, error) {
+	cfg, err := Load(baseDir)
+	if err != nil {
+		return "", err
+	}
+	return cfg.DefaultQuery, nil
+}
+
+// SetDefaultQuery sets the default query. An empty query clears it.
+func SetDefaultQuery(baseDir, query string) error {
+	return withConfigLock(baseDir, func() error {
+		cfg, err := Load(baseDir)
+		if err != nil {
+			return err
+		}
+		cfg.DefaultQuery = query
 		return Save(baseDir, cfg)
 	})
 }
@@ -272,6 +355,132 @@ func GetTitleLengthLimits(baseDir string) (min, max int, err error) {
 	return min, max, nil
 }
 
+// GetIDFormat returns the prefix and format for new issue IDs (with
+// defaults). A prefix given without its trailing "-" gets one. Invalid
+// settings return the defaults along with an error.
+func GetIDFormat(baseDir string) (prefix, format string, err error) {
+	cfg, err := Load(baseDir)
+	if err != nil {
+		return DefaultIDPrefix, IDFormatHex, err
+	}
+
+	prefix = cfg.IDPrefix
+	if prefix == "" {
+		prefix = DefaultIDPrefix
+	} else if prefix[len(prefix)-1] != '-' {
+		prefix += "-"
+	}
+	if !idPrefixPattern.MatchString(prefix) {
+		return DefaultIDPrefix, IDFormatHex, fmt.Errorf("invalid id_prefix %q: use letters and digits, e.g. \"PROJ-\"", cfg.IDPrefix)
+	}
+
+	format = cfg.IDFormat
+	switch format {
+	case "":
+		format = IDFormatHex
+	case IDFormatHex, IDFormatSeq:
+	default:
+		return DefaultIDPrefix, IDFormatHex, fmt.Errorf("invalid id_format %q: use %q or %q", cfg.IDFormat, IDFormatHex, IDFormatSeq)
+	}
+	return prefix, format, nil
+}
+
+// GetWIPLimit returns the per-session WIP limit from config (with default).
+// A result of 0 or less means the limit is disabled.
+func GetWIPLimit(baseDir string) (int, error) {
+	cfg, err := Load(baseDir)
+	if err != nil {
+		return DefaultWIPLimit, err
+	}
+	switch {
+	case cfg.WIPLimit == 0:
+		return DefaultWIPLimit, nil
+	case cfg.WIPLimit < 0:
+		return 0, nil
+	}
+	return cfg.WIPLimit, nil
+}
+
+// GetCascadeApproval reports whether approving the last approved child of an
+// epic should approve the epic too.
+func GetCascadeApproval(baseDir string) (bool, error) {
+	cfg, err := Load(baseDir)
+	if err != nil {
+		return false, err
+	}
+	return cfg.CascadeApproval, nil
+}
+
+// GetRequireCloseReason reports whether closing or rejecting an issue needs a
+// reason. Without a local setting the synced project setting applies.
+func GetRequireCloseReason(baseDir string) (bool, error) {
+	cfg, err := Load(baseDir)
+	if err != nil {
+		return false, err
+	}
+	if !cfg.RequireCloseReason && cfg.ProjectRequireCloseReason != nil {
+		return *cfg.ProjectRequireCloseReason, nil
+	}
+	return cfg.RequireCloseReason, nil
+}
+
+// GetWorkflow returns the project's custom workflow, or nil when only the
+// built-in workflow is used. A local workflow wins over the synced one.
+func GetWorkflow(baseDir string) (*models.WorkflowConfig, error) {
+	cfg, err := Load(baseDir)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Workflow != nil {
+		return cfg.Workflow, nil
+	}
+	return cfg.ProjectWorkflow, nil
+}
+
+// GetLabelColors returns the explicit label colors, keyed by label.
+func GetLabelColors(baseDir string) (map[string]string, error) {
+	cfg, err := Load(baseDir)
+	if err != nil {
+		return nil, err
+	}
+	return cfg.LabelColors, nil
+}
+
+// SetLabelColor sets the color a label renders in. An empty color removes
+// the entry, returning the label to its hash-derived default.
+func SetLabelColor(baseDir, label, color string) error {
+	return withConfigLock(baseDir, func() error {
+		cfg, err := Load(baseDir)
+		if err != nil {
+			return err
+		}
+		if color == "" {
+			delete(cfg.LabelColors, label)
+			if len(cfg.LabelColors) == 0 {
+				cfg.LabelColors = nil
+			}
+			return Save(baseDir, cfg)
+		}
+		if cfg.LabelColors == nil {
+			cfg.LabelColors = make(map[string]string)
+		}
+		cfg.LabelColors[label] = color
+		return Save(baseDir, cfg)
+	})
+}
+
+// SetLastExportSeq records the action_log cursor of the latest JSON export.
+func SetLastExportSeq(baseDir string, seq int64) error {
+	return withConfigLock(baseDir, func() error {
+		cfg, err := Load(baseDir)
+		if err != nil {
+			return err
+		}
+		cfg.LastExportSeq = seq
+		return Save(baseDir, cfg)
+	})
+}
+
 // GetFeatureFlag returns a feature flag from local config.
 // The second return value indicates whether the flag is explicitly set.
 func GetFeatureFlag(baseDir, name string) (bool, bool, error) {
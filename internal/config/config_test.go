@@ -926,3 +926,34 @@ func TestGettingStartedSeen(t *testing.T) {
 		}
 	})
 }
+
+func TestGetIDFormat(t *testing.T) {
+	tests := []struct {
+		name       string
+		cfg        models.Config
+		wantPrefix string
+		wantFormat string
+		wantErr    bool
+	}{
+		{"defaults", models.Config{}, DefaultIDPrefix, IDFormatHex, false},
+		{"prefix gets trailing dash", models.Config{IDPrefix: "PROJ"}, "PROJ-", IDFormatHex, false},
+		{"sequential", models.Config{IDPrefix: "ops-", IDFormat: IDFormatSeq}, "ops-", IDFormatSeq, false},
+		{"invalid prefix", models.Config{IDPrefix: "my proj"}, DefaultIDPrefix, IDFormatHex, true},
+		{"invalid format", models.Config{IDFormat: "uuid"}, DefaultIDPrefix, IDFormatHex, true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := Save(dir, &tc.cfg); err != nil {
+				t.Fatalf("Save failed: %v", err)
+			}
+			prefix, format, err := GetIDFormat(dir)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tc.wantErr)
+			}
+			if prefix != tc.wantPrefix || format != tc.wantFormat {
+				t.Errorf("GetIDFormat = %q, %q; want %q, %q", prefix, format, tc.wantPrefix, tc.wantFormat)
+			}
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/marcus/td/internal/models"
@@ -125,6 +127,33 @@ func (db *DB) GetLogsByWorkSession(wsID string) ([]models.Log, error) {
 	return logs, nil
 }
 
+// GetLogsBySession retrieves every log written by a session, oldest first
+func (db *DB) GetLogsBySession(sessionID string) ([]models.Log, error) {
+	query := `SELECT CAST(id AS TEXT), issue_id, session_id, work_session_id, message, type, timestamp
+	          FROM logs WHERE session_id = ? ORDER BY timestamp`
+
+	rows, err := db.conn.Query(query, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var logs []models.Log
+	for rows.Next() {
+		var log models.Log
+		err := rows.Scan(&log.ID, &log.IssueID, &log.SessionID, &log.WorkSessionID, &log.Message, &log.Type, &log.Timestamp)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, log)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
 // GetRecentLogsAll returns recent logs across all issues
 func (db *DB) GetRecentLogsAll(limit int) ([]models.Log, error) {
 	query := `SELECT CAST(id AS TEXT), issue_id, session_id, work_session_id, message, type, timestamp
@@ -158,6 +187,59 @@ func (db *DB) GetRecentLogsAll(limit int) ([]models.Log, error) {
 	return logs, nil
 }
 
+// LogFilter narrows GetLogsSince to one issue and/or one log type; empty
+// fields match everything.
+type LogFilter struct {
+	IssueID string
+	Type    models.LogType
+}
+
+// GetLogsSince returns logs across all issues added after the log sequence
+// number afterSeq (its rowid), oldest first. With limit > 0 only the newest
+// limit of them are returned. lastSeq is the sequence number of the newest
+// log returned, or afterSeq when there is none, so passing it back on the
+// next call yields only logs added since.
+func (db *DB) GetLogsSince(afterSeq int64, filter LogFilter, limit int) (logs []models.Log, lastSeq int64, err error) {
+	query := `SELECT rowid, CAST(id AS TEXT), issue_id, session_id, work_session_id, message, type, timestamp
+	          FROM logs WHERE rowid > ?`
+	args := []interface{}{afterSeq}
+	if filter.IssueID != "" {
+		query += " AND issue_id = ?"
+		args = append(args, NormalizeIssueID(filter.IssueID))
+	}
+	if filter.Type != "" {
+		query += " AND type = ?"
+		args = append(args, filter.Type)
+	}
+	query += " ORDER BY rowid DESC"
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		return nil, afterSeq, err
+	}
+	defer rows.Close()
+
+	lastSeq = afterSeq
+	for rows.Next() {
+		var log models.Log
+		var seq int64
+		if err := rows.Scan(&seq, &log.ID, &log.IssueID, &log.SessionID, &log.WorkSessionID, &log.Message, &log.Type, &log.Timestamp); err != nil {
+			return nil, afterSeq, err
+		}
+		lastSeq = max(lastSeq, seq)
+		logs = append(logs, log)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, afterSeq, err
+	}
+	slices.Reverse(logs)
+	return logs, lastSeq, nil
+}
+
 // GetLogByID retrieves a single log entry by ID
 func (db *DB) GetLogByID(id string) (*models.Log, error) {
 	var log models.Log
@@ -340,6 +422,65 @@ func (db *DB) GetHandoffs(issueID string) ([]models.Handoff, error) {
 	return handoffs, nil
 }
 
+// GenerateHandoffDraft assembles an unsaved handoff for an issue from its
+// current state, for an agent to edit before recording it:
+//   - Done: closed subtasks, then progress logs
+//   - Remaining: subtasks that are not closed
+//   - Decisions: decision logs
+//   - Uncertain: blocker logs
+//
+// Only logs written after the issue's latest handoff are used, since
+// earlier ones are already covered by it.
+func (db *DB) GenerateHandoffDraft(issueID, sessionID string) (*models.Handoff, error) {
+	issue, err := db.GetIssue(issueID)
+	if err != nil {
+		return nil, err
+	}
+	draft := &models.Handoff{IssueID: issue.ID, SessionID: sessionID}
+
+	children, err := db.GetDirectChildren(issue.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get subtasks: %w", err)
+	}
+	for _, child := range children {
+		item := fmt.Sprintf("%s: %s", child.ID, child.Title)
+		if child.Status == models.StatusClosed {
+			draft.Done = append(draft.Done, item)
+		} else {
+			draft.Remaining = append(draft.Remaining, item)
+		}
+	}
+
+	var since time.Time
+	latest, err := db.GetLatestHandoff(issue.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get latest handoff: %w", err)
+	}
+	if latest != nil {
+		since = latest.Timestamp
+	}
+
+	logs, err := db.GetLogs(issue.ID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("get logs: %w", err)
+	}
+	for _, log := range logs {
+		if !log.Timestamp.After(since) {
+			continue
+		}
+		switch log.Type {
+		case models.LogTypeProgress:
+			draft.Done = append(draft.Done, log.Message)
+		case models.LogTypeDecision:
+			draft.Decisions = append(draft.Decisions, log.Message)
+		case models.LogTypeBlocker:
+			draft.Uncertain = append(draft.Uncertain, log.Message)
+		}
+	}
+
+	return draft, nil
+}
+
 // DeleteHandoff removes a handoff by ID (for undo support)
 func (db *DB) DeleteHandoff(handoffID string) error {
 	return db.withWriteLock(func() error {
@@ -401,6 +542,17 @@ func (db *DB) AddComment(comment *models.Comment) error {
 		comment.IssueID = NormalizeIssueID(comment.IssueID)
 		comment.CreatedAt = time.Now()
 
+		if comment.ParentCommentID != "" {
+			var parentIssue string
+			err := db.conn.QueryRow(`SELECT issue_id FROM comments WHERE id = ?`, comment.ParentCommentID).Scan(&parentIssue)
+			if err == sql.ErrNoRows || (err == nil && parentIssue != comment.IssueID) {
+				return fmt.Errorf("comment %s not found on %s", comment.ParentCommentID, comment.IssueID)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
 		id, err := generateCommentID()
 		if err != nil {
 			return fmt.Errorf("generate ID: %w", err)
@@ -408,9 +560,9 @@ func (db *DB) AddComment(comment *models.Comment) error {
 		comment.ID = id
 
 		_, err = db.conn.Exec(`
-			INSERT INTO comments (id, issue_id, session_id, text, created_at)
-			VALUES (?, ?, ?, ?, ?)
-		`, comment.ID, comment.IssueID, comment.SessionID, comment.Text, comment.CreatedAt)
+			INSERT INTO comments (id, issue_id, session_id, text, parent_comment_id, created_at)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`, comment.ID, comment.IssueID, comment.SessionID, comment.Text, comment.ParentCommentID, comment.CreatedAt)
 		if err != nil {
 			return err
 		}
@@ -419,10 +571,14 @@ func (db *DB) AddComment(comment *models.Comment) error {
 		if err != nil {
 			return fmt.Errorf("generate action ID: %w", err)
 		}
-		newData, _ := json.Marshal(map[string]interface{}{
+		data := map[string]interface{}{
 			"id": comment.ID, "issue_id": comment.IssueID, "session_id": comment.SessionID,
 			"text": comment.Text, "created_at": comment.CreatedAt,
-		})
+		}
+		if comment.ParentCommentID != "" {
+			data["parent_comment_id"] = comment.ParentCommentID
+		}
+		newData, _ := json.Marshal(data)
 		actionTS := actionLogTimestampNow()
 		_, err = db.conn.Exec(`INSERT INTO action_log (id, session_id, action_type, entity_type, entity_id, previous_data, new_data, timestamp, undone) VALUES (?, ?, ?, ?, ?, ?, ?, ?, 0)`,
 			actionID, comment.SessionID, "create", "comments", comment.ID, "", string(newData), actionTS)
@@ -437,7 +593,7 @@ func (db *DB) AddComment(comment *models.Comment) error {
 // GetComments retrieves comments for an issue
 func (db *DB) GetComments(issueID string) ([]models.Comment, error) {
 	rows, err := db.conn.Query(`
-		SELECT CAST(id AS TEXT), issue_id, session_id, text, created_at
+		SELECT CAST(id AS TEXT), issue_id, session_id, text, COALESCE(parent_comment_id, ''), created_at
 		FROM comments WHERE issue_id = ? ORDER BY created_at
 	`, issueID)
 	if err != nil {
@@ -448,7 +604,7 @@ func (db *DB) GetComments(issueID string) ([]models.Comment, error) {
 	var comments []models.Comment
 	for rows.Next() {
 		var c models.Comment
-		if err := rows.Scan(&c.ID, &c.IssueID, &c.SessionID, &c.Text, &c.CreatedAt); err != nil {
+		if err := rows.Scan(&c.ID, &c.IssueID, &c.SessionID, &c.Text, &c.ParentCommentID, &c.CreatedAt); err != nil {
 			return nil, err
 		}
 		comments = append(comments, c)
@@ -461,7 +617,7 @@ func (db *DB) GetComments(issueID string) ([]models.Comment, error) {
 
 // GetRecentCommentsAll returns recent comments across all issues
 func (db *DB) GetRecentCommentsAll(limit int) ([]models.Comment, error) {
-	query := `SELECT CAST(id AS TEXT), issue_id, session_id, text, created_at
+	query := `SELECT CAST(id AS TEXT), issue_id, session_id, text, COALESCE(parent_comment_id, ''), created_at
 	          FROM comments ORDER BY created_at DESC`
 	args := []interface{}{}
 
@@ -479,7 +635,7 @@ func (db *DB) GetRecentCommentsAll(limit int) ([]models.Comment, error) {
 	var comments []models.Comment
 	for rows.Next() {
 		var c models.Comment
-		if err := rows.Scan(&c.ID, &c.IssueID, &c.SessionID, &c.Text, &c.CreatedAt); err != nil {
+		if err := rows.Scan(&c.ID, &c.IssueID, &c.SessionID, &c.Text, &c.ParentCommentID, &c.CreatedAt); err != nil {
 			return nil, err
 		}
 		comments = append(comments, c)
@@ -494,9 +650,9 @@ func (db *DB) GetRecentCommentsAll(limit int) ([]models.Comment, error) {
 func (db *DB) GetCommentByID(id string) (*models.Comment, error) {
 	var c models.Comment
 	err := db.conn.QueryRow(`
-		SELECT CAST(id AS TEXT), issue_id, session_id, text, created_at
+		SELECT CAST(id AS TEXT), issue_id, session_id, text, COALESCE(parent_comment_id, ''), created_at
 		FROM comments WHERE id = ?
-	`, id).Scan(&c.ID, &c.IssueID, &c.SessionID, &c.Text, &c.CreatedAt)
+	`, id).Scan(&c.ID, &c.IssueID, &c.SessionID, &c.Text, &c.ParentCommentID, &c.CreatedAt)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -512,9 +668,9 @@ func (db *DB) DeleteCommentLogged(commentID, sessionID string) error {
 		// Capture the comment before deletion
 		var c models.Comment
 		err := db.conn.QueryRow(`
-			SELECT CAST(id AS TEXT), issue_id, session_id, text, created_at
+			SELECT CAST(id AS TEXT), issue_id, session_id, text, COALESCE(parent_comment_id, ''), created_at
 			FROM comments WHERE id = ?
-		`, commentID).Scan(&c.ID, &c.IssueID, &c.SessionID, &c.Text, &c.CreatedAt)
+		`, commentID).Scan(&c.ID, &c.IssueID, &c.SessionID, &c.Text, &c.ParentCommentID, &c.CreatedAt)
 		if err == sql.ErrNoRows {
 			return fmt.Errorf("comment not found: %s", commentID)
 		}
@@ -533,10 +689,14 @@ func (db *DB) DeleteCommentLogged(commentID, sessionID string) error {
 		if err != nil {
 			return fmt.Errorf("generate action ID: %w", err)
 		}
-		previousData, _ := json.Marshal(map[string]interface{}{
+		prev := map[string]interface{}{
 			"id": c.ID, "issue_id": c.IssueID, "session_id": c.SessionID,
 			"text": c.Text, "created_at": c.CreatedAt,
-		})
+		}
+		if c.ParentCommentID != "" {
+			prev["parent_comment_id"] = c.ParentCommentID
+		}
+		previousData, _ := json.Marshal(prev)
 		actionTS := actionLogTimestampNow()
 		_, err = db.conn.Exec(`INSERT INTO action_log (id, session_id, action_type, entity_type, entity_id, previous_data, new_data, timestamp, undone) VALUES (?, ?, ?, ?, ?, ?, ?, ?, 0)`,
 			actionID, sessionID, "delete", "comments", commentID, string(previousData), "", actionTS)
@@ -712,9 +872,10 @@ func (db *DB) GetActionLogByID(id string) (*models.ActionLog, error) {
 	return &action, nil
 }
 
-// GetRejectedInProgressIssueIDs returns IDs of open or in_progress issues that have a
-// recent ActionReject without a subsequent ActionReview (needs rework).
+// GetRejectedInProgressIssueIDs returns IDs of open or in_progress issues whose
+// latest reject is newer than their latest review or approval (needs rework).
 // Rejected issues are reset to open; they may then be picked up (in_progress).
+// Undone actions are ignored on both sides.
 func (db *DB) GetRejectedInProgressIssueIDs() (map[string]bool, error) {
 	query := `
 		SELECT DISTINCT i.id FROM issues i
@@ -724,7 +885,9 @@ func (db *DB) GetRejectedInProgressIssueIDs() (map[string]bool, error) {
 			WHERE al.entity_id = i.id AND al.action_type = 'reject' AND al.undone = 0
 			  AND NOT EXISTS (
 				SELECT 1 FROM action_log al2
-				WHERE al2.entity_id = i.id AND al2.action_type = 'review'
+				WHERE al2.entity_id = i.id
+				  AND al2.action_type IN ('review', 'approve', 'review_approve', 'close_after_review')
+				  AND al2.undone = 0
 				  AND al2.timestamp > al.timestamp
 			  )
 		  )
@@ -801,3 +964,94 @@ func (db *DB) GetStartSnapshot(issueID string) (*models.GitSnapshot, error) {
 
 	return &snapshot, nil
 }
+
+// GetActionSeq returns the newest action_log sequence number (its rowid), or
+// 0 for an empty log. Used as the cursor for incremental exports.
+func (db *DB) GetActionSeq() (int64, error) {
+	var seq int64
+	err := db.conn.QueryRow(`SELECT COALESCE(MAX(rowid), 0) FROM action_log`).Scan(&seq)
+	return seq, err
+}
+
+// GetStatusChangedAt returns, for each of the given issues, when its status
+// last changed according to the action log. Undone actions are ignored.
+// Issues whose status never changed are absent; they have been in their
+// status since they were created.
+func (db *DB) GetStatusChangedAt(ids []string) (map[string]time.Time, error) {
+	result := make(map[string]time.Time)
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		placeholders[i] = "?"
+		args[i] = NormalizeIssueID(id)
+	}
+
+	// Review actions wrap the new issue snapshot as {"issue": {...}}
+	rows, err := db.conn.Query(fmt.Sprintf(`
+		SELECT entity_id, timestamp FROM (
+			SELECT entity_id, timestamp,
+				json_extract(previous_data, '$.status') AS old_status,
+				COALESCE(json_extract(new_data, '$.status'), json_extract(new_data, '$.issue.status')) AS new_status
+			FROM action_log
+			WHERE entity_type IN ('issue', 'issues') AND undone = 0 AND entity_id IN (%s)
+				AND json_valid(previous_data) AND json_valid(new_data)
+		)
+		WHERE new_status IS NOT NULL AND new_status IS NOT old_status
+		ORDER BY timestamp`, strings.Join(placeholders, ",")), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		var ts time.Time
+		if err := rows.Scan(&id, &ts); err != nil {
+			return nil, err
+		}
+		result[id] = ts
+	}
+	return result, rows.Err()
+}
+
+// GetIssueIDsChangedSince returns the IDs of issues touched by action_log
+// entries after afterSeq, or after the time since when it is non-zero. Changes
+// to an issue's logs, handoffs, comments and dependencies count as changes to
+// the issue. IDs are returned in the order they were first touched.
+func (db *DB) GetIssueIDsChangedSince(afterSeq int64, since time.Time) ([]string, error) {
+	where, arg := `rowid > ?`, any(afterSeq)
+	if !since.IsZero() {
+		where, arg = `timestamp > ?`, formatActionLogTimestamp(since)
+	}
+	rows, err := db.conn.Query(`
+		SELECT issue_id FROM (
+			SELECT rowid AS seq, CASE
+				WHEN entity_type IN ('issue', 'issues') THEN entity_id
+				WHEN json_valid(new_data) AND json_extract(new_data, '$.issue_id') IS NOT NULL THEN json_extract(new_data, '$.issue_id')
+				WHEN json_valid(previous_data) THEN json_extract(previous_data, '$.issue_id')
+			END AS issue_id
+			FROM action_log WHERE `+where+`
+		)
+		WHERE issue_id IS NOT NULL AND issue_id != ''
+		GROUP BY issue_id
+		ORDER BY MIN(seq)
+	`, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
@@ -1,6 +1,8 @@
 package db
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -748,6 +750,96 @@ func TestAddHandoff_Basic(t *testing.T) {
 	}
 }
 
+func TestGenerateHandoffDraft(t *testing.T) {
+	dir := t.TempDir()
+	db, err := Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer db.Close()
+
+	parent := &models.Issue{Title: "Parser", Status: models.StatusInProgress}
+	if err := db.CreateIssue(parent); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	closedChild := &models.Issue{Title: "Lexer", ParentID: parent.ID, Status: models.StatusClosed}
+	openChild := &models.Issue{Title: "Error recovery", ParentID: parent.ID, Status: models.StatusOpen}
+	for _, child := range []*models.Issue{closedChild, openChild} {
+		if err := db.CreateIssue(child); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	addLog := func(msg string, typ models.LogType) {
+		t.Helper()
+		if err := db.AddLog(&models.Log{IssueID: parent.ID, SessionID: "ses_a", Message: msg, Type: typ}); err != nil {
+			t.Fatalf("AddLog failed: %v", err)
+		}
+	}
+
+	// Logs before the latest handoff are already covered by it
+	addLog("Sketched grammar", models.LogTypeProgress)
+	if err := db.AddHandoff(&models.Handoff{IssueID: parent.ID, SessionID: "ses_a", Done: []string{"Sketched grammar"}}); err != nil {
+		t.Fatalf("AddHandoff failed: %v", err)
+	}
+	addLog("Parsed expressions", models.LogTypeProgress)
+	addLog("Use Pratt parsing", models.LogTypeDecision)
+	addLog("Unclear how to report EOF errors", models.LogTypeBlocker)
+	addLog("Maybe precedence is off", models.LogTypeHypothesis)
+
+	draft, err := db.GenerateHandoffDraft(parent.ID, "ses_b")
+	if err != nil {
+		t.Fatalf("GenerateHandoffDraft failed: %v", err)
+	}
+
+	if draft.IssueID != parent.ID || draft.SessionID != "ses_b" {
+		t.Errorf("draft issue/session = %s/%s, want %s/ses_b", draft.IssueID, draft.SessionID, parent.ID)
+	}
+	if draft.ID != "" {
+		t.Errorf("draft should not be saved, got ID %q", draft.ID)
+	}
+	checks := []struct {
+		name      string
+		got, want []string
+	}{
+		{"done", draft.Done, []string{closedChild.ID + ": Lexer", "Parsed expressions"}},
+		{"remaining", draft.Remaining, []string{openChild.ID + ": Error recovery"}},
+		{"decisions", draft.Decisions, []string{"Use Pratt parsing"}},
+		{"uncertain", draft.Uncertain, []string{"Unclear how to report EOF errors"}},
+	}
+	for _, c := range checks {
+		if strings.Join(c.got, "|") != strings.Join(c.want, "|") {
+			t.Errorf("%s = %q, want %q", c.name, c.got, c.want)
+		}
+	}
+}
+
+func TestGenerateHandoffDraft_NoHistory(t *testing.T) {
+	dir := t.TempDir()
+	db, err := Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer db.Close()
+
+	issue := &models.Issue{Title: "Fresh"}
+	if err := db.CreateIssue(issue); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	draft, err := db.GenerateHandoffDraft(issue.ID, "ses_a")
+	if err != nil {
+		t.Fatalf("GenerateHandoffDraft failed: %v", err)
+	}
+	if len(draft.Done)+len(draft.Remaining)+len(draft.Decisions)+len(draft.Uncertain) != 0 {
+		t.Errorf("expected empty draft, got %+v", draft)
+	}
+
+	if _, err := db.GenerateHandoffDraft("td-missing", "ses_a"); err == nil {
+		t.Error("expected error for unknown issue")
+	}
+}
+
 func TestAddHandoff_CreatesActionLog(t *testing.T) {
 	dir := t.TempDir()
 	db, err := Initialize(dir)
@@ -1192,6 +1284,72 @@ func TestGetComments_Basic(t *testing.T) {
 	}
 }
 
+func TestAddComment_Reply(t *testing.T) {
+	dir := t.TempDir()
+	db, err := Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer db.Close()
+
+	issue := &models.Issue{Title: "Test Issue"}
+	other := &models.Issue{Title: "Other Issue"}
+	for _, iss := range []*models.Issue{issue, other} {
+		if err := db.CreateIssue(iss); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	top := &models.Comment{IssueID: issue.ID, SessionID: "ses_a", Text: "Top level"}
+	if err := db.AddComment(top); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+	second := &models.Comment{IssueID: issue.ID, SessionID: "ses_a", Text: "Second top level"}
+	if err := db.AddComment(second); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+	reply := &models.Comment{IssueID: issue.ID, SessionID: "ses_b", Text: "Reply", ParentCommentID: top.ID}
+	if err := db.AddComment(reply); err != nil {
+		t.Fatalf("AddComment reply failed: %v", err)
+	}
+
+	got, err := db.GetCommentByID(reply.ID)
+	if err != nil || got == nil {
+		t.Fatalf("GetCommentByID failed: %v", err)
+	}
+	if got.ParentCommentID != top.ID {
+		t.Errorf("ParentCommentID = %q, want %q", got.ParentCommentID, top.ID)
+	}
+
+	// Stored order stays chronological; threading places the reply under its parent
+	comments, err := db.GetComments(issue.ID)
+	if err != nil {
+		t.Fatalf("GetComments failed: %v", err)
+	}
+	var stored []string
+	for _, c := range comments {
+		stored = append(stored, c.Text)
+	}
+	if strings.Join(stored, ",") != "Top level,Second top level,Reply" {
+		t.Errorf("GetComments order = %v", stored)
+	}
+	var threaded []string
+	for _, c := range models.ThreadComments(comments) {
+		threaded = append(threaded, fmt.Sprintf("%d:%s", c.Depth, c.Text))
+	}
+	if strings.Join(threaded, ",") != "0:Top level,1:Reply,0:Second top level" {
+		t.Errorf("threaded order = %v", threaded)
+	}
+
+	// Replies must target a comment on the same issue
+	if err := db.AddComment(&models.Comment{IssueID: other.ID, SessionID: "ses_b", Text: "Stray", ParentCommentID: top.ID}); err == nil {
+		t.Error("expected error replying to a comment on another issue")
+	}
+	if err := db.AddComment(&models.Comment{IssueID: issue.ID, SessionID: "ses_b", Text: "Stray", ParentCommentID: "cm-missing"}); err == nil {
+		t.Error("expected error replying to a missing comment")
+	}
+}
+
 func TestGetComments_ChronologicalOrder(t *testing.T) {
 	dir := t.TempDir()
 	db, err := Initialize(dir)
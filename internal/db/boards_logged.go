@@ -305,3 +305,85 @@ func (db *DB) DeleteBoardLogged(boardID, sessionID string) error {
 		return tx.Commit()
 	})
 }
+
+// PriorityChange is one issue's priority change from a board reprioritization.
+type PriorityChange struct {
+	IssueID string          `json:"id"`
+	From    models.Priority `json:"from"`
+	To      models.Priority `json:"to"`
+}
+
+// SetBoardPrioritiesLogged applies priority changes derived from boardID's
+// order in a single transaction, logging an update per issue. Every issue must
+// hold an explicit position on boardID, so a board's order can never
+// reprioritize issues it doesn't rank. Changes whose priority already matches
+// are skipped; the applied changes are returned.
+func (db *DB) SetBoardPrioritiesLogged(boardID string, changes []PriorityChange, sessionID string) ([]PriorityChange, error) {
+	var applied []PriorityChange
+	err := db.withWriteLock(func() error {
+		positions, err := db.queryBoardPositionsSorted(boardID)
+		if err != nil {
+			return err
+		}
+		onBoard := make(map[string]bool, len(positions))
+		for _, p := range positions {
+			onBoard[NormalizeIssueID(p.IssueID)] = true
+		}
+
+		var prevs, nexts []*models.Issue
+		now := time.Now()
+		for _, c := range changes {
+			id := NormalizeIssueID(c.IssueID)
+			if !onBoard[id] {
+				return fmt.Errorf("%s has no position on board %s", id, boardID)
+			}
+			prev, err := db.scanIssueRow(id)
+			if err != nil {
+				return err
+			}
+			if prev.Priority == c.To {
+				continue
+			}
+			next := *prev
+			next.Priority = c.To
+			next.UpdatedAt = now
+			prevs = append(prevs, prev)
+			nexts = append(nexts, &next)
+		}
+		if len(nexts) == 0 {
+			return nil
+		}
+
+		tx, err := db.conn.Begin()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = tx.Rollback() }()
+
+		actionTS := formatActionLogTimestamp(now)
+		for i, issue := range nexts {
+			if _, err := tx.Exec(`UPDATE issues SET priority = ?, updated_at = ? WHERE id = ?`,
+				issue.Priority, issue.UpdatedAt, issue.ID); err != nil {
+				return fmt.Errorf("update priority on %s: %w", issue.ID, err)
+			}
+			actionID, err := generateActionID()
+			if err != nil {
+				return fmt.Errorf("generate action ID: %w", err)
+			}
+			if _, err := tx.Exec(`INSERT INTO action_log (id, session_id, action_type, entity_type, entity_id, previous_data, new_data, timestamp, undone) VALUES (?, ?, ?, ?, ?, ?, ?, ?, 0)`,
+				actionID, sessionID, string(models.ActionUpdate), "issue", issue.ID, marshalIssue(prevs[i]), marshalIssue(issue), actionTS); err != nil {
+				return fmt.Errorf("log action: %w", err)
+			}
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+
+		for i, issue := range nexts {
+			db.supersedeIfReviewInvalidating(prevs[i], issue)
+			applied = append(applied, PriorityChange{IssueID: issue.ID, From: prevs[i].Priority, To: issue.Priority})
+		}
+		return nil
+	})
+	return applied, err
+}
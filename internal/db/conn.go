@@ -3,6 +3,7 @@ package db
 import (
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	_ "modernc.org/sqlite"
@@ -32,6 +33,11 @@ type OpenOptions struct {
 	// migration); this flag keeps td-d4a67c scope-limited to pragma
 	// centralization. Remove once td-4846e6 lands.
 	DisableForeignKeys bool
+
+	// CanonicalTimestamps binds time.Time arguments as RFC3339 UTC text
+	// (see formatTimestamp) instead of the driver's time.Time.String()
+	// default. Set for the local issues DB.
+	CanonicalTimestamps bool
 }
 
 // OpenSQLite opens a SQLite database at path and applies td's standard pragma
@@ -50,7 +56,11 @@ func OpenSQLite(path string, opts OpenOptions) (*sql.DB, error) {
 		dsn = path + "?mode=ro"
 	}
 
-	conn, err := sql.Open("sqlite", dsn)
+	driverName := "sqlite"
+	if opts.CanonicalTimestamps {
+		driverName = canonicalTimeDriverName
+	}
+	conn, err := sql.Open(driverName, dsn)
 	if err != nil {
 		return nil, fmt.Errorf("open database: %w", err)
 	}
@@ -96,3 +106,41 @@ func OpenSQLite(path string, opts OpenOptions) (*sql.DB, error) {
 
 	return conn, nil
 }
+
+// CheckIntegrity runs PRAGMA integrity_check against the SQLite file at path
+// without modifying it. It returns an error describing the first problems
+// SQLite reports, or nil if the database is intact.
+func CheckIntegrity(path string) error {
+	conn, err := OpenSQLite(path, OpenOptions{ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	rows, err := conn.Query("PRAGMA integrity_check")
+	if err != nil {
+		return fmt.Errorf("integrity check: %w", err)
+	}
+	defer rows.Close()
+
+	var problems []string
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return fmt.Errorf("integrity check: %w", err)
+		}
+		if line != "ok" {
+			problems = append(problems, line)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("integrity check: %w", err)
+	}
+	if len(problems) > 0 {
+		if len(problems) > 3 {
+			problems = append(problems[:3], fmt.Sprintf("(%d more)", len(problems)-3))
+		}
+		return fmt.Errorf("integrity check failed: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package db
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/marcus/td/internal/models"
+)
+
+func TestCheckIntegrity(t *testing.T) {
+	dir := t.TempDir()
+	database, err := Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if err := database.CreateIssue(&models.Issue{Title: "intact"}); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	database.Close()
+
+	path := filepath.Join(dir, ".todos", "issues.db")
+	if err := CheckIntegrity(path); err != nil {
+		t.Fatalf("CheckIntegrity on a fresh DB: %v", err)
+	}
+
+	// Scribble over a b-tree page past the header
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read db: %v", err)
+	}
+	pageSize := int(data[16])<<8 | int(data[17])
+	if len(data) < 3*pageSize {
+		t.Fatalf("db unexpectedly small: %d bytes", len(data))
+	}
+	for i := 2 * pageSize; i < 2*pageSize+64; i++ {
+		data[i] = 0xff
+	}
+	corrupt := filepath.Join(dir, "corrupt.db")
+	if err := os.WriteFile(corrupt, data, 0644); err != nil {
+		t.Fatalf("write corrupt db: %v", err)
+	}
+	if err := CheckIntegrity(corrupt); err == nil {
+		t.Fatal("CheckIntegrity on a corrupted DB returned nil")
+	}
+}
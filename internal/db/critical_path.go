@@ -0,0 +1,121 @@
+package db
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/marcus/td/internal/models"
+)
+
+// CriticalPath is the longest chain of dependencies among an epic's
+// remaining work, weighted by points.
+type CriticalPath struct {
+	// Issues is the chain in the order the work must happen: each issue
+	// depends on the one before it
+	Issues []models.Issue `json:"issues"`
+	Points int            `json:"points"`
+	// Cyclic reports that a dependency cycle was found; the link closing
+	// each cycle is ignored
+	Cyclic bool `json:"cyclic,omitempty"`
+}
+
+// CriticalPath returns the dependency chain among epicID's open descendants
+// (at any depth) with the most points in total. Issues without an estimate
+// count as zero, closed issues are left out, and dependencies on issues
+// outside the epic are ignored. Among chains with equal points the one with
+// more issues wins, so unestimated work still shows up, then the one ending
+// at the lowest issue ID. An epic with no open descendants has an empty path.
+func (db *DB) CriticalPath(epicID string) (*CriticalPath, error) {
+	descendants, err := db.GetDescendantIssues(epicID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get descendants of %s: %w", epicID, err)
+	}
+
+	issues := make(map[string]*models.Issue)
+	var ids []string
+	for _, issue := range descendants {
+		if issue.Status == models.StatusClosed {
+			continue
+		}
+		issues[issue.ID] = issue
+		ids = append(ids, issue.ID)
+	}
+	sort.Strings(ids)
+
+	deps := make(map[string][]string, len(ids))
+	for _, id := range ids {
+		depIDs, err := db.GetDependencies(id)
+		if err != nil {
+			return nil, fmt.Errorf("get dependencies of %s: %w", id, err)
+		}
+		for _, depID := range depIDs {
+			if issues[depID] != nil {
+				deps[id] = append(deps[id], depID)
+			}
+		}
+		sort.Strings(deps[id])
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(ids))
+	weight := make(map[string]int, len(ids))  // points of the longest chain ending at an issue
+	length := make(map[string]int, len(ids))  // issues in that chain
+	next := make(map[string]string, len(ids)) // the dependency that chain continues through
+	result := &CriticalPath{}
+
+	longer := func(a, b string) bool {
+		if weight[a] != weight[b] {
+			return weight[a] > weight[b]
+		}
+		return length[a] > length[b]
+	}
+
+	var visit func(id string)
+	visit = func(id string) {
+		state[id] = visiting
+		bestID := ""
+		for _, depID := range deps[id] {
+			switch state[depID] {
+			case visiting:
+				result.Cyclic = true
+				continue
+			case unvisited:
+				visit(depID)
+			}
+			if bestID == "" || longer(depID, bestID) {
+				bestID = depID
+			}
+		}
+		weight[id] = issues[id].Points + weight[bestID]
+		length[id] = 1 + length[bestID]
+		next[id] = bestID
+		state[id] = done
+	}
+
+	end := ""
+	for _, id := range ids {
+		if state[id] == unvisited {
+			visit(id)
+		}
+		if end == "" || longer(id, end) {
+			end = id
+		}
+	}
+	if end == "" {
+		return result, nil
+	}
+
+	result.Points = weight[end]
+	for id := end; id != ""; id = next[id] {
+		result.Issues = append(result.Issues, *issues[id])
+	}
+	// Walked from the last issue back; report in work order
+	for i, j := 0, len(result.Issues)-1; i < j; i, j = i+1, j-1 {
+		result.Issues[i], result.Issues[j] = result.Issues[j], result.Issues[i]
+	}
+	return result, nil
+}
@@ -0,0 +1,105 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/marcus/td/internal/models"
+)
+
+func TestCriticalPath(t *testing.T) {
+	database, err := Initialize(t.TempDir())
+	if err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	defer database.Close()
+
+	create := func(title, parentID string, points int, status models.Status) string {
+		issue := &models.Issue{Title: title, Type: models.TypeTask, Status: status, ParentID: parentID, Points: points}
+		if err := database.CreateIssue(issue); err != nil {
+			t.Fatalf("CreateIssue %q: %v", title, err)
+		}
+		return issue.ID
+	}
+	depend := func(issueID, dependsOnID string) {
+		if err := database.AddDependency(issueID, dependsOnID, "depends_on"); err != nil {
+			t.Fatalf("AddDependency: %v", err)
+		}
+	}
+
+	// A(3) <- B(5) <- C(2) <- G(1) is 11 points; D(8) <- F(unestimated) <- G is 9.
+	// C sits a level down, under story S.
+	epic := create("Epic", "", 0, models.StatusOpen)
+	story := create("Story", epic, 0, models.StatusOpen)
+	a := create("A", epic, 3, models.StatusOpen)
+	b := create("B", epic, 5, models.StatusInProgress)
+	c := create("C", story, 2, models.StatusOpen)
+	d := create("D", epic, 8, models.StatusOpen)
+	f := create("F", epic, 0, models.StatusOpen)
+	g := create("G", epic, 1, models.StatusOpen)
+	done := create("Done", epic, 100, models.StatusClosed)
+	outside := create("Outside", "", 50, models.StatusOpen)
+	depend(b, a)
+	depend(c, b)
+	depend(g, c)
+	depend(f, d)
+	depend(g, f)
+	depend(g, done)    // closed work doesn't count
+	depend(g, outside) // nor work outside the epic
+
+	path, err := database.CriticalPath(epic)
+	if err != nil {
+		t.Fatalf("CriticalPath: %v", err)
+	}
+	want := []string{a, b, c, g}
+	if len(path.Issues) != len(want) {
+		t.Fatalf("path = %v, want %v", pathIDs(path), want)
+	}
+	for i, id := range want {
+		if path.Issues[i].ID != id {
+			t.Fatalf("path = %v, want %v", pathIDs(path), want)
+		}
+	}
+	if path.Points != 11 || path.Cyclic {
+		t.Errorf("points = %d cyclic = %v, want 11 and no cycle", path.Points, path.Cyclic)
+	}
+
+	// Unestimated work at the end of a chain still belongs to it
+	h := create("H", epic, 0, models.StatusOpen)
+	depend(h, g)
+	path, err = database.CriticalPath(epic)
+	if err != nil {
+		t.Fatalf("CriticalPath: %v", err)
+	}
+	if got := pathIDs(path); len(got) != 5 || got[4] != h || path.Points != 11 {
+		t.Errorf("path = %v (%d points), want %v then %s at 11 points", got, path.Points, want, h)
+	}
+
+	// A cycle is reported and broken rather than looping
+	cyclic := create("Cyclic epic", "", 0, models.StatusOpen)
+	p := create("P", cyclic, 2, models.StatusOpen)
+	q := create("Q", cyclic, 3, models.StatusOpen)
+	depend(p, q)
+	depend(q, p)
+	path, err = database.CriticalPath(cyclic)
+	if err != nil {
+		t.Fatalf("CriticalPath with cycle: %v", err)
+	}
+	if !path.Cyclic || path.Points != 5 || len(path.Issues) != 2 {
+		t.Errorf("cyclic path = %v (%d points, cyclic %v), want both issues, 5 points, cyclic", pathIDs(path), path.Points, path.Cyclic)
+	}
+
+	// No open work, no path
+	empty := create("Empty epic", "", 0, models.StatusOpen)
+	path, err = database.CriticalPath(empty)
+	if err != nil || len(path.Issues) != 0 || path.Points != 0 {
+		t.Errorf("empty epic path = %+v (err %v), want empty", path, err)
+	}
+}
+
+func pathIDs(path *CriticalPath) []string {
+	var ids []string
+	for _, issue := range path.Issues {
+		ids = append(ids, issue.ID)
+	}
+	return ids
+}
@@ -39,8 +39,9 @@ func ResolveBaseDir(baseDir string) string {
 // FK enforcement (PRAGMA foreign_keys=ON) is the default from OpenSQLite.
 // Migration 30 (td-4846e6) cleans up pre-existing orphans and adds
 // ON DELETE CASCADE to child tables before this was flipped on.
+// time.Time arguments are stored as canonical RFC3339 UTC text.
 func openConn(dbPath string) (*sql.DB, error) {
-	return OpenSQLite(dbPath, OpenOptions{})
+	return OpenSQLite(dbPath, OpenOptions{CanonicalTimestamps: true})
 }
 
 // Open opens the database and runs any pending migrations
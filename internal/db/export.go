@@ -0,0 +1,65 @@
+package db
+
+import (
+	"fmt"
+	"os"
+)
+
+// exportTransientTables hold local state that means nothing outside this
+// checkout: the sync link and its history, and per-session focus.
+var exportTransientTables = []string{"sync_state", "sync_conflicts", "sync_history", "session_state"}
+
+// ExportSQLite writes a compacted, consistent copy of the database to path
+// with VACUUM INTO, so it is safe to take while other td processes are
+// writing. Local-only state is removed from the copy and actions are
+// marked unsynced, so the file can be shared as a standalone project.
+// path must not exist, and is removed again if the export fails.
+func (db *DB) ExportSQLite(path string) error {
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%s already exists", path)
+	}
+	if _, err := db.conn.Exec(`VACUUM INTO ?`, path); err != nil {
+		removeExportCopy(path)
+		return fmt.Errorf("copy database: %w", err)
+	}
+	// A half-scrubbed copy would still carry the sync link, so never leave one
+	if err := scrubExportCopy(path); err != nil {
+		removeExportCopy(path)
+		return err
+	}
+	return nil
+}
+
+// scrubExportCopy strips local-only state from the copy at path and
+// compacts it into a single file.
+func scrubExportCopy(path string) error {
+	conn, err := OpenSQLite(path, OpenOptions{})
+	if err != nil {
+		return fmt.Errorf("open copy: %w", err)
+	}
+	defer conn.Close()
+
+	for _, table := range exportTransientTables {
+		if _, err := conn.Exec(`DELETE FROM ` + table); err != nil {
+			return fmt.Errorf("clear %s: %w", table, err)
+		}
+	}
+	if _, err := conn.Exec(`UPDATE action_log SET synced_at = NULL, server_seq = NULL`); err != nil {
+		return fmt.Errorf("reset sync markers: %w", err)
+	}
+	if _, err := conn.Exec(`VACUUM`); err != nil {
+		return fmt.Errorf("compact copy: %w", err)
+	}
+	// Leave a single self-contained file rather than a WAL pair
+	if _, err := conn.Exec(`PRAGMA journal_mode=DELETE`); err != nil {
+		return fmt.Errorf("set journal mode: %w", err)
+	}
+	return nil
+}
+
+// removeExportCopy removes a failed copy and any SQLite sidecar files.
+func removeExportCopy(path string) {
+	for _, p := range []string{path, path + "-wal", path + "-shm", path + "-journal"} {
+		_ = os.Remove(p)
+	}
+}
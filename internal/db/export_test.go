@@ -0,0 +1,107 @@
+package db
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/marcus/td/internal/models"
+)
+
+func TestExportSQLite(t *testing.T) {
+	database, err := Initialize(t.TempDir())
+	if err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	defer database.Close()
+
+	var ids []string
+	for _, title := range []string{"First", "Second", "Third"} {
+		issue := &models.Issue{Title: title, Type: models.TypeTask, Status: models.StatusOpen}
+		if err := database.CreateIssueLogged(issue, "ses_export"); err != nil {
+			t.Fatalf("CreateIssueLogged: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+	if err := database.SetSyncState("proj_1"); err != nil {
+		t.Fatalf("SetSyncState: %v", err)
+	}
+	if err := database.SetFocus(SessionStateScope{SessionID: "ses_export"}, ids[0]); err != nil {
+		t.Fatalf("SetFocus: %v", err)
+	}
+	if _, err := database.conn.Exec(`UPDATE action_log SET synced_at = CURRENT_TIMESTAMP, server_seq = 7`); err != nil {
+		t.Fatalf("mark synced: %v", err)
+	}
+
+	// The copy opens as a project of its own
+	copyDir := t.TempDir()
+	path := filepath.Join(copyDir, dbFile)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := database.ExportSQLite(path); err != nil {
+		t.Fatalf("ExportSQLite: %v", err)
+	}
+	if _, err := os.Stat(path + "-wal"); err == nil {
+		t.Error("expected a single-file copy without a WAL")
+	}
+
+	exported, err := Open(copyDir)
+	if err != nil {
+		t.Fatalf("open copy: %v", err)
+	}
+	defer exported.Close()
+
+	issues, err := exported.ListIssues(ListIssuesOptions{})
+	if err != nil {
+		t.Fatalf("ListIssues: %v", err)
+	}
+	if len(issues) != len(ids) {
+		t.Fatalf("expected %d issues in copy, got %d", len(ids), len(issues))
+	}
+	for _, id := range ids {
+		if _, err := exported.GetIssue(id); err != nil {
+			t.Errorf("issue %s missing from copy: %v", id, err)
+		}
+	}
+
+	if state, err := exported.GetSyncState(); err != nil || state != nil {
+		t.Errorf("expected no sync link in copy, got %+v (err %v)", state, err)
+	}
+	var n int
+	if err := exported.conn.QueryRow(`SELECT COUNT(*) FROM session_state`).Scan(&n); err != nil || n != 0 {
+		t.Errorf("expected empty session_state, got %d rows (err %v)", n, err)
+	}
+	if err := exported.conn.QueryRow(`SELECT COUNT(*) FROM action_log WHERE synced_at IS NOT NULL OR server_seq IS NOT NULL`).Scan(&n); err != nil || n != 0 {
+		t.Errorf("expected unsynced actions, got %d synced (err %v)", n, err)
+	}
+	if err := exported.conn.QueryRow(`SELECT COUNT(*) FROM action_log`).Scan(&n); err != nil || n != len(ids) {
+		t.Errorf("expected %d actions kept, got %d (err %v)", len(ids), n, err)
+	}
+
+	if err := database.ExportSQLite(path); err == nil {
+		t.Error("expected exporting over an existing file to fail")
+	}
+}
+
+func TestExportSQLiteRemovesCopyOnFailure(t *testing.T) {
+	database, err := Initialize(t.TempDir())
+	if err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	defer database.Close()
+
+	// Without one of the local-only tables the copy can't be scrubbed
+	if _, err := database.conn.Exec(`DROP TABLE session_state`); err != nil {
+		t.Fatalf("drop table: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "export.db")
+	if err := database.ExportSQLite(path); err == nil {
+		t.Fatal("expected export to fail")
+	}
+	for _, p := range []string{path, path + "-wal", path + "-shm"} {
+		if _, err := os.Stat(p); !os.IsNotExist(err) {
+			t.Errorf("%s left behind after a failed export (stat err %v)", p, err)
+		}
+	}
+}
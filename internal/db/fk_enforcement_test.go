@@ -133,3 +133,138 @@ func TestFKEnforcement_ParentDeleteDoesNotCascade(t *testing.T) {
 		t.Errorf("expected child to survive parent delete; got %d rows", n)
 	}
 }
+
+// TestFKEnforcement_DeleteIssueCascadesDependenciesAndPositions verifies that
+// hard-deleting an issue removes the issue_dependencies rows on both sides
+// and its board_issue_positions rows, leaving unrelated rows alone.
+func TestFKEnforcement_DeleteIssueCascadesDependenciesAndPositions(t *testing.T) {
+	dir := t.TempDir()
+	database, err := Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	defer database.Close()
+
+	conn := database.Conn()
+
+	for _, stmt := range []string{
+		`INSERT INTO issues (id, title) VALUES ('td-a', 'A'), ('td-b', 'B'), ('td-c', 'C')`,
+		`INSERT INTO issue_dependencies (id, issue_id, depends_on_id, relation_type) VALUES
+			('dep-ab', 'td-a', 'td-b', 'depends_on'),
+			('dep-ca', 'td-c', 'td-a', 'depends_on'),
+			('dep-cb', 'td-c', 'td-b', 'depends_on')`,
+		`INSERT INTO boards (id, name, query) VALUES ('bd-x', 'X', '')`,
+		`INSERT INTO board_issue_positions (id, board_id, issue_id, position) VALUES
+			('bip-a', 'bd-x', 'td-a', 1),
+			('bip-b', 'bd-x', 'td-b', 2)`,
+	} {
+		if _, err := conn.Exec(stmt); err != nil {
+			t.Fatalf("seed: %v", err)
+		}
+	}
+
+	if _, err := conn.Exec(`DELETE FROM issues WHERE id = 'td-a'`); err != nil {
+		t.Fatalf("delete issue: %v", err)
+	}
+
+	var deps, positions int
+	if err := conn.QueryRow(`SELECT COUNT(*) FROM issue_dependencies WHERE issue_id = 'td-a' OR depends_on_id = 'td-a'`).Scan(&deps); err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.QueryRow(`SELECT COUNT(*) FROM board_issue_positions WHERE issue_id = 'td-a'`).Scan(&positions); err != nil {
+		t.Fatal(err)
+	}
+	if deps != 0 || positions != 0 {
+		t.Errorf("expected cascade delete; got %d dependency rows, %d position rows", deps, positions)
+	}
+
+	if err := conn.QueryRow(`SELECT COUNT(*) FROM issue_dependencies WHERE id = 'dep-cb'`).Scan(&deps); err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.QueryRow(`SELECT COUNT(*) FROM board_issue_positions WHERE id = 'bip-b'`).Scan(&positions); err != nil {
+		t.Fatal(err)
+	}
+	if deps != 1 || positions != 1 {
+		t.Errorf("unrelated rows removed; got %d dependency rows, %d position rows", deps, positions)
+	}
+}
+
+// TestFKEnforcement_DependencyOnMissingIssueRejected verifies that a
+// dependency pointing at a nonexistent issue fails, both through the raw
+// table and through AddDependency.
+func TestFKEnforcement_DependencyOnMissingIssueRejected(t *testing.T) {
+	dir := t.TempDir()
+	database, err := Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	defer database.Close()
+
+	if _, err := database.Conn().Exec(`INSERT INTO issues (id, title) VALUES ('td-real', 'R')`); err != nil {
+		t.Fatalf("insert issue: %v", err)
+	}
+
+	_, err = database.Conn().Exec(
+		`INSERT INTO issue_dependencies (id, issue_id, depends_on_id, relation_type) VALUES ('dep-bad', 'td-real', 'td-missing', 'depends_on')`,
+	)
+	if err == nil {
+		t.Fatalf("expected FK violation inserting dependency on missing issue, got nil")
+	}
+	if !strings.Contains(strings.ToLower(err.Error()), "foreign") && !strings.Contains(strings.ToLower(err.Error()), "constraint") {
+		t.Fatalf("expected FK/constraint error, got: %v", err)
+	}
+
+	if err := database.AddDependency("td-real", "td-missing", "depends_on"); err == nil {
+		t.Fatalf("expected AddDependency on missing issue to fail")
+	}
+}
+
+// TestFKEnforcement_MigrationRemovesOrphanDependenciesAndPositions seeds
+// dependency and position orphans with enforcement off, then reruns
+// migration 30 and checks they were cleaned before the FKs were rebuilt.
+func TestFKEnforcement_MigrationRemovesOrphanDependenciesAndPositions(t *testing.T) {
+	dir := t.TempDir()
+	database, err := Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	defer database.Close()
+
+	conn := database.Conn()
+
+	if _, err := conn.Exec("PRAGMA foreign_keys=OFF"); err != nil {
+		t.Fatalf("disable FKs: %v", err)
+	}
+	for _, stmt := range []string{
+		`INSERT INTO issues (id, title) VALUES ('td-kept', 'K')`,
+		`INSERT INTO issue_dependencies (id, issue_id, depends_on_id, relation_type) VALUES
+			('dep-orphan', 'td-kept', 'td-gone', 'depends_on')`,
+		`INSERT INTO board_issue_positions (id, board_id, issue_id, position) VALUES
+			('bip-orphan', 'bd-all-issues', 'td-gone', 1),
+			('bip-kept', 'bd-all-issues', 'td-kept', 2)`,
+	} {
+		if _, err := conn.Exec(stmt); err != nil {
+			t.Fatalf("seed orphans: %v", err)
+		}
+	}
+	if _, err := conn.Exec("PRAGMA foreign_keys=ON"); err != nil {
+		t.Fatalf("enable FKs: %v", err)
+	}
+
+	if err := database.migrateEnableFKEnforcement(); err != nil {
+		t.Fatalf("migrateEnableFKEnforcement: %v", err)
+	}
+
+	var orphans, kept int
+	if err := conn.QueryRow(`SELECT
+		(SELECT COUNT(*) FROM issue_dependencies WHERE id = 'dep-orphan') +
+		(SELECT COUNT(*) FROM board_issue_positions WHERE id = 'bip-orphan')`).Scan(&orphans); err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.QueryRow(`SELECT COUNT(*) FROM board_issue_positions WHERE id = 'bip-kept'`).Scan(&kept); err != nil {
+		t.Fatal(err)
+	}
+	if orphans != 0 || kept != 1 {
+		t.Errorf("expected orphans removed and valid rows kept; got orphans=%d kept=%d", orphans, kept)
+	}
+}
@@ -3,8 +3,14 @@ package db
 import (
 	"crypto/rand"
 	"crypto/sha256"
+	"database/sql"
 	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
 	"strings"
+
+	"github.com/marcus/td/internal/config"
 )
 
 const (
@@ -26,16 +32,101 @@ const (
 	wsiIDPrefix           = "wsi_"
 )
 
-// NormalizeIssueID ensures an issue ID has the td- prefix
-// Accepts bare hex IDs like "abc123" and returns "td-abc123"
+// NormalizeIssueID ensures an issue ID has a prefix.
+// Accepts bare hex IDs like "abc123" and returns "td-abc123". IDs that
+// already carry a prefix (td-, or a configured one like PROJ-) are unchanged.
 func NormalizeIssueID(id string) string {
-	if id == "" {
+	if id == "" || strings.Contains(id, "-") {
 		return id
 	}
-	if !strings.HasPrefix(id, idPrefix) {
-		return idPrefix + id
+	return idPrefix + id
+}
+
+// maxListedCandidates caps how many matches an ambiguous ID error lists.
+const maxListedCandidates = 10
+
+// AmbiguousIssueIDError is returned by ResolveIssueID when a partial ID
+// matches more than one issue.
+type AmbiguousIssueIDError struct {
+	Partial    string
+	Candidates []string // sorted
+}
+
+func (e *AmbiguousIssueIDError) Error() string {
+	listed := e.Candidates
+	more := ""
+	if len(listed) > maxListedCandidates {
+		more = fmt.Sprintf(" (and %d more)", len(listed)-maxListedCandidates)
+		listed = listed[:maxListedCandidates]
+	}
+	return fmt.Sprintf("ambiguous issue ID %q matches %s%s", e.Partial, strings.Join(listed, ", "), more)
+}
+
+// ResolveIssueID returns the ID of the one issue that partial refers to.
+// An exact match wins, deleted issues included, as with GetIssue. Otherwise
+// partial is matched against live issues: a bare "a3f" matches any ID whose
+// part after the prefix starts with a3f, "td-a3f" only IDs under td-, and a
+// numeric part such as "td-1" also matches the sequential ID td-001.
+// Several matches return an *AmbiguousIssueIDError listing them.
+func (db *DB) ResolveIssueID(partial string) (string, error) {
+	partial = strings.TrimSpace(partial)
+	if partial == "" {
+		return "", fmt.Errorf("issue not found: empty ID")
+	}
+
+	var id string
+	err := db.conn.QueryRow(`SELECT id FROM issues WHERE id = ?`, NormalizeIssueID(partial)).Scan(&id)
+	if err == nil {
+		return id, nil
+	}
+	if err != sql.ErrNoRows {
+		return "", err
+	}
+
+	prefix, suffix := "", partial
+	if i := strings.LastIndex(partial, "-"); i >= 0 {
+		prefix, suffix = partial[:i+1], partial[i+1:]
+	}
+	if suffix == "" {
+		return "", fmt.Errorf("issue not found: %s", partial)
+	}
+	suffix = strings.ToLower(suffix)
+	num, numErr := strconv.Atoi(suffix)
+
+	rows, err := db.conn.Query(`SELECT id FROM issues WHERE deleted_at IS NULL`)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	var matches []string
+	for rows.Next() {
+		if err := rows.Scan(&id); err != nil {
+			return "", err
+		}
+		i := strings.LastIndex(id, "-")
+		if prefix != "" && !strings.EqualFold(id[:i+1], prefix) {
+			continue
+		}
+		rest := strings.ToLower(id[i+1:])
+		if strings.HasPrefix(rest, suffix) {
+			matches = append(matches, id)
+		} else if n, err := strconv.Atoi(rest); err == nil && numErr == nil && n == num {
+			matches = append(matches, id)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("issue not found: %s", partial)
+	case 1:
+		return matches[0], nil
 	}
-	return id
+	sort.Strings(matches)
+	return "", &AmbiguousIssueIDError{Partial: partial, Candidates: matches}
 }
 
 // idGenerator is the function used to generate issue IDs.
@@ -56,6 +147,71 @@ func generateID() (string, error) {
 	return idGenerator()
 }
 
+// seqIDWidth is the minimum number of digits in a sequential issue ID, wide
+// enough that IDs sort in creation order for the first 99999 issues.
+const seqIDWidth = 5
+
+// seqCounterKeyPrefix prefixes the schema_info key holding the last number
+// handed out for an ID prefix.
+const seqCounterKeyPrefix = "issue_seq:"
+
+// newIssueID returns the ID for a new issue per the project's id_prefix and
+// id_format settings. Callers hold the write lock, so sequential IDs can't
+// race another local writer; their UNIQUE-constraint retry covers the rest.
+func (db *DB) newIssueID() (string, error) {
+	prefix, format, err := config.GetIDFormat(db.baseDir)
+	if err != nil {
+		return "", fmt.Errorf("issue ID settings: %w", err)
+	}
+	if format == config.IDFormatSeq {
+		return db.nextSequentialIssueID(prefix)
+	}
+	if prefix == idPrefix {
+		return generateID()
+	}
+	bytes := make([]byte, 3)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return prefix + hex.EncodeToString(bytes), nil
+}
+
+// nextSequentialIssueID returns prefix plus the next number in that prefix's
+// sequence. The last number handed out is kept in schema_info, so numbers are
+// never reused and other IDs that happen to be all digits, like the hex ID
+// td-123456, can't move the sequence. Numbers already taken, for instance by
+// an issue synced from another machine, are skipped.
+func (db *DB) nextSequentialIssueID(prefix string) (string, error) {
+	key := seqCounterKeyPrefix + prefix
+	n := 0
+	var value string
+	err := db.conn.QueryRow(`SELECT value FROM schema_info WHERE key = ?`, key).Scan(&value)
+	if err != nil && err != sql.ErrNoRows {
+		return "", err
+	}
+	if err == nil {
+		if n, err = strconv.Atoi(value); err != nil {
+			return "", fmt.Errorf("issue ID sequence for %s: %w", prefix, err)
+		}
+	}
+
+	for {
+		n++
+		id := fmt.Sprintf("%s%0*d", prefix, seqIDWidth, n)
+		var taken int
+		if err := db.conn.QueryRow(`SELECT COUNT(*) FROM issues WHERE id = ?`, id).Scan(&taken); err != nil {
+			return "", err
+		}
+		if taken > 0 {
+			continue
+		}
+		if _, err := db.conn.Exec(`INSERT OR REPLACE INTO schema_info (key, value) VALUES (?, ?)`, key, strconv.Itoa(n)); err != nil {
+			return "", err
+		}
+		return id, nil
+	}
+}
+
 // generateWSID generates a unique work session ID
 func generateWSID() (string, error) {
 	bytes := make([]byte, 2) // 4 hex characters
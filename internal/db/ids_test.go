@@ -0,0 +1,259 @@
+package db
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"sort"
+	"testing"
+
+	"github.com/marcus/td/internal/config"
+	"github.com/marcus/td/internal/models"
+)
+
+func TestCreateIssue_SequentialCustomPrefix(t *testing.T) {
+	dir := t.TempDir()
+	database, err := Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	// An issue created before the switch keeps its td- ID
+	legacy := &models.Issue{Title: "Legacy issue"}
+	if err := database.CreateIssue(legacy); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	if err := config.Save(dir, &models.Config{IDPrefix: "PROJ", IDFormat: config.IDFormatSeq}); err != nil {
+		t.Fatalf("config.Save failed: %v", err)
+	}
+
+	var ids []string
+	for i := 0; i < 4; i++ {
+		issue := &models.Issue{Title: "Sequenced issue"}
+		var err error
+		if i%2 == 0 {
+			err = database.CreateIssue(issue)
+		} else {
+			err = database.CreateIssueLogged(issue, "ses-seq")
+		}
+		if err != nil {
+			t.Fatalf("create %d failed: %v", i, err)
+		}
+		ids = append(ids, issue.ID)
+	}
+	want := []string{"PROJ-00001", "PROJ-00002", "PROJ-00003", "PROJ-00004"}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Fatalf("ids = %v, want %v", ids, want)
+		}
+	}
+	if !sort.StringsAreSorted(ids) {
+		t.Errorf("sequential ids should sort in creation order: %v", ids)
+	}
+
+	// Deleted numbers are not reused
+	if err := database.DeleteIssueLogged("PROJ-00004", "ses-seq"); err != nil {
+		t.Fatalf("DeleteIssueLogged failed: %v", err)
+	}
+	next := &models.Issue{Title: "After delete"}
+	if err := database.CreateIssue(next); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if next.ID != "PROJ-00005" {
+		t.Errorf("next id = %q, want PROJ-00005", next.ID)
+	}
+
+	// Existing and new IDs both resolve
+	for _, id := range []string{legacy.ID, "PROJ-00001", "PROJ-00005"} {
+		issue, err := database.GetIssue(id)
+		if err != nil || issue == nil {
+			t.Errorf("GetIssue(%q) = %v, %v", id, issue, err)
+		}
+	}
+	if got := NormalizeIssueID("PROJ-001"); got != "PROJ-001" {
+		t.Errorf("NormalizeIssueID(PROJ-001) = %q", got)
+	}
+	if got := NormalizeIssueID("abc123"); got != "td-abc123" {
+		t.Errorf("NormalizeIssueID(abc123) = %q", got)
+	}
+}
+
+func TestCreateIssue_SequentialIgnoresOtherIDs(t *testing.T) {
+	dir := t.TempDir()
+	database, err := Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	// A hex ID made only of digits looks like a sequence number
+	orig := idGenerator
+	idGenerator = func() (string, error) { return "td-123456", nil }
+	defer func() { idGenerator = orig }()
+	if err := database.CreateIssue(&models.Issue{Title: "All-digit hex"}); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	idGenerator = orig
+
+	// An issue synced from elsewhere already holds the second number
+	if _, err := database.conn.Exec(`INSERT INTO issues (id, title) VALUES ('td-00002', 'Synced')`); err != nil {
+		t.Fatalf("insert synced issue: %v", err)
+	}
+
+	if err := config.Save(dir, &models.Config{IDPrefix: "td", IDFormat: config.IDFormatSeq}); err != nil {
+		t.Fatalf("config.Save failed: %v", err)
+	}
+	var ids []string
+	for i := 0; i < 2; i++ {
+		issue := &models.Issue{Title: "Sequenced issue"}
+		if err := database.CreateIssue(issue); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+	if ids[0] != "td-00001" || ids[1] != "td-00003" {
+		t.Errorf("ids = %v, want [td-00001 td-00003]", ids)
+	}
+}
+
+func TestCreateIssue_SequentialSortsPast999(t *testing.T) {
+	dir := t.TempDir()
+	database, err := Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	if err := config.Save(dir, &models.Config{IDPrefix: "td", IDFormat: config.IDFormatSeq}); err != nil {
+		t.Fatalf("config.Save failed: %v", err)
+	}
+	if _, err := database.conn.Exec(`INSERT INTO schema_info (key, value) VALUES ('issue_seq:td-', '998')`); err != nil {
+		t.Fatalf("seed counter: %v", err)
+	}
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		issue := &models.Issue{Title: "Sequenced issue"}
+		if err := database.CreateIssue(issue); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+	want := []string{"td-00999", "td-01000", "td-01001"}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Fatalf("ids = %v, want %v", ids, want)
+		}
+	}
+	if !sort.StringsAreSorted(ids) {
+		t.Errorf("sequential ids should sort in creation order: %v", ids)
+	}
+}
+
+func TestCreateIssue_HexCustomPrefixAndInvalidConfig(t *testing.T) {
+	dir := t.TempDir()
+	database, err := Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	if err := config.Save(dir, &models.Config{IDPrefix: "ops-"}); err != nil {
+		t.Fatalf("config.Save failed: %v", err)
+	}
+	seen := map[string]bool{}
+	for i := 0; i < 5; i++ {
+		issue := &models.Issue{Title: "Hex issue"}
+		if err := database.CreateIssue(issue); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		if !regexp.MustCompile(`^ops-[0-9a-f]{6}$`).MatchString(issue.ID) {
+			t.Errorf("id %q does not match ops-<hex>", issue.ID)
+		}
+		if seen[issue.ID] {
+			t.Errorf("duplicate id %q", issue.ID)
+		}
+		seen[issue.ID] = true
+	}
+
+	if err := config.Save(dir, &models.Config{IDPrefix: "bad prefix"}); err != nil {
+		t.Fatalf("config.Save failed: %v", err)
+	}
+	if err := database.CreateIssue(&models.Issue{Title: "Should fail"}); err == nil {
+		t.Error("expected CreateIssue to fail with an invalid id_prefix")
+	}
+}
+
+func TestResolveIssueID(t *testing.T) {
+	dir := t.TempDir()
+	database, err := Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	originalGenerator := idGenerator
+	defer func() { idGenerator = originalGenerator }()
+	for _, id := range []string{"td-a3f012", "td-a3f9c1", "td-b71e44", "td-c0ffee"} {
+		idGenerator = func() (string, error) { return id, nil }
+		if err := database.CreateIssue(&models.Issue{Title: "Issue " + id}); err != nil {
+			t.Fatalf("CreateIssue %s failed: %v", id, err)
+		}
+	}
+	if err := database.DeleteIssue("td-c0ffee"); err != nil {
+		t.Fatalf("DeleteIssue failed: %v", err)
+	}
+
+	// Sequential IDs under a custom prefix
+	if err := config.Save(dir, &models.Config{IDPrefix: "PROJ", IDFormat: config.IDFormatSeq}); err != nil {
+		t.Fatalf("config.Save failed: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := database.CreateIssue(&models.Issue{Title: "Sequenced issue"}); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	tests := []struct {
+		partial string
+		want    string
+	}{
+		{"td-a3f012", "td-a3f012"},   // exact
+		{"a3f012", "td-a3f012"},      // exact, bare
+		{"td-c0ffee", "td-c0ffee"},   // exact match finds deleted issues
+		{"td-b7", "td-b71e44"},       // unique prefix
+		{"b71", "td-b71e44"},         // unique prefix, bare
+		{"A3F9", "td-a3f9c1"},        // case-insensitive
+		{"PROJ-1", "PROJ-00001"},     // sequential number
+		{"proj-00002", "PROJ-00002"}, // prefix case-insensitive
+	}
+	for _, tt := range tests {
+		got, err := database.ResolveIssueID(tt.partial)
+		if err != nil {
+			t.Errorf("ResolveIssueID(%q) failed: %v", tt.partial, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ResolveIssueID(%q) = %q, want %q", tt.partial, got, tt.want)
+		}
+	}
+
+	_, err = database.ResolveIssueID("a3f")
+	var ambiguous *AmbiguousIssueIDError
+	if !errors.As(err, &ambiguous) {
+		t.Fatalf("ResolveIssueID(a3f) error = %v, want ambiguity", err)
+	}
+	if want := []string{"td-a3f012", "td-a3f9c1"}; !reflect.DeepEqual(ambiguous.Candidates, want) {
+		t.Errorf("candidates = %v, want %v", ambiguous.Candidates, want)
+	}
+
+	for _, partial := range []string{"c0f", "td-zzz", "ops-a3f", "td-", ""} {
+		if id, err := database.ResolveIssueID(partial); err == nil {
+			t.Errorf("ResolveIssueID(%q) = %q, want not found", partial, id)
+		} else if errors.As(err, &ambiguous) {
+			t.Errorf("ResolveIssueID(%q) reported ambiguity, want not found: %v", partial, err)
+		}
+	}
+}
@@ -33,15 +33,15 @@ func upsertIssueExec(e execer, issue *models.Issue) error {
 			parent_id, acceptance, sprint, implementer_session, creator_session,
 			reviewer_session, review_requested_by_session, closed_by_session,
 			created_at, updated_at, reviewed_at, closed_at, deleted_at,
-			minor, created_branch, defer_until, due_date, defer_count
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			minor, created_branch, defer_until, due_date, defer_count, created_by
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`, issue.ID, issue.Title, issue.Description, issue.Status, issue.Type,
 		issue.Priority, issue.Points, labels, issue.ParentID, issue.Acceptance,
 		issue.Sprint, issue.ImplementerSession, issue.CreatorSession,
 		issue.ReviewerSession, issue.ReviewRequestedBySession, issue.ClosedBySession,
 		issue.CreatedAt, issue.UpdatedAt, issue.ReviewedAt,
 		issue.ClosedAt, issue.DeletedAt, issue.Minor, issue.CreatedBranch,
-		deferUntil, dueDate, issue.DeferCount)
+		deferUntil, dueDate, issue.DeferCount, issue.CreatedBy)
 	return err
 }
 
@@ -3,6 +3,7 @@ package db
 import (
 	"database/sql"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -66,12 +67,35 @@ func (db *DB) HasChildren(issueID string) (bool, error) {
 	return count > 0, err
 }
 
+// GetParentIDs returns parent_id keyed by issue ID for every non-deleted issue
+// that has a parent. Used to compute child/descendant counts in one query.
+func (db *DB) GetParentIDs() (map[string]string, error) {
+	rows, err := db.conn.Query(`SELECT id, parent_id FROM issues WHERE parent_id IS NOT NULL AND parent_id != '' AND deleted_at IS NULL`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	parents := make(map[string]string)
+	for rows.Next() {
+		var id, parentID string
+		if err := rows.Scan(&id, &parentID); err != nil {
+			return nil, err
+		}
+		parents[id] = parentID
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return parents, nil
+}
+
 // GetDirectChildren returns the direct children of an issue (not recursive)
 func (db *DB) GetDirectChildren(issueID string) ([]*models.Issue, error) {
 	rows, err := db.conn.Query(`
 		SELECT id, title, description, status, type, priority, points, labels, parent_id, acceptance, sprint,
 		       implementer_session, creator_session, reviewer_session, created_at, updated_at, closed_at, deleted_at, minor, created_branch,
-		       defer_until, due_date, defer_count
+		       defer_until, due_date, defer_count, created_by
 		FROM issues WHERE parent_id = ? AND deleted_at IS NULL
 	`, issueID)
 	if err != nil {
@@ -86,7 +110,7 @@ func (db *DB) GetDirectChildren(issueID string) ([]*models.Issue, error) {
 		var description, labels sql.NullString
 		var closedAt, deletedAt sql.NullTime
 		var parentID, acceptance, sprint sql.NullString
-		var implSession, creatorSession, reviewerSession sql.NullString
+		var implSession, creatorSession, createdBy, reviewerSession sql.NullString
 		var createdBranch sql.NullString
 		var pointsNull sql.NullInt64
 		var deferUntil, dueDate sql.NullString
@@ -95,7 +119,7 @@ func (db *DB) GetDirectChildren(issueID string) ([]*models.Issue, error) {
 			&issue.ID, &issue.Title, &description, &issue.Status, &issue.Type, &issue.Priority,
 			&pointsNull, &labels, &parentID, &acceptance, &sprint,
 			&implSession, &creatorSession, &reviewerSession, &issue.CreatedAt, &issue.UpdatedAt, &closedAt, &deletedAt, &issue.Minor, &createdBranch,
-			&deferUntil, &dueDate, &issue.DeferCount,
+			&deferUntil, &dueDate, &issue.DeferCount, &createdBy,
 		)
 		if err != nil {
 			return nil, err
@@ -117,6 +141,7 @@ func (db *DB) GetDirectChildren(issueID string) ([]*models.Issue, error) {
 		issue.Sprint = sprint.String
 		issue.ImplementerSession = implSession.String
 		issue.CreatorSession = creatorSession.String
+		issue.CreatedBy = createdBy.String
 		issue.ReviewerSession = reviewerSession.String
 		issue.CreatedBranch = createdBranch.String
 		if deferUntil.Valid {
@@ -163,6 +188,126 @@ func (db *DB) GetDescendantIssues(issueID string, statuses []models.Status) ([]*
 	return issues, nil
 }
 
+// BulkReparent moves each issue in ids under parentID, logging an update for
+// every issue it changes. All IDs are checked before anything is written, so
+// one bad ID leaves the tree untouched: an issue cannot become a child of
+// itself or of one of its own descendants. Issues already under parentID are
+// left alone. Once every issue is moved, the parent's status is cascaded a
+// single time. Returns the moved IDs and any parents the cascade updated.
+func (db *DB) BulkReparent(ids []string, parentID, sessionID string) ([]string, []string, error) {
+	var moved, cascaded []string
+	err := db.withWriteLock(func() error {
+		parent, err := db.GetIssue(parentID)
+		if err != nil {
+			return err
+		}
+		if parent.DeletedAt != nil {
+			return fmt.Errorf("parent %s is deleted", parent.ID)
+		}
+		issues, err := db.reparentCandidatesLocked(ids, parent)
+		if err != nil {
+			return err
+		}
+		moved, cascaded, err = db.reparentLocked(issues, parent, sessionID)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return moved, cascaded, nil
+}
+
+// GroupUnderNewEpic creates epic and moves each issue in ids under it, like
+// BulkReparent, under a single write lock. The IDs are checked before the
+// epic is created, so a bad ID leaves no empty epic behind.
+func (db *DB) GroupUnderNewEpic(ids []string, epic *models.Issue, sessionID string) ([]string, []string, error) {
+	var moved, cascaded []string
+	err := db.withWriteLock(func() error {
+		issues, err := db.reparentCandidatesLocked(ids, epic)
+		if err != nil {
+			return err
+		}
+		if err := db.createIssueWithDependenciesLocked(epic, nil, sessionID); err != nil {
+			return fmt.Errorf("create epic: %w", err)
+		}
+		moved, cascaded, err = db.reparentLocked(issues, epic, sessionID)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return moved, cascaded, nil
+}
+
+// reparentCandidatesLocked checks every issue in ids against parent and
+// returns those not already under it. parent may be an epic not created yet
+// (empty ID), which has no ancestors or children. Caller MUST hold the write
+// lock.
+func (db *DB) reparentCandidatesLocked(ids []string, parent *models.Issue) ([]*models.Issue, error) {
+	// The parent and its ancestors are exactly the issues that would
+	// become their own descendants if moved under parent.
+	ancestors := map[string]bool{parent.ID: true}
+	for cur := parent; cur.ParentID != "" && !ancestors[cur.ParentID]; {
+		ancestors[cur.ParentID] = true
+		next, err := db.GetIssue(cur.ParentID)
+		if err != nil {
+			break
+		}
+		cur = next
+	}
+
+	var issues []*models.Issue
+	seen := make(map[string]bool)
+	for _, id := range ids {
+		issue, err := db.scanIssueRow(NormalizeIssueID(id))
+		if err != nil {
+			return nil, fmt.Errorf("issue not found: %s", id)
+		}
+		if seen[issue.ID] {
+			continue
+		}
+		seen[issue.ID] = true
+		if issue.DeletedAt != nil {
+			return nil, fmt.Errorf("issue %s is deleted", issue.ID)
+		}
+		if issue.ID == parent.ID {
+			return nil, fmt.Errorf("cannot move %s under itself", issue.ID)
+		}
+		if ancestors[issue.ID] {
+			return nil, fmt.Errorf("cannot move %s under %s: %s is its descendant", issue.ID, parent.ID, parent.ID)
+		}
+		if parent.ID == "" || issue.ParentID != parent.ID {
+			issues = append(issues, issue)
+		}
+	}
+	return issues, nil
+}
+
+// reparentLocked moves issues under parent and cascades the parent's status
+// once. Caller MUST hold the write lock.
+func (db *DB) reparentLocked(issues []*models.Issue, parent *models.Issue, sessionID string) ([]string, []string, error) {
+	var moved, cascaded []string
+	allClosed, allReviewed := len(issues) > 0, len(issues) > 0
+	for _, prev := range issues {
+		updated := *prev
+		updated.ParentID = parent.ID
+		if err := db.updateIssueAndLogFromPrevious(&updated, prev, sessionID, models.ActionUpdate); err != nil {
+			return nil, nil, fmt.Errorf("move %s: %w", prev.ID, err)
+		}
+		moved = append(moved, prev.ID)
+		allClosed = allClosed && prev.Status == models.StatusClosed
+		allReviewed = allReviewed && (prev.Status == models.StatusInReview || prev.Status == models.StatusClosed)
+	}
+
+	switch {
+	case allClosed:
+		_, cascaded = db.cascadeUpParentStatusLocked(moved[0], models.StatusClosed, sessionID)
+	case allReviewed:
+		_, cascaded = db.cascadeUpParentStatusLocked(moved[0], models.StatusInReview, sessionID)
+	}
+	return moved, cascaded, nil
+}
+
 // CascadeUpParentStatus checks if all children of a parent epic have reached the target status,
 // and if so, updates the parent to that status. Works recursively up the parent chain.
 // Returns the number of parents that were cascaded and the list of cascaded parent IDs.
@@ -271,6 +416,78 @@ func (db *DB) cascadeUpParentStatusLocked(issueID string, targetStatus models.St
 	return cascadedCount, cascadedIDs
 }
 
+// CascadeUpParentApproval approves the epic parent of issueID when every
+// direct child is approved (closed with an active approval review), then
+// repeats for that epic's parent. The parent is closed with sessionID as
+// reviewer and closer, logged as an approval and given its own approval
+// review. Returns the count and IDs of approved parents. Parents already
+// closed are left alone.
+func (db *DB) CascadeUpParentApproval(issueID, sessionID string) (int, []string) {
+	var cascadedCount int
+	var cascadedIDs []string
+
+	_ = db.withWriteLock(func() error {
+		cascadedCount, cascadedIDs = db.cascadeUpParentApprovalLocked(issueID, sessionID)
+		return nil
+	})
+
+	return cascadedCount, cascadedIDs
+}
+
+// cascadeUpParentApprovalLocked is the inner implementation that assumes the write lock is held.
+func (db *DB) cascadeUpParentApprovalLocked(issueID, sessionID string) (int, []string) {
+	issue, err := db.GetIssue(issueID)
+	if err != nil || issue.ParentID == "" {
+		return 0, nil
+	}
+
+	parent, err := db.GetIssue(issue.ParentID)
+	if err != nil || parent.Type != models.TypeEpic || parent.Status == models.StatusClosed {
+		return 0, nil
+	}
+
+	children, err := db.GetDirectChildren(parent.ID)
+	if err != nil || len(children) == 0 {
+		return 0, nil
+	}
+	for _, child := range children {
+		if child.Status != models.StatusClosed {
+			return 0, nil
+		}
+		review, err := db.GetActiveApprovalReview(child.ID)
+		if err != nil || review == nil {
+			return 0, nil
+		}
+	}
+
+	now := time.Now()
+	parent.Status = models.StatusClosed
+	parent.ReviewerSession = sessionID
+	parent.ClosedBySession = sessionID
+	parent.ReviewedAt = &now
+	parent.ClosedAt = &now
+	if err := db.updateIssueAndLog(parent, sessionID, models.ActionApprove); err != nil {
+		return 0, nil
+	}
+
+	const approvedMsg = "Auto-approved (all children approved)"
+	_, _ = db.createIssueReviewLocked(parent.ID, sessionID, "approved", approvedMsg, parent.ReviewRequestedBySession, false)
+	_ = db.addLogEntry(parent.ID, sessionID, approvedMsg, models.LogTypeProgress)
+
+	cascadedCount := 1
+	cascadedIDs := []string{parent.ID}
+
+	// Auto-unblock issues that depend on this newly-closed parent
+	db.cascadeUnblockDependentsLocked(parent.ID, sessionID)
+
+	// Recursively check parent's parent
+	moreCount, moreIDs := db.cascadeUpParentApprovalLocked(parent.ID, sessionID)
+	cascadedCount += moreCount
+	cascadedIDs = append(cascadedIDs, moreIDs...)
+
+	return cascadedCount, cascadedIDs
+}
+
 // CascadeUnblockDependents checks issues that depend on closedIssueID.
 // For each dependent in "blocked" status, if ALL its dependencies are now closed,
 // it transitions the dependent from blocked → open.
@@ -365,11 +582,74 @@ func (db *DB) AddDependency(issueID, dependsOnID, relationType string) error {
 // This unlogged variant exists for sync receiver applying remote events.
 func (db *DB) RemoveDependency(issueID, dependsOnID string) error {
 	return db.withWriteLock(func() error {
-		_, err := db.conn.Exec(`DELETE FROM issue_dependencies WHERE issue_id = ? AND depends_on_id = ?`, issueID, dependsOnID)
+		_, err := db.conn.Exec(`DELETE FROM issue_dependencies WHERE issue_id = ? AND depends_on_id = ? AND relation_type = 'depends_on'`, issueID, dependsOnID)
 		return err
 	})
 }
 
+// ============================================================================
+// Relation Functions
+// ============================================================================
+
+// relationPair orders two issue IDs so a relates_to link is stored as a
+// single row no matter which side it was created from.
+func relationPair(a, b string) (string, string) {
+	if b < a {
+		return b, a
+	}
+	return a, b
+}
+
+// AddRelation links two issues with a non-blocking relates_to relation and
+// logs the action. Relations are symmetric and never affect readiness.
+func (db *DB) AddRelation(issueID, relatedID, sessionID string) error {
+	if issueID == relatedID {
+		return fmt.Errorf("cannot relate an issue to itself")
+	}
+	a, b := relationPair(issueID, relatedID)
+	return db.AddDependencyLogged(a, b, models.RelationRelatesTo, sessionID)
+}
+
+// RemoveRelation removes the relates_to link between two issues, in
+// whichever direction it was stored, and logs the action.
+func (db *DB) RemoveRelation(issueID, relatedID, sessionID string) error {
+	a, b := relationPair(issueID, relatedID)
+	if _, err := db.removeRelationRowLogged(a, b, models.RelationRelatesTo, sessionID); err != nil {
+		return err
+	}
+	// Links imported or synced from elsewhere may be stored in the other order.
+	_, err := db.removeRelationRowLogged(b, a, models.RelationRelatesTo, sessionID)
+	return err
+}
+
+// GetRelated returns the IDs of issues linked to issueID by a relates_to
+// relation, regardless of which side the link was created from.
+func (db *DB) GetRelated(issueID string) ([]string, error) {
+	rows, err := db.conn.Query(`
+		SELECT depends_on_id FROM issue_dependencies WHERE issue_id = ? AND relation_type = 'relates_to'
+		UNION
+		SELECT issue_id FROM issue_dependencies WHERE depends_on_id = ? AND relation_type = 'relates_to'
+		ORDER BY 1
+	`, issueID, issueID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var related []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		related = append(related, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return related, nil
+}
+
 // GetIssueDependencyRelations returns all dependency relations for an issue,
 // including relation_type. Used by export for lossless round-trips.
 func (db *DB) GetIssueDependencyRelations(issueID string) ([]models.IssueDependency, error) {
@@ -417,6 +697,67 @@ func (db *DB) GetDependencies(issueID string) ([]string, error) {
 	return deps, nil
 }
 
+// GetOpenDependencies returns the issues this issue depends on that are not
+// yet closed.
+func (db *DB) GetOpenDependencies(issueID string) ([]string, error) {
+	rows, err := db.conn.Query(`
+		SELECT d.depends_on_id
+		FROM issue_dependencies d
+		JOIN issues i ON d.depends_on_id = i.id
+		WHERE d.issue_id = ? AND d.relation_type = 'depends_on'
+		  AND i.status != 'closed' AND i.deleted_at IS NULL
+		ORDER BY d.depends_on_id
+	`, issueID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deps []string
+	for rows.Next() {
+		var dep string
+		if err := rows.Scan(&dep); err != nil {
+			return nil, err
+		}
+		deps = append(deps, dep)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return deps, nil
+}
+
+// GetOpenDependentCounts returns, for each issue, how many issues that are
+// not closed or deleted depend on it. Issues with none are left out.
+func (db *DB) GetOpenDependentCounts() (map[string]int, error) {
+	rows, err := db.conn.Query(`
+		SELECT d.depends_on_id, COUNT(*)
+		FROM issue_dependencies d
+		JOIN issues i ON d.issue_id = i.id
+		WHERE d.relation_type = 'depends_on'
+		  AND i.status != 'closed' AND i.deleted_at IS NULL
+		GROUP BY d.depends_on_id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var id string
+		var n int
+		if err := rows.Scan(&id, &n); err != nil {
+			return nil, err
+		}
+		counts[id] = n
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
 // GetBlockedBy returns what issues are blocked by this issue
 func (db *DB) GetBlockedBy(issueID string) ([]string, error) {
 	rows, err := db.conn.Query(`
@@ -511,6 +852,80 @@ func (db *DB) GetIssuesWithOpenDeps() (map[string]bool, error) {
 	return result, nil
 }
 
+// GetIssuesWithComments returns a set of issue IDs that have at least one comment.
+// This is used by the has_comments() query function.
+func (db *DB) GetIssuesWithComments() (map[string]bool, error) {
+	return db.issueIDsWithRowsIn("comments")
+}
+
+// GetIssuesWithHandoffs returns a set of issue IDs that have at least one handoff.
+// This is used by the has_handoff() query function.
+func (db *DB) GetIssuesWithHandoffs() (map[string]bool, error) {
+	return db.issueIDsWithRowsIn("handoffs")
+}
+
+// GetIssuesWithFiles returns a set of issue IDs that have at least one linked file.
+// This is used by the has_files() query function.
+func (db *DB) GetIssuesWithFiles() (map[string]bool, error) {
+	return db.issueIDsWithRowsIn("issue_files")
+}
+
+// GetIssuesUpdatedBySession returns a set of non-deleted issue IDs with at
+// least one action_log entry by sessionID, undone actions excluded. Unlike
+// GetIssueSessionLog this covers changes made without a progress log.
+// This is used by the updated_by_session() query function.
+func (db *DB) GetIssuesUpdatedBySession(sessionID string) (map[string]bool, error) {
+	rows, err := db.conn.Query(`
+		SELECT i.id FROM issues i
+		WHERE i.deleted_at IS NULL
+		  AND EXISTS (
+			SELECT 1 FROM action_log al
+			WHERE al.entity_id = i.id AND al.session_id = ? AND al.undone = 0
+		  )
+	`, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[string]bool)
+	for rows.Next() {
+		var issueID string
+		if err := rows.Scan(&issueID); err != nil {
+			return nil, err
+		}
+		result[issueID] = true
+	}
+	return result, rows.Err()
+}
+
+// issueIDsWithRowsIn returns non-deleted issue IDs referenced by at least one
+// row of table. table must be a trusted constant, never user input.
+func (db *DB) issueIDsWithRowsIn(table string) (map[string]bool, error) {
+	rows, err := db.conn.Query(fmt.Sprintf(`
+		SELECT i.id FROM issues i
+		WHERE i.deleted_at IS NULL
+		  AND EXISTS (SELECT 1 FROM %s t WHERE t.issue_id = i.id)
+	`, table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[string]bool)
+	for rows.Next() {
+		var issueID string
+		if err := rows.Scan(&issueID); err != nil {
+			return nil, err
+		}
+		result[issueID] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // GetIssueStatuses fetches statuses for multiple issues in a single query
 func (db *DB) GetIssueStatuses(ids []string) (map[string]models.Status, error) {
 	if len(ids) == 0 {
@@ -715,6 +1130,56 @@ func (db *DB) GetLinkedFiles(issueID string) ([]models.IssueFile, error) {
 	return files, nil
 }
 
+// FileOverlap is an issue that links some of the same files as another.
+type FileOverlap struct {
+	IssueID     string        `json:"id"`
+	Title       string        `json:"title"`
+	Status      models.Status `json:"status"`
+	SharedFiles []string      `json:"shared_files"`
+}
+
+// SuggestRelatedByFiles returns the open (non-closed, non-deleted) issues that
+// link at least one of issueID's files, most shared files first. Issues
+// touching the same files are likely to conflict; callers treat this as a
+// hint and never link anything automatically.
+func (db *DB) SuggestRelatedByFiles(issueID string) ([]FileOverlap, error) {
+	issueID = NormalizeIssueID(issueID)
+	rows, err := db.conn.Query(`
+		SELECT o.issue_id, i.title, i.status, o.file_path
+		FROM issue_files f
+		JOIN issue_files o ON o.file_path = f.file_path AND o.issue_id != f.issue_id
+		JOIN issues i ON i.id = o.issue_id
+		WHERE f.issue_id = ? AND i.deleted_at IS NULL AND i.status != ?
+		ORDER BY o.issue_id, o.file_path
+	`, issueID, models.StatusClosed)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var overlaps []FileOverlap
+	for rows.Next() {
+		var id, title, filePath string
+		var status models.Status
+		if err := rows.Scan(&id, &title, &status, &filePath); err != nil {
+			return nil, err
+		}
+		if n := len(overlaps); n > 0 && overlaps[n-1].IssueID == id {
+			overlaps[n-1].SharedFiles = append(overlaps[n-1].SharedFiles, filePath)
+			continue
+		}
+		overlaps = append(overlaps, FileOverlap{IssueID: id, Title: title, Status: status, SharedFiles: []string{filePath}})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(overlaps, func(i, j int) bool {
+		return len(overlaps[i].SharedFiles) > len(overlaps[j].SharedFiles)
+	})
+	return overlaps, nil
+}
+
 // ============================================================================
 // Issue Session Functions
 // ============================================================================
@@ -874,6 +874,155 @@ func TestCascadeUpParentStatus_RecursiveCascade(t *testing.T) {
 	}
 }
 
+// ============================================================================
+// BulkReparent Tests
+// ============================================================================
+
+func TestBulkReparent_GroupsUnderEpic(t *testing.T) {
+	dir := t.TempDir()
+	db, err := Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer db.Close()
+
+	epic := &models.Issue{Title: "Cleanup", Type: models.TypeEpic}
+	if err := db.CreateIssue(epic); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	var ids []string
+	for _, title := range []string{"a", "b", "c"} {
+		issue := &models.Issue{Title: title, Status: models.StatusClosed}
+		if err := db.CreateIssue(issue); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+
+	// Duplicate IDs are moved once
+	moved, cascaded, err := db.BulkReparent(append(ids, ids[0]), epic.ID, "ses-1")
+	if err != nil {
+		t.Fatalf("BulkReparent failed: %v", err)
+	}
+	if len(moved) != 3 {
+		t.Errorf("moved: got %v, want 3 issues", moved)
+	}
+	children, _ := db.GetDirectChildren(epic.ID)
+	if len(children) != 3 {
+		t.Errorf("Expected 3 children of epic, got %d", len(children))
+	}
+
+	// Every moved child was closed, so the epic cascades to closed
+	if len(cascaded) != 1 || cascaded[0] != epic.ID {
+		t.Errorf("cascaded: got %v, want [%s]", cascaded, epic.ID)
+	}
+	updated, _ := db.GetIssue(epic.ID)
+	if updated.Status != models.StatusClosed {
+		t.Errorf("Expected epic closed after cascade, got %s", updated.Status)
+	}
+
+	var updates int
+	if err := db.conn.QueryRow(
+		`SELECT COUNT(*) FROM action_log WHERE action_type = 'update' AND entity_type = 'issue' AND entity_id IN (?, ?, ?)`,
+		ids[0], ids[1], ids[2],
+	).Scan(&updates); err != nil {
+		t.Fatalf("count action_log: %v", err)
+	}
+	if updates != 3 {
+		t.Errorf("Expected 3 logged updates, got %d", updates)
+	}
+
+	// Regrouping under the same parent is a no-op
+	moved, _, err = db.BulkReparent(ids, epic.ID, "ses-1")
+	if err != nil {
+		t.Fatalf("BulkReparent (again) failed: %v", err)
+	}
+	if len(moved) != 0 {
+		t.Errorf("Expected nothing moved on regroup, got %v", moved)
+	}
+}
+
+func TestBulkReparent_RejectsDescendantParent(t *testing.T) {
+	dir := t.TempDir()
+	db, err := Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer db.Close()
+
+	// epic -> story -> task
+	epic := &models.Issue{Title: "Epic", Type: models.TypeEpic}
+	if err := db.CreateIssue(epic); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	story := &models.Issue{Title: "Story", ParentID: epic.ID}
+	if err := db.CreateIssue(story); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	task := &models.Issue{Title: "Task", ParentID: story.ID}
+	if err := db.CreateIssue(task); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	loose := &models.Issue{Title: "Loose"}
+	if err := db.CreateIssue(loose); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	// Moving epic under its grandchild would make a cycle; loose must not move either
+	_, _, err = db.BulkReparent([]string{loose.ID, epic.ID}, task.ID, "ses-1")
+	if err == nil || !strings.Contains(err.Error(), "descendant") {
+		t.Fatalf("Expected descendant error, got %v", err)
+	}
+	if got, _ := db.GetIssue(loose.ID); got.ParentID != "" {
+		t.Errorf("Expected loose issue unmoved, got parent %q", got.ParentID)
+	}
+	if got, _ := db.GetIssue(epic.ID); got.ParentID != "" {
+		t.Errorf("Expected epic unmoved, got parent %q", got.ParentID)
+	}
+
+	if _, _, err := db.BulkReparent([]string{task.ID}, task.ID, "ses-1"); err == nil {
+		t.Error("Expected error moving an issue under itself")
+	}
+}
+
+func TestGroupUnderNewEpic(t *testing.T) {
+	dir := t.TempDir()
+	db, err := Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer db.Close()
+
+	loose := &models.Issue{Title: "Loose"}
+	if err := db.CreateIssue(loose); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	// A bad ID fails before the epic is created
+	epic := &models.Issue{Title: "Cleanup", Type: models.TypeEpic}
+	if _, _, err := db.GroupUnderNewEpic([]string{loose.ID, "td-nothere"}, epic, "ses-1"); err == nil {
+		t.Fatal("Expected error for unknown issue")
+	}
+	epics, err := db.ListIssues(ListIssuesOptions{Type: []models.Type{models.TypeEpic}})
+	if err != nil {
+		t.Fatalf("ListIssues failed: %v", err)
+	}
+	if len(epics) != 0 {
+		t.Errorf("Expected no epic after failed group, got %d", len(epics))
+	}
+
+	moved, _, err := db.GroupUnderNewEpic([]string{loose.ID}, epic, "ses-1")
+	if err != nil {
+		t.Fatalf("GroupUnderNewEpic failed: %v", err)
+	}
+	if epic.ID == "" || len(moved) != 1 || moved[0] != loose.ID {
+		t.Fatalf("moved: got %v under %q", moved, epic.ID)
+	}
+	if got, _ := db.GetIssue(loose.ID); got.ParentID != epic.ID {
+		t.Errorf("Expected loose issue under %s, got parent %q", epic.ID, got.ParentID)
+	}
+}
+
 // ============================================================================
 // Dependency Functions Tests
 // ============================================================================
@@ -1059,6 +1208,122 @@ func TestGetIssuesWithOpenDeps(t *testing.T) {
 	}
 }
 
+func TestAddRelation_SymmetricInGetRelated(t *testing.T) {
+	dir := t.TempDir()
+	db, err := Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer db.Close()
+
+	a := &models.Issue{Title: "A"}
+	b := &models.Issue{Title: "B"}
+	c := &models.Issue{Title: "C"}
+	for _, issue := range []*models.Issue{a, b, c} {
+		if err := db.CreateIssue(issue); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	// Relate from both ends; the second call must not add a second row.
+	if err := db.AddRelation(b.ID, a.ID, "ses_test"); err != nil {
+		t.Fatalf("AddRelation failed: %v", err)
+	}
+	if err := db.AddRelation(a.ID, b.ID, "ses_test"); err != nil {
+		t.Fatalf("AddRelation failed: %v", err)
+	}
+	if err := db.AddRelation(c.ID, a.ID, "ses_test"); err != nil {
+		t.Fatalf("AddRelation failed: %v", err)
+	}
+	if err := db.AddRelation(a.ID, a.ID, "ses_test"); err == nil {
+		t.Error("AddRelation should refuse to relate an issue to itself")
+	}
+
+	var rows int
+	if err := db.conn.QueryRow(`SELECT COUNT(*) FROM issue_dependencies WHERE relation_type = 'relates_to'`).Scan(&rows); err != nil {
+		t.Fatalf("count relations: %v", err)
+	}
+	if rows != 2 {
+		t.Errorf("expected 2 relation rows, got %d", rows)
+	}
+
+	wantA := []string{b.ID, c.ID}
+	if wantA[1] < wantA[0] {
+		wantA[0], wantA[1] = wantA[1], wantA[0]
+	}
+	for _, tc := range []struct {
+		id   string
+		want []string
+	}{
+		{a.ID, wantA},
+		{b.ID, []string{a.ID}},
+		{c.ID, []string{a.ID}},
+	} {
+		got, err := db.GetRelated(tc.id)
+		if err != nil {
+			t.Fatalf("GetRelated(%s) failed: %v", tc.id, err)
+		}
+		if strings.Join(got, ",") != strings.Join(tc.want, ",") {
+			t.Errorf("GetRelated(%s) = %v, want %v", tc.id, got, tc.want)
+		}
+	}
+
+	if err := db.RemoveRelation(b.ID, a.ID, "ses_test"); err != nil {
+		t.Fatalf("RemoveRelation failed: %v", err)
+	}
+	if got, _ := db.GetRelated(b.ID); len(got) != 0 {
+		t.Errorf("GetRelated(b) after removal = %v, want none", got)
+	}
+	if got, _ := db.GetRelated(a.ID); len(got) != 1 || got[0] != c.ID {
+		t.Errorf("GetRelated(a) after removal = %v, want [%s]", got, c.ID)
+	}
+}
+
+func TestAddRelation_DoesNotBlock(t *testing.T) {
+	dir := t.TempDir()
+	db, err := Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer db.Close()
+
+	a := &models.Issue{Title: "A", Status: models.StatusOpen}
+	b := &models.Issue{Title: "B", Status: models.StatusOpen}
+	_ = db.CreateIssue(a)
+	_ = db.CreateIssue(b)
+
+	if err := db.AddRelation(a.ID, b.ID, "ses_test"); err != nil {
+		t.Fatalf("AddRelation failed: %v", err)
+	}
+
+	openDeps, err := db.GetIssuesWithOpenDeps()
+	if err != nil {
+		t.Fatalf("GetIssuesWithOpenDeps failed: %v", err)
+	}
+	if openDeps[a.ID] || openDeps[b.ID] {
+		t.Errorf("related issues should not have open deps: %v", openDeps)
+	}
+	for _, id := range []string{a.ID, b.ID} {
+		if deps, _ := db.GetDependencies(id); len(deps) != 0 {
+			t.Errorf("GetDependencies(%s) = %v, want none", id, deps)
+		}
+		if blocked, _ := db.GetBlockedBy(id); len(blocked) != 0 {
+			t.Errorf("GetBlockedBy(%s) = %v, want none", id, blocked)
+		}
+	}
+
+	// A real dependency alongside the relation is independent of it.
+	if err := db.AddDependencyLogged(a.ID, b.ID, models.RelationDependsOn, "ses_test"); err != nil {
+		t.Fatalf("AddDependencyLogged failed: %v", err)
+	}
+	if err := db.RemoveDependencyLogged(a.ID, b.ID, "ses_test"); err != nil {
+		t.Fatalf("RemoveDependencyLogged failed: %v", err)
+	}
+	if related, _ := db.GetRelated(a.ID); len(related) != 1 || related[0] != b.ID {
+		t.Errorf("removing the dependency should keep the relation, got %v", related)
+	}
+}
+
 func TestGetIssueStatuses(t *testing.T) {
 	dir := t.TempDir()
 	db, err := Initialize(dir)
@@ -1311,6 +1576,57 @@ func TestGetLinkedFiles_SortedByRoleAndPath(t *testing.T) {
 	}
 }
 
+func TestSuggestRelatedByFiles(t *testing.T) {
+	dir := t.TempDir()
+	db, err := Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer db.Close()
+
+	target := &models.Issue{Title: "Target"}
+	one := &models.Issue{Title: "Shares one file"}
+	two := &models.Issue{Title: "Shares two files"}
+	none := &models.Issue{Title: "Shares nothing"}
+	closed := &models.Issue{Title: "Closed, shares a file", Status: models.StatusClosed}
+	for _, issue := range []*models.Issue{target, one, two, none, closed} {
+		if err := db.CreateIssue(issue); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	_ = db.LinkFile(target.ID, "a.go", models.FileRoleImplementation, "")
+	_ = db.LinkFile(target.ID, "b.go", models.FileRoleImplementation, "")
+	_ = db.LinkFile(one.ID, "b.go", models.FileRoleTest, "")
+	_ = db.LinkFile(two.ID, "a.go", models.FileRoleImplementation, "")
+	_ = db.LinkFile(two.ID, "b.go", models.FileRoleImplementation, "")
+	_ = db.LinkFile(none.ID, "c.go", models.FileRoleImplementation, "")
+	_ = db.LinkFile(closed.ID, "a.go", models.FileRoleImplementation, "")
+
+	overlaps, err := db.SuggestRelatedByFiles(target.ID)
+	if err != nil {
+		t.Fatalf("SuggestRelatedByFiles failed: %v", err)
+	}
+	if len(overlaps) != 2 {
+		t.Fatalf("Expected 2 overlapping issues, got %d: %+v", len(overlaps), overlaps)
+	}
+	if overlaps[0].IssueID != two.ID || strings.Join(overlaps[0].SharedFiles, ",") != "a.go,b.go" {
+		t.Errorf("Expected %s sharing a.go,b.go first, got %+v", two.ID, overlaps[0])
+	}
+	if overlaps[1].IssueID != one.ID || strings.Join(overlaps[1].SharedFiles, ",") != "b.go" {
+		t.Errorf("Expected %s sharing b.go second, got %+v", one.ID, overlaps[1])
+	}
+
+	// An issue with no shared files gets no suggestions
+	overlaps, err = db.SuggestRelatedByFiles(none.ID)
+	if err != nil {
+		t.Fatalf("SuggestRelatedByFiles failed: %v", err)
+	}
+	if len(overlaps) != 0 {
+		t.Errorf("Expected no suggestions for %s, got %+v", none.ID, overlaps)
+	}
+}
+
 // ============================================================================
 // Session History Tests (Issue Relations specific)
 // Note: Basic session action tests are in bypass_prevention_test.go
@@ -18,6 +18,7 @@ type ListIssuesOptions struct {
 	IncludeDeleted       bool
 	OnlyDeleted          bool
 	Search               string
+	SearchContent        bool // With Search, also match log, comment and handoff text
 	Implementer          string
 	Reviewer             string
 	ReviewableBy         string // Issues that this session can review
@@ -70,6 +71,9 @@ func (db *DB) CreateIssue(issue *models.Issue) error {
 		if issue.Priority == "" {
 			issue.Priority = models.PriorityP2
 		}
+		if issue.CreatedBy == "" {
+			issue.CreatedBy = issue.CreatorSession
+		}
 
 		now := time.Now()
 		issue.CreatedAt = now
@@ -77,10 +81,11 @@ func (db *DB) CreateIssue(issue *models.Issue) error {
 
 		labels := strings.Join(issue.Labels, ",")
 
-		// Retry loop for rare ID collisions (6 hex chars = 16.7M keyspace)
+		// Retry loop for rare ID collisions (6 hex chars = 16.7M keyspace, or a
+		// sequential ID taken by another writer)
 		const maxRetries = 3
 		for attempt := 0; attempt < maxRetries; attempt++ {
-			id, err := generateID()
+			id, err := db.newIssueID()
 			if err != nil {
 				return err
 			}
@@ -96,9 +101,9 @@ func (db *DB) CreateIssue(issue *models.Issue) error {
 			}
 
 			_, err = db.conn.Exec(`
-				INSERT INTO issues (id, title, description, status, type, priority, points, labels, parent_id, acceptance, created_at, updated_at, minor, created_branch, creator_session, defer_until, due_date, defer_count)
-				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-			`, issue.ID, issue.Title, issue.Description, issue.Status, issue.Type, issue.Priority, issue.Points, labels, issue.ParentID, issue.Acceptance, issue.CreatedAt, issue.UpdatedAt, issue.Minor, issue.CreatedBranch, issue.CreatorSession, deferUntil, dueDate, issue.DeferCount)
+				INSERT INTO issues (id, title, description, status, type, priority, points, labels, parent_id, acceptance, created_at, updated_at, minor, created_branch, creator_session, created_by, defer_until, due_date, defer_count)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			`, issue.ID, issue.Title, issue.Description, issue.Status, issue.Type, issue.Priority, issue.Points, labels, issue.ParentID, issue.Acceptance, issue.CreatedAt, issue.UpdatedAt, issue.Minor, issue.CreatedBranch, issue.CreatorSession, issue.CreatedBy, deferUntil, dueDate, issue.DeferCount)
 
 			if err == nil {
 				return nil
@@ -123,7 +128,7 @@ func (db *DB) GetIssue(id string) (*models.Issue, error) {
 	var description, labels sql.NullString
 	var closedAt, deletedAt, reviewedAt sql.NullTime
 	var parentID, acceptance, sprint sql.NullString
-	var implSession, creatorSession, reviewerSession sql.NullString
+	var implSession, creatorSession, createdBy, reviewerSession sql.NullString
 	var reviewRequestedBy, closedBy sql.NullString
 	var createdBranch sql.NullString
 	var pointsNull sql.NullInt64
@@ -133,14 +138,14 @@ func (db *DB) GetIssue(id string) (*models.Issue, error) {
 		SELECT id, title, description, status, type, priority, points, labels, parent_id, acceptance, sprint,
 		       implementer_session, creator_session, reviewer_session, review_requested_by_session, closed_by_session,
 		       created_at, updated_at, reviewed_at, closed_at, deleted_at, minor, created_branch,
-		       defer_until, due_date, defer_count
+		       defer_until, due_date, defer_count, created_by
 	FROM issues WHERE id = ?
 	`, id).Scan(
 		&issue.ID, &issue.Title, &description, &issue.Status, &issue.Type, &issue.Priority,
 		&pointsNull, &labels, &parentID, &acceptance, &sprint,
 		&implSession, &creatorSession, &reviewerSession, &reviewRequestedBy, &closedBy,
 		&issue.CreatedAt, &issue.UpdatedAt, &reviewedAt, &closedAt, &deletedAt, &issue.Minor, &createdBranch,
-		&deferUntil, &dueDate, &issue.DeferCount,
+		&deferUntil, &dueDate, &issue.DeferCount, &createdBy,
 	)
 
 	if err == sql.ErrNoRows {
@@ -169,6 +174,7 @@ func (db *DB) GetIssue(id string) (*models.Issue, error) {
 	issue.Sprint = sprint.String
 	issue.ImplementerSession = implSession.String
 	issue.CreatorSession = creatorSession.String
+	issue.CreatedBy = createdBy.String
 	issue.ReviewerSession = reviewerSession.String
 	issue.ReviewRequestedBySession = reviewRequestedBy.String
 	issue.ClosedBySession = closedBy.String
@@ -211,7 +217,7 @@ func (db *DB) GetIssuesByIDs(ids []string) ([]models.Issue, error) {
 		SELECT id, title, description, status, type, priority, points, labels, parent_id, acceptance, sprint,
 		       implementer_session, creator_session, reviewer_session, review_requested_by_session, closed_by_session,
 		       created_at, updated_at, reviewed_at, closed_at, deleted_at, minor, created_branch,
-		       defer_until, due_date, defer_count
+		       defer_until, due_date, defer_count, created_by
 		FROM issues WHERE id IN (%s)
 	`, strings.Join(placeholders, ","))
 
@@ -228,7 +234,7 @@ func (db *DB) GetIssuesByIDs(ids []string) ([]models.Issue, error) {
 		var description, labels sql.NullString
 		var closedAt, deletedAt, reviewedAt sql.NullTime
 		var parentID, acceptance, sprint sql.NullString
-		var implSession, creatorSession, reviewerSession sql.NullString
+		var implSession, creatorSession, createdBy, reviewerSession sql.NullString
 		var reviewRequestedBy, closedBy sql.NullString
 		var createdBranch sql.NullString
 		var pointsNull sql.NullInt64
@@ -238,7 +244,7 @@ func (db *DB) GetIssuesByIDs(ids []string) ([]models.Issue, error) {
 			&pointsNull, &labels, &parentID, &acceptance, &sprint,
 			&implSession, &creatorSession, &reviewerSession, &reviewRequestedBy, &closedBy,
 			&issue.CreatedAt, &issue.UpdatedAt, &reviewedAt, &closedAt, &deletedAt, &issue.Minor, &createdBranch,
-			&deferUntil, &dueDate, &issue.DeferCount,
+			&deferUntil, &dueDate, &issue.DeferCount, &createdBy,
 		); err != nil {
 			return nil, err
 		}
@@ -261,6 +267,7 @@ func (db *DB) GetIssuesByIDs(ids []string) ([]models.Issue, error) {
 		issue.Sprint = sprint.String
 		issue.ImplementerSession = implSession.String
 		issue.CreatorSession = creatorSession.String
+		issue.CreatedBy = createdBy.String
 		issue.ReviewerSession = reviewerSession.String
 		issue.ReviewRequestedBySession = reviewRequestedBy.String
 		issue.ClosedBySession = closedBy.String
@@ -548,7 +555,7 @@ func (db *DB) ListIssues(opts ListIssuesOptions) ([]models.Issue, error) {
 	query := `SELECT id, title, description, status, type, priority, points, labels, parent_id, acceptance, sprint,
                  implementer_session, creator_session, reviewer_session, review_requested_by_session, closed_by_session,
                  created_at, updated_at, reviewed_at, closed_at, deleted_at, minor, created_branch,
-                 defer_until, due_date, defer_count
+                 defer_until, due_date, defer_count, created_by
           FROM issues WHERE 1=1`
 	var args []interface{}
 
@@ -615,9 +622,23 @@ func (db *DB) ListIssues(opts ListIssuesOptions) ([]models.Issue, error) {
 
 	// Search filter
 	if opts.Search != "" {
-		query += " AND (id LIKE ? OR title LIKE ? OR description LIKE ?)"
 		searchPattern := "%" + opts.Search + "%"
-		args = append(args, searchPattern, searchPattern, searchPattern)
+		if opts.SearchContent {
+			// Handoff lists are stored as JSON blobs, so cast them to text
+			// for LIKE. Each list is matched on its own since any may be NULL.
+			query += ` AND (id LIKE ? OR title LIKE ? OR description LIKE ?
+				OR id IN (SELECT issue_id FROM logs WHERE message LIKE ?)
+				OR id IN (SELECT issue_id FROM comments WHERE text LIKE ?)
+				OR id IN (SELECT issue_id FROM handoffs
+					WHERE CAST(done AS TEXT) LIKE ? OR CAST(remaining AS TEXT) LIKE ?
+						OR CAST(decisions AS TEXT) LIKE ? OR CAST(uncertain AS TEXT) LIKE ?))`
+			for i := 0; i < 9; i++ {
+				args = append(args, searchPattern)
+			}
+		} else {
+			query += " AND (id LIKE ? OR title LIKE ? OR description LIKE ?)"
+			args = append(args, searchPattern, searchPattern, searchPattern)
+		}
 	}
 
 	// Implementer filter
@@ -794,7 +815,7 @@ func (db *DB) ListIssues(opts ListIssuesOptions) ([]models.Issue, error) {
 		var description, labels sql.NullString
 		var closedAt, deletedAt, reviewedAt sql.NullTime
 		var parentID, acceptance, sprint sql.NullString
-		var implSession, creatorSession, reviewerSession sql.NullString
+		var implSession, creatorSession, createdBy, reviewerSession sql.NullString
 		var reviewRequestedBy, closedBy sql.NullString
 		var createdBranch sql.NullString
 		var pointsNull sql.NullInt64
@@ -805,7 +826,7 @@ func (db *DB) ListIssues(opts ListIssuesOptions) ([]models.Issue, error) {
 			&pointsNull, &labels, &parentID, &acceptance, &sprint,
 			&implSession, &creatorSession, &reviewerSession, &reviewRequestedBy, &closedBy,
 			&issue.CreatedAt, &issue.UpdatedAt, &reviewedAt, &closedAt, &deletedAt, &issue.Minor, &createdBranch,
-			&deferUntil, &dueDate, &issue.DeferCount,
+			&deferUntil, &dueDate, &issue.DeferCount, &createdBy,
 		)
 		if err != nil {
 			return nil, err
@@ -830,6 +851,7 @@ func (db *DB) ListIssues(opts ListIssuesOptions) ([]models.Issue, error) {
 		issue.Sprint = sprint.String
 		issue.ImplementerSession = implSession.String
 		issue.CreatorSession = creatorSession.String
+		issue.CreatedBy = createdBy.String
 		issue.ReviewerSession = reviewerSession.String
 		issue.ReviewRequestedBySession = reviewRequestedBy.String
 		issue.ClosedBySession = closedBy.String
@@ -3,6 +3,7 @@ package db
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -80,6 +81,19 @@ func (e *StaleIssueStatusError) Error() string {
 	return fmt.Sprintf("issue %s status changed from %s to %s", e.IssueID, e.Expected, e.Actual)
 }
 
+// WIPLimitError indicates a claim was refused because the session already
+// holds as many in_progress issues as its WIP limit allows.
+type WIPLimitError struct {
+	SessionID string
+	Limit     int
+	Held      []string
+}
+
+func (e *WIPLimitError) Error() string {
+	return fmt.Sprintf("session %s already has %d in_progress issue(s) (WIP limit %d): %s; hand off, submit for review or unstart one before claiming another",
+		e.SessionID, len(e.Held), e.Limit, strings.Join(e.Held, ", "))
+}
+
 // marshalIssue returns a JSON representation of an issue for action_log storage.
 func marshalIssue(issue *models.Issue) string {
 	data, _ := json.Marshal(issue)
@@ -96,7 +110,7 @@ func (db *DB) scanIssueRow(id string) (*models.Issue, error) {
 	var description, labels sql.NullString
 	var closedAt, deletedAt, reviewedAt sql.NullTime
 	var parentID, acceptance, sprint sql.NullString
-	var implSession, creatorSession, reviewerSession sql.NullString
+	var implSession, creatorSession, createdBy, reviewerSession sql.NullString
 	var reviewRequestedBy, closedBy sql.NullString
 	var createdBranch sql.NullString
 	var pointsNull sql.NullInt64
@@ -106,14 +120,14 @@ func (db *DB) scanIssueRow(id string) (*models.Issue, error) {
 		SELECT id, title, description, status, type, priority, points, labels, parent_id, acceptance, sprint,
 		       implementer_session, creator_session, reviewer_session, review_requested_by_session, closed_by_session,
 		       created_at, updated_at, reviewed_at, closed_at, deleted_at, minor, created_branch,
-		       defer_until, due_date, defer_count
+		       defer_until, due_date, defer_count, created_by
 		FROM issues WHERE id = ?
 	`, id).Scan(
 		&issue.ID, &issue.Title, &description, &issue.Status, &issue.Type, &issue.Priority,
 		&pointsNull, &labels, &parentID, &acceptance, &sprint,
 		&implSession, &creatorSession, &reviewerSession, &reviewRequestedBy, &closedBy,
 		&issue.CreatedAt, &issue.UpdatedAt, &reviewedAt, &closedAt, &deletedAt, &issue.Minor, &createdBranch,
-		&deferUntil, &dueDate, &issue.DeferCount,
+		&deferUntil, &dueDate, &issue.DeferCount, &createdBy,
 	)
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("issue not found: %s", id)
@@ -141,6 +155,7 @@ func (db *DB) scanIssueRow(id string) (*models.Issue, error) {
 	issue.Sprint = sprint.String
 	issue.ImplementerSession = implSession.String
 	issue.CreatorSession = creatorSession.String
+	issue.CreatedBy = createdBy.String
 	issue.ReviewerSession = reviewerSession.String
 	issue.ReviewRequestedBySession = reviewRequestedBy.String
 	issue.ClosedBySession = closedBy.String
@@ -156,72 +171,125 @@ func (db *DB) scanIssueRow(id string) (*models.Issue, error) {
 }
 
 // CreateIssueLogged creates an issue and logs the action atomically within a single withWriteLock call.
+// CreatedBy defaults to sessionID when the caller has not set an identity.
 func (db *DB) CreateIssueLogged(issue *models.Issue, sessionID string) error {
+	return db.CreateIssueWithDependenciesLogged(issue, nil, sessionID)
+}
+
+// CreateIssueWithDependenciesLogged creates an issue together with a
+// depends_on edge to each of dependsOn, logging every row, in one transaction
+// under a single withWriteLock call: either the issue exists with all of its
+// dependencies or nothing is written. Callers validate the dependency IDs.
+func (db *DB) CreateIssueWithDependenciesLogged(issue *models.Issue, dependsOn []string, sessionID string) error {
 	return db.withWriteLock(func() error {
-		if issue.Status == "" {
-			issue.Status = models.StatusOpen
+		return db.createIssueWithDependenciesLocked(issue, dependsOn, sessionID)
+	})
+}
+
+// createIssueWithDependenciesLocked is the inner implementation that assumes the write lock is held.
+func (db *DB) createIssueWithDependenciesLocked(issue *models.Issue, dependsOn []string, sessionID string) error {
+	if issue.Status == "" {
+		issue.Status = models.StatusOpen
+	}
+	if issue.Type == "" {
+		issue.Type = models.TypeTask
+	}
+	if issue.Priority == "" {
+		issue.Priority = models.PriorityP2
+	}
+	if issue.CreatedBy == "" {
+		issue.CreatedBy = sessionID
+	}
+
+	now := time.Now()
+	issue.CreatedAt = now
+	issue.UpdatedAt = now
+
+	const maxRetries = 3
+	for attempt := range maxRetries {
+		// The ID is picked outside the transaction: sequential IDs read
+		// the issues table through db.conn, which the open tx would hold
+		id, err := db.newIssueID()
+		if err != nil {
+			return err
 		}
-		if issue.Type == "" {
-			issue.Type = models.TypeTask
+		issue.ID = id
+
+		err = db.insertIssueWithDependencies(issue, dependsOn, sessionID, now)
+		if err == nil {
+			return nil
 		}
-		if issue.Priority == "" {
-			issue.Priority = models.PriorityP2
+		if !strings.Contains(err.Error(), "UNIQUE constraint") {
+			return err
 		}
+		if attempt == maxRetries-1 {
+			return fmt.Errorf("failed to generate unique issue ID after %d attempts", maxRetries)
+		}
+	}
+	return nil
+}
 
-		now := time.Now()
-		issue.CreatedAt = now
-		issue.UpdatedAt = now
-
-		labels := strings.Join(issue.Labels, ",")
+// insertIssueWithDependencies writes issue, its dependencies and their
+// action_log rows in one transaction. Caller MUST hold the write lock.
+func (db *DB) insertIssueWithDependencies(issue *models.Issue, dependsOn []string, sessionID string, now time.Time) error {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
 
-		const maxRetries = 3
-		for attempt := range maxRetries {
-			id, err := generateID()
-			if err != nil {
-				return err
-			}
-			issue.ID = id
+	deferUntil := sql.NullString{String: "", Valid: false}
+	if issue.DeferUntil != nil {
+		deferUntil = sql.NullString{String: *issue.DeferUntil, Valid: true}
+	}
+	dueDate := sql.NullString{String: "", Valid: false}
+	if issue.DueDate != nil {
+		dueDate = sql.NullString{String: *issue.DueDate, Valid: true}
+	}
 
-			deferUntil := sql.NullString{String: "", Valid: false}
-			if issue.DeferUntil != nil {
-				deferUntil = sql.NullString{String: *issue.DeferUntil, Valid: true}
-			}
-			dueDate := sql.NullString{String: "", Valid: false}
-			if issue.DueDate != nil {
-				dueDate = sql.NullString{String: *issue.DueDate, Valid: true}
-			}
+	_, err = tx.Exec(`
+		INSERT INTO issues (id, title, description, status, type, priority, points, labels, parent_id, acceptance, created_at, updated_at, minor, created_branch, creator_session, created_by, defer_until, due_date, defer_count)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, issue.ID, issue.Title, issue.Description, issue.Status, issue.Type, issue.Priority, issue.Points, strings.Join(issue.Labels, ","), issue.ParentID, issue.Acceptance, issue.CreatedAt, issue.UpdatedAt, issue.Minor, issue.CreatedBranch, issue.CreatorSession, issue.CreatedBy, deferUntil, dueDate, issue.DeferCount)
+	if err != nil {
+		return err
+	}
 
-			_, err = db.conn.Exec(`
-				INSERT INTO issues (id, title, description, status, type, priority, points, labels, parent_id, acceptance, created_at, updated_at, minor, created_branch, creator_session, defer_until, due_date, defer_count)
-				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-			`, issue.ID, issue.Title, issue.Description, issue.Status, issue.Type, issue.Priority, issue.Points, labels, issue.ParentID, issue.Acceptance, issue.CreatedAt, issue.UpdatedAt, issue.Minor, issue.CreatedBranch, issue.CreatorSession, deferUntil, dueDate, issue.DeferCount)
+	// Log the action
+	actionID, err := generateActionID()
+	if err != nil {
+		return fmt.Errorf("generate action ID: %w", err)
+	}
+	actionTS := formatActionLogTimestamp(now)
+	_, err = tx.Exec(`INSERT INTO action_log (id, session_id, action_type, entity_type, entity_id, previous_data, new_data, timestamp, undone) VALUES (?, ?, ?, ?, ?, ?, ?, ?, 0)`,
+		actionID, sessionID, string(models.ActionCreate), "issue", issue.ID, "", marshalIssue(issue), actionTS)
+	if err != nil {
+		return fmt.Errorf("log action: %w", err)
+	}
 
-			if err == nil {
-				break
-			}
-			if !strings.Contains(err.Error(), "UNIQUE constraint") {
-				return err
-			}
-			if attempt == maxRetries-1 {
-				return fmt.Errorf("failed to generate unique issue ID after %d attempts", maxRetries)
-			}
+	for _, dependsOnID := range dependsOn {
+		depID := DependencyID(issue.ID, dependsOnID, models.RelationDependsOn)
+		_, err = tx.Exec(`
+			INSERT OR REPLACE INTO issue_dependencies (id, issue_id, depends_on_id, relation_type)
+			VALUES (?, ?, ?, ?)
+		`, depID, issue.ID, dependsOnID, models.RelationDependsOn)
+		if err != nil {
+			return fmt.Errorf("add dependency on %s: %w", dependsOnID, err)
 		}
 
-		// Log the action
 		actionID, err := generateActionID()
 		if err != nil {
 			return fmt.Errorf("generate action ID: %w", err)
 		}
-		newData := marshalIssue(issue)
-		actionTS := formatActionLogTimestamp(now)
-		_, err = db.conn.Exec(`INSERT INTO action_log (id, session_id, action_type, entity_type, entity_id, previous_data, new_data, timestamp, undone) VALUES (?, ?, ?, ?, ?, ?, ?, ?, 0)`,
-			actionID, sessionID, string(models.ActionCreate), "issue", issue.ID, "", newData, actionTS)
+		_, err = tx.Exec(`INSERT INTO action_log (id, session_id, action_type, entity_type, entity_id, previous_data, new_data, timestamp, undone) VALUES (?, ?, ?, ?, ?, ?, ?, ?, 0)`,
+			actionID, sessionID, string(models.ActionAddDep), "issue_dependencies", depID, "",
+			marshalDependency(depID, issue.ID, dependsOnID, models.RelationDependsOn), actionTS)
 		if err != nil {
 			return fmt.Errorf("log action: %w", err)
 		}
+	}
 
-		return nil
-	})
+	return tx.Commit()
 }
 
 // updateIssueAndLog updates an issue and logs the action WITHOUT acquiring withWriteLock.
@@ -241,6 +309,7 @@ func (db *DB) updateIssueAndLogFromPrevious(issue, prev *models.Issue, sessionID
 
 	// Apply update
 	issue.UpdatedAt = time.Now()
+	issue.Labels = keepReservedLabels(prev.Labels, issue.Labels)
 	labels := strings.Join(issue.Labels, ",")
 
 	deferUntil := sql.NullString{String: "", Valid: false}
@@ -455,6 +524,173 @@ func (db *DB) UpdateIssueLoggedIfStatus(issue *models.Issue, expectedStatus mode
 	})
 }
 
+// ClaimNextIssue claims the first candidate that is still open for sessionID,
+// moving it to in_progress and logging a start action. Each candidate is
+// re-read and checked under the write lock, so concurrent callers working from
+// the same candidate list never claim the same issue: a caller that loses the
+// race simply moves on to the next candidate. The WIP limit is checked under
+// the same lock, so concurrent claims by one session can't exceed it; a
+// *WIPLimitError is returned when it is reached. A wipLimit of 0 or less
+// disables the check. Returns nil if no candidate could be claimed.
+func (db *DB) ClaimNextIssue(candidates []models.Issue, sessionID string, wipLimit int) (*models.Issue, error) {
+	if len(candidates) == 0 {
+		return nil, db.CheckWIPLimit(sessionID, wipLimit)
+	}
+	for _, candidate := range candidates {
+		var claimed *models.Issue
+		err := db.withWriteLock(func() error {
+			if err := db.CheckWIPLimit(sessionID, wipLimit); err != nil {
+				return err
+			}
+			prev, err := db.scanIssueRow(candidate.ID)
+			if err != nil {
+				return err
+			}
+			if prev.Status != models.StatusOpen || prev.DeletedAt != nil {
+				return &StaleIssueStatusError{
+					IssueID:  candidate.ID,
+					Expected: models.StatusOpen,
+					Actual:   prev.Status,
+				}
+			}
+			issue := *prev
+			issue.Status = models.StatusInProgress
+			issue.ImplementerSession = sessionID
+			if err := db.updateIssueAndLogFromPrevious(&issue, prev, sessionID, models.ActionStart); err != nil {
+				return err
+			}
+			claimed = &issue
+			return nil
+		})
+		var staleErr *StaleIssueStatusError
+		if errors.As(err, &staleErr) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return claimed, nil
+	}
+	return nil, nil
+}
+
+// IssueAssignment hands one issue to a session. From is the implementer the
+// assignment was planned against; empty when the issue was unassigned.
+type IssueAssignment struct {
+	IssueID string `json:"id"`
+	From    string `json:"from,omitempty"`
+	To      string `json:"to"`
+}
+
+// AssignIssuesLogged sets the implementer session of every issue in
+// assignments in a single transaction, logging an update per issue. Each issue
+// is re-read under the write lock and must still have the implementer the
+// assignment was planned against; otherwise nothing is written, so a batch is
+// never applied over a concurrent claim. Assignments that change nothing are
+// skipped; the applied ones are returned.
+func (db *DB) AssignIssuesLogged(assignments []IssueAssignment, sessionID string) ([]IssueAssignment, error) {
+	var applied []IssueAssignment
+	err := db.withWriteLock(func() error {
+		var prevs, nexts []*models.Issue
+		now := time.Now()
+		for _, a := range assignments {
+			prev, err := db.scanIssueRow(NormalizeIssueID(a.IssueID))
+			if err != nil {
+				return err
+			}
+			if prev.DeletedAt != nil {
+				return fmt.Errorf("issue %s is deleted", prev.ID)
+			}
+			if prev.ImplementerSession != a.From {
+				return fmt.Errorf("%s was assigned to %q meanwhile", prev.ID, prev.ImplementerSession)
+			}
+			if prev.ImplementerSession == a.To {
+				continue
+			}
+			next := *prev
+			next.ImplementerSession = a.To
+			next.UpdatedAt = now
+			prevs = append(prevs, prev)
+			nexts = append(nexts, &next)
+		}
+		if len(nexts) == 0 {
+			return nil
+		}
+
+		tx, err := db.conn.Begin()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = tx.Rollback() }()
+
+		actionTS := formatActionLogTimestamp(now)
+		for i, issue := range nexts {
+			if _, err := tx.Exec(`UPDATE issues SET implementer_session = ?, updated_at = ? WHERE id = ?`,
+				issue.ImplementerSession, issue.UpdatedAt, issue.ID); err != nil {
+				return fmt.Errorf("assign %s: %w", issue.ID, err)
+			}
+			actionID, err := generateActionID()
+			if err != nil {
+				return fmt.Errorf("generate action ID: %w", err)
+			}
+			if _, err := tx.Exec(`INSERT INTO action_log (id, session_id, action_type, entity_type, entity_id, previous_data, new_data, timestamp, undone) VALUES (?, ?, ?, ?, ?, ?, ?, ?, 0)`,
+				actionID, sessionID, string(models.ActionUpdate), "issue", issue.ID, marshalIssue(prevs[i]), marshalIssue(issue), actionTS); err != nil {
+				return fmt.Errorf("log action: %w", err)
+			}
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+
+		for i, issue := range nexts {
+			applied = append(applied, IssueAssignment{IssueID: issue.ID, From: prevs[i].ImplementerSession, To: issue.ImplementerSession})
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// GetClaimedIssueIDs returns the IDs of in_progress issues implemented by
+// sessionID, oldest claim first. `td next --claim` compares their count with
+// the WIP limit.
+func (db *DB) GetClaimedIssueIDs(sessionID string) ([]string, error) {
+	rows, err := db.conn.Query(`
+		SELECT id FROM issues
+		WHERE status = 'in_progress' AND implementer_session = ? AND deleted_at IS NULL
+		ORDER BY updated_at, id
+	`, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// CheckWIPLimit returns a *WIPLimitError when sessionID already holds limit
+// or more in_progress issues. A limit of 0 or less disables the check.
+func (db *DB) CheckWIPLimit(sessionID string, limit int) error {
+	if limit <= 0 {
+		return nil
+	}
+	held, err := db.GetClaimedIssueIDs(sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to count claimed issues: %w", err)
+	}
+	if len(held) >= limit {
+		return &WIPLimitError{SessionID: sessionID, Limit: limit, Held: held}
+	}
+	return nil
+}
+
 // DeleteIssueLogged soft-deletes an issue and logs the action atomically within a single withWriteLock call.
 func (db *DB) DeleteIssueLogged(issueID, sessionID string) error {
 	return db.withWriteLock(func() error {
@@ -488,6 +724,118 @@ func (db *DB) DeleteIssueLogged(issueID, sessionID string) error {
 	})
 }
 
+// DeleteIssueTreeLogged deletes an issue that may have children, in one
+// transaction, and logs every change. With orphanChildren the direct
+// children move to the top level; otherwise every descendant is deleted
+// too. Children are read inside the write lock, so ones added after the
+// caller looked are handled the same way. Returns the deleted IDs, the
+// issue itself last.
+func (db *DB) DeleteIssueTreeLogged(issueID, sessionID string, orphanChildren bool) ([]string, error) {
+	var deletedIDs []string
+	err := db.withWriteLock(func() error {
+		root, err := db.scanIssueRow(issueID)
+		if err != nil {
+			return err
+		}
+		if root.DeletedAt != nil {
+			return fmt.Errorf("issue %s is already deleted", root.ID)
+		}
+
+		var affectedIDs []string
+		if orphanChildren {
+			rows, err := db.conn.Query(`SELECT id FROM issues WHERE parent_id = ? AND deleted_at IS NULL ORDER BY id`, root.ID)
+			if err != nil {
+				return err
+			}
+			for rows.Next() {
+				var id string
+				if err := rows.Scan(&id); err != nil {
+					rows.Close()
+					return err
+				}
+				affectedIDs = append(affectedIDs, id)
+			}
+			rows.Close()
+			if err := rows.Err(); err != nil {
+				return err
+			}
+		} else {
+			descendants, err := db.getDescendants(root.ID)
+			if err != nil {
+				return err
+			}
+			// Deepest first, matching the order the issues are logged in
+			for i := len(descendants) - 1; i >= 0; i-- {
+				affectedIDs = append(affectedIDs, descendants[i])
+			}
+		}
+		var affected []*models.Issue
+		for _, id := range affectedIDs {
+			issue, err := db.scanIssueRow(id)
+			if err != nil {
+				return err
+			}
+			affected = append(affected, issue)
+		}
+
+		tx, err := db.conn.Begin()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = tx.Rollback() }()
+
+		now := time.Now()
+		actionTS := formatActionLogTimestamp(now)
+		logAction := func(actionType models.ActionType, id, previousData, newData string) error {
+			actionID, err := generateActionID()
+			if err != nil {
+				return fmt.Errorf("generate action ID: %w", err)
+			}
+			if _, err := tx.Exec(`INSERT INTO action_log (id, session_id, action_type, entity_type, entity_id, previous_data, new_data, timestamp, undone) VALUES (?, ?, ?, ?, ?, ?, ?, ?, 0)`,
+				actionID, sessionID, string(actionType), "issue", id, previousData, newData, actionTS); err != nil {
+				return fmt.Errorf("log action: %w", err)
+			}
+			return nil
+		}
+
+		var deleted []string
+		for _, issue := range affected {
+			if orphanChildren {
+				next := *issue
+				next.ParentID = ""
+				next.UpdatedAt = now
+				if _, err := tx.Exec(`UPDATE issues SET parent_id = '', updated_at = ? WHERE id = ?`, now, issue.ID); err != nil {
+					return fmt.Errorf("detach %s: %w", issue.ID, err)
+				}
+				if err := logAction(models.ActionUpdate, issue.ID, marshalIssue(issue), marshalIssue(&next)); err != nil {
+					return err
+				}
+				continue
+			}
+			if _, err := tx.Exec(`UPDATE issues SET deleted_at = ?, updated_at = ? WHERE id = ?`, now, now, issue.ID); err != nil {
+				return fmt.Errorf("delete %s: %w", issue.ID, err)
+			}
+			if err := logAction(models.ActionDelete, issue.ID, marshalIssue(issue), ""); err != nil {
+				return err
+			}
+			deleted = append(deleted, issue.ID)
+		}
+
+		if _, err := tx.Exec(`UPDATE issues SET deleted_at = ?, updated_at = ? WHERE id = ?`, now, now, root.ID); err != nil {
+			return fmt.Errorf("delete %s: %w", root.ID, err)
+		}
+		if err := logAction(models.ActionDelete, root.ID, marshalIssue(root), ""); err != nil {
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+		deletedIDs = append(deleted, root.ID)
+		return nil
+	})
+	return deletedIDs, err
+}
+
 // RestoreIssueLogged restores a soft-deleted issue and logs the action atomically.
 func (db *DB) RestoreIssueLogged(issueID, sessionID string) error {
 	return db.withWriteLock(func() error {
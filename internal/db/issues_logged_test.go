@@ -3,6 +3,9 @@ package db
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"reflect"
+	"sync"
 	"testing"
 
 	"github.com/marcus/td/internal/models"
@@ -74,6 +77,55 @@ func TestCreateIssueLogged(t *testing.T) {
 	}
 }
 
+func TestCreateIssueLogged_CreatedBy(t *testing.T) {
+	dir := t.TempDir()
+	database, err := Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	named := &models.Issue{Title: "Filed by a named session", CreatedBy: "alice"}
+	if err := database.CreateIssueLogged(named, "ses_a1"); err != nil {
+		t.Fatalf("CreateIssueLogged named: %v", err)
+	}
+	unnamed := &models.Issue{Title: "Filed by an unnamed session"}
+	if err := database.CreateIssueLogged(unnamed, "ses_b2"); err != nil {
+		t.Fatalf("CreateIssueLogged unnamed: %v", err)
+	}
+
+	got, err := database.GetIssue(named.ID)
+	if err != nil {
+		t.Fatalf("GetIssue: %v", err)
+	}
+	if got.CreatedBy != "alice" {
+		t.Errorf("named CreatedBy: got %q, want alice", got.CreatedBy)
+	}
+
+	issues, err := database.ListIssues(ListIssuesOptions{IDs: []string{unnamed.ID}})
+	if err != nil {
+		t.Fatalf("ListIssues: %v", err)
+	}
+	if len(issues) != 1 || issues[0].CreatedBy != "ses_b2" {
+		t.Errorf("unnamed CreatedBy: got %+v, want session ID ses_b2", issues)
+	}
+
+	// The create event carries created_by so synced peers record it too
+	var newData string
+	if err := database.conn.QueryRow(
+		`SELECT new_data FROM action_log WHERE entity_id = ? AND action_type = 'create'`, named.ID,
+	).Scan(&newData); err != nil {
+		t.Fatalf("Query action_log: %v", err)
+	}
+	var logged models.Issue
+	if err := json.Unmarshal([]byte(newData), &logged); err != nil {
+		t.Fatalf("Unmarshal new_data: %v", err)
+	}
+	if logged.CreatedBy != "alice" {
+		t.Errorf("new_data created_by: got %q, want alice", logged.CreatedBy)
+	}
+}
+
 func TestUpdateIssueLogged(t *testing.T) {
 	dir := t.TempDir()
 	database, err := Initialize(dir)
@@ -220,6 +272,147 @@ func TestUpdateIssueLoggedIfStatusDetectsStaleTransition(t *testing.T) {
 	}
 }
 
+func TestClaimNextIssueConcurrent(t *testing.T) {
+	dir := t.TempDir()
+	database, err := Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	const numIssues = 3
+	const numClaimers = 8
+	for i := 0; i < numIssues; i++ {
+		issue := &models.Issue{
+			Title:    fmt.Sprintf("Claim target %d", i),
+			Status:   models.StatusOpen,
+			Type:     models.TypeTask,
+			Priority: models.PriorityP2,
+		}
+		if err := database.CreateIssue(issue); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	// Every claimer works from the same snapshot of candidates, as concurrent
+	// `td next --claim` processes would.
+	candidates, err := database.ListIssues(ListIssuesOptions{Status: []models.Status{models.StatusOpen}})
+	if err != nil {
+		t.Fatalf("ListIssues failed: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	claimedBy := make(map[string]string)
+	empty := 0
+	for i := 0; i < numClaimers; i++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			conn, err := Open(dir)
+			if err != nil {
+				t.Errorf("Open failed: %v", err)
+				return
+			}
+			defer conn.Close()
+
+			sessionID := fmt.Sprintf("ses_claim%d", n)
+			claimed, err := conn.ClaimNextIssue(candidates, sessionID, 0)
+			if err != nil {
+				t.Errorf("ClaimNextIssue failed: %v", err)
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if claimed == nil {
+				empty++
+				return
+			}
+			if prev, dup := claimedBy[claimed.ID]; dup {
+				t.Errorf("issue %s claimed by both %s and %s", claimed.ID, prev, sessionID)
+			}
+			claimedBy[claimed.ID] = sessionID
+		}(i)
+	}
+	wg.Wait()
+
+	if len(claimedBy) != numIssues {
+		t.Fatalf("claimed %d issues, want %d", len(claimedBy), numIssues)
+	}
+	if empty != numClaimers-numIssues {
+		t.Fatalf("empty claims = %d, want %d", empty, numClaimers-numIssues)
+	}
+	for id, sessionID := range claimedBy {
+		got, err := database.GetIssue(id)
+		if err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		if got.Status != models.StatusInProgress || got.ImplementerSession != sessionID {
+			t.Errorf("%s: status=%s implementer=%s, want in_progress/%s", id, got.Status, got.ImplementerSession, sessionID)
+		}
+	}
+}
+
+// TestClaimNextIssueWIPLimitConcurrent checks that concurrent claims by one
+// session never take it past its WIP limit.
+func TestClaimNextIssueWIPLimitConcurrent(t *testing.T) {
+	dir := t.TempDir()
+	database, err := Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	const numClaimers = 4
+	for i := 0; i < numClaimers; i++ {
+		if err := database.CreateIssue(&models.Issue{Title: fmt.Sprintf("WIP target %d", i), Status: models.StatusOpen}); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	candidates, err := database.ListIssues(ListIssuesOptions{Status: []models.Status{models.StatusOpen}})
+	if err != nil {
+		t.Fatalf("ListIssues failed: %v", err)
+	}
+
+	const sessionID = "ses_wip"
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	refused := 0
+	for i := 0; i < numClaimers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			conn, err := Open(dir)
+			if err != nil {
+				t.Errorf("Open failed: %v", err)
+				return
+			}
+			defer conn.Close()
+
+			_, err = conn.ClaimNextIssue(candidates, sessionID, 1)
+			var wipErr *WIPLimitError
+			if errors.As(err, &wipErr) {
+				mu.Lock()
+				refused++
+				mu.Unlock()
+				return
+			}
+			if err != nil {
+				t.Errorf("ClaimNextIssue failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	held, err := database.GetClaimedIssueIDs(sessionID)
+	if err != nil {
+		t.Fatalf("GetClaimedIssueIDs failed: %v", err)
+	}
+	if len(held) != 1 || refused != numClaimers-1 {
+		t.Fatalf("held %v with %d refused, want 1 held and %d refused", held, refused, numClaimers-1)
+	}
+}
+
 func TestDeleteIssueLogged(t *testing.T) {
 	dir := t.TempDir()
 	database, err := Initialize(dir)
@@ -313,6 +506,77 @@ func TestDeleteIssueLogged_NotFound(t *testing.T) {
 	}
 }
 
+func TestDeleteIssueTreeLogged(t *testing.T) {
+	dir := t.TempDir()
+	database, err := Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	create := func(title, parentID string) *models.Issue {
+		issue := &models.Issue{Title: title, Type: models.TypeTask, ParentID: parentID}
+		if err := database.CreateIssue(issue); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue
+	}
+	countActions := func(actionType models.ActionType, id string) int {
+		var n int
+		if err := database.conn.QueryRow(`SELECT COUNT(*) FROM action_log WHERE action_type = ? AND entity_id = ?`,
+			string(actionType), id).Scan(&n); err != nil {
+			t.Fatalf("count action_log: %v", err)
+		}
+		return n
+	}
+
+	// Cascade deletes the whole subtree, deepest first, root last
+	epic := create("Epic", "")
+	child := create("Child", epic.ID)
+	grandchild := create("Grandchild", child.ID)
+	deleted, err := database.DeleteIssueTreeLogged(epic.ID, "sess-tree", false)
+	if err != nil {
+		t.Fatalf("DeleteIssueTreeLogged cascade failed: %v", err)
+	}
+	if want := []string{grandchild.ID, child.ID, epic.ID}; !reflect.DeepEqual(deleted, want) {
+		t.Errorf("deleted = %v, want %v", deleted, want)
+	}
+	for _, id := range deleted {
+		if got, _ := database.GetIssue(id); got.DeletedAt == nil {
+			t.Errorf("%s not deleted", id)
+		}
+		if n := countActions(models.ActionDelete, id); n != 1 {
+			t.Errorf("%s has %d delete actions, want 1", id, n)
+		}
+	}
+
+	// Orphan deletes only the root and detaches its children
+	parent := create("Parent", "")
+	kept := create("Kept", parent.ID)
+	deleted, err = database.DeleteIssueTreeLogged(parent.ID, "sess-tree", true)
+	if err != nil {
+		t.Fatalf("DeleteIssueTreeLogged orphan failed: %v", err)
+	}
+	if !reflect.DeepEqual(deleted, []string{parent.ID}) {
+		t.Errorf("deleted = %v, want [%s]", deleted, parent.ID)
+	}
+	got, _ := database.GetIssue(kept.ID)
+	if got.DeletedAt != nil || got.ParentID != "" {
+		t.Errorf("child deleted=%v parent=%q, want kept at top level", got.DeletedAt != nil, got.ParentID)
+	}
+	if n := countActions(models.ActionUpdate, kept.ID); n != 1 {
+		t.Errorf("child has %d update actions, want 1", n)
+	}
+
+	// Deleting again fails and changes nothing
+	if _, err := database.DeleteIssueTreeLogged(parent.ID, "sess-tree", true); err == nil {
+		t.Error("expected error deleting an already deleted issue")
+	}
+	if n := countActions(models.ActionDelete, parent.ID); n != 1 {
+		t.Errorf("parent has %d delete actions after retry, want 1", n)
+	}
+}
+
 func TestUnloggedVariants_NoActionLog(t *testing.T) {
 	dir := t.TempDir()
 	database, err := Initialize(dir)
@@ -0,0 +1,41 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/marcus/td/internal/models"
+)
+
+func TestContentSearchMatchesPartlyEmptyHandoff(t *testing.T) {
+	dir := t.TempDir()
+	db, err := Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer db.Close()
+
+	issue := &models.Issue{Title: "Handoff match"}
+	if err := db.CreateIssue(issue); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := db.CreateIssue(&models.Issue{Title: "Unrelated"}); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	handoff := &models.Handoff{IssueID: issue.ID, SessionID: "ses-search", Remaining: []string{"retry the widget build"}}
+	if err := db.AddHandoff(handoff); err != nil {
+		t.Fatalf("AddHandoff failed: %v", err)
+	}
+	// Handoffs synced from older clients can leave lists NULL
+	if _, err := db.conn.Exec(`UPDATE handoffs SET done = NULL, uncertain = NULL WHERE id = ?`, handoff.ID); err != nil {
+		t.Fatalf("clear handoff lists: %v", err)
+	}
+
+	issues, err := db.ListIssues(ListIssuesOptions{Search: "widget", SearchContent: true})
+	if err != nil {
+		t.Fatalf("ListIssues failed: %v", err)
+	}
+	if len(issues) != 1 || issues[0].ID != issue.ID {
+		t.Errorf("content search matched %v, want only %s", issues, issue.ID)
+	}
+}
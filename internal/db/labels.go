@@ -4,11 +4,21 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
+
+	"github.com/marcus/td/internal/models"
 )
 
-// ListDistinctLabels returns the sorted set of labels found on non-deleted
-// issues in the current database.
-func (db *DB) ListDistinctLabels() ([]string, error) {
+// LabelCount is a label in use and the number of non-deleted issues that
+// carry it.
+type LabelCount struct {
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
+// ListAllLabels returns every label found on non-deleted issues with its
+// usage count, sorted by label. An issue that lists a label twice counts once.
+func (db *DB) ListAllLabels() ([]LabelCount, error) {
 	rows, err := db.conn.Query(`
 		SELECT labels
 		FROM issues
@@ -21,33 +31,186 @@ func (db *DB) ListDistinctLabels() ([]string, error) {
 	}
 	defer rows.Close()
 
-	seen := make(map[string]struct{})
-	labels := make([]string, 0)
-
+	counts := make(map[string]int)
 	for rows.Next() {
 		var raw string
 		if err := rows.Scan(&raw); err != nil {
 			return nil, fmt.Errorf("scan labels: %w", err)
 		}
+		seen := make(map[string]bool)
 		for _, label := range strings.Split(raw, ",") {
 			label = strings.TrimSpace(label)
-			if label == "" {
+			if label == "" || seen[label] {
 				continue
 			}
-			if _, ok := seen[label]; ok {
-				continue
-			}
-			seen[label] = struct{}{}
-			labels = append(labels, label)
+			seen[label] = true
+			counts[label]++
 		}
 	}
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("iterate labels: %w", err)
 	}
 
-	sort.Strings(labels)
-	if labels == nil {
-		return []string{}, nil
+	labels := make([]LabelCount, 0, len(counts))
+	for label, n := range counts {
+		labels = append(labels, LabelCount{Label: label, Count: n})
+	}
+	sort.Slice(labels, func(i, j int) bool { return labels[i].Label < labels[j].Label })
+	return labels, nil
+}
+
+// ListDistinctLabels returns the sorted set of labels found on non-deleted
+// issues in the current database.
+func (db *DB) ListDistinctLabels() ([]string, error) {
+	counts, err := db.ListAllLabels()
+	if err != nil {
+		return nil, err
+	}
+	labels := make([]string, len(counts))
+	for i, lc := range counts {
+		labels[i] = lc.Label
 	}
 	return labels, nil
 }
+
+// replaceLabel swaps oldLabel for newLabel in labels, keeping the first
+// occurrence of each label so an issue that already carries newLabel ends up
+// with it once. Returns false if oldLabel is not present.
+func replaceLabel(labels []string, oldLabel, newLabel string) ([]string, bool) {
+	found := false
+	seen := make(map[string]bool, len(labels))
+	out := make([]string, 0, len(labels))
+	for _, label := range labels {
+		label = strings.TrimSpace(label)
+		if label == "" {
+			continue
+		}
+		if label == oldLabel {
+			found = true
+			label = newLabel
+		}
+		if seen[label] {
+			continue
+		}
+		seen[label] = true
+		out = append(out, label)
+	}
+	return out, found
+}
+
+// keepReservedLabels returns next with its reserved labels (see
+// models.ReservedLabelPrefixes) replaced by prev's, so an edit can neither
+// drop nor add them.
+func keepReservedLabels(prev, next []string) []string {
+	var out []string
+	for _, label := range next {
+		if !models.IsReservedLabel(strings.TrimSpace(label)) {
+			out = append(out, label)
+		}
+	}
+	for _, label := range prev {
+		if models.IsReservedLabel(label) {
+			out = append(out, label)
+		}
+	}
+	return out
+}
+
+// RenameLabel rewrites oldLabel to newLabel on every non-deleted issue in a
+// single transaction. If an issue already has newLabel the two are merged.
+// Each changed issue gets an update entry in the action log so the rename
+// syncs like any other edit. Returns the number of issues changed.
+func (db *DB) RenameLabel(oldLabel, newLabel, sessionID string) (int, error) {
+	oldLabel = strings.TrimSpace(oldLabel)
+	newLabel = strings.TrimSpace(newLabel)
+	if oldLabel == "" || newLabel == "" {
+		return 0, fmt.Errorf("label names cannot be empty")
+	}
+	if strings.Contains(newLabel, ",") {
+		return 0, fmt.Errorf("label cannot contain a comma: %q", newLabel)
+	}
+	if oldLabel == newLabel {
+		return 0, nil
+	}
+	for _, label := range []string{oldLabel, newLabel} {
+		if models.IsReservedLabel(label) {
+			return 0, fmt.Errorf("label %q is reserved", label)
+		}
+	}
+
+	changed := 0
+	err := db.withWriteLock(func() error {
+		rows, err := db.conn.Query(`
+			SELECT id FROM issues
+			WHERE deleted_at IS NULL
+			  AND labels IS NOT NULL
+			  AND labels != ''
+		`)
+		if err != nil {
+			return fmt.Errorf("query labels: %w", err)
+		}
+		var ids []string
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return fmt.Errorf("scan issue id: %w", err)
+			}
+			ids = append(ids, id)
+		}
+		if err := rows.Err(); err != nil {
+			rows.Close()
+			return fmt.Errorf("iterate issues: %w", err)
+		}
+		rows.Close()
+
+		var updates []*models.Issue
+		var previous []string
+		now := time.Now()
+		for _, id := range ids {
+			issue, err := db.scanIssueRow(id)
+			if err != nil {
+				return err
+			}
+			labels, ok := replaceLabel(issue.Labels, oldLabel, newLabel)
+			if !ok {
+				continue
+			}
+			previous = append(previous, marshalIssue(issue))
+			issue.Labels = labels
+			issue.UpdatedAt = now
+			updates = append(updates, issue)
+		}
+		if len(updates) == 0 {
+			return nil
+		}
+
+		tx, err := db.conn.Begin()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = tx.Rollback() }()
+
+		actionTS := formatActionLogTimestamp(now)
+		for i, issue := range updates {
+			if _, err := tx.Exec(`UPDATE issues SET labels = ?, updated_at = ? WHERE id = ?`,
+				strings.Join(issue.Labels, ","), issue.UpdatedAt, issue.ID); err != nil {
+				return fmt.Errorf("update labels on %s: %w", issue.ID, err)
+			}
+			actionID, err := generateActionID()
+			if err != nil {
+				return fmt.Errorf("generate action ID: %w", err)
+			}
+			if _, err := tx.Exec(`INSERT INTO action_log (id, session_id, action_type, entity_type, entity_id, previous_data, new_data, timestamp, undone) VALUES (?, ?, ?, ?, ?, ?, ?, ?, 0)`,
+				actionID, sessionID, string(models.ActionUpdate), "issue", issue.ID, previous[i], marshalIssue(issue), actionTS); err != nil {
+				return fmt.Errorf("log action: %w", err)
+			}
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+		changed = len(updates)
+		return nil
+	})
+	return changed, err
+}
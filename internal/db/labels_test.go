@@ -0,0 +1,174 @@
+package db
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/marcus/td/internal/models"
+)
+
+func TestRenameLabel(t *testing.T) {
+	database, err := Initialize(t.TempDir())
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	tagged := &models.Issue{Title: "Tagged", Labels: []string{"front-end", "ui"}}
+	other := &models.Issue{Title: "Other", Labels: []string{"backend"}}
+	for _, issue := range []*models.Issue{tagged, other} {
+		if err := database.CreateIssue(issue); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	count, err := database.RenameLabel("front-end", "frontend", "ses_test")
+	if err != nil {
+		t.Fatalf("RenameLabel failed: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("RenameLabel count = %d, want 1", count)
+	}
+
+	got, _ := database.GetIssue(tagged.ID)
+	if want := []string{"frontend", "ui"}; !reflect.DeepEqual(got.Labels, want) {
+		t.Errorf("labels = %v, want %v", got.Labels, want)
+	}
+	got, _ = database.GetIssue(other.ID)
+	if want := []string{"backend"}; !reflect.DeepEqual(got.Labels, want) {
+		t.Errorf("untouched labels = %v, want %v", got.Labels, want)
+	}
+
+	var logged int
+	if err := database.conn.QueryRow(
+		`SELECT COUNT(*) FROM action_log WHERE entity_id = ? AND action_type = ? AND session_id = ?`,
+		tagged.ID, string(models.ActionUpdate), "ses_test",
+	).Scan(&logged); err != nil {
+		t.Fatalf("count action log: %v", err)
+	}
+	if logged != 1 {
+		t.Errorf("update actions logged = %d, want 1", logged)
+	}
+}
+
+func TestReservedLabelsSurviveEdits(t *testing.T) {
+	database, err := Initialize(t.TempDir())
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	imported := &models.Issue{Title: "Imported", Labels: []string{"ops", "jira:OPS-1"}}
+	if err := database.CreateIssue(imported); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	// An edit that drops the source label and forges another keeps the original
+	imported.Labels = []string{"ops", "infra", "jira:OPS-2"}
+	if err := database.UpdateIssueLogged(imported, "ses_test", models.ActionUpdate); err != nil {
+		t.Fatalf("UpdateIssueLogged failed: %v", err)
+	}
+	got, _ := database.GetIssue(imported.ID)
+	if want := []string{"ops", "infra", "jira:OPS-1"}; !reflect.DeepEqual(got.Labels, want) {
+		t.Errorf("labels = %v, want %v", got.Labels, want)
+	}
+
+	for _, pair := range [][2]string{{"jira:OPS-1", "ops-1"}, {"ops", "jira:OPS-3"}} {
+		if _, err := database.RenameLabel(pair[0], pair[1], "ses_test"); err == nil {
+			t.Errorf("RenameLabel(%q, %q) succeeded, want reserved-label error", pair[0], pair[1])
+		}
+	}
+}
+
+func TestRenameLabelMergesIntoExisting(t *testing.T) {
+	database, err := Initialize(t.TempDir())
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	both := &models.Issue{Title: "Both", Labels: []string{"frontend", "front-end", "ui"}}
+	old := &models.Issue{Title: "Old", Labels: []string{"front-end"}}
+	for _, issue := range []*models.Issue{both, old} {
+		if err := database.CreateIssue(issue); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	count, err := database.RenameLabel("front-end", "frontend", "ses_test")
+	if err != nil {
+		t.Fatalf("RenameLabel failed: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("RenameLabel count = %d, want 2", count)
+	}
+
+	got, _ := database.GetIssue(both.ID)
+	if want := []string{"frontend", "ui"}; !reflect.DeepEqual(got.Labels, want) {
+		t.Errorf("merged labels = %v, want %v", got.Labels, want)
+	}
+	got, _ = database.GetIssue(old.ID)
+	if want := []string{"frontend"}; !reflect.DeepEqual(got.Labels, want) {
+		t.Errorf("renamed labels = %v, want %v", got.Labels, want)
+	}
+
+	labels, err := database.ListDistinctLabels()
+	if err != nil {
+		t.Fatalf("ListDistinctLabels failed: %v", err)
+	}
+	if want := []string{"frontend", "ui"}; !reflect.DeepEqual(labels, want) {
+		t.Errorf("distinct labels = %v, want %v", labels, want)
+	}
+}
+
+func TestListAllLabels(t *testing.T) {
+	database, err := Initialize(t.TempDir())
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	issues := []*models.Issue{
+		{Title: "One", Labels: []string{"backend", "ui"}},
+		{Title: "Two", Labels: []string{"backend"}},
+		{Title: "Dup", Labels: []string{"ui", "ui"}},
+		{Title: "Gone", Labels: []string{"backend", "obsolete"}},
+		{Title: "Bare"},
+	}
+	for _, issue := range issues {
+		if err := database.CreateIssue(issue); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if err := database.DeleteIssue(issues[3].ID); err != nil {
+		t.Fatalf("DeleteIssue failed: %v", err)
+	}
+
+	got, err := database.ListAllLabels()
+	if err != nil {
+		t.Fatalf("ListAllLabels failed: %v", err)
+	}
+	want := []LabelCount{
+		{Label: "backend", Count: 2},
+		{Label: "ui", Count: 2},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ListAllLabels = %v, want %v", got, want)
+	}
+}
+
+func TestListAllLabelsEmpty(t *testing.T) {
+	database, err := Initialize(t.TempDir())
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	got, err := database.ListAllLabels()
+	if err != nil {
+		t.Fatalf("ListAllLabels failed: %v", err)
+	}
+	if got == nil || len(got) != 0 {
+		t.Errorf("ListAllLabels = %#v, want empty non-nil slice", got)
+	}
+}
@@ -292,6 +292,26 @@ func (db *DB) runMigrationsInternal() (int, error) {
 				migrationsRun++
 				continue
 			}
+			if migration.Version == 36 {
+				if err := db.migrateCommentThreads(); err != nil {
+					return migrationsRun, fmt.Errorf("migration 36 (comment threads): %w", err)
+				}
+				if err := db.setSchemaVersionInternal(migration.Version); err != nil {
+					return migrationsRun, fmt.Errorf("set version %d: %w", migration.Version, err)
+				}
+				migrationsRun++
+				continue
+			}
+			if migration.Version == 37 {
+				if err := db.migrateIssueCreatedBy(); err != nil {
+					return migrationsRun, fmt.Errorf("migration 37 (issue created_by): %w", err)
+				}
+				if err := db.setSchemaVersionInternal(migration.Version); err != nil {
+					return migrationsRun, fmt.Errorf("set version %d: %w", migration.Version, err)
+				}
+				migrationsRun++
+				continue
+			}
 			if _, err := db.conn.Exec(migration.SQL); err != nil {
 				return migrationsRun, fmt.Errorf("migration %d (%s): %w", migration.Version, migration.Description, err)
 			}
@@ -419,6 +439,41 @@ CREATE INDEX IF NOT EXISTS idx_sessions_branch_agent ON sessions(branch, agent_t
 	return nil
 }
 
+// migrateCommentThreads adds comments.parent_comment_id so a comment can reply
+// to another comment on the same issue. Existing rows default to empty (top
+// level). Guarded by columnExists so re-running is safe.
+func (db *DB) migrateCommentThreads() error {
+	exists, err := db.columnExists("comments", "parent_comment_id")
+	if err != nil {
+		return fmt.Errorf("check parent_comment_id column: %w", err)
+	}
+	if !exists {
+		if _, err := db.conn.Exec(
+			`ALTER TABLE comments ADD COLUMN parent_comment_id TEXT DEFAULT ''`); err != nil {
+			return fmt.Errorf("add parent_comment_id column: %w", err)
+		}
+	}
+	return nil
+}
+
+// migrateIssueCreatedBy adds issues.created_by, the identity (session name or
+// session ID) that filed the issue. Issues created before the column existed
+// keep an empty value; creator_session is not copied because it is a bare
+// session ID, not an identity.
+func (db *DB) migrateIssueCreatedBy() error {
+	exists, err := db.columnExists("issues", "created_by")
+	if err != nil {
+		return fmt.Errorf("check created_by column: %w", err)
+	}
+	if !exists {
+		if _, err := db.conn.Exec(
+			`ALTER TABLE issues ADD COLUMN created_by TEXT DEFAULT ''`); err != nil {
+			return fmt.Errorf("add created_by column: %w", err)
+		}
+	}
+	return nil
+}
+
 // migrateWorktreeIdentity adds current checkout metadata to sessions and work
 // sessions. Existing rows keep empty values, and session lookup has a one
 // release legacy fallback for those empty-worktree rows.
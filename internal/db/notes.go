@@ -79,7 +79,7 @@ func (db *DB) CreateNote(title, content string) (*models.Note, error) {
 			_, err = db.conn.Exec(`
 				INSERT INTO notes (id, title, content, created_at, updated_at, pinned, archived)
 				VALUES (?, ?, ?, ?, ?, 0, 0)
-			`, note.ID, note.Title, note.Content, note.CreatedAt.Format(time.RFC3339), note.UpdatedAt.Format(time.RFC3339))
+			`, note.ID, note.Title, note.Content, formatTimestamp(note.CreatedAt), formatTimestamp(note.UpdatedAt))
 
 			if err == nil {
 				break
@@ -227,7 +227,7 @@ func (db *DB) UpdateNote(id, title, content string) (*models.Note, error) {
 		now := time.Now()
 		_, err = db.conn.Exec(`
 			UPDATE notes SET title = ?, content = ?, updated_at = ? WHERE id = ?
-		`, title, content, now.Format(time.RFC3339), id)
+		`, title, content, formatTimestamp(now), id)
 		if err != nil {
 			return err
 		}
@@ -273,7 +273,7 @@ func (db *DB) DeleteNote(id string) error {
 
 		now := time.Now()
 		_, err = db.conn.Exec(`UPDATE notes SET deleted_at = ?, updated_at = ? WHERE id = ?`,
-			now.Format(time.RFC3339), now.Format(time.RFC3339), id)
+			formatTimestamp(now), formatTimestamp(now), id)
 		if err != nil {
 			return err
 		}
@@ -299,7 +299,7 @@ func (db *DB) PinNote(id string) error {
 	return db.withWriteLock(func() error {
 		now := time.Now()
 		result, err := db.conn.Exec(`UPDATE notes SET pinned = 1, updated_at = ? WHERE id = ? AND deleted_at IS NULL`,
-			now.Format(time.RFC3339), id)
+			formatTimestamp(now), id)
 		if err != nil {
 			return err
 		}
@@ -316,7 +316,7 @@ func (db *DB) UnpinNote(id string) error {
 	return db.withWriteLock(func() error {
 		now := time.Now()
 		result, err := db.conn.Exec(`UPDATE notes SET pinned = 0, updated_at = ? WHERE id = ? AND deleted_at IS NULL`,
-			now.Format(time.RFC3339), id)
+			formatTimestamp(now), id)
 		if err != nil {
 			return err
 		}
@@ -333,7 +333,7 @@ func (db *DB) ArchiveNote(id string) error {
 	return db.withWriteLock(func() error {
 		now := time.Now()
 		result, err := db.conn.Exec(`UPDATE notes SET archived = 1, updated_at = ? WHERE id = ? AND deleted_at IS NULL`,
-			now.Format(time.RFC3339), id)
+			formatTimestamp(now), id)
 		if err != nil {
 			return err
 		}
@@ -350,7 +350,7 @@ func (db *DB) UnarchiveNote(id string) error {
 	return db.withWriteLock(func() error {
 		now := time.Now()
 		result, err := db.conn.Exec(`UPDATE notes SET archived = 0, updated_at = ? WHERE id = ? AND deleted_at IS NULL`,
-			now.Format(time.RFC3339), id)
+			formatTimestamp(now), id)
 		if err != nil {
 			return err
 		}
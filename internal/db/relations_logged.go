@@ -44,9 +44,10 @@ func (db *DB) AddDependencyLogged(issueID, dependsOnID, relationType, sessionID
 
 		return nil
 	})
-	if err == nil {
+	if err == nil && relationType == models.RelationDependsOn {
 		// reviewpolicy.IssueMutation.DependenciesChanged: post-mutation
 		// invalidation of any active approval on this issue. Best-effort.
+		// Non-blocking relations don't change what was reviewed.
 		db.supersedeApprovalIfLinked(issueID)
 	}
 	return err
@@ -139,25 +140,33 @@ func (db *DB) UnlinkFileLogged(issueID, filePath, sessionID string) error {
 
 // RemoveDependencyLogged removes a dependency and logs the action atomically within a single withWriteLock call.
 // If the dependency does not exist locally, this is a no-op (no action_log entry is created).
+// Only depends_on rows are touched; relates_to links between the same pair survive.
 func (db *DB) RemoveDependencyLogged(issueID, dependsOnID, sessionID string) error {
+	didDelete, err := db.removeRelationRowLogged(issueID, dependsOnID, models.RelationDependsOn, sessionID)
+	if err == nil && didDelete {
+		// DependenciesChanged invalidation. Best-effort.
+		db.supersedeApprovalIfLinked(issueID)
+	}
+	return err
+}
+
+// removeRelationRowLogged deletes one issue_dependencies row of the given
+// relation type and logs the removal. Reports whether a row was deleted.
+func (db *DB) removeRelationRowLogged(issueID, dependsOnID, relationType, sessionID string) (bool, error) {
 	var didDelete bool
 	err := db.withWriteLock(func() error {
-		// Check if the dependency exists before deleting
-		var relationType string
-		err := db.conn.QueryRow(`SELECT relation_type FROM issue_dependencies WHERE issue_id = ? AND depends_on_id = ?`, issueID, dependsOnID).Scan(&relationType)
-		if err != nil {
-			// Row doesn't exist, nothing to remove
-			return nil
-		}
-
 		depID := DependencyID(issueID, dependsOnID, relationType)
-		previousData := marshalDependency(depID, issueID, dependsOnID, relationType)
-
-		_, err = db.conn.Exec(`DELETE FROM issue_dependencies WHERE issue_id = ? AND depends_on_id = ?`, issueID, dependsOnID)
+		res, err := db.conn.Exec(`DELETE FROM issue_dependencies WHERE issue_id = ? AND depends_on_id = ? AND relation_type = ?`,
+			issueID, dependsOnID, relationType)
 		if err != nil {
 			return err
 		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			// Row doesn't exist, nothing to remove
+			return nil
+		}
 		didDelete = true
+		previousData := marshalDependency(depID, issueID, dependsOnID, relationType)
 
 		// Log the action
 		actionID, err := generateActionID()
@@ -174,9 +183,5 @@ func (db *DB) RemoveDependencyLogged(issueID, dependsOnID, sessionID string) err
 
 		return nil
 	})
-	if err == nil && didDelete {
-		// DependenciesChanged invalidation. Best-effort.
-		db.supersedeApprovalIfLinked(issueID)
-	}
-	return err
+	return didDelete, err
 }
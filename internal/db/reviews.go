@@ -14,23 +14,29 @@ import (
 func (db *DB) CreateIssueReview(issueID, reviewerSession, decision, summary, requestedBySession string, selfReview bool) (string, error) {
 	var id string
 	err := db.withWriteLock(func() error {
-		newID, err := generateTextID(reviewIDPrefix)
-		if err != nil {
-			return fmt.Errorf("generate review id: %w", err)
-		}
-		_, err = db.conn.Exec(`
-			INSERT INTO issue_reviews (id, issue_id, reviewer_session, decision, summary, requested_by_session, created_at, self_review)
-			VALUES (?, ?, ?, ?, ?, ?, ?, ?)
-		`, newID, NormalizeIssueID(issueID), reviewerSession, decision, summary, requestedBySession, time.Now(), selfReview)
-		if err != nil {
-			return fmt.Errorf("insert issue_reviews: %w", err)
-		}
-		id = newID
-		return nil
+		var err error
+		id, err = db.createIssueReviewLocked(issueID, reviewerSession, decision, summary, requestedBySession, selfReview)
+		return err
 	})
 	return id, err
 }
 
+// createIssueReviewLocked is the inner implementation that assumes the write lock is held.
+func (db *DB) createIssueReviewLocked(issueID, reviewerSession, decision, summary, requestedBySession string, selfReview bool) (string, error) {
+	id, err := generateTextID(reviewIDPrefix)
+	if err != nil {
+		return "", fmt.Errorf("generate review id: %w", err)
+	}
+	_, err = db.conn.Exec(`
+		INSERT INTO issue_reviews (id, issue_id, reviewer_session, decision, summary, requested_by_session, created_at, self_review)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, id, NormalizeIssueID(issueID), reviewerSession, decision, summary, requestedBySession, time.Now(), selfReview)
+	if err != nil {
+		return "", fmt.Errorf("insert issue_reviews: %w", err)
+	}
+	return id, nil
+}
+
 // GetActiveApprovalReview returns the current non-superseded approval review
 // for an issue, or nil if none exists. Only decisions that represent an
 // actual approval are considered (approved and approved_by_parent_cascade);
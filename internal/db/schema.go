@@ -1,7 +1,7 @@
 package db
 
 // SchemaVersion is the current database schema version
-const SchemaVersion = 35
+const SchemaVersion = 37
 
 const schema = `
 -- Issues table
@@ -309,11 +309,13 @@ ALTER TABLE issues ADD COLUMN sprint TEXT DEFAULT '';
 
 -- Create built-in "All Issues" board (empty query = all issues)
 INSERT INTO boards (id, name, query, is_builtin, created_at, updated_at)
-VALUES ('bd-all-issues', 'All Issues', '', 1, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+VALUES ('bd-all-issues', 'All Issues', '', 1,
+        strftime('%Y-%m-%dT%H:%M:%S.000000000Z', 'now'),
+        strftime('%Y-%m-%dT%H:%M:%S.000000000Z', 'now'))
 ON CONFLICT(name) DO UPDATE SET
     query = excluded.query,
     is_builtin = 1,
-    updated_at = CURRENT_TIMESTAMP;
+    updated_at = excluded.updated_at;
 `,
 	},
 	{
@@ -545,4 +547,18 @@ CREATE TABLE IF NOT EXISTS session_state (
 );
 `,
 	},
+	{
+		Version:     36,
+		Description: "Add parent_comment_id to comments for threaded replies",
+		// Handled by custom Go code in migrations.go (migrateCommentThreads)
+		// using a columnExists guard so re-running is safe.
+		SQL: "",
+	},
+	{
+		Version:     37,
+		Description: "Add created_by to issues for creator identity",
+		// Handled by custom Go code in migrations.go (migrateIssueCreatedBy).
+		// Existing rows are left empty.
+		SQL: "",
+	},
 }
@@ -6,11 +6,11 @@ import (
 	"time"
 )
 
-// TestSchemaVersion_At35 confirms the current schema version is 35 and that
+// TestSchemaVersion_At37 confirms the current schema version is 37 and that
 // a freshly initialized database reports that version after migrations run.
-func TestSchemaVersion_At35(t *testing.T) {
-	if SchemaVersion != 35 {
-		t.Fatalf("SchemaVersion: want 35, got %d", SchemaVersion)
+func TestSchemaVersion_At37(t *testing.T) {
+	if SchemaVersion != 37 {
+		t.Fatalf("SchemaVersion: want 37, got %d", SchemaVersion)
 	}
 
 	dir := t.TempDir()
@@ -120,8 +120,8 @@ func TestMigration35_SessionStateTableShapeAndIdempotency(t *testing.T) {
 	}
 	if n, err := database.RunMigrations(); err != nil {
 		t.Fatalf("RunMigrations first: %v", err)
-	} else if n != 1 {
-		t.Fatalf("RunMigrations first count: got %d want 1", n)
+	} else if n != SchemaVersion-34 {
+		t.Fatalf("RunMigrations first count: got %d want %d", n, SchemaVersion-34)
 	}
 	assertSessionStateTableShape(t, database)
 
@@ -130,8 +130,8 @@ func TestMigration35_SessionStateTableShapeAndIdempotency(t *testing.T) {
 	}
 	if n, err := database.RunMigrations(); err != nil {
 		t.Fatalf("RunMigrations second: %v", err)
-	} else if n != 1 {
-		t.Fatalf("RunMigrations second count: got %d want 1", n)
+	} else if n != SchemaVersion-34 {
+		t.Fatalf("RunMigrations second count: got %d want %d", n, SchemaVersion-34)
 	}
 	assertSessionStateTableShape(t, database)
 }
@@ -274,3 +274,64 @@ func TestMigration31_ClosedBySessionBackfill(t *testing.T) {
 		t.Errorf("untouched row closed_by_session: want empty, got %q", untouchedClosedBy)
 	}
 }
+
+func TestMigration36_CommentParentColumnIdempotent(t *testing.T) {
+	dir := t.TempDir()
+	database, err := Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	defer database.Close()
+
+	for i := 0; i < 2; i++ {
+		if err := database.setSchemaVersionInternal(35); err != nil {
+			t.Fatalf("rewind schema version: %v", err)
+		}
+		if _, err := database.RunMigrations(); err != nil {
+			t.Fatalf("RunMigrations: %v", err)
+		}
+	}
+	exists, err := database.columnExists("comments", "parent_comment_id")
+	if err != nil {
+		t.Fatalf("columnExists: %v", err)
+	}
+	if !exists {
+		t.Error("expected comments.parent_comment_id to exist after migration 36")
+	}
+}
+
+func TestMigration37_IssueCreatedByBackfillsEmpty(t *testing.T) {
+	dir := t.TempDir()
+	database, err := Initialize(dir)
+	if err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	defer database.Close()
+
+	// Simulate a pre-37 database with an existing issue
+	if _, err := database.conn.Exec(`ALTER TABLE issues DROP COLUMN created_by`); err != nil {
+		t.Fatalf("drop created_by: %v", err)
+	}
+	if _, err := database.conn.Exec(
+		`INSERT INTO issues (id, title, creator_session) VALUES ('td-old001', 'old', 'ses_x')`); err != nil {
+		t.Fatalf("insert legacy issue: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := database.setSchemaVersionInternal(36); err != nil {
+			t.Fatalf("rewind schema version: %v", err)
+		}
+		if _, err := database.RunMigrations(); err != nil {
+			t.Fatalf("RunMigrations: %v", err)
+		}
+	}
+
+	var createdBy string
+	if err := database.conn.QueryRow(
+		`SELECT created_by FROM issues WHERE id = 'td-old001'`).Scan(&createdBy); err != nil {
+		t.Fatalf("scan created_by: %v", err)
+	}
+	if createdBy != "" {
+		t.Errorf("legacy created_by: want empty, got %q", createdBy)
+	}
+}
@@ -11,7 +11,7 @@ import (
 type SearchResult struct {
 	Issue      models.Issue
 	Score      int    // Higher = better match (0-100)
-	MatchField string // Primary field that matched: 'id', 'title', 'description', 'labels'
+	MatchField string // Primary field that matched: 'id', 'title', 'description', 'labels', 'activity'
 }
 
 // SearchIssues performs full-text search across issues
@@ -61,6 +61,10 @@ func (db *DB) SearchIssuesRanked(query string, opts ListIssuesOptions) ([]Search
 		} else if strings.Contains(labelsLower, queryLower) {
 			score = 20
 			matchField = "labels"
+		} else if opts.SearchContent {
+			// Only a log, comment or handoff matched
+			score = 10
+			matchField = "activity"
 		}
 
 		results = append(results, SearchResult{
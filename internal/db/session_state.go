@@ -3,6 +3,7 @@ package db
 import (
 	"database/sql"
 	"fmt"
+	"time"
 )
 
 // SessionStateScope identifies one local session/worktree state row.
@@ -34,11 +35,11 @@ func (db *DB) SetFocus(scope SessionStateScope, issueID string) error {
 	return db.withWriteLock(func() error {
 		_, err := db.conn.Exec(`
 INSERT INTO session_state (session_id, worktree_id, focused_issue_id, updated_at)
-VALUES (?, ?, ?, strftime('%Y-%m-%d %H:%M:%f', 'now'))
+VALUES (?, ?, ?, ?)
 ON CONFLICT(session_id, worktree_id) DO UPDATE SET
     focused_issue_id = excluded.focused_issue_id,
-    updated_at = strftime('%Y-%m-%d %H:%M:%f', 'now')
-`, scope.SessionID, scope.WorktreeID, issueID)
+    updated_at = excluded.updated_at
+`, scope.SessionID, scope.WorktreeID, issueID, formatTimestamp(time.Now()))
 		return err
 	})
 }
@@ -75,11 +76,11 @@ func (db *DB) SetActiveWorkSession(scope SessionStateScope, wsID string) error {
 	return db.withWriteLock(func() error {
 		_, err := db.conn.Exec(`
 INSERT INTO session_state (session_id, worktree_id, active_work_session_id, updated_at)
-VALUES (?, ?, ?, strftime('%Y-%m-%d %H:%M:%f', 'now'))
+VALUES (?, ?, ?, ?)
 ON CONFLICT(session_id, worktree_id) DO UPDATE SET
     active_work_session_id = excluded.active_work_session_id,
-    updated_at = strftime('%Y-%m-%d %H:%M:%f', 'now')
-`, scope.SessionID, scope.WorktreeID, wsID)
+    updated_at = excluded.updated_at
+`, scope.SessionID, scope.WorktreeID, wsID, formatTimestamp(time.Now()))
 		return err
 	})
 }
@@ -117,7 +117,7 @@ func (db *DB) GetExtendedStats() (*models.ExtendedStats, error) {
 	var description, labels sql.NullString
 	var closedAt, deletedAt, reviewedAt sql.NullTime
 	var parentID1, acceptance1, sprint1 sql.NullString
-	var implSession1, creatorSession1, reviewerSession1 sql.NullString
+	var implSession1, creatorSession1, createdBy1, reviewerSession1 sql.NullString
 	var reviewRequestedBy1, closedBy1 sql.NullString
 	var createdBranch1 sql.NullString
 	var deferUntil1, dueDate1 sql.NullString
@@ -125,7 +125,7 @@ func (db *DB) GetExtendedStats() (*models.ExtendedStats, error) {
 		SELECT id, title, description, status, type, priority, points, labels, parent_id, acceptance, sprint,
 		       implementer_session, creator_session, reviewer_session, review_requested_by_session, closed_by_session,
 		       created_at, updated_at, reviewed_at, closed_at, deleted_at, minor, created_branch,
-		       defer_until, due_date, defer_count
+		       defer_until, due_date, defer_count, created_by
 		FROM issues WHERE status = ? AND deleted_at IS NULL ORDER BY created_at ASC LIMIT 1
 	`, models.StatusOpen).Scan(
 		&oldestIssue.ID, &oldestIssue.Title, &description, &oldestIssue.Status, &oldestIssue.Type,
@@ -133,7 +133,7 @@ func (db *DB) GetExtendedStats() (*models.ExtendedStats, error) {
 		&implSession1, &creatorSession1, &reviewerSession1, &reviewRequestedBy1, &closedBy1,
 		&oldestIssue.CreatedAt, &oldestIssue.UpdatedAt,
 		&reviewedAt, &closedAt, &deletedAt, &oldestIssue.Minor, &createdBranch1,
-		&deferUntil1, &dueDate1, &oldestIssue.DeferCount,
+		&deferUntil1, &dueDate1, &oldestIssue.DeferCount, &createdBy1,
 	)
 	if err == nil {
 		oldestIssue.Description = description.String
@@ -154,6 +154,7 @@ func (db *DB) GetExtendedStats() (*models.ExtendedStats, error) {
 		oldestIssue.Sprint = sprint1.String
 		oldestIssue.ImplementerSession = implSession1.String
 		oldestIssue.CreatorSession = creatorSession1.String
+		oldestIssue.CreatedBy = createdBy1.String
 		oldestIssue.ReviewerSession = reviewerSession1.String
 		oldestIssue.ReviewRequestedBySession = reviewRequestedBy1.String
 		oldestIssue.ClosedBySession = closedBy1.String
@@ -175,7 +176,7 @@ func (db *DB) GetExtendedStats() (*models.ExtendedStats, error) {
 	deletedAt = sql.NullTime{}
 	reviewedAt = sql.NullTime{}
 	var parentID2, acceptance2, sprint2 sql.NullString
-	var implSession2, creatorSession2, reviewerSession2 sql.NullString
+	var implSession2, creatorSession2, createdBy2, reviewerSession2 sql.NullString
 	var reviewRequestedBy2, closedBy2 sql.NullString
 	var createdBranch2 sql.NullString
 	var deferUntil2, dueDate2 sql.NullString
@@ -183,7 +184,7 @@ func (db *DB) GetExtendedStats() (*models.ExtendedStats, error) {
 		SELECT id, title, description, status, type, priority, points, labels, parent_id, acceptance, sprint,
 		       implementer_session, creator_session, reviewer_session, review_requested_by_session, closed_by_session,
 		       created_at, updated_at, reviewed_at, closed_at, deleted_at, minor, created_branch,
-		       defer_until, due_date, defer_count
+		       defer_until, due_date, defer_count, created_by
 		FROM issues WHERE deleted_at IS NULL ORDER BY created_at DESC LIMIT 1
 	`).Scan(
 		&newestIssue.ID, &newestIssue.Title, &description, &newestIssue.Status, &newestIssue.Type,
@@ -191,7 +192,7 @@ func (db *DB) GetExtendedStats() (*models.ExtendedStats, error) {
 		&implSession2, &creatorSession2, &reviewerSession2, &reviewRequestedBy2, &closedBy2,
 		&newestIssue.CreatedAt, &newestIssue.UpdatedAt,
 		&reviewedAt, &closedAt, &deletedAt, &newestIssue.Minor, &createdBranch2,
-		&deferUntil2, &dueDate2, &newestIssue.DeferCount,
+		&deferUntil2, &dueDate2, &newestIssue.DeferCount, &createdBy2,
 	)
 	if err == nil {
 		newestIssue.Description = description.String
@@ -212,6 +213,7 @@ func (db *DB) GetExtendedStats() (*models.ExtendedStats, error) {
 		newestIssue.Sprint = sprint2.String
 		newestIssue.ImplementerSession = implSession2.String
 		newestIssue.CreatorSession = creatorSession2.String
+		newestIssue.CreatedBy = createdBy2.String
 		newestIssue.ReviewerSession = reviewerSession2.String
 		newestIssue.ReviewRequestedBySession = reviewRequestedBy2.String
 		newestIssue.ClosedBySession = closedBy2.String
@@ -233,7 +235,7 @@ func (db *DB) GetExtendedStats() (*models.ExtendedStats, error) {
 	deletedAt = sql.NullTime{}
 	reviewedAt = sql.NullTime{}
 	var parentID3, acceptance3, sprint3 sql.NullString
-	var implSession3, creatorSession3, reviewerSession3 sql.NullString
+	var implSession3, creatorSession3, createdBy3, reviewerSession3 sql.NullString
 	var reviewRequestedBy3, closedBy3 sql.NullString
 	var createdBranch3 sql.NullString
 	var deferUntil3, dueDate3 sql.NullString
@@ -241,7 +243,7 @@ func (db *DB) GetExtendedStats() (*models.ExtendedStats, error) {
 		SELECT id, title, description, status, type, priority, points, labels, parent_id, acceptance, sprint,
 		       implementer_session, creator_session, reviewer_session, review_requested_by_session, closed_by_session,
 		       created_at, updated_at, reviewed_at, closed_at, deleted_at, minor, created_branch,
-		       defer_until, due_date, defer_count
+		       defer_until, due_date, defer_count, created_by
 		FROM issues WHERE status = ? AND closed_at IS NOT NULL AND deleted_at IS NULL
 		ORDER BY closed_at DESC LIMIT 1
 	`, models.StatusClosed).Scan(
@@ -250,7 +252,7 @@ func (db *DB) GetExtendedStats() (*models.ExtendedStats, error) {
 		&implSession3, &creatorSession3, &reviewerSession3, &reviewRequestedBy3, &closedBy3,
 		&closedIssue.CreatedAt, &closedIssue.UpdatedAt,
 		&reviewedAt, &closedAt, &deletedAt, &closedIssue.Minor, &createdBranch3,
-		&deferUntil3, &dueDate3, &closedIssue.DeferCount,
+		&deferUntil3, &dueDate3, &closedIssue.DeferCount, &createdBy3,
 	)
 	if err == nil {
 		closedIssue.Description = description.String
@@ -271,6 +273,7 @@ func (db *DB) GetExtendedStats() (*models.ExtendedStats, error) {
 		closedIssue.Sprint = sprint3.String
 		closedIssue.ImplementerSession = implSession3.String
 		closedIssue.CreatorSession = creatorSession3.String
+		closedIssue.CreatedBy = createdBy3.String
 		closedIssue.ReviewerSession = reviewerSession3.String
 		closedIssue.ReviewRequestedBySession = reviewRequestedBy3.String
 		closedIssue.ClosedBySession = closedBy3.String
@@ -304,6 +307,65 @@ func (db *DB) GetExtendedStats() (*models.ExtendedStats, error) {
 	return stats, nil
 }
 
+// GetClosedTimesSince returns when each issue closed at or after since was
+// closed, skipping deleted issues.
+func (db *DB) GetClosedTimesSince(since time.Time) ([]time.Time, error) {
+	rows, err := db.conn.Query(`
+		SELECT closed_at FROM issues
+		WHERE status = ? AND closed_at >= ? AND deleted_at IS NULL
+	`, models.StatusClosed, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var times []time.Time
+	for rows.Next() {
+		var t time.Time
+		if err := rows.Scan(&t); err != nil {
+			return nil, err
+		}
+		times = append(times, t)
+	}
+	return times, rows.Err()
+}
+
+// GetActionTimesBySession returns the times of the actions each session
+// logged at or after since, ignoring undone actions.
+func (db *DB) GetActionTimesBySession(since time.Time) (map[string][]time.Time, error) {
+	rows, err := db.conn.Query(`
+		SELECT session_id, timestamp FROM action_log
+		WHERE timestamp >= ? AND undone = 0 AND session_id != ''
+	`, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	times := make(map[string][]time.Time)
+	for rows.Next() {
+		var sessionID string
+		var t time.Time
+		if err := rows.Scan(&sessionID, &t); err != nil {
+			return nil, err
+		}
+		times[sessionID] = append(times[sessionID], t)
+	}
+	return times, rows.Err()
+}
+
+// QueryRevision returns a number that advances whenever issue data changes:
+// the newest action_log seq, which covers local writes, plus the last pulled
+// server seq, which covers changes applied by sync (those write no
+// action_log rows). Query result caches are keyed on it.
+func (db *DB) QueryRevision() (int64, error) {
+	var rev int64
+	err := db.conn.QueryRow(`SELECT
+		(SELECT COALESCE(MAX(rowid), 0) FROM action_log) +
+		(SELECT COALESCE(MAX(last_pulled_server_seq), 0) FROM sync_state)`).Scan(&rev)
+	return rev, err
+}
+
 // GetChangeToken returns the MAX(rowid) from action_log as a string.
 // This serves as a lightweight change-detection token for the HTTP API:
 // clients compare consecutive tokens to know whether any mutation has occurred.
@@ -32,6 +32,7 @@ func parseTimestamp(s string) (time.Time, error) {
 		time.RFC3339,
 		time.RFC3339Nano,
 		"2006-01-02T15:04:05Z07:00",
+		"2006-01-02 15:04:05.999999999-07:00",     // modernc/sqlite _time_format=sqlite
 		"2006-01-02 15:04:05.999999999 -0700 MST", // Go time.Time.String() via modernc/sqlite driver
 	} {
 		if t, err := time.Parse(layout, s); err == nil {
@@ -29,7 +29,7 @@ func (db *DB) GetRecentConflicts(limit int, since *time.Time) ([]SyncConflict, e
 			WHERE overwritten_at >= ?
 			ORDER BY overwritten_at DESC
 			LIMIT ?
-		`, since.Format("2006-01-02 15:04:05"), limit)
+		`, formatTimestamp(*since), limit)
 	} else {
 		rows, err = db.conn.Query(`
 			SELECT id, entity_type, entity_id, server_seq, COALESCE(local_data,'null'), COALESCE(remote_data,'null'), overwritten_at
@@ -115,8 +115,8 @@ func (db *DB) SetSyncState(projectID string) error {
 func (db *DB) UpdateSyncPushed(lastActionID int64) error {
 	return db.withWriteLock(func() error {
 		_, err := db.conn.Exec(`
-			UPDATE sync_state SET last_pushed_action_id = ?, last_sync_at = CURRENT_TIMESTAMP
-		`, lastActionID)
+			UPDATE sync_state SET last_pushed_action_id = ?, last_sync_at = ?
+		`, lastActionID, formatTimestamp(time.Now()))
 		return err
 	})
 }
@@ -125,8 +125,8 @@ func (db *DB) UpdateSyncPushed(lastActionID int64) error {
 func (db *DB) UpdateSyncPulled(lastServerSeq int64) error {
 	return db.withWriteLock(func() error {
 		_, err := db.conn.Exec(`
-			UPDATE sync_state SET last_pulled_server_seq = ?, last_sync_at = CURRENT_TIMESTAMP
-		`, lastServerSeq)
+			UPDATE sync_state SET last_pulled_server_seq = ?, last_sync_at = ?
+		`, lastServerSeq, formatTimestamp(time.Now()))
 		return err
 	})
 }
@@ -167,3 +167,26 @@ func (db *DB) CountSyncedEvents() (int64, error) {
 	err := db.conn.QueryRow(`SELECT COUNT(*) FROM action_log WHERE synced_at IS NOT NULL`).Scan(&count)
 	return count, err
 }
+
+// GetSnapshotProjectSettings returns the project settings JSON a sync server
+// stored in this database's snapshot, or nil if it holds none (a database
+// that was never bootstrapped, or a server without project settings).
+func (db *DB) GetSnapshotProjectSettings() ([]byte, error) {
+	var exists int
+	if err := db.conn.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'project_settings'`).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if exists == 0 {
+		return nil, nil
+	}
+
+	var settings string
+	err := db.conn.QueryRow(`SELECT settings FROM project_settings WHERE id = 1`).Scan(&settings)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return []byte(settings), nil
+}
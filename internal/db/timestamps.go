@@ -0,0 +1,317 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// canonicalTimestampLayout is how td stores timestamps: RFC3339 in UTC with
+// fixed-width nanoseconds, so values sort and compare correctly as text.
+const canonicalTimestampLayout = "2006-01-02T15:04:05.000000000Z07:00"
+
+// formatTimestamp renders t in canonicalTimestampLayout.
+func formatTimestamp(t time.Time) string {
+	return t.UTC().Format(canonicalTimestampLayout)
+}
+
+// isCanonicalTimestamp reports whether s is already in canonical form.
+func isCanonicalTimestamp(s string) bool {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	return err == nil && s == formatTimestamp(t)
+}
+
+// canonicalTimeDriverName is a wrapper around modernc's "sqlite" driver that
+// binds time.Time arguments as canonical timestamp text. The bare driver
+// writes time.Time.String() ("2006-01-02 15:04:05.999 -0700 MST m=+0.01"),
+// which mixes zones and doesn't sort as text.
+const canonicalTimeDriverName = "sqlite-td"
+
+func init() {
+	// sql.Open doesn't connect; it only resolves the registered driver.
+	base, err := sql.Open("sqlite", "")
+	if err != nil {
+		panic(fmt.Sprintf("resolve sqlite driver: %v", err))
+	}
+	sql.Register(canonicalTimeDriverName, canonicalTimeDriver{base: base.Driver()})
+	_ = base.Close()
+}
+
+type canonicalTimeDriver struct {
+	base driver.Driver
+}
+
+func (d canonicalTimeDriver) Open(name string) (driver.Conn, error) {
+	c, err := d.base.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &canonicalTimeConn{Conn: c}, nil
+}
+
+// canonicalTimeConn forwards to the wrapped connection. database/sql asks
+// the connection to check arguments (CheckNamedValue) before every Exec and
+// Query, which is where time.Time values are rewritten.
+type canonicalTimeConn struct {
+	driver.Conn
+}
+
+var (
+	_ driver.NamedValueChecker  = (*canonicalTimeConn)(nil)
+	_ driver.ConnBeginTx        = (*canonicalTimeConn)(nil)
+	_ driver.ConnPrepareContext = (*canonicalTimeConn)(nil)
+	_ driver.ExecerContext      = (*canonicalTimeConn)(nil)
+	_ driver.QueryerContext     = (*canonicalTimeConn)(nil)
+	_ driver.Pinger             = (*canonicalTimeConn)(nil)
+	_ driver.SessionResetter    = (*canonicalTimeConn)(nil)
+	_ driver.Validator          = (*canonicalTimeConn)(nil)
+)
+
+func (c *canonicalTimeConn) CheckNamedValue(nv *driver.NamedValue) error {
+	switch v := nv.Value.(type) {
+	case time.Time:
+		nv.Value = formatTimestamp(v)
+		return nil
+	case *time.Time:
+		if v == nil {
+			nv.Value = nil
+		} else {
+			nv.Value = formatTimestamp(*v)
+		}
+		return nil
+	}
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+func (c *canonicalTimeConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if b, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return b.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin()
+}
+
+func (c *canonicalTimeConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if p, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		return p.PrepareContext(ctx, query)
+	}
+	return c.Conn.Prepare(query)
+}
+
+func (c *canonicalTimeConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if e, ok := c.Conn.(driver.ExecerContext); ok {
+		return e.ExecContext(ctx, query, args)
+	}
+	return nil, driver.ErrSkip
+}
+
+func (c *canonicalTimeConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if q, ok := c.Conn.(driver.QueryerContext); ok {
+		return q.QueryContext(ctx, query, args)
+	}
+	return nil, driver.ErrSkip
+}
+
+func (c *canonicalTimeConn) Ping(ctx context.Context) error {
+	if p, ok := c.Conn.(driver.Pinger); ok {
+		return p.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *canonicalTimeConn) ResetSession(ctx context.Context) error {
+	if r, ok := c.Conn.(driver.SessionResetter); ok {
+		return r.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *canonicalTimeConn) IsValid() bool {
+	if v, ok := c.Conn.(driver.Validator); ok {
+		return v.IsValid()
+	}
+	return true
+}
+
+// extraTimestampColumns are timestamp columns declared TEXT rather than
+// DATETIME, which NormalizeTimestamps can't discover from the schema.
+var extraTimestampColumns = map[string][]string{
+	"notes": {"created_at", "updated_at", "deleted_at"},
+}
+
+// TimestampColumnReport counts non-canonical values in one column.
+type TimestampColumnReport struct {
+	Table       string `json:"table"`
+	Column      string `json:"column"`
+	NonCanon    int    `json:"non_canonical"`
+	Unparseable int    `json:"unparseable"`
+}
+
+// TimestampReport is the result of CheckTimestamps or NormalizeTimestamps.
+// Columns lists only columns with at least one non-canonical value.
+type TimestampReport struct {
+	Scanned     int                     `json:"scanned"`
+	NonCanon    int                     `json:"non_canonical"`
+	Unparseable int                     `json:"unparseable"`
+	Rewritten   int                     `json:"rewritten"`
+	Columns     []TimestampColumnReport `json:"columns"`
+}
+
+// CheckTimestamps scans every timestamp column for values that aren't in
+// canonical RFC3339 UTC form, without modifying anything.
+func (db *DB) CheckTimestamps() (*TimestampReport, error) {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	return scanTimestamps(tx, false)
+}
+
+// NormalizeTimestamps rewrites every parseable non-canonical timestamp
+// (Go's time.Time.String() format, SQLite's "YYYY-MM-DD HH:MM:SS", RFC3339
+// with a zone offset) to canonical RFC3339 UTC in a single transaction.
+// Values that match no known format are counted and left alone.
+func (db *DB) NormalizeTimestamps() (*TimestampReport, error) {
+	var report *TimestampReport
+	err := db.withWriteLock(func() error {
+		tx, err := db.conn.Begin()
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+
+		report, err = scanTimestamps(tx, true)
+		if err != nil {
+			return err
+		}
+		return tx.Commit()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// timestampColumns lists every DATETIME/TIMESTAMP column in the schema plus
+// extraTimestampColumns, as table -> columns.
+func timestampColumns(tx *sql.Tx) (map[string][]string, []string, error) {
+	rows, err := tx.Query(`
+		SELECT m.name, p.name
+		FROM sqlite_master m JOIN pragma_table_info(m.name) p
+		WHERE m.type = 'table' AND UPPER(p.type) IN ('DATETIME', 'TIMESTAMP')
+		ORDER BY m.name, p.cid
+	`)
+	if err != nil {
+		return nil, nil, fmt.Errorf("list timestamp columns: %w", err)
+	}
+	defer rows.Close()
+
+	cols := map[string][]string{}
+	var tables []string
+	for rows.Next() {
+		var table, col string
+		if err := rows.Scan(&table, &col); err != nil {
+			return nil, nil, err
+		}
+		if _, ok := cols[table]; !ok {
+			tables = append(tables, table)
+		}
+		cols[table] = append(cols[table], col)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	for table, extra := range extraTimestampColumns {
+		var exists int
+		if err := tx.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?`, table).Scan(&exists); err != nil {
+			return nil, nil, err
+		}
+		if exists == 0 {
+			continue
+		}
+		if _, ok := cols[table]; !ok {
+			tables = append(tables, table)
+		}
+		cols[table] = append(cols[table], extra...)
+	}
+	return cols, tables, nil
+}
+
+func scanTimestamps(tx *sql.Tx, fix bool) (*TimestampReport, error) {
+	cols, tables, err := timestampColumns(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &TimestampReport{Columns: []TimestampColumnReport{}}
+	for _, table := range tables {
+		for _, col := range cols[table] {
+			colReport, err := scanTimestampColumn(tx, table, col, fix, report)
+			if err != nil {
+				return nil, err
+			}
+			if colReport.NonCanon > 0 {
+				report.Columns = append(report.Columns, colReport)
+			}
+		}
+	}
+	return report, nil
+}
+
+func scanTimestampColumn(tx *sql.Tx, table, col string, fix bool, report *TimestampReport) (TimestampColumnReport, error) {
+	colReport := TimestampColumnReport{Table: table, Column: col}
+
+	// CAST keeps the driver from parsing DATETIME text into time.Time.
+	rows, err := tx.Query(fmt.Sprintf(
+		`SELECT rowid, CAST(%[2]s AS TEXT) FROM %[1]s WHERE %[2]s IS NOT NULL AND CAST(%[2]s AS TEXT) != ''`, table, col))
+	if err != nil {
+		return colReport, fmt.Errorf("scan %s.%s: %w", table, col, err)
+	}
+	type rewrite struct {
+		rowid int64
+		value string
+	}
+	var rewrites []rewrite
+	for rows.Next() {
+		var rowid int64
+		var value string
+		if err := rows.Scan(&rowid, &value); err != nil {
+			rows.Close()
+			return colReport, err
+		}
+		report.Scanned++
+		if isCanonicalTimestamp(value) {
+			continue
+		}
+		colReport.NonCanon++
+		report.NonCanon++
+		t, err := parseTimestamp(strings.TrimSpace(value))
+		if err != nil {
+			colReport.Unparseable++
+			report.Unparseable++
+			continue
+		}
+		rewrites = append(rewrites, rewrite{rowid, formatTimestamp(t)})
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return colReport, err
+	}
+
+	if !fix {
+		return colReport, nil
+	}
+	for _, r := range rewrites {
+		if _, err := tx.Exec(fmt.Sprintf(`UPDATE %s SET %s = ? WHERE rowid = ?`, table, col), r.value, r.rowid); err != nil {
+			return colReport, fmt.Errorf("rewrite %s.%s: %w", table, col, err)
+		}
+		report.Rewritten++
+	}
+	return colReport, nil
+}
@@ -0,0 +1,132 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatTimestampIsFixedWidthUTC(t *testing.T) {
+	loc := time.FixedZone("EST", -5*3600)
+	whole := formatTimestamp(time.Date(2026, 1, 2, 5, 0, 1, 0, loc))
+	frac := formatTimestamp(time.Date(2026, 1, 2, 5, 0, 1, 500_000_000, loc))
+
+	if whole != "2026-01-02T10:00:01.000000000Z" {
+		t.Fatalf("formatTimestamp = %q", whole)
+	}
+	if len(whole) != len(frac) || !(whole < frac) {
+		t.Fatalf("expected fixed-width, text-sortable values, got %q and %q", whole, frac)
+	}
+	if !isCanonicalTimestamp(whole) || isCanonicalTimestamp("2026-01-02T10:00:01Z") {
+		t.Fatal("isCanonicalTimestamp misclassified a value")
+	}
+}
+
+func TestNormalizeTimestamps_MixedFormatsSortChronologically(t *testing.T) {
+	database, err := Initialize(t.TempDir())
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	defer database.Close()
+
+	// Chronological order is ts-1 .. ts-5; as raw text they sort differently.
+	seeds := []struct {
+		id, timestamp string
+	}{
+		{"ts-1", "2026-01-02 09:00:00"},                         // SQLite CURRENT_TIMESTAMP
+		{"ts-2", "2026-01-02T05:30:00-04:00"},                   // RFC3339 with offset
+		{"ts-3", "2026-01-02 11:00:00.5 +0100 CET m=+0.012345"}, // time.Time.String()
+		{"ts-4", "2026-01-02T10:00:01Z"},                        // RFC3339Nano, whole second
+		{"ts-5", "2026-01-02T10:00:01.5Z"},                      // RFC3339Nano, fractional
+	}
+	for i, s := range seeds {
+		if _, err := database.conn.Exec(
+			`INSERT INTO logs (id, issue_id, session_id, message, type, timestamp) VALUES (?, 'td-x', 'ses-1', 'm', 'progress', ?)`,
+			s.id, s.timestamp); err != nil {
+			t.Fatalf("seed log: %v", err)
+		}
+		if _, err := database.conn.Exec(
+			`INSERT INTO action_log (id, session_id, action_type, entity_type, entity_id, timestamp) VALUES (?, 'ses-1', 'update', 'issue', ?, ?)`,
+			"al-"+s.id, s.id, seeds[len(seeds)-1-i].timestamp); err != nil {
+			t.Fatalf("seed action_log: %v", err)
+		}
+	}
+	if _, err := database.conn.Exec(
+		`INSERT INTO logs (id, issue_id, session_id, message, type, timestamp) VALUES ('ts-bad', 'td-y', 'ses-1', 'm', 'progress', 'yesterday')`); err != nil {
+		t.Fatalf("seed unparseable log: %v", err)
+	}
+
+	check, err := database.CheckTimestamps()
+	if err != nil {
+		t.Fatalf("CheckTimestamps: %v", err)
+	}
+	// Every seeded value is non-canonical: 5 logs + 5 action_log + 1 bad.
+	if check.NonCanon != 11 || check.Unparseable != 1 || check.Rewritten != 0 {
+		t.Fatalf("CheckTimestamps = %+v", check)
+	}
+
+	report, err := database.NormalizeTimestamps()
+	if err != nil {
+		t.Fatalf("NormalizeTimestamps: %v", err)
+	}
+	if report.Rewritten != 10 || report.Unparseable != 1 {
+		t.Fatalf("NormalizeTimestamps = %+v", report)
+	}
+
+	order := func(query string) []string {
+		t.Helper()
+		rows, err := database.conn.Query(query)
+		if err != nil {
+			t.Fatalf("query: %v", err)
+		}
+		defer rows.Close()
+		var ids []string
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				t.Fatalf("scan: %v", err)
+			}
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	assertOrder := func(name string, got, want []string) {
+		t.Helper()
+		if len(got) != len(want) {
+			t.Fatalf("%s order = %v, want %v", name, got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("%s order = %v, want %v", name, got, want)
+			}
+		}
+	}
+	assertOrder("logs",
+		order(`SELECT id FROM logs WHERE issue_id = 'td-x' ORDER BY timestamp`),
+		[]string{"ts-1", "ts-2", "ts-3", "ts-4", "ts-5"})
+	assertOrder("action_log",
+		order(`SELECT entity_id FROM action_log WHERE session_id = 'ses-1' ORDER BY timestamp`),
+		[]string{"ts-5", "ts-4", "ts-3", "ts-2", "ts-1"})
+
+	var ts3, bad string
+	if err := database.conn.QueryRow(`SELECT CAST(timestamp AS TEXT) FROM logs WHERE id = 'ts-3'`).Scan(&ts3); err != nil {
+		t.Fatalf("read ts-3: %v", err)
+	}
+	if ts3 != "2026-01-02T10:00:00.500000000Z" {
+		t.Fatalf("ts-3 normalized to %q", ts3)
+	}
+	if err := database.conn.QueryRow(`SELECT CAST(timestamp AS TEXT) FROM logs WHERE id = 'ts-bad'`).Scan(&bad); err != nil {
+		t.Fatalf("read ts-bad: %v", err)
+	}
+	if bad != "yesterday" {
+		t.Fatalf("unparseable value rewritten to %q", bad)
+	}
+
+	// A second pass finds nothing left to fix except the unparseable row.
+	again, err := database.CheckTimestamps()
+	if err != nil {
+		t.Fatalf("CheckTimestamps: %v", err)
+	}
+	if again.NonCanon != 1 || again.Unparseable != 1 {
+		t.Fatalf("after normalize, CheckTimestamps = %+v", again)
+	}
+}
@@ -0,0 +1,180 @@
+package dependency
+
+import (
+	"sort"
+
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/models"
+)
+
+// Cycle kinds reported by DetectCycles.
+const (
+	CycleDependsOn = "depends_on" // issues that (transitively) depend on each other
+	CycleParent    = "parent"     // issues that are (transitively) their own parent
+)
+
+// Edge is one link in a cycle: From depends on To, or To is From's parent.
+type Edge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Cycle is a set of issues caught in a loop, with the links between them.
+type Cycle struct {
+	Kind     string   `json:"kind"`
+	IssueIDs []string `json:"issues"`
+	Edges    []Edge   `json:"edges"`
+}
+
+// FindCycles returns the strongly connected components of graph that
+// contain a loop: every component of two or more nodes, plus single nodes
+// that point at themselves. IDs within a component are sorted and the
+// components are ordered by their first ID.
+func FindCycles(graph map[string][]string) [][]string {
+	nodes := make([]string, 0, len(graph))
+	for id := range graph {
+		nodes = append(nodes, id)
+	}
+	sort.Strings(nodes)
+
+	// Tarjan's algorithm
+	index := make(map[string]int)
+	lowlink := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var cycles [][]string
+	next := 0
+
+	var visit func(id string)
+	visit = func(id string) {
+		index[id] = next
+		lowlink[id] = next
+		next++
+		stack = append(stack, id)
+		onStack[id] = true
+
+		for _, to := range graph[id] {
+			if _, seen := index[to]; !seen {
+				visit(to)
+				lowlink[id] = min(lowlink[id], lowlink[to])
+			} else if onStack[to] {
+				lowlink[id] = min(lowlink[id], index[to])
+			}
+		}
+
+		if lowlink[id] != index[id] {
+			return
+		}
+		var scc []string
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			scc = append(scc, top)
+			if top == id {
+				break
+			}
+		}
+		if len(scc) > 1 || pointsAt(graph, id, id) {
+			sort.Strings(scc)
+			cycles = append(cycles, scc)
+		}
+	}
+
+	for _, id := range nodes {
+		if _, seen := index[id]; !seen {
+			visit(id)
+		}
+	}
+
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0] < cycles[j][0] })
+	return cycles
+}
+
+func pointsAt(graph map[string][]string, from, to string) bool {
+	for _, id := range graph[from] {
+		if id == to {
+			return true
+		}
+	}
+	return false
+}
+
+// cycleEdges returns the edges of graph that stay within ids, sorted.
+func cycleEdges(graph map[string][]string, ids []string) []Edge {
+	in := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		in[id] = true
+	}
+	var edges []Edge
+	for _, from := range ids {
+		for _, to := range graph[from] {
+			if in[to] {
+				edges = append(edges, Edge{From: from, To: to})
+			}
+		}
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].From != edges[j].From {
+			return edges[i].From < edges[j].From
+		}
+		return edges[i].To < edges[j].To
+	})
+	return edges
+}
+
+// DetectCycles scans every depends_on link and every parent link and
+// returns the cycles found, dependency cycles first.
+func DetectCycles(database *db.DB) ([]Cycle, error) {
+	deps, err := database.GetAllDependencies()
+	if err != nil {
+		return nil, err
+	}
+	issues, err := database.ListIssues(db.ListIssuesOptions{})
+	if err != nil {
+		return nil, err
+	}
+	parents := make(map[string][]string)
+	for _, issue := range issues {
+		if issue.ParentID != "" {
+			parents[issue.ID] = []string{issue.ParentID}
+		}
+	}
+
+	var cycles []Cycle
+	for _, ids := range FindCycles(deps) {
+		cycles = append(cycles, Cycle{Kind: CycleDependsOn, IssueIDs: ids, Edges: cycleEdges(deps, ids)})
+	}
+	for _, ids := range FindCycles(parents) {
+		cycles = append(cycles, Cycle{Kind: CycleParent, IssueIDs: ids, Edges: cycleEdges(parents, ids)})
+	}
+	return cycles, nil
+}
+
+// WeakestEdge picks the link of a dependency cycle that is cheapest to
+// drop: a dependency on a closed issue first, then the dependency on the
+// lowest-priority issue, then the first edge in ID order.
+func WeakestEdge(database *db.DB, cycle Cycle) (Edge, bool) {
+	if len(cycle.Edges) == 0 {
+		return Edge{}, false
+	}
+	weight := func(e Edge) (closed bool, priority models.Priority) {
+		issue, err := database.GetIssue(e.To)
+		if err != nil {
+			// A missing issue is as weak as a closed one
+			return true, models.PriorityP4
+		}
+		return issue.Status == models.StatusClosed, issue.Priority
+	}
+
+	best := cycle.Edges[0]
+	bestClosed, bestPriority := weight(best)
+	for _, e := range cycle.Edges[1:] {
+		closed, priority := weight(e)
+		// Priorities compare as strings: "P4" is weaker than "P0"
+		if closed && !bestClosed || closed == bestClosed && priority > bestPriority {
+			best, bestClosed, bestPriority = e, closed, priority
+		}
+	}
+	return best, true
+}
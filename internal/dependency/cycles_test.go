@@ -0,0 +1,101 @@
+package dependency
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/marcus/td/internal/models"
+)
+
+func TestFindCycles(t *testing.T) {
+	tests := []struct {
+		name  string
+		graph map[string][]string
+		want  [][]string
+	}{
+		{"no edges", map[string][]string{}, nil},
+		{"chain", map[string][]string{"a": {"b"}, "b": {"c"}}, nil},
+		{"two node loop", map[string][]string{"a": {"b"}, "b": {"a"}}, [][]string{{"a", "b"}}},
+		{"self loop", map[string][]string{"a": {"a"}}, [][]string{{"a"}}},
+		{
+			"loop with tail",
+			map[string][]string{"x": {"a"}, "a": {"b"}, "b": {"c"}, "c": {"a"}},
+			[][]string{{"a", "b", "c"}},
+		},
+		{
+			"two loops",
+			map[string][]string{"a": {"b"}, "b": {"a"}, "c": {"d"}, "d": {"c"}, "e": {"a"}},
+			[][]string{{"a", "b"}, {"c", "d"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FindCycles(tt.graph); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindCycles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectCyclesSeeded(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	var ids []string
+	for _, p := range []models.Priority{models.PriorityP1, models.PriorityP3, models.PriorityP2, models.PriorityP2} {
+		issue := &models.Issue{Title: "Issue", Priority: p}
+		if err := database.CreateIssue(issue); err != nil {
+			t.Fatalf("CreateIssue: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+	a, b, c, d := ids[0], ids[1], ids[2], ids[3]
+
+	// a -> b -> c -> a, written directly since validation would refuse it
+	for _, edge := range [][2]string{{a, b}, {b, c}, {c, a}, {d, a}} {
+		if err := database.AddDependency(edge[0], edge[1], "depends_on"); err != nil {
+			t.Fatalf("AddDependency(%s, %s): %v", edge[0], edge[1], err)
+		}
+	}
+
+	// c and d are each other's parent
+	for id, parent := range map[string]string{c: d, d: c} {
+		issue, err := database.GetIssue(id)
+		if err != nil {
+			t.Fatalf("GetIssue: %v", err)
+		}
+		issue.ParentID = parent
+		if err := database.UpdateIssue(issue); err != nil {
+			t.Fatalf("UpdateIssue: %v", err)
+		}
+	}
+
+	cycles, err := DetectCycles(database)
+	if err != nil {
+		t.Fatalf("DetectCycles: %v", err)
+	}
+	if len(cycles) != 2 {
+		t.Fatalf("got %d cycles, want 2: %+v", len(cycles), cycles)
+	}
+
+	deps := cycles[0]
+	if deps.Kind != CycleDependsOn || len(deps.IssueIDs) != 3 || len(deps.Edges) != 3 {
+		t.Fatalf("dependency cycle = %+v, want a, b, c with 3 edges", deps)
+	}
+	for _, id := range deps.IssueIDs {
+		if id == d {
+			t.Errorf("%s only points into the cycle and should not be part of it", d)
+		}
+	}
+
+	parents := cycles[1]
+	if parents.Kind != CycleParent || len(parents.IssueIDs) != 2 {
+		t.Fatalf("parent cycle = %+v, want c, d", parents)
+	}
+
+	// b is the lowest-priority issue in the loop, so a -> b is the weakest link
+	edge, ok := WeakestEdge(database, deps)
+	if !ok || edge != (Edge{From: a, To: b}) {
+		t.Errorf("WeakestEdge = %+v, want %s -> %s", edge, a, b)
+	}
+}
@@ -4,6 +4,7 @@ package dependency
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/marcus/td/internal/db"
 	"github.com/marcus/td/internal/models"
@@ -164,3 +165,57 @@ func getTransitiveBlockedFiltered(database *db.DB, issueID string, visited map[s
 
 	return all
 }
+
+// BlockerLink is one issue reached by a transitive dependency walk. Depth is
+// 1 for direct links, 2 for the issues linked to those, and so on.
+type BlockerLink struct {
+	ID    string
+	Depth int
+}
+
+// GetTransitiveBlockers returns every issue issueID transitively depends on,
+// depth-first in ID order, each listed once. Siblings are claimed before
+// descending so a direct dependency is always reported at depth 1. It is the
+// blockers-direction counterpart of GetTransitiveBlocked.
+func GetTransitiveBlockers(database *db.DB, issueID string) []BlockerLink {
+	return GetTransitiveBlockersWithin(database, issueID, 0)
+}
+
+// GetTransitiveBlockersWithin is GetTransitiveBlockers stopped maxDepth
+// levels out. A maxDepth of 0 means no limit.
+func GetTransitiveBlockersWithin(database *db.DB, issueID string, maxDepth int) []BlockerLink {
+	visited := map[string]bool{issueID: true}
+	return walkLinks(database.GetDependencies, issueID, 1, maxDepth, visited)
+}
+
+// GetTransitiveBlockedWithin returns the issues issueID transitively blocks,
+// up to maxDepth levels out (0 means no limit), in the same order and with
+// the same depths as GetTransitiveBlockersWithin.
+func GetTransitiveBlockedWithin(database *db.DB, issueID string, maxDepth int) []BlockerLink {
+	visited := map[string]bool{issueID: true}
+	return walkLinks(database.GetBlockedBy, issueID, 1, maxDepth, visited)
+}
+
+func walkLinks(next func(string) ([]string, error), issueID string, depth, maxDepth int, visited map[string]bool) []BlockerLink {
+	if maxDepth > 0 && depth > maxDepth {
+		return nil
+	}
+	ids, _ := next(issueID)
+	sort.Strings(ids)
+
+	var level []string
+	for _, id := range ids {
+		if !visited[id] {
+			visited[id] = true
+			level = append(level, id)
+		}
+	}
+
+	var all []BlockerLink
+	for _, id := range level {
+		all = append(all, BlockerLink{ID: id, Depth: depth})
+		all = append(all, walkLinks(next, id, depth+1, maxDepth, visited)...)
+	}
+
+	return all
+}
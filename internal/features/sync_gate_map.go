@@ -43,7 +43,7 @@ var SyncGateMap = []GateMapEntry{
 	{
 		Feature: SyncCLI.Name,
 		Surface: "cmd/config.go",
-		Notes:   "Gates sync configuration commands",
+		Notes:   "Gates the sync.* config keys",
 	},
 	{
 		Feature: SyncCLI.Name,
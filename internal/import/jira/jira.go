@@ -0,0 +1,316 @@
+// Package jira maps a Jira JSON export onto td issues for td import jira.
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/marcus/td/internal/models"
+)
+
+// SourceLabelPrefix prefixes the label that records an imported issue's Jira
+// key, e.g. "jira:PROJ-12", so re-imports can skip it. It is one of
+// models.ReservedLabelPrefixes, so edits can't drop or forge the label.
+const SourceLabelPrefix = "jira:"
+
+// Issue is one Jira issue mapped to td. Issue carries no ID; parent and
+// dependency references stay as Jira keys until the importer assigns IDs.
+type Issue struct {
+	SourceKey string
+	ParentKey string
+	DependsOn []string // keys of issues this one is blocked by
+	Issue     models.Issue
+}
+
+// SourceLabel returns the label that records key as an issue's Jira source.
+func SourceLabel(key string) string {
+	return SourceLabelPrefix + key
+}
+
+type export struct {
+	Issues []rawIssue `json:"issues"`
+}
+
+type rawIssue struct {
+	Key    string    `json:"key"`
+	Fields rawFields `json:"fields"`
+}
+
+type rawFields struct {
+	Summary     string          `json:"summary"`
+	Description json.RawMessage `json:"description"`
+	IssueType   struct {
+		Name string `json:"name"`
+	} `json:"issuetype"`
+	Status struct {
+		Name           string `json:"name"`
+		StatusCategory struct {
+			Key string `json:"key"`
+		} `json:"statusCategory"`
+	} `json:"status"`
+	Priority *struct {
+		Name string `json:"name"`
+	} `json:"priority"`
+	Labels []string `json:"labels"`
+	Parent *struct {
+		Key string `json:"key"`
+	} `json:"parent"`
+	// Epic Link, the field classic projects use for an issue's epic;
+	// next-gen projects use parent instead
+	EpicLink   string         `json:"customfield_10014"`
+	IssueLinks []rawIssueLink `json:"issuelinks"`
+	Resolved   string         `json:"resolutiondate"`
+}
+
+type rawIssueLink struct {
+	Type struct {
+		Name string `json:"name"`
+	} `json:"type"`
+	InwardIssue *struct {
+		Key string `json:"key"`
+	} `json:"inwardIssue"`
+	OutwardIssue *struct {
+		Key string `json:"key"`
+	} `json:"outwardIssue"`
+}
+
+// Parse reads a Jira export: the JSON of a REST search ({"issues": [...]})
+// or a bare array of issues. Issues are returned parents first, so each
+// parent can be created before its children.
+func Parse(data []byte) ([]Issue, error) {
+	var raw []rawIssue
+	trimmed := strings.TrimSpace(string(data))
+	if strings.HasPrefix(trimmed, "[") {
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("failed to parse Jira export: %w", err)
+		}
+	} else {
+		var exp export
+		if err := json.Unmarshal(data, &exp); err != nil {
+			return nil, fmt.Errorf("failed to parse Jira export: %w", err)
+		}
+		raw = exp.Issues
+	}
+
+	issues := make([]Issue, 0, len(raw))
+	byKey := make(map[string]*Issue, len(raw))
+	for _, r := range raw {
+		if r.Key == "" {
+			return nil, fmt.Errorf("Jira issue %q has no key", r.Fields.Summary)
+		}
+		if _, dup := byKey[r.Key]; dup {
+			return nil, fmt.Errorf("duplicate Jira key %s", r.Key)
+		}
+		issues = append(issues, mapIssue(r))
+		byKey[r.Key] = &issues[len(issues)-1]
+	}
+
+	// Blocks links appear on both ends of the link; record each edge once,
+	// on the blocked issue.
+	for _, r := range raw {
+		for _, link := range r.Fields.IssueLinks {
+			if !strings.EqualFold(link.Type.Name, "Blocks") {
+				continue
+			}
+			switch {
+			case link.InwardIssue != nil:
+				// r is blocked by the inward issue
+				addDependency(byKey[r.Key], link.InwardIssue.Key)
+			case link.OutwardIssue != nil:
+				// r blocks the outward issue
+				addDependency(byKey[link.OutwardIssue.Key], r.Key)
+			}
+		}
+	}
+
+	return parentsFirst(issues), nil
+}
+
+func addDependency(issue *Issue, key string) {
+	if issue == nil || key == "" || key == issue.SourceKey {
+		return
+	}
+	for _, k := range issue.DependsOn {
+		if k == key {
+			return
+		}
+	}
+	issue.DependsOn = append(issue.DependsOn, key)
+}
+
+func mapIssue(r rawIssue) Issue {
+	f := r.Fields
+	issue := models.Issue{
+		Title:       f.Summary,
+		Description: descriptionText(f.Description),
+		Type:        MapType(f.IssueType.Name),
+		Status:      MapStatus(f.Status.Name, f.Status.StatusCategory.Key),
+		Priority:    models.PriorityP2,
+	}
+	// A Jira label that looks like a source label would make re-imports
+	// skip the wrong issue
+	for _, label := range f.Labels {
+		if !models.IsReservedLabel(label) {
+			issue.Labels = append(issue.Labels, label)
+		}
+	}
+	issue.Labels = append(issue.Labels, SourceLabel(r.Key))
+	if f.Priority != nil {
+		issue.Priority = MapPriority(f.Priority.Name)
+	}
+	if issue.Status == models.StatusClosed {
+		closedAt := time.Now()
+		if t, err := parseJiraTime(f.Resolved); err == nil {
+			closedAt = t
+		}
+		issue.ClosedAt = &closedAt
+	}
+
+	parent := f.EpicLink
+	if f.Parent != nil && f.Parent.Key != "" {
+		parent = f.Parent.Key
+	}
+	return Issue{SourceKey: r.Key, ParentKey: parent, Issue: issue}
+}
+
+// MapType maps a Jira issue type name onto a td type. Unknown types become
+// tasks.
+func MapType(name string) models.Type {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "epic":
+		return models.TypeEpic
+	case "bug", "defect":
+		return models.TypeBug
+	case "story", "feature", "new feature", "improvement":
+		return models.TypeFeature
+	case "chore", "maintenance":
+		return models.TypeChore
+	}
+	return models.TypeTask
+}
+
+// MapStatus maps a Jira status onto a td status, by name for the statuses
+// td has its own state for and by status category otherwise.
+func MapStatus(name, category string) models.Status {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "blocked", "on hold", "impeded":
+		return models.StatusBlocked
+	case "in review", "code review", "review", "in qa", "qa":
+		return models.StatusInReview
+	}
+	switch strings.ToLower(category) {
+	case "indeterminate":
+		return models.StatusInProgress
+	case "done":
+		return models.StatusClosed
+	}
+	return models.StatusOpen
+}
+
+// MapPriority maps a Jira priority name onto P0-P4. Unknown priorities
+// become P2.
+func MapPriority(name string) models.Priority {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "highest", "blocker", "critical":
+		return models.PriorityP0
+	case "high", "major":
+		return models.PriorityP1
+	case "low", "minor":
+		return models.PriorityP3
+	case "lowest", "trivial":
+		return models.PriorityP4
+	}
+	return models.PriorityP2
+}
+
+// descriptionText returns a plain-text description from either a Jira v2
+// string or a v3 Atlassian Document Format tree.
+func descriptionText(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return strings.TrimSpace(s)
+	}
+	var doc adfNode
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return ""
+	}
+	var b strings.Builder
+	doc.writeText(&b)
+	return strings.TrimSpace(b.String())
+}
+
+type adfNode struct {
+	Type    string    `json:"type"`
+	Text    string    `json:"text"`
+	Content []adfNode `json:"content"`
+}
+
+// writeText flattens an ADF node, ending block nodes with a newline.
+func (n adfNode) writeText(b *strings.Builder) {
+	switch n.Type {
+	case "text":
+		b.WriteString(n.Text)
+		return
+	case "hardBreak":
+		b.WriteString("\n")
+		return
+	case "listItem":
+		b.WriteString("- ")
+	}
+	for _, c := range n.Content {
+		c.writeText(b)
+	}
+	switch n.Type {
+	case "paragraph", "heading", "codeBlock", "blockquote":
+		b.WriteString("\n\n")
+	}
+}
+
+func parseJiraTime(s string) (time.Time, error) {
+	// Jira writes offsets without a colon, e.g. 2024-03-01T10:00:00.000+0000
+	for _, layout := range []string{"2006-01-02T15:04:05.000-0700", time.RFC3339} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized Jira time %q", s)
+}
+
+// parentsFirst orders issues so every parent in the set precedes its
+// children, keeping export order otherwise. Parent cycles are broken at the
+// first issue reached.
+func parentsFirst(issues []Issue) []Issue {
+	index := make(map[string]int, len(issues))
+	for i, issue := range issues {
+		index[issue.SourceKey] = i
+	}
+	depth := make([]int, len(issues))
+	for i := range issues {
+		seen := map[int]bool{i: true}
+		for j := i; ; {
+			p, ok := index[issues[j].ParentKey]
+			if !ok || seen[p] {
+				break
+			}
+			seen[p] = true
+			depth[i]++
+			j = p
+		}
+	}
+	order := make([]int, len(issues))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return depth[order[a]] < depth[order[b]] })
+	sorted := make([]Issue, len(issues))
+	for i, idx := range order {
+		sorted[i] = issues[idx]
+	}
+	return sorted
+}
@@ -0,0 +1,205 @@
+package jira
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/marcus/td/internal/models"
+)
+
+// sampleExport is a trimmed /rest/api/3/search response: an epic with a
+// story and a task under it, a subtask of the story, and a bug blocking the
+// story. Issues are out of order on purpose.
+const sampleExport = `{
+  "startAt": 0,
+  "maxResults": 50,
+  "total": 5,
+  "issues": [
+    {
+      "key": "PAY-3",
+      "fields": {
+        "summary": "Validate card numbers",
+        "description": "Luhn check before submit",
+        "issuetype": {"name": "Sub-task", "subtask": true},
+        "status": {"name": "Done", "statusCategory": {"key": "done"}},
+        "priority": {"name": "Medium"},
+        "labels": ["frontend"],
+        "parent": {"key": "PAY-2"},
+        "resolutiondate": "2024-03-04T16:20:00.000+0000"
+      }
+    },
+    {
+      "key": "PAY-2",
+      "fields": {
+        "summary": "Card payment form",
+        "description": {
+          "type": "doc",
+          "version": 1,
+          "content": [
+            {"type": "paragraph", "content": [{"type": "text", "text": "Collect card details"}]}
+          ]
+        },
+        "issuetype": {"name": "Story"},
+        "status": {"name": "Code Review", "statusCategory": {"key": "indeterminate"}},
+        "priority": {"name": "High"},
+        "labels": [],
+        "parent": {"key": "PAY-1"},
+        "issuelinks": [
+          {
+            "type": {"name": "Blocks", "inward": "is blocked by", "outward": "blocks"},
+            "inwardIssue": {"key": "PAY-5"}
+          }
+        ]
+      }
+    },
+    {
+      "key": "PAY-1",
+      "fields": {
+        "summary": "Checkout payments",
+        "description": null,
+        "issuetype": {"name": "Epic"},
+        "status": {"name": "In Progress", "statusCategory": {"key": "indeterminate"}},
+        "priority": {"name": "Highest"},
+        "labels": ["payments", "q2"]
+      }
+    },
+    {
+      "key": "PAY-4",
+      "fields": {
+        "summary": "Refund endpoint",
+        "issuetype": {"name": "Task"},
+        "status": {"name": "To Do", "statusCategory": {"key": "new"}},
+        "priority": {"name": "Lowest"},
+        "customfield_10014": "PAY-1",
+        "issuelinks": [
+          {
+            "type": {"name": "Relates", "inward": "relates to", "outward": "relates to"},
+            "outwardIssue": {"key": "PAY-2"}
+          }
+        ]
+      }
+    },
+    {
+      "key": "PAY-5",
+      "fields": {
+        "summary": "Payment provider sandbox account",
+        "issuetype": {"name": "Bug"},
+        "status": {"name": "Blocked", "statusCategory": {"key": "indeterminate"}},
+        "priority": {"name": "Major"},
+        "issuelinks": [
+          {
+            "type": {"name": "Blocks", "inward": "is blocked by", "outward": "blocks"},
+            "outwardIssue": {"key": "PAY-2"}
+          }
+        ]
+      }
+    }
+  ]
+}`
+
+func TestParseSampleExport(t *testing.T) {
+	issues, err := Parse([]byte(sampleExport))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	byKey := make(map[string]Issue)
+	pos := make(map[string]int)
+	for i, issue := range issues {
+		byKey[issue.SourceKey] = issue
+		pos[issue.SourceKey] = i
+	}
+	if len(byKey) != 5 {
+		t.Fatalf("got %d issues, want 5", len(byKey))
+	}
+
+	tests := []struct {
+		key      string
+		typ      models.Type
+		status   models.Status
+		priority models.Priority
+		parent   string
+		deps     []string
+		labels   []string
+	}{
+		{"PAY-1", models.TypeEpic, models.StatusInProgress, models.PriorityP0, "", nil, []string{"payments", "q2", "jira:PAY-1"}},
+		{"PAY-2", models.TypeFeature, models.StatusInReview, models.PriorityP1, "PAY-1", []string{"PAY-5"}, []string{"jira:PAY-2"}},
+		{"PAY-3", models.TypeTask, models.StatusClosed, models.PriorityP2, "PAY-2", nil, []string{"frontend", "jira:PAY-3"}},
+		{"PAY-4", models.TypeTask, models.StatusOpen, models.PriorityP4, "PAY-1", nil, []string{"jira:PAY-4"}},
+		{"PAY-5", models.TypeBug, models.StatusBlocked, models.PriorityP1, "", nil, []string{"jira:PAY-5"}},
+	}
+	for _, tt := range tests {
+		got, ok := byKey[tt.key]
+		if !ok {
+			t.Errorf("%s missing", tt.key)
+			continue
+		}
+		if got.Issue.Type != tt.typ || got.Issue.Status != tt.status || got.Issue.Priority != tt.priority {
+			t.Errorf("%s = %s/%s/%s, want %s/%s/%s", tt.key, got.Issue.Type, got.Issue.Status, got.Issue.Priority, tt.typ, tt.status, tt.priority)
+		}
+		if got.ParentKey != tt.parent {
+			t.Errorf("%s parent = %q, want %q", tt.key, got.ParentKey, tt.parent)
+		}
+		if !reflect.DeepEqual(got.DependsOn, tt.deps) {
+			t.Errorf("%s depends on %v, want %v", tt.key, got.DependsOn, tt.deps)
+		}
+		if !reflect.DeepEqual(got.Issue.Labels, tt.labels) {
+			t.Errorf("%s labels = %v, want %v", tt.key, got.Issue.Labels, tt.labels)
+		}
+	}
+
+	// Parents come before their children
+	for _, child := range []string{"PAY-2", "PAY-3", "PAY-4"} {
+		parent := byKey[child].ParentKey
+		if pos[parent] > pos[child] {
+			t.Errorf("%s (pos %d) comes after its child %s (pos %d)", parent, pos[parent], child, pos[child])
+		}
+	}
+
+	if got := byKey["PAY-2"].Issue.Description; got != "Collect card details" {
+		t.Errorf("ADF description = %q", got)
+	}
+	if got := byKey["PAY-3"].Issue.Description; got != "Luhn check before submit" {
+		t.Errorf("string description = %q", got)
+	}
+	closed := byKey["PAY-3"].Issue.ClosedAt
+	if closed == nil || closed.Format("2006-01-02 15:04") != "2024-03-04 16:20" {
+		t.Errorf("closed_at = %v, want resolution date", closed)
+	}
+}
+
+func TestParseBareArray(t *testing.T) {
+	issues, err := Parse([]byte(`[{"key": "OPS-1", "fields": {"summary": "Rotate keys", "issuetype": {"name": "Chore"}}}]`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(issues) != 1 || issues[0].SourceKey != "OPS-1" || issues[0].Issue.Type != models.TypeChore {
+		t.Fatalf("Parse() = %+v", issues)
+	}
+}
+
+func TestParseDropsReservedJiraLabels(t *testing.T) {
+	issues, err := Parse([]byte(`[{"key": "OPS-2", "fields": {"summary": "Spoof", "labels": ["ops", "jira:OPS-9"]}}]`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if want := []string{"ops", "jira:OPS-2"}; !reflect.DeepEqual(issues[0].Issue.Labels, want) {
+		t.Errorf("labels = %v, want %v", issues[0].Issue.Labels, want)
+	}
+	if !models.IsReservedLabel(SourceLabel("OPS-2")) {
+		t.Error("source label is not reserved")
+	}
+}
+
+func TestParseRejectsBadInput(t *testing.T) {
+	for _, input := range []string{
+		`not json`,
+		`[{"fields": {"summary": "no key"}}]`,
+		`[{"key": "A-1", "fields": {}}, {"key": "A-1", "fields": {}}]`,
+	} {
+		if _, err := Parse([]byte(input)); err == nil {
+			t.Errorf("Parse(%s) succeeded, want error", strings.TrimSpace(input))
+		}
+	}
+}
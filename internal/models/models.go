@@ -95,6 +95,7 @@ type Issue struct {
 	Sprint                   string     `json:"sprint,omitempty"`
 	ImplementerSession       string     `json:"implementer_session"`
 	CreatorSession           string     `json:"creator_session"`
+	CreatedBy                string     `json:"created_by,omitempty"` // session name, or session ID when unnamed
 	ReviewerSession          string     `json:"reviewer_session"`
 	ReviewRequestedBySession string     `json:"review_requested_by_session,omitempty"`
 	ClosedBySession          string     `json:"closed_by_session,omitempty"`
@@ -158,9 +159,17 @@ type IssueFile struct {
 type IssueDependency struct {
 	IssueID      string `json:"issue_id"`
 	DependsOnID  string `json:"depends_on_id"`
-	RelationType string `json:"relation_type"` // blocks, depends_on
+	RelationType string `json:"relation_type"` // depends_on, relates_to
 }
 
+// Relation types stored in IssueDependency.RelationType.
+const (
+	// RelationDependsOn means IssueID is blocked until DependsOnID closes.
+	RelationDependsOn = "depends_on"
+	// RelationRelatesTo is a symmetric, informational link that never blocks.
+	RelationRelatesTo = "relates_to"
+)
+
 // WorkSession represents a multi-issue work session
 type WorkSession struct {
 	ID           string     `json:"id"`
@@ -241,11 +250,60 @@ type BoardIssueView struct {
 
 // Comment represents a comment on an issue
 type Comment struct {
-	ID        string    `json:"id"`
-	IssueID   string    `json:"issue_id"`
-	SessionID string    `json:"session_id"`
-	Text      string    `json:"text"`
-	CreatedAt time.Time `json:"created_at"`
+	ID              string    `json:"id"`
+	IssueID         string    `json:"issue_id"`
+	SessionID       string    `json:"session_id"`
+	Text            string    `json:"text"`
+	ParentCommentID string    `json:"parent_comment_id,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// ThreadedComment is a comment placed in its reply thread. Depth is 0 for
+// top-level comments and grows by one per reply level.
+type ThreadedComment struct {
+	Comment
+	Depth int
+}
+
+// ThreadComments orders comments so each reply follows its parent, with
+// siblings kept in their input order (chronological as stored). Replies whose
+// parent is not in the list are shown at the top level.
+func ThreadComments(comments []Comment) []ThreadedComment {
+	present := make(map[string]bool, len(comments))
+	for _, c := range comments {
+		present[c.ID] = true
+	}
+	replies := make(map[string][]Comment)
+	var roots []Comment
+	for _, c := range comments {
+		if c.ParentCommentID != "" && c.ParentCommentID != c.ID && present[c.ParentCommentID] {
+			replies[c.ParentCommentID] = append(replies[c.ParentCommentID], c)
+		} else {
+			roots = append(roots, c)
+		}
+	}
+
+	out := make([]ThreadedComment, 0, len(comments))
+	visited := make(map[string]bool, len(comments))
+	var walk func(c Comment, depth int)
+	walk = func(c Comment, depth int) {
+		if visited[c.ID] {
+			return
+		}
+		visited[c.ID] = true
+		out = append(out, ThreadedComment{Comment: c, Depth: depth})
+		for _, r := range replies[c.ID] {
+			walk(r, depth+1)
+		}
+	}
+	for _, c := range roots {
+		walk(c, 0)
+	}
+	// Reply cycles have no root; keep them visible rather than dropping them
+	for _, c := range comments {
+		walk(c, 0)
+	}
+	return out
 }
 
 // Note represents a freeform note (synced via sidecar)
@@ -275,14 +333,67 @@ type Config struct {
 	SearchQuery   string `json:"search_query,omitempty"`
 	SortMode      string `json:"sort_mode,omitempty"`   // "priority", "created", "updated"
 	TypeFilter    string `json:"type_filter,omitempty"` // "epic", "task", "bug", "feature", "chore", ""
+	LabelFilter   string `json:"label_filter,omitempty"`
 	IncludeClosed bool   `json:"include_closed,omitempty"`
+	QueryPreset   string `json:"query_preset,omitempty"` // Last-used monitor query preset, "" for none
+	// Project-wide default monitor view, cached from the sync server on
+	// `td sync`. Applied only when there is no local filter state or board.
+	ProjectDefaultQuery string `json:"project_default_query,omitempty"`
+	ProjectDefaultBoard string `json:"project_default_board,omitempty"`
+	// Project settings cached from the sync server on `td sync`. They apply
+	// only where the local RequireCloseReason and Workflow are unset.
+	ProjectRequireCloseReason *bool           `json:"project_require_close_reason,omitempty"`
+	ProjectWorkflow           *WorkflowConfig `json:"project_workflow,omitempty"`
+	// DefaultQuery is a TDQ expression `td list` and `td query` start from,
+	// set with `td config set default-query`. --all ignores it.
+	DefaultQuery string `json:"default_query,omitempty"`
 	// Title validation limits
 	TitleMinLength int `json:"title_min_length,omitempty"` // Default: 15
 	TitleMaxLength int `json:"title_max_length,omitempty"` // Default: 100
+	// New issue IDs. IDPrefix (default "td-") is letters and digits ending
+	// in "-"; IDFormat is "hex" (random, the default) or "seq" (zero-padded
+	// per-prefix sequence, e.g. PROJ-001). Existing IDs are never rewritten.
+	IDPrefix string `json:"id_prefix,omitempty"`
+	IDFormat string `json:"id_format,omitempty"`
+	// WIPLimit caps how many in_progress issues one session may hold before
+	// `td next --claim` refuses to claim another. 0 uses the default (1);
+	// a negative value disables the limit.
+	WIPLimit int `json:"wip_limit,omitempty"`
+	// CascadeApproval makes `td approve` approve a parent epic once every
+	// child is approved, recursively up the epic chain. Off by default;
+	// without it the epic is only auto-closed.
+	CascadeApproval bool `json:"cascade_approval,omitempty"`
+	// RequireCloseReason makes closing or rejecting an issue fail without a
+	// reason, so every close and rejection is explained in the issue's log.
+	RequireCloseReason bool `json:"require_close_reason,omitempty"`
+	// LabelColors maps labels to the color they render in: a 256-color
+	// code or #rrggbb (see `td label color`). Unset labels get a color
+	// derived from their name.
+	LabelColors map[string]string `json:"label_colors,omitempty"`
+	// LastExportSeq is the action_log sequence number at the last JSON
+	// `td export`; `td export --since last` exports changes after it.
+	LastExportSeq int64 `json:"last_export_seq,omitempty"`
 	// GettingStartedSeen records that the Welcome/Getting Started modal has been
 	// shown at least once in this project, so it is not re-shown on every monitor
 	// launch. Set automatically the first time the modal is displayed.
 	GettingStartedSeen bool `json:"getting_started_seen,omitempty"`
+	// Workflow adds project-specific statuses and transitions on top of
+	// the built-in workflow (see `td workflow`).
+	Workflow *WorkflowConfig `json:"workflow,omitempty"`
+}
+
+// WorkflowConfig extends the built-in status workflow. Statuses are extra
+// lowercase status names; Transitions are the extra moves allowed between
+// any two statuses, built-in or custom. Built-in transitions always remain.
+type WorkflowConfig struct {
+	Statuses    []Status             `json:"statuses,omitempty"`
+	Transitions []WorkflowTransition `json:"transitions,omitempty"`
+}
+
+// WorkflowTransition is one allowed status change in a WorkflowConfig.
+type WorkflowTransition struct {
+	From Status `json:"from"`
+	To   Status `json:"to"`
 }
 
 // ActionType represents the type of action that was performed
@@ -373,7 +484,25 @@ func IsValidPoints(p int) bool {
 	return false
 }
 
-// IsValidStatus checks if a status is valid
+// ReservedLabelPrefixes mark labels td manages itself: "jira:<KEY>" records
+// the Jira issue td import jira created an issue from, and is how re-imports
+// skip it. Edits keep an issue's reserved labels and can't add new ones, and
+// they can't be renamed.
+var ReservedLabelPrefixes = []string{"jira:"}
+
+// IsReservedLabel reports whether label has a reserved prefix.
+func IsReservedLabel(label string) bool {
+	for _, prefix := range ReservedLabelPrefixes {
+		if strings.HasPrefix(label, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidStatus checks if a status is one of the built-in statuses. A
+// project's custom workflow statuses come from its workflow config; see
+// workflow.ForProject.
 func IsValidStatus(s Status) bool {
 	switch s {
 	case StatusOpen, StatusInProgress, StatusBlocked, StatusInReview, StatusClosed:
@@ -0,0 +1,51 @@
+package output
+
+import (
+	"os"
+	"sync"
+
+	"charm.land/lipgloss/v2"
+	"golang.org/x/term"
+)
+
+var (
+	colorOnce    sync.Once
+	colorEnabled bool
+)
+
+// ColorEnabled reports whether styled output should carry ANSI escapes. Color
+// is on only when stdout is a terminal, NO_COLOR is unset and TERM is not
+// "dumb", so piped and redirected output stays plain.
+func ColorEnabled() bool {
+	colorOnce.Do(func() {
+		colorEnabled = detectColor(os.Getenv, term.IsTerminal(int(os.Stdout.Fd())))
+	})
+	return colorEnabled
+}
+
+// setColorEnabled overrides terminal detection.
+func setColorEnabled(enabled bool) {
+	colorOnce.Do(func() {})
+	colorEnabled = enabled
+}
+
+// detectColor applies the NO_COLOR (https://no-color.org) and TERM=dumb
+// conventions on top of the TTY check.
+func detectColor(getenv func(string) string, isTTY bool) bool {
+	if getenv("NO_COLOR") != "" {
+		return false
+	}
+	if getenv("TERM") == "dumb" {
+		return false
+	}
+	return isTTY
+}
+
+// paint renders s with style when color is enabled and returns it unchanged
+// otherwise.
+func paint(style lipgloss.Style, s string) string {
+	if !ColorEnabled() {
+		return s
+	}
+	return style.Render(s)
+}
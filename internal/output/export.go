@@ -0,0 +1,93 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/marcus/td/internal/models"
+)
+
+// ExportCSVHeader is the column order used by FormatIssuesCSV.
+var ExportCSVHeader = []string{"id", "title", "status", "type", "priority", "points", "labels", "parent_id", "created_at", "updated_at"}
+
+// FormatIssuesMarkdown renders issues as the Markdown document used by td export.
+func FormatIssuesMarkdown(issues []models.Issue) string {
+	var sb strings.Builder
+	sb.WriteString("# Issues Export\n\n")
+	for _, issue := range issues {
+		sb.WriteString(fmt.Sprintf("## %s: %s\n\n", issue.ID, issue.Title))
+		writeIssueMarkdownBody(&sb, issue)
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// FormatIssueMarkdown renders one issue in the td export Markdown style,
+// followed by its acceptance criteria and logs (oldest first), for pasting
+// into chat or a PR description.
+func FormatIssueMarkdown(issue models.Issue, logs []models.Log) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# %s: %s\n\n", issue.ID, issue.Title))
+	writeIssueMarkdownBody(&sb, issue)
+	if issue.Acceptance != "" {
+		sb.WriteString(fmt.Sprintf("\n## Acceptance Criteria\n\n%s\n", issue.Acceptance))
+	}
+	if len(logs) > 0 {
+		sb.WriteString("\n## Recent Logs\n\n")
+		for _, l := range logs {
+			sb.WriteString(fmt.Sprintf("- %s [%s] %s\n", l.Timestamp.Local().Format("2006-01-02 15:04"), l.Type, l.Message))
+		}
+	}
+	return sb.String()
+}
+
+// writeIssueMarkdownBody writes the metadata list and description shared by
+// the Markdown export formats.
+func writeIssueMarkdownBody(sb *strings.Builder, issue models.Issue) {
+	sb.WriteString(fmt.Sprintf("- Status: %s\n", issue.Status))
+	sb.WriteString(fmt.Sprintf("- Type: %s\n", issue.Type))
+	sb.WriteString(fmt.Sprintf("- Priority: %s\n", issue.Priority))
+	if issue.Points > 0 {
+		sb.WriteString(fmt.Sprintf("- Points: %d\n", issue.Points))
+	}
+	if len(issue.Labels) > 0 {
+		sb.WriteString(fmt.Sprintf("- Labels: %s\n", strings.Join(issue.Labels, ", ")))
+	}
+	if issue.Description != "" {
+		sb.WriteString(fmt.Sprintf("\n%s\n", issue.Description))
+	}
+}
+
+// FormatIssuesCSV renders issues as CSV with a header row (see ExportCSVHeader).
+func FormatIssuesCSV(issues []models.Issue) (string, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(ExportCSVHeader); err != nil {
+		return "", err
+	}
+	for _, issue := range issues {
+		record := []string{
+			issue.ID,
+			issue.Title,
+			string(issue.Status),
+			string(issue.Type),
+			string(issue.Priority),
+			strconv.Itoa(issue.Points),
+			strings.Join(issue.Labels, ","),
+			issue.ParentID,
+			issue.CreatedAt.UTC().Format("2006-01-02T15:04:05Z"),
+			issue.UpdatedAt.UTC().Format("2006-01-02T15:04:05Z"),
+		}
+		if err := w.Write(record); err != nil {
+			return "", err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
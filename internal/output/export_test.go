@@ -0,0 +1,56 @@
+package output
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/marcus/td/internal/models"
+)
+
+func TestFormatIssueMarkdown(t *testing.T) {
+	issue := models.Issue{
+		ID:          "td-a1b2c3",
+		Title:       "Fix login timeout",
+		Status:      models.StatusInProgress,
+		Type:        models.TypeBug,
+		Priority:    models.PriorityP1,
+		Labels:      []string{"auth", "backend"},
+		Description: "Sessions expire after 5 minutes.",
+		Acceptance:  "- Sessions last 24 hours",
+	}
+	at := time.Date(2026, 3, 4, 9, 30, 0, 0, time.Local)
+	logs := []models.Log{
+		{Type: models.LogTypeProgress, Message: "Found the TTL constant", Timestamp: at},
+		{Type: models.LogTypeDecision, Message: "Read TTL from config", Timestamp: at.Add(time.Hour)},
+	}
+
+	want := `# td-a1b2c3: Fix login timeout
+
+- Status: in_progress
+- Type: bug
+- Priority: P1
+- Labels: auth, backend
+
+Sessions expire after 5 minutes.
+
+## Acceptance Criteria
+
+- Sessions last 24 hours
+
+## Recent Logs
+
+- 2026-03-04 09:30 [progress] Found the TTL constant
+- 2026-03-04 10:30 [decision] Read TTL from config
+`
+	if got := FormatIssueMarkdown(issue, logs); got != want {
+		t.Errorf("FormatIssueMarkdown() =\n%s\nwant\n%s", got, want)
+	}
+
+	// Without acceptance criteria or logs those sections are left out
+	issue.Acceptance = ""
+	got := FormatIssueMarkdown(issue, nil)
+	if strings.Contains(got, "## Acceptance Criteria") || strings.Contains(got, "## Recent Logs") {
+		t.Errorf("expected no empty sections, got:\n%s", got)
+	}
+}
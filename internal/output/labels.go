@@ -0,0 +1,105 @@
+package output
+
+import (
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+)
+
+// labelPalette is the set of 256-color codes unset labels are hashed onto:
+// mid-brightness hues that read on dark and light backgrounds alike.
+var labelPalette = []string{
+	"33", "39", "44", "42", "78", "114", "148", "178",
+	"214", "208", "203", "168", "170", "141", "99", "75",
+}
+
+// labelColorNames maps the color names `td label color` accepts to codes.
+var labelColorNames = map[string]string{
+	"red":     "196",
+	"orange":  "208",
+	"yellow":  "226",
+	"green":   "42",
+	"teal":    "37",
+	"cyan":    "51",
+	"blue":    "33",
+	"purple":  "141",
+	"magenta": "201",
+	"pink":    "212",
+	"brown":   "130",
+	"gray":    "245",
+	"grey":    "245",
+}
+
+var hexColorPattern = regexp.MustCompile(`^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// labelColors holds the project's explicit label colors for FormatLabels.
+var labelColors map[string]string
+
+// SetLabelColors sets the explicit label colors FormatLabels uses, keyed by
+// label. Labels without an entry get a hash-derived color.
+func SetLabelColors(colors map[string]string) {
+	labelColors = colors
+}
+
+// LabelColorNames returns the color names NormalizeLabelColor accepts, sorted.
+func LabelColorNames() []string {
+	names := make([]string, 0, len(labelColorNames))
+	for name := range labelColorNames {
+		if name != "grey" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NormalizeLabelColor validates a label color given as a name ("red"), a
+// 256-color code ("196") or a hex value ("#ff8800"), returning the value to
+// store: the code for names and codes, the lowercased hex otherwise.
+func NormalizeLabelColor(color string) (string, error) {
+	c := strings.ToLower(strings.TrimSpace(color))
+	if code, ok := labelColorNames[c]; ok {
+		return code, nil
+	}
+	if n, err := strconv.Atoi(c); err == nil && n >= 0 && n <= 255 {
+		return strconv.Itoa(n), nil
+	}
+	if hexColorPattern.MatchString(c) {
+		return c, nil
+	}
+	return "", fmt.Errorf("invalid color %q: use a name (%s), a 256-color code (0-255) or #rrggbb",
+		color, strings.Join(LabelColorNames(), ", "))
+}
+
+// LabelColor resolves the color of label: its entry in colors when that is
+// a valid color, otherwise a color derived from a hash of the label, so a
+// label looks the same everywhere without any configuration.
+func LabelColor(label string, colors map[string]string) string {
+	if explicit, ok := colors[label]; ok {
+		if c, err := NormalizeLabelColor(explicit); err == nil {
+			return c
+		}
+	}
+	h := fnv.New32a()
+	h.Write([]byte(label))
+	return labelPalette[h.Sum32()%uint32(len(labelPalette))]
+}
+
+// FormatLabel renders label in its resolved color.
+func FormatLabel(label string) string {
+	return paint(lipgloss.NewStyle().Foreground(lipgloss.Color(LabelColor(label, labelColors))), label)
+}
+
+// FormatLabels renders labels comma-separated, each in its resolved color.
+func FormatLabels(labels []string) string {
+	parts := make([]string, len(labels))
+	for i, label := range labels {
+		parts[i] = FormatLabel(label)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -21,12 +21,20 @@ var (
 	errorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
 	warningStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
 	priorityStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("212"))
-	statusStyles  = map[models.Status]lipgloss.Style{
+	// Status and priority colors match the monitor (pkg/monitor/styles.go)
+	statusStyles = map[models.Status]lipgloss.Style{
 		models.StatusOpen:       lipgloss.NewStyle().Foreground(lipgloss.Color("45")),
 		models.StatusInProgress: lipgloss.NewStyle().Foreground(lipgloss.Color("214")),
 		models.StatusBlocked:    lipgloss.NewStyle().Foreground(lipgloss.Color("196")),
 		models.StatusInReview:   lipgloss.NewStyle().Foreground(lipgloss.Color("141")),
-		models.StatusClosed:     lipgloss.NewStyle().Foreground(lipgloss.Color("242")),
+		models.StatusClosed:     lipgloss.NewStyle().Foreground(lipgloss.Color("241")),
+	}
+	priorityStyles = map[models.Priority]lipgloss.Style{
+		models.PriorityP0: lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true),
+		models.PriorityP1: lipgloss.NewStyle().Foreground(lipgloss.Color("214")),
+		models.PriorityP2: lipgloss.NewStyle().Foreground(lipgloss.Color("45")),
+		models.PriorityP3: lipgloss.NewStyle().Foreground(lipgloss.Color("241")),
+		models.PriorityP4: lipgloss.NewStyle().Foreground(lipgloss.Color("241")),
 	}
 )
 
@@ -41,24 +49,24 @@ const (
 
 // Success prints a success message
 func Success(format string, args ...interface{}) {
-	fmt.Println(successStyle.Render(fmt.Sprintf(format, args...)))
+	fmt.Println(paint(successStyle, fmt.Sprintf(format, args...)))
 }
 
 // Error prints an error message
 func Error(format string, args ...interface{}) {
-	fmt.Println(errorStyle.Render("ERROR: " + fmt.Sprintf(format, args...)))
+	fmt.Println(paint(errorStyle, "ERROR: "+fmt.Sprintf(format, args...)))
 }
 
 // Warning prints a warning message
 func Warning(format string, args ...interface{}) {
-	fmt.Println(warningStyle.Render("Warning: " + fmt.Sprintf(format, args...)))
+	fmt.Println(paint(warningStyle, "Warning: "+fmt.Sprintf(format, args...)))
 }
 
 // WarningErr prints a warning message to stderr. Use this instead of Warning
 // for out-of-band notices (e.g. background auto-sync) so the message does not
 // corrupt stdout when a command is emitting machine-readable JSON.
 func WarningErr(format string, args ...interface{}) {
-	fmt.Fprintln(os.Stderr, warningStyle.Render("Warning: "+fmt.Sprintf(format, args...)))
+	fmt.Fprintln(os.Stderr, paint(warningStyle, "Warning: "+fmt.Sprintf(format, args...)))
 }
 
 // Info prints an info message
@@ -180,12 +188,16 @@ func FormatStatus(s models.Status) string {
 	if !ok {
 		return string(s)
 	}
-	return style.Render(fmt.Sprintf("[%s]", s))
+	return paint(style, fmt.Sprintf("[%s]", s))
 }
 
-// FormatPriority formats a priority
+// FormatPriority formats a priority with color
 func FormatPriority(p models.Priority) string {
-	return priorityStyle.Render(fmt.Sprintf("[%s]", p))
+	style, ok := priorityStyles[p]
+	if !ok {
+		style = priorityStyle
+	}
+	return paint(style, fmt.Sprintf("[%s]", p))
 }
 
 // FormatPoints returns empty string if points is 0, otherwise "Npts"
@@ -208,15 +220,15 @@ func FormatPointsSuffix(points int) string {
 // FormatIssueShort formats an issue in short format
 func FormatIssueShort(issue *models.Issue) string {
 	var parts []string
-	parts = append(parts, titleStyle.Render(issue.ID))
+	parts = append(parts, paint(titleStyle, issue.ID))
 	parts = append(parts, FormatPriority(issue.Priority))
 	parts = append(parts, issue.Title)
 
 	if issue.Points > 0 {
-		parts = append(parts, subtleStyle.Render(fmt.Sprintf("%dpts", issue.Points)))
+		parts = append(parts, paint(subtleStyle, fmt.Sprintf("%dpts", issue.Points)))
 	}
 
-	parts = append(parts, subtleStyle.Render(string(issue.Type)))
+	parts = append(parts, paint(subtleStyle, string(issue.Type)))
 	parts = append(parts, FormatStatus(issue.Status))
 
 	return strings.Join(parts, "  ")
@@ -225,16 +237,16 @@ func FormatIssueShort(issue *models.Issue) string {
 // FormatIssueDeleted formats a deleted issue showing [deleted] marker instead of status
 func FormatIssueDeleted(issue *models.Issue) string {
 	var parts []string
-	parts = append(parts, titleStyle.Render(issue.ID))
+	parts = append(parts, paint(titleStyle, issue.ID))
 	parts = append(parts, FormatPriority(issue.Priority))
 	parts = append(parts, issue.Title)
 
 	if issue.Points > 0 {
-		parts = append(parts, subtleStyle.Render(fmt.Sprintf("%dpts", issue.Points)))
+		parts = append(parts, paint(subtleStyle, fmt.Sprintf("%dpts", issue.Points)))
 	}
 
-	parts = append(parts, subtleStyle.Render(string(issue.Type)))
-	parts = append(parts, errorStyle.Render("[deleted]"))
+	parts = append(parts, paint(subtleStyle, string(issue.Type)))
+	parts = append(parts, paint(errorStyle, "[deleted]"))
 
 	return strings.Join(parts, "  ")
 }
@@ -244,7 +256,7 @@ func FormatIssueLong(issue *models.Issue, logs []models.Log, handoff *models.Han
 	var sb strings.Builder
 
 	// Header
-	sb.WriteString(titleStyle.Render(fmt.Sprintf("%s: %s", issue.ID, issue.Title)))
+	sb.WriteString(paint(titleStyle, fmt.Sprintf("%s: %s", issue.ID, issue.Title)))
 	sb.WriteString("\n")
 	sb.WriteString(fmt.Sprintf("Status: %s\n", FormatStatus(issue.Status)))
 	sb.WriteString(fmt.Sprintf("Type: %s | Priority: %s", issue.Type, issue.Priority))
@@ -257,7 +269,7 @@ func FormatIssueLong(issue *models.Issue, logs []models.Log, handoff *models.Han
 	sb.WriteString("\n")
 
 	if len(issue.Labels) > 0 {
-		sb.WriteString(fmt.Sprintf("Labels: %s\n", strings.Join(issue.Labels, ", ")))
+		sb.WriteString(fmt.Sprintf("Labels: %s\n", FormatLabels(issue.Labels)))
 	}
 	if issue.DeferUntil != nil {
 		sb.WriteString(fmt.Sprintf("Deferred until: %s", *issue.DeferUntil))
@@ -273,7 +285,7 @@ func FormatIssueLong(issue *models.Issue, logs []models.Log, handoff *models.Han
 	// Description
 	if issue.Description != "" {
 		sb.WriteString("\n")
-		sb.WriteString(subtleStyle.Render("Description:"))
+		sb.WriteString(paint(subtleStyle, "Description:"))
 		sb.WriteString("\n")
 		sb.WriteString(issue.Description)
 		sb.WriteString("\n")
@@ -282,7 +294,7 @@ func FormatIssueLong(issue *models.Issue, logs []models.Log, handoff *models.Han
 	// Acceptance criteria
 	if issue.Acceptance != "" {
 		sb.WriteString("\n")
-		sb.WriteString(subtleStyle.Render("Acceptance Criteria:"))
+		sb.WriteString(paint(subtleStyle, "Acceptance Criteria:"))
 		sb.WriteString("\n")
 		sb.WriteString(issue.Acceptance)
 		sb.WriteString("\n")
@@ -292,31 +304,7 @@ func FormatIssueLong(issue *models.Issue, logs []models.Log, handoff *models.Han
 	if handoff != nil {
 		sb.WriteString("\n")
 		sb.WriteString(fmt.Sprintf("CURRENT HANDOFF (%s, %s):\n", handoff.SessionID, FormatTimeAgo(handoff.Timestamp)))
-
-		if len(handoff.Done) > 0 {
-			sb.WriteString("  Done:\n")
-			for _, item := range handoff.Done {
-				sb.WriteString(fmt.Sprintf("    - %s\n", item))
-			}
-		}
-		if len(handoff.Remaining) > 0 {
-			sb.WriteString("  Remaining:\n")
-			for _, item := range handoff.Remaining {
-				sb.WriteString(fmt.Sprintf("    - %s\n", item))
-			}
-		}
-		if len(handoff.Decisions) > 0 {
-			sb.WriteString("  Decisions:\n")
-			for _, item := range handoff.Decisions {
-				sb.WriteString(fmt.Sprintf("    - %s\n", item))
-			}
-		}
-		if len(handoff.Uncertain) > 0 {
-			sb.WriteString("  Uncertain:\n")
-			for _, item := range handoff.Uncertain {
-				sb.WriteString(fmt.Sprintf("    - %s\n", item))
-			}
-		}
+		sb.WriteString(FormatHandoff(handoff))
 	}
 
 	// Session log
@@ -342,6 +330,31 @@ func FormatIssueLong(issue *models.Issue, logs []models.Log, handoff *models.Han
 	return sb.String()
 }
 
+// FormatHandoff formats a handoff's sections as indented lists, omitting
+// empty sections
+func FormatHandoff(handoff *models.Handoff) string {
+	var sb strings.Builder
+	sections := []struct {
+		title string
+		items []string
+	}{
+		{"Done", handoff.Done},
+		{"Remaining", handoff.Remaining},
+		{"Decisions", handoff.Decisions},
+		{"Uncertain", handoff.Uncertain},
+	}
+	for _, section := range sections {
+		if len(section.items) == 0 {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("  %s:\n", section.title))
+		for _, item := range section.items {
+			sb.WriteString(fmt.Sprintf("    - %s\n", item))
+		}
+	}
+	return sb.String()
+}
+
 // FormatTimeAgo formats a time as a human-readable "ago" string
 func FormatTimeAgo(t time.Time) string {
 	diff := time.Since(t)
@@ -418,7 +431,7 @@ func StatusBadge(status models.Status) string {
 	}
 	style, hasStyle := statusStyles[status]
 	if hasStyle {
-		return style.Render(fmt.Sprintf("%s %s", symbol, status))
+		return paint(style, fmt.Sprintf("%s %s", symbol, status))
 	}
 	return fmt.Sprintf("%s %s", symbol, status)
 }
@@ -875,3 +875,128 @@ func TestDependencyLineNotResolved(t *testing.T) {
 		t.Error("Open issue should not have checkmark even with showResolved=true")
 	}
 }
+
+func TestDetectColor(t *testing.T) {
+	tests := []struct {
+		name  string
+		env   map[string]string
+		isTTY bool
+		want  bool
+	}{
+		{"tty", nil, true, true},
+		{"not a tty", nil, false, false},
+		{"NO_COLOR set", map[string]string{"NO_COLOR": "1"}, true, false},
+		{"dumb terminal", map[string]string{"TERM": "dumb"}, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(k string) string { return tt.env[k] }
+			if got := detectColor(getenv, tt.isTTY); got != tt.want {
+				t.Errorf("detectColor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatIssueShortColor(t *testing.T) {
+	issue := &models.Issue{ID: "td-abc", Title: "Fix it", Status: models.StatusOpen, Priority: models.PriorityP0, Type: models.TypeBug}
+	t.Cleanup(func() { setColorEnabled(false) })
+
+	setColorEnabled(false)
+	plain := FormatIssueShort(issue)
+	if strings.Contains(plain, "\x1b[") {
+		t.Errorf("color disabled: escape codes leaked into %q", plain)
+	}
+	if !strings.Contains(plain, "[P0]") || !strings.Contains(plain, "[open]") {
+		t.Errorf("color disabled: missing priority/status in %q", plain)
+	}
+
+	setColorEnabled(true)
+	if colored := FormatIssueShort(issue); !strings.Contains(colored, "\x1b[") {
+		t.Errorf("color enabled: expected escape codes in %q", colored)
+	}
+}
+
+func TestJSONOutputHasNoEscapeCodes(t *testing.T) {
+	setColorEnabled(true)
+	t.Cleanup(func() { setColorEnabled(false) })
+
+	out := captureStdout(t, func() {
+		_ = JSON(&models.Issue{ID: "td-abc", Title: "Fix it", Status: models.StatusBlocked, Priority: models.PriorityP1})
+	})
+	if strings.Contains(out, "\x1b[") {
+		t.Errorf("escape codes leaked into JSON output: %q", out)
+	}
+}
+
+func TestLabelColor(t *testing.T) {
+	colors := map[string]string{
+		"backend": "33",
+		"urgent":  "#ff5f00",
+		"broken":  "not-a-color",
+	}
+
+	if got := LabelColor("backend", colors); got != "33" {
+		t.Errorf("explicit code: got %q, want 33", got)
+	}
+	if got := LabelColor("urgent", colors); got != "#ff5f00" {
+		t.Errorf("explicit hex: got %q, want #ff5f00", got)
+	}
+
+	// Unset labels hash onto the palette, the same way every time
+	hashed := LabelColor("frontend", colors)
+	if hashed != LabelColor("frontend", nil) {
+		t.Errorf("hashed color depends on other labels' colors: %q vs %q", hashed, LabelColor("frontend", nil))
+	}
+	found := false
+	for _, c := range labelPalette {
+		found = found || c == hashed
+	}
+	if !found {
+		t.Errorf("hashed color %q is not in the palette", hashed)
+	}
+
+	// An invalid stored color falls back to the hash
+	if got := LabelColor("broken", colors); got != LabelColor("broken", nil) {
+		t.Errorf("invalid explicit color: got %q, want hashed %q", got, LabelColor("broken", nil))
+	}
+
+	// Different labels spread across the palette
+	seen := map[string]bool{}
+	for _, label := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		seen[LabelColor(label, nil)] = true
+	}
+	if len(seen) < 3 {
+		t.Errorf("expected hashed colors to vary across labels, got %v", seen)
+	}
+}
+
+func TestNormalizeLabelColor(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "red", want: "196"},
+		{in: " Blue ", want: "33"},
+		{in: "grey", want: "245"},
+		{in: "208", want: "208"},
+		{in: "#FF8800", want: "#ff8800"},
+		{in: "#abc", want: "#abc"},
+		{in: "256", wantErr: true},
+		{in: "-1", wantErr: true},
+		{in: "#12345", wantErr: true},
+		{in: "chartreuse", wantErr: true},
+		{in: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := NormalizeLabelColor(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("NormalizeLabelColor(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("NormalizeLabelColor(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -13,15 +13,17 @@ type TreeNode struct {
 	Title    string
 	Type     models.Type
 	Status   models.Status
+	Priority models.Priority
 	Children []TreeNode
 }
 
 // TreeRenderOptions configures tree rendering behavior
 type TreeRenderOptions struct {
-	MaxDepth    int  // 0 = unlimited
-	ShowStatus  bool // Whether to show status indicator
-	ShowType    bool // Whether to show issue type
-	Indentation int  // Base indentation level (for nested contexts)
+	MaxDepth     int  // 0 = unlimited
+	ShowStatus   bool // Whether to show status indicator
+	ShowType     bool // Whether to show issue type
+	ShowPriority bool // Whether to show issue priority
+	Indentation  int  // Base indentation level (for nested contexts)
 }
 
 // statusMark returns a status indicator symbol
@@ -78,6 +80,9 @@ func renderTreeNodes(nodes []TreeNode, opts TreeRenderOptions, depth int, prefix
 		parts = append(parts, node.ID+":")
 		parts = append(parts, node.Title)
 
+		if opts.ShowPriority && node.Priority != "" {
+			parts = append(parts, FormatPriority(node.Priority))
+		}
 		if opts.ShowStatus {
 			parts = append(parts, FormatStatus(node.Status))
 			parts = append(parts, statusMark(node.Status))
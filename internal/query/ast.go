@@ -149,10 +149,16 @@ var KnownFields = map[string]string{
 	"priority":    "ordinal",
 	"points":      "number",
 	"labels":      "string",
+
+	// Hierarchy counts (computed from parent links, non-deleted issues)
+	"child_count":      "number",
+	"descendant_count": "number",
+
 	"parent":      "string",
 	"epic":        "string",
 	"implementer": "string",
 	"reviewer":    "string",
+	"created_by":  "string",
 	"minor":       "bool",
 	"branch":      "string",
 	"sprint":      "string",
@@ -222,21 +228,30 @@ var KnownFunctions = map[string]struct {
 	MaxArgs int
 	Help    string
 }{
-	"has":           {1, 1, "has(field) - field is not empty"},
-	"is":            {1, 1, "is(status) - shorthand for status check"},
-	"any":           {2, -1, "any(field, v1, v2, ...) - field matches any value"},
-	"all":           {2, -1, "all(field, v1, v2, ...) - field matches all values"},
-	"none":          {2, -1, "none(field, v1, v2, ...) - field matches none"},
-	"blocks":        {1, 1, "blocks(id) - issues that block the given id"},
-	"blocked_by":    {1, 1, "blocked_by(id) - issues blocked by the given id"},
-	"child_of":      {1, 1, "child_of(id) - direct children of issue"},
-	"descendant_of": {1, 1, "descendant_of(id) - all descendants (recursive)"},
-	"linked_to":     {1, 1, "linked_to(path) - issues linked to file path"},
-	"rework":        {0, 0, "rework() - issues rejected and awaiting rework"},
-	"is_ready":      {0, 0, "is_ready() - issues with no open dependencies"},
-	"has_open_deps": {0, 0, "has_open_deps() - issues with open dependencies"},
-	"label":         {1, 1, "label(name) - issues with the given label"},
-	"labels":        {1, 1, "labels(name) - alias for label()"},
+	"has":                {1, 1, "has(field) - field is not empty"},
+	"is":                 {1, 1, "is(status) - shorthand for status check"},
+	"any":                {2, -1, "any(field, v1, v2, ...) - field matches any value"},
+	"all":                {2, -1, "all(field, v1, v2, ...) - field matches all values"},
+	"none":               {2, -1, "none(field, v1, v2, ...) - field matches none"},
+	"blocks":             {1, 1, "blocks(id) - issues the given id depends on (they block it)"},
+	"blocked_by":         {1, 1, "blocked_by(id) - issues that depend on the given id (it blocks them)"},
+	"depends_on":         {1, 1, "depends_on(id) - issues that directly depend on the given id; same as blocked_by(id)"},
+	"dependency_of":      {1, 1, "dependency_of(id) - issues the given id directly depends on; same as blocks(id)"},
+	"child_of":           {1, 1, "child_of(id) - direct children of issue"},
+	"descendant_of":      {1, 1, "descendant_of(id) - all descendants (recursive)"},
+	"linked_to":          {1, 1, "linked_to(path) - issues linked to file path"},
+	"related_to":         {1, 1, "related_to(id) - issues with a non-blocking relates_to link to id"},
+	"rework":             {0, 0, "rework() - open/in_progress issues rejected since their last review or approval"},
+	"is_ready":           {0, 0, "is_ready() - issues with no open dependencies"},
+	"is_actionable":      {0, 0, "is_actionable() - is_ready() issues whose parent chain is not blocked or unstarted"},
+	"has_open_deps":      {0, 0, "has_open_deps() - issues with open dependencies"},
+	"has_comments":       {0, 0, "has_comments() - issues with at least one comment"},
+	"has_handoff":        {0, 0, "has_handoff() - issues with at least one handoff"},
+	"has_files":          {0, 0, "has_files() - issues with linked files"},
+	"mine":               {0, 0, "mine() - issues claimed by, assigned to, or created by the current session/identity"},
+	"updated_by_session": {1, 1, "updated_by_session(id) - issues with any recorded action by the given session"},
+	"label":              {1, 1, "label(name) - issues with the given label"},
+	"labels":             {1, 1, "labels(name) - alias for label()"},
 }
 
 // SortClause represents a sort specification
@@ -292,3 +307,54 @@ func (q *Query) String() string {
 	}
 	return strings.Join(parts, " ")
 }
+
+// Explain renders the parsed query as an indented tree, one node per line,
+// for `td query --explain`.
+func (q *Query) Explain() string {
+	var sb strings.Builder
+	if q.Root == nil {
+		sb.WriteString("(match all)\n")
+	} else {
+		explainNode(&sb, q.Root, 0)
+	}
+	if q.Sort != nil {
+		sb.WriteString(q.Sort.String())
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func explainNode(sb *strings.Builder, n Node, depth int) {
+	indent := strings.Repeat("  ", depth)
+	switch node := n.(type) {
+	case *BinaryExpr:
+		fmt.Fprintf(sb, "%s%s\n", indent, node.Op)
+		// Flatten chains of the same operator so a AND b AND c reads as one list
+		for _, child := range flattenBinary(node) {
+			explainNode(sb, child, depth+1)
+		}
+	case *UnaryExpr:
+		fmt.Fprintf(sb, "%s%s\n", indent, node.Op)
+		explainNode(sb, node.Expr, depth+1)
+	case *FieldExpr:
+		fmt.Fprintf(sb, "%sfield   %s %s %v\n", indent, node.Field, node.Operator, node.Value)
+	case *FunctionCall:
+		fmt.Fprintf(sb, "%sfunc    %s\n", indent, node.String())
+	case *TextSearch:
+		fmt.Fprintf(sb, "%ssearch  %s\n", indent, node.String())
+	default:
+		fmt.Fprintf(sb, "%s%s\n", indent, n.String())
+	}
+}
+
+func flattenBinary(b *BinaryExpr) []Node {
+	var out []Node
+	for _, side := range []Node{b.Left, b.Right} {
+		if child, ok := side.(*BinaryExpr); ok && child.Op == b.Op {
+			out = append(out, flattenBinary(child)...)
+		} else {
+			out = append(out, side)
+		}
+	}
+	return out
+}
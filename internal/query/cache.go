@@ -0,0 +1,143 @@
+package query
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/marcus/td/internal/models"
+)
+
+// maxCacheEntries bounds a Cache; when a new result would exceed it the
+// cache starts over.
+const maxCacheEntries = 64
+
+// RevisionSource is a QuerySource that reports a revision which advances
+// whenever its data changes. *db.DB implements it.
+type RevisionSource interface {
+	QuerySource
+	QueryRevision() (int64, error)
+}
+
+// Cache memoizes Execute results for callers that re-run the same queries
+// against an unchanged database, such as the monitor refreshing boards.
+// Results are keyed by normalized query, session and options, and are only
+// reused while the source's revision stays the same. A Cache serves a single
+// source and is safe for concurrent use; a nil Cache runs every query.
+type Cache struct {
+	mu       sync.Mutex
+	revision int64
+	entries  map[cacheKey][]models.Issue
+}
+
+type cacheKey struct {
+	query      string
+	sessionID  string
+	limit      int
+	sortBy     string
+	sortDesc   bool
+	maxResults int
+	identities string
+	statuses   string
+}
+
+// NewCache returns an empty Cache.
+func NewCache() *Cache {
+	return &Cache{entries: make(map[cacheKey][]models.Issue)}
+}
+
+// Execute runs the query like the package-level Execute, returning a cached
+// result when the same query ran at the current revision. Queries with
+// relative dates ("-7d", today) depend on the clock as well and always run.
+func (c *Cache) Execute(database RevisionSource, queryStr string, sessionID string, opts ExecuteOptions) ([]models.Issue, error) {
+	if c == nil {
+		return Execute(database, queryStr, sessionID, opts)
+	}
+	q, err := Parse(queryStr)
+	if err != nil || hasRelativeDate(q.Root) {
+		return Execute(database, queryStr, sessionID, opts)
+	}
+	revision, err := database.QueryRevision()
+	if err != nil {
+		return Execute(database, queryStr, sessionID, opts)
+	}
+
+	key := cacheKey{
+		query:      normalizeQuery(queryStr),
+		sessionID:  sessionID,
+		limit:      opts.Limit,
+		sortBy:     opts.SortBy,
+		sortDesc:   opts.SortDesc,
+		maxResults: opts.MaxResults,
+		identities: strings.Join(opts.Identities, ","),
+		statuses:   fmt.Sprint(opts.Statuses),
+	}
+
+	c.mu.Lock()
+	if revision != c.revision {
+		c.revision = revision
+		clear(c.entries)
+	}
+	cached, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok {
+		return slices.Clone(cached), nil
+	}
+
+	results, err := Execute(database, queryStr, sessionID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	// A slower run may finish after the revision moved on; keep only
+	// results that are still current
+	if revision == c.revision {
+		if len(c.entries) >= maxCacheEntries {
+			clear(c.entries)
+		}
+		c.entries[key] = slices.Clone(results)
+	}
+	c.mu.Unlock()
+	return results, nil
+}
+
+// normalizeQuery reduces a query to its token stream, so spacing and
+// quoting differences share a cache entry.
+func normalizeQuery(queryStr string) string {
+	tokens, err := NewLexer(queryStr).Tokenize()
+	if err != nil {
+		return queryStr
+	}
+	parts := make([]string, len(tokens))
+	for i, tok := range tokens {
+		parts[i] = tok.String()
+	}
+	return strings.Join(parts, " ")
+}
+
+// hasRelativeDate reports whether n compares against a relative date.
+func hasRelativeDate(n Node) bool {
+	switch node := n.(type) {
+	case *BinaryExpr:
+		return hasRelativeDate(node.Left) || hasRelativeDate(node.Right)
+	case *UnaryExpr:
+		return hasRelativeDate(node.Expr)
+	case *FieldExpr:
+		return isRelativeDateValue(node.Value)
+	case *FunctionCall:
+		return slices.ContainsFunc(node.Args, isRelativeDateValue)
+	}
+	return false
+}
+
+func isRelativeDateValue(v interface{}) bool {
+	switch val := v.(type) {
+	case *DateValue:
+		return val.Relative
+	case *ListValue:
+		return slices.ContainsFunc(val.Values, isRelativeDateValue)
+	}
+	return false
+}
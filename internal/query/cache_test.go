@@ -0,0 +1,84 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/models"
+)
+
+// countingSource counts the issue listings Execute does, which is one per
+// query actually run.
+type countingSource struct {
+	*db.DB
+	lists int
+}
+
+func (s *countingSource) ListIssues(opts db.ListIssuesOptions) ([]models.Issue, error) {
+	s.lists++
+	return s.DB.ListIssues(opts)
+}
+
+func TestCacheHitsUntilRevisionChanges(t *testing.T) {
+	database := setupTestDB(t)
+	defer database.Close()
+	src := &countingSource{DB: database}
+
+	createTestIssue(t, database, "td-001", "Fix auth bug", models.StatusOpen, models.TypeBug, models.PriorityP1)
+	createTestIssue(t, database, "td-002", "Add login feature", models.StatusOpen, models.TypeFeature, models.PriorityP2)
+
+	cache := NewCache()
+	results, err := cache.Execute(src, "type = bug", "ses_test", ExecuteOptions{})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(results) != 1 || src.lists != 1 {
+		t.Fatalf("first run: %d results, %d listings; want 1, 1", len(results), src.lists)
+	}
+
+	// Same query, spelled differently, at the same revision: served from cache
+	results, err = cache.Execute(src, "type=bug", "ses_test", ExecuteOptions{})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(results) != 1 || src.lists != 1 {
+		t.Fatalf("repeat run: %d results, %d listings; want 1, 1 (cache hit)", len(results), src.lists)
+	}
+
+	// Another session is a different entry
+	if _, err := cache.Execute(src, "type = bug", "ses_other", ExecuteOptions{}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if src.lists != 2 {
+		t.Fatalf("other session: %d listings, want 2 (cache miss)", src.lists)
+	}
+
+	// A logged write advances the revision and the query runs again
+	bug := &models.Issue{Title: "Another bug", Type: models.TypeBug}
+	if err := database.CreateIssueLogged(bug, "ses_test"); err != nil {
+		t.Fatalf("CreateIssueLogged failed: %v", err)
+	}
+	results, err = cache.Execute(src, "type = bug", "ses_test", ExecuteOptions{})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(results) != 2 || src.lists != 3 {
+		t.Fatalf("after write: %d results, %d listings; want 2, 3 (cache miss)", len(results), src.lists)
+	}
+}
+
+func TestCacheSkipsRelativeDates(t *testing.T) {
+	database := setupTestDB(t)
+	defer database.Close()
+	src := &countingSource{DB: database}
+
+	cache := NewCache()
+	for range 2 {
+		if _, err := cache.Execute(src, "created >= -7d", "ses_test", ExecuteOptions{}); err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+	}
+	if src.lists != 2 {
+		t.Fatalf("relative date query: %d listings, want 2 (never cached)", src.lists)
+	}
+}
@@ -13,6 +13,7 @@ import (
 // EvalContext provides context for query evaluation
 type EvalContext struct {
 	CurrentSession string    // for @me resolution
+	Identities     []string  // other session IDs belonging to the caller, for mine()
 	Now            time.Time // for relative date calculation
 }
 
@@ -24,6 +25,19 @@ func NewEvalContext(sessionID string) *EvalContext {
 	}
 }
 
+// ownSessions returns the current session plus any sessions sharing the caller's identity
+func (c *EvalContext) ownSessions() []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, id := range append([]string{c.CurrentSession}, c.Identities...) {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
 // escapeSQLWildcards escapes SQL LIKE pattern wildcards (% and _) in user input
 // to prevent unintended pattern matching
 func escapeSQLWildcards(s string) string {
@@ -121,7 +135,7 @@ func (e *Evaluator) hasCrossEntity(n Node) bool {
 		return e.hasCrossEntity(node.Expr)
 	case *FieldExpr:
 		// "epic" without dot (e.g., "epic = td-123") requires walking the parent chain
-		if node.Field == "epic" {
+		if node.Field == "epic" || isHierarchyCountField(node.Field) {
 			return true
 		}
 		parts := strings.Split(node.Field, ".")
@@ -131,12 +145,37 @@ func (e *Evaluator) hasCrossEntity(n Node) bool {
 		}
 		return false
 	case *FunctionCall:
-		return node.Name == "blocks" || node.Name == "blocked_by" || node.Name == "linked_to" || node.Name == "descendant_of" || node.Name == "rework" || node.Name == "is_ready" || node.Name == "has_open_deps"
+		return isCrossEntityFunction(node.Name)
 	default:
 		return false
 	}
 }
 
+// crossEntityFunctions lists query functions that need database lookups
+// beyond the issue row itself and are evaluated by the cross-entity filter.
+var crossEntityFunctions = map[string]bool{
+	"blocks":             true,
+	"blocked_by":         true,
+	"depends_on":         true,
+	"dependency_of":      true,
+	"linked_to":          true,
+	"related_to":         true,
+	"descendant_of":      true,
+	"rework":             true,
+	"is_ready":           true,
+	"is_actionable":      true,
+	"has_open_deps":      true,
+	"has_comments":       true,
+	"has_handoff":        true,
+	"has_files":          true,
+	"updated_by_session": true,
+}
+
+// isCrossEntityFunction reports whether the named function requires database lookups
+func isCrossEntityFunction(name string) bool {
+	return crossEntityFunctions[name]
+}
+
 // isCrossEntityNode checks if a node is a cross-entity field expression
 // Used to skip in-memory negation for cross-entity conditions
 func (e *Evaluator) isCrossEntityNode(n Node) bool {
@@ -153,7 +192,7 @@ func (e *Evaluator) isCrossEntityNode(n Node) bool {
 		}
 		return false
 	case *FunctionCall:
-		return node.Name == "blocks" || node.Name == "blocked_by" || node.Name == "linked_to" || node.Name == "descendant_of" || node.Name == "rework" || node.Name == "is_ready" || node.Name == "has_open_deps"
+		return isCrossEntityFunction(node.Name)
 	default:
 		return false
 	}
@@ -338,14 +377,40 @@ func (e *Evaluator) functionToSQL(node *FunctionCall) ([]SQLCondition, error) {
 		parentID := fmt.Sprintf("%v", node.Args[0])
 		return []SQLCondition{{Clause: "parent_id = ?", Args: []interface{}{parentID}}}, nil
 
+	case "mine":
+		ids := e.ctx.ownSessions()
+		if len(ids) == 0 {
+			return []SQLCondition{{Clause: "1 = 0"}}, nil
+		}
+		placeholders := make([]string, len(ids))
+		args := make([]interface{}, len(ids))
+		for i, id := range ids {
+			placeholders[i] = "?"
+			args[i] = id
+		}
+		in := strings.Join(placeholders, ",")
+		return []SQLCondition{{
+			Clause: fmt.Sprintf("(implementer_session IN (%s) OR creator_session IN (%s))", in, in),
+			Args:   append(args, args...),
+		}}, nil
+
 	case "descendant_of":
 		// This requires recursive query, return nil and handle in memory
 		return nil, nil
 
-	case "blocks", "blocked_by", "linked_to":
+	case "blocks", "blocked_by", "depends_on", "dependency_of", "linked_to", "related_to":
 		// These require joins, handle in memory
 		return nil, nil
 
+	case "updated_by_session":
+		if len(node.Args) < 1 {
+			return nil, fmt.Errorf("%s() requires 1 argument", node.Name)
+		}
+		return []SQLCondition{{
+			Clause: "EXISTS (SELECT 1 FROM action_log al WHERE al.entity_id = issues.id AND al.session_id = ? AND al.undone = 0)",
+			Args:   []interface{}{fmt.Sprintf("%v", node.Args[0])},
+		}}, nil
+
 	case "label", "labels":
 		if len(node.Args) < 1 {
 			return nil, fmt.Errorf("%s() requires 1 argument", node.Name)
@@ -493,7 +558,9 @@ func (e *Evaluator) parseRelativeOffset(s string) string {
 	case 'm':
 		return now.AddDate(0, num, 0).Format("2006-01-02")
 	case 'h':
-		return now.Add(time.Duration(num) * time.Hour).Format("2006-01-02 15:04:05")
+		// Match the stored timestamp format (RFC3339 UTC, fixed-width
+		// nanoseconds) so the text comparison is exact.
+		return now.Add(time.Duration(num) * time.Hour).UTC().Format("2006-01-02T15:04:05.000000000Z07:00")
 	default:
 		return s
 	}
@@ -617,6 +684,8 @@ func (e *Evaluator) getFieldGetter(field string) func(models.Issue) interface{}
 		return func(i models.Issue) interface{} { return i.ImplementerSession }
 	case "reviewer", "reviewer_session":
 		return func(i models.Issue) interface{} { return i.ReviewerSession }
+	case "created_by":
+		return func(i models.Issue) interface{} { return i.CreatedBy }
 	case "branch", "created_branch":
 		return func(i models.Issue) interface{} { return i.CreatedBranch }
 	case "sprint":
@@ -788,12 +857,22 @@ func (e *Evaluator) functionToMatcher(node *FunctionCall) (func(models.Issue) bo
 			return i.ParentID == parentID
 		}, nil
 
+	case "mine":
+		own := make(map[string]bool)
+		for _, id := range e.ctx.ownSessions() {
+			own[id] = true
+		}
+		return func(i models.Issue) bool {
+			return own[i.ImplementerSession] || own[i.CreatorSession]
+		}, nil
+
 	case "descendant_of":
 		// This requires recursive parent traversal, handled via cross-entity filter
 		// Return placeholder that allows issue through (will be filtered in Execute)
 		return func(models.Issue) bool { return true }, nil
 
-	case "blocks", "blocked_by", "linked_to", "rework", "is_ready", "has_open_deps":
+	case "blocks", "blocked_by", "depends_on", "dependency_of", "linked_to", "related_to", "rework", "is_ready", "is_actionable", "has_open_deps",
+		"has_comments", "has_handoff", "has_files", "updated_by_session":
 		// These require database lookups, handled via cross-entity filter
 		return func(models.Issue) bool { return true }, nil
 
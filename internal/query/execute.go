@@ -21,6 +21,12 @@ type ExecuteOptions struct {
 	SortBy     string
 	SortDesc   bool
 	MaxResults int // Max issues to process in-memory (0 = DefaultMaxResults)
+	// Identities lists other session IDs that belong to the caller (e.g.
+	// sessions sharing its name); mine() matches these alongside sessionID.
+	Identities []string
+	// Statuses are the project's custom workflow statuses, which status
+	// comparisons accept alongside the built-in ones.
+	Statuses []models.Status
 }
 
 // Execute parses and executes a TDQ query
@@ -32,7 +38,7 @@ func Execute(database QuerySource, queryStr string, sessionID string, opts Execu
 	}
 
 	// Validate the query
-	if errs := query.Validate(); len(errs) > 0 {
+	if errs := query.ValidateWithStatuses(opts.Statuses); len(errs) > 0 {
 		return nil, fmt.Errorf("validation error: %v", errs[0])
 	}
 
@@ -52,6 +58,7 @@ func Execute(database QuerySource, queryStr string, sessionID string, opts Execu
 
 	// Create evaluation context
 	ctx := NewEvalContext(sessionID)
+	ctx.Identities = opts.Identities
 	evaluator := NewEvaluator(ctx, query)
 
 	// Check if we need cross-entity queries
@@ -125,8 +132,17 @@ func applyCrossEntityFilters(database QuerySource, issues []models.Issue, query
 
 // crossEntityPrefetch holds pre-fetched bulk data to avoid per-issue queries
 type crossEntityPrefetch struct {
-	reworkIDs        map[string]bool
+	reworkIDs          map[string]bool
 	issuesWithOpenDeps map[string]bool
+	stalledByParent    map[string]bool
+	issuesWithComments map[string]bool
+	issuesWithHandoffs map[string]bool
+	issuesWithFiles    map[string]bool
+	childCounts        map[string]int
+	descendantCounts   map[string]int
+	// updatedBySession caches updated_by_session() matches per session ID,
+	// filled on first use since the argument varies per call
+	updatedBySession map[string]map[string]bool
 }
 
 // prefetchCrossEntityData walks the AST to find what bulk data needs pre-fetching
@@ -140,15 +156,104 @@ func prefetchCrossEntityData(database QuerySource, n Node) (*crossEntityPrefetch
 			return nil, fmt.Errorf("failed to fetch rework IDs: %w", err)
 		}
 	}
-	if needs["is_ready"] || needs["has_open_deps"] {
+	if needs["is_ready"] || needs["is_actionable"] || needs["has_open_deps"] {
 		p.issuesWithOpenDeps, err = database.GetIssuesWithOpenDeps()
 		if err != nil {
 			return nil, fmt.Errorf("failed to fetch dependency data: %w", err)
 		}
 	}
+	if needs["is_actionable"] {
+		p.stalledByParent, err = stalledByParent(database)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch parent data: %w", err)
+		}
+	}
+	if needs["has_comments"] {
+		p.issuesWithComments, err = database.GetIssuesWithComments()
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch comment data: %w", err)
+		}
+	}
+	if needs["has_handoff"] {
+		p.issuesWithHandoffs, err = database.GetIssuesWithHandoffs()
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch handoff data: %w", err)
+		}
+	}
+	if needs["has_files"] {
+		p.issuesWithFiles, err = database.GetIssuesWithFiles()
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch linked file data: %w", err)
+		}
+	}
+	if needs["child_count"] || needs["descendant_count"] {
+		parents, err := database.GetParentIDs()
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch hierarchy data: %w", err)
+		}
+		p.childCounts, p.descendantCounts = hierarchyCounts(parents)
+	}
 	return p, nil
 }
 
+// stalledByParent returns the IDs of issues with a blocked or not yet
+// started (open) issue anywhere in their parent chain. Missing parents
+// don't stall their children.
+func stalledByParent(database QuerySource) (map[string]bool, error) {
+	parents, err := database.GetParentIDs()
+	if err != nil {
+		return nil, err
+	}
+	statuses := make(map[string]models.Status)
+	status := func(id string) models.Status {
+		if s, ok := statuses[id]; ok {
+			return s
+		}
+		var s models.Status
+		if issue, err := database.GetIssue(id); err == nil && issue.DeletedAt == nil {
+			s = issue.Status
+		}
+		statuses[id] = s
+		return s
+	}
+
+	stalled := make(map[string]bool)
+	for id, parent := range parents {
+		seen := map[string]bool{id: true}
+		for p := parent; p != "" && !seen[p]; p = parents[p] {
+			seen[p] = true
+			if s := status(p); s == models.StatusBlocked || s == models.StatusOpen {
+				stalled[id] = true
+				break
+			}
+		}
+	}
+	return stalled, nil
+}
+
+// isHierarchyCountField reports whether field is one of the virtual
+// child_count / descendant_count fields.
+func isHierarchyCountField(field string) bool {
+	return field == "child_count" || field == "descendant_count"
+}
+
+// hierarchyCounts derives direct-child and descendant counts per issue from
+// a child → parent map. Cycles in the parent chain are counted once.
+func hierarchyCounts(parents map[string]string) (children, descendants map[string]int) {
+	children = make(map[string]int)
+	descendants = make(map[string]int)
+	for id, parent := range parents {
+		children[parent]++
+		// Credit every ancestor of id with one descendant
+		seen := map[string]bool{id: true}
+		for p := parent; p != "" && !seen[p]; p = parents[p] {
+			seen[p] = true
+			descendants[p]++
+		}
+	}
+	return children, descendants
+}
+
 func collectFunctionNames(n Node) map[string]bool {
 	names := make(map[string]bool)
 	switch node := n.(type) {
@@ -165,6 +270,10 @@ func collectFunctionNames(n Node) map[string]bool {
 		}
 	case *FunctionCall:
 		names[node.Name] = true
+	case *FieldExpr:
+		if isHierarchyCountField(node.Field) {
+			names[node.Field] = true
+		}
 	}
 	return names
 }
@@ -204,9 +313,16 @@ func evalCrossEntityNode(database QuerySource, issue models.Issue, n Node, ctx *
 		return !inner, nil
 
 	case *FieldExpr:
+		if isHierarchyCountField(node.Field) {
+			counts := pf.childCounts
+			if node.Field == "descendant_count" {
+				counts = pf.descendantCounts
+			}
+			return matchCount(counts[issue.ID], node, ctx), nil
+		}
 		filter := fieldExprToFilter(node, false)
 		if filter != nil {
-			return applyCrossEntityFilter(database, issue, *filter, ctx, pf.reworkIDs, pf.issuesWithOpenDeps)
+			return applyCrossEntityFilter(database, issue, *filter, ctx, pf)
 		}
 		// Regular field - evaluate in-memory
 		evaluator := NewEvaluator(ctx, &Query{})
@@ -219,7 +335,7 @@ func evalCrossEntityNode(database QuerySource, issue models.Issue, n Node, ctx *
 	case *FunctionCall:
 		filter := functionCallToFilter(node, false)
 		if filter != nil {
-			return applyCrossEntityFilter(database, issue, *filter, ctx, pf.reworkIDs, pf.issuesWithOpenDeps)
+			return applyCrossEntityFilter(database, issue, *filter, ctx, pf)
 		}
 		// Regular function - evaluate in-memory
 		evaluator := NewEvaluator(ctx, &Query{})
@@ -273,7 +389,7 @@ func fieldExprToFilter(node *FieldExpr, negated bool) *crossEntityFilter {
 // functionCallToFilter converts a FunctionCall to a crossEntityFilter if it's a cross-entity function.
 // Returns nil for non-cross-entity functions.
 func functionCallToFilter(node *FunctionCall, negated bool) *crossEntityFilter {
-	if node.Name == "blocks" || node.Name == "blocked_by" || node.Name == "linked_to" || node.Name == "descendant_of" || node.Name == "rework" || node.Name == "is_ready" || node.Name == "has_open_deps" {
+	if isCrossEntityFunction(node.Name) {
 		return &crossEntityFilter{
 			entity:   "function",
 			field:    node.Name,
@@ -315,7 +431,7 @@ type crossEntityFilter struct {
 	negated  bool // true if wrapped in NOT (unused in new AST-walk approach)
 }
 
-func applyCrossEntityFilter(database QuerySource, issue models.Issue, filter crossEntityFilter, ctx *EvalContext, reworkIDs, issuesWithOpenDeps map[string]bool) (bool, error) {
+func applyCrossEntityFilter(database QuerySource, issue models.Issue, filter crossEntityFilter, ctx *EvalContext, pf *crossEntityPrefetch) (bool, error) {
 	switch filter.entity {
 	case "log":
 		logs, err := database.GetLogs(issue.ID, 0) // 0 = no limit
@@ -353,7 +469,7 @@ func applyCrossEntityFilter(database QuerySource, issue models.Issue, filter cro
 		return matchEpicAncestor(database, issue, filter, ctx)
 
 	case "function":
-		return applyFunctionFilter(database, issue, filter, reworkIDs, issuesWithOpenDeps)
+		return applyFunctionFilter(database, issue, filter, pf)
 
 	default:
 		return true, nil
@@ -514,17 +630,42 @@ func matchValue(fieldValue, operator string, value interface{}, ctx *EvalContext
 	}
 }
 
-func applyFunctionFilter(database QuerySource, issue models.Issue, filter crossEntityFilter, reworkIDs, issuesWithOpenDeps map[string]bool) (bool, error) {
+// matchCount compares a computed count against a numeric field condition.
+func matchCount(count int, node *FieldExpr, ctx *EvalContext) bool {
+	e := NewEvaluator(ctx, &Query{})
+	value := e.resolveValue(node.Value)
+	switch node.Operator {
+	case OpEq:
+		return e.compareEqual(count, value)
+	case OpNeq:
+		return !e.compareEqual(count, value)
+	case OpLt, OpGt, OpLte, OpGte:
+		return e.compareOrder(count, value, node.Operator)
+	default:
+		return false
+	}
+}
+
+func applyFunctionFilter(database QuerySource, issue models.Issue, filter crossEntityFilter, pf *crossEntityPrefetch) (bool, error) {
 	// Handle no-arg functions first
 	switch filter.field {
 	case "rework":
-		return reworkIDs[issue.ID], nil
+		return pf.reworkIDs[issue.ID], nil
 	case "is_ready":
 		// is_ready() returns true if the issue has NO open dependencies
-		return !issuesWithOpenDeps[issue.ID], nil
+		return !pf.issuesWithOpenDeps[issue.ID], nil
+	case "is_actionable":
+		// is_actionable() is is_ready() that also skips children of stalled parents
+		return !pf.issuesWithOpenDeps[issue.ID] && !pf.stalledByParent[issue.ID], nil
 	case "has_open_deps":
 		// has_open_deps() returns true if the issue has at least one open dependency
-		return issuesWithOpenDeps[issue.ID], nil
+		return pf.issuesWithOpenDeps[issue.ID], nil
+	case "has_comments":
+		return pf.issuesWithComments[issue.ID], nil
+	case "has_handoff":
+		return pf.issuesWithHandoffs[issue.ID], nil
+	case "has_files":
+		return pf.issuesWithFiles[issue.ID], nil
 	}
 
 	// Functions that require arguments
@@ -536,7 +677,25 @@ func applyFunctionFilter(database QuerySource, issue models.Issue, filter crossE
 	targetID := fmt.Sprintf("%v", args[0])
 
 	switch filter.field {
-	case "blocks":
+	case "updated_by_session":
+		ids, ok := pf.updatedBySession[targetID]
+		if !ok {
+			var err error
+			ids, err = database.GetIssuesUpdatedBySession(targetID)
+			if err != nil {
+				return false, fmt.Errorf("failed to fetch session actions: %w", err)
+			}
+			if pf.updatedBySession == nil {
+				pf.updatedBySession = make(map[string]map[string]bool)
+			}
+			pf.updatedBySession[targetID] = ids
+		}
+		return ids[issue.ID], nil
+
+	// Dependency direction: "A depends on B" is stored as issue_id=A,
+	// depends_on_id=B, and B blocks A. is_ready() is the same edge read the
+	// other way: an issue is ready when nothing open is a dependency_of it.
+	case "blocks", "dependency_of":
 		// Check if this issue blocks the target (i.e., target depends on this issue)
 		deps, err := database.GetDependencies(targetID)
 		if err != nil {
@@ -549,7 +708,7 @@ func applyFunctionFilter(database QuerySource, issue models.Issue, filter crossE
 		}
 		return false, nil
 
-	case "blocked_by":
+	case "blocked_by", "depends_on":
 		// Check if this issue is blocked by the target (i.e., this issue depends on target)
 		deps, err := database.GetDependencies(issue.ID)
 		if err != nil {
@@ -562,6 +721,19 @@ func applyFunctionFilter(database QuerySource, issue models.Issue, filter crossE
 		}
 		return false, nil
 
+	case "related_to":
+		// Relations are symmetric, so either side of the link matches
+		related, err := database.GetRelated(issue.ID)
+		if err != nil {
+			return false, err
+		}
+		for _, id := range related {
+			if id == targetID {
+				return true, nil
+			}
+		}
+		return false, nil
+
 	case "linked_to":
 		// Check if this issue is linked to the file
 		files, err := database.GetLinkedFiles(issue.ID)
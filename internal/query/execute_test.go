@@ -616,6 +616,46 @@ func TestExecuteIsReadyAndHasOpenDeps(t *testing.T) {
 	})
 }
 
+func TestRelatedToFunction(t *testing.T) {
+	database := setupTestDB(t)
+
+	a := &models.Issue{Title: "Auth refactor", Status: models.StatusOpen, Type: models.TypeTask}
+	b := &models.Issue{Title: "Session cleanup", Status: models.StatusOpen, Type: models.TypeTask}
+	c := &models.Issue{Title: "Unrelated", Status: models.StatusOpen, Type: models.TypeTask}
+	for _, issue := range []*models.Issue{a, b, c} {
+		if err := database.CreateIssue(issue); err != nil {
+			t.Fatalf("failed to create issue: %v", err)
+		}
+	}
+	if err := database.AddRelation(a.ID, b.ID, "ses_test"); err != nil {
+		t.Fatalf("AddRelation failed: %v", err)
+	}
+
+	for _, tc := range []struct {
+		target, want string
+	}{
+		{a.ID, b.ID},
+		{b.ID, a.ID}, // symmetric
+	} {
+		results, err := Execute(database, "related_to("+tc.target+")", "ses_test", ExecuteOptions{})
+		if err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		if len(results) != 1 || results[0].ID != tc.want {
+			t.Errorf("related_to(%s) = %v, want [%s]", tc.target, results, tc.want)
+		}
+	}
+
+	// Relations never block.
+	results, err := Execute(database, "is_ready()", "ses_test", ExecuteOptions{})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(results) != 3 {
+		t.Errorf("is_ready() returned %d results, want all 3", len(results))
+	}
+}
+
 func TestExecuteWithLogs(t *testing.T) {
 	database := setupTestDB(t)
 	defer database.Close()
@@ -698,48 +738,68 @@ func TestReworkFunction(t *testing.T) {
 	issue2 := createTestIssue(t, database, "td-rework2", "Rejected then resubmitted", models.StatusInProgress, models.TypeTask, models.PriorityP2)
 	createTestIssue(t, database, "td-rework3", "Never rejected", models.StatusInProgress, models.TypeTask, models.PriorityP2)
 	createTestIssue(t, database, "td-rework4", "Rejected but closed", models.StatusClosed, models.TypeTask, models.PriorityP2)
+	issue5 := createTestIssue(t, database, "td-rework5", "Rejected then picked up", models.StatusInProgress, models.TypeTask, models.PriorityP2)
+	issue6 := createTestIssue(t, database, "td-rework6", "Rejected, approved, reopened", models.StatusOpen, models.TypeTask, models.PriorityP2)
+	issue7 := createTestIssue(t, database, "td-rework7", "Rejected, resubmit undone", models.StatusOpen, models.TypeTask, models.PriorityP2)
 
-	// issue1: rejected, no subsequent review (should be detected by rework())
-	_ = database.LogAction(&models.ActionLog{
-		SessionID:  "ses_reviewer",
-		ActionType: models.ActionReject,
-		EntityType: "issue",
-		EntityID:   issue1.ID,
-	})
+	logAction := func(sessionID string, action models.ActionType, issueID string) *models.ActionLog {
+		t.Helper()
+		entry := &models.ActionLog{
+			SessionID:  sessionID,
+			ActionType: action,
+			EntityType: "issue",
+			EntityID:   issueID,
+		}
+		if err := database.LogAction(entry); err != nil {
+			t.Fatalf("LogAction failed: %v", err)
+		}
+		return entry
+	}
 
-	// issue2: rejected, then re-submitted (should NOT be detected)
-	_ = database.LogAction(&models.ActionLog{
-		SessionID:  "ses_reviewer",
-		ActionType: models.ActionReject,
-		EntityType: "issue",
-		EntityID:   issue2.ID,
-	})
-	_ = database.LogAction(&models.ActionLog{
-		SessionID:  "ses_implementer",
-		ActionType: models.ActionReview,
-		EntityType: "issue",
-		EntityID:   issue2.ID,
-	})
+	// issue1: rejected back to open, no subsequent review (detected)
+	logAction("ses_reviewer", models.ActionReject, issue1.ID)
 
-	// issue3: never rejected (should NOT be detected)
-	// issue4: rejected but closed status (should NOT be detected)
-	_ = database.LogAction(&models.ActionLog{
-		SessionID:  "ses_reviewer",
-		ActionType: models.ActionReject,
-		EntityType: "issue",
-		EntityID:   "td-rework4",
-	})
+	// issue2: rejected, then re-submitted (NOT detected)
+	logAction("ses_reviewer", models.ActionReject, issue2.ID)
+	logAction("ses_implementer", models.ActionReview, issue2.ID)
+
+	// issue3: never rejected (NOT detected)
+	// issue4: rejected but closed status (NOT detected)
+	logAction("ses_reviewer", models.ActionReject, "td-rework4")
+
+	// issue5: rejected, then claimed again by an implementer (detected)
+	logAction("ses_reviewer", models.ActionReject, issue5.ID)
+	logAction("ses_implementer", models.ActionStart, issue5.ID)
+
+	// issue6: rejected, later approved and reopened (NOT detected)
+	logAction("ses_reviewer", models.ActionReject, issue6.ID)
+	logAction("ses_reviewer", models.ActionApprove, issue6.ID)
+	logAction("ses_other", models.ActionReopen, issue6.ID)
+
+	// issue7: rejected, resubmitted, resubmit undone (detected)
+	logAction("ses_reviewer", models.ActionReject, issue7.ID)
+	review := logAction("ses_implementer", models.ActionReview, issue7.ID)
+	if err := database.MarkActionUndone(review.ID); err != nil {
+		t.Fatalf("MarkActionUndone failed: %v", err)
+	}
 
 	t.Run("rework() returns rejected open/in_progress issues", func(t *testing.T) {
 		results, err := Execute(database, "rework()", "ses_test", ExecuteOptions{})
 		if err != nil {
 			t.Fatalf("Execute() error = %v", err)
 		}
-		if len(results) != 1 {
-			t.Errorf("Execute() returned %d results, want 1", len(results))
+		got := make(map[string]bool)
+		for _, r := range results {
+			got[r.ID] = true
+		}
+		want := []string{issue1.ID, issue5.ID, issue7.ID}
+		if len(results) != len(want) {
+			t.Errorf("Execute() returned %d results, want %d", len(results), len(want))
 		}
-		if len(results) > 0 && results[0].ID != issue1.ID {
-			t.Errorf("Expected %s, got %s", issue1.ID, results[0].ID)
+		for _, id := range want {
+			if !got[id] {
+				t.Errorf("expected %s in rework() results", id)
+			}
 		}
 	})
 
@@ -748,8 +808,8 @@ func TestReworkFunction(t *testing.T) {
 		if err != nil {
 			t.Fatalf("Execute() error = %v", err)
 		}
-		if len(results) != 1 {
-			t.Errorf("Execute() returned %d results, want 1", len(results))
+		if len(results) != 2 {
+			t.Errorf("Execute() returned %d results, want 2", len(results))
 		}
 	})
 }
@@ -1061,6 +1121,28 @@ func TestExecuteCommentCrossEntity(t *testing.T) {
 	})
 }
 
+func TestExecuteCommentTextMatchesReplies(t *testing.T) {
+	database := setupTestDB(t)
+	defer database.Close()
+
+	issue := createTestIssue(t, database, "", "Threaded", models.StatusOpen, models.TypeTask, models.PriorityP1)
+	top := &models.Comment{IssueID: issue.ID, SessionID: "ses_test", Text: "please check the retry loop"}
+	if err := database.AddComment(top); err != nil {
+		t.Fatal(err)
+	}
+	if err := database.AddComment(&models.Comment{IssueID: issue.ID, SessionID: "ses_other", Text: "backoff added", ParentCommentID: top.ID}); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := Execute(database, `comment.text ~ "backoff"`, "ses_test", ExecuteOptions{})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(results) != 1 || results[0].ID != issue.ID {
+		t.Errorf("comment.text on reply = %v, want %s", idSet(results), issue.ID)
+	}
+}
+
 func TestExecuteHandoffCrossEntity(t *testing.T) {
 	database := setupTestDB(t)
 	defer database.Close()
@@ -1198,6 +1280,117 @@ func TestExecuteFileCrossEntity(t *testing.T) {
 	})
 }
 
+func TestExecuteHasAttachmentFunctions(t *testing.T) {
+	database := setupTestDB(t)
+	defer database.Close()
+
+	withComment := createTestIssue(t, database, "", "Has comment", models.StatusOpen, models.TypeTask, models.PriorityP1)
+	withHandoff := createTestIssue(t, database, "", "Has handoff", models.StatusOpen, models.TypeTask, models.PriorityP2)
+	withFile := createTestIssue(t, database, "", "Has file", models.StatusOpen, models.TypeTask, models.PriorityP2)
+	bare := createTestIssue(t, database, "", "Nothing attached", models.StatusInProgress, models.TypeTask, models.PriorityP3)
+
+	if err := database.AddComment(&models.Comment{IssueID: withComment.ID, SessionID: "ses_a", Text: "looks good"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := database.AddHandoff(&models.Handoff{IssueID: withHandoff.ID, SessionID: "ses_a", Done: []string{"step one"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := database.LinkFile(withFile.ID, "cmd/main.go", models.FileRoleImplementation, "abc123"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		query string
+		want  map[string]bool
+	}{
+		{"has_comments()", map[string]bool{withComment.ID: true}},
+		{"has_handoff()", map[string]bool{withHandoff.ID: true}},
+		{"has_files()", map[string]bool{withFile.ID: true}},
+		{"NOT has_comments()", map[string]bool{withHandoff.ID: true, withFile.ID: true, bare.ID: true}},
+		{"NOT has_handoff()", map[string]bool{withComment.ID: true, withFile.ID: true, bare.ID: true}},
+		{"NOT has_files()", map[string]bool{withComment.ID: true, withHandoff.ID: true, bare.ID: true}},
+		{"NOT has_comments() AND NOT has_handoff() AND NOT has_files()", map[string]bool{bare.ID: true}},
+		{"has_comments() OR has_files()", map[string]bool{withComment.ID: true, withFile.ID: true}},
+		{"NOT has_files() AND status = in_progress", map[string]bool{bare.ID: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			results, err := Execute(database, tt.query, "ses_test", ExecuteOptions{})
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			got := idSet(results)
+			if !equalSets(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecuteMine(t *testing.T) {
+	database := setupTestDB(t)
+	defer database.Close()
+
+	ids := make(map[string]string)
+	for _, tc := range []struct {
+		name, session, creator string
+		status                 models.Status
+	}{
+		{"alice-active", "ses_alice", "", models.StatusInProgress},
+		{"alice-review", "ses_alice", "", models.StatusInReview},
+		{"bob-active", "ses_bob", "", models.StatusInProgress},
+		{"alice-old-active", "ses_alice_old", "", models.StatusInProgress},
+		{"unclaimed", "", "", models.StatusOpen},
+		{"alice-filed", "", "ses_alice", models.StatusOpen},
+		{"alice-old-filed", "", "ses_alice_old", models.StatusOpen},
+	} {
+		issue := createTestIssue(t, database, "", tc.name, tc.status, models.TypeTask, models.PriorityP2)
+		issue.ImplementerSession = tc.session
+		issue.CreatorSession = tc.creator
+		if err := database.UpdateIssue(issue); err != nil {
+			t.Fatal(err)
+		}
+		ids[tc.name] = issue.ID
+	}
+	want := func(names ...string) map[string]bool {
+		m := make(map[string]bool)
+		for _, n := range names {
+			m[ids[n]] = true
+		}
+		return m
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		session    string
+		identities []string
+		want       map[string]bool
+	}{
+		{"alice", "mine()", "ses_alice", nil, want("alice-active", "alice-review", "alice-filed")},
+		{"bob", "mine()", "ses_bob", nil, want("bob-active")},
+		{"no claims", "mine()", "ses_carol", nil, want()},
+		{"empty session matches nothing", "mine()", "", nil, want()},
+		{"with status filter", "mine() AND status = in_progress", "ses_alice", nil, want("alice-active")},
+		{"identity sessions", "mine()", "ses_alice", []string{"ses_alice_old"}, want("alice-active", "alice-review", "alice-filed", "alice-old-active", "alice-old-filed")},
+		{"NOT mine()", "NOT mine() AND status = in_progress", "ses_alice", nil, want("bob-active", "alice-old-active")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := Execute(database, tt.query, tt.session, ExecuteOptions{Identities: tt.identities})
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			got := idSet(results)
+			if !equalSets(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestExecuteMixedCrossEntityOR(t *testing.T) {
 	database := setupTestDB(t)
 	defer database.Close()
@@ -1480,3 +1673,317 @@ func TestMain(m *testing.M) {
 	code := m.Run()
 	os.Exit(code)
 }
+
+func TestHierarchyCountFields(t *testing.T) {
+	database := setupTestDB(t)
+	defer database.Close()
+
+	mk := func(title string, typ models.Type, parent string) string {
+		t.Helper()
+		issue := &models.Issue{Title: title, Status: models.StatusOpen, Type: typ, Priority: models.PriorityP2, ParentID: parent}
+		if err := database.CreateIssue(issue); err != nil {
+			t.Fatalf("CreateIssue(%s) failed: %v", title, err)
+		}
+		return issue.ID
+	}
+
+	// big epic: 3 children, one of which has 2 children of its own
+	big := mk("Big epic", models.TypeEpic, "")
+	small := mk("Small epic", models.TypeEpic, "")
+	c1 := mk("Child 1", models.TypeTask, big)
+	mk("Child 2", models.TypeTask, big)
+	mk("Child 3", models.TypeTask, big)
+	mk("Grandchild 1", models.TypeTask, c1)
+	mk("Grandchild 2", models.TypeTask, c1)
+	mk("Small child", models.TypeTask, small)
+	gone := mk("Deleted child", models.TypeTask, small)
+	if err := database.DeleteIssue(gone); err != nil {
+		t.Fatalf("DeleteIssue failed: %v", err)
+	}
+
+	tests := []struct {
+		query string
+		want  map[string]bool
+	}{
+		{"child_count = 3", map[string]bool{big: true}},
+		{"child_count = 1", map[string]bool{small: true}},
+		{"descendant_count = 5", map[string]bool{big: true}},
+		{"descendant_count > 1", map[string]bool{big: true, c1: true}},
+		{"descendant_count >= 1 AND type = epic", map[string]bool{big: true, small: true}},
+		{"child_count > 0 AND child_count < 3", map[string]bool{small: true, c1: true}},
+		{"type = epic AND descendant_count <= 2", map[string]bool{small: true}},
+		{"NOT child_count > 0 AND type = epic", map[string]bool{}},
+		{"child_count != 0 AND type = task", map[string]bool{c1: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			results, err := Execute(database, tt.query, "ses_test", ExecuteOptions{})
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if got := idSet(results); !equalSets(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecuteCreatedBy(t *testing.T) {
+	database := setupTestDB(t)
+	defer database.Close()
+
+	mk := func(title, createdBy string) *models.Issue {
+		issue := &models.Issue{Title: title, CreatedBy: createdBy}
+		if err := database.CreateIssueLogged(issue, "ses_default"); err != nil {
+			t.Fatalf("create %s: %v", title, err)
+		}
+		return issue
+	}
+	alice := mk("alice bug", "alice")
+	bob := mk("bob task", "bob")
+	anon := mk("unnamed session task", "")
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{`created_by = "alice"`, []string{alice.ID}},
+		{`created_by != "alice"`, []string{bob.ID, anon.ID}},
+		{`created_by = "ses_default"`, []string{anon.ID}},
+		{`created_by ~ "b"`, []string{bob.ID}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			results, err := Execute(database, tt.query, "ses_default", ExecuteOptions{})
+			if err != nil {
+				t.Fatalf("Execute(%q): %v", tt.query, err)
+			}
+			want := make(map[string]bool, len(tt.want))
+			for _, id := range tt.want {
+				want[id] = true
+			}
+			if got := idSet(results); !equalSets(got, want) {
+				t.Errorf("Execute(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecuteDependencyDirection(t *testing.T) {
+	database := setupTestDB(t)
+	defer database.Close()
+
+	// api depends on schema; ui depends on api and schema. done is closed
+	// and ui also depends on it.
+	schema := createTestIssue(t, database, "", "Schema", models.StatusOpen, models.TypeTask, models.PriorityP1)
+	api := createTestIssue(t, database, "", "API", models.StatusOpen, models.TypeTask, models.PriorityP1)
+	ui := createTestIssue(t, database, "", "UI", models.StatusOpen, models.TypeTask, models.PriorityP2)
+	done := createTestIssue(t, database, "", "Done", models.StatusClosed, models.TypeTask, models.PriorityP2)
+	for _, d := range [][2]string{{api.ID, schema.ID}, {ui.ID, api.ID}, {ui.ID, schema.ID}, {ui.ID, done.ID}} {
+		if err := database.AddDependency(d[0], d[1], "depends_on"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"depends_on(" + schema.ID + ")", []string{api.ID, ui.ID}},
+		{"depends_on(" + api.ID + ")", []string{ui.ID}},
+		{"depends_on(" + ui.ID + ")", nil},
+		{"dependency_of(" + ui.ID + ")", []string{api.ID, schema.ID, done.ID}},
+		{"dependency_of(" + api.ID + ")", []string{schema.ID}},
+		{"dependency_of(" + schema.ID + ")", nil},
+		// blocked_by and blocks are the same edges under their older names
+		{"blocked_by(" + schema.ID + ")", []string{api.ID, ui.ID}},
+		{"blocks(" + ui.ID + ")", []string{api.ID, schema.ID, done.ID}},
+		// Ready means nothing open is a dependency_of the issue
+		{"is_ready() AND status != closed", []string{schema.ID}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			results, err := Execute(database, tt.query, "ses_test", ExecuteOptions{})
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			want := make(map[string]bool)
+			for _, id := range tt.want {
+				want[id] = true
+			}
+			if got := idSet(results); !equalSets(got, want) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestExecuteUpdatedBySession(t *testing.T) {
+	database := setupTestDB(t)
+	defer database.Close()
+
+	changed := createTestIssue(t, database, "", "Changed", models.StatusOpen, models.TypeTask, models.PriorityP1)
+	logged := createTestIssue(t, database, "", "Logged", models.StatusOpen, models.TypeTask, models.PriorityP1)
+	undone := createTestIssue(t, database, "", "Undone", models.StatusOpen, models.TypeTask, models.PriorityP1)
+	other := createTestIssue(t, database, "", "Other", models.StatusOpen, models.TypeTask, models.PriorityP1)
+
+	// A status change without a progress log
+	changed.Status = models.StatusInProgress
+	if err := database.UpdateIssueLogged(changed, "ses_x", models.ActionStart); err != nil {
+		t.Fatal(err)
+	}
+	// A progress log without any change
+	if err := database.AddLog(&models.Log{IssueID: logged.ID, SessionID: "ses_x", Message: "looked into it", Type: models.LogTypeProgress}); err != nil {
+		t.Fatal(err)
+	}
+	// A change that was undone
+	undone.Priority = models.PriorityP3
+	if err := database.UpdateIssueLogged(undone, "ses_x", models.ActionUpdate); err != nil {
+		t.Fatal(err)
+	}
+	last, err := database.GetLastAction("ses_x")
+	if err != nil || last == nil {
+		t.Fatalf("GetLastAction() = %v, %v", last, err)
+	}
+	if err := database.MarkActionUndone(last.ID); err != nil {
+		t.Fatal(err)
+	}
+	// Another session's change
+	other.Priority = models.PriorityP0
+	if err := database.UpdateIssueLogged(other, "ses_y", models.ActionUpdate); err != nil {
+		t.Fatal(err)
+	}
+
+	// The log-based view only sees the logged issue
+	touched, err := database.GetIssueSessionLog("ses_x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(touched) != 1 || touched[0] != logged.ID {
+		t.Errorf("GetIssueSessionLog() = %v, want [%s]", touched, logged.ID)
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{`updated_by_session("ses_x")`, []string{changed.ID}},
+		{`updated_by_session("ses_y")`, []string{other.ID}},
+		{`updated_by_session("ses_none")`, nil},
+		{`updated_by_session("ses_x") OR updated_by_session("ses_y")`, []string{changed.ID, other.ID}},
+		{`NOT updated_by_session("ses_x")`, []string{logged.ID, undone.ID, other.ID}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			results, err := Execute(database, tt.query, "ses_test", ExecuteOptions{})
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			want := make(map[string]bool)
+			for _, id := range tt.want {
+				want[id] = true
+			}
+			if got := idSet(results); !equalSets(got, want) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestIsActionableFunction(t *testing.T) {
+	database := setupTestDB(t)
+
+	create := func(title string, status models.Status, typ models.Type, parentID string) *models.Issue {
+		t.Helper()
+		issue := &models.Issue{Title: title, Status: status, Type: typ, Priority: models.PriorityP2, ParentID: parentID}
+		if err := database.CreateIssue(issue); err != nil {
+			t.Fatalf("failed to create %s: %v", title, err)
+		}
+		return issue
+	}
+
+	blockedEpic := create("Blocked epic", models.StatusBlocked, models.TypeEpic, "")
+	unstartedEpic := create("Unstarted epic", models.StatusOpen, models.TypeEpic, "")
+	activeEpic := create("Active epic", models.StatusInProgress, models.TypeEpic, "")
+	subEpic := create("Sub-epic of blocked epic", models.StatusInProgress, models.TypeEpic, blockedEpic.ID)
+
+	blockedChild := create("Child of blocked epic", models.StatusOpen, models.TypeTask, blockedEpic.ID)
+	grandchild := create("Grandchild of blocked epic", models.StatusOpen, models.TypeTask, subEpic.ID)
+	unstartedChild := create("Child of unstarted epic", models.StatusOpen, models.TypeTask, unstartedEpic.ID)
+	activeChild := create("Child of active epic", models.StatusOpen, models.TypeTask, activeEpic.ID)
+	standalone := create("Standalone", models.StatusOpen, models.TypeTask, "")
+
+	run := func(q string) map[string]bool {
+		t.Helper()
+		results, err := Execute(database, q, "ses_test", ExecuteOptions{})
+		if err != nil {
+			t.Fatalf("Execute(%q) error = %v", q, err)
+		}
+		ids := make(map[string]bool)
+		for _, r := range results {
+			ids[r.ID] = true
+		}
+		return ids
+	}
+
+	ready := run("is_ready() AND type = task")
+	actionable := run("is_actionable() AND type = task")
+
+	for _, issue := range []*models.Issue{blockedChild, grandchild, unstartedChild, activeChild, standalone} {
+		if !ready[issue.ID] {
+			t.Errorf("is_ready() should include %q", issue.Title)
+		}
+	}
+	for _, issue := range []*models.Issue{blockedChild, grandchild, unstartedChild} {
+		if actionable[issue.ID] {
+			t.Errorf("is_actionable() should exclude %q", issue.Title)
+		}
+	}
+	for _, issue := range []*models.Issue{activeChild, standalone} {
+		if !actionable[issue.ID] {
+			t.Errorf("is_actionable() should include %q", issue.Title)
+		}
+	}
+
+	// Open dependencies still rule an issue out
+	if err := database.AddDependency(activeChild.ID, standalone.ID, "depends_on"); err != nil {
+		t.Fatalf("failed to add dependency: %v", err)
+	}
+	if run("is_actionable()")[activeChild.ID] {
+		t.Error("is_actionable() should exclude issues with open dependencies")
+	}
+
+	// Unblocking the epic frees its whole subtree
+	blockedEpic.Status = models.StatusInProgress
+	if err := database.UpdateIssue(blockedEpic); err != nil {
+		t.Fatalf("failed to update epic: %v", err)
+	}
+	actionable = run("is_actionable()")
+	if !actionable[blockedChild.ID] || !actionable[grandchild.ID] {
+		t.Error("is_actionable() should include children once the epic is in progress")
+	}
+}
+
+func TestExecuteCustomStatuses(t *testing.T) {
+	database := setupTestDB(t)
+	defer database.Close()
+
+	issue := createTestIssue(t, database, "", "In QA", models.StatusOpen, models.TypeTask, models.PriorityP2)
+	issue.Status = "qa"
+	if err := database.UpdateIssue(issue); err != nil {
+		t.Fatal(err)
+	}
+	createTestIssue(t, database, "", "Open", models.StatusOpen, models.TypeTask, models.PriorityP2)
+
+	// Only a project whose workflow has qa can query it
+	if _, err := Execute(database, "status = qa", "", ExecuteOptions{}); err == nil {
+		t.Error("status = qa without the custom status succeeded, want validation error")
+	}
+	results, err := Execute(database, "status = qa", "", ExecuteOptions{Statuses: []models.Status{"qa"}})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(results) != 1 || results[0].ID != issue.ID {
+		t.Errorf("results = %v, want only %s", idSet(results), issue.ID)
+	}
+}
@@ -130,7 +130,13 @@ func (l *Lexer) Tokenize() ([]Token, error) {
 			break
 		}
 		if tok.Type == TokenError {
-			return l.tokens, fmt.Errorf("lexer error at line %d, column %d: %s", tok.Line, tok.Column, tok.Value)
+			return l.tokens, &ParseError{
+				Message: tok.Value,
+				Pos:     tok.Pos,
+				Line:    tok.Line,
+				Column:  tok.Column,
+				Token:   tok,
+			}
 		}
 	}
 	return l.tokens, nil
@@ -4,8 +4,10 @@ package query
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/marcus/td/internal/models"
 )
@@ -24,24 +26,58 @@ type Parser struct {
 // ParseError represents a parsing error with position information
 type ParseError struct {
 	Message  string
-	Pos      int
+	Pos      int // byte offset into Input
 	Line     int
 	Column   int
 	Token    Token
 	Expected string
+	Input    string // query text the error refers to, set by Parse
 }
 
 func (e *ParseError) Error() string {
 	if e.Expected != "" {
-		return fmt.Sprintf("parse error at line %d, column %d: %s (expected %s, got %s)",
-			e.Line, e.Column, e.Message, e.Expected, e.Token.String())
+		return fmt.Sprintf("parse error at line %d, column %d (offset %d): %s (expected %s, got %s)",
+			e.Line, e.Column, e.Pos, e.Message, e.Expected, e.Token.String())
 	}
-	return fmt.Sprintf("parse error at line %d, column %d: %s", e.Line, e.Column, e.Message)
+	return fmt.Sprintf("parse error at line %d, column %d (offset %d): %s", e.Line, e.Column, e.Pos, e.Message)
 }
 
-// Parse parses a TDQ query string and returns a Query AST
+// Snippet returns the offending line of the query with a caret under the
+// problem token, or "" when the input is unknown.
+func (e *ParseError) Snippet() string {
+	if e.Input == "" {
+		return ""
+	}
+	pos := e.Pos
+	if pos < 0 {
+		pos = 0
+	}
+	if pos > len(e.Input) {
+		pos = len(e.Input)
+	}
+	start := strings.LastIndexByte(e.Input[:pos], '\n') + 1
+	end := strings.IndexByte(e.Input[pos:], '\n')
+	if end < 0 {
+		end = len(e.Input)
+	} else {
+		end += pos
+	}
+	pad := utf8.RuneCountInString(e.Input[start:pos])
+	return e.Input[start:end] + "\n" + strings.Repeat(" ", pad) + "^"
+}
+
+// Parse parses a TDQ query string and returns a Query AST. Syntax errors are
+// returned as *ParseError carrying the query text for Snippet.
 func Parse(input string) (*Query, error) {
 	input = strings.TrimSpace(input)
+	q, err := parse(input)
+	if pe, ok := err.(*ParseError); ok {
+		pe.Input = input
+	}
+	return q, err
+}
+
+func parse(input string) (*Query, error) {
 	if input == "" {
 		return &Query{Root: nil, Raw: input}, nil
 	}
@@ -578,33 +614,40 @@ func isRelativeDate(s string) bool {
 	return false
 }
 
-// Validate checks the query AST for semantic errors
+// Validate checks the query AST for semantic errors, accepting only the
+// built-in statuses
 func (q *Query) Validate() []error {
+	return q.ValidateWithStatuses(nil)
+}
+
+// ValidateWithStatuses is Validate for a project whose workflow adds the
+// custom statuses to the built-in ones.
+func (q *Query) ValidateWithStatuses(custom []models.Status) []error {
 	if q.Root == nil {
 		return nil
 	}
 	var errs []error
-	validateNode(q.Root, &errs)
+	validateNode(q.Root, custom, &errs)
 	return errs
 }
 
-func validateNode(n Node, errs *[]error) {
+func validateNode(n Node, custom []models.Status, errs *[]error) {
 	switch node := n.(type) {
 	case *BinaryExpr:
-		validateNode(node.Left, errs)
-		validateNode(node.Right, errs)
+		validateNode(node.Left, custom, errs)
+		validateNode(node.Right, custom, errs)
 	case *UnaryExpr:
-		validateNode(node.Expr, errs)
+		validateNode(node.Expr, custom, errs)
 	case *FieldExpr:
-		validateFieldExpr(node, errs)
+		validateFieldExpr(node, custom, errs)
 	case *FunctionCall:
-		validateFunctionCall(node, errs)
+		validateFunctionCall(node, custom, errs)
 	case *TextSearch:
 		// Text search is always valid
 	}
 }
 
-func validateFieldExpr(f *FieldExpr, errs *[]error) {
+func validateFieldExpr(f *FieldExpr, custom []models.Status, errs *[]error) {
 	// Check if field is known
 	parts := strings.Split(f.Field, ".")
 	baseName := parts[0]
@@ -631,17 +674,17 @@ func validateFieldExpr(f *FieldExpr, errs *[]error) {
 	// Validate enum values
 	if enumVals, ok := EnumValues[f.Field]; ok {
 		if strVal, ok := f.Value.(string); ok {
-			if normalized, ok := normalizeEnumValue(f.Field, strVal); ok {
+			if normalized, ok := normalizeEnumValue(f.Field, strVal, custom); ok {
 				f.Value = normalized // normalize to canonical form
 			} else {
 				*errs = append(*errs, fmt.Errorf("invalid value for %s: %q (expected one of: %s)",
-					f.Field, strVal, strings.Join(enumVals, ", ")))
+					f.Field, strVal, strings.Join(enumValuesFor(f.Field, enumVals, custom), ", ")))
 			}
 		}
 	}
 }
 
-func validateFunctionCall(fn *FunctionCall, errs *[]error) {
+func validateFunctionCall(fn *FunctionCall, custom []models.Status, errs *[]error) {
 	spec, ok := KnownFunctions[fn.Name]
 	if !ok {
 		*errs = append(*errs, fmt.Errorf("unknown function: %s", fn.Name))
@@ -665,7 +708,7 @@ func validateFunctionCall(fn *FunctionCall, errs *[]error) {
 		// is(status) - single arg is a status enum value
 		if len(fn.Args) >= 1 {
 			if strVal, ok := fn.Args[0].(string); ok {
-				if normalized, ok := normalizeEnumValue("status", strVal); ok {
+				if normalized, ok := normalizeEnumValue("status", strVal, custom); ok {
 					fn.Args[0] = normalized
 				}
 			}
@@ -677,7 +720,7 @@ func validateFunctionCall(fn *FunctionCall, errs *[]error) {
 			if _, ok := EnumValues[fieldName]; ok {
 				for i := 1; i < len(fn.Args); i++ {
 					if strVal, ok := fn.Args[i].(string); ok {
-						if normalized, ok := normalizeEnumValue(fieldName, strVal); ok {
+						if normalized, ok := normalizeEnumValue(fieldName, strVal, custom); ok {
 							fn.Args[i] = normalized
 						}
 					}
@@ -687,11 +730,24 @@ func validateFunctionCall(fn *FunctionCall, errs *[]error) {
 	}
 }
 
-func normalizeEnumValue(field, value string) (string, bool) {
+// enumValuesFor returns the values an enum field accepts: its EnumValues,
+// plus the project's custom workflow statuses for status.
+func enumValuesFor(field string, enumVals []string, custom []models.Status) []string {
+	if field != "status" {
+		return enumVals
+	}
+	vals := append([]string(nil), enumVals...)
+	for _, s := range custom {
+		vals = append(vals, string(s))
+	}
+	return vals
+}
+
+func normalizeEnumValue(field, value string, custom []models.Status) (string, bool) {
 	switch field {
 	case "status":
 		normalized := models.NormalizeStatus(strings.ToLower(value))
-		if models.IsValidStatus(normalized) {
+		if models.IsValidStatus(normalized) || slices.Contains(custom, normalized) {
 			return string(normalized), true
 		}
 	case "type":
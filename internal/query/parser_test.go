@@ -1,6 +1,8 @@
 package query
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -536,3 +538,85 @@ func TestMultipleSortClauses(t *testing.T) {
 		t.Error("expected error for multiple sort clauses, got nil")
 	}
 }
+
+func TestParseErrorPosition(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantOffset int
+		wantCaret  string
+	}{
+		{
+			name:       "unclosed paren",
+			input:      "status = open AND (type = bug",
+			wantOffset: 29,
+			wantCaret:  "                             ^",
+		},
+		{
+			name:       "bad special value",
+			input:      "type @ bug",
+			wantOffset: 5,
+			wantCaret:  "     ^",
+		},
+		{
+			name:       "trailing operator",
+			input:      "status =",
+			wantOffset: 8,
+			wantCaret:  "        ^",
+		},
+		{
+			name:       "unterminated string",
+			input:      `title ~ "login`,
+			wantOffset: 8,
+			wantCaret:  "        ^",
+		},
+		{
+			name:       "error on second line",
+			input:      "status = open\nAND AND type = bug",
+			wantOffset: 18,
+			wantCaret:  "    ^",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.input)
+			if err == nil {
+				t.Fatal("expected parse error")
+			}
+			pe, ok := err.(*ParseError)
+			if !ok {
+				t.Fatalf("error type = %T, want *ParseError", err)
+			}
+			if pe.Pos != tt.wantOffset {
+				t.Errorf("Pos = %d, want %d", pe.Pos, tt.wantOffset)
+			}
+			if !strings.Contains(err.Error(), fmt.Sprintf("offset %d", tt.wantOffset)) {
+				t.Errorf("Error() = %q, want offset %d", err.Error(), tt.wantOffset)
+			}
+			lines := strings.Split(pe.Snippet(), "\n")
+			if len(lines) != 2 || lines[1] != tt.wantCaret {
+				t.Errorf("Snippet() = %q, want caret line %q", pe.Snippet(), tt.wantCaret)
+			}
+		})
+	}
+}
+
+func TestQueryExplain(t *testing.T) {
+	q, err := Parse(`status = open AND (type = bug OR NOT has(labels)) AND "login" sort:-priority`)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	want := `AND
+  field   status = open
+  OR
+    field   type = bug
+    NOT
+      func    has(labels)
+  search  "login"
+sort:-priority
+`
+	if got := q.Explain(); got != want {
+		t.Errorf("Explain() =\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -15,8 +15,14 @@ type QuerySource interface {
 	GetLatestHandoff(issueID string) (*models.Handoff, error)
 	GetLinkedFiles(issueID string) ([]models.IssueFile, error)
 	GetDependencies(issueID string) ([]string, error)
+	GetRelated(issueID string) ([]string, error)
 	GetRejectedInProgressIssueIDs() (map[string]bool, error)
 	GetIssuesWithOpenDeps() (map[string]bool, error)
+	GetIssuesWithComments() (map[string]bool, error)
+	GetIssuesWithHandoffs() (map[string]bool, error)
+	GetIssuesWithFiles() (map[string]bool, error)
+	GetIssuesUpdatedBySession(sessionID string) (map[string]bool, error)
+	GetParentIDs() (map[string]string, error)
 }
 
 // NoteQuerySource abstracts note-related database operations for TDQ note queries.
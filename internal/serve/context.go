@@ -3,7 +3,9 @@ package serve
 import (
 	"github.com/marcus/td/internal/config"
 	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/models"
 	"github.com/marcus/td/internal/workdir"
+	"github.com/marcus/td/internal/workflow"
 )
 
 // HandlerConfig carries optional, request-scoped configuration consumed by
@@ -77,6 +79,17 @@ func worktreeIDForBaseDir(baseDir string) string {
 	return wt.WorktreeID
 }
 
+// customStatusesFor returns the custom workflow statuses of the project at
+// ctx.BaseDir. Without an on-disk td root, or with an invalid workflow
+// config, there are none.
+func customStatusesFor(ctx HandlerContext) []models.Status {
+	if ctx.BaseDir == "" {
+		return nil
+	}
+	sm, _ := workflow.ForProject(ctx.BaseDir)
+	return sm.CustomStatuses()
+}
+
 // titleLengthLimitsFor resolves the effective min/max title length for a
 // handler context. When ctx.Config.TitleMin/TitleMax are zero (e.g. td-sync
 // callers that don't carry per-project title rules), it falls back to the
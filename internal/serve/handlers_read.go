@@ -3,6 +3,7 @@ package serve
 import (
 	"log/slog"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -53,7 +54,7 @@ func HandleMonitor(ctx HandlerContext, w http.ResponseWriter, r *http.Request) {
 	includeClosed := q.Get("include_closed") == "true"
 	sortMode := monitor.SortModeFromString(q.Get("sort"))
 	search := q.Get("search")
-	searchMode := q.Get("search_mode") // auto, text, tdq
+	searchMode := q.Get("search_mode") // auto, text, tdq, content
 
 	// For search_mode=tdq, validate the query first
 	if searchMode == "tdq" && search != "" {
@@ -129,7 +130,7 @@ func HandleListIssues(ctx HandlerContext, w http.ResponseWriter, r *http.Request
 	}
 
 	// Parse filters
-	statuses := parseStatusParams(q["status"])
+	statuses := parseStatusParams(q["status"], customStatusesFor(ctx))
 	types := parseTypeParams(q["type"])
 	priorities := q["priority"]
 	labels := parseStringParams(q["labels"])
@@ -487,7 +488,7 @@ func HandleGetBoard(ctx HandlerContext, w http.ResponseWriter, r *http.Request)
 	if board.Query != "" {
 		// Execute TDQ query with neutral @me behavior
 		// Pass empty session ID to neutralize @me clauses
-		queryResults, err := query.Execute(ctx.DB, board.Query, "", query.ExecuteOptions{})
+		queryResults, err := query.Execute(ctx.DB, board.Query, "", query.ExecuteOptions{Statuses: customStatusesFor(ctx)})
 		if err != nil {
 			WriteError(w, ErrInternal, "board query error: "+err.Error(), http.StatusInternalServerError)
 			return
@@ -662,8 +663,9 @@ func buildDependencySummaries(ctx HandlerContext, cardIDs []string) map[string]*
 }
 
 // parseStatusParams converts repeated query params like ?status=open&status=closed
-// into a slice of models.Status values.
-func parseStatusParams(values []string) []models.Status {
+// into a slice of models.Status values, accepting the custom workflow statuses
+// alongside the built-in ones.
+func parseStatusParams(values []string, custom []models.Status) []models.Status {
 	var statuses []models.Status
 	for _, v := range values {
 		// Support comma-separated within a single param
@@ -673,7 +675,7 @@ func parseStatusParams(values []string) []models.Status {
 				continue
 			}
 			status := models.NormalizeStatus(part)
-			if models.IsValidStatus(status) {
+			if models.IsValidStatus(status) || slices.Contains(custom, status) {
 				statuses = append(statuses, status)
 			}
 		}
@@ -750,9 +752,10 @@ func resolveSortOptions(sortBy, order string) (string, bool) {
 }
 
 // tryTDQSearch attempts a TDQ search and returns issues or an error. Reads
-// from ctx.DB and uses ctx.SessionID for @me resolution.
+// from ctx.DB and uses ctx.SessionID for @me and mine() resolution.
 func tryTDQSearch(ctx HandlerContext, search, searchMode string, statuses []models.Status) ([]models.Issue, error) {
-	issues, err := query.Execute(ctx.DB, search, ctx.SessionID, query.ExecuteOptions{})
+	identities, _ := session.IdentitySessionIDsByID(ctx.DB, ctx.SessionID)
+	issues, err := query.Execute(ctx.DB, search, ctx.SessionID, query.ExecuteOptions{Identities: identities, Statuses: customStatusesFor(ctx)})
 	if err != nil {
 		return nil, err
 	}
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/marcus/td/internal/config"
 	"github.com/marcus/td/internal/features"
 	"github.com/marcus/td/internal/models"
 	"github.com/marcus/td/internal/reviewpolicy"
@@ -215,7 +216,7 @@ func availableTransitionsFor(ctx HandlerContext, issue *models.Issue) []string {
 	if issue == nil {
 		return actions
 	}
-	sm := workflow.DefaultMachine()
+	sm, _ := workflow.ForProject(ctx.BaseDir)
 	status := issue.Status
 
 	// consider appends name when the issue's status is a valid source for the
@@ -296,6 +297,9 @@ type transitionSpec struct {
 	// Used to write issue_reviews rows for approve so audit output records
 	// the reviewer independently of the closer.
 	postCommit func(ctx HandlerContext, issue *models.Issue)
+	// reasonPolicy names the action ("close", "reject") whose reason is
+	// mandatory when the project sets require_close_reason.
+	reasonPolicy string
 }
 
 // handleTransition is the common handler for all status transition endpoints.
@@ -322,7 +326,7 @@ func handleTransition(ctx HandlerContext, w http.ResponseWriter, r *http.Request
 	canonicalIssueID := issue.ID
 
 	// Validate current status against allowed "from" statuses using state machine
-	sm := workflow.DefaultMachine()
+	sm, _ := workflow.ForProject(ctx.BaseDir)
 	if !sm.IsValidTransition(issue.Status, spec.toStatus) {
 		WriteError(w, ErrConflict,
 			fmt.Sprintf("cannot transition %s from %s to %s", canonicalIssueID, issue.Status, spec.toStatus),
@@ -362,6 +366,15 @@ func handleTransition(ctx HandlerContext, w http.ResponseWriter, r *http.Request
 		}
 	}
 
+	if spec.reasonPolicy != "" && ctx.BaseDir != "" && strings.TrimSpace(reason) == "" {
+		if required, _ := config.GetRequireCloseReason(ctx.BaseDir); required {
+			WriteError(w, ErrValidation,
+				fmt.Sprintf("a reason is required to %s issues in this project (require_close_reason)", spec.reasonPolicy),
+				http.StatusBadRequest)
+			return
+		}
+	}
+
 	// Apply the transition
 	issue.Status = spec.toStatus
 	if spec.applySideEffects != nil {
@@ -750,6 +763,7 @@ func HandleReject(ctx HandlerContext, w http.ResponseWriter, r *http.Request) {
 			_ = c.DB.SupersedeActiveReviews(issue.ID)
 		},
 		defaultLogMsg: "Rejected",
+		reasonPolicy:  "reject",
 	})
 }
 
@@ -837,6 +851,7 @@ func HandleClose(ctx HandlerContext, w http.ResponseWriter, r *http.Request) {
 			return cr
 		},
 		defaultLogMsg: "Closed",
+		reasonPolicy:  "close",
 	})
 }
 
@@ -124,6 +124,7 @@ type IssueDTO struct {
 	Sprint                   string   `json:"sprint"`
 	ImplementerSession       *string  `json:"implementer_session"`
 	CreatorSession           *string  `json:"creator_session"`
+	CreatedBy                *string  `json:"created_by"`
 	ReviewerSession          *string  `json:"reviewer_session"`
 	ReviewRequestedBySession *string  `json:"review_requested_by_session"`
 	ClosedBySession          *string  `json:"closed_by_session"`
@@ -210,6 +211,7 @@ func IssueToDTO(issue *models.Issue) IssueDTO {
 	dto.ParentID = nullableString(issue.ParentID)
 	dto.ImplementerSession = nullableString(issue.ImplementerSession)
 	dto.CreatorSession = nullableString(issue.CreatorSession)
+	dto.CreatedBy = nullableString(issue.CreatedBy)
 	dto.ReviewerSession = nullableString(issue.ReviewerSession)
 	dto.ReviewRequestedBySession = nullableString(issue.ReviewRequestedBySession)
 	dto.ClosedBySession = nullableString(issue.ClosedBySession)
@@ -370,6 +370,7 @@ func (s *Server) autoSyncOnce() {
 	apiKey := syncconfig.GetAPIKey()
 	client := syncclient.New(serverURL, apiKey, deviceID)
 	client.HTTP.Timeout = 5 * time.Second
+	client.PushBatchSize = syncconfig.GetPushBatchSize()
 
 	sess, err := session.Get(s.db)
 	if err != nil {
@@ -134,7 +134,7 @@ func (db *ServerDB) AdminListProjectMembers(projectID string) ([]AdminProjectMem
 // ListSyncCursorsForProject returns all sync cursors for a project.
 func (db *ServerDB) ListSyncCursorsForProject(projectID string) ([]SyncCursor, error) {
 	rows, err := db.conn.Query(
-		`SELECT project_id, client_id, last_event_id, last_sync_at FROM sync_cursors WHERE project_id = ? ORDER BY client_id`,
+		`SELECT `+syncCursorColumns+` FROM sync_cursors WHERE project_id = ? ORDER BY client_id`,
 		projectID,
 	)
 	if err != nil {
@@ -145,7 +145,7 @@ func (db *ServerDB) ListSyncCursorsForProject(projectID string) ([]SyncCursor, e
 	var cursors []SyncCursor
 	for rows.Next() {
 		var c SyncCursor
-		if err := rows.Scan(&c.ProjectID, &c.ClientID, &c.LastEventID, &c.LastSyncAt); err != nil {
+		if err := rows.Scan(&c.ProjectID, &c.ClientID, &c.LastEventID, &c.LastSyncAt, &c.UserID, &c.LastPulledSeq, &c.LastPulledAt); err != nil {
 			return nil, fmt.Errorf("scan cursor: %w", err)
 		}
 		cursors = append(cursors, c)
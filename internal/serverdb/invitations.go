@@ -33,6 +33,10 @@ type Invitation struct {
 	CreatedAt  time.Time
 	ExpiresAt  time.Time
 	AcceptedAt *time.Time
+	// AutoAccept marks an invite created by email alone. It is claimed
+	// automatically when the invited email registers instead of being
+	// accepted with a token.
+	AutoAccept bool
 }
 
 func normalizeEmail(email string) string {
@@ -54,6 +58,7 @@ func scanInvitation(row interface {
 		&inv.CreatedAt,
 		&inv.ExpiresAt,
 		&inv.AcceptedAt,
+		&inv.AutoAccept,
 	)
 	if err != nil {
 		return nil, err
@@ -62,9 +67,35 @@ func scanInvitation(row interface {
 }
 
 const invitationSelectCols = `
-	id, project_id, email, role, invited_by, token_hash, status, created_at, expires_at, accepted_at`
+	id, project_id, email, role, invited_by, token_hash, status, created_at, expires_at, accepted_at, auto_accept`
+
+// execQuerier is satisfied by both *sql.DB and *sql.Tx.
+type execQuerier interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
 
 func (db *ServerDB) CreateInvitation(projectID, email, role, invitedBy, tokenHash string, expiresAt time.Time) (*Invitation, error) {
+	return insertInvitation(db.conn, projectID, email, role, invitedBy, tokenHash, expiresAt, false)
+}
+
+// CreatePendingInvite records an invite for an email that has no account yet.
+// The invite is claimed by ClaimPendingInvites when that email registers.
+func (db *ServerDB) CreatePendingInvite(projectID, email, role, invitedBy string, expiresAt time.Time) (*Invitation, error) {
+	return insertPendingInvite(db.conn, projectID, email, role, invitedBy, expiresAt)
+}
+
+func insertPendingInvite(q execQuerier, projectID, email, role, invitedBy string, expiresAt time.Time) (*Invitation, error) {
+	// Auto-accept invites are never redeemed by token, but token_hash is
+	// unique and required, so store a random placeholder.
+	placeholder, err := generateID("auto_")
+	if err != nil {
+		return nil, fmt.Errorf("generate invite token: %w", err)
+	}
+	return insertInvitation(q, projectID, email, role, invitedBy, placeholder, expiresAt, true)
+}
+
+func insertInvitation(q execQuerier, projectID, email, role, invitedBy, tokenHash string, expiresAt time.Time, autoAccept bool) (*Invitation, error) {
 	if !isValidRole(role) {
 		return nil, fmt.Errorf("invalid role: %s", role)
 	}
@@ -83,13 +114,13 @@ func (db *ServerDB) CreateInvitation(projectID, email, role, invitedBy, tokenHas
 	}
 
 	var exists int
-	if err := db.conn.QueryRow(`SELECT 1 FROM projects WHERE id = ? AND deleted_at IS NULL`, projectID).Scan(&exists); err != nil {
+	if err := q.QueryRow(`SELECT 1 FROM projects WHERE id = ? AND deleted_at IS NULL`, projectID).Scan(&exists); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("project not found: %s", projectID)
 		}
 		return nil, fmt.Errorf("check project: %w", err)
 	}
-	if err := db.conn.QueryRow(`SELECT 1 FROM users WHERE id = ?`, invitedBy).Scan(&exists); err != nil {
+	if err := q.QueryRow(`SELECT 1 FROM users WHERE id = ?`, invitedBy).Scan(&exists); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("inviter not found: %s", invitedBy)
 		}
@@ -102,26 +133,27 @@ func (db *ServerDB) CreateInvitation(projectID, email, role, invitedBy, tokenHas
 	}
 
 	now := time.Now().UTC()
-	_, err = db.conn.Exec(
+	_, err = q.Exec(
 		`INSERT INTO invitations
-			(id, project_id, email, role, invited_by, token_hash, status, created_at, expires_at)
-		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
-		id, projectID, email, role, invitedBy, tokenHash, InvitationStatusPending, now, expiresAt.UTC(),
+			(id, project_id, email, role, invited_by, token_hash, status, created_at, expires_at, auto_accept)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		id, projectID, email, role, invitedBy, tokenHash, InvitationStatusPending, now, expiresAt.UTC(), autoAccept,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("insert invitation: %w", err)
 	}
 
 	return &Invitation{
-		ID:        id,
-		ProjectID: projectID,
-		Email:     email,
-		Role:      role,
-		InvitedBy: invitedBy,
-		TokenHash: tokenHash,
-		Status:    InvitationStatusPending,
-		CreatedAt: now,
-		ExpiresAt: expiresAt.UTC(),
+		ID:         id,
+		ProjectID:  projectID,
+		Email:      email,
+		Role:       role,
+		InvitedBy:  invitedBy,
+		TokenHash:  tokenHash,
+		Status:     InvitationStatusPending,
+		CreatedAt:  now,
+		ExpiresAt:  expiresAt.UTC(),
+		AutoAccept: autoAccept,
 	}, nil
 }
 
@@ -152,6 +184,92 @@ func (db *ServerDB) ListProjectInvitations(projectID string) ([]*Invitation, err
 	return invitations, nil
 }
 
+// ListPendingInvites returns a project's unclaimed auto-accept invites.
+func (db *ServerDB) ListPendingInvites(projectID string) ([]*Invitation, error) {
+	rows, err := db.conn.Query(
+		`SELECT`+invitationSelectCols+`
+		 FROM invitations
+		 WHERE project_id = ? AND auto_accept = 1 AND status = ?
+		 ORDER BY created_at DESC`,
+		projectID, InvitationStatusPending,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list pending invites: %w", err)
+	}
+	defer rows.Close()
+
+	var invites []*Invitation
+	for rows.Next() {
+		inv, err := scanInvitation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan invitation: %w", err)
+		}
+		invites = append(invites, inv)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list pending invites: iterate: %w", err)
+	}
+	return invites, nil
+}
+
+// DeletePendingInvite removes an auto-accept invite from a project.
+func (db *ServerDB) DeletePendingInvite(projectID, inviteID string) error {
+	res, err := db.conn.Exec(`DELETE FROM invitations WHERE project_id = ? AND id = ? AND auto_accept = 1`, projectID, inviteID)
+	if err != nil {
+		return fmt.Errorf("delete invite: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return ErrInvitationNotFound
+	}
+	return nil
+}
+
+// claimPendingInvites turns every live auto-accept invite for email into a
+// membership for userID. It runs inside the transaction that creates the user.
+func claimPendingInvites(tx *sql.Tx, userID, email string, now time.Time) error {
+	rows, err := tx.Query(
+		`SELECT id, project_id, role, invited_by
+		 FROM invitations
+		 WHERE email = ? AND auto_accept = 1 AND status = ? AND expires_at > ?`,
+		normalizeEmail(email), InvitationStatusPending, now,
+	)
+	if err != nil {
+		return fmt.Errorf("list pending invites: %w", err)
+	}
+	type claim struct{ id, projectID, role, invitedBy string }
+	var claims []claim
+	for rows.Next() {
+		var c claim
+		if err := rows.Scan(&c.id, &c.projectID, &c.role, &c.invitedBy); err != nil {
+			rows.Close()
+			return fmt.Errorf("scan invite: %w", err)
+		}
+		claims = append(claims, c)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return fmt.Errorf("list pending invites: iterate: %w", err)
+	}
+	rows.Close()
+
+	for _, c := range claims {
+		if _, err := tx.Exec(
+			`INSERT OR IGNORE INTO memberships (project_id, user_id, role, invited_by, created_at) VALUES (?, ?, ?, ?, ?)`,
+			c.projectID, userID, c.role, c.invitedBy, now,
+		); err != nil {
+			return fmt.Errorf("claim invite %s: %w", c.id, err)
+		}
+		if _, err := tx.Exec(
+			`UPDATE invitations SET status = ?, accepted_at = ? WHERE id = ?`,
+			InvitationStatusAccepted, now, c.id,
+		); err != nil {
+			return fmt.Errorf("mark invite %s accepted: %w", c.id, err)
+		}
+	}
+	return nil
+}
+
 func (db *ServerDB) ListPendingInvitationsForEmail(email string) ([]*Invitation, error) {
 	email = normalizeEmail(email)
 	rows, err := db.conn.Query(
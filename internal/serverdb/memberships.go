@@ -178,3 +178,127 @@ func (db *ServerDB) CountMembers() (int, error) {
 func isValidRole(role string) bool {
 	return role == RoleOwner || role == RoleWriter || role == RoleReader
 }
+
+// Bulk member import outcomes.
+const (
+	BulkMemberAdded   = "added"
+	BulkMemberInvited = "invited"
+	BulkMemberSkipped = "skipped"
+	BulkMemberError   = "error"
+)
+
+// BulkMemberEntry is one member to import: an existing user by ID, or an
+// email that is added if it has an account and invited otherwise.
+type BulkMemberEntry struct {
+	UserID string
+	Email  string
+	Role   string
+}
+
+// BulkMemberResult is the outcome of one BulkMemberEntry. Reason explains a
+// skip or an error.
+type BulkMemberResult struct {
+	Status   string
+	UserID   string
+	InviteID string
+	Reason   string
+}
+
+// BulkAddMembers imports entries into a project in one transaction. Each
+// entry gets its own result; entries that are already members, already
+// invited or repeated in the list are skipped, and bad entries are reported
+// without affecting the rest. Unknown emails get a pending invite expiring
+// at inviteExpiresAt, as CreatePendingInvite does. An error means nothing
+// was imported.
+func (db *ServerDB) BulkAddMembers(projectID, invitedBy string, entries []BulkMemberEntry, inviteExpiresAt time.Time) ([]BulkMemberResult, error) {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	now := time.Now().UTC()
+	results := make([]BulkMemberResult, len(entries))
+	seen := make(map[string]bool)
+	for i, e := range entries {
+		res := &results[i]
+		if !isValidRole(e.Role) {
+			*res = BulkMemberResult{Status: BulkMemberError, Reason: fmt.Sprintf("invalid role: %q", e.Role)}
+			continue
+		}
+
+		email := normalizeEmail(e.Email)
+		userID := e.UserID
+		if userID == "" && email != "" {
+			err := tx.QueryRow(`SELECT id FROM users WHERE email = ?`, email).Scan(&userID)
+			if err != nil && err != sql.ErrNoRows {
+				return nil, fmt.Errorf("look up %s: %w", email, err)
+			}
+		}
+
+		key := "user:" + userID
+		if userID == "" {
+			key = "email:" + email
+		}
+		if seen[key] {
+			*res = BulkMemberResult{Status: BulkMemberSkipped, UserID: userID, Reason: "duplicate entry"}
+			continue
+		}
+		seen[key] = true
+
+		if userID == "" {
+			if email == "" {
+				*res = BulkMemberResult{Status: BulkMemberError, Reason: "user_id or email is required"}
+				continue
+			}
+			var pending int
+			err := tx.QueryRow(
+				`SELECT COUNT(*) FROM invitations WHERE project_id = ? AND email = ? AND status = ? AND expires_at > ?`,
+				projectID, email, InvitationStatusPending, now,
+			).Scan(&pending)
+			if err != nil {
+				return nil, fmt.Errorf("check invites for %s: %w", email, err)
+			}
+			if pending > 0 {
+				*res = BulkMemberResult{Status: BulkMemberSkipped, Reason: "already invited"}
+				continue
+			}
+			inv, err := insertPendingInvite(tx, projectID, email, e.Role, invitedBy, inviteExpiresAt)
+			if err != nil {
+				return nil, fmt.Errorf("invite %s: %w", email, err)
+			}
+			*res = BulkMemberResult{Status: BulkMemberInvited, InviteID: inv.ID}
+			continue
+		}
+
+		var exists int
+		if err := tx.QueryRow(`SELECT 1 FROM users WHERE id = ?`, userID).Scan(&exists); err != nil {
+			if err == sql.ErrNoRows {
+				*res = BulkMemberResult{Status: BulkMemberError, UserID: userID, Reason: "user not found"}
+				continue
+			}
+			return nil, fmt.Errorf("check user %s: %w", userID, err)
+		}
+		var role string
+		err := tx.QueryRow(`SELECT role FROM memberships WHERE project_id = ? AND user_id = ?`, projectID, userID).Scan(&role)
+		if err == nil {
+			*res = BulkMemberResult{Status: BulkMemberSkipped, UserID: userID, Reason: "already a member"}
+			continue
+		}
+		if err != sql.ErrNoRows {
+			return nil, fmt.Errorf("check membership of %s: %w", userID, err)
+		}
+		if _, err := tx.Exec(
+			`INSERT INTO memberships (project_id, user_id, role, invited_by, created_at) VALUES (?, ?, ?, ?, ?)`,
+			projectID, userID, e.Role, invitedBy, now,
+		); err != nil {
+			return nil, fmt.Errorf("add member %s: %w", userID, err)
+		}
+		*res = BulkMemberResult{Status: BulkMemberAdded, UserID: userID}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+	return results, nil
+}
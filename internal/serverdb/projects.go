@@ -2,6 +2,7 @@ package serverdb
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"time"
 )
@@ -174,6 +175,80 @@ func (db *ServerDB) UpdateProject(id, name, description string) (*Project, error
 	return db.GetProject(id, false)
 }
 
+// ProjectDefaults is the view team members open the monitor to: a TDQ query
+// and a board reference (ID or name). Empty fields mean no default.
+type ProjectDefaults struct {
+	Query string
+	Board string
+}
+
+// GetProjectDefaults returns the default query and board for a project, or
+// nil if the project does not exist.
+func (db *ServerDB) GetProjectDefaults(projectID string) (*ProjectDefaults, error) {
+	d := &ProjectDefaults{}
+	err := db.conn.QueryRow(
+		`SELECT default_query, default_board FROM projects WHERE id = ? AND deleted_at IS NULL`, projectID,
+	).Scan(&d.Query, &d.Board)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get project defaults: %w", err)
+	}
+	return d, nil
+}
+
+// SetProjectDefaults replaces the default query and board for a project.
+func (db *ServerDB) SetProjectDefaults(projectID string, d ProjectDefaults) error {
+	now := time.Now().UTC()
+	res, err := db.conn.Exec(
+		`UPDATE projects SET default_query = ?, default_board = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL`,
+		d.Query, d.Board, now, projectID,
+	)
+	if err != nil {
+		return fmt.Errorf("set project defaults: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return fmt.Errorf("project not found: %s", projectID)
+	}
+	return nil
+}
+
+// GetProjectSettings returns a project's settings JSON object, or nil if
+// the project does not exist.
+func (db *ServerDB) GetProjectSettings(projectID string) (json.RawMessage, error) {
+	var settings string
+	err := db.conn.QueryRow(
+		`SELECT settings FROM projects WHERE id = ? AND deleted_at IS NULL`, projectID,
+	).Scan(&settings)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get project settings: %w", err)
+	}
+	return json.RawMessage(settings), nil
+}
+
+// SetProjectSettings replaces a project's settings JSON object. The caller
+// validates it.
+func (db *ServerDB) SetProjectSettings(projectID string, settings json.RawMessage) error {
+	now := time.Now().UTC()
+	res, err := db.conn.Exec(
+		`UPDATE projects SET settings = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL`,
+		string(settings), now, projectID,
+	)
+	if err != nil {
+		return fmt.Errorf("set project settings: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return fmt.Errorf("project not found: %s", projectID)
+	}
+	return nil
+}
+
 // SoftDeleteProject marks a project as deleted.
 func (db *ServerDB) SoftDeleteProject(id string) error {
 	now := time.Now().UTC()
@@ -223,6 +298,25 @@ func (db *ServerDB) CountProjects() (int, error) {
 	return count, err
 }
 
+// ListProjectIDs returns the IDs of all non-deleted projects.
+func (db *ServerDB) ListProjectIDs() ([]string, error) {
+	rows, err := db.conn.Query(`SELECT id FROM projects WHERE deleted_at IS NULL ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("list project ids: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan project id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
 // BackfillProjectSlugs assigns a slug to every project that has a NULL or
 // empty slug. Projects are processed in created_at ASC order so the
 // deterministic ordering means the same project always wins the base slug.
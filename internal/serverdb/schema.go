@@ -1,7 +1,7 @@
 package serverdb
 
 // ServerSchemaVersion is the current server database schema version
-const ServerSchemaVersion = 6
+const ServerSchemaVersion = 10
 
 const serverSchema = `
 -- Users table
@@ -213,4 +213,27 @@ var Migrations = []Migration{
 		SQL: `ALTER TABLE projects ADD COLUMN slug TEXT;
 		CREATE UNIQUE INDEX IF NOT EXISTS idx_projects_slug ON projects(slug);`,
 	},
+	{
+		Version:     7,
+		Description: "Add auto_accept column to invitations for email invites claimed on registration",
+		SQL:         `ALTER TABLE invitations ADD COLUMN auto_accept INTEGER NOT NULL DEFAULT 0;`,
+	},
+	{
+		Version:     8,
+		Description: "Add default query and board to projects for a shared monitor view",
+		SQL: `ALTER TABLE projects ADD COLUMN default_query TEXT NOT NULL DEFAULT '';
+		ALTER TABLE projects ADD COLUMN default_board TEXT NOT NULL DEFAULT '';`,
+	},
+	{
+		Version:     9,
+		Description: "Add pulled seq and user to sync_cursors for per-device behind-by status",
+		SQL: `ALTER TABLE sync_cursors ADD COLUMN user_id TEXT NOT NULL DEFAULT '';
+		ALTER TABLE sync_cursors ADD COLUMN last_pulled_seq BIGINT NOT NULL DEFAULT 0;
+		ALTER TABLE sync_cursors ADD COLUMN last_pulled_at DATETIME;`,
+	},
+	{
+		Version:     10,
+		Description: "Add settings JSON to projects for project-level config synced to clients",
+		SQL:         `ALTER TABLE projects ADD COLUMN settings TEXT NOT NULL DEFAULT '{}';`,
+	},
 }
@@ -6,14 +6,22 @@ import (
 	"time"
 )
 
-// SyncCursor tracks a client's sync position in a project.
+// SyncCursor tracks a client's sync position in a project. LastEventID is
+// the furthest seq the client has seen through a push or pull; LastPulledSeq
+// only moves on pull, so it is what the client's local DB has caught up to.
 type SyncCursor struct {
-	ProjectID   string
-	ClientID    string
-	LastEventID int64
-	LastSyncAt  *time.Time
+	ProjectID     string
+	ClientID      string
+	LastEventID   int64
+	LastSyncAt    *time.Time
+	UserID        string // member that last pulled as this client
+	LastPulledSeq int64
+	LastPulledAt  *time.Time
 }
 
+// syncCursorColumns is the column list scanned into a SyncCursor.
+const syncCursorColumns = `project_id, client_id, last_event_id, last_sync_at, user_id, last_pulled_seq, last_pulled_at`
+
 // UpsertSyncCursor creates or updates a sync cursor for a project/client pair.
 func (db *ServerDB) UpsertSyncCursor(projectID, clientID string, lastEventID int64) error {
 	now := time.Now().UTC()
@@ -29,6 +37,23 @@ func (db *ServerDB) UpsertSyncCursor(projectID, clientID string, lastEventID int
 	return nil
 }
 
+// RecordSyncPull records that userID pulled as clientID up to seq, moving
+// both the pulled position and the last-seen cursor.
+func (db *ServerDB) RecordSyncPull(projectID, clientID, userID string, seq int64) error {
+	now := time.Now().UTC()
+	_, err := db.conn.Exec(`
+		INSERT INTO sync_cursors (project_id, client_id, last_event_id, last_sync_at, user_id, last_pulled_seq, last_pulled_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(project_id, client_id)
+		DO UPDATE SET last_event_id = excluded.last_event_id, last_sync_at = excluded.last_sync_at,
+			user_id = excluded.user_id, last_pulled_seq = excluded.last_pulled_seq, last_pulled_at = excluded.last_pulled_at
+	`, projectID, clientID, seq, now, userID, seq, now)
+	if err != nil {
+		return fmt.Errorf("record sync pull: %w", err)
+	}
+	return nil
+}
+
 // DeleteSyncCursor removes the cursor for a project/client pair. Used by
 // tests and diagnostic tooling. Safe to call when no row exists.
 func (db *ServerDB) DeleteSyncCursor(projectID, clientID string) error {
@@ -43,9 +68,9 @@ func (db *ServerDB) DeleteSyncCursor(projectID, clientID string) error {
 func (db *ServerDB) GetSyncCursor(projectID, clientID string) (*SyncCursor, error) {
 	c := &SyncCursor{}
 	err := db.conn.QueryRow(
-		`SELECT project_id, client_id, last_event_id, last_sync_at FROM sync_cursors WHERE project_id = ? AND client_id = ?`,
+		`SELECT `+syncCursorColumns+` FROM sync_cursors WHERE project_id = ? AND client_id = ?`,
 		projectID, clientID,
-	).Scan(&c.ProjectID, &c.ClientID, &c.LastEventID, &c.LastSyncAt)
+	).Scan(&c.ProjectID, &c.ClientID, &c.LastEventID, &c.LastSyncAt, &c.UserID, &c.LastPulledSeq, &c.LastPulledAt)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
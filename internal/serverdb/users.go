@@ -54,6 +54,10 @@ func (db *ServerDB) CreateUser(email string) (*User, error) {
 		return nil, fmt.Errorf("insert user: %w", err)
 	}
 
+	if err := claimPendingInvites(tx, id, email, now); err != nil {
+		return nil, err
+	}
+
 	if err := tx.Commit(); err != nil {
 		return nil, fmt.Errorf("commit: %w", err)
 	}
@@ -300,6 +300,58 @@ func ListSessions(database *db.DB) ([]Session, error) {
 	return sessions, nil
 }
 
+// Resolve finds the session that ref names: a session ID, or a session name
+// (td session "name"), in which case the most recently active session with
+// that name is returned.
+func Resolve(database *db.DB, ref string) (*Session, error) {
+	if row, err := database.GetSessionByID(ref); err == nil && row != nil {
+		return sessionFromRow(row), nil
+	}
+	rows, err := database.ListAllSessions()
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		if row.Name == ref {
+			return sessionFromRow(&row), nil
+		}
+	}
+	return nil, fmt.Errorf("no session with ID or name %q", ref)
+}
+
+// IdentitySessionIDs returns IDs of other sessions that share sess's identity.
+// A session's identity is its configured name (td session "name"); unnamed
+// sessions have no identity beyond their own ID.
+func IdentitySessionIDs(database *db.DB, sess *Session) ([]string, error) {
+	if sess == nil || sess.Name == "" {
+		return nil, nil
+	}
+	rows, err := database.ListAllSessions()
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, row := range rows {
+		if row.ID != sess.ID && row.Name == sess.Name {
+			ids = append(ids, row.ID)
+		}
+	}
+	return ids, nil
+}
+
+// IdentitySessionIDsByID is IdentitySessionIDs for a session known only by
+// ID. An unknown session has no other identities.
+func IdentitySessionIDsByID(database *db.DB, sessionID string) ([]string, error) {
+	if sessionID == "" {
+		return nil, nil
+	}
+	row, err := database.GetSessionByID(sessionID)
+	if err != nil || row == nil {
+		return nil, err
+	}
+	return IdentitySessionIDs(database, sessionFromRow(row))
+}
+
 // CleanupStaleSessions removes sessions older than maxAge
 func CleanupStaleSessions(database *db.DB, maxAge time.Duration) (int, error) {
 	before := time.Now().Add(-maxAge)
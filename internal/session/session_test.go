@@ -569,3 +569,47 @@ func TestEdgeCasesSessionMigration(t *testing.T) {
 		})
 	}
 }
+
+func TestIdentitySessionIDsMatchesByName(t *testing.T) {
+	database := setupTestDB(t)
+
+	now := time.Now().Truncate(time.Second)
+	for _, row := range []*db.SessionRow{
+		{ID: "ses_cur", Name: "alice", StartedAt: now, LastActivity: now},
+		{ID: "ses_prev", Name: "alice", StartedAt: now, LastActivity: now},
+		{ID: "ses_other", Name: "bob", StartedAt: now, LastActivity: now},
+		{ID: "ses_anon", StartedAt: now, LastActivity: now},
+	} {
+		if err := database.UpsertSession(row); err != nil {
+			t.Fatalf("upsert session: %v", err)
+		}
+	}
+
+	ids, err := IdentitySessionIDs(database, &Session{ID: "ses_cur", Name: "alice"})
+	if err != nil {
+		t.Fatalf("IdentitySessionIDs: %v", err)
+	}
+	if len(ids) != 1 || ids[0] != "ses_prev" {
+		t.Fatalf("expected [ses_prev], got %v", ids)
+	}
+
+	ids, err = IdentitySessionIDs(database, &Session{ID: "ses_anon"})
+	if err != nil {
+		t.Fatalf("IdentitySessionIDs: %v", err)
+	}
+	if len(ids) != 0 {
+		t.Fatalf("unnamed session should have no identity sessions, got %v", ids)
+	}
+
+	// By ID, as query callers that only know the session ID look it up
+	ids, err = IdentitySessionIDsByID(database, "ses_prev")
+	if err != nil {
+		t.Fatalf("IdentitySessionIDsByID: %v", err)
+	}
+	if len(ids) != 1 || ids[0] != "ses_cur" {
+		t.Fatalf("expected [ses_cur], got %v", ids)
+	}
+	if ids, err := IdentitySessionIDsByID(database, "ses_missing"); err != nil || len(ids) != 0 {
+		t.Fatalf("unknown session: got %v, %v", ids, err)
+	}
+}
@@ -8,6 +8,15 @@ import (
 )
 
 // InitServerEventLog creates the events table and index if they don't exist.
+//
+// server_seq is the pull cursor clients hold, so it must be monotonic and
+// immutable: AUTOINCREMENT never reuses a seq even after rows are pruned, and
+// a trigger rejects any UPDATE that would renumber an event. Pruning only
+// removes a prefix of the log and records how far it went in event_retention
+// (see PruneEventsThrough), so a cursor can always tell whether the events
+// after it are still present. Pruning also keeps the highest client_action_id
+// it removed per device and session in event_dedup_watermarks, so a retried
+// push of a pruned event is still recognized as a duplicate.
 func InitServerEventLog(db *sql.DB) error {
 	_, err := db.Exec(`
 		CREATE TABLE IF NOT EXISTS events (
@@ -24,6 +33,22 @@ func InitServerEventLog(db *sql.DB) error {
 			UNIQUE(device_id, session_id, client_action_id)
 		);
 		CREATE INDEX IF NOT EXISTS idx_events_entity ON events(entity_type, entity_id);
+		CREATE TRIGGER IF NOT EXISTS events_server_seq_immutable
+		BEFORE UPDATE OF server_seq ON events
+		BEGIN
+			SELECT RAISE(ABORT, 'server_seq is immutable');
+		END;
+		CREATE TABLE IF NOT EXISTS event_retention (
+			id                 INTEGER PRIMARY KEY CHECK (id = 1),
+			pruned_through_seq INTEGER NOT NULL
+		);
+		CREATE TABLE IF NOT EXISTS event_dedup_watermarks (
+			device_id        TEXT NOT NULL,
+			session_id       TEXT NOT NULL,
+			client_action_id INTEGER NOT NULL,
+			server_seq       INTEGER NOT NULL,
+			PRIMARY KEY (device_id, session_id)
+		);
 	`)
 	if err != nil {
 		return fmt.Errorf("init event log: %w", err)
@@ -54,6 +79,8 @@ func InsertServerEventsAttached(tx *sql.Tx, schema string, events []Event) (Push
 		 VALUES (?, ?, ?, ?, ?, ?, ?, ?)`, schema)
 	dupSelectSQL := fmt.Sprintf(
 		`SELECT server_seq FROM %s.events WHERE device_id=? AND session_id=? AND client_action_id=?`, schema)
+	prunedSelectSQL := fmt.Sprintf(
+		`SELECT server_seq FROM %s.event_dedup_watermarks WHERE device_id=? AND session_id=? AND client_action_id>=?`, schema)
 
 	var result PushResult
 
@@ -81,6 +108,22 @@ func InsertServerEventsAttached(tx *sql.Tx, schema string, events []Event) (Push
 			continue
 		}
 
+		// The original row may have been pruned; its watermark still marks
+		// the push as a duplicate.
+		var prunedSeq int64
+		err := tx.QueryRow(prunedSelectSQL, ev.DeviceID, ev.SessionID, ev.ClientActionID).Scan(&prunedSeq)
+		if err == nil {
+			result.Rejected = append(result.Rejected, Rejection{
+				ClientActionID: ev.ClientActionID,
+				Reason:         "duplicate",
+				ServerSeq:      prunedSeq,
+			})
+			continue
+		}
+		if err != sql.ErrNoRows {
+			return result, fmt.Errorf("check dedup watermark %d: %w", ev.ClientActionID, err)
+		}
+
 		payload := scrubLocalOnlySyncPayload(ev.EntityType, ev.Payload)
 		res, err := tx.Exec(insertSQL,
 			ev.DeviceID, ev.SessionID, ev.ClientActionID,
@@ -129,6 +172,63 @@ func InsertServerEventsAttached(tx *sql.Tx, schema string, events []Event) (Push
 	return result, nil
 }
 
+// PrunedThroughSeq returns the highest server_seq removed from the event log
+// by PruneEventsThrough, or 0 if nothing has been pruned. Pull cursors below
+// this value can no longer be served incrementally.
+func PrunedThroughSeq(tx *sql.Tx) (int64, error) {
+	var seq int64
+	err := tx.QueryRow(`SELECT pruned_through_seq FROM event_retention WHERE id = 1`).Scan(&seq)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("read retention boundary: %w", err)
+	}
+	return seq, nil
+}
+
+// PruneEventsThrough deletes every event with server_seq <= seq and advances
+// the retention boundary. The boundary never moves backwards. Before deleting,
+// it folds the pruned events into event_dedup_watermarks so their
+// client_action_ids keep being rejected as duplicates. Returns the number of
+// events deleted.
+func PruneEventsThrough(tx *sql.Tx, seq int64) (int64, error) {
+	current, err := PrunedThroughSeq(tx)
+	if err != nil {
+		return 0, err
+	}
+	if seq <= current {
+		return 0, nil
+	}
+
+	if _, err := tx.Exec(
+		`INSERT INTO event_retention (id, pruned_through_seq) VALUES (1, ?)
+		 ON CONFLICT(id) DO UPDATE SET pruned_through_seq = excluded.pruned_through_seq`,
+		seq,
+	); err != nil {
+		return 0, fmt.Errorf("record retention boundary: %w", err)
+	}
+
+	if _, err := tx.Exec(
+		`INSERT INTO event_dedup_watermarks (device_id, session_id, client_action_id, server_seq)
+		 SELECT device_id, session_id, MAX(client_action_id), MAX(server_seq)
+		 FROM events WHERE server_seq <= ? GROUP BY device_id, session_id
+		 ON CONFLICT(device_id, session_id) DO UPDATE SET
+			client_action_id = MAX(client_action_id, excluded.client_action_id),
+			server_seq = MAX(server_seq, excluded.server_seq)`,
+		seq,
+	); err != nil {
+		return 0, fmt.Errorf("record dedup watermarks: %w", err)
+	}
+
+	res, err := tx.Exec(`DELETE FROM events WHERE server_seq <= ?`, seq)
+	if err != nil {
+		return 0, fmt.Errorf("prune events: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	return n, nil
+}
+
 // GetEventsSince retrieves events after the given sequence number.
 // If excludeDevice is non-empty, events from that device are filtered out.
 func GetEventsSince(tx *sql.Tx, afterSeq int64, limit int, excludeDevice string) (PullResult, error) {
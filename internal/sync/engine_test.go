@@ -208,6 +208,13 @@ func TestInsertServerEventsAttached_ScrubsWorkSessionLocalMetadata(t *testing.T)
 			server_timestamp  DATETIME DEFAULT CURRENT_TIMESTAMP,
 			UNIQUE(device_id, session_id, client_action_id)
 		);
+		CREATE TABLE events_db.event_dedup_watermarks (
+			device_id        TEXT NOT NULL,
+			session_id       TEXT NOT NULL,
+			client_action_id INTEGER NOT NULL,
+			server_seq       INTEGER NOT NULL,
+			PRIMARY KEY (device_id, session_id)
+		);
 	`); err != nil {
 		t.Fatalf("create attached events table: %v", err)
 	}
@@ -472,3 +479,100 @@ func assertWorkSessionPayloadOmitsLocalFields(t *testing.T, raw json.RawMessage)
 		}
 	}
 }
+
+func TestServerSeqImmutable(t *testing.T) {
+	db := setupEngineDB(t)
+	tx, _ := db.Begin()
+	if _, err := InsertServerEvents(tx, []Event{makeEvent("d1", "s1", 1, "e1")}); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+	tx.Commit()
+
+	if _, err := db.Exec(`UPDATE events SET server_seq = 100 WHERE server_seq = 1`); err == nil {
+		t.Fatal("expected renumbering server_seq to fail")
+	}
+}
+
+func TestPruneEventsThrough(t *testing.T) {
+	db := setupEngineDB(t)
+
+	tx, _ := db.Begin()
+	var events []Event
+	for i := int64(1); i <= 5; i++ {
+		events = append(events, makeEvent("d1", "s1", i, "e"))
+	}
+	if _, err := InsertServerEvents(tx, events); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+	n, err := PruneEventsThrough(tx, 3)
+	if err != nil {
+		t.Fatalf("prune: %v", err)
+	}
+	if n != 3 {
+		t.Fatalf("pruned: got %d, want 3", n)
+	}
+
+	// The boundary never moves backwards
+	if _, err := PruneEventsThrough(tx, 1); err != nil {
+		t.Fatalf("prune backwards: %v", err)
+	}
+	boundary, err := PrunedThroughSeq(tx)
+	if err != nil {
+		t.Fatalf("boundary: %v", err)
+	}
+	if boundary != 3 {
+		t.Fatalf("boundary: got %d, want 3", boundary)
+	}
+
+	// New events continue above the highest seq ever assigned, even after
+	// pruning, so existing cursors stay valid.
+	if _, err := PruneEventsThrough(tx, 5); err != nil {
+		t.Fatalf("prune all: %v", err)
+	}
+	result, err := InsertServerEvents(tx, []Event{makeEvent("d1", "s1", 6, "e")})
+	if err != nil {
+		t.Fatalf("insert after prune: %v", err)
+	}
+	if got := result.Acks[0].ServerSeq; got != 6 {
+		t.Fatalf("server_seq after prune: got %d, want 6", got)
+	}
+	tx.Commit()
+}
+
+func TestPrunedEventsStayDuplicates(t *testing.T) {
+	db := setupEngineDB(t)
+
+	tx, _ := db.Begin()
+	defer tx.Rollback()
+	var events []Event
+	for i := int64(1); i <= 3; i++ {
+		events = append(events, makeEvent("d1", "s1", i, "e"))
+	}
+	if _, err := InsertServerEvents(tx, events); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+	if _, err := PruneEventsThrough(tx, 2); err != nil {
+		t.Fatalf("prune: %v", err)
+	}
+
+	// A retried push of a pruned event must not be stored again
+	result, err := InsertServerEvents(tx, []Event{makeEvent("d1", "s1", 2, "e"), makeEvent("d1", "s1", 4, "e")})
+	if err != nil {
+		t.Fatalf("re-push: %v", err)
+	}
+	if result.Accepted != 1 || result.Acks[0].ClientActionID != 4 {
+		t.Fatalf("re-push acks: got %+v", result.Acks)
+	}
+	if len(result.Rejected) != 1 || result.Rejected[0].Reason != "duplicate" || result.Rejected[0].ServerSeq != 2 {
+		t.Fatalf("re-push rejected: got %+v, want duplicate of seq 2", result.Rejected)
+	}
+
+	// Other sessions on the same device are unaffected
+	result, err = InsertServerEvents(tx, []Event{makeEvent("d1", "s2", 1, "e")})
+	if err != nil {
+		t.Fatalf("insert other session: %v", err)
+	}
+	if result.Accepted != 1 {
+		t.Fatalf("other session: got %+v, want accepted", result)
+	}
+}
@@ -79,6 +79,9 @@ func checkAndResolveCyclicDependency(tx *sql.Tx, event Event) bool {
 	if issueID == "" || dependsOnID == "" {
 		return false // missing fields, let upsert handle validation
 	}
+	if rt, _ := fields["relation_type"].(string); rt != "" && rt != "depends_on" {
+		return false // non-blocking relations can't form a dependency cycle
+	}
 
 	if !wouldCreateCycleTx(tx, issueID, dependsOnID) {
 		return false // no cycle, proceed with create
@@ -95,7 +98,7 @@ func checkAndResolveCyclicDependency(tx *sql.Tx, event Event) bool {
 
 	if incomingKey < conflictKey {
 		// Incoming edge wins - remove the conflicting edge
-		_, err := tx.Exec(`DELETE FROM issue_dependencies WHERE issue_id = ? AND depends_on_id = ?`,
+		_, err := tx.Exec(`DELETE FROM issue_dependencies WHERE issue_id = ? AND depends_on_id = ? AND relation_type = 'depends_on'`,
 			conflictIssueID, conflictDependsOnID)
 		if err != nil {
 			slog.Warn("cycle resolution: failed to remove conflicting edge",
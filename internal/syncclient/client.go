@@ -2,6 +2,8 @@ package syncclient
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -9,7 +11,10 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
+
+	"github.com/marcus/td/internal/models"
 )
 
 // Sentinel errors for common HTTP error classes.
@@ -17,6 +22,30 @@ var (
 	ErrUnauthorized = errors.New("unauthorized")
 	ErrForbidden    = errors.New("forbidden")
 	ErrNotFound     = errors.New("not found")
+
+	// ErrSnapshotChecksum means a downloaded snapshot did not match the
+	// server's X-Snapshot-SHA256 digest, even after retrying.
+	ErrSnapshotChecksum = errors.New("snapshot checksum mismatch")
+
+	// ErrEventTooLarge means a single event can't fit in a push request
+	// under the server's body limit, so no batching can send it.
+	ErrEventTooLarge = errors.New("event too large to push")
+)
+
+const (
+	// MaxPushBatchSize is the most events the server accepts in one push.
+	MaxPushBatchSize = 1000
+
+	// DefaultPushBatchSize is used when Client.PushBatchSize is unset.
+	DefaultPushBatchSize = 500
+
+	// maxPushBytes bounds the estimated JSON size of one push request. The
+	// server caps request bodies at 10 MiB; this leaves room for framing.
+	maxPushBytes = 8 << 20
+
+	// pushEventOverhead approximates the JSON size of an event's fields
+	// other than its payload.
+	pushEventOverhead = 256
 )
 
 // Client is an HTTP client for the td-sync server.
@@ -25,6 +54,10 @@ type Client struct {
 	APIKey   string
 	DeviceID string
 	HTTP     *http.Client
+
+	// PushBatchSize caps the events sent per push request. 0 selects
+	// DefaultPushBatchSize; values above MaxPushBatchSize are clamped.
+	PushBatchSize int
 }
 
 // New creates a new sync client.
@@ -155,6 +188,16 @@ type SyncStatusResponse struct {
 	EventCount    int64  `json:"event_count"`
 	LastServerSeq int64  `json:"last_server_seq"`
 	LastEventTime string `json:"last_event_time,omitempty"`
+
+	// EntityTypes breaks the counts down by entity type (issues, logs,
+	// comments, boards, ...). Types with no events are omitted.
+	EntityTypes map[string]EntityTypeStatus `json:"entity_types,omitempty"`
+}
+
+// EntityTypeStatus is the per-entity-type part of SyncStatusResponse.
+type EntityTypeStatus struct {
+	EventCount    int64 `json:"event_count"`
+	LastServerSeq int64 `json:"last_server_seq"`
 }
 
 // HealthResponse is the response from GET /healthz.
@@ -250,6 +293,47 @@ func (c *Client) ListProjects() ([]ProjectResponse, error) {
 	return resp, nil
 }
 
+// ProjectDefaults is the project-wide default monitor view. Board is a
+// board ID or name; empty fields mean no default.
+type ProjectDefaults struct {
+	Query string `json:"query"`
+	Board string `json:"board"`
+}
+
+// GetProjectDefaults fetches the project's default query and board.
+func (c *Client) GetProjectDefaults(projectID string) (*ProjectDefaults, error) {
+	var resp ProjectDefaults
+	if err := c.do("GET", fmt.Sprintf("/v1/projects/%s/defaults", projectID), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ProjectSettings is the project-level config every client shares: the
+// close-reason policy and a custom workflow. Omitted fields are unset.
+type ProjectSettings struct {
+	RequireCloseReason *bool                  `json:"require_close_reason,omitempty"`
+	Workflow           *models.WorkflowConfig `json:"workflow,omitempty"`
+}
+
+// GetProjectSettings fetches the project's shared settings.
+func (c *Client) GetProjectSettings(projectID string) (*ProjectSettings, error) {
+	var resp ProjectSettings
+	if err := c.do("GET", fmt.Sprintf("/v1/projects/%s/settings", projectID), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SetProjectDefaults replaces the project's default query and board.
+func (c *Client) SetProjectDefaults(projectID string, defaults ProjectDefaults) (*ProjectDefaults, error) {
+	var resp ProjectDefaults
+	if err := c.do("PUT", fmt.Sprintf("/v1/projects/%s/defaults", projectID), defaults, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // --- Member types ---
 
 // MemberResponse represents a project member from the server.
@@ -295,13 +379,69 @@ func (c *Client) RemoveMember(projectID, userID string) error {
 
 // --- Sync methods ---
 
-// Push sends local events to the server.
+// Push sends local events to the server. A request with more events than
+// the batch size, or too much payload for one request body, is split into
+// several server-safe pushes whose acks and rejections are merged into one
+// response. If a later batch fails, earlier batches have already landed; the
+// server reports those as duplicates when they are pushed again.
 func (c *Client) Push(projectID string, req *PushRequest) (*PushResponse, error) {
-	var resp PushResponse
-	if err := c.do("POST", fmt.Sprintf("/v1/projects/%s/sync/push", projectID), req, &resp); err != nil {
+	batches, err := splitPushEvents(req.Events, c.pushBatchSize())
+	if err != nil {
 		return nil, err
 	}
-	return &resp, nil
+
+	path := fmt.Sprintf("/v1/projects/%s/sync/push", projectID)
+	merged := &PushResponse{}
+	for i, events := range batches {
+		batchReq := &PushRequest{DeviceID: req.DeviceID, SessionID: req.SessionID, Events: events}
+		var resp PushResponse
+		if err := c.do("POST", path, batchReq, &resp); err != nil {
+			if len(batches) == 1 {
+				return nil, err
+			}
+			return nil, fmt.Errorf("push batch %d/%d: %w", i+1, len(batches), err)
+		}
+		merged.Accepted += resp.Accepted
+		merged.Acks = append(merged.Acks, resp.Acks...)
+		merged.Rejected = append(merged.Rejected, resp.Rejected...)
+	}
+	return merged, nil
+}
+
+// pushBatchSize returns the effective events-per-request limit.
+func (c *Client) pushBatchSize() int {
+	switch {
+	case c.PushBatchSize <= 0:
+		return DefaultPushBatchSize
+	case c.PushBatchSize > MaxPushBatchSize:
+		return MaxPushBatchSize
+	}
+	return c.PushBatchSize
+}
+
+// splitPushEvents splits events into batches of at most batchSize events and
+// roughly maxPushBytes of JSON each. It fails up front, before anything is
+// sent, if any single event is too large to push on its own.
+func splitPushEvents(events []EventInput, batchSize int) ([][]EventInput, error) {
+	var batches [][]EventInput
+	var cur []EventInput
+	curBytes := 0
+	for _, ev := range events {
+		size := len(ev.Payload) + pushEventOverhead
+		if size > maxPushBytes {
+			return nil, fmt.Errorf("%w: client_action_id %d (%s %s) is %d bytes", ErrEventTooLarge, ev.ClientActionID, ev.EntityType, ev.EntityID, len(ev.Payload))
+		}
+		if len(cur) > 0 && (len(cur) >= batchSize || curBytes+size > maxPushBytes) {
+			batches = append(batches, cur)
+			cur, curBytes = nil, 0
+		}
+		cur = append(cur, ev)
+		curBytes += size
+	}
+	if len(cur) > 0 || len(batches) == 0 {
+		batches = append(batches, cur)
+	}
+	return batches, nil
 }
 
 // Pull fetches remote events from the server.
@@ -326,49 +466,136 @@ type SnapshotResponse struct {
 	SnapshotSeq int64
 }
 
-// GetSnapshot downloads a snapshot database for bootstrap.
+// snapshotResumeAttempts bounds how many times GetSnapshot resumes an
+// interrupted download with a Range request before giving up.
+const snapshotResumeAttempts = 3
+
+// snapshotChecksumAttempts bounds how many times GetSnapshot downloads the
+// snapshot again after the body fails checksum verification.
+const snapshotChecksumAttempts = 3
+
+// GetSnapshot downloads a snapshot database for bootstrap. The body is checked
+// against the server's X-Snapshot-SHA256 digest and downloaded again from
+// scratch if it doesn't match; servers that don't send the header are trusted
+// as before.
 func (c *Client) GetSnapshot(projectID string) (*SnapshotResponse, error) {
+	var err error
+	for attempt := 0; attempt < snapshotChecksumAttempts; attempt++ {
+		var snapshot *SnapshotResponse
+		var checksum string
+		snapshot, checksum, err = c.downloadSnapshot(projectID)
+		if err != nil || snapshot == nil {
+			return nil, err
+		}
+		if checksum == "" {
+			return snapshot, nil
+		}
+		sum := sha256.Sum256(snapshot.Data)
+		got := hex.EncodeToString(sum[:])
+		if strings.EqualFold(got, checksum) {
+			return snapshot, nil
+		}
+		err = fmt.Errorf("%w: got %s, want %s", ErrSnapshotChecksum, got, checksum)
+	}
+	return nil, err
+}
+
+// downloadSnapshot fetches the snapshot body and the server's digest for it.
+// If the body is cut off mid-transfer, the download resumes from the received
+// offset with a Range request, starting over if the server has moved on to a
+// newer snapshot.
+func (c *Client) downloadSnapshot(projectID string) (*SnapshotResponse, string, error) {
+	var data bytes.Buffer
+	var snapshotSeq int64
+	var etag, checksum string
+	for attempt := 0; ; attempt++ {
+		offset := int64(data.Len())
+		resp, seq, err := c.openSnapshot(projectID, offset, etag)
+		if err != nil || resp == nil {
+			return nil, "", err
+		}
+
+		if offset > 0 {
+			if seq != snapshotSeq {
+				// A newer snapshot was built; the partial bytes are useless.
+				resp.Body.Close()
+				data.Reset()
+				snapshotSeq = 0
+				continue
+			}
+			if resp.StatusCode != http.StatusPartialContent {
+				// Range not honored: the server is sending the whole file again.
+				data.Reset()
+			}
+		}
+		snapshotSeq = seq
+		etag = resp.Header.Get("ETag")
+		checksum = resp.Header.Get("X-Snapshot-SHA256")
+
+		_, err = io.Copy(&data, resp.Body)
+		resp.Body.Close()
+		if err == nil {
+			break
+		}
+		if attempt >= snapshotResumeAttempts {
+			return nil, "", fmt.Errorf("read snapshot: %w", err)
+		}
+	}
+
+	return &SnapshotResponse{Data: data.Bytes(), SnapshotSeq: snapshotSeq}, checksum, nil
+}
+
+// openSnapshot requests the snapshot starting at offset and returns the open
+// response with its X-Snapshot-Seq. When resuming, etag (if known) is sent as
+// If-Range so the server replies with the full file if the snapshot changed.
+// Returns a nil response if the project has no events to snapshot.
+func (c *Client) openSnapshot(projectID string, offset int64, etag string) (*http.Response, int64, error) {
 	path := fmt.Sprintf("/v1/projects/%s/sync/snapshot", projectID)
 	req, err := http.NewRequest("GET", c.BaseURL+path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
+		return nil, 0, fmt.Errorf("create request: %w", err)
 	}
 	req.Header.Set("Authorization", "Bearer "+c.APIKey)
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		if etag != "" {
+			req.Header.Set("If-Range", etag)
+		}
+	}
 
 	resp, err := c.HTTP.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("http request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, nil // no events to snapshot
-	}
-	if resp.StatusCode == http.StatusUnauthorized {
-		return nil, ErrUnauthorized
-	}
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("snapshot: HTTP %d", resp.StatusCode)
+		return nil, 0, fmt.Errorf("http request: %w", err)
 	}
 
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("read snapshot: %w", err)
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusPartialContent:
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, 0, nil // no events to snapshot
+	case http.StatusUnauthorized:
+		resp.Body.Close()
+		return nil, 0, ErrUnauthorized
+	default:
+		resp.Body.Close()
+		return nil, 0, fmt.Errorf("snapshot: HTTP %d", resp.StatusCode)
 	}
 
 	seqStr := resp.Header.Get("X-Snapshot-Seq")
 	if seqStr == "" {
-		return nil, fmt.Errorf("snapshot response missing X-Snapshot-Seq header")
+		resp.Body.Close()
+		return nil, 0, fmt.Errorf("snapshot response missing X-Snapshot-Seq header")
 	}
 	seq, err := strconv.ParseInt(seqStr, 10, 64)
 	if err != nil {
-		return nil, fmt.Errorf("parse X-Snapshot-Seq %q: %w", seqStr, err)
+		resp.Body.Close()
+		return nil, 0, fmt.Errorf("parse X-Snapshot-Seq %q: %w", seqStr, err)
 	}
 	if seq <= 0 {
-		return nil, fmt.Errorf("snapshot seq must be positive")
+		resp.Body.Close()
+		return nil, 0, fmt.Errorf("snapshot seq must be positive")
 	}
-
-	return &SnapshotResponse{Data: data, SnapshotSeq: seq}, nil
+	return resp, seq, nil
 }
 
 // SyncStatus gets the sync status for a project.
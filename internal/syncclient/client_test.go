@@ -0,0 +1,122 @@
+package syncclient
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestGetSnapshotResumesInterruptedDownload(t *testing.T) {
+	snapshot := bytes.Repeat([]byte("0123456789"), 100)
+	var ranges []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ranges = append(ranges, r.Header.Get("Range"))
+		w.Header().Set("X-Snapshot-Seq", "42")
+		if len(ranges) == 1 {
+			// Promise the whole file, send part of it, then drop the connection.
+			w.Header().Set("Content-Length", strconv.Itoa(len(snapshot)))
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(snapshot[:300])
+			w.(http.Flusher).Flush()
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Errorf("hijack: %v", err)
+				return
+			}
+			conn.Close()
+			return
+		}
+		http.ServeContent(w, r, "42.db", time.Time{}, bytes.NewReader(snapshot))
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, "key", "dev")
+	resp, err := c.GetSnapshot("p1")
+	if err != nil {
+		t.Fatalf("GetSnapshot: %v", err)
+	}
+	if !bytes.Equal(resp.Data, snapshot) {
+		t.Fatalf("snapshot data mismatch: got %d bytes, want %d", len(resp.Data), len(snapshot))
+	}
+	if resp.SnapshotSeq != 42 {
+		t.Fatalf("SnapshotSeq = %d, want 42", resp.SnapshotSeq)
+	}
+	if len(ranges) != 2 || ranges[0] != "" || ranges[1] != "bytes=300-" {
+		t.Fatalf("Range headers = %q, want [\"\" \"bytes=300-\"]", ranges)
+	}
+}
+
+func TestGetSnapshotRejectsTamperedBody(t *testing.T) {
+	snapshot := bytes.Repeat([]byte("0123456789"), 100)
+	sum := sha256.Sum256(snapshot)
+	tampered := append([]byte(nil), snapshot...)
+	tampered[500] ^= 0xff
+
+	var requests, corruptUntil int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("X-Snapshot-Seq", "42")
+		w.Header().Set("X-Snapshot-SHA256", hex.EncodeToString(sum[:]))
+		body := snapshot
+		if requests <= corruptUntil {
+			body = tampered
+		}
+		http.ServeContent(w, r, "42.db", time.Time{}, bytes.NewReader(body))
+	}))
+	defer srv.Close()
+	c := New(srv.URL, "key", "dev")
+
+	// First download is corrupt; the retry gets the real file
+	corruptUntil = 1
+	resp, err := c.GetSnapshot("p1")
+	if err != nil {
+		t.Fatalf("GetSnapshot: %v", err)
+	}
+	if !bytes.Equal(resp.Data, snapshot) {
+		t.Fatal("GetSnapshot returned the tampered body")
+	}
+	if requests != 2 {
+		t.Fatalf("requests = %d, want 2 (one rejected, one retry)", requests)
+	}
+
+	// Every download corrupt: give up with ErrSnapshotChecksum
+	requests, corruptUntil = 0, snapshotChecksumAttempts
+	if _, err := c.GetSnapshot("p1"); !errors.Is(err, ErrSnapshotChecksum) {
+		t.Fatalf("GetSnapshot err = %v, want ErrSnapshotChecksum", err)
+	}
+	if requests != snapshotChecksumAttempts {
+		t.Fatalf("requests = %d, want %d", requests, snapshotChecksumAttempts)
+	}
+}
+
+func TestPushRejectsOversizedEvent(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"accepted":0,"acks":[]}`))
+	}))
+	defer srv.Close()
+
+	huge := append([]byte(`"`), bytes.Repeat([]byte("x"), maxPushBytes)...)
+	huge = append(huge, '"')
+	req := &PushRequest{DeviceID: "dev", SessionID: "sess", Events: []EventInput{
+		{ClientActionID: 1, ActionType: "create", EntityType: "issues", EntityID: "i_001", Payload: []byte(`{}`)},
+		{ClientActionID: 2, ActionType: "create", EntityType: "issues", EntityID: "i_002", Payload: huge},
+	}}
+
+	c := New(srv.URL, "key", "dev")
+	if _, err := c.Push("p1", req); !errors.Is(err, ErrEventTooLarge) {
+		t.Fatalf("Push err = %v, want ErrEventTooLarge", err)
+	}
+	if requests != 0 {
+		t.Fatalf("requests = %d, want 0 (nothing sent when an event can't fit)", requests)
+	}
+}
@@ -26,6 +26,7 @@ type SyncConfig struct {
 	URL               string         `json:"url"`
 	Enabled           bool           `json:"enabled"`
 	SnapshotThreshold *int           `json:"snapshot_threshold,omitempty"`
+	PushBatchSize     int            `json:"push_batch_size,omitempty"`
 	Auto              AutoSyncConfig `json:"auto"`
 }
 
@@ -166,6 +167,22 @@ func GetSnapshotThreshold() int {
 	return 100
 }
 
+// GetPushBatchSize returns the number of events sent per push request.
+// Priority: TD_SYNC_PUSH_BATCH_SIZE env > config.json > 0. Zero leaves the
+// choice to syncclient, which also clamps values to the server's limit.
+func GetPushBatchSize() int {
+	if v := os.Getenv("TD_SYNC_PUSH_BATCH_SIZE"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			return n
+		}
+	}
+	cfg, err := LoadConfig()
+	if err == nil && cfg.Sync.PushBatchSize > 0 {
+		return cfg.Sync.PushBatchSize
+	}
+	return 0
+}
+
 // GetAPIKey returns the API key.
 // Priority: TD_AUTH_KEY env > auth.json.
 func GetAPIKey() string {
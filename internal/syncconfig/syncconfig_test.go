@@ -162,3 +162,21 @@ func TestAutoSyncEnvOverridesConfig(t *testing.T) {
 		t.Error("env should override config for pull")
 	}
 }
+
+func TestPushBatchSizeFromConfig(t *testing.T) {
+	os.Unsetenv("TD_SYNC_PUSH_BATCH_SIZE")
+	writeTestConfig(t, &Config{Sync: SyncConfig{PushBatchSize: 250}})
+	if got := GetPushBatchSize(); got != 250 {
+		t.Fatalf("config push batch size: got %d, want 250", got)
+	}
+
+	t.Setenv("TD_SYNC_PUSH_BATCH_SIZE", "100")
+	if got := GetPushBatchSize(); got != 100 {
+		t.Fatalf("env override: got %d, want 100", got)
+	}
+
+	t.Setenv("TD_SYNC_PUSH_BATCH_SIZE", "0")
+	if got := GetPushBatchSize(); got != 250 {
+		t.Fatalf("zero env: got %d, want 250 (config)", got)
+	}
+}
@@ -0,0 +1,96 @@
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/marcus/td/internal/config"
+	"github.com/marcus/td/internal/models"
+)
+
+// statusNamePattern is what a custom status may be called: lowercase, like
+// the built-in statuses, so it survives NormalizeStatus unchanged.
+var statusNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// ValidateConfig checks a custom workflow: statuses must be new lowercase
+// names, and transitions must join two different known statuses.
+func ValidateConfig(wf *models.WorkflowConfig) error {
+	if wf == nil {
+		return nil
+	}
+	known := AllStatuses()
+	for _, s := range wf.Statuses {
+		if !statusNamePattern.MatchString(string(s)) {
+			return fmt.Errorf("invalid custom status %q: use lowercase letters, digits and _", s)
+		}
+		if slices.Contains(AllStatuses(), s) {
+			return fmt.Errorf("custom status %q is already a built-in status", s)
+		}
+		if slices.Contains(known, s) {
+			return fmt.Errorf("custom status %q is listed twice", s)
+		}
+		known = append(known, s)
+	}
+	for _, t := range wf.Transitions {
+		for _, s := range []models.Status{t.From, t.To} {
+			if !slices.Contains(known, s) {
+				return fmt.Errorf("transition %s → %s: unknown status %q", t.From, t.To, s)
+			}
+		}
+		if t.From == t.To {
+			return fmt.Errorf("transition %s → %s: a status cannot transition to itself", t.From, t.To)
+		}
+	}
+	return nil
+}
+
+// NewWithConfig creates a StateMachine with the given mode whose workflow is
+// the built-in one extended by wf. Custom transitions carry no guards.
+func NewWithConfig(mode TransitionMode, wf *models.WorkflowConfig) (*StateMachine, error) {
+	if err := ValidateConfig(wf); err != nil {
+		return nil, err
+	}
+	sm := New(mode)
+	if wf == nil {
+		return sm, nil
+	}
+	sm.custom = slices.Clone(wf.Statuses)
+	for _, t := range wf.Transitions {
+		if sm.GetTransition(t.From, t.To) == nil {
+			sm.addTransition(&Transition{From: t.From, To: t.To})
+		}
+	}
+	return sm, nil
+}
+
+// ForProject returns the liberal state machine for the project at baseDir,
+// including its custom workflow from config. An unreadable or invalid
+// workflow falls back to the built-in one and is returned as the error.
+func ForProject(baseDir string) (*StateMachine, error) {
+	wf, err := config.GetWorkflow(baseDir)
+	if err != nil {
+		return DefaultMachine(), err
+	}
+	sm, err := NewWithConfig(ModeLiberal, wf)
+	if err != nil {
+		return DefaultMachine(), fmt.Errorf("workflow config: %w", err)
+	}
+	return sm, nil
+}
+
+// CustomStatuses returns the statuses the machine adds to the built-in ones.
+func (sm *StateMachine) CustomStatuses() []models.Status {
+	return sm.custom
+}
+
+// Statuses returns every status the machine knows: the built-in ones, then
+// the custom ones in config order.
+func (sm *StateMachine) Statuses() []models.Status {
+	return append(AllStatuses(), sm.custom...)
+}
+
+// HasStatus reports whether s is a built-in or custom status of the machine.
+func (sm *StateMachine) HasStatus(s models.Status) bool {
+	return slices.Contains(sm.Statuses(), s)
+}
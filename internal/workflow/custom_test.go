@@ -0,0 +1,128 @@
+package workflow
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/marcus/td/internal/config"
+	"github.com/marcus/td/internal/models"
+)
+
+// qaWorkflow adds a qa status between review and close.
+func qaWorkflow() *models.WorkflowConfig {
+	return &models.WorkflowConfig{
+		Statuses: []models.Status{"qa"},
+		Transitions: []models.WorkflowTransition{
+			{From: models.StatusInReview, To: "qa"},
+			{From: "qa", To: models.StatusClosed},
+			{From: "qa", To: models.StatusInProgress},
+		},
+	}
+}
+
+func TestCustomWorkflowTransitions(t *testing.T) {
+	sm, err := NewWithConfig(ModeLiberal, qaWorkflow())
+	if err != nil {
+		t.Fatalf("NewWithConfig: %v", err)
+	}
+
+	tests := []struct {
+		from, to models.Status
+		want     bool
+	}{
+		{models.StatusInReview, "qa", true},
+		{"qa", models.StatusClosed, true},
+		{"qa", models.StatusInProgress, true},
+		{models.StatusOpen, "qa", false},
+		{"qa", models.StatusOpen, false},
+		// Built-in transitions are kept
+		{models.StatusOpen, models.StatusInProgress, true},
+		{models.StatusBlocked, models.StatusInReview, false},
+	}
+	for _, tt := range tests {
+		if got := sm.IsValidTransition(tt.from, tt.to); got != tt.want {
+			t.Errorf("IsValidTransition(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+
+	issue := &models.Issue{ID: "td-qa1", Status: "qa"}
+	if _, err := sm.Validate(&TransitionContext{Issue: issue, FromStatus: "qa", ToStatus: models.StatusClosed}); err != nil {
+		t.Errorf("qa → closed rejected: %v", err)
+	}
+	_, err = sm.Validate(&TransitionContext{Issue: issue, FromStatus: "qa", ToStatus: models.StatusOpen})
+	var te *TransitionError
+	if !errors.As(err, &te) {
+		t.Errorf("qa → open: got %v, want TransitionError", err)
+	}
+
+	if !sm.HasStatus("qa") || sm.HasStatus("staging") {
+		t.Errorf("HasStatus: qa=%v staging=%v, want true/false", sm.HasStatus("qa"), sm.HasStatus("staging"))
+	}
+	if got := sm.Statuses(); len(got) != 6 || got[5] != "qa" {
+		t.Errorf("Statuses() = %v, want built-ins then qa", got)
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		wf      *models.WorkflowConfig
+		wantErr string
+	}{
+		{"nil", nil, ""},
+		{"qa", qaWorkflow(), ""},
+		{"bad name", &models.WorkflowConfig{Statuses: []models.Status{"Q A"}}, "invalid custom status"},
+		{"built-in", &models.WorkflowConfig{Statuses: []models.Status{"closed"}}, "already a built-in"},
+		{"twice", &models.WorkflowConfig{Statuses: []models.Status{"qa", "qa"}}, "listed twice"},
+		{"unknown", &models.WorkflowConfig{Transitions: []models.WorkflowTransition{{From: "open", To: "qa"}}}, `unknown status "qa"`},
+		{"self", &models.WorkflowConfig{Statuses: []models.Status{"qa"}, Transitions: []models.WorkflowTransition{{From: "qa", To: "qa"}}}, "to itself"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateConfig(tt.wf)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestForProject(t *testing.T) {
+	dir := t.TempDir()
+	sm, err := ForProject(dir)
+	if err != nil {
+		t.Fatalf("ForProject without config: %v", err)
+	}
+	if len(sm.CustomStatuses()) != 0 {
+		t.Errorf("custom statuses without config = %v", sm.CustomStatuses())
+	}
+
+	if err := config.Save(dir, &models.Config{Workflow: qaWorkflow()}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	sm, err = ForProject(dir)
+	if err != nil {
+		t.Fatalf("ForProject: %v", err)
+	}
+	if !sm.IsValidTransition(models.StatusInReview, "qa") {
+		t.Error("configured transition in_review → qa missing")
+	}
+
+	if err := config.Save(dir, &models.Config{Workflow: &models.WorkflowConfig{Statuses: []models.Status{"open"}}}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	sm, err = ForProject(dir)
+	if err == nil {
+		t.Error("expected an error for an invalid workflow")
+	}
+	if sm == nil || len(sm.CustomStatuses()) != 0 {
+		t.Error("invalid workflow should fall back to the built-in machine")
+	}
+}
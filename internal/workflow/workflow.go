@@ -68,6 +68,7 @@ type Transition struct {
 type StateMachine struct {
 	transitions map[models.Status]map[models.Status]*Transition
 	mode        TransitionMode
+	custom      []models.Status // statuses added by a custom workflow
 }
 
 // New creates a new StateMachine with the given mode
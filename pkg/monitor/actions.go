@@ -1,14 +1,16 @@
 package monitor
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	tea "charm.land/bubbletea/v2"
 	"github.com/marcus/td/internal/db"
 	"github.com/marcus/td/internal/features"
 	"github.com/marcus/td/internal/models"
+	"github.com/marcus/td/internal/output"
 	"github.com/marcus/td/internal/reviewpolicy"
-	"github.com/marcus/td/internal/workflow"
 )
 
 // monitorApproveInputs bundles the facts the shared reviewpolicy package needs
@@ -152,7 +154,7 @@ func (m Model) markForReview() (tea.Model, tea.Cmd) {
 	}
 
 	// Validate transition with state machine
-	sm := workflow.DefaultMachine()
+	sm := m.workflowMachine()
 	if !sm.IsValidTransition(issue.Status, models.StatusInReview) {
 		return m, nil
 	}
@@ -243,19 +245,42 @@ func (m Model) executeDelete() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	deletedID := m.ConfirmIssueID
-
-	// Delete issue (captures previous state and logs atomically)
-	if err := m.DB.DeleteIssueLogged(deletedID, m.SessionID); err != nil {
-		m.closeDeleteConfirmModal()
-		return m, nil
+	// The tree may have changed while the modal was open. If the delete
+	// would now touch issues the user wasn't shown, ask again.
+	impact := computeDeleteImpact(m.DB, m.ConfirmIssueID)
+	changed := (m.ConfirmDeleteMode == "" && impact.HasChildren()) ||
+		(m.ConfirmDeleteMode == deleteModeCascade && !sameIDs(impact.Descendants, m.ConfirmImpact.Descendants))
+	m.ConfirmImpact = impact
+	if changed {
+		if !impact.HasChildren() {
+			m.ConfirmDeleteMode = ""
+		}
+		m.rebuildDeleteConfirmModal()
+		m.StatusMessage = "Issues under " + m.ConfirmIssueID + " changed, confirm again"
+		m.StatusIsError = true
+		return m, tea.Tick(3*time.Second, func(t time.Time) tea.Msg {
+			return ClearStatusMsg{}
+		})
 	}
 
-	// Close the delete confirmation modal
+	// Children that appear after this point are detached, never deleted
+	orphan := m.ConfirmDeleteMode != deleteModeCascade
+	deletedIDs, err := m.DB.DeleteIssueTreeLogged(m.ConfirmIssueID, m.SessionID, orphan)
 	m.closeDeleteConfirmModal()
+	if err != nil {
+		m.StatusMessage = "Delete failed: " + err.Error()
+		m.StatusIsError = true
+		return m, tea.Batch(m.fetchData(), tea.Tick(3*time.Second, func(t time.Time) tea.Msg {
+			return ClearStatusMsg{}
+		}))
+	}
+	deleted := make(map[string]bool, len(deletedIDs))
+	for _, id := range deletedIDs {
+		deleted[id] = true
+	}
 
 	// Close modal if we just deleted the issue being viewed
-	if modal := m.CurrentModal(); modal != nil && modal.IssueID == deletedID {
+	if modal := m.CurrentModal(); modal != nil && deleted[modal.IssueID] {
 		m.closeModal()
 	}
 
@@ -265,6 +290,23 @@ func (m Model) executeDelete() (tea.Model, tea.Cmd) {
 	return m, m.fetchData()
 }
 
+// sameIDs reports whether two ID lists hold the same IDs, in any order.
+func sameIDs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	seen := make(map[string]bool, len(a))
+	for _, id := range a {
+		seen[id] = true
+	}
+	for _, id := range b {
+		if !seen[id] {
+			return false
+		}
+	}
+	return true
+}
+
 // confirmClose opens confirmation dialog for closing selected issue
 // Works from both main panel selection and modal view
 func (m Model) confirmClose() (tea.Model, tea.Cmd) {
@@ -319,6 +361,12 @@ func (m Model) executeCloseWithReason() (tea.Model, tea.Cmd) {
 
 	issueID := m.CloseConfirmIssueID
 	reason := m.CloseConfirmInput.Value()
+	if m.CloseConfirmNeedsReason && strings.TrimSpace(reason) == "" {
+		// Keep the modal open so the reason can be filled in
+		m.StatusMessage = "A reason is required to close issues in this project"
+		m.StatusIsError = true
+		return m, tea.Tick(3*time.Second, func(t time.Time) tea.Msg { return ClearStatusMsg{} })
+	}
 
 	// Get the issue
 	issue, err := m.DB.GetIssue(issueID)
@@ -328,7 +376,7 @@ func (m Model) executeCloseWithReason() (tea.Model, tea.Cmd) {
 	}
 
 	// Validate transition with state machine
-	sm := workflow.DefaultMachine()
+	sm := m.workflowMachine()
 	if !sm.IsValidTransition(issue.Status, models.StatusClosed) {
 		m.closeCloseConfirmModal()
 		return m, nil
@@ -430,7 +478,7 @@ func (m Model) approveIssue() (tea.Model, tea.Cmd) {
 	}
 
 	// Validate transition with state machine
-	sm := workflow.DefaultMachine()
+	sm := m.workflowMachine()
 	if !sm.IsValidTransition(issue.Status, models.StatusClosed) {
 		return m, nil
 	}
@@ -618,7 +666,7 @@ func (m Model) reopenIssue() (tea.Model, tea.Cmd) {
 	}
 
 	// Validate transition with state machine
-	sm := workflow.DefaultMachine()
+	sm := m.workflowMachine()
 	if !sm.IsValidTransition(issue.Status, models.StatusOpen) {
 		m.StatusMessage = "Cannot reopen from " + string(issue.Status)
 		m.StatusIsError = true
@@ -670,6 +718,39 @@ func (m Model) reopenIssue() (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// undoLastAction reverts this session's most recent undoable action through
+// UndoFunc (the td undo path) and refreshes. The undo key only reaches here
+// while CanUndo is set; if the action was undone elsewhere since the last
+// refresh it says so.
+func (m Model) undoLastAction() (tea.Model, tea.Cmd) {
+	if m.UndoFunc == nil {
+		return m, nil
+	}
+	clearStatus := tea.Tick(2*time.Second, func(t time.Time) tea.Msg { return ClearStatusMsg{} })
+
+	action, err := m.UndoFunc(m.DB, m.SessionID)
+	if err != nil {
+		m.StatusMessage = "Undo failed: " + err.Error()
+		m.StatusIsError = true
+		return m, clearStatus
+	}
+	if action == nil {
+		m.CanUndo = false
+		m.StatusMessage = "Nothing to undo"
+		m.StatusIsError = false
+		return m, clearStatus
+	}
+
+	m.StatusMessage = fmt.Sprintf("UNDONE: %s %s %s", action.ActionType, action.EntityType, action.EntityID)
+	m.StatusIsError = false
+
+	cmds := []tea.Cmd{clearStatus, m.fetchData()}
+	if m.TaskListMode == TaskListModeBoard && m.BoardMode.Board != nil {
+		cmds = append(cmds, m.fetchBoardIssues(m.BoardMode.Board.ID))
+	}
+	return m, tea.Batch(cmds...)
+}
+
 // copyCurrentIssueToClipboard copies the current issue to clipboard as markdown
 // Works from modal view or list views (PanelCurrentWork, PanelTaskList)
 func (m Model) copyCurrentIssueToClipboard() (tea.Model, tea.Cmd) {
@@ -757,6 +838,49 @@ func (m Model) copyIssueIDToClipboard() (tea.Model, tea.Cmd) {
 	})
 }
 
+// clipboardLogLimit is how many recent logs the Markdown copy includes.
+const clipboardLogLimit = 10
+
+// copyIssueMarkdownWithLogs copies the current issue to clipboard in the td
+// export Markdown format, with acceptance criteria and recent logs.
+// Works from modal view or list views
+func (m Model) copyIssueMarkdownWithLogs() (tea.Model, tea.Cmd) {
+	var issue *models.Issue
+	if modal := m.CurrentModal(); modal != nil && modal.Issue != nil {
+		issue = modal.Issue
+	} else {
+		issueID := m.SelectedIssueID(m.ActivePanel)
+		if issueID == "" {
+			return m, nil
+		}
+		var err error
+		issue, err = m.DB.GetIssue(issueID)
+		if err != nil || issue == nil {
+			return m, nil
+		}
+	}
+
+	logs, _ := m.DB.GetLogs(issue.ID, clipboardLogLimit)
+	markdown := output.FormatIssueMarkdown(*issue, logs)
+
+	clipFn := m.ClipboardFn
+	if clipFn == nil {
+		clipFn = copyToClipboard
+	}
+	if err := clipFn(markdown); err != nil {
+		m.StatusMessage = "Copy failed: " + err.Error()
+		m.StatusIsError = true
+	} else {
+		m.StatusMessage = fmt.Sprintf("Yanked %s as markdown with %d log(s)", issue.ID, len(logs))
+		m.StatusIsError = false
+	}
+
+	// Clear status after 2 seconds
+	return m, tea.Tick(2*time.Second, func(t time.Time) tea.Msg {
+		return ClearStatusMsg{}
+	})
+}
+
 // sendToWorktree emits a message for embedding contexts to handle
 func (m Model) sendToWorktree() (tea.Model, tea.Cmd) {
 	var issueID, title string
@@ -938,7 +1062,7 @@ func (m Model) executeSelfReviewApprove() (tea.Model, tea.Cmd) {
 	}
 
 	// Validate transition with state machine
-	sm := workflow.DefaultMachine()
+	sm := m.workflowMachine()
 	if !sm.IsValidTransition(issue.Status, models.StatusClosed) {
 		return m, nil
 	}
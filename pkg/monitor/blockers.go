@@ -0,0 +1,109 @@
+package monitor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/dependency"
+	"github.com/marcus/td/internal/models"
+)
+
+// BlockerChainEntry is one issue in the transitive chain of dependencies
+// blocking a modal's issue. Depth 1 entries are direct dependencies.
+type BlockerChainEntry struct {
+	Issue models.Issue
+	Depth int
+}
+
+// buildBlockerChain walks issueID's dependencies transitively and returns
+// them depth-first, each with its current status. Issues that no longer
+// exist are skipped.
+func buildBlockerChain(database *db.DB, issueID string) []BlockerChainEntry {
+	links := dependency.GetTransitiveBlockers(database, issueID)
+	if len(links) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(links))
+	for i, link := range links {
+		ids[i] = link.ID
+	}
+	issues, _ := database.GetIssuesByIDs(ids)
+	issueMap := make(map[string]models.Issue, len(issues))
+	for _, issue := range issues {
+		issueMap[issue.ID] = issue
+	}
+
+	chain := make([]BlockerChainEntry, 0, len(links))
+	for _, link := range links {
+		if issue, ok := issueMap[link.ID]; ok {
+			chain = append(chain, BlockerChainEntry{Issue: issue, Depth: link.Depth})
+		}
+	}
+	return chain
+}
+
+// blockerOutstanding reports whether a blocker still holds up its dependents.
+func blockerOutstanding(issue models.Issue) bool {
+	return issue.Status != models.StatusClosed
+}
+
+// blockedBecauseSummary lists the outstanding direct blockers with their
+// statuses, e.g. "td-a1 (open), td-b2 (in_progress)". Returns "" when every
+// direct blocker is resolved.
+func blockedBecauseSummary(chain []BlockerChainEntry) string {
+	var parts []string
+	for _, entry := range chain {
+		if entry.Depth == 1 && blockerOutstanding(entry.Issue) {
+			parts = append(parts, fmt.Sprintf("%s (%s)", entry.Issue.ID, entry.Issue.Status))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// renderBlockerChain renders the "blocked because" summary and the
+// transitive blocker chain for the issue modal. Outstanding blockers use the
+// blocked color; resolved ones are dimmed. Nothing is shown unless the issue
+// is blocked or something in the chain is still outstanding.
+func renderBlockerChain(issue *models.Issue, chain []BlockerChainEntry, contentWidth int) []string {
+	if issue == nil || len(chain) == 0 {
+		return nil
+	}
+	outstanding := 0
+	for _, entry := range chain {
+		if blockerOutstanding(entry.Issue) {
+			outstanding++
+		}
+	}
+	if outstanding == 0 && issue.Status != models.StatusBlocked {
+		return nil
+	}
+
+	var lines []string
+	if summary := blockedBecauseSummary(chain); summary != "" {
+		lines = append(lines, blockedColor.Render(truncateString("Blocked because: "+summary, contentWidth)))
+	} else {
+		lines = append(lines, subtleStyle.Render("Blocked because: no open direct blockers"))
+	}
+
+	lines = append(lines, sectionHeader.Render(fmt.Sprintf("BLOCKER CHAIN (%d outstanding / %d)", outstanding, len(chain))))
+	for _, entry := range chain {
+		indent := strings.Repeat("  ", entry.Depth)
+		dep := entry.Issue
+		if blockerOutstanding(dep) {
+			lines = append(lines, fmt.Sprintf("%s%s %s %s",
+				indent,
+				blockedColor.Render("● "+dep.ID),
+				formatStatus(dep.Status),
+				truncateString(dep.Title, contentWidth-len(indent)-24)))
+		} else {
+			lines = append(lines, subtleStyle.Render(fmt.Sprintf("%s✓ %s %s",
+				indent,
+				dep.ID,
+				truncateString(dep.Title, contentWidth-len(indent)-15))))
+		}
+	}
+	lines = append(lines, "")
+	return lines
+}
@@ -0,0 +1,72 @@
+package monitor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/models"
+)
+
+func TestBuildBlockerChain(t *testing.T) {
+	database, err := db.Initialize(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	defer database.Close()
+
+	// target -> a (open) -> c (in_progress) -> a (cycle back, ignored)
+	//        -> b (closed) -> a (already a direct blocker)
+	target := createTestIssue(t, database, "Target", models.StatusBlocked)
+	a := createTestIssue(t, database, "Blocker A", models.StatusOpen)
+	b := createTestIssue(t, database, "Blocker B", models.StatusClosed)
+	c := createTestIssue(t, database, "Deep blocker C", models.StatusInProgress)
+	for _, dep := range [][2]string{
+		{target.ID, a.ID}, {target.ID, b.ID}, {a.ID, c.ID}, {b.ID, a.ID}, {c.ID, a.ID},
+	} {
+		if err := database.AddDependency(dep[0], dep[1], "depends_on"); err != nil {
+			t.Fatalf("AddDependency(%s, %s): %v", dep[0], dep[1], err)
+		}
+	}
+
+	chain := buildBlockerChain(database, target.ID)
+	if len(chain) != 3 {
+		t.Fatalf("chain = %+v, want 3 entries", chain)
+	}
+	index := map[string]int{}
+	for i, entry := range chain {
+		index[entry.Issue.ID] = i
+	}
+	wantDepth := map[string]int{a.ID: 1, b.ID: 1, c.ID: 2}
+	for id, depth := range wantDepth {
+		i, ok := index[id]
+		if !ok || chain[i].Depth != depth {
+			t.Errorf("%s: got %+v, want depth %d", id, chain, depth)
+		}
+	}
+	if index[c.ID] != index[a.ID]+1 {
+		t.Errorf("deep blocker %s should follow %s: %+v", c.ID, a.ID, chain)
+	}
+
+	if got, want := blockedBecauseSummary(chain), a.ID+" (open)"; got != want {
+		t.Errorf("blockedBecauseSummary = %q, want %q", got, want)
+	}
+
+	lines := renderBlockerChain(target, chain, 80)
+	if len(lines) != 2+len(chain)+1 {
+		t.Fatalf("renderBlockerChain returned %d lines: %q", len(lines), lines)
+	}
+	if header := ansi.Strip(lines[1]); !strings.Contains(header, "2 outstanding / 3") {
+		t.Errorf("chain header = %q", header)
+	}
+	if resolved := ansi.Strip(lines[2+index[b.ID]]); !strings.Contains(resolved, "✓ "+b.ID) {
+		t.Errorf("resolved blocker line = %q", resolved)
+	}
+
+	// Nothing to explain when every blocker is resolved and the issue isn't blocked.
+	if lines := renderBlockerChain(&models.Issue{Status: models.StatusOpen},
+		[]BlockerChainEntry{{Issue: *b, Depth: 1}}, 80); lines != nil {
+		t.Errorf("expected no chain section, got %q", lines)
+	}
+}
@@ -0,0 +1,181 @@
+package monitor
+
+import (
+	tea "charm.land/bubbletea/v2"
+)
+
+// BoardDrag tracks an issue being dragged with the mouse in board mode.
+// While dragging, the board cursor follows the drop target so the
+// highlighted row shows where the issue will land.
+type BoardDrag struct {
+	Active  bool
+	IssueID string // Issue being dragged
+	FromRow int    // Row the drag started on
+	Target  int    // Current drop target row, -1 when over no valid target
+}
+
+// boardDropTarget returns the row a drag that started at from would drop
+// onto when the pointer is over row over, or -1 if over is not a valid
+// target. In swimlanes (categories non-nil) an issue can only be dropped
+// within its own lane, matching keyboard moves.
+func boardDropTarget(rowCount int, categories []TaskListCategory, from, over int) int {
+	if from < 0 || from >= rowCount || over < 0 || over >= rowCount {
+		return -1
+	}
+	if categories != nil && categories[over] != categories[from] {
+		return -1
+	}
+	return over
+}
+
+// boardRowCount returns the number of rows in the current board view.
+func (m Model) boardRowCount() int {
+	if m.BoardMode.ViewMode == BoardViewSwimlanes {
+		return len(m.BoardMode.SwimlaneRows)
+	}
+	return len(m.BoardMode.Issues)
+}
+
+// boardRowCategories returns the lane of each swimlane row, or nil in the
+// backlog view where there are no lanes.
+func (m Model) boardRowCategories() []TaskListCategory {
+	if m.BoardMode.ViewMode != BoardViewSwimlanes {
+		return nil
+	}
+	cats := make([]TaskListCategory, len(m.BoardMode.SwimlaneRows))
+	for i, row := range m.BoardMode.SwimlaneRows {
+		cats[i] = row.Category
+	}
+	return cats
+}
+
+// boardCursor returns the cursor of the current board view.
+func (m Model) boardCursor() int {
+	if m.BoardMode.ViewMode == BoardViewSwimlanes {
+		return m.BoardMode.SwimlaneCursor
+	}
+	return m.BoardMode.Cursor
+}
+
+// setBoardCursor moves the cursor of the current board view.
+func (m *Model) setBoardCursor(row int) {
+	if m.BoardMode.ViewMode == BoardViewSwimlanes {
+		m.BoardMode.SwimlaneCursor = row
+		m.ensureSwimlaneCursorVisible()
+		return
+	}
+	m.BoardMode.Cursor = row
+}
+
+// boardRowIssueID returns the ID of the issue on a board row.
+func (m Model) boardRowIssueID(row int) string {
+	if m.BoardMode.ViewMode == BoardViewSwimlanes {
+		return m.BoardMode.SwimlaneRows[row].Issue.ID
+	}
+	return m.BoardMode.Issues[row].Issue.ID
+}
+
+// startBoardDrag begins dragging the issue on row.
+func (m Model) startBoardDrag(row int) Model {
+	if m.BoardMode.Board == nil || row < 0 || row >= m.boardRowCount() {
+		return m
+	}
+	m.BoardMode.Drag = BoardDrag{
+		Active:  true,
+		IssueID: m.boardRowIssueID(row),
+		FromRow: row,
+		Target:  row,
+	}
+	return m
+}
+
+// updateBoardDrag moves the drop target to the row under y.
+func (m Model) updateBoardDrag(x, y int) (tea.Model, tea.Cmd) {
+	drag := &m.BoardMode.Drag
+	over := -1
+	if m.HitTestPanel(x, y) == PanelTaskList {
+		over = m.HitTestRow(PanelTaskList, y)
+	}
+	drag.Target = boardDropTarget(m.boardRowCount(), m.boardRowCategories(), drag.FromRow, over)
+	if drag.Target >= 0 {
+		m.setBoardCursor(drag.Target)
+	} else {
+		m.setBoardCursor(drag.FromRow)
+	}
+	return m, nil
+}
+
+// endBoardDrag drops the dragged issue on the current target. Releasing
+// outside a valid target cancels the drag and leaves the board unchanged.
+func (m Model) endBoardDrag() (tea.Model, tea.Cmd) {
+	drag := m.BoardMode.Drag
+	m.BoardMode.Drag = BoardDrag{}
+	m.setBoardCursor(drag.FromRow)
+
+	// The board may have refreshed under the drag; only drop if the
+	// dragged issue is still on the row it started from
+	if drag.Target < 0 || drag.Target == drag.FromRow ||
+		drag.FromRow >= m.boardRowCount() || m.boardRowIssueID(drag.FromRow) != drag.IssueID {
+		return m, nil
+	}
+	return m.moveBoardIssueTo(drag.FromRow, drag.Target)
+}
+
+// moveBoardIssueTo moves the issue on row from to row to by repeating the
+// single-step keyboard move, re-reading positions between steps so each
+// step sees the result of the previous one.
+func (m Model) moveBoardIssueTo(from, to int) (tea.Model, tea.Cmd) {
+	direction := 1
+	if to < from {
+		direction = -1
+	}
+
+	var cmd tea.Cmd
+	for row := from; row != to; row += direction {
+		m.setBoardCursor(row)
+		m, cmd = m.moveIssueInBoard(direction)
+		if cmd == nil {
+			// Move refused or failed; refresh to show what was applied
+			return m, m.fetchBoardIssues(m.BoardMode.Board.ID)
+		}
+		m.swapBoardRows(row, row+direction)
+		if err := m.reloadBoardPositions(); err != nil {
+			m.StatusMessage = "Error: " + err.Error()
+			m.StatusIsError = true
+			return m, m.fetchBoardIssues(m.BoardMode.Board.ID)
+		}
+	}
+	m.setBoardCursor(to)
+	return m, cmd
+}
+
+// swapBoardRows swaps two adjacent rows of the current board view locally,
+// ahead of the refresh that would otherwise reorder them.
+func (m *Model) swapBoardRows(a, b int) {
+	if m.BoardMode.ViewMode == BoardViewSwimlanes {
+		rows := m.BoardMode.SwimlaneRows
+		rows[a], rows[b] = rows[b], rows[a]
+		return
+	}
+	issues := m.BoardMode.Issues
+	issues[a], issues[b] = issues[b], issues[a]
+}
+
+// reloadBoardPositions refreshes the local issue positions from the
+// database.
+func (m *Model) reloadBoardPositions() error {
+	positions, err := m.DB.GetBoardIssuePositions(m.BoardMode.Board.ID)
+	if err != nil {
+		return err
+	}
+	byID := make(map[string]int, len(positions))
+	for _, p := range positions {
+		byID[p.IssueID] = p.Position
+	}
+	for i := range m.BoardMode.Issues {
+		pos, ok := byID[m.BoardMode.Issues[i].Issue.ID]
+		m.BoardMode.Issues[i].HasPosition = ok
+		m.BoardMode.Issues[i].Position = pos
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package monitor
+
+import (
+	"testing"
+
+	"github.com/marcus/td/internal/models"
+)
+
+func TestBoardDropTarget(t *testing.T) {
+	lanes := []TaskListCategory{
+		CategoryInProgress, CategoryInProgress,
+		CategoryReady, CategoryReady, CategoryReady,
+	}
+
+	tests := []struct {
+		name       string
+		categories []TaskListCategory
+		from, over int
+		want       int
+	}{
+		{"backlog down", nil, 0, 4, 4},
+		{"backlog up", nil, 3, 1, 1},
+		{"backlog same row", nil, 2, 2, 2},
+		{"backlog outside rows", nil, 1, -1, -1},
+		{"backlog past end", nil, 1, 5, -1},
+		{"swimlane within lane", lanes, 2, 4, 4},
+		{"swimlane to other lane", lanes, 2, 1, -1},
+		{"swimlane first lane", lanes, 1, 0, 0},
+		{"invalid origin", lanes, 7, 2, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := boardDropTarget(5, tt.categories, tt.from, tt.over); got != tt.want {
+				t.Errorf("boardDropTarget(from=%d, over=%d) = %d, want %d", tt.from, tt.over, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEndBoardDragCancelsWithoutTarget(t *testing.T) {
+	m := newTestModel()
+	m.TaskListMode = TaskListModeBoard
+	m.BoardMode.Board = &models.Board{ID: "bd-1"}
+	m.BoardMode.ViewMode = BoardViewBacklog
+	m.BoardMode.Issues = []models.BoardIssueView{
+		{Issue: models.Issue{ID: "td-a"}},
+		{Issue: models.Issue{ID: "td-b"}},
+	}
+
+	m = m.startBoardDrag(0)
+	m.BoardMode.Drag.Target = -1 // released outside the list
+	m.BoardMode.Cursor = 1
+
+	result, cmd := m.endBoardDrag()
+	got := result.(Model)
+	if cmd != nil {
+		t.Error("cancelled drag should not move anything")
+	}
+	if got.BoardMode.Drag.Active {
+		t.Error("drag still active after release")
+	}
+	if got.BoardMode.Cursor != 0 {
+		t.Errorf("cursor = %d, want it back on the dragged issue (0)", got.BoardMode.Cursor)
+	}
+}
@@ -9,8 +9,10 @@ import (
 	"charm.land/bubbles/v2/textinput"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
+	"github.com/marcus/td/internal/db"
 	"github.com/marcus/td/internal/models"
 	"github.com/marcus/td/internal/query"
+	"github.com/marcus/td/internal/session"
 	"github.com/marcus/td/pkg/monitor/modal"
 	"github.com/marcus/td/pkg/monitor/mouse"
 )
@@ -227,15 +229,22 @@ func (m *Model) renderBoardEditorQueryPreview(contentWidth int) string {
 
 	if preview.Error != nil {
 		errStyle := lipgloss.NewStyle().Foreground(errorColor)
-		sb.WriteString(errStyle.Render("Error: " + preview.Error.Error()))
+		sb.WriteString(errStyle.Render("✗ Invalid query: " + preview.Error.Error()))
+		return sb.String()
+	}
+	if preview.Query == "" {
+		sb.WriteString(subtleStyle.Render("Preview: (checking...)"))
 		return sb.String()
 	}
 
 	// Show results
-	if preview.Count < 0 {
-		sb.WriteString("Matches: 5+ issue(s)")
-	} else {
-		sb.WriteString(fmt.Sprintf("Matches: %d issue(s)", preview.Count))
+	switch {
+	case preview.Count < 0:
+		sb.WriteString(fmt.Sprintf("✓ Matches: %d+ issue(s)", boardEditorPreviewCountCap))
+	case preview.Count == 0:
+		sb.WriteString(lipgloss.NewStyle().Foreground(warningColor).Render("⚠ Matches no issues"))
+	default:
+		sb.WriteString(fmt.Sprintf("✓ Matches: %d issue(s)", preview.Count))
 	}
 	if len(preview.Titles) > 0 {
 		for _, t := range preview.Titles {
@@ -247,7 +256,7 @@ func (m *Model) renderBoardEditorQueryPreview(contentWidth int) string {
 			sb.WriteString("\n  " + subtleStyle.Render("• "+title))
 		}
 		if preview.Count < 0 {
-			sb.WriteString("\n  " + subtleStyle.Render("... and more"))
+			sb.WriteString("\n  " + subtleStyle.Render("... and many more"))
 		} else if preview.Count > len(preview.Titles) {
 			sb.WriteString(fmt.Sprintf("\n  "+subtleStyle.Render("... and %d more"), preview.Count-len(preview.Titles)))
 		}
@@ -346,6 +355,12 @@ func (m Model) executeBoardEditorSave() (Model, tea.Cmd) {
 		return m, tea.Tick(3*time.Second, func(t time.Time) tea.Msg { return ClearStatusMsg{} })
 	}
 
+	if err := validateBoardQuery(queryStr); err != nil {
+		m.StatusMessage = "Invalid query: " + err.Error()
+		m.StatusIsError = true
+		return m, tea.Tick(3*time.Second, func(t time.Time) tea.Msg { return ClearStatusMsg{} })
+	}
+
 	isNew := m.BoardEditorMode == "create"
 
 	return m, func() tea.Msg {
@@ -383,38 +398,60 @@ func (m Model) boardEditorDebouncedPreview(queryStr string) tea.Cmd {
 	})
 }
 
+// Board editor preview sizes: how many titles are listed, and how many
+// matches are counted before the count is shown as "N+".
+const (
+	boardEditorPreviewTitles   = 5
+	boardEditorPreviewCountCap = 100
+)
+
 // boardEditorQueryPreview returns a command that executes the query for live preview
 func (m Model) boardEditorQueryPreview(queryStr string) tea.Cmd {
 	return func() tea.Msg {
-		if queryStr == "" {
-			return BoardEditorQueryPreviewMsg{Query: queryStr}
-		}
-
-		issues, err := query.Execute(m.DB, queryStr, m.SessionID, query.ExecuteOptions{
-			Limit: 6, // Get 6 to know if there are more than 5
+		identities, _ := session.IdentitySessionIDsByID(m.DB, m.SessionID)
+		return computeBoardEditorPreview(m.DB, queryStr, m.SessionID, query.ExecuteOptions{
+			Identities: identities,
+			Statuses:   m.CustomStatuses,
 		})
-		if err != nil {
-			return BoardEditorQueryPreviewMsg{Query: queryStr, Error: err}
-		}
+	}
+}
 
-		titles := make([]string, 0, 5)
-		for i, issue := range issues {
-			if i >= 5 {
-				break
-			}
-			titles = append(titles, issue.Title)
-		}
+// computeBoardEditorPreview validates queryStr the same way saving a board
+// does, then runs it with a small limit for the match count and the first
+// few titles. Count is -1 when more than boardEditorPreviewCountCap match.
+// opts carries the caller's identities and custom statuses; its limit is set
+// here.
+func computeBoardEditorPreview(src query.QuerySource, queryStr, sessionID string, opts query.ExecuteOptions) BoardEditorQueryPreviewMsg {
+	if strings.TrimSpace(queryStr) == "" {
+		return BoardEditorQueryPreviewMsg{Query: queryStr}
+	}
+	if err := validateBoardQuery(queryStr); err != nil {
+		return BoardEditorQueryPreviewMsg{Query: queryStr, Error: err}
+	}
 
-		count := len(issues)
-		hasMore := count > 5
-		if hasMore {
-			count = -1 // signal "more than 5" to the renderer
-		}
+	opts.Limit = boardEditorPreviewCountCap + 1
+	issues, err := query.Execute(src, queryStr, sessionID, opts)
+	if err != nil {
+		return BoardEditorQueryPreviewMsg{Query: queryStr, Error: err}
+	}
 
-		return BoardEditorQueryPreviewMsg{
-			Query:  queryStr,
-			Count:  count,
-			Titles: titles,
-		}
+	titles := make([]string, 0, boardEditorPreviewTitles)
+	for i := 0; i < len(issues) && i < boardEditorPreviewTitles; i++ {
+		titles = append(titles, issues[i].Title)
+	}
+	count := len(issues)
+	if count > boardEditorPreviewCountCap {
+		count = -1
+	}
+	return BoardEditorQueryPreviewMsg{Query: queryStr, Count: count, Titles: titles}
+}
+
+// validateBoardQuery checks queryStr with the registered db.QueryValidator,
+// which is what CreateBoard and UpdateBoard run, so the editor rejects
+// exactly the queries the save would.
+func validateBoardQuery(queryStr string) error {
+	if db.QueryValidator == nil || strings.TrimSpace(queryStr) == "" {
+		return nil
 	}
+	return db.QueryValidator(queryStr)
 }
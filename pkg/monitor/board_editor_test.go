@@ -0,0 +1,75 @@
+package monitor
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"charm.land/bubbles/v2/textarea"
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/models"
+	"github.com/marcus/td/internal/query"
+)
+
+func TestComputeBoardEditorPreview(t *testing.T) {
+	database, err := db.Initialize(t.TempDir())
+	if err != nil {
+		t.Fatalf("db init: %v", err)
+	}
+	defer database.Close()
+
+	orig := db.QueryValidator
+	defer func() { db.QueryValidator = orig }()
+	db.QueryValidator = func(queryStr string) error {
+		_, err := query.Parse(queryStr)
+		return err
+	}
+
+	for i := 0; i < 7; i++ {
+		issue := &models.Issue{Title: fmt.Sprintf("Bug %d", i), Type: models.TypeBug}
+		if err := database.CreateIssue(issue); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if err := database.CreateIssue(&models.Issue{Title: "A feature", Type: models.TypeFeature}); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	preview := computeBoardEditorPreview(database, "type = bug", "ses-1", query.ExecuteOptions{})
+	if preview.Error != nil {
+		t.Fatalf("valid query: unexpected error %v", preview.Error)
+	}
+	if preview.Count != 7 {
+		t.Errorf("valid query: count = %d, want 7", preview.Count)
+	}
+	if len(preview.Titles) != boardEditorPreviewTitles {
+		t.Errorf("valid query: %d titles, want %d", len(preview.Titles), boardEditorPreviewTitles)
+	}
+
+	preview = computeBoardEditorPreview(database, "type = epic", "ses-1", query.ExecuteOptions{})
+	if preview.Error != nil || preview.Count != 0 || len(preview.Titles) != 0 {
+		t.Errorf("no-match query: got %+v", preview)
+	}
+
+	preview = computeBoardEditorPreview(database, "type = bug AND (", "ses-1", query.ExecuteOptions{})
+	if preview.Error == nil {
+		t.Fatal("invalid query: expected a parse error")
+	}
+	if preview.Count != 0 || len(preview.Titles) != 0 {
+		t.Errorf("invalid query: expected no results, got %+v", preview)
+	}
+
+	input := textarea.New()
+	input.SetWidth(40)
+	input.SetValue("type = bug AND (")
+	m := Model{
+		BoardEditorQueryInput: &input,
+		BoardEditorPreview: &boardEditorPreviewData{
+			Query: preview.Query,
+			Error: preview.Error,
+		},
+	}
+	if got := m.renderBoardEditorQueryPreview(60); !strings.Contains(got, "Invalid query") {
+		t.Errorf("expected invalid query indicator, got %q", got)
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/marcus/td/internal/models"
 	"github.com/marcus/td/internal/query"
 	"github.com/marcus/td/internal/reviewpolicy"
+	"github.com/marcus/td/internal/session"
 	"github.com/marcus/td/internal/syncclient"
 	"github.com/marcus/td/internal/syncconfig"
 	"github.com/marcus/td/pkg/monitor/keymap"
@@ -82,6 +83,10 @@ func (m Model) currentContext() keymap.Context {
 			if modal.BlocksSectionFocused {
 				return keymap.ContextBlocksFocused
 			}
+			// Check if related section is focused
+			if modal.RelatedSectionFocused {
+				return keymap.ContextRelatedFocused
+			}
 		}
 		return keymap.ContextModal
 	}
@@ -98,10 +103,19 @@ func (m Model) currentContext() keymap.Context {
 
 // handleFormUpdate handles all messages when form is open
 func (m Model) handleFormUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// Discard confirmation sits on top of the form and takes all input
+	if m.DiscardConfirmOpen && m.DiscardConfirmModal != nil && m.DiscardConfirmMouseHandler != nil {
+		if model, cmd, handled := m.handleDiscardConfirmMsg(msg); handled {
+			return model, cmd
+		}
+	}
+
 	// Handle our custom key bindings first
 	if keyMsg, ok := msg.(tea.KeyMsg); ok {
 		key := keyMsg.String()
 		switch {
+		case key == "ctrl+c":
+			return m.leaveForm(true)
 		case key == "ctrl+s":
 			return m.executeCommand(keymap.CmdFormSubmit)
 		case key == "esc" && (m.FormState == nil || m.FormState.Autofill == nil || !m.FormState.Autofill.Active):
@@ -224,6 +238,7 @@ func (m Model) handleFormUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if afMsg, ok := msg.(AutofillResultMsg); ok {
 		if m.FormState != nil {
 			m.FormState.AutofillAll = afMsg.Items
+			m.FormState.AutofillLabels = afMsg.Labels
 			var epics []AutofillItem
 			for _, item := range afMsg.Items {
 				if item.Type == models.TypeEpic {
@@ -575,6 +590,81 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		// Fall through to keymap only for unhandled keys (like esc)
 	}
 
+	// Group prompt: route keys through the declarative modal (text input,
+	// tab/enter/esc), same as the close confirmation modal.
+	if m.GroupOpen && m.GroupModal != nil && m.GroupMouseHandler != nil {
+		action, cmd := m.GroupModal.HandleKey(msg)
+		if action != "" {
+			return m.handleGroupAction(action)
+		}
+		if cmd != nil {
+			return m, cmd
+		}
+		switch key {
+		case "tab", "shift+tab", "enter", "up", "down", "left", "right", "home", "end", "backspace", "delete":
+			return m, nil
+		}
+		if msg.Key().Text != "" {
+			return m, nil
+		}
+	}
+
+	// Quick-add prompt: same key routing as the group prompt.
+	if m.QuickAddOpen && m.QuickAddModal != nil && m.QuickAddMouseHandler != nil {
+		action, cmd := m.QuickAddModal.HandleKey(msg)
+		if action != "" {
+			return m.handleQuickAddAction(action)
+		}
+		if cmd != nil {
+			return m, cmd
+		}
+		switch key {
+		case "tab", "shift+tab", "enter", "up", "down", "left", "right", "home", "end", "backspace", "delete":
+			return m, nil
+		}
+		if msg.Key().Text != "" {
+			return m, nil
+		}
+	}
+
+	// Message history pane: read-only, closed by esc or the toggle key.
+	if m.StatusHistoryOpen && m.StatusHistoryModal != nil && m.StatusHistoryMouseHandler != nil {
+		if action, _ := m.StatusHistoryModal.HandleKey(msg); action == "cancel" || key == "M" {
+			m.closeStatusHistory()
+		}
+		return m, nil
+	}
+
+	// Dependency graph: j/k/enter/esc are handled by the modal's list
+	// section; D closes it like it opened.
+	if m.DepGraphOpen && m.DepGraphModal != nil && m.DepGraphMouseHandler != nil {
+		if key == "D" {
+			m.closeDepGraph()
+			return m, nil
+		}
+		action, cmd := m.DepGraphModal.HandleKey(msg)
+		if action != "" {
+			return m.handleDepGraphAction(action)
+		}
+		return m, cmd
+	}
+
+	// Label picker: j/k/enter/esc are handled by the modal's list section;
+	// c switches to picking the selected label's color.
+	if m.LabelPickerOpen && m.LabelPickerModal != nil && m.LabelPickerMouseHandler != nil {
+		if key == "c" && m.LabelColorTarget == "" {
+			return m.openLabelColorPicker()
+		}
+		action, cmd := m.LabelPickerModal.HandleKey(msg)
+		if action != "" {
+			return m.handleLabelPickerAction(action)
+		}
+		if cmd != nil {
+			return m, cmd
+		}
+		return m, nil
+	}
+
 	// Record-review modal: 'c' toggles decision between approved and
 	// changes_requested. All other keys are routed through the declarative
 	// modal (tab/shift+tab/enter/esc + text input). Without this block the
@@ -647,6 +737,14 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, inputCmd
 	}
 
+	// While a search highlights matches, n/N jump between them ahead of
+	// their main bindings
+	if ctx == keymap.ContextMain && m.highlightingSearch() && m.TaskListMode != TaskListModeBoard {
+		if cmd, found := m.Keymap.LookupLayer(msg, keymap.ContextSearchMatches); found {
+			return m.executeCommand(cmd)
+		}
+	}
+
 	// Look up command from keymap
 	cmd, found := m.Keymap.Lookup(msg, ctx)
 	if !found {
@@ -764,6 +862,12 @@ func (m Model) executeCommand(cmd keymap.Command) (tea.Model, tea.Cmd) {
 					modal.BlocksCursor++
 				}
 				// At last item, stay there
+			} else if modal.RelatedSectionFocused {
+				// Move related cursor within bounds
+				if modal.RelatedCursor < len(modal.Related)-1 {
+					modal.RelatedCursor++
+				}
+				// At last item, stay there
 			} else if modal.Scroll == 0 && modal.ParentEpic != nil {
 				// At top with parent epic, focus it first before scrolling
 				modal.ParentEpicFocused = true
@@ -839,6 +943,12 @@ func (m Model) executeCommand(cmd keymap.Command) (tea.Model, tea.Cmd) {
 					modal.BlocksCursor--
 				}
 				// At first item, stay there
+			} else if modal.RelatedSectionFocused {
+				// Move related cursor
+				if modal.RelatedCursor > 0 {
+					modal.RelatedCursor--
+				}
+				// At first item, stay there
 			} else if modal.Scroll == 0 && modal.ParentEpic != nil {
 				// At top of scroll with parent epic, focus it
 				modal.ParentEpicFocused = true
@@ -1217,6 +1327,8 @@ func (m Model) executeCommand(cmd keymap.Command) (tea.Model, tea.Cmd) {
 	case keymap.CmdSearch:
 		m.SearchMode = true
 		m.SearchQuery = ""
+		m.QueryPreset = ""
+		m.forgetBoardFilter()
 		m.SearchInput.SetValue("")
 		m.updatePanelBounds() // Recalc bounds for search bar
 		return m, m.SearchInput.Focus()
@@ -1225,8 +1337,15 @@ func (m Model) executeCommand(cmd keymap.Command) (tea.Model, tea.Cmd) {
 		m.IncludeClosed = !m.IncludeClosed
 		return m, tea.Batch(m.fetchData(), m.saveFilterState())
 
+	case keymap.CmdToggleAgeColumns:
+		m.ShowAgeColumns = !m.ShowAgeColumns
+		return m, nil
+
+	case keymap.CmdToggleExpiredClaims:
+		return m.toggleExpiredClaimsFilter()
+
 	case keymap.CmdCycleSortMode:
-		m.SortMode = (m.SortMode + 1) % 3
+		m.SortMode = (m.SortMode + 1) % 4
 		oldQuery := m.SearchQuery
 		m.SearchQuery = updateQuerySort(m.SearchQuery, m.SortMode)
 		// Recalc bounds if search bar visibility changed
@@ -1263,6 +1382,21 @@ func (m Model) executeCommand(cmd keymap.Command) (tea.Model, tea.Cmd) {
 		}
 		return m, tea.Batch(cmds...)
 
+	case keymap.CmdOpenLabelFilter:
+		return m.openLabelPicker()
+
+	case keymap.CmdCycleQueryPreset:
+		return m.cycleQueryPreset()
+
+	case keymap.CmdCycleIssueStatus:
+		return m.cycleIssueStatus()
+
+	case keymap.CmdRaiseIssuePriority:
+		return m.shiftIssuePriority(-1)
+
+	case keymap.CmdLowerIssuePriority:
+		return m.shiftIssuePriority(1)
+
 	case keymap.CmdMarkForReview:
 		// Mark for review works from modal, TaskList, or CurrentWork panel
 		if m.ModalOpen() {
@@ -1304,6 +1438,12 @@ func (m Model) executeCommand(cmd keymap.Command) (tea.Model, tea.Cmd) {
 		m.ActivePanel = PanelTaskList
 		m.Cursor[PanelTaskList] = 0
 		m.ScrollOffset[PanelTaskList] = 0
+		// Highlighting keeps the full list, so start on the first match
+		if matches := m.searchMatchRows(); m.highlightingSearch() && len(matches) > 0 {
+			m.Cursor[PanelTaskList] = matches[0]
+			m.saveSelectedID(PanelTaskList)
+			m.ensureCursorVisible(PanelTaskList)
+		}
 		return m, m.saveFilterState()
 
 	case keymap.CmdSearchCancel:
@@ -1314,8 +1454,11 @@ func (m Model) executeCommand(cmd keymap.Command) (tea.Model, tea.Cmd) {
 		}
 		// Otherwise exit search mode entirely
 		m.SearchMode = false
-		m.SearchQuery = ""
-		m.SearchInput.SetValue("")
+		if !m.restoreBoardFilter() {
+			m.SearchQuery = ""
+			m.QueryPreset = ""
+			m.SearchInput.SetValue("")
+		}
 		m.SearchInput.Blur()
 		m.updatePanelBounds() // Recalc bounds after search bar closes
 		// Refresh appropriate data based on mode
@@ -1328,8 +1471,12 @@ func (m Model) executeCommand(cmd keymap.Command) (tea.Model, tea.Cmd) {
 		if m.SearchQuery == "" {
 			return m, nil // Nothing to clear
 		}
-		m.SearchQuery = ""
-		m.SearchInput.SetValue("")
+		// A board query filter clears back to the filter it replaced
+		if !m.restoreBoardFilter() {
+			m.SearchQuery = ""
+			m.QueryPreset = ""
+			m.SearchInput.SetValue("")
+		}
 		// Recalc bounds since search bar disappears when query is empty
 		if !m.SearchMode {
 			m.updatePanelBounds()
@@ -1340,6 +1487,26 @@ func (m Model) executeCommand(cmd keymap.Command) (tea.Model, tea.Cmd) {
 		}
 		return m, tea.Batch(m.fetchData(), m.saveFilterState())
 
+	case keymap.CmdToggleSearchContent:
+		m.SearchContent = !m.SearchContent
+		if m.SearchQuery == "" {
+			return m, nil
+		}
+		return m, m.fetchData()
+
+	case keymap.CmdToggleSearchHighlight:
+		m.SearchHighlight = !m.SearchHighlight
+		if m.SearchQuery == "" {
+			return m, nil
+		}
+		return m, m.fetchData()
+
+	case keymap.CmdNextSearchMatch:
+		return m.jumpToSearchMatch(true)
+
+	case keymap.CmdPrevSearchMatch:
+		return m.jumpToSearchMatch(false)
+
 	// Confirmation commands
 	case keymap.CmdConfirm:
 		if m.CloseConfirmOpen {
@@ -1392,9 +1559,10 @@ func (m Model) executeCommand(cmd keymap.Command) (tea.Model, tea.Cmd) {
 			activeBlockers := filterActiveBlockers(modal.BlockedBy)
 			hasBlockedBy := len(activeBlockers) > 0
 			hasBlocks := len(modal.Blocks) > 0
+			hasRelated := len(modal.Related) > 0
 
 			// Cycle through sections in top-to-bottom order:
-			// scroll -> parent-epic -> epic-tasks -> blocked-by -> blocks -> scroll
+			// scroll -> parent-epic -> epic-tasks -> blocked-by -> blocks -> related -> scroll
 			if modal.ParentEpicFocused {
 				modal.ParentEpicFocused = false
 				if hasEpicTasks {
@@ -1406,6 +1574,9 @@ func (m Model) executeCommand(cmd keymap.Command) (tea.Model, tea.Cmd) {
 				} else if hasBlocks {
 					modal.BlocksSectionFocused = true
 					modal.BlocksCursor = 0
+				} else if hasRelated {
+					modal.RelatedSectionFocused = true
+					modal.RelatedCursor = 0
 				}
 				// else: back to scroll mode (all false)
 			} else if modal.TaskSectionFocused {
@@ -1416,6 +1587,9 @@ func (m Model) executeCommand(cmd keymap.Command) (tea.Model, tea.Cmd) {
 				} else if hasBlocks {
 					modal.BlocksSectionFocused = true
 					modal.BlocksCursor = 0
+				} else if hasRelated {
+					modal.RelatedSectionFocused = true
+					modal.RelatedCursor = 0
 				}
 				// else: back to scroll mode (all false)
 			} else if modal.BlockedBySectionFocused {
@@ -1423,10 +1597,20 @@ func (m Model) executeCommand(cmd keymap.Command) (tea.Model, tea.Cmd) {
 				if hasBlocks {
 					modal.BlocksSectionFocused = true
 					modal.BlocksCursor = 0
+				} else if hasRelated {
+					modal.RelatedSectionFocused = true
+					modal.RelatedCursor = 0
 				}
 				// else: back to scroll mode (all false)
 			} else if modal.BlocksSectionFocused {
 				modal.BlocksSectionFocused = false
+				if hasRelated {
+					modal.RelatedSectionFocused = true
+					modal.RelatedCursor = 0
+				}
+				// else: back to scroll mode (all false)
+			} else if modal.RelatedSectionFocused {
+				modal.RelatedSectionFocused = false
 				// back to scroll mode (all false)
 			} else {
 				// Currently in scroll mode - focus first available section
@@ -1441,6 +1625,9 @@ func (m Model) executeCommand(cmd keymap.Command) (tea.Model, tea.Cmd) {
 				} else if hasBlocks {
 					modal.BlocksSectionFocused = true
 					modal.BlocksCursor = 0
+				} else if hasRelated {
+					modal.RelatedSectionFocused = true
+					modal.RelatedCursor = 0
 				}
 				// else: no sections to focus, stay in scroll mode
 			}
@@ -1484,15 +1671,54 @@ func (m Model) executeCommand(cmd keymap.Command) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case keymap.CmdOpenRelatedIssue:
+		if modal := m.CurrentModal(); modal != nil && modal.RelatedSectionFocused {
+			if modal.RelatedCursor < len(modal.Related) {
+				modal.RelatedSectionFocused = false // Unfocus before pushing
+				return m.pushModal(modal.Related[modal.RelatedCursor].ID, m.ModalSourcePanel())
+			}
+		}
+		return m, nil
+
 	case keymap.CmdCopyToClipboard:
 		return m.copyCurrentIssueToClipboard()
 
 	case keymap.CmdCopyIDToClipboard:
 		return m.copyIssueIDToClipboard()
 
+	case keymap.CmdCopyMarkdownLogs:
+		return m.copyIssueMarkdownWithLogs()
+
+	case keymap.CmdExportView:
+		return m.exportCurrentView(viewExportMarkdown)
+
+	case keymap.CmdExportViewCSV:
+		return m.exportCurrentView(viewExportCSV)
+
+	case keymap.CmdToggleMark:
+		return m.toggleMark()
+
+	case keymap.CmdGroupMarked:
+		return m.groupMarkedAction()
+
+	case keymap.CmdUndo:
+		if !m.CanUndo {
+			return m, nil
+		}
+		return m.undoLastAction()
+
+	case keymap.CmdQuickAdd:
+		return m.openQuickAdd()
+
+	case keymap.CmdToggleStatusHistory:
+		return m.openStatusHistory()
+
 	case keymap.CmdSendToWorktree:
 		return m.sendToWorktree()
 
+	case keymap.CmdOpenDepGraph:
+		return m.openDepGraph()
+
 	// Form commands
 	case keymap.CmdNewIssue:
 		return m.openNewIssueForm()
@@ -1504,8 +1730,7 @@ func (m Model) executeCommand(cmd keymap.Command) (tea.Model, tea.Cmd) {
 		return m.submitForm()
 
 	case keymap.CmdFormCancel:
-		m.closeForm()
-		return m, nil
+		return m.leaveForm(false)
 
 	case keymap.CmdFormToggleExtend:
 		if m.FormState != nil {
@@ -1539,6 +1764,9 @@ func (m Model) executeCommand(cmd keymap.Command) (tea.Model, tea.Cmd) {
 	case keymap.CmdSelectBoard:
 		return m.selectBoard()
 
+	case keymap.CmdApplyBoardQuery:
+		return m.applyBoardQueryFilter()
+
 	case keymap.CmdCloseBoardPicker:
 		m.closeBoardPickerModal()
 		return m, nil
@@ -1615,7 +1843,7 @@ func (m Model) selectBoard() (Model, tea.Cmd) {
 	m.BoardMode.SwimlaneScroll = 0
 	m.BoardMode.ViewMode = BoardViewModeFromString(board.ViewMode)
 	if m.BoardMode.StatusFilter == nil {
-		m.BoardMode.StatusFilter = DefaultBoardStatusFilter()
+		m.BoardMode.StatusFilter = DefaultBoardStatusFilter(m.CustomStatuses)
 	}
 	m.closeBoardPickerModal()
 
@@ -1666,12 +1894,14 @@ func (m Model) exitBoardMode() (Model, tea.Cmd) {
 	hasSearchQuery := m.SearchQuery != ""
 	hasNonDefaultSort := m.SortMode != SortByPriority
 	hasTypeFilter := m.TypeFilterMode != TypeFilterNone
+	hasLabelFilter := m.LabelFilter != ""
 
-	if hasSearchQuery || hasNonDefaultSort || hasTypeFilter {
+	if hasSearchQuery || hasNonDefaultSort || hasTypeFilter || hasLabelFilter {
 		// Clear filters instead of exiting
 		m.SearchQuery = ""
 		m.SortMode = SortByPriority
 		m.TypeFilterMode = TypeFilterNone
+		m.LabelFilter = ""
 		m.updatePanelBounds()
 		m.StatusMessage = "Filters cleared"
 		// Refresh board issues with cleared filters
@@ -1686,6 +1916,13 @@ func (m Model) exitBoardMode() (Model, tea.Cmd) {
 	}
 
 	// No filters active, exit board mode
+	m.leaveBoardMode()
+	return m, m.fetchData()
+}
+
+// leaveBoardMode switches the task list back to the categorized view and
+// drops the board state.
+func (m *Model) leaveBoardMode() {
 	m.closeKanbanView()
 	m.TaskListMode = TaskListModeCategorized
 	m.BoardMode.Board = nil
@@ -1696,7 +1933,6 @@ func (m Model) exitBoardMode() (Model, tea.Cmd) {
 	m.BoardMode.SwimlaneScroll = 0
 	m.BoardMode.SwimlaneData = TaskListData{}
 	m.BoardMode.SwimlaneRows = nil
-	return m, m.fetchData()
 }
 
 // toggleBoardClosed toggles the closed status in the board status filter
@@ -1706,7 +1942,7 @@ func (m Model) toggleBoardClosed() (Model, tea.Cmd) {
 	}
 
 	if m.BoardMode.StatusFilter == nil {
-		m.BoardMode.StatusFilter = DefaultBoardStatusFilter()
+		m.BoardMode.StatusFilter = DefaultBoardStatusFilter(m.CustomStatuses)
 	}
 	m.BoardMode.StatusFilter[models.StatusClosed] = !m.BoardMode.StatusFilter[models.StatusClosed]
 
@@ -1730,7 +1966,7 @@ func (m Model) cycleBoardStatusFilter() (Model, tea.Cmd) {
 
 	// Cycle to next preset
 	m.BoardStatusPreset = (m.BoardStatusPreset + 1) % 7 // 7 presets
-	m.BoardMode.StatusFilter = m.BoardStatusPreset.ToFilter()
+	m.BoardMode.StatusFilter = m.BoardStatusPreset.ToFilter(m.CustomStatuses)
 
 	m.StatusMessage = "Filter: " + m.BoardStatusPreset.Name()
 
@@ -2175,7 +2411,7 @@ func (m Model) fetchBoardIssues(boardID string) tea.Cmd {
 		statusFilter[k] = v
 	}
 	if len(statusFilter) == 0 {
-		statusFilter = DefaultBoardStatusFilter()
+		statusFilter = DefaultBoardStatusFilter(m.CustomStatuses)
 	}
 
 	return func() tea.Msg {
@@ -2188,7 +2424,8 @@ func (m Model) fetchBoardIssues(boardID string) tea.Cmd {
 		var issues []models.BoardIssueView
 		if board.Query != "" {
 			// Execute TDQ query, then apply positions
-			queryResults, err := query.Execute(m.DB, board.Query, m.SessionID, query.ExecuteOptions{})
+			identities, _ := session.IdentitySessionIDsByID(m.DB, m.SessionID)
+			queryResults, err := m.QueryCache.Execute(m.DB, board.Query, m.SessionID, query.ExecuteOptions{Identities: identities, Statuses: m.CustomStatuses})
 			if err != nil {
 				return BoardIssuesMsg{BoardID: boardID, Error: err}
 			}
@@ -2225,11 +2462,18 @@ func (m Model) fetchBoardIssues(boardID string) tea.Cmd {
 // saveFilterState returns a command that persists the current filter state to config
 func (m Model) saveFilterState() tea.Cmd {
 	return func() tea.Msg {
+		// A board query filter is transient; keep the filter it replaced
+		searchQuery, preset := m.SearchQuery, m.QueryPreset
+		if m.BoardFilter != "" {
+			searchQuery, preset = m.BoardFilterPrevQuery, m.BoardFilterPrevPreset
+		}
 		state := &config.FilterState{
-			SearchQuery:   m.SearchQuery,
+			SearchQuery:   searchQuery,
 			SortMode:      m.SortMode.String(),
 			TypeFilter:    m.TypeFilterMode.String(),
+			LabelFilter:   m.LabelFilter,
 			IncludeClosed: m.IncludeClosed,
+			QueryPreset:   preset,
 		}
 		// Fire and forget - errors are not critical
 		_ = config.SetFilterState(m.BaseDir, state)
@@ -5,7 +5,9 @@ import (
 	"unicode/utf8"
 
 	tea "charm.land/bubbletea/v2"
+	"github.com/marcus/td/internal/db"
 	"github.com/marcus/td/internal/models"
+	"github.com/marcus/td/pkg/monitor/keymap"
 )
 
 func TestFetchBoardIssuesDeepCopiesStatusFilter(t *testing.T) {
@@ -100,3 +102,63 @@ func TestHelpFilterBackspaceUTF8(t *testing.T) {
 		})
 	}
 }
+
+// TestUndoCommandDispatchesToUndoFunc checks that u maps to CmdUndo and that
+// executing it calls the injected revert path with the monitor's session,
+// reporting what was undone (or that there was nothing to undo).
+func TestUndoCommandDispatchesToUndoFunc(t *testing.T) {
+	km := newTestKeymap()
+	if cmd, found := km.Lookup(tea.KeyPressMsg{Code: 'u', Text: "u"}, keymap.ContextMain); !found || cmd != keymap.CmdUndo {
+		t.Fatalf("u in main = %v (found=%v), want CmdUndo", cmd, found)
+	}
+
+	var calls []string
+	pending := []*models.ActionLog{
+		{ActionType: models.ActionUpdate, EntityType: "issue", EntityID: "td-abc123"},
+	}
+	m := Model{
+		SessionID: "ses-undo",
+		CanUndo:   true,
+		UndoFunc: func(_ *db.DB, sessionID string) (*models.ActionLog, error) {
+			calls = append(calls, sessionID)
+			if len(pending) == 0 {
+				return nil, nil
+			}
+			action := pending[0]
+			pending = pending[1:]
+			return action, nil
+		},
+	}
+
+	updated, cmd := m.executeCommand(keymap.CmdUndo)
+	m = updated.(Model)
+	if len(calls) != 1 || calls[0] != "ses-undo" {
+		t.Fatalf("UndoFunc calls = %v, want one call for ses-undo", calls)
+	}
+	if m.StatusMessage != "UNDONE: update issue td-abc123" || m.StatusIsError {
+		t.Errorf("status = %q (error=%v)", m.StatusMessage, m.StatusIsError)
+	}
+	if cmd == nil {
+		t.Error("expected a refresh command after undo")
+	}
+
+	updated, _ = m.executeCommand(keymap.CmdUndo)
+	m = updated.(Model)
+	if m.StatusMessage != "Nothing to undo" || m.StatusIsError || m.CanUndo {
+		t.Errorf("status with nothing to undo = %q (error=%v, canUndo=%v)", m.StatusMessage, m.StatusIsError, m.CanUndo)
+	}
+
+	// Once nothing is undoable the key is inert.
+	m.StatusMessage = ""
+	updated, cmd = m.executeCommand(keymap.CmdUndo)
+	if len(calls) != 2 || updated.(Model).StatusMessage != "" || cmd != nil {
+		t.Errorf("expected undo to be a no-op without an undoable action (calls=%v)", calls)
+	}
+
+	// Without a revert path wired in, the key does nothing.
+	m = Model{CanUndo: true}
+	updated, cmd = m.executeCommand(keymap.CmdUndo)
+	if updated.(Model).StatusMessage != "" || cmd != nil {
+		t.Error("expected undo to be a no-op without UndoFunc")
+	}
+}
@@ -1,7 +1,9 @@
 package monitor
 
 import (
+	"math"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -13,6 +15,7 @@ import (
 	"github.com/marcus/td/internal/query"
 	"github.com/marcus/td/internal/reviewpolicy"
 	"github.com/marcus/td/internal/session"
+	"github.com/marcus/td/internal/workflow"
 )
 
 // resolveMonitorPolicyMode resolves the project review policy mode, returning
@@ -28,6 +31,16 @@ func resolveMonitorPolicyMode(baseDir string) reviewpolicy.Mode {
 	return reviewpolicy.ModeStrict
 }
 
+// projectCustomStatuses returns the custom workflow statuses of the project
+// at baseDir. An unreadable or invalid workflow config has none.
+func projectCustomStatuses(baseDir string) []models.Status {
+	if baseDir == "" {
+		return nil
+	}
+	sm, _ := workflow.ForProject(baseDir)
+	return sm.CustomStatuses()
+}
+
 // categorizeInReviewIssue returns the monitor task-list category for an
 // in_review issue given the current session's role, impl involvement, and
 // whether the issue carries an active approval. The decision routes through
@@ -105,9 +118,28 @@ func categorizeInReviewIssue(
 // StatsData holds statistics for the stats modal
 type StatsData struct {
 	ExtendedStats *models.ExtendedStats
-	Error         error
+	// ClosedPerDay counts issues closed on each of the last
+	// velocityDays days, oldest first
+	ClosedPerDay []int
+	// SessionActivity is the hourly action count of the sessions active in
+	// the last sessionActivityHours hours, busiest first
+	SessionActivity []SessionActivity
+	Error           error
 }
 
+// SessionActivity is one session's actions per hour, oldest first.
+type SessionActivity struct {
+	SessionID string
+	PerHour   []int
+	Total     int
+}
+
+const (
+	velocityDays         = 14 // days covered by the closed-per-day sparkline
+	sessionActivityHours = 12 // hours covered by the session sparklines
+	maxActivitySessions  = 5  // sessions shown with sparklines
+)
+
 // StatsDataMsg carries fetched stats data
 type StatsDataMsg struct {
 	Data  *StatsData
@@ -121,7 +153,8 @@ func FetchData(database *db.DB, sessionID string, startedAt time.Time, searchQue
 }
 
 // FetchDataWithSearchMode retrieves all data needed for the monitor display
-// using explicit search mode semantics: auto|text|tdq.
+// using explicit search mode semantics: auto|text|tdq|content. content is
+// auto where plain-text queries also match log, comment and handoff text.
 func FetchDataWithSearchMode(database *db.DB, sessionID string, startedAt time.Time, searchQuery, searchMode string, includeClosed bool, sortMode SortMode) RefreshDataMsg {
 	msg := RefreshDataMsg{
 		Timestamp: time.Now(),
@@ -165,6 +198,11 @@ func FetchDataWithSearchMode(database *db.DB, sessionID string, startedAt time.T
 	// Get active sessions (activity in last 5 minutes)
 	msg.ActiveSessions = fetchActiveSessions(database)
 
+	// Check whether the monitor's session has anything left to undo
+	if action, err := database.GetLastAction(sessionID); err == nil && action != nil {
+		msg.CanUndo = true
+	}
+
 	return msg
 }
 
@@ -257,7 +295,8 @@ func isTDQQuery(q string) bool {
 		" = ", " != ", " ~ ", " !~ ",
 		" < ", " > ", " <= ", " >= ",
 		" AND ", " OR ", "NOT ",
-		"has(", "is(", "any(", "blocks(", "blocked_by(", "descendant_of(",
+		"has(", "is(", "any(", "label(", "blocks(", "blocked_by(", "depends_on(", "dependency_of(", "related_to(", "descendant_of(",
+		"updated_by_session(",
 		"log.", "comment.", "handoff.", "file.",
 		"@me", "EMPTY",
 		"sort:", // Sort prefix is considered TDQ
@@ -312,6 +351,7 @@ func fetchTaskList(database *db.DB, sessionID string, searchQuery, searchMode st
 // to pin the policy mode.
 func fetchTaskListWithMode(database *db.DB, sessionID string, searchQuery, searchMode string, includeClosed bool, sortMode SortMode, mode reviewpolicy.Mode) TaskListData {
 	var data TaskListData
+	custom := projectCustomStatuses(database.BaseDir())
 
 	// Get default sort from SortMode (used for non-TDQ queries)
 	sortBy, sortDesc := sortMode.ToDBOptions()
@@ -338,6 +378,7 @@ func fetchTaskListWithMode(database *db.DB, sessionID string, searchQuery, searc
 	if err != nil {
 		rejectedIDs = make(map[string]bool) // Safe fallback on error
 	}
+	data.ReworkIDs = rejectedIDs
 
 	// Helper to check if issue is blocked by unclosed dependencies
 	isBlockedByDeps := func(issueID string) bool {
@@ -354,7 +395,9 @@ func fetchTaskListWithMode(database *db.DB, sessionID string, searchQuery, searc
 	// - tdq: always attempt TDQ execution (when query is non-empty)
 	// - text: never attempt TDQ execution
 	// - auto/empty/unknown: TDQ auto-detection with fallback to text search
+	// - content: auto, with text search also matching issue activity
 	searchModeNorm := strings.ToLower(strings.TrimSpace(searchMode))
+	searchContent := searchModeNorm == "content"
 	useTDQ := false
 	if searchQuery != "" {
 		switch searchModeNorm {
@@ -369,7 +412,8 @@ func fetchTaskListWithMode(database *db.DB, sessionID string, searchQuery, searc
 
 	if useTDQ {
 		// Use TDQ to filter issues across all categories
-		allIssues, err := query.Execute(database, searchQuery, sessionID, query.ExecuteOptions{})
+		identities, _ := session.IdentitySessionIDsByID(database, sessionID)
+		allIssues, err := query.Execute(database, searchQuery, sessionID, query.ExecuteOptions{Identities: identities, Statuses: custom})
 		if err != nil {
 			// Fall back to simple search on TDQ parse error
 			useTDQ = false
@@ -409,8 +453,13 @@ func fetchTaskListWithMode(database *db.DB, sessionID string, searchQuery, searc
 					if includeClosed {
 						data.Closed = append(data.Closed, issue)
 					}
+				default:
+					if slices.Contains(custom, issue.Status) {
+						data.InProgress = append(data.InProgress, issue)
+					}
 				}
 			}
+			loadStatusAges(database, &data, sortMode)
 			return data
 		}
 	}
@@ -420,7 +469,8 @@ func fetchTaskListWithMode(database *db.DB, sessionID string, searchQuery, searc
 	var openIssues []models.Issue
 	if searchQuery != "" && !useTDQ {
 		results, _ := database.SearchIssuesRanked(searchQuery, db.ListIssuesOptions{
-			Status: []models.Status{models.StatusOpen},
+			Status:        []models.Status{models.StatusOpen},
+			SearchContent: searchContent,
 		})
 		openIssues = extractIssues(results)
 	} else if searchQuery == "" {
@@ -445,7 +495,8 @@ func fetchTaskListWithMode(database *db.DB, sessionID string, searchQuery, searc
 	var inProgressIssues []models.Issue
 	if searchQuery != "" && !useTDQ {
 		results, _ := database.SearchIssuesRanked(searchQuery, db.ListIssuesOptions{
-			Status: []models.Status{models.StatusInProgress},
+			Status:        []models.Status{models.StatusInProgress},
+			SearchContent: searchContent,
 		})
 		inProgressIssues = extractIssues(results)
 	} else if searchQuery == "" {
@@ -455,6 +506,21 @@ func fetchTaskListWithMode(database *db.DB, sessionID string, searchQuery, searc
 			SortDesc: sortDesc,
 		})
 	}
+	// Issues in custom workflow statuses are work under way, so they are
+	// listed with in-progress work
+	if len(custom) > 0 {
+		if searchQuery != "" && !useTDQ {
+			results, _ := database.SearchIssuesRanked(searchQuery, db.ListIssuesOptions{Status: custom, SearchContent: searchContent})
+			inProgressIssues = append(inProgressIssues, extractIssues(results)...)
+		} else if searchQuery == "" {
+			customIssues, _ := database.ListIssues(db.ListIssuesOptions{
+				Status:   custom,
+				SortBy:   sortBy,
+				SortDesc: sortDesc,
+			})
+			inProgressIssues = append(inProgressIssues, customIssues...)
+		}
+	}
 	for _, issue := range inProgressIssues {
 		if rejectedIDs[issue.ID] {
 			data.NeedsRework = append(data.NeedsRework, issue)
@@ -472,7 +538,8 @@ func fetchTaskListWithMode(database *db.DB, sessionID string, searchQuery, searc
 	var inReviewIssues []models.Issue
 	if searchQuery != "" && !useTDQ {
 		results, _ := database.SearchIssuesRanked(searchQuery, db.ListIssuesOptions{
-			Status: []models.Status{models.StatusInReview},
+			Status:        []models.Status{models.StatusInReview},
+			SearchContent: searchContent,
 		})
 		inReviewIssues = extractIssues(results)
 	} else if searchQuery == "" {
@@ -499,7 +566,8 @@ func fetchTaskListWithMode(database *db.DB, sessionID string, searchQuery, searc
 	// Blocked issues: explicit blocked status + issues blocked by dependencies
 	if searchQuery != "" && !useTDQ {
 		results, _ := database.SearchIssuesRanked(searchQuery, db.ListIssuesOptions{
-			Status: []models.Status{models.StatusBlocked},
+			Status:        []models.Status{models.StatusBlocked},
+			SearchContent: searchContent,
 		})
 		data.Blocked = append(extractIssues(results), blockedByDep...)
 	} else if searchQuery == "" {
@@ -517,7 +585,8 @@ func fetchTaskListWithMode(database *db.DB, sessionID string, searchQuery, searc
 	if includeClosed {
 		if searchQuery != "" && !useTDQ {
 			results, _ := database.SearchIssuesRanked(searchQuery, db.ListIssuesOptions{
-				Status: []models.Status{models.StatusClosed},
+				Status:        []models.Status{models.StatusClosed},
+				SearchContent: searchContent,
 			})
 			data.Closed = extractIssues(results)
 		} else if searchQuery == "" {
@@ -529,9 +598,63 @@ func fetchTaskListWithMode(database *db.DB, sessionID string, searchQuery, searc
 		}
 	}
 
+	loadStatusAges(database, &data, sortMode)
+	loadExpiredClaims(database, &data, time.Now())
 	return data
 }
 
+// loadStatusAges fills data.StatusSince for every listed issue and, for the
+// staleness sort, orders each section longest-in-status first.
+func loadStatusAges(database *db.DB, data *TaskListData, sortMode SortMode) {
+	sections := []*[]models.Issue{
+		&data.Reviewable, &data.ReadyToClose, &data.NeedsRework, &data.InProgress, &data.Ready,
+		&data.PendingReview, &data.PendingOther, &data.Blocked, &data.Closed,
+	}
+	var ids []string
+	for _, section := range sections {
+		for _, issue := range *section {
+			ids = append(ids, issue.ID)
+		}
+	}
+	changedAt, err := database.GetStatusChangedAt(ids)
+	if err != nil {
+		changedAt = make(map[string]time.Time) // Fall back to creation times
+	}
+
+	data.StatusSince = make(map[string]time.Time, len(ids))
+	for _, section := range sections {
+		for i := range *section {
+			issue := &(*section)[i]
+			data.StatusSince[issue.ID] = statusSince(issue, changedAt)
+		}
+		if sortMode == SortByStaleness {
+			issues := *section
+			sort.SliceStable(issues, func(i, j int) bool {
+				return staleBefore(&issues[i], &issues[j], data.StatusSince)
+			})
+		}
+	}
+}
+
+// statusSince returns when an issue entered its current status: its last
+// status change, or its creation when the status never changed.
+func statusSince(issue *models.Issue, changedAt map[string]time.Time) time.Time {
+	if t, ok := changedAt[issue.ID]; ok {
+		return t
+	}
+	return issue.CreatedAt
+}
+
+// staleBefore orders issues for the staleness sort: longest in their current
+// status first, then oldest first.
+func staleBefore(a, b *models.Issue, since map[string]time.Time) bool {
+	sa, sb := statusSince(a, since), statusSince(b, since)
+	if !sa.Equal(sb) {
+		return sa.Before(sb)
+	}
+	return a.CreatedAt.Before(b.CreatedAt)
+}
+
 // fetchActiveSessions retrieves sessions with activity in the last 5 minutes
 func fetchActiveSessions(database *db.DB) []string {
 	since := time.Now().Add(-5 * time.Minute)
@@ -611,9 +734,81 @@ func FetchStats(database *db.DB) StatsDataMsg {
 			Error: err,
 		}
 	}
-	return StatsDataMsg{
-		Data: &StatsData{ExtendedStats: stats},
+	data := &StatsData{ExtendedStats: stats}
+
+	now := time.Now()
+	dayStart := startOfDay(now).AddDate(0, 0, -(velocityDays - 1))
+	if closed, err := database.GetClosedTimesSince(dayStart); err == nil {
+		data.ClosedPerDay = bucketByDay(closed, now, velocityDays)
+	}
+	hourStart := now.Truncate(time.Hour).Add(-(sessionActivityHours - 1) * time.Hour)
+	if actions, err := database.GetActionTimesBySession(hourStart); err == nil {
+		data.SessionActivity = sessionActivity(actions, now, sessionActivityHours)
+	}
+
+	return StatsDataMsg{Data: data}
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// bucketByDay counts times per local calendar day over the days ending
+// today (now's day), oldest first. Times outside the window are dropped.
+func bucketByDay(times []time.Time, now time.Time, days int) []int {
+	buckets := make([]int, days)
+	today := startOfDay(now)
+	for _, t := range times {
+		// Round so a 23 or 25 hour day across a DST change counts as one
+		ago := int(math.Round(today.Sub(startOfDay(t.In(now.Location()))).Hours() / 24))
+		if ago < 0 || ago >= days {
+			continue
+		}
+		buckets[days-1-ago]++
+	}
+	return buckets
+}
+
+// bucketByHour counts times per clock hour over the hours ending with
+// now's hour, oldest first. Times outside the window are dropped.
+func bucketByHour(times []time.Time, now time.Time, hours int) []int {
+	buckets := make([]int, hours)
+	current := now.Truncate(time.Hour)
+	for _, t := range times {
+		ago := int(current.Sub(t.Truncate(time.Hour)) / time.Hour)
+		if ago < 0 || ago >= hours {
+			continue
+		}
+		buckets[hours-1-ago]++
+	}
+	return buckets
+}
+
+// sessionActivity buckets each session's action times by hour and returns
+// the busiest maxActivitySessions sessions.
+func sessionActivity(actions map[string][]time.Time, now time.Time, hours int) []SessionActivity {
+	result := make([]SessionActivity, 0, len(actions))
+	for sessionID, times := range actions {
+		perHour := bucketByHour(times, now, hours)
+		total := 0
+		for _, n := range perHour {
+			total += n
+		}
+		if total == 0 {
+			continue
+		}
+		result = append(result, SessionActivity{SessionID: sessionID, PerHour: perHour, Total: total})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Total != result[j].Total {
+			return result[i].Total > result[j].Total
+		}
+		return result[i].SessionID < result[j].SessionID
+	})
+	if len(result) > maxActivitySessions {
+		result = result[:maxActivitySessions]
 	}
+	return result
 }
 
 // ComputeBoardIssueCategories sets the Category field on each BoardIssueView.
@@ -625,6 +820,8 @@ func ComputeBoardIssueCategories(database *db.DB, issues []models.BoardIssueView
 		return
 	}
 
+	custom := projectCustomStatuses(database.BaseDir())
+
 	// Use pre-computed rejected IDs if available, otherwise query
 	rejectedIDs := precomputedRejectedIDs
 	if rejectedIDs == nil {
@@ -682,7 +879,12 @@ func ComputeBoardIssueCategories(database *db.DB, issues []models.BoardIssueView
 		case models.StatusClosed:
 			category = CategoryClosed
 		default:
-			category = CategoryReady
+			// Custom workflow statuses are work under way
+			if slices.Contains(custom, issue.Status) {
+				category = CategoryInProgress
+			} else {
+				category = CategoryReady
+			}
 		}
 
 		issues[i].Category = string(category)
@@ -701,6 +903,16 @@ func CategorizeBoardIssues(database *db.DB, issues []models.BoardIssueView, sess
 		return data
 	}
 
+	// Resolve the rework set once so categories and row badges agree
+	if rejectedIDs == nil {
+		var err error
+		rejectedIDs, err = database.GetRejectedInProgressIssueIDs()
+		if err != nil {
+			rejectedIDs = make(map[string]bool)
+		}
+	}
+	data.ReworkIDs = rejectedIDs
+
 	// Compute categories (sets Category field on each issue)
 	ComputeBoardIssueCategories(database, issues, sessionID, rejectedIDs)
 
@@ -721,8 +933,22 @@ func CategorizeBoardIssues(database *db.DB, issues []models.BoardIssueView, sess
 		categories[cat] = append(categories[cat], biv)
 	}
 
+	// Resolve time in status for the age columns and the staleness sort
+	ids := make([]string, len(issues))
+	for i, biv := range issues {
+		ids[i] = biv.Issue.ID
+	}
+	changedAt, err := database.GetStatusChangedAt(ids)
+	if err != nil {
+		changedAt = make(map[string]time.Time)
+	}
+	data.StatusSince = make(map[string]time.Time, len(issues))
+	for i := range issues {
+		data.StatusSince[issues[i].Issue.ID] = statusSince(&issues[i].Issue, changedAt)
+	}
+
 	// Sort each category with position awareness
-	sortFunc := getSortFuncWithPosition(sortMode)
+	sortFunc := getSortFuncWithPosition(sortMode, data.StatusSince)
 	for cat := range categories {
 		sort.Slice(categories[cat], sortFunc(categories[cat]))
 	}
@@ -812,7 +1038,8 @@ func filterBoardIssuesByQuery(issues []models.BoardIssueView, query string) []mo
 
 // getSortFuncWithPosition returns a sort function that respects backlog positions.
 // Positioned issues come first (by position ASC), then unpositioned (by sortMode).
-func getSortFuncWithPosition(sortMode SortMode) func(issues []models.BoardIssueView) func(i, j int) bool {
+// statusSince is consulted by the staleness sort.
+func getSortFuncWithPosition(sortMode SortMode, statusSince map[string]time.Time) func(issues []models.BoardIssueView) func(i, j int) bool {
 	return func(issues []models.BoardIssueView) func(i, j int) bool {
 		return func(i, j int) bool {
 			// Positioned issues come before unpositioned
@@ -832,6 +1059,8 @@ func getSortFuncWithPosition(sortMode SortMode) func(issues []models.BoardIssueV
 				return issues[i].Issue.CreatedAt.After(issues[j].Issue.CreatedAt)
 			case SortByUpdatedDesc:
 				return issues[i].Issue.UpdatedAt.After(issues[j].Issue.UpdatedAt)
+			case SortByStaleness:
+				return staleBefore(&issues[i].Issue, &issues[j].Issue, statusSince)
 			default: // SortByPriority
 				if issues[i].Issue.Priority != issues[j].Issue.Priority {
 					return issues[i].Issue.Priority < issues[j].Issue.Priority
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/marcus/td/internal/config"
 	"github.com/marcus/td/internal/db"
 	"github.com/marcus/td/internal/models"
 )
@@ -160,7 +161,7 @@ func TestGetSortFuncWithPosition(t *testing.T) {
 			name:     "mixed - positioned come before unpositioned",
 			sortMode: SortByPriority,
 			issues: []models.BoardIssueView{
-				{Issue: models.Issue{ID: "unpos-p0", Priority: models.PriorityP0, UpdatedAt: now}}, // high priority but unpositioned
+				{Issue: models.Issue{ID: "unpos-p0", Priority: models.PriorityP0, UpdatedAt: now}},               // high priority but unpositioned
 				{Issue: models.Issue{ID: "pos-p3", Priority: models.PriorityP3}, Position: 1, HasPosition: true}, // low priority but positioned
 				{Issue: models.Issue{ID: "unpos-p1", Priority: models.PriorityP1, UpdatedAt: now}},
 			},
@@ -193,7 +194,7 @@ func TestGetSortFuncWithPosition(t *testing.T) {
 			issues := make([]models.BoardIssueView, len(tt.issues))
 			copy(issues, tt.issues)
 
-			sortFunc := getSortFuncWithPosition(tt.sortMode)
+			sortFunc := getSortFuncWithPosition(tt.sortMode, nil)
 			sort.Slice(issues, sortFunc(issues))
 
 			gotIDs := make([]string, len(issues))
@@ -327,3 +328,276 @@ func TestComputeBoardIssueCategoriesClosedDepUnblocks(t *testing.T) {
 		t.Errorf("dependent with closed blocker: got %q, want %q", issues[0].Category, CategoryReady)
 	}
 }
+
+func TestCustomStatusesComeFromTheProject(t *testing.T) {
+	qaProject, plainProject := t.TempDir(), t.TempDir()
+	if err := config.Save(qaProject, &models.Config{Workflow: &models.WorkflowConfig{
+		Statuses: []models.Status{"qa"},
+	}}); err != nil {
+		t.Fatalf("Save config: %v", err)
+	}
+
+	// The same issue in two projects: only the one whose workflow has qa
+	// lists it with in-progress work
+	for _, tc := range []struct {
+		dir        string
+		inProgress bool
+	}{{qaProject, true}, {plainProject, false}} {
+		database, err := db.Initialize(tc.dir)
+		if err != nil {
+			t.Fatalf("failed to open db: %v", err)
+		}
+		defer database.Close()
+		issue := createTestIssue(t, database, "In QA", models.StatusOpen)
+		issue.Status = "qa"
+		if err := database.UpdateIssue(issue); err != nil {
+			t.Fatalf("UpdateIssue: %v", err)
+		}
+
+		data := fetchTaskList(database, "test-session", "", "", false, SortByPriority)
+		if got := len(data.InProgress) == 1; got != tc.inProgress {
+			t.Errorf("%s: in progress = %v, want %v", tc.dir, data.InProgress, tc.inProgress)
+		}
+		views := []models.BoardIssueView{{Issue: *issue}}
+		ComputeBoardIssueCategories(database, views, "test-session", nil)
+		if got := views[0].Category == string(CategoryInProgress); got != tc.inProgress {
+			t.Errorf("%s: board category = %q", tc.dir, views[0].Category)
+		}
+	}
+}
+
+func TestReworkIDsFeedRowTags(t *testing.T) {
+	database, err := db.Initialize(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	defer database.Close()
+
+	// Rejected issues are reset to open, so they land in Ready rather than
+	// the NeedsRework bucket; the rework set is what flags them.
+	rejectedOpen := createTestIssue(t, database, "Rejected and reopened", models.StatusOpen)
+	rejectedWIP := createTestIssue(t, database, "Rejected and picked up", models.StatusInProgress)
+	plain := createTestIssue(t, database, "Never rejected", models.StatusOpen)
+	for _, issue := range []*models.Issue{rejectedOpen, rejectedWIP} {
+		if err := database.LogAction(&models.ActionLog{
+			SessionID:  "ses_reviewer",
+			ActionType: models.ActionReject,
+			EntityType: "issue",
+			EntityID:   issue.ID,
+		}); err != nil {
+			t.Fatalf("LogAction failed: %v", err)
+		}
+	}
+
+	data := fetchTaskList(database, "test-session", "", "", false, SortByPriority)
+	if !data.ReworkIDs[rejectedOpen.ID] || !data.ReworkIDs[rejectedWIP.ID] {
+		t.Fatalf("ReworkIDs = %v, want both rejected issues", data.ReworkIDs)
+	}
+	if data.ReworkIDs[plain.ID] {
+		t.Errorf("ReworkIDs unexpectedly contains %s", plain.ID)
+	}
+
+	rework := (Model{}).formatCategoryTag(CategoryNeedsRework)
+	m := Model{TaskList: data}
+	if got := m.rowTag(rejectedOpen.ID, CategoryReady); got != rework {
+		t.Errorf("rejected open row tag = %q, want %q", got, rework)
+	}
+	if got := m.rowTag(plain.ID, CategoryReady); got == rework {
+		t.Errorf("plain row tagged as rework")
+	}
+
+	// Board views read the same set from the swimlane data.
+	issues := []models.BoardIssueView{{Issue: *rejectedOpen}, {Issue: *plain}}
+	board := CategorizeBoardIssues(database, issues, "test-session", SortByPriority, nil)
+	m = Model{TaskListMode: TaskListModeBoard}
+	m.BoardMode.SwimlaneData = board
+	if got := m.rowTag(rejectedOpen.ID, CategoryReady); got != rework {
+		t.Errorf("board row tag = %q, want %q", got, rework)
+	}
+
+	// A later review clears the flag on the next refresh.
+	if err := database.LogAction(&models.ActionLog{
+		SessionID:  "ses_impl",
+		ActionType: models.ActionReview,
+		EntityType: "issue",
+		EntityID:   rejectedOpen.ID,
+		Timestamp:  time.Now().Add(time.Second),
+	}); err != nil {
+		t.Fatalf("LogAction failed: %v", err)
+	}
+	m = Model{TaskList: fetchTaskList(database, "test-session", "", "", false, SortByPriority)}
+	if got := m.rowTag(rejectedOpen.ID, CategoryReady); got == rework {
+		t.Errorf("reviewed issue still tagged as rework after refresh")
+	}
+}
+
+func TestStalenessSortOrdersByTimeInStatus(t *testing.T) {
+	database, err := db.Initialize(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	defer database.Close()
+
+	now := time.Now()
+	backdate := func(issue *models.Issue, age time.Duration) {
+		t.Helper()
+		if _, err := database.Conn().Exec(`UPDATE issues SET created_at = ? WHERE id = ?`, now.Add(-age), issue.ID); err != nil {
+			t.Fatalf("backdate %s: %v", issue.ID, err)
+		}
+	}
+	day := 24 * time.Hour
+
+	// Untouched for ten days
+	idle := createTestIssue(t, database, "Idle", models.StatusOpen)
+	backdate(idle, 10*day)
+	// Oldest issue, but reopened two days ago
+	reopened := createTestIssue(t, database, "Reopened", models.StatusOpen)
+	backdate(reopened, 20*day)
+	if err := database.LogAction(&models.ActionLog{
+		SessionID:    "ses_test",
+		ActionType:   models.ActionReopen,
+		EntityType:   "issue",
+		EntityID:     reopened.ID,
+		PreviousData: `{"status":"closed"}`,
+		NewData:      `{"status":"open"}`,
+	}); err != nil {
+		t.Fatalf("LogAction failed: %v", err)
+	}
+	if _, err := database.Conn().Exec(`UPDATE action_log SET timestamp = ? WHERE entity_id = ?`, now.Add(-2*day), reopened.ID); err != nil {
+		t.Fatalf("backdate action: %v", err)
+	}
+	fresh := createTestIssue(t, database, "Fresh", models.StatusOpen)
+	backdate(fresh, day)
+
+	data := fetchTaskList(database, "test-session", "", "", false, SortByStaleness)
+	var got []string
+	for _, issue := range data.Ready {
+		got = append(got, issue.Title)
+	}
+	want := []string{"Idle", "Reopened", "Fresh"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("stale order = %v, want %v", got, want)
+	}
+
+	if since := data.StatusSince[reopened.ID]; now.Sub(since).Round(time.Hour) != 2*day {
+		t.Errorf("reopened in status since %v, want about 2 days ago", since)
+	}
+	if since := data.StatusSince[idle.ID]; now.Sub(since).Round(time.Hour) != 10*day {
+		t.Errorf("idle in status since %v, want its creation 10 days ago", since)
+	}
+}
+
+func TestContentSearchMergesActivityMatches(t *testing.T) {
+	database, err := db.Initialize(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	defer database.Close()
+
+	byTitle := createTestIssue(t, database, "Fix flaky widget test", models.StatusOpen)
+	byLog := createTestIssue(t, database, "Log match", models.StatusInProgress)
+	byComment := createTestIssue(t, database, "Comment match", models.StatusOpen)
+	byHandoff := createTestIssue(t, database, "Handoff match", models.StatusBlocked)
+	createTestIssue(t, database, "Unrelated", models.StatusOpen)
+
+	if err := database.AddLog(&models.Log{IssueID: byLog.ID, SessionID: "s1", Message: "traced the widget timeout", Type: models.LogTypeProgress}); err != nil {
+		t.Fatalf("AddLog: %v", err)
+	}
+	if err := database.AddComment(&models.Comment{IssueID: byComment.ID, SessionID: "s1", Text: "Same WIDGET crash on CI"}); err != nil {
+		t.Fatalf("AddComment: %v", err)
+	}
+	if err := database.AddHandoff(&models.Handoff{IssueID: byHandoff.ID, SessionID: "s1", Remaining: []string{"retry the widget build"}}); err != nil {
+		t.Fatalf("AddHandoff: %v", err)
+	}
+
+	matched := func(data TaskListData) map[string]bool {
+		ids := map[string]bool{}
+		for _, section := range [][]models.Issue{data.Ready, data.InProgress, data.NeedsRework, data.Blocked} {
+			for _, issue := range section {
+				ids[issue.ID] = true
+			}
+		}
+		return ids
+	}
+
+	got := matched(fetchTaskList(database, "test-session", "widget", "auto", false, SortByPriority))
+	if want := map[string]bool{byTitle.ID: true}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("auto search matched %v, want only the title match", got)
+	}
+
+	data := fetchTaskList(database, "test-session", "widget", "content", false, SortByPriority)
+	got = matched(data)
+	want := map[string]bool{byTitle.ID: true, byLog.ID: true, byComment.ID: true, byHandoff.ID: true}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("content search matched %v, want %v", got, want)
+	}
+	if len(data.Ready) != 2 || data.Ready[0].ID != byTitle.ID {
+		t.Errorf("expected the title match ranked above the comment match in Ready, got %v", data.Ready)
+	}
+	if len(data.InProgress) != 1 || len(data.Blocked) != 1 {
+		t.Errorf("expected activity matches kept in their status sections, got in_progress=%d blocked=%d", len(data.InProgress), len(data.Blocked))
+	}
+}
+
+func TestVelocityBucketing(t *testing.T) {
+	now := time.Date(2026, 3, 10, 14, 30, 0, 0, time.Local)
+
+	closed := []time.Time{
+		now.Add(-time.Minute),                           // today
+		time.Date(2026, 3, 10, 0, 5, 0, 0, time.Local),  // today, just after midnight
+		time.Date(2026, 3, 9, 23, 55, 0, 0, time.Local), // yesterday
+		time.Date(2026, 3, 8, 12, 0, 0, 0, time.Local),
+		time.Date(2026, 3, 4, 12, 0, 0, 0, time.Local),  // first day of a 7-day window
+		time.Date(2026, 3, 3, 23, 59, 0, 0, time.Local), // outside the window
+		now.Add(24 * time.Hour),                         // tomorrow, dropped
+	}
+	if got, want := bucketByDay(closed, now, 7), []int{1, 0, 0, 0, 1, 1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("bucketByDay = %v, want %v", got, want)
+	}
+
+	actions := map[string][]time.Time{
+		"ses_busy": {
+			now, now.Add(-10 * time.Minute), // current hour
+			time.Date(2026, 3, 10, 13, 59, 0, 0, time.Local),
+			time.Date(2026, 3, 10, 11, 0, 0, 0, time.Local),  // first hour of a 4-hour window
+			time.Date(2026, 3, 10, 10, 59, 0, 0, time.Local), // outside
+		},
+		"ses_quiet": {time.Date(2026, 3, 10, 12, 15, 0, 0, time.Local)},
+		"ses_gone":  {now.Add(-6 * time.Hour)},
+	}
+	got := sessionActivity(actions, now, 4)
+	want := []SessionActivity{
+		{SessionID: "ses_busy", PerHour: []int{1, 0, 1, 2}, Total: 4},
+		{SessionID: "ses_quiet", PerHour: []int{0, 1, 0, 0}, Total: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sessionActivity = %+v, want %+v", got, want)
+	}
+
+	if got, want := sparkline([]int{0, 1, 4, 8}), "▁▂▅█"; got != want {
+		t.Errorf("sparkline = %q, want %q", got, want)
+	}
+}
+
+func TestFetchDataReportsUndoableAction(t *testing.T) {
+	database, err := db.Initialize(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	defer database.Close()
+
+	if FetchDataWithSearchMode(database, "ses-undo", time.Now(), "", "auto", false, SortByPriority).CanUndo {
+		t.Fatal("expected nothing to undo in an empty database")
+	}
+
+	issue := &models.Issue{Title: "Undoable", Type: models.TypeTask, Status: models.StatusOpen}
+	if err := database.CreateIssueLogged(issue, "ses-undo"); err != nil {
+		t.Fatalf("CreateIssueLogged: %v", err)
+	}
+	if !FetchDataWithSearchMode(database, "ses-undo", time.Now(), "", "auto", false, SortByPriority).CanUndo {
+		t.Error("expected the session's create to be undoable")
+	}
+	if FetchDataWithSearchMode(database, "ses-other", time.Now(), "", "auto", false, SortByPriority).CanUndo {
+		t.Error("another session's action should not be undoable here")
+	}
+}
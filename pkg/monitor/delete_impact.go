@@ -0,0 +1,114 @@
+package monitor
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/models"
+)
+
+// Delete modes for an issue with children, chosen in the delete-confirm modal
+const (
+	deleteModeCascade = "cascade" // delete the issue and all its descendants
+	deleteModeOrphan  = "orphan"  // delete the issue, detach its children
+)
+
+// deleteImpact is what deleting an issue would touch, shown in the
+// delete-confirm modal before anything is removed.
+type deleteImpact struct {
+	Children        []string // direct children, detached in orphan mode
+	Descendants     []string // children, grandchildren, ... level by level; deleted in cascade mode
+	OpenDescendants int      // descendants that aren't closed
+	Dependents      []string // other issues that depend on the issue
+	// DescendantDependents are other issues that depend on one of the
+	// descendants, and lose that dependency only in cascade mode
+	DescendantDependents []string
+}
+
+// HasChildren reports whether deleting the issue needs a choice between
+// cascading and orphaning.
+func (d deleteImpact) HasChildren() bool {
+	return len(d.Children) > 0
+}
+
+// computeDeleteImpact works out the impact of deleting issueID. Deleted
+// issues are left out everywhere.
+func computeDeleteImpact(database *db.DB, issueID string) deleteImpact {
+	var impact deleteImpact
+
+	if children, err := database.GetDirectChildren(issueID); err == nil {
+		for _, child := range children {
+			impact.Children = append(impact.Children, child.ID)
+		}
+		sort.Strings(impact.Children)
+	}
+	if descendants, err := database.GetDescendantIssues(issueID, nil); err == nil {
+		for _, issue := range descendants {
+			impact.Descendants = append(impact.Descendants, issue.ID)
+			if issue.Status != models.StatusClosed {
+				impact.OpenDescendants++
+			}
+		}
+	}
+
+	// Dependents inside the deleted subtree go with it, so aren't impact
+	inSubtree := map[string]bool{issueID: true}
+	for _, id := range impact.Descendants {
+		inSubtree[id] = true
+	}
+	impact.Dependents = liveDependents(database, []string{issueID}, inSubtree)
+	impact.DescendantDependents = liveDependents(database, impact.Descendants, inSubtree)
+	return impact
+}
+
+// liveDependents returns the sorted, distinct undeleted issues outside
+// exclude that depend on any of ids.
+func liveDependents(database *db.DB, ids []string, exclude map[string]bool) []string {
+	seen := map[string]bool{}
+	var dependents []string
+	for _, id := range ids {
+		blocked, err := database.GetBlockedBy(id)
+		if err != nil {
+			continue
+		}
+		for _, depID := range blocked {
+			if exclude[depID] || seen[depID] {
+				continue
+			}
+			seen[depID] = true
+			issue, err := database.GetIssue(depID)
+			if err != nil || issue.DeletedAt != nil {
+				continue
+			}
+			dependents = append(dependents, depID)
+		}
+	}
+	sort.Strings(dependents)
+	return dependents
+}
+
+// summaryLines describes the impact for the delete-confirm modal, one fact
+// per line. Empty for an issue with no children or dependents.
+func (d deleteImpact) summaryLines() []string {
+	var lines []string
+	if len(d.Descendants) > 0 {
+		lines = append(lines, fmt.Sprintf("%s under it, %d open",
+			pluralize(len(d.Descendants), "issue", "issues"), d.OpenDescendants))
+	}
+	if len(d.Dependents) > 0 {
+		lines = append(lines, "Blocks "+pluralize(len(d.Dependents), "other issue", "other issues"))
+	}
+	if len(d.DescendantDependents) > 0 {
+		lines = append(lines, "Children block "+pluralize(len(d.DescendantDependents), "other issue", "other issues"))
+	}
+	return lines
+}
+
+// pluralize formats n with the singular or plural noun.
+func pluralize(n int, singular, plural string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, singular)
+	}
+	return fmt.Sprintf("%d %s", n, plural)
+}
@@ -0,0 +1,181 @@
+package monitor
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/models"
+)
+
+func TestDeleteImpactFeedsConfirmModal(t *testing.T) {
+	database, err := db.Initialize(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	defer database.Close()
+
+	create := func(title, parentID string, status models.Status) *models.Issue {
+		issue := &models.Issue{Title: title, Type: models.TypeTask, Status: status, ParentID: parentID}
+		if err := database.CreateIssue(issue); err != nil {
+			t.Fatalf("create %q: %v", title, err)
+		}
+		return issue
+	}
+	depend := func(issue, on *models.Issue) {
+		if err := database.AddDependency(issue.ID, on.ID, "depends_on"); err != nil {
+			t.Fatalf("AddDependency: %v", err)
+		}
+	}
+
+	// epic -> open child -> grandchild, and a closed child
+	epic := create("Epic", "", models.StatusOpen)
+	child := create("Child", epic.ID, models.StatusOpen)
+	closed := create("Closed child", epic.ID, models.StatusClosed)
+	grandchild := create("Grandchild", child.ID, models.StatusInProgress)
+	outside := create("Outside", "", models.StatusOpen)
+	other := create("Other", "", models.StatusOpen)
+	gone := create("Deleted", "", models.StatusOpen)
+	depend(outside, epic)
+	depend(gone, epic)
+	depend(other, grandchild)
+	depend(closed, child) // inside the subtree, not impact
+	if err := database.DeleteIssue(gone.ID); err != nil {
+		t.Fatalf("DeleteIssue: %v", err)
+	}
+
+	impact := computeDeleteImpact(database, epic.ID)
+	if !impact.HasChildren() || len(impact.Children) != 2 {
+		t.Errorf("children = %v, want 2", impact.Children)
+	}
+	if len(impact.Descendants) != 3 || impact.OpenDescendants != 2 {
+		t.Errorf("descendants = %v (%d open), want 3 (2 open)", impact.Descendants, impact.OpenDescendants)
+	}
+	if !reflect.DeepEqual(impact.Dependents, []string{outside.ID}) {
+		t.Errorf("dependents = %v, want [%s]", impact.Dependents, outside.ID)
+	}
+	if !reflect.DeepEqual(impact.DescendantDependents, []string{other.ID}) {
+		t.Errorf("descendant dependents = %v, want [%s]", impact.DescendantDependents, other.ID)
+	}
+	want := []string{"3 issues under it, 2 open", "Blocks 1 other issue", "Children block 1 other issue"}
+	if got := impact.summaryLines(); !reflect.DeepEqual(got, want) {
+		t.Errorf("summary = %q, want %q", got, want)
+	}
+	if leaf := computeDeleteImpact(database, grandchild.ID); leaf.HasChildren() || len(leaf.summaryLines()) != 1 {
+		t.Errorf("leaf impact = %+v", leaf)
+	}
+
+	// The modal needs a choice before a plain yes deletes anything
+	m := Model{DB: database, SessionID: "ses_test", Keymap: newTestKeymap()}
+	m = m.openDeleteConfirmModal(epic.ID, epic.Title)
+	if !reflect.DeepEqual(m.ConfirmImpact, impact) {
+		t.Fatalf("modal impact = %+v, want %+v", m.ConfirmImpact, impact)
+	}
+	next, _ := m.handleDeleteConfirmAction("yes")
+	m = next.(Model)
+	if !m.ConfirmOpen {
+		t.Fatal("yes before choosing a mode closed the modal")
+	}
+	if issue, _ := database.GetIssue(epic.ID); issue.DeletedAt != nil {
+		t.Fatal("yes before choosing a mode deleted the epic")
+	}
+
+	// Orphan: the epic goes, its children move to the top level
+	next, _ = m.handleDeleteConfirmAction(deleteModeOrphan)
+	m = next.(Model)
+	if m.ConfirmDeleteMode != deleteModeOrphan || !m.ConfirmOpen {
+		t.Fatalf("mode = %q open = %v after choosing orphan", m.ConfirmDeleteMode, m.ConfirmOpen)
+	}
+	next, _ = m.handleDeleteConfirmAction("yes")
+	m = next.(Model)
+	if m.ConfirmOpen {
+		t.Error("modal still open after confirming")
+	}
+	if issue, _ := database.GetIssue(epic.ID); issue.DeletedAt == nil {
+		t.Error("epic not deleted")
+	}
+	for _, id := range []string{child.ID, closed.ID} {
+		issue, _ := database.GetIssue(id)
+		if issue.DeletedAt != nil || issue.ParentID != "" {
+			t.Errorf("%s: deleted=%v parent=%q, want kept at top level", id, issue.DeletedAt != nil, issue.ParentID)
+		}
+	}
+
+	// Cascade: the issue and everything under it goes
+	m = m.openDeleteConfirmModal(child.ID, child.Title)
+	next, _ = m.handleDeleteConfirmAction(deleteModeCascade)
+	m = next.(Model)
+	next, _ = m.handleDeleteConfirmAction("yes")
+	m = next.(Model)
+	for _, id := range []string{child.ID, grandchild.ID} {
+		if issue, _ := database.GetIssue(id); issue.DeletedAt == nil {
+			t.Errorf("%s not deleted by cascade", id)
+		}
+	}
+	if issue, _ := database.GetIssue(other.ID); issue.DeletedAt != nil {
+		t.Error("cascade deleted an issue outside the subtree")
+	}
+}
+
+func TestDeleteRecomputesImpactBeforeDeleting(t *testing.T) {
+	database, err := db.Initialize(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	defer database.Close()
+
+	create := func(title, parentID string) *models.Issue {
+		issue := &models.Issue{Title: title, Type: models.TypeTask, ParentID: parentID}
+		if err := database.CreateIssue(issue); err != nil {
+			t.Fatalf("create %q: %v", title, err)
+		}
+		return issue
+	}
+	m := Model{DB: database, SessionID: "ses_test", Keymap: newTestKeymap()}
+
+	// A child added while a plain yes/no modal is open brings back the choice
+	leaf := create("Leaf", "")
+	m = m.openDeleteConfirmModal(leaf.ID, leaf.Title)
+	late := create("Late child", leaf.ID)
+	next, _ := m.handleDeleteConfirmAction("yes")
+	m = next.(Model)
+	if !m.ConfirmOpen || !m.ConfirmImpact.HasChildren() || !m.StatusIsError {
+		t.Fatalf("open=%v impact=%+v status=%q, want the choice shown again", m.ConfirmOpen, m.ConfirmImpact, m.StatusMessage)
+	}
+	if issue, _ := database.GetIssue(leaf.ID); issue.DeletedAt != nil {
+		t.Fatal("deleted an issue whose children weren't shown")
+	}
+
+	// A grandchild added after choosing cascade needs a fresh confirmation
+	next, _ = m.handleDeleteConfirmAction(deleteModeCascade)
+	m = next.(Model)
+	grandchild := create("Late grandchild", late.ID)
+	next, _ = m.handleDeleteConfirmAction("yes")
+	m = next.(Model)
+	if !m.ConfirmOpen || len(m.ConfirmImpact.Descendants) != 2 {
+		t.Fatalf("open=%v descendants=%v, want a fresh confirmation of 2", m.ConfirmOpen, m.ConfirmImpact.Descendants)
+	}
+	next, _ = m.handleDeleteConfirmAction("yes")
+	m = next.(Model)
+	if m.ConfirmOpen {
+		t.Error("modal still open after confirming again")
+	}
+	for _, id := range []string{leaf.ID, late.ID, grandchild.ID} {
+		if issue, _ := database.GetIssue(id); issue.DeletedAt == nil {
+			t.Errorf("%s not deleted by cascade", id)
+		}
+	}
+
+	// A failed delete is reported, not swallowed
+	gone := create("Gone", "")
+	m = m.openDeleteConfirmModal(gone.ID, gone.Title)
+	if err := database.DeleteIssue(gone.ID); err != nil {
+		t.Fatalf("DeleteIssue: %v", err)
+	}
+	next, _ = m.handleDeleteConfirmAction("yes")
+	m = next.(Model)
+	if m.ConfirmOpen || !m.StatusIsError || !strings.HasPrefix(m.StatusMessage, "Delete failed") {
+		t.Errorf("open=%v status=%q, want the failure reported", m.ConfirmOpen, m.StatusMessage)
+	}
+}
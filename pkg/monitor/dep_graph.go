@@ -0,0 +1,248 @@
+package monitor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/dependency"
+	"github.com/marcus/td/internal/models"
+	"github.com/marcus/td/pkg/monitor/modal"
+	"github.com/marcus/td/pkg/monitor/mouse"
+)
+
+// depGraphDepth is how many levels the graph walks out from the focal issue
+// in each direction; deeper links are left out to keep the overlay readable.
+const depGraphDepth = 3
+
+// depGraphItemPrefix prefixes the graph list's item IDs so issue IDs can't
+// collide with the modal's own actions ("cancel", etc.).
+const depGraphItemPrefix = "dep-graph:"
+
+// DepGraphRelation is how a graph node relates to the focal issue.
+type DepGraphRelation int
+
+const (
+	DepGraphFocus DepGraphRelation = iota
+	DepGraphParent
+	DepGraphBlocker
+	DepGraphBlocked
+	DepGraphChild
+)
+
+// depGraphGlyphs marks each relation in the rendered graph.
+var depGraphGlyphs = map[DepGraphRelation]string{
+	DepGraphFocus:   "●",
+	DepGraphParent:  "▲",
+	DepGraphBlocker: "◀",
+	DepGraphBlocked: "▶",
+	DepGraphChild:   "▼",
+}
+
+// DepGraphNode is one issue in a dependency graph. Depth is how many links
+// it is from the focal issue: 1 for a parent, direct dependency, dependent
+// or child, 2 for the next level out, and 0 for the focus itself.
+type DepGraphNode struct {
+	Issue    models.Issue
+	Relation DepGraphRelation
+	Depth    int
+}
+
+// DepGraph is the neighborhood of one issue: its ancestors, descendants,
+// blockers and the issues it blocks, each walked up to a fixed depth.
+type DepGraph struct {
+	Focus    models.Issue
+	Parents  []DepGraphNode // nearest first
+	Blockers []DepGraphNode // depth-first, as dependency.GetTransitiveBlockersWithin
+	Blocked  []DepGraphNode // depth-first, as dependency.GetTransitiveBlockedWithin
+	Children []DepGraphNode // depth-first in ID order
+}
+
+// buildDepGraph assembles the graph around issueID, following each kind of
+// link at most maxDepth levels out. Issues that no longer exist are skipped.
+func buildDepGraph(database *db.DB, issueID string, maxDepth int) (*DepGraph, error) {
+	focus, err := database.GetIssue(issueID)
+	if err != nil {
+		return nil, err
+	}
+	g := &DepGraph{Focus: *focus}
+
+	seen := map[string]bool{focus.ID: true}
+	for parentID, depth := focus.ParentID, 1; parentID != "" && depth <= maxDepth && !seen[parentID]; depth++ {
+		seen[parentID] = true
+		parent, err := database.GetIssue(parentID)
+		if err != nil || parent.DeletedAt != nil {
+			break
+		}
+		g.Parents = append(g.Parents, DepGraphNode{Issue: *parent, Relation: DepGraphParent, Depth: depth})
+		parentID = parent.ParentID
+	}
+
+	g.Blockers = depGraphLinkNodes(database, dependency.GetTransitiveBlockersWithin(database, focus.ID, maxDepth), DepGraphBlocker)
+	g.Blocked = depGraphLinkNodes(database, dependency.GetTransitiveBlockedWithin(database, focus.ID, maxDepth), DepGraphBlocked)
+	g.Children = depGraphChildNodes(database, focus.ID, 1, maxDepth, map[string]bool{focus.ID: true})
+	return g, nil
+}
+
+// depGraphLinkNodes loads the issues of a dependency walk, keeping its order.
+func depGraphLinkNodes(database *db.DB, links []dependency.BlockerLink, relation DepGraphRelation) []DepGraphNode {
+	if len(links) == 0 {
+		return nil
+	}
+	ids := make([]string, len(links))
+	for i, link := range links {
+		ids[i] = link.ID
+	}
+	issues, _ := database.GetIssuesByIDs(ids)
+	issueMap := make(map[string]models.Issue, len(issues))
+	for _, issue := range issues {
+		issueMap[issue.ID] = issue
+	}
+
+	nodes := make([]DepGraphNode, 0, len(links))
+	for _, link := range links {
+		if issue, ok := issueMap[link.ID]; ok {
+			nodes = append(nodes, DepGraphNode{Issue: issue, Relation: relation, Depth: link.Depth})
+		}
+	}
+	return nodes
+}
+
+// depGraphChildNodes walks issueID's descendants depth-first in ID order,
+// stopping maxDepth levels down.
+func depGraphChildNodes(database *db.DB, issueID string, depth, maxDepth int, visited map[string]bool) []DepGraphNode {
+	if depth > maxDepth {
+		return nil
+	}
+	children, _ := database.GetDirectChildren(issueID)
+	sort.Slice(children, func(i, j int) bool { return children[i].ID < children[j].ID })
+
+	var nodes []DepGraphNode
+	for _, child := range children {
+		if visited[child.ID] {
+			continue
+		}
+		visited[child.ID] = true
+		nodes = append(nodes, DepGraphNode{Issue: *child, Relation: DepGraphChild, Depth: depth})
+		nodes = append(nodes, depGraphChildNodes(database, child.ID, depth+1, maxDepth, visited)...)
+	}
+	return nodes
+}
+
+// Nodes flattens the graph in display order: ancestors root first, the focal
+// issue, then its blockers, the issues it blocks and its children.
+func (g *DepGraph) Nodes() []DepGraphNode {
+	nodes := make([]DepGraphNode, 0, 1+len(g.Parents)+len(g.Blockers)+len(g.Blocked)+len(g.Children))
+	for i := len(g.Parents) - 1; i >= 0; i-- {
+		nodes = append(nodes, g.Parents[i])
+	}
+	nodes = append(nodes, DepGraphNode{Issue: g.Focus, Relation: DepGraphFocus})
+	nodes = append(nodes, g.Blockers...)
+	nodes = append(nodes, g.Blocked...)
+	return append(nodes, g.Children...)
+}
+
+// depGraphIndent is the nesting level a node is drawn at. Ancestors step in
+// from the root to the focus; everything else hangs below the focus.
+func (g *DepGraph) depGraphIndent(node DepGraphNode) int {
+	switch node.Relation {
+	case DepGraphParent:
+		return len(g.Parents) - node.Depth
+	case DepGraphFocus:
+		return len(g.Parents)
+	default:
+		return len(g.Parents) + node.Depth
+	}
+}
+
+// depGraphLabel renders one node as a graph row, hooked under the row it
+// hangs from.
+func (g *DepGraph) depGraphLabel(node DepGraphNode, width int) string {
+	level := g.depGraphIndent(node)
+	glyph := depGraphGlyphs[node.Relation]
+	if level > 0 {
+		glyph = "└" + glyph
+	}
+	prefix := fmt.Sprintf("%s%s %s ", strings.Repeat("  ", level), glyph, node.Issue.ID)
+	title := truncateString(node.Issue.Title, width-len([]rune(prefix))-len(node.Issue.Status)-3)
+	if node.Relation == DepGraphFocus {
+		return titleStyle.Render(prefix+title) + " " + formatStatus(node.Issue.Status)
+	}
+	return prefix + title + " " + formatStatus(node.Issue.Status)
+}
+
+// openDepGraph opens the dependency graph overlay for the issue in the top
+// modal, with the focal issue selected.
+func (m Model) openDepGraph() (tea.Model, tea.Cmd) {
+	entry := m.CurrentModal()
+	if entry == nil || m.DB == nil {
+		return m, nil
+	}
+	graph, err := buildDepGraph(m.DB, entry.IssueID, depGraphDepth)
+	if err != nil {
+		m.StatusMessage = "Failed to load dependency graph: " + err.Error()
+		m.StatusIsError = true
+		return m, tea.Tick(3*time.Second, func(t time.Time) tea.Msg { return ClearStatusMsg{} })
+	}
+
+	m.DepGraphOpen = true
+	m.DepGraph = graph
+	cursor := len(graph.Parents)
+	m.DepGraphCursor = &cursor
+	m.DepGraphModal = m.createDepGraphModal()
+	m.DepGraphModal.Reset()
+	m.DepGraphModal.SetFocus("dep-graph-list")
+	m.DepGraphMouseHandler = mouse.NewHandler()
+	return m, nil
+}
+
+// closeDepGraph closes the dependency graph overlay.
+func (m *Model) closeDepGraph() {
+	m.DepGraphOpen = false
+	m.DepGraph = nil
+	m.DepGraphCursor = nil
+	m.DepGraphModal = nil
+	m.DepGraphMouseHandler = nil
+}
+
+// createDepGraphModal builds the declarative modal for the graph overlay.
+func (m *Model) createDepGraphModal() *modal.Modal {
+	g := m.DepGraph
+	nodes := g.Nodes()
+	md := modal.New(fmt.Sprintf("Dependency graph: %s", g.Focus.ID),
+		modal.WithWidth(80),
+		modal.WithHints(false),
+	)
+
+	items := make([]modal.ListItem, len(nodes))
+	for i, node := range nodes {
+		items[i] = modal.ListItem{ID: depGraphItemPrefix + node.Issue.ID, Label: g.depGraphLabel(node, 72)}
+	}
+	maxVisible := max(5, min(len(items), m.Height-12))
+	md.AddSection(modal.List("dep-graph-list", items, m.DepGraphCursor, modal.WithMaxVisible(maxVisible)))
+	if len(nodes) == 1 {
+		md.AddSection(modal.Text(subtleStyle.Render("No parents, children or dependencies")))
+	}
+	md.AddSection(modal.Spacer())
+	md.AddSection(modal.Text(subtleStyle.Render(fmt.Sprintf("▲ parent  ◀ blocked by  ▶ blocks  ▼ child  (%d levels)", depGraphDepth))))
+	md.AddSection(modal.Text("j/k:move  Enter:open  Esc:close"))
+	return md
+}
+
+// handleDepGraphAction opens the picked node's issue on top of the modal
+// stack. Picking the focal issue just closes the overlay.
+func (m Model) handleDepGraphAction(action string) (tea.Model, tea.Cmd) {
+	issueID, ok := strings.CutPrefix(action, depGraphItemPrefix)
+	if action != "cancel" && !ok {
+		return m, nil
+	}
+	focusID := m.DepGraph.Focus.ID
+	m.closeDepGraph()
+	if !ok || issueID == focusID {
+		return m, nil
+	}
+	return m.pushModal(issueID, m.ModalSourcePanel())
+}
@@ -0,0 +1,135 @@
+package monitor
+
+import (
+	"testing"
+
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/models"
+)
+
+func TestBuildDepGraph(t *testing.T) {
+	database, err := db.Initialize(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	defer database.Close()
+
+	// root > epic > focus > child > grandchild > too deep
+	// focus -> blocker -> deep blocker -> too deep blocker
+	// dependent -> focus
+	root := createTestIssue(t, database, "Root", models.StatusOpen)
+	epic := createTestIssue(t, database, "Epic", models.StatusOpen)
+	focus := createTestIssue(t, database, "Focus", models.StatusBlocked)
+	child := createTestIssue(t, database, "Child", models.StatusOpen)
+	grandchild := createTestIssue(t, database, "Grandchild", models.StatusOpen)
+	tooDeepChild := createTestIssue(t, database, "Great-grandchild", models.StatusOpen)
+	blocker := createTestIssue(t, database, "Blocker", models.StatusInProgress)
+	deepBlocker := createTestIssue(t, database, "Deep blocker", models.StatusOpen)
+	tooDeepBlocker := createTestIssue(t, database, "Too deep blocker", models.StatusOpen)
+	dependent := createTestIssue(t, database, "Dependent", models.StatusBlocked)
+
+	for issue, parent := range map[*models.Issue]*models.Issue{
+		epic: root, focus: epic, child: focus, grandchild: child, tooDeepChild: grandchild,
+	} {
+		issue.ParentID = parent.ID
+		if err := database.UpdateIssue(issue); err != nil {
+			t.Fatalf("UpdateIssue(%s): %v", issue.ID, err)
+		}
+	}
+	for _, dep := range [][2]string{
+		{focus.ID, blocker.ID}, {blocker.ID, deepBlocker.ID}, {deepBlocker.ID, tooDeepBlocker.ID},
+		{dependent.ID, focus.ID},
+	} {
+		if err := database.AddDependency(dep[0], dep[1], "depends_on"); err != nil {
+			t.Fatalf("AddDependency(%s, %s): %v", dep[0], dep[1], err)
+		}
+	}
+
+	g, err := buildDepGraph(database, focus.ID, 2)
+	if err != nil {
+		t.Fatalf("buildDepGraph: %v", err)
+	}
+	if g.Focus.ID != focus.ID {
+		t.Fatalf("focus = %s, want %s", g.Focus.ID, focus.ID)
+	}
+
+	type want struct {
+		id       string
+		relation DepGraphRelation
+		depth    int
+	}
+	wantNodes := []want{
+		{root.ID, DepGraphParent, 2},
+		{epic.ID, DepGraphParent, 1},
+		{focus.ID, DepGraphFocus, 0},
+		{blocker.ID, DepGraphBlocker, 1},
+		{deepBlocker.ID, DepGraphBlocker, 2},
+		{dependent.ID, DepGraphBlocked, 1},
+		{child.ID, DepGraphChild, 1},
+		{grandchild.ID, DepGraphChild, 2},
+	}
+	nodes := g.Nodes()
+	if len(nodes) != len(wantNodes) {
+		t.Fatalf("got %d nodes, want %d: %+v", len(nodes), len(wantNodes), nodes)
+	}
+	for i, w := range wantNodes {
+		n := nodes[i]
+		if n.Issue.ID != w.id || n.Relation != w.relation || n.Depth != w.depth {
+			t.Errorf("node %d = {%s %d %d}, want {%s %d %d}", i, n.Issue.ID, n.Relation, n.Depth, w.id, w.relation, w.depth)
+		}
+	}
+
+	// Ancestors step in towards the focus; everything else hangs below it
+	for _, tc := range []struct {
+		node   int
+		indent int
+	}{{0, 0}, {1, 1}, {2, 2}, {3, 3}, {4, 4}, {7, 4}} {
+		if got := g.depGraphIndent(nodes[tc.node]); got != tc.indent {
+			t.Errorf("indent of %s = %d, want %d", nodes[tc.node].Issue.ID, got, tc.indent)
+		}
+	}
+}
+
+func TestDepGraphActionOpensNode(t *testing.T) {
+	database, err := db.Initialize(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	defer database.Close()
+
+	focus := createTestIssue(t, database, "Focus", models.StatusOpen)
+	blocker := createTestIssue(t, database, "Blocker", models.StatusOpen)
+	if err := database.AddDependency(focus.ID, blocker.ID, "depends_on"); err != nil {
+		t.Fatalf("AddDependency: %v", err)
+	}
+
+	m := Model{
+		DB:         database,
+		Height:     40,
+		Keymap:     newTestKeymap(),
+		ModalStack: []ModalEntry{{IssueID: focus.ID, SourcePanel: PanelTaskList}},
+	}
+	next, _ := m.openDepGraph()
+	m = next.(Model)
+	if !m.DepGraphOpen || m.DepGraphCursor == nil || *m.DepGraphCursor != 0 {
+		t.Fatalf("graph not opened on the focus: open=%v cursor=%v", m.DepGraphOpen, m.DepGraphCursor)
+	}
+
+	next, _ = m.handleDepGraphAction(depGraphItemPrefix + blocker.ID)
+	m = next.(Model)
+	if m.DepGraphOpen {
+		t.Error("graph should close after jumping to a node")
+	}
+	if m.ModalDepth() != 2 || m.CurrentModal().IssueID != blocker.ID {
+		t.Fatalf("modal stack = %+v, want %s pushed", m.ModalStack, blocker.ID)
+	}
+
+	// Picking the focus itself just closes the overlay
+	next, _ = m.openDepGraph()
+	m = next.(Model)
+	next, _ = m.handleDepGraphAction(depGraphItemPrefix + blocker.ID)
+	m = next.(Model)
+	if m.ModalDepth() != 2 {
+		t.Errorf("picking the focal issue pushed a modal: %+v", m.ModalStack)
+	}
+}
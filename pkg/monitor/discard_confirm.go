@@ -0,0 +1,107 @@
+package monitor
+
+import (
+	tea "charm.land/bubbletea/v2"
+	"github.com/marcus/td/pkg/monitor/modal"
+	"github.com/marcus/td/pkg/monitor/mouse"
+)
+
+// leaveForm closes the issue form, or quits when quit is set. If the form has
+// unsaved edits it asks first via the discard confirmation.
+func (m Model) leaveForm(quit bool) (tea.Model, tea.Cmd) {
+	if m.FormState != nil && m.FormState.IsDirty() {
+		m.DiscardConfirmOpen = true
+		m.DiscardConfirmQuit = quit
+		m.DiscardConfirmModal = m.createDiscardConfirmModal()
+		m.DiscardConfirmModal.Reset()
+		m.DiscardConfirmMouseHandler = mouse.NewHandler()
+		return m, nil
+	}
+	m.closeForm()
+	if quit {
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// closeDiscardConfirm closes the discard confirmation, leaving the form open.
+func (m *Model) closeDiscardConfirm() {
+	m.DiscardConfirmOpen = false
+	m.DiscardConfirmQuit = false
+	m.DiscardConfirmModal = nil
+	m.DiscardConfirmMouseHandler = nil
+}
+
+// createDiscardConfirmModal builds the declarative modal for discarding
+// unsaved form edits. Same shape as the delete confirmation.
+func (m *Model) createDiscardConfirmModal() *modal.Modal {
+	title := "Discard changes?"
+	if m.DiscardConfirmQuit {
+		title = "Discard changes and quit?"
+	}
+
+	md := modal.New(title,
+		modal.WithWidth(50),
+		modal.WithVariant(modal.VariantDanger),
+		modal.WithHints(false),
+	)
+	md.AddSection(modal.Text("The form has unsaved changes."))
+	md.AddSection(modal.Spacer())
+	md.AddSection(modal.Buttons(
+		modal.Btn(" Discard ", "discard", modal.BtnDanger()),
+		modal.Btn(" Keep editing ", "keep"),
+	))
+	md.AddSection(modal.Spacer())
+	md.AddSection(modal.Text("Tab:switch  Y/N:quick  Esc:keep editing"))
+	return md
+}
+
+// handleDiscardConfirmAction handles actions from the discard confirmation.
+func (m Model) handleDiscardConfirmAction(action string) (tea.Model, tea.Cmd) {
+	switch action {
+	case "discard":
+		quit := m.DiscardConfirmQuit
+		m.closeDiscardConfirm()
+		m.closeForm()
+		if quit {
+			return m, tea.Quit
+		}
+		return m, nil
+	case "keep", "cancel":
+		m.closeDiscardConfirm()
+		return m, nil
+	}
+	return m, nil
+}
+
+// handleDiscardConfirmMsg routes input to the discard confirmation while it
+// is open over the form. Other messages fall through to the form.
+func (m Model) handleDiscardConfirmMsg(msg tea.Msg) (tea.Model, tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "y", "Y":
+			model, cmd := m.handleDiscardConfirmAction("discard")
+			return model, cmd, true
+		case "n", "N":
+			model, cmd := m.handleDiscardConfirmAction("keep")
+			return model, cmd, true
+		}
+		action, cmd := m.DiscardConfirmModal.HandleKey(msg)
+		if action != "" {
+			model, actionCmd := m.handleDiscardConfirmAction(action)
+			return model, actionCmd, true
+		}
+		return m, cmd, true
+	case tea.MouseClickMsg:
+		if action := m.DiscardConfirmModal.HandleMouse(msg, m.DiscardConfirmMouseHandler); action != "" {
+			model, cmd := m.handleDiscardConfirmAction(action)
+			return model, cmd, true
+		}
+		return m, nil, true
+	case tea.MouseMsg:
+		_ = m.DiscardConfirmModal.HandleMouse(msg, m.DiscardConfirmMouseHandler)
+		return m, nil, true
+	}
+	return m, nil, false
+}
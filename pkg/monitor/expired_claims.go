@@ -0,0 +1,78 @@
+package monitor
+
+import (
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/models"
+)
+
+// claimLeaseTTL is how long a claim outlives its session's last activity.
+// Every td command from a session renews its activity, so an in_progress
+// issue whose implementer has been idle longer than this was most likely
+// claimed by an agent that died.
+const claimLeaseTTL = time.Hour
+
+// loadExpiredClaims fills data.ExpiredClaimIDs with the listed in_progress
+// issues whose implementer session's lease has run out. Sessions this
+// database doesn't know, such as ones from another machine, are left alone.
+func loadExpiredClaims(database *db.DB, data *TaskListData, now time.Time) {
+	sections := [][]models.Issue{data.NeedsRework, data.InProgress, data.Blocked}
+	lastSeen := make(map[string]time.Time)
+	data.ExpiredClaimIDs = make(map[string]bool)
+	for _, section := range sections {
+		for _, issue := range section {
+			if issue.Status != models.StatusInProgress || issue.ImplementerSession == "" {
+				continue
+			}
+			seen, ok := lastSeen[issue.ImplementerSession]
+			if !ok {
+				sess, err := database.GetSessionByID(issue.ImplementerSession)
+				if err == nil && sess != nil {
+					seen = sess.LastActivity
+					if seen.IsZero() {
+						seen = sess.StartedAt
+					}
+				}
+				lastSeen[issue.ImplementerSession] = seen
+			}
+			if !seen.IsZero() && now.Sub(seen) > claimLeaseTTL {
+				data.ExpiredClaimIDs[issue.ID] = true
+			}
+		}
+	}
+}
+
+// isExpiredClaim reports whether an issue in the task list is held by a
+// session whose claim lease has expired.
+func (m Model) isExpiredClaim(issueID string) bool {
+	return m.TaskListMode != TaskListModeBoard && m.TaskList.ExpiredClaimIDs[issueID]
+}
+
+// expiredClaimRows keeps only the rows whose claim lease has expired.
+func expiredClaimRows(rows []TaskListRow, expired map[string]bool) []TaskListRow {
+	var kept []TaskListRow
+	for _, row := range rows {
+		if expired[row.Issue.ID] {
+			kept = append(kept, row)
+		}
+	}
+	return kept
+}
+
+// toggleExpiredClaimsFilter switches the task list between all issues and
+// only those with expired claim leases.
+func (m Model) toggleExpiredClaimsFilter() (tea.Model, tea.Cmd) {
+	m.ExpiredClaimsOnly = !m.ExpiredClaimsOnly
+	m.buildTaskListRows()
+	m.restoreCursors()
+	if m.ExpiredClaimsOnly {
+		m.StatusMessage = fmt.Sprintf("Expired claims only (%d)", len(m.TaskListRows))
+	} else {
+		m.StatusMessage = "Expired claims filter off"
+	}
+	m.StatusIsError = false
+	return m, tea.Tick(2*time.Second, func(t time.Time) tea.Msg { return ClearStatusMsg{} })
+}
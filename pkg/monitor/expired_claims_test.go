@@ -0,0 +1,76 @@
+package monitor
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/models"
+)
+
+func TestExpiredClaimsFeedTaskList(t *testing.T) {
+	database, err := db.Initialize(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	defer database.Close()
+
+	now := time.Now()
+	for id, lastActive := range map[string]time.Time{
+		"ses_dead":  now.Add(-3 * claimLeaseTTL),
+		"ses_alive": now.Add(-time.Minute),
+	} {
+		if err := database.UpsertSession(&db.SessionRow{ID: id, StartedAt: lastActive, LastActivity: lastActive}); err != nil {
+			t.Fatalf("UpsertSession %s: %v", id, err)
+		}
+	}
+
+	create := func(title string, status models.Status, implementer string) string {
+		issue := &models.Issue{Title: title, Type: models.TypeTask, Status: status}
+		if err := database.CreateIssue(issue); err != nil {
+			t.Fatalf("create %q: %v", title, err)
+		}
+		// CreateIssue doesn't store the implementer
+		issue.ImplementerSession = implementer
+		if err := database.UpdateIssue(issue); err != nil {
+			t.Fatalf("update %q: %v", title, err)
+		}
+		return issue.ID
+	}
+	dead := create("Claimed by a dead session", models.StatusInProgress, "ses_dead")
+	alive := create("Claimed by a live session", models.StatusInProgress, "ses_alive")
+	unknown := create("Claimed on another machine", models.StatusInProgress, "ses_elsewhere")
+	open := create("Open, last touched by the dead session", models.StatusOpen, "ses_dead")
+
+	data := fetchTaskList(database, "test-session", "", "", false, SortByPriority)
+	if !data.ExpiredClaimIDs[dead] {
+		t.Errorf("%s held by an idle session not flagged as expired", dead)
+	}
+	for _, id := range []string{alive, unknown, open} {
+		if data.ExpiredClaimIDs[id] {
+			t.Errorf("%s flagged as an expired claim", id)
+		}
+	}
+
+	// The badge marks the row, and the filter keeps only expired claims
+	m := Model{TaskList: data, Cursor: make(map[Panel]int), SelectedID: make(map[Panel]string)}
+	m.buildTaskListRows()
+	if tag := m.rowTag(dead, CategoryInProgress); !strings.Contains(tag, "[EXP]") {
+		t.Errorf("expired claim tag = %q, want [EXP]", tag)
+	}
+	if tag := m.rowTag(alive, CategoryInProgress); strings.Contains(tag, "[EXP]") {
+		t.Errorf("live claim tag = %q", tag)
+	}
+
+	next, _ := m.toggleExpiredClaimsFilter()
+	m = next.(Model)
+	if len(m.TaskListRows) != 1 || m.TaskListRows[0].Issue.ID != dead {
+		t.Fatalf("filtered rows = %v, want only %s", m.TaskListRows, dead)
+	}
+	next, _ = m.toggleExpiredClaimsFilter()
+	m = next.(Model)
+	if len(m.TaskListRows) != 4 {
+		t.Errorf("rows after clearing the filter = %d, want 4", len(m.TaskListRows))
+	}
+}
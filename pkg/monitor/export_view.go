@@ -0,0 +1,75 @@
+package monitor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/marcus/td/internal/models"
+	"github.com/marcus/td/internal/output"
+)
+
+// Export formats for the current-view export
+const (
+	viewExportMarkdown = "md"
+	viewExportCSV      = "csv"
+)
+
+// viewExportIssues returns the issues currently visible in the task list, in
+// display order. Search, sort and type filters are already applied to the
+// task list data when it is fetched; in board mode the active board view
+// (swimlanes or backlog, after its status filter) is used instead.
+func (m Model) viewExportIssues() []models.Issue {
+	var issues []models.Issue
+	if m.TaskListMode == TaskListModeBoard && m.BoardMode.Board != nil {
+		if m.BoardMode.ViewMode == BoardViewBacklog {
+			for _, biv := range m.BoardMode.Issues {
+				issues = append(issues, biv.Issue)
+			}
+			return issues
+		}
+		for _, row := range m.BoardMode.SwimlaneRows {
+			issues = append(issues, row.Issue)
+		}
+		return issues
+	}
+	for _, row := range m.TaskListRows {
+		issues = append(issues, row.Issue)
+	}
+	return issues
+}
+
+// exportCurrentView writes the visible task list to a file in the project
+// directory and reports the written path in the status bar.
+func (m Model) exportCurrentView(format string) (tea.Model, tea.Cmd) {
+	issues := m.viewExportIssues()
+
+	var data string
+	var err error
+	switch format {
+	case viewExportCSV:
+		data, err = output.FormatIssuesCSV(issues)
+	default:
+		format = viewExportMarkdown
+		data = output.FormatIssuesMarkdown(issues)
+	}
+
+	path := filepath.Join(m.BaseDir, fmt.Sprintf("td-view-%s.%s", time.Now().Format("20060102-150405"), format))
+	if err == nil {
+		err = os.WriteFile(path, []byte(data), 0644)
+	}
+
+	if err != nil {
+		m.StatusMessage = "Export failed: " + err.Error()
+		m.StatusIsError = true
+	} else {
+		m.StatusMessage = fmt.Sprintf("Exported %d issues to %s", len(issues), path)
+		m.StatusIsError = false
+	}
+
+	return m, tea.Tick(3*time.Second, func(t time.Time) tea.Msg {
+		return ClearStatusMsg{}
+	})
+}
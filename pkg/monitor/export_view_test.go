@@ -0,0 +1,108 @@
+package monitor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/marcus/td/internal/models"
+)
+
+func exportIDs(issues []models.Issue) []string {
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	return ids
+}
+
+func TestViewExportIssues(t *testing.T) {
+	board := &models.Board{ID: "bd-1", Name: "Bugs"}
+	taskRows := []TaskListRow{
+		{Issue: models.Issue{ID: "td-1"}, Category: CategoryReviewable},
+		{Issue: models.Issue{ID: "td-2"}, Category: CategoryReady},
+	}
+	swimlaneRows := []TaskListRow{
+		{Issue: models.Issue{ID: "td-3"}, Category: CategoryInProgress},
+	}
+	backlog := []models.BoardIssueView{
+		{BoardID: "bd-1", Issue: models.Issue{ID: "td-4"}},
+		{BoardID: "bd-1", Issue: models.Issue{ID: "td-5"}},
+	}
+
+	tests := []struct {
+		name string
+		m    Model
+		want []string
+	}{
+		{
+			name: "categorized task list",
+			m:    Model{TaskListRows: taskRows},
+			want: []string{"td-1", "td-2"},
+		},
+		{
+			name: "board swimlanes",
+			m: Model{
+				TaskListRows: taskRows,
+				TaskListMode: TaskListModeBoard,
+				BoardMode:    BoardMode{Board: board, ViewMode: BoardViewSwimlanes, SwimlaneRows: swimlaneRows, Issues: backlog},
+			},
+			want: []string{"td-3"},
+		},
+		{
+			name: "board backlog",
+			m: Model{
+				TaskListRows: taskRows,
+				TaskListMode: TaskListModeBoard,
+				BoardMode:    BoardMode{Board: board, ViewMode: BoardViewBacklog, SwimlaneRows: swimlaneRows, Issues: backlog},
+			},
+			want: []string{"td-4", "td-5"},
+		},
+		{
+			name: "board mode without a board falls back to task list",
+			m:    Model{TaskListRows: taskRows, TaskListMode: TaskListModeBoard},
+			want: []string{"td-1", "td-2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := exportIDs(tt.m.viewExportIssues())
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("viewExportIssues() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExportCurrentViewWritesFile(t *testing.T) {
+	dir := t.TempDir()
+	m := Model{
+		BaseDir: dir,
+		TaskListRows: []TaskListRow{
+			{Issue: models.Issue{ID: "td-1", Title: "First", Status: models.StatusOpen}},
+		},
+	}
+
+	updated, _ := m.exportCurrentView(viewExportCSV)
+	um := updated.(Model)
+	if um.StatusIsError {
+		t.Fatalf("unexpected export error: %s", um.StatusMessage)
+	}
+
+	matches, _ := filepath.Glob(filepath.Join(dir, "td-view-*.csv"))
+	if len(matches) != 1 {
+		t.Fatalf("expected one csv export, got %v", matches)
+	}
+	if !strings.Contains(um.StatusMessage, matches[0]) {
+		t.Errorf("status %q should mention %s", um.StatusMessage, matches[0])
+	}
+	data, err := os.ReadFile(matches[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "td-1,First,open") {
+		t.Errorf("csv missing row: %s", data)
+	}
+}
@@ -0,0 +1,23 @@
+package monitor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/marcus/td/internal/db"
+)
+
+// renderFileOverlapHint renders a one-line hint naming the open issues that
+// link the same files as the modal's issue. Advisory only; td related lists
+// the shared files.
+func renderFileOverlapHint(overlaps []db.FileOverlap, contentWidth int) []string {
+	if len(overlaps) == 0 {
+		return nil
+	}
+	parts := make([]string, 0, len(overlaps))
+	for _, o := range overlaps {
+		parts = append(parts, fmt.Sprintf("%s (%d)", o.IssueID, len(o.SharedFiles)))
+	}
+	hint := "Shares files with: " + strings.Join(parts, ", ")
+	return []string{subtleStyle.Render(truncateString(hint, contentWidth)), ""}
+}
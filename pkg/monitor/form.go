@@ -52,6 +52,9 @@ type FormState struct {
 	Description string
 	Labels      string // Comma-separated
 	Status      string // Only used in edit mode
+	// CustomStatuses are the project's custom workflow statuses, offered
+	// after the built-in ones in edit mode
+	CustomStatuses []models.Status
 
 	// Extended fields (toggled with Tab)
 	ShowExtended bool
@@ -68,10 +71,48 @@ type FormState struct {
 	// Width for form fields (set from modal dimensions, reapplied on rebuild)
 	Width int
 
-	// Autofill state for Parent Epic and Dependencies fields
-	Autofill      *AutofillState // Active dropdown state (nil when not showing)
-	AutofillEpics []AutofillItem // Cached epics (for parent field, type=epic only)
-	AutofillAll   []AutofillItem // Cached all open issues (for dependencies field)
+	// Autofill state for Labels, Parent Epic and Dependencies fields
+	Autofill       *AutofillState // Active dropdown state (nil when not showing)
+	AutofillEpics  []AutofillItem // Cached epics (for parent field, type=epic only)
+	AutofillAll    []AutofillItem // Cached all open issues (for dependencies field)
+	AutofillLabels []AutofillItem // Cached labels in use (for labels field)
+
+	// Values when the form opened, for unsaved-change detection
+	initial formValues
+}
+
+// formValues is the comparable set of user-editable form fields.
+type formValues struct {
+	Title, Type, Priority, Description, Labels, Status string
+	Parent, Points, Acceptance, Dependencies           string
+	Minor                                              bool
+}
+
+// values returns the form's current field values.
+func (fs *FormState) values() formValues {
+	return formValues{
+		Title:        fs.Title,
+		Type:         fs.Type,
+		Priority:     fs.Priority,
+		Description:  fs.Description,
+		Labels:       fs.Labels,
+		Status:       fs.Status,
+		Parent:       fs.Parent,
+		Points:       fs.Points,
+		Acceptance:   fs.Acceptance,
+		Dependencies: fs.Dependencies,
+		Minor:        fs.Minor,
+	}
+}
+
+// markPristine records the current values as the form's unedited state.
+func (fs *FormState) markPristine() {
+	fs.initial = fs.values()
+}
+
+// IsDirty reports whether any field differs from when the form opened.
+func (fs *FormState) IsDirty() bool {
+	return fs.values() != fs.initial
 }
 
 // NewFormState creates a new form state for creating an issue
@@ -87,11 +128,13 @@ func NewFormState(mode FormMode, parentID string) *FormState {
 		ButtonHover: 0,
 	}
 	state.buildForm()
+	state.markPristine()
 	return state
 }
 
-// NewFormStateForEdit creates a form state populated with existing issue data
-func NewFormStateForEdit(issue *models.Issue) *FormState {
+// NewFormStateForEdit creates a form state populated with existing issue
+// data. customStatuses are the project's custom workflow statuses.
+func NewFormStateForEdit(issue *models.Issue, customStatuses []models.Status) *FormState {
 	state := &FormState{
 		Mode:        FormModeEdit,
 		IssueID:     issue.ID,
@@ -108,7 +151,9 @@ func NewFormStateForEdit(issue *models.Issue) *FormState {
 		ButtonFocus: formButtonFocusForm,
 		ButtonHover: 0,
 	}
+	state.CustomStatuses = customStatuses
 	state.buildForm()
+	state.markPristine()
 	return state
 }
 
@@ -152,6 +197,9 @@ func (fs *FormState) buildForm() {
 		huh.NewOption("In Review", string(models.StatusInReview)),
 		huh.NewOption("Closed", string(models.StatusClosed)),
 	}
+	for _, status := range fs.CustomStatuses {
+		statusOptions = append(statusOptions, huh.NewOption(string(status), string(status)))
+	}
 
 	titleStr := "New Issue"
 	if fs.Mode == FormModeEdit {
@@ -319,16 +367,19 @@ func (fs *FormState) GetDependencies() []string {
 	return parseLabels(fs.Dependencies)
 }
 
-// parseLabels parses a comma-separated string into a slice of trimmed strings
+// parseLabels parses a comma-separated string into a slice of trimmed
+// strings, dropping empty entries and repeats
 func parseLabels(s string) []string {
 	if strings.TrimSpace(s) == "" {
 		return nil
 	}
 	parts := strings.Split(s, ",")
 	var result []string
+	seen := make(map[string]bool, len(parts))
 	for _, p := range parts {
 		p = strings.TrimSpace(p)
-		if p != "" {
+		if p != "" && !seen[p] {
+			seen[p] = true
 			result = append(result, p)
 		}
 	}
@@ -16,27 +16,32 @@ import (
 // AutofillState holds state for an autocomplete dropdown attached to a form field.
 type AutofillState struct {
 	Active   bool           // Whether the dropdown is showing
-	FieldKey string         // Which form field this is for ("parent" or "dependencies")
+	FieldKey string         // Which form field this is for ("parent", "dependencies" or "labels")
 	Filtered []AutofillItem // Filtered by current query
 	Idx      int            // Selected index in dropdown
 	Query    string         // Current search text (tracked separately from huh field value)
 }
 
-// AutofillItem represents a single autocomplete suggestion.
+// AutofillItem represents a single autocomplete suggestion. Label
+// suggestions carry the label as ID and its usage as Title.
 type AutofillItem struct {
 	ID    string
 	Title string
 	Type  models.Type
 }
 
-// AutofillResultMsg carries loaded issues for the autocomplete dropdown.
+// AutofillResultMsg carries loaded issues and labels for the autocomplete
+// dropdown.
 type AutofillResultMsg struct {
-	Items []AutofillItem
+	Items  []AutofillItem
+	Labels []AutofillItem
 }
 
-// loadAutofillData fetches all non-closed issues from the database for autocomplete.
+// loadAutofillData fetches all non-closed issues and every label in use
+// from the database for autocomplete.
 func loadAutofillData(database *db.DB) tea.Cmd {
 	return func() tea.Msg {
+		labels := labelAutofillItems(database)
 		issues, err := database.ListIssues(db.ListIssuesOptions{
 			Status: []models.Status{
 				models.StatusOpen,
@@ -47,7 +52,7 @@ func loadAutofillData(database *db.DB) tea.Cmd {
 			Limit: 500,
 		})
 		if err != nil {
-			return AutofillResultMsg{Items: nil}
+			return AutofillResultMsg{Items: nil, Labels: labels}
 		}
 
 		items := make([]AutofillItem, len(issues))
@@ -58,10 +63,29 @@ func loadAutofillData(database *db.DB) tea.Cmd {
 				Type:  issue.Type,
 			}
 		}
-		return AutofillResultMsg{Items: items}
+		return AutofillResultMsg{Items: items, Labels: labels}
 	}
 }
 
+// labelAutofillItems returns one suggestion per label in use, most used
+// first.
+func labelAutofillItems(database *db.DB) []AutofillItem {
+	counts, err := database.ListAllLabels()
+	if err != nil {
+		return nil
+	}
+	sort.SliceStable(counts, func(i, j int) bool { return counts[i].Count > counts[j].Count })
+	items := make([]AutofillItem, len(counts))
+	for i, lc := range counts {
+		usage := "1 issue"
+		if lc.Count != 1 {
+			usage = fmt.Sprintf("%d issues", lc.Count)
+		}
+		items[i] = AutofillItem{ID: lc.Label, Title: usage}
+	}
+	return items
+}
+
 // autofillSearchSource adapts []AutofillItem for the fuzzy library.
 // Each item produces a searchable string of "ID Title" so fuzzy matching
 // works across both fields simultaneously.
@@ -96,8 +120,8 @@ func filterAutofillItems(query string, items []AutofillItem) []AutofillItem {
 	return result
 }
 
-// currentDepToken extracts the text after the last comma for multi-select dependency input.
-// For "td-xxx, td-yyy, partial" it returns "partial".
+// currentDepToken extracts the text after the last comma for multi-select
+// dependency and label input. For "td-xxx, td-yyy, partial" it returns "partial".
 func currentDepToken(deps string) string {
 	parts := strings.Split(deps, ",")
 	if len(parts) == 0 {
@@ -143,16 +167,42 @@ func isExactAutofillID(query string, items []AutofillItem) bool {
 // syncAutofillState detects which form field is focused and activates/deactivates
 // the autofill dropdown accordingly. Called after every huh form update.
 func (m *Model) syncAutofillState() {
-	if m.FormState == nil || !m.FormState.ShowExtended {
-		if m.FormState != nil {
-			m.FormState.Autofill = nil
-		}
+	if m.FormState == nil {
 		return
 	}
 
+	// Labels sit in the standard group; the rest only show when extended
 	focusedKey := m.FormState.focusedFieldKey()
+	if !m.FormState.ShowExtended && focusedKey != formKeyLabels {
+		m.FormState.Autofill = nil
+		return
+	}
 
 	switch focusedKey {
+	case formKeyLabels:
+		query := currentDepToken(m.FormState.Labels)
+		// Don't show dropdown if current token is an exact label (user just selected)
+		if isExactAutofillID(query, m.FormState.AutofillLabels) {
+			m.FormState.Autofill = nil
+			return
+		}
+		// Don't re-activate with empty query after selection cleared the state
+		if m.FormState.Autofill == nil && query == "" {
+			return
+		}
+		if m.FormState.Autofill == nil || m.FormState.Autofill.FieldKey != formKeyLabels {
+			m.FormState.Autofill = &AutofillState{
+				Active:   true,
+				FieldKey: formKeyLabels,
+			}
+		}
+		if query != m.FormState.Autofill.Query {
+			m.FormState.Autofill.Query = query
+			filtered := filterAutofillItems(query, m.FormState.AutofillLabels)
+			m.FormState.Autofill.Filtered = excludeIDs(filtered, parseLabels(m.FormState.Labels))
+			m.FormState.Autofill.Idx = 0
+		}
+
 	case formKeyParent:
 		query := m.FormState.Parent
 		// Don't show dropdown if value is an exact item ID (user just selected)
@@ -223,15 +273,22 @@ func (m Model) selectAutofillItem() (tea.Model, tea.Cmd) {
 		return m, m.FormState.Form.NextGroup()
 	}
 
-	if af.FieldKey == formKeyDependencies {
-		// Replace only the current token (text after last comma) with the selected ID
-		parts := strings.Split(m.FormState.Dependencies, ",")
-		if len(parts) > 0 {
-			parts[len(parts)-1] = " " + selected.ID
-		} else {
-			parts = []string{selected.ID}
+	if af.FieldKey == formKeyLabels {
+		m.FormState.Labels = replaceLastToken(m.FormState.Labels, selected.ID)
+		m.FormState.Autofill = nil
+		m.FormState.buildForm()
+		// Init form synchronously, discard cmd to avoid tea.WindowSize() race.
+		_ = m.FormState.Form.Init()
+		// Labels is the fifth field of the standard group; only the last
+		// cmd runs async (target field cursor).
+		for i := 0; i < 3; i++ {
+			_ = m.FormState.Form.NextField()
 		}
-		m.FormState.Dependencies = strings.TrimSpace(strings.Join(parts, ",")) + ", "
+		return m, m.FormState.Form.NextField()
+	}
+
+	if af.FieldKey == formKeyDependencies {
+		m.FormState.Dependencies = replaceLastToken(m.FormState.Dependencies, selected.ID)
 		// Close dropdown after selection (syncAutofillState won't re-activate
 		// because currentDepToken returns "" which triggers the nil guard)
 		m.FormState.Autofill = nil
@@ -247,6 +304,15 @@ func (m Model) selectAutofillItem() (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// replaceLastToken replaces the text after the last comma of a
+// comma-separated value with value, leaving a trailing ", " for the next
+// entry.
+func replaceLastToken(list, value string) string {
+	parts := strings.Split(list, ",")
+	parts[len(parts)-1] = " " + value
+	return strings.TrimSpace(strings.Join(parts, ",")) + ", "
+}
+
 // insertDropdownAfterField injects dropdownView into formView by scanning
 // for the line containing nextFieldTitle and inserting the dropdown before it.
 // Uses ansi.Strip for reliable matching in ANSI-styled output.
@@ -276,6 +342,10 @@ func (m Model) renderFormAutofillDropdown() string {
 	af := m.FormState.Autofill
 
 	if len(af.Filtered) == 0 {
+		if af.FieldKey == formKeyLabels {
+			// Any new label is fine, so there's nothing to warn about
+			return ""
+		}
 		if af.Query != "" {
 			return subtleStyle.Render("  No matching issues")
 		}
@@ -300,8 +370,11 @@ func (m Model) renderFormAutofillDropdown() string {
 
 	// Header hint
 	fieldLabel := "epics"
-	if af.FieldKey == formKeyDependencies {
+	switch af.FieldKey {
+	case formKeyDependencies:
 		fieldLabel = "issues"
+	case formKeyLabels:
+		fieldLabel = "labels"
 	}
 	lines = append(lines, subtleStyle.Render(fmt.Sprintf("  Matching %s:", fieldLabel)))
 
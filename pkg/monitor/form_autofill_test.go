@@ -242,3 +242,59 @@ func TestIsExactAutofillID_Partial(t *testing.T) {
 		t.Error("expected false for partial ID match")
 	}
 }
+
+func TestReplaceLastToken(t *testing.T) {
+	tests := []struct {
+		list, value, want string
+	}{
+		{"", "bug", "bug, "},
+		{"b", "bug", "bug, "},
+		{"ui, b", "bug", "ui, bug, "},
+		{"ui, ", "bug", "ui, bug, "},
+	}
+	for _, tt := range tests {
+		if got := replaceLastToken(tt.list, tt.value); got != tt.want {
+			t.Errorf("replaceLastToken(%q, %q) = %q, want %q", tt.list, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestLabelAutofillSuggestsUnusedLabels(t *testing.T) {
+	m := newTestModel()
+	m.FormState = NewFormState(FormModeCreate, "")
+	m.FormState.AutofillLabels = []AutofillItem{
+		{ID: "backend", Title: "3 issues"},
+		{ID: "bug", Title: "2 issues"},
+		{ID: "ui", Title: "1 issue"},
+	}
+	_ = m.FormState.Form.Init()
+	for i := 0; i < 4; i++ {
+		_ = m.FormState.Form.NextField()
+	}
+	if key := m.FormState.focusedFieldKey(); key != formKeyLabels {
+		t.Fatalf("focused field = %q, want labels", key)
+	}
+
+	// Labels autofill works without the extended fields
+	m.FormState.Labels = "bug, b"
+	m.syncAutofillState()
+	af := m.FormState.Autofill
+	if af == nil || af.FieldKey != formKeyLabels {
+		t.Fatalf("autofill = %+v, want labels dropdown", af)
+	}
+	if len(af.Filtered) != 1 || af.Filtered[0].ID != "backend" {
+		t.Fatalf("filtered = %v, want [backend] (bug already set)", af.Filtered)
+	}
+
+	next, _ := m.selectAutofillItem()
+	m = next.(Model)
+	if m.FormState.Labels != "bug, backend, " {
+		t.Errorf("labels after select = %q, want %q", m.FormState.Labels, "bug, backend, ")
+	}
+	if m.FormState.Autofill != nil {
+		t.Error("dropdown should close after selection")
+	}
+	if key := m.FormState.focusedFieldKey(); key != formKeyLabels {
+		t.Errorf("focus after select = %q, want labels", key)
+	}
+}
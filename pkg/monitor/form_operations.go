@@ -9,8 +9,8 @@ import (
 
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
+	"github.com/marcus/td/internal/config"
 	"github.com/marcus/td/internal/models"
-	"github.com/marcus/td/internal/workflow"
 )
 
 // openNewIssueForm opens the new issue form
@@ -61,7 +61,7 @@ func (m Model) openEditIssueForm() (tea.Model, tea.Cmd) {
 	}
 
 	// Create form state with issue data
-	m.FormState = NewFormStateForEdit(issue)
+	m.FormState = NewFormStateForEdit(issue, m.CustomStatuses)
 	m.FormOpen = true
 	m.FormScrollOffset = 0
 
@@ -70,6 +70,7 @@ func (m Model) openEditIssueForm() (tea.Model, tea.Cmd) {
 	if len(depIDs) > 0 {
 		m.FormState.Dependencies = strings.Join(depIDs, ", ")
 		m.FormState.buildForm()
+		m.FormState.markPristine()
 	}
 
 	// Set form width for text wrapping (subtract modal horizontal padding)
@@ -135,7 +136,7 @@ func (m Model) submitForm() (tea.Model, tea.Cmd) {
 
 		// Validate status transition if changed
 		if statusChanged {
-			sm := workflow.DefaultMachine()
+			sm := m.workflowMachine()
 			if !sm.IsValidTransition(oldStatus, newStatus) {
 				m.StatusMessage = fmt.Sprintf("Invalid transition: %s → %s", oldStatus, newStatus)
 				m.StatusIsError = true
@@ -143,6 +144,15 @@ func (m Model) submitForm() (tea.Model, tea.Cmd) {
 					return ClearStatusMsg{}
 				})
 			}
+			// The form has no reason field, so send closes that need one
+			// through the close modal
+			if required, _ := config.GetRequireCloseReason(m.BaseDir); required && newStatus == models.StatusClosed {
+				m.StatusMessage = "A reason is required to close issues in this project: close with C instead"
+				m.StatusIsError = true
+				return m, tea.Tick(3*time.Second, func(t time.Time) tea.Msg {
+					return ClearStatusMsg{}
+				})
+			}
 		}
 
 		// Determine action type based on status transition
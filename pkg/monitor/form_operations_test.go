@@ -595,7 +595,7 @@ func TestEditorFormModes(t *testing.T) {
 					Title: "Test Issue",
 					Type:  models.TypeTask,
 				}
-				fs = NewFormStateForEdit(issue)
+				fs = NewFormStateForEdit(issue, nil)
 			}
 
 			if fs == nil {
@@ -823,7 +823,7 @@ func TestFormStateAfterEditorOnEditMode(t *testing.T) {
 		Type:  models.TypeTask,
 	}
 
-	fs := NewFormStateForEdit(issue)
+	fs := NewFormStateForEdit(issue, nil)
 	fs.Description = "Original description"
 
 	m := Model{FormState: fs}
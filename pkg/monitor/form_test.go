@@ -3,7 +3,10 @@ package monitor
 import (
 	"testing"
 
+	"github.com/marcus/td/internal/config"
+	"github.com/marcus/td/internal/db"
 	"github.com/marcus/td/internal/models"
+	"github.com/marcus/td/pkg/monitor/keymap"
 )
 
 // TestPointsToString tests conversion from int to string for story points
@@ -277,7 +280,7 @@ func TestNewFormStateForEdit(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			state := NewFormStateForEdit(tt.issue)
+			state := NewFormStateForEdit(tt.issue, nil)
 			tt.validate(t, state)
 		})
 	}
@@ -613,7 +616,7 @@ func TestFormStateEditModeTitle(t *testing.T) {
 		Priority: models.PriorityP2,
 	}
 
-	state := NewFormStateForEdit(issue)
+	state := NewFormStateForEdit(issue, nil)
 
 	if state.Mode != FormModeEdit {
 		t.Errorf("Mode = %v, want FormModeEdit", state.Mode)
@@ -708,7 +711,7 @@ func TestFormStatePreservesAllIssueFields(t *testing.T) {
 	}
 
 	// Create form state from issue
-	formState := NewFormStateForEdit(originalIssue)
+	formState := NewFormStateForEdit(originalIssue, nil)
 
 	// Convert back to issue
 	newIssue := formState.ToIssue()
@@ -755,3 +758,191 @@ func slicesEqual(a, b []string) bool {
 	}
 	return true
 }
+
+// TestFormStateIsDirty tests unsaved-change detection against the values the
+// form opened with
+func TestFormStateIsDirty(t *testing.T) {
+	issue := &models.Issue{
+		ID:       "td-abc123",
+		Title:    "Original",
+		Type:     models.TypeBug,
+		Priority: models.PriorityP1,
+		Status:   models.StatusOpen,
+		Labels:   []string{"ui"},
+	}
+
+	tests := []struct {
+		name  string
+		edit  func(fs *FormState)
+		dirty bool
+	}{
+		{"pristine", func(fs *FormState) {}, false},
+		{"toggle extended only", func(fs *FormState) { fs.ToggleExtended() }, false},
+		{"title edited", func(fs *FormState) { fs.Title = "Changed" }, true},
+		{"priority edited", func(fs *FormState) { fs.Priority = string(models.PriorityP3) }, true},
+		{"minor toggled", func(fs *FormState) { fs.Minor = true }, true},
+		{"dependencies edited", func(fs *FormState) { fs.Dependencies = "td-xyz" }, true},
+		{"edited then reverted", func(fs *FormState) {
+			orig := fs.Title
+			fs.Title = "Changed"
+			fs.Title = orig
+		}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, fs := range []*FormState{NewFormStateForEdit(issue, nil), NewFormState(FormModeCreate, "")} {
+				tt.edit(fs)
+				if got := fs.IsDirty(); got != tt.dirty {
+					t.Errorf("%s form: IsDirty() = %v, want %v", fs.Mode, got, tt.dirty)
+				}
+			}
+		})
+	}
+}
+
+// TestFormCancelConfirmsOnlyWhenDirty tests that cancelling a pristine form
+// closes it while an edited form asks before discarding
+func TestFormCancelConfirmsOnlyWhenDirty(t *testing.T) {
+	m := Model{Keymap: newTestKeymap(), FormOpen: true, FormState: NewFormState(FormModeCreate, "")}
+	updated, _ := m.executeCommand(keymap.CmdFormCancel)
+	if got := updated.(Model); got.FormOpen || got.DiscardConfirmOpen {
+		t.Fatalf("pristine form: expected form closed without confirm, got open=%v confirm=%v", got.FormOpen, got.DiscardConfirmOpen)
+	}
+
+	m.FormState = NewFormState(FormModeCreate, "")
+	m.FormState.Title = "Half-typed title"
+	updated, _ = m.executeCommand(keymap.CmdFormCancel)
+	got := updated.(Model)
+	if !got.FormOpen || !got.DiscardConfirmOpen {
+		t.Fatalf("dirty form: expected discard confirm over open form, got open=%v confirm=%v", got.FormOpen, got.DiscardConfirmOpen)
+	}
+
+	updated, _ = got.handleDiscardConfirmAction("keep")
+	got = updated.(Model)
+	if !got.FormOpen || got.DiscardConfirmOpen || got.FormState.Title != "Half-typed title" {
+		t.Fatal("keep editing: expected form open with input intact")
+	}
+
+	updated, _ = got.executeCommand(keymap.CmdFormCancel)
+	updated, _ = updated.(Model).handleDiscardConfirmAction("discard")
+	if got = updated.(Model); got.FormOpen || got.DiscardConfirmOpen {
+		t.Fatal("discard: expected form and confirm closed")
+	}
+}
+
+// TestFormSubmitLabels tests that labels round-trip through the form
+// submit path on create and edit
+func TestFormSubmitLabels(t *testing.T) {
+	database, err := db.Initialize(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	t.Cleanup(func() { database.Close() })
+
+	m := newTestModel()
+	m.DB = database
+
+	// Create: repeats and blanks are dropped
+	m.FormState = NewFormState(FormModeCreate, "")
+	m.FormOpen = true
+	m.FormState.Title = "Labelled"
+	m.FormState.Labels = " bug, ui ,, bug "
+	next, _ := m.submitForm()
+	m = next.(Model)
+
+	issues, err := database.ListIssues(db.ListIssuesOptions{})
+	if err != nil || len(issues) != 1 {
+		t.Fatalf("ListIssues = %v, %v; want one issue", issues, err)
+	}
+	issue := issues[0]
+	if !slicesEqual(issue.Labels, []string{"bug", "ui"}) {
+		t.Fatalf("created labels = %v, want [bug ui]", issue.Labels)
+	}
+
+	// Edit: existing labels are shown, and the new list replaces them
+	m.FormState = NewFormStateForEdit(&issue, nil)
+	m.FormOpen = true
+	if m.FormState.Labels != "bug, ui" {
+		t.Fatalf("edit form labels = %q, want %q", m.FormState.Labels, "bug, ui")
+	}
+	m.FormState.Labels = "ui, backend, "
+	next, _ = m.submitForm()
+	m = next.(Model)
+
+	got, err := database.GetIssue(issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue: %v", err)
+	}
+	if !slicesEqual(got.Labels, []string{"ui", "backend"}) {
+		t.Errorf("edited labels = %v, want [ui backend]", got.Labels)
+	}
+
+	// Clearing the field removes every label
+	m.FormState = NewFormStateForEdit(got, nil)
+	m.FormOpen = true
+	m.FormState.Labels = ""
+	next, _ = m.submitForm()
+	m = next.(Model)
+
+	got, _ = database.GetIssue(issue.ID)
+	if len(got.Labels) != 0 {
+		t.Errorf("cleared labels = %v, want none", got.Labels)
+	}
+	if m.FormOpen {
+		t.Error("form should close after submit")
+	}
+}
+
+// TestFormEditCloseRequiresReason tests that the edit form refuses to close
+// an issue when the project requires a close reason
+func TestFormEditCloseRequiresReason(t *testing.T) {
+	dir := t.TempDir()
+	database, err := db.Initialize(dir)
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	t.Cleanup(func() { database.Close() })
+
+	issue := &models.Issue{Title: "Needs a reason", Type: models.TypeTask, Status: models.StatusOpen}
+	if err := database.CreateIssue(issue); err != nil {
+		t.Fatalf("CreateIssue: %v", err)
+	}
+
+	m := newTestModel()
+	m.DB = database
+	m.BaseDir = dir
+	submitClosed := func() Model {
+		t.Helper()
+		m.FormState = NewFormStateForEdit(issue, nil)
+		m.FormOpen = true
+		m.FormState.Status = string(models.StatusClosed)
+		next, _ := m.submitForm()
+		return next.(Model)
+	}
+
+	cfg, err := config.Load(dir)
+	if err != nil {
+		t.Fatalf("config.Load: %v", err)
+	}
+	cfg.RequireCloseReason = true
+	if err := config.Save(dir, cfg); err != nil {
+		t.Fatalf("config.Save: %v", err)
+	}
+	got := submitClosed()
+	if !got.FormOpen || !got.StatusIsError {
+		t.Errorf("form open = %v, error = %v; want the form kept open with an error", got.FormOpen, got.StatusIsError)
+	}
+	if stored, _ := database.GetIssue(issue.ID); stored.Status != models.StatusOpen {
+		t.Fatalf("status = %s, want open", stored.Status)
+	}
+
+	cfg.RequireCloseReason = false
+	if err := config.Save(dir, cfg); err != nil {
+		t.Fatalf("config.Save: %v", err)
+	}
+	submitClosed()
+	if stored, _ := database.GetIssue(issue.ID); stored.Status != models.StatusClosed {
+		t.Errorf("status without the policy = %s, want closed", stored.Status)
+	}
+}
@@ -0,0 +1,177 @@
+package monitor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"github.com/marcus/td/pkg/monitor/modal"
+	"github.com/marcus/td/pkg/monitor/mouse"
+)
+
+// toggleMark adds or removes the selected task list issue from the marked
+// set used by bulk actions. In the list view the cursor then moves down so a
+// run of issues can be marked with repeated presses.
+func (m Model) toggleMark() (tea.Model, tea.Cmd) {
+	if m.ActivePanel != PanelTaskList {
+		return m, nil
+	}
+	issueID := m.SelectedIssueID(PanelTaskList)
+	if issueID == "" {
+		return m, nil
+	}
+	if m.MarkedIssues == nil {
+		m.MarkedIssues = make(map[string]bool)
+	}
+	if m.MarkedIssues[issueID] {
+		delete(m.MarkedIssues, issueID)
+	} else {
+		m.MarkedIssues[issueID] = true
+	}
+	if m.TaskListMode != TaskListModeBoard {
+		m.moveCursor(1)
+	}
+	return m, nil
+}
+
+// markedIssueIDs returns the marked issue IDs in a stable order.
+func (m Model) markedIssueIDs() []string {
+	ids := make([]string, 0, len(m.MarkedIssues))
+	for id := range m.MarkedIssues {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// rowTag returns the category tag for a task list row, or a selection tag
+// when the issue is marked for a bulk action. Issues that need rework are
+// tagged as such whichever category they are listed under.
+func (m Model) rowTag(issueID string, cat TaskListCategory) string {
+	if m.MarkedIssues[issueID] {
+		return titleStyle.Render("[SEL]")
+	}
+	if m.isExpiredClaim(issueID) {
+		return expiredClaimColor.Render("[EXP]")
+	}
+	if m.isRework(issueID) {
+		return m.formatCategoryTag(CategoryNeedsRework)
+	}
+	return m.formatCategoryTag(cat)
+}
+
+// groupMarkedAction opens the parent prompt for the marked issues. With
+// nothing marked it explains how to mark instead.
+func (m Model) groupMarkedAction() (tea.Model, tea.Cmd) {
+	if len(m.MarkedIssues) == 0 {
+		m.StatusMessage = "No issues marked (space to mark)"
+		m.StatusIsError = true
+		return m, tea.Tick(2*time.Second, func(t time.Time) tea.Msg { return ClearStatusMsg{} })
+	}
+	m = m.openGroupModal()
+	return m, nil
+}
+
+// openGroupModal opens the group-under-parent prompt. Same shape as the
+// close-confirm modal: one text input plus Confirm / Cancel.
+func (m Model) openGroupModal() Model {
+	m.GroupOpen = true
+
+	m.GroupInput = textinput.New()
+	m.GroupInput.Placeholder = "Parent epic ID"
+	m.GroupInput.SetWidth(40)
+	m.GroupInput.Focus()
+
+	m.GroupModal = m.createGroupModal()
+	m.GroupModal.Reset()
+	m.GroupMouseHandler = mouse.NewHandler()
+	return m
+}
+
+// closeGroupModal closes the group prompt. Marks are kept so a typo in the
+// parent ID doesn't lose the selection.
+func (m *Model) closeGroupModal() {
+	m.GroupOpen = false
+	m.GroupModal = nil
+	m.GroupMouseHandler = nil
+}
+
+// createGroupModal builds the declarative modal for the group prompt.
+func (m *Model) createGroupModal() *modal.Modal {
+	n := len(m.MarkedIssues)
+	noun := "issues"
+	if n == 1 {
+		noun = "issue"
+	}
+	md := modal.New(fmt.Sprintf("Group %d %s", n, noun),
+		modal.WithWidth(56),
+		modal.WithHints(false),
+		modal.WithPrimaryAction("confirm"),
+	)
+
+	ids := strings.Join(m.markedIssueIDs(), ", ")
+	if len(ids) > 48 {
+		ids = ids[:45] + "..."
+	}
+	md.AddSection(modal.Text(ids))
+	md.AddSection(modal.Spacer())
+	md.AddSection(modal.InputWithLabel("parent", "Move under:", &m.GroupInput,
+		modal.WithSubmitOnEnter(true),
+		modal.WithSubmitAction("confirm"),
+	))
+	md.AddSection(modal.Spacer())
+	md.AddSection(modal.Buttons(
+		modal.Btn(" Confirm ", "confirm"),
+		modal.Btn(" Cancel ", "cancel"),
+	))
+	md.AddSection(modal.Spacer())
+	md.AddSection(modal.Text("Tab:switch  Enter:confirm  Esc:cancel"))
+	return md
+}
+
+// handleGroupAction handles button actions on the group prompt.
+func (m Model) handleGroupAction(action string) (tea.Model, tea.Cmd) {
+	switch action {
+	case "confirm":
+		return m.executeGroup()
+	case "cancel":
+		m.closeGroupModal()
+		return m, nil
+	}
+	return m, nil
+}
+
+// executeGroup moves the marked issues under the entered parent. On failure
+// (unknown parent, or a move that would create a cycle) the prompt stays open
+// with the error in the status bar and nothing is moved.
+func (m Model) executeGroup() (tea.Model, tea.Cmd) {
+	parentID := strings.TrimSpace(m.GroupInput.Value())
+	if parentID == "" {
+		m.StatusMessage = "Enter a parent issue ID"
+		m.StatusIsError = true
+		return m, tea.Tick(2*time.Second, func(t time.Time) tea.Msg { return ClearStatusMsg{} })
+	}
+
+	moved, _, err := m.DB.BulkReparent(m.markedIssueIDs(), parentID, m.SessionID)
+	if err != nil {
+		m.StatusMessage = "Group failed: " + err.Error()
+		m.StatusIsError = true
+		return m, tea.Tick(3*time.Second, func(t time.Time) tea.Msg { return ClearStatusMsg{} })
+	}
+
+	m.closeGroupModal()
+	m.MarkedIssues = nil
+	m.StatusMessage = fmt.Sprintf("Grouped %d under %s", len(moved), parentID)
+	m.StatusIsError = false
+	refresh := m.fetchData()
+	if m.TaskListMode == TaskListModeBoard && m.BoardMode.Board != nil {
+		refresh = m.fetchBoardIssues(m.BoardMode.Board.ID)
+	}
+	return m, tea.Batch(
+		refresh,
+		tea.Tick(2*time.Second, func(t time.Time) tea.Msg { return ClearStatusMsg{} }),
+	)
+}
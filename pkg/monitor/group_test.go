@@ -0,0 +1,102 @@
+package monitor
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/models"
+	"github.com/marcus/td/pkg/monitor/keymap"
+)
+
+func TestSpaceAndShiftPBindings(t *testing.T) {
+	km := newTestKeymap()
+	if cmd, found := km.Lookup(tea.KeyPressMsg{Code: tea.KeySpace}, keymap.ContextMain); !found || cmd != keymap.CmdToggleMark {
+		t.Errorf("space in main = %v (found=%v), want CmdToggleMark", cmd, found)
+	}
+	if cmd, found := km.Lookup(tea.KeyPressMsg{Code: 'P', Text: "P"}, keymap.ContextMain); !found || cmd != keymap.CmdGroupMarked {
+		t.Errorf("P in main = %v (found=%v), want CmdGroupMarked", cmd, found)
+	}
+}
+
+// TestGroupMarkedIssues marks two rows, then moves them under an epic
+// through the group prompt.
+func TestGroupMarkedIssues(t *testing.T) {
+	baseDir := t.TempDir()
+	database, err := db.Initialize(baseDir)
+	if err != nil {
+		t.Fatalf("db init: %v", err)
+	}
+	defer database.Close()
+
+	epic := &models.Issue{Title: "Epic", Type: models.TypeEpic}
+	a := &models.Issue{Title: "a"}
+	b := &models.Issue{Title: "b"}
+	for _, issue := range []*models.Issue{epic, a, b} {
+		if err := database.CreateIssue(issue); err != nil {
+			t.Fatalf("create: %v", err)
+		}
+	}
+
+	m := Model{
+		DB:           database,
+		SessionID:    "ses-1",
+		BaseDir:      baseDir,
+		ActivePanel:  PanelTaskList,
+		SelectedID:   map[Panel]string{},
+		Cursor:       map[Panel]int{PanelTaskList: 0},
+		ScrollOffset: map[Panel]int{},
+		TaskListRows: []TaskListRow{
+			{Issue: *a, Category: CategoryReady},
+			{Issue: *b, Category: CategoryReady},
+			{Issue: *epic, Category: CategoryReady},
+		},
+	}
+
+	// Nothing marked yet: P just reports it
+	updated, _ := m.groupMarkedAction()
+	m = updated.(Model)
+	if m.GroupOpen || !m.StatusIsError {
+		t.Fatalf("expected status error with nothing marked, got open=%v msg=%q", m.GroupOpen, m.StatusMessage)
+	}
+
+	// Marking advances the cursor, so two presses mark a and b
+	for i := 0; i < 2; i++ {
+		updated, _ = m.toggleMark()
+		m = updated.(Model)
+	}
+	if !m.MarkedIssues[a.ID] || !m.MarkedIssues[b.ID] || len(m.MarkedIssues) != 2 {
+		t.Fatalf("MarkedIssues = %v, want a and b", m.MarkedIssues)
+	}
+	if tag := m.rowTag(a.ID, CategoryReady); !strings.Contains(tag, "[SEL]") {
+		t.Errorf("rowTag for marked issue = %q, want [SEL]", tag)
+	}
+
+	updated, _ = m.groupMarkedAction()
+	m = updated.(Model)
+	if !m.GroupOpen {
+		t.Fatal("expected group prompt to open")
+	}
+
+	// A parent that is one of the marked issues is refused and nothing moves
+	m.GroupInput.SetValue(a.ID)
+	updated, _ = m.executeGroup()
+	m = updated.(Model)
+	if !m.GroupOpen || !m.StatusIsError {
+		t.Fatalf("expected prompt to stay open with an error, got open=%v msg=%q", m.GroupOpen, m.StatusMessage)
+	}
+
+	m.GroupInput.SetValue(epic.ID)
+	updated, _ = m.executeGroup()
+	m = updated.(Model)
+	if m.GroupOpen || len(m.MarkedIssues) != 0 {
+		t.Errorf("expected prompt closed and marks cleared, got open=%v marks=%v", m.GroupOpen, m.MarkedIssues)
+	}
+	for _, id := range []string{a.ID, b.ID} {
+		got, _ := database.GetIssue(id)
+		if got.ParentID != epic.ID {
+			t.Errorf("%s parent = %q, want %s", id, got.ParentID, epic.ID)
+		}
+	}
+}
@@ -441,29 +441,38 @@ func (m *Model) buildCurrentWorkRows() {
 
 // buildTaskListRows builds the flattened list of task list rows with category metadata
 func (m *Model) buildTaskListRows() {
-	m.TaskListRows = nil
+	m.TaskListRows = taskListRows(m.TaskList)
+	if m.ExpiredClaimsOnly {
+		m.TaskListRows = expiredClaimRows(m.TaskListRows, m.TaskList.ExpiredClaimIDs)
+	}
+}
+
+// taskListRows flattens task list data into display rows.
+func taskListRows(data TaskListData) []TaskListRow {
+	var rows []TaskListRow
 	// Order: Reviewable, NeedsRework, InProgress, Ready, PendingReview, Blocked, Closed
-	for _, issue := range m.TaskList.Reviewable {
-		m.TaskListRows = append(m.TaskListRows, TaskListRow{Issue: issue, Category: CategoryReviewable})
+	for _, issue := range data.Reviewable {
+		rows = append(rows, TaskListRow{Issue: issue, Category: CategoryReviewable})
 	}
-	for _, issue := range m.TaskList.NeedsRework {
-		m.TaskListRows = append(m.TaskListRows, TaskListRow{Issue: issue, Category: CategoryNeedsRework})
+	for _, issue := range data.NeedsRework {
+		rows = append(rows, TaskListRow{Issue: issue, Category: CategoryNeedsRework})
 	}
-	for _, issue := range m.TaskList.InProgress {
-		m.TaskListRows = append(m.TaskListRows, TaskListRow{Issue: issue, Category: CategoryInProgress})
+	for _, issue := range data.InProgress {
+		rows = append(rows, TaskListRow{Issue: issue, Category: CategoryInProgress})
 	}
-	for _, issue := range m.TaskList.Ready {
-		m.TaskListRows = append(m.TaskListRows, TaskListRow{Issue: issue, Category: CategoryReady})
+	for _, issue := range data.Ready {
+		rows = append(rows, TaskListRow{Issue: issue, Category: CategoryReady})
 	}
-	for _, issue := range m.TaskList.PendingReview {
-		m.TaskListRows = append(m.TaskListRows, TaskListRow{Issue: issue, Category: CategoryPendingReview})
+	for _, issue := range data.PendingReview {
+		rows = append(rows, TaskListRow{Issue: issue, Category: CategoryPendingReview})
 	}
-	for _, issue := range m.TaskList.Blocked {
-		m.TaskListRows = append(m.TaskListRows, TaskListRow{Issue: issue, Category: CategoryBlocked})
+	for _, issue := range data.Blocked {
+		rows = append(rows, TaskListRow{Issue: issue, Category: CategoryBlocked})
 	}
-	for _, issue := range m.TaskList.Closed {
-		m.TaskListRows = append(m.TaskListRows, TaskListRow{Issue: issue, Category: CategoryClosed})
+	for _, issue := range data.Closed {
+		rows = append(rows, TaskListRow{Issue: issue, Category: CategoryClosed})
 	}
+	return rows
 }
 
 // restoreCursors restores cursor positions from saved issue IDs after data refresh
@@ -1047,6 +1056,73 @@ func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 		}
 	}
 
+	// Handle group prompt mouse events (declarative modal)
+	if m.GroupOpen && m.GroupModal != nil && m.GroupMouseHandler != nil {
+		if isLeftClick {
+			action := m.GroupModal.HandleMouse(msg, m.GroupMouseHandler)
+			if action != "" {
+				return m.handleGroupAction(action)
+			}
+			return m, nil
+		}
+		if isMotion {
+			_ = m.GroupModal.HandleMouse(msg, m.GroupMouseHandler)
+			return m, nil
+		}
+	}
+
+	// Handle quick-add prompt mouse events (declarative modal)
+	if m.QuickAddOpen && m.QuickAddModal != nil && m.QuickAddMouseHandler != nil {
+		if isLeftClick {
+			action := m.QuickAddModal.HandleMouse(msg, m.QuickAddMouseHandler)
+			if action != "" {
+				return m.handleQuickAddAction(action)
+			}
+			return m, nil
+		}
+		if isMotion {
+			_ = m.QuickAddModal.HandleMouse(msg, m.QuickAddMouseHandler)
+			return m, nil
+		}
+	}
+
+	// Message history pane swallows mouse events while open
+	if m.StatusHistoryOpen && m.StatusHistoryModal != nil && m.StatusHistoryMouseHandler != nil {
+		if isLeftClick || isMotion {
+			_ = m.StatusHistoryModal.HandleMouse(msg, m.StatusHistoryMouseHandler)
+			return m, nil
+		}
+	}
+
+	// Handle dependency graph mouse events (declarative modal)
+	if m.DepGraphOpen && m.DepGraphModal != nil && m.DepGraphMouseHandler != nil {
+		if isLeftClick {
+			if action := m.DepGraphModal.HandleMouse(msg, m.DepGraphMouseHandler); action != "" {
+				return m.handleDepGraphAction(action)
+			}
+			return m, nil
+		}
+		if isMotion {
+			_ = m.DepGraphModal.HandleMouse(msg, m.DepGraphMouseHandler)
+			return m, nil
+		}
+	}
+
+	// Handle label picker mouse events (declarative modal)
+	if m.LabelPickerOpen && m.LabelPickerModal != nil && m.LabelPickerMouseHandler != nil {
+		if isLeftClick {
+			action := m.LabelPickerModal.HandleMouse(msg, m.LabelPickerMouseHandler)
+			if action != "" {
+				return m.handleLabelPickerAction(action)
+			}
+			return m, nil
+		}
+		if isMotion {
+			_ = m.LabelPickerModal.HandleMouse(msg, m.LabelPickerMouseHandler)
+			return m, nil
+		}
+	}
+
 	// Handle Record-review modal mouse events (declarative modal)
 	if m.RecordReviewOpen && m.RecordReviewModal != nil && m.RecordReviewMouseHandler != nil {
 		if isLeftClick {
@@ -1117,7 +1193,7 @@ func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 	}
 
 	// Ignore other mouse events when modals/overlays are open
-	if m.ModalOpen() || m.ActivityDetailOpen || m.StatsOpen || m.HandoffsOpen || m.ConfirmOpen || m.CloseConfirmOpen || m.SelfReviewConfirmOpen || m.RecordReviewOpen || m.FormOpen || m.BoardPickerOpen || m.BoardEditorOpen || m.HelpOpen || m.ShowTDQHelp || m.GettingStartedOpen || m.SyncPromptOpen {
+	if m.ModalOpen() || m.ActivityDetailOpen || m.StatsOpen || m.HandoffsOpen || m.ConfirmOpen || m.CloseConfirmOpen || m.SelfReviewConfirmOpen || m.RecordReviewOpen || m.GroupOpen || m.QuickAddOpen || m.StatusHistoryOpen || m.DepGraphOpen || m.LabelPickerOpen || m.FormOpen || m.BoardPickerOpen || m.BoardEditorOpen || m.HelpOpen || m.ShowTDQHelp || m.GettingStartedOpen || m.SyncPromptOpen {
 		return m, nil
 	}
 
@@ -1138,6 +1214,9 @@ func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 		if m.DraggingDivider >= 0 {
 			return m.endDividerDrag()
 		}
+		if m.BoardMode.Drag.Active {
+			return m.endBoardDrag()
+		}
 	}
 
 	if isMotion {
@@ -1146,6 +1225,11 @@ func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 			return m.updateDividerDrag(mouseEvent.Y)
 		}
 
+		// Handle board issue dragging
+		if m.BoardMode.Drag.Active {
+			return m.updateBoardDrag(mouseEvent.X, mouseEvent.Y)
+		}
+
 		// Track divider hover for visual feedback
 		divider := m.HitTestDivider(mouseEvent.X, mouseEvent.Y)
 		if divider != m.DividerHover {
@@ -1486,6 +1570,10 @@ func (m Model) handleMouseClick(x, y int) (tea.Model, tea.Cmd) {
 					m.BoardMode.Cursor = row
 				}
 			}
+			// Pressing on an issue may start a drag to reorder it
+			if !isDoubleClick {
+				m = m.startBoardDrag(row)
+			}
 		} else if row != m.Cursor[panel] {
 			m.Cursor[panel] = row
 			m.ScrollIndependent[panel] = false
@@ -1544,8 +1632,7 @@ func (m Model) handleFormDialogClick(x, y int) (tea.Model, tea.Cmd) {
 		return m.submitForm()
 	}
 	if x >= cancelStartX && x < cancelEndX {
-		m.closeForm()
-		return m, nil
+		return m.leaveForm(false)
 	}
 
 	return m, nil
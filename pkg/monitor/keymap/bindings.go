@@ -42,23 +42,39 @@ func DefaultBindings() []Binding {
 		{Key: "enter", Command: CmdOpenDetails, Context: ContextMain, Description: "Open details"},
 		{Key: "s", Command: CmdOpenStats, Context: ContextMain, Description: "Open statistics"},
 		{Key: "h", Command: CmdOpenHandoffs, Context: ContextMain, Description: "Open handoffs"},
+		{Key: "M", Command: CmdToggleStatusHistory, Context: ContextMain, Description: "Message history"},
 		{Key: "N", Command: CmdOpenNotes, Context: ContextMain, Description: "Open notes"},
 		{Key: "/", Command: CmdSearch, Context: ContextMain, Description: "Search"},
 		{Key: "c", Command: CmdToggleClosed, Context: ContextMain, Description: "Toggle closed tasks"},
 		{Key: "S", Command: CmdCycleSortMode, Context: ContextMain, Description: "Cycle sort mode"},
+		{Key: "D", Command: CmdToggleAgeColumns, Context: ContextMain, Description: "Toggle age columns"},
+		{Key: "X", Command: CmdToggleExpiredClaims, Context: ContextMain, Description: "Toggle expired claims filter"},
 		{Key: "T", Command: CmdCycleTypeFilter, Context: ContextMain, Description: "Cycle type filter"},
+		{Key: "L", Command: CmdOpenLabelFilter, Context: ContextMain, Description: "Filter by label"},
+		{Key: "m", Command: CmdCycleQueryPreset, Context: ContextMain, Description: "Cycle query preset (my queue/review/rework)"},
 		{Key: "r", Command: CmdMarkForReview, Context: ContextMain, Description: "Review/Refresh"},
 		{Key: "R", Command: CmdMarkForReview, Context: ContextMain, Description: "Submit for review"},
+		{Key: "t", Command: CmdCycleIssueStatus, Context: ContextMain, Description: "Cycle issue status"},
+		{Key: "+", Command: CmdRaiseIssuePriority, Context: ContextMain, Description: "Raise issue priority"},
+		{Key: "-", Command: CmdLowerIssuePriority, Context: ContextMain, Description: "Lower issue priority"},
+		{Key: "ctrl+r", Command: CmdRefresh, Context: ContextMain, Description: "Refresh"},
 		{Key: "a", Command: CmdApprove, Context: ContextMain, Description: "Approve issue"},
 		{Key: "V", Command: CmdRecordReview, Context: ContextMain, Description: "Record approval review (delegated mode)"},
 		{Key: "x", Command: CmdDelete, Context: ContextMain, Description: "Delete issue"},
 		{Key: "C", Command: CmdCloseIssue, Context: ContextMain, Description: "Close issue"},
 		{Key: "O", Command: CmdReopenIssue, Context: ContextMain, Description: "Reopen issue"},
 		{Key: "n", Command: CmdNewIssue, Context: ContextMain, Description: "New issue"},
+		{Key: "A", Command: CmdQuickAdd, Context: ContextMain, Description: "Quick add task"},
 		{Key: "e", Command: CmdEditIssue, Context: ContextMain, Description: "Edit issue"},
 		{Key: "y", Command: CmdCopyToClipboard, Context: ContextMain, Description: "Copy issue as markdown"},
 		{Key: "Y", Command: CmdCopyIDToClipboard, Context: ContextMain, Description: "Copy issue ID"},
+		{Key: "ctrl+y", Command: CmdCopyMarkdownLogs, Context: ContextMain, Description: "Copy issue with logs as markdown"},
 		{Key: "W", Command: CmdSendToWorktree, Context: ContextMain, Description: "Send to worktree"},
+		{Key: "E", Command: CmdExportView, Context: ContextMain, Description: "Export view to Markdown"},
+		{Key: "ctrl+e", Command: CmdExportViewCSV, Context: ContextMain, Description: "Export view to CSV"},
+		{Key: "space", Command: CmdToggleMark, Context: ContextMain, Description: "Mark/unmark issue"},
+		{Key: "P", Command: CmdGroupMarked, Context: ContextMain, Description: "Group marked issues under a parent"},
+		{Key: "u", Command: CmdUndo, Context: ContextMain, Description: "Undo last action"},
 
 		// ============================================================
 		// MODAL BINDINGS (Issue Details)
@@ -100,6 +116,7 @@ func DefaultBindings() []Binding {
 		// Copy to clipboard
 		{Key: "y", Command: CmdCopyToClipboard, Context: ContextModal, Description: "Copy to clipboard"},
 		{Key: "Y", Command: CmdCopyIDToClipboard, Context: ContextModal, Description: "Copy issue ID"},
+		{Key: "ctrl+y", Command: CmdCopyMarkdownLogs, Context: ContextModal, Description: "Copy with logs as markdown"},
 
 		// Issue CRUD from modal
 		{Key: "n", Command: CmdNewIssue, Context: ContextModal, Description: "New issue"},
@@ -109,6 +126,9 @@ func DefaultBindings() []Binding {
 		{Key: "O", Command: CmdReopenIssue, Context: ContextModal, Description: "Reopen issue"},
 		{Key: "W", Command: CmdSendToWorktree, Context: ContextModal, Description: "Send to worktree"},
 
+		// Dependency graph overlay
+		{Key: "D", Command: CmdOpenDepGraph, Context: ContextModal, Description: "Dependency graph"},
+
 		// ============================================================
 		// STATS MODAL BINDINGS
 		// Active when the statistics modal is open
@@ -171,6 +191,16 @@ func DefaultBindings() []Binding {
 		{Key: "enter", Command: CmdSearchConfirm, Context: ContextSearch, Description: "Apply search"},
 		{Key: "ctrl+u", Command: CmdSearchClear, Context: ContextSearch, Description: "Clear search"},
 		{Key: "ctrl+w", Command: CmdSearchClear, Context: ContextSearch, Description: "Clear search"},
+		{Key: "ctrl+t", Command: CmdToggleSearchContent, Context: ContextSearch, Description: "Toggle searching logs, comments and handoffs"},
+		{Key: "ctrl+f", Command: CmdToggleSearchHighlight, Context: ContextSearch, Description: "Toggle highlighting matches instead of filtering"},
+
+		// ============================================================
+		// SEARCH MATCH BINDINGS
+		// Layered over the main context while a search highlights matches
+		// in the full task list; other keys keep their main bindings
+		// ============================================================
+		{Key: "n", Command: CmdNextSearchMatch, Context: ContextSearchMatches, Description: "Next match"},
+		{Key: "N", Command: CmdPrevSearchMatch, Context: ContextSearchMatches, Description: "Previous match"},
 
 		// ============================================================
 		// CONFIRMATION DIALOG BINDINGS
@@ -252,6 +282,20 @@ func DefaultBindings() []Binding {
 		{Key: "y", Command: CmdCopyToClipboard, Context: ContextBlocksFocused, Description: "Copy to clipboard"},
 		{Key: "Y", Command: CmdCopyIDToClipboard, Context: ContextBlocksFocused, Description: "Copy issue ID"},
 
+		// ============================================================
+		// RELATED FOCUSED BINDINGS
+		// Active when related section is focused in modal
+		// ============================================================
+		{Key: "j", Command: CmdCursorDown, Context: ContextRelatedFocused, Description: "Move down"},
+		{Key: "down", Command: CmdCursorDown, Context: ContextRelatedFocused, Description: "Move down"},
+		{Key: "k", Command: CmdCursorUp, Context: ContextRelatedFocused, Description: "Move up"},
+		{Key: "up", Command: CmdCursorUp, Context: ContextRelatedFocused, Description: "Move up"},
+		{Key: "enter", Command: CmdOpenRelatedIssue, Context: ContextRelatedFocused, Description: "Open issue"},
+		{Key: "tab", Command: CmdFocusTaskSection, Context: ContextRelatedFocused, Description: "Next section"},
+		{Key: "esc", Command: CmdClose, Context: ContextRelatedFocused, Description: "Close modal"},
+		{Key: "y", Command: CmdCopyToClipboard, Context: ContextRelatedFocused, Description: "Copy to clipboard"},
+		{Key: "Y", Command: CmdCopyIDToClipboard, Context: ContextRelatedFocused, Description: "Copy issue ID"},
+
 		// ============================================================
 		// HANDOFFS MODAL BINDINGS
 		// Active when the handoffs modal is open
@@ -316,6 +360,7 @@ func DefaultBindings() []Binding {
 		{Key: "k", Command: CmdCursorUp, Context: ContextBoardPicker, Description: "Move up"},
 		{Key: "up", Command: CmdCursorUp, Context: ContextBoardPicker, Description: "Move up"},
 		{Key: "enter", Command: CmdSelectBoard, Context: ContextBoardPicker, Description: "Select board"},
+		{Key: "f", Command: CmdApplyBoardQuery, Context: ContextBoardPicker, Description: "Filter list by board query"},
 		{Key: "e", Command: CmdEditBoard, Context: ContextBoardPicker, Description: "Edit board"},
 		{Key: "n", Command: CmdNewBoard, Context: ContextBoardPicker, Description: "New board"},
 		{Key: "esc", Command: CmdCloseBoardPicker, Context: ContextBoardPicker, Description: "Close picker"},
@@ -351,7 +396,9 @@ func DefaultBindings() []Binding {
 		{Key: "ctrl+u", Command: CmdHalfPageUp, Context: ContextBoard, Description: "Half page up"},
 		{Key: "y", Command: CmdCopyToClipboard, Context: ContextBoard, Description: "Copy issue as markdown"},
 		{Key: "Y", Command: CmdCopyIDToClipboard, Context: ContextBoard, Description: "Copy issue ID"},
+		{Key: "ctrl+y", Command: CmdCopyMarkdownLogs, Context: ContextBoard, Description: "Copy issue with logs as markdown"},
 		{Key: "r", Command: CmdRefresh, Context: ContextBoard, Description: "Refresh"},
+		{Key: "ctrl+r", Command: CmdRefresh, Context: ContextBoard, Description: "Refresh"},
 		{Key: "v", Command: CmdToggleBoardView, Context: ContextBoard, Description: "Toggle swimlanes/backlog view"},
 
 		// Panel navigation (same as ContextMain)
@@ -365,17 +412,30 @@ func DefaultBindings() []Binding {
 		{Key: "C", Command: CmdCloseIssue, Context: ContextBoard, Description: "Close issue"},
 		{Key: "O", Command: CmdReopenIssue, Context: ContextBoard, Description: "Reopen issue"},
 		{Key: "n", Command: CmdNewIssue, Context: ContextBoard, Description: "New issue"},
+		{Key: "A", Command: CmdQuickAdd, Context: ContextBoard, Description: "Quick add task"},
 		{Key: "e", Command: CmdEditIssue, Context: ContextBoard, Description: "Edit issue"},
 		{Key: "x", Command: CmdDelete, Context: ContextBoard, Description: "Delete issue"},
 		{Key: "a", Command: CmdApprove, Context: ContextBoard, Description: "Approve issue"},
 		{Key: "R", Command: CmdMarkForReview, Context: ContextBoard, Description: "Submit for review"},
+		{Key: "t", Command: CmdCycleIssueStatus, Context: ContextBoard, Description: "Cycle issue status"},
+		{Key: "+", Command: CmdRaiseIssuePriority, Context: ContextBoard, Description: "Raise issue priority"},
+		{Key: "-", Command: CmdLowerIssuePriority, Context: ContextBoard, Description: "Lower issue priority"},
 
 		// Other actions (same as ContextMain)
 		{Key: "s", Command: CmdOpenStats, Context: ContextBoard, Description: "Open statistics"},
 		{Key: "h", Command: CmdOpenHandoffs, Context: ContextBoard, Description: "Open handoffs"},
+		{Key: "M", Command: CmdToggleStatusHistory, Context: ContextBoard, Description: "Message history"},
 		{Key: "S", Command: CmdCycleSortMode, Context: ContextBoard, Description: "Cycle sort mode"},
+		{Key: "D", Command: CmdToggleAgeColumns, Context: ContextBoard, Description: "Toggle age columns"},
 		{Key: "T", Command: CmdCycleTypeFilter, Context: ContextBoard, Description: "Cycle type filter"},
+		{Key: "L", Command: CmdOpenLabelFilter, Context: ContextBoard, Description: "Filter by label"},
+		{Key: "m", Command: CmdCycleQueryPreset, Context: ContextBoard, Description: "Cycle query preset (my queue/review/rework)"},
 		{Key: "W", Command: CmdSendToWorktree, Context: ContextBoard, Description: "Send to worktree"},
+		{Key: "E", Command: CmdExportView, Context: ContextBoard, Description: "Export view to Markdown"},
+		{Key: "ctrl+e", Command: CmdExportViewCSV, Context: ContextBoard, Description: "Export view to CSV"},
+		{Key: "space", Command: CmdToggleMark, Context: ContextBoard, Description: "Mark/unmark issue"},
+		{Key: "P", Command: CmdGroupMarked, Context: ContextBoard, Description: "Group marked issues under a parent"},
+		{Key: "u", Command: CmdUndo, Context: ContextBoard, Description: "Undo last action"},
 
 		// Additional navigation (same as ContextMain)
 		{Key: "ctrl+f", Command: CmdFullPageDown, Context: ContextBoard, Description: "Full page down"},
@@ -3,9 +3,14 @@
 package keymap
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 )
 
 // Config represents user key binding configuration.
@@ -22,7 +27,8 @@ func ConfigPath(baseDir string) string {
 }
 
 // LoadConfig loads key binding overrides from a JSON file.
-// Returns an empty config if the file doesn't exist.
+// Returns an empty config if the file doesn't exist. A binding listed twice
+// is an error rather than silently keeping the last one.
 func LoadConfig(path string) (*Config, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -36,6 +42,9 @@ func LoadConfig(path string) (*Config, error) {
 	if err := json.Unmarshal(data, &cfg); err != nil {
 		return nil, err
 	}
+	if err := checkDuplicateBindings(data); err != nil {
+		return nil, err
+	}
 
 	if cfg.Bindings == nil {
 		cfg.Bindings = make(map[string]string)
@@ -60,27 +69,122 @@ func SaveConfig(path string, cfg *Config) error {
 	return os.WriteFile(path, data, 0644)
 }
 
-// ApplyConfig applies user configuration overrides to the registry.
-func ApplyConfig(r *Registry, cfg *Config) {
-	for binding, cmdStr := range cfg.Bindings {
-		// Parse "context:key" format
+// checkDuplicateBindings reports a key that appears twice in the bindings
+// object, which json.Unmarshal would otherwise resolve to the last value.
+func checkDuplicateBindings(data []byte) error {
+	var raw struct {
+		Bindings json.RawMessage `json:"bindings"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil || len(raw.Bindings) == 0 {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw.Bindings))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return err
+	}
+	seen := make(map[string]bool)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := tok.(string)
+		if seen[key] {
+			return fmt.Errorf("duplicate binding %q", key)
+		}
+		seen[key] = true
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateConfig checks cfg against the registry's default bindings: every
+// binding must name a context and a command the registry knows, and no
+// context may bind the same key twice (e.g. "j" and "global:j"). All
+// problems are reported together.
+func (r *Registry) ValidateConfig(cfg *Config) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	commands := make(map[Command]bool)
+	for _, bindings := range r.bindings {
+		for _, b := range bindings {
+			commands[b.Command] = true
+		}
+	}
+
+	names := make([]string, 0, len(cfg.Bindings))
+	for binding := range cfg.Bindings {
+		names = append(names, binding)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	bound := make(map[string]string) // "context:key" -> binding as written
+	for _, binding := range names {
 		ctx, key := parseBinding(binding)
-		if ctx == "" || key == "" {
+		cmd := Command(strings.TrimSpace(cfg.Bindings[binding]))
+		switch {
+		case key == "":
+			errs = append(errs, fmt.Errorf("binding %q: missing key", binding))
+			continue
+		case len(r.bindings[ctx]) == 0:
+			errs = append(errs, fmt.Errorf("binding %q: unknown context %q", binding, ctx))
+			continue
+		case !commands[cmd]:
+			errs = append(errs, fmt.Errorf("binding %q: unknown command %q", binding, cmd))
 			continue
 		}
-		r.SetUserOverride(ctx, key, Command(cmdStr))
+		id := string(ctx) + ":" + key
+		if prev, ok := bound[id]; ok {
+			errs = append(errs, fmt.Errorf("bindings %q and %q both bind %s in context %s", prev, binding, key, ctx))
+			continue
+		}
+		bound[id] = binding
+	}
+	return errors.Join(errs...)
+}
+
+// ApplyConfig validates cfg and applies its overrides to the registry.
+// An invalid config changes nothing, leaving the defaults in place.
+func ApplyConfig(r *Registry, cfg *Config) error {
+	if err := r.ValidateConfig(cfg); err != nil {
+		return err
+	}
+	for binding, cmdStr := range cfg.Bindings {
+		ctx, key := parseBinding(binding)
+		r.SetUserOverride(ctx, key, Command(strings.TrimSpace(cmdStr)))
+	}
+	return nil
+}
+
+// LoadAndApply loads baseDir's keymap.json, if any, and applies it to the
+// registry. On error the registry keeps its defaults.
+func LoadAndApply(r *Registry, baseDir string) error {
+	path := ConfigPath(baseDir)
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if err := ApplyConfig(r, cfg); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
 	}
+	return nil
 }
 
-// parseBinding parses a "context:key" string into context and key parts.
+// parseBinding parses a "context:key" string into context and key parts,
+// trimming surrounding spaces. A string without a colon is a global binding.
 func parseBinding(s string) (Context, string) {
 	for i := 0; i < len(s); i++ {
 		if s[i] == ':' {
-			return Context(s[:i]), s[i+1:]
+			return Context(strings.TrimSpace(s[:i])), strings.TrimSpace(s[i+1:])
 		}
 	}
 	// If no colon, assume global context
-	return ContextGlobal, s
+	return ContextGlobal, strings.TrimSpace(s)
 }
 
 // ExampleConfig returns an example configuration for documentation
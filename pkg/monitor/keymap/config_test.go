@@ -3,7 +3,10 @@ package keymap
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	tea "charm.land/bubbletea/v2"
 )
 
 func TestLoadConfigNonExistent(t *testing.T) {
@@ -68,7 +71,9 @@ func TestApplyConfig(t *testing.T) {
 		},
 	}
 
-	ApplyConfig(r, cfg)
+	if err := ApplyConfig(r, cfg); err != nil {
+		t.Fatalf("ApplyConfig: %v", err)
+	}
 
 	// Create a key event for 'x'
 	// The override should now make 'x' quit instead of delete
@@ -125,3 +130,95 @@ func TestExampleConfig(t *testing.T) {
 		t.Error("ExampleConfig should have some bindings")
 	}
 }
+
+func TestConfigOverrideChangesResolvedCommand(t *testing.T) {
+	r := NewRegistry()
+	RegisterDefaults(r)
+
+	j := tea.KeyPressMsg{Code: 'j', Text: "j"}
+	if cmd, _ := r.Lookup(j, ContextMain); cmd != CmdCursorDown {
+		t.Fatalf("default: j resolved to %q, want %q", cmd, CmdCursorDown)
+	}
+
+	dir := t.TempDir()
+	path := ConfigPath(dir)
+	if err := SaveConfig(path, &Config{Bindings: map[string]string{"main:j": "open-stats"}}); err != nil {
+		t.Fatalf("SaveConfig: %v", err)
+	}
+	if err := LoadAndApply(r, dir); err != nil {
+		t.Fatalf("LoadAndApply: %v", err)
+	}
+
+	if cmd, _ := r.Lookup(j, ContextMain); cmd != CmdOpenStats {
+		t.Errorf("override: j resolved to %q in main, want %q", cmd, CmdOpenStats)
+	}
+	// Other contexts and unmapped keys keep their defaults
+	if cmd, _ := r.Lookup(j, ContextModal); cmd != CmdScrollDown {
+		t.Errorf("modal: j resolved to %q, want %q", cmd, CmdScrollDown)
+	}
+	if cmd, _ := r.Lookup(tea.KeyPressMsg{Code: 'k', Text: "k"}, ContextMain); cmd != CmdCursorUp {
+		t.Errorf("unmapped: k resolved to %q, want %q", cmd, CmdCursorUp)
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	r := NewRegistry()
+	RegisterDefaults(r)
+
+	tests := []struct {
+		name     string
+		bindings map[string]string
+		wantErr  string
+	}{
+		{name: "valid", bindings: map[string]string{"main:ctrl+s": "open-stats", "q": "quit"}},
+		{name: "unknown command", bindings: map[string]string{"main:x": "explode"}, wantErr: `unknown command "explode"`},
+		{name: "unknown context", bindings: map[string]string{"mian:x": "quit"}, wantErr: `unknown context "mian"`},
+		{name: "missing key", bindings: map[string]string{"main:": "quit"}, wantErr: "missing key"},
+		{
+			name:     "same key twice in a context",
+			bindings: map[string]string{"j": "quit", "global:j": "refresh"},
+			wantErr:  "both bind j in context global",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := r.ValidateConfig(&Config{Bindings: tt.bindings})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestInvalidConfigKeepsDefaults(t *testing.T) {
+	r := NewRegistry()
+	RegisterDefaults(r)
+
+	err := ApplyConfig(r, &Config{Bindings: map[string]string{
+		"main:j": "open-stats",
+		"main:k": "no-such-command",
+	}})
+	if err == nil {
+		t.Fatal("expected an error for the unknown command")
+	}
+	if cmd, _ := r.Lookup(tea.KeyPressMsg{Code: 'j', Text: "j"}, ContextMain); cmd != CmdCursorDown {
+		t.Errorf("j resolved to %q after a rejected config, want %q", cmd, CmdCursorDown)
+	}
+}
+
+func TestLoadConfigRejectsDuplicateKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keymap.json")
+	data := `{"bindings": {"main:x": "quit", "main:x": "delete"}}`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadConfig(path); err == nil || !strings.Contains(err.Error(), `duplicate binding "main:x"`) {
+		t.Fatalf("LoadConfig error = %v, want duplicate binding", err)
+	}
+}
@@ -36,6 +36,7 @@ var contextToSidecar = map[Context]string{
 	ContextBoardEditor:       "td-board-editor",
 	ContextCloseConfirm:      "td-close-confirm",
 	ContextKanban:            "td-kanban",
+	ContextSearchMatches:     "td-search-matches",
 }
 
 // commandMetadata defines display info and priority for each command.
@@ -54,17 +55,27 @@ var commandMetadata = map[Command]struct {
 	CmdClose:         {"Close", "Close modal", 1},
 
 	// Medium priority - footer when space allows (P2)
-	CmdOpenHandoffs:    {"Handoffs", "Open handoffs", 2},
-	CmdToggleClosed:    {"Closed", "Toggle closed tasks", 2},
-	CmdDelete:          {"Delete", "Delete issue", 2},
-	CmdCloseIssue:      {"Close", "Close issue", 2},
-	CmdReopenIssue:     {"Reopen", "Reopen closed issue", 2},
-	CmdCycleSortMode:   {"Sort", "Cycle sort mode", 2},
-	CmdCycleTypeFilter: {"Type", "Cycle type filter", 2},
+	CmdOpenHandoffs:        {"Handoffs", "Open handoffs", 2},
+	CmdToggleClosed:        {"Closed", "Toggle closed tasks", 2},
+	CmdDelete:              {"Delete", "Delete issue", 2},
+	CmdCloseIssue:          {"Close", "Close issue", 2},
+	CmdReopenIssue:         {"Reopen", "Reopen closed issue", 2},
+	CmdCycleSortMode:       {"Sort", "Cycle sort mode", 2},
+	CmdCycleTypeFilter:     {"Type", "Cycle type filter", 2},
+	CmdOpenLabelFilter:     {"Label", "Filter by label", 2},
+	CmdCycleQueryPreset:    {"Preset", "Cycle query preset", 2},
+	CmdToggleAgeColumns:    {"Ages", "Toggle age columns", 2},
+	CmdToggleExpiredClaims: {"Expired", "Toggle expired claims filter", 3},
+
+	// Quick edits (P3)
+	CmdCycleIssueStatus:   {"Status", "Cycle issue status", 3},
+	CmdRaiseIssuePriority: {"Pri+", "Raise issue priority", 3},
+	CmdLowerIssuePriority: {"Pri-", "Lower issue priority", 3},
 
 	// Board mode controls (P2)
 	CmdOpenBoardPicker:        {"Boards", "Open board picker", 2},
 	CmdSelectBoard:            {"Select", "Select board", 3},
+	CmdApplyBoardQuery:        {"Filter", "Filter list by board query", 3},
 	CmdCloseBoardPicker:       {"Close", "Close board picker", 3},
 	CmdMoveIssueUp:            {"Move Up", "Move issue up in column", 3},
 	CmdMoveIssueDown:          {"Move Down", "Move issue down in column", 3},
@@ -76,12 +87,20 @@ var commandMetadata = map[Command]struct {
 	CmdCycleBoardStatusFilter: {"Filter", "Cycle status filter", 2},
 
 	// Lower priority - palette only (P3+)
-	CmdToggleHelp:        {"Help", "Toggle help overlay", 3},
-	CmdQuit:              {"Quit", "Quit application", 3},
-	CmdCopyToClipboard:   {"Copy", "Copy to clipboard", 3},
-	CmdOpenStats:         {"Stats", "Open statistics", 3},
-	CmdRefresh:           {"Refresh", "Refresh data", 2},
-	CmdCopyIDToClipboard: {"CopyID", "Copy issue ID", 3},
+	CmdToggleHelp:          {"Help", "Toggle help overlay", 3},
+	CmdQuit:                {"Quit", "Quit application", 3},
+	CmdCopyToClipboard:     {"Copy", "Copy to clipboard", 3},
+	CmdOpenStats:           {"Stats", "Open statistics", 3},
+	CmdRefresh:             {"Refresh", "Refresh data", 2},
+	CmdCopyIDToClipboard:   {"CopyID", "Copy issue ID", 3},
+	CmdCopyMarkdownLogs:    {"CopyMD", "Copy issue with logs as Markdown", 3},
+	CmdExportView:          {"Export", "Export current view to Markdown", 4},
+	CmdExportViewCSV:       {"ExportCSV", "Export current view to CSV", 4},
+	CmdToggleMark:          {"Mark", "Mark issue for a bulk action", 3},
+	CmdGroupMarked:         {"Group", "Group marked issues under a parent", 3},
+	CmdUndo:                {"Undo", "Undo last action", 3},
+	CmdQuickAdd:            {"Quick add", "Quick add task", 3},
+	CmdToggleStatusHistory: {"Messages", "Show status message history", 3},
 
 	// Navigation - usually palette only (P4)
 	CmdNextPanel:          {"Next", "Next panel", 4},
@@ -105,13 +124,19 @@ var commandMetadata = map[Command]struct {
 	CmdOpenParentEpic:     {"Parent", "Open parent epic", 4},
 	CmdOpenBlockedByIssue: {"Open", "Open blocker issue", 4},
 	CmdOpenBlocksIssue:    {"Open", "Open blocked issue", 4},
+	CmdOpenRelatedIssue:   {"Open", "Open related issue", 4},
+	CmdOpenDepGraph:       {"Graph", "Show dependency graph", 4},
 
 	// Search mode - context specific (P4)
-	CmdSearchConfirm:   {"Apply", "Apply search", 4},
-	CmdSearchCancel:    {"Cancel", "Cancel search", 4},
-	CmdSearchClear:     {"Clear", "Clear search", 4},
-	CmdSearchBackspace: {"Delete", "Delete character", 5},
-	CmdSearchInput:     {"Input", "Input character", 5},
+	CmdSearchConfirm:         {"Apply", "Apply search", 4},
+	CmdSearchCancel:          {"Cancel", "Cancel search", 4},
+	CmdSearchClear:           {"Clear", "Clear search", 4},
+	CmdSearchBackspace:       {"Delete", "Delete character", 5},
+	CmdSearchInput:           {"Input", "Input character", 5},
+	CmdToggleSearchContent:   {"Activity", "Toggle activity search", 3},
+	CmdToggleSearchHighlight: {"Highlight", "Toggle highlighting matches", 3},
+	CmdNextSearchMatch:       {"Next", "Next search match", 2},
+	CmdPrevSearchMatch:       {"Prev", "Previous search match", 2},
 
 	// Confirm dialog (P4)
 	CmdConfirm: {"Yes", "Confirm action", 4},
@@ -56,7 +56,9 @@ func (r *Registry) GenerateHelp() string {
 		{Keys: "Esc", Description: "Close modal (return to previous)"},
 		{Keys: "r", Description: "Refresh modal content"},
 		{Keys: "y", Description: "Copy to clipboard (markdown)"},
+		{Keys: "Ctrl+y", Description: "Copy with recent logs (markdown)"},
 		{Keys: "Tab", Description: "Focus epic task list (if epic)"},
+		{Keys: "D", Description: "Dependency graph (Enter jumps to a node)"},
 	}
 	for _, b := range modalBindings {
 		sb.WriteString(fmt.Sprintf("  %-20s %s\n", b.Keys, b.Description))
@@ -77,10 +79,12 @@ func (r *Registry) GenerateHelp() string {
 	sb.WriteString("\nCRUD:\n")
 	crudBindings := []HelpBinding{
 		{Keys: "n", Description: "New issue"},
+		{Keys: "A", Description: "Quick add task (title only)"},
 		{Keys: "e", Description: "Edit selected/open issue"},
 		{Keys: "x", Description: "Delete issue (confirmation required)"},
 		{Keys: "C", Description: "Close issue"},
 		{Keys: "O", Description: "Reopen closed issue"},
+		{Keys: "u", Description: "Undo this session's last action"},
 	}
 	for _, b := range crudBindings {
 		sb.WriteString(fmt.Sprintf("  %-20s %s\n", b.Keys, b.Description))
@@ -102,7 +106,8 @@ func (r *Registry) GenerateHelp() string {
 	sb.WriteString("\nFORM (when editing):\n")
 	formBindings := []HelpBinding{
 		{Keys: "Ctrl+S", Description: "Save form"},
-		{Keys: "Esc", Description: "Cancel form"},
+		{Keys: "Esc", Description: "Cancel form (confirms if there are unsaved changes)"},
+		{Keys: "Ctrl+C", Description: "Quit (confirms if there are unsaved changes)"},
 		{Keys: "Ctrl+X", Description: "Toggle extended fields"},
 		{Keys: "Ctrl+O", Description: "Edit description in $EDITOR"},
 	}
@@ -113,15 +118,23 @@ func (r *Registry) GenerateHelp() string {
 	sb.WriteString("\nACTIONS:\n")
 	actionBindings := []HelpBinding{
 		{Keys: "r", Description: "Mark for review (Current Work) / Refresh"},
+		{Keys: "Ctrl+R", Description: "Refresh (applies held-back updates)"},
 		{Keys: "a", Description: "Approve issue (review + close, or close using recorded approval)"},
 		{Keys: "V", Description: "Record approval review without closing (delegated mode)"},
 		{Keys: "s", Description: "Show statistics dashboard"},
 		{Keys: "h", Description: "Show handoffs modal"},
-		{Keys: "S", Description: "Cycle sort (priority/created/updated)"},
+		{Keys: "M", Description: "Show status message history"},
+		{Keys: "S", Description: "Cycle sort (priority/created/updated/stale)"},
+		{Keys: "D", Description: "Toggle age and time-in-status columns"},
+		{Keys: "X", Description: "Show only issues with expired claims ([EXP])"},
 		{Keys: "T", Description: "Cycle type filter (epic/task/bug/...)"},
+		{Keys: "m", Description: "Cycle query preset (my queue/needs review/rework)"},
 		{Keys: "/", Description: "Search tasks"},
+		{Keys: "Ctrl+F", Description: "Highlight matches instead of filtering (while searching)"},
+		{Keys: "n / N", Description: "Next/previous highlighted match"},
 		{Keys: "Esc", Description: "Clear search filter"},
 		{Keys: "c", Description: "Toggle closed tasks"},
+		{Keys: "E / Ctrl+E", Description: "Export current view to Markdown/CSV"},
 		{Keys: "q / Ctrl+C", Description: "Quit"},
 	}
 	for _, b := range actionBindings {
@@ -179,6 +192,7 @@ func (r *Registry) GenerateHelp() string {
 		{Keys: "Enter", Description: "Confirm search"},
 		{Keys: "Esc", Description: "Cancel search"},
 		{Keys: "Backspace", Description: "Delete character"},
+		{Keys: "Ctrl+T", Description: "Also match logs, comments, handoffs"},
 		{Keys: "?", Description: "Show TDQ syntax help"},
 	}
 	for _, b := range searchBindings {
@@ -364,16 +378,32 @@ func CommandHelp(cmd Command) string {
 		return "Open statistics dashboard"
 	case CmdOpenHandoffs:
 		return "Open handoffs modal"
+	case CmdToggleStatusHistory:
+		return "Show recent status messages with timestamps"
 	case CmdSearch:
 		return "Enter search mode"
 	case CmdToggleClosed:
 		return "Show/hide closed tasks"
 	case CmdCycleSortMode:
-		return "Cycle sort: priority → created → updated"
+		return "Cycle sort: priority → created → updated → stale (longest in status)"
+	case CmdToggleAgeColumns:
+		return "Show/hide issue age and time-in-status columns"
+	case CmdToggleExpiredClaims:
+		return "Show only in-progress issues whose session went idle past the claim lease"
 	case CmdCycleTypeFilter:
 		return "Cycle type filter: epic → task → bug → feature → chore → all"
+	case CmdOpenLabelFilter:
+		return "Filter by label (pick a label or clear)"
+	case CmdCycleQueryPreset:
+		return "Cycle query preset: my queue → needs review → rework → off"
 	case CmdMarkForReview:
 		return "Mark issue for review"
+	case CmdCycleIssueStatus:
+		return "Move issue to its next allowed status (open → in progress → blocked)"
+	case CmdRaiseIssuePriority:
+		return "Raise issue priority one step (toward P0)"
+	case CmdLowerIssuePriority:
+		return "Lower issue priority one step (toward P4)"
 	case CmdApprove:
 		return "Approve: review + close, or close using a recorded approval"
 	case CmdRecordReview:
@@ -390,10 +420,30 @@ func CommandHelp(cmd Command) string {
 		return "Open selected blocker issue"
 	case CmdOpenBlocksIssue:
 		return "Open selected blocked issue"
+	case CmdOpenRelatedIssue:
+		return "Open selected related issue"
+	case CmdToggleSearchHighlight:
+		return "Keep the full task list and highlight search matches instead of filtering"
+	case CmdNextSearchMatch:
+		return "Move to the next highlighted search match"
+	case CmdPrevSearchMatch:
+		return "Move to the previous highlighted search match"
+	case CmdOpenDepGraph:
+		return "Show parents, children, blockers and blocked issues as a graph"
 	case CmdCopyToClipboard:
 		return "Copy issue as markdown to clipboard"
 	case CmdCopyIDToClipboard:
 		return "Copy issue ID to clipboard"
+	case CmdCopyMarkdownLogs:
+		return "Copy issue with acceptance criteria and recent logs as Markdown"
+	case CmdToggleMark:
+		return "Mark or unmark the selected issue for a bulk action"
+	case CmdGroupMarked:
+		return "Move all marked issues under a parent epic"
+	case CmdUndo:
+		return "Undo this session's last action (same as td undo)"
+	case CmdQuickAdd:
+		return "Create an open task from a one-line title"
 	case CmdFormOpenEditor:
 		return "Open form field in external editor"
 	case CmdCloseIssue:
@@ -404,6 +454,8 @@ func CommandHelp(cmd Command) string {
 		return "Open board picker to select a board"
 	case CmdSelectBoard:
 		return "Select the highlighted board"
+	case CmdApplyBoardQuery:
+		return "Show the highlighted board's query as a list filter"
 	case CmdCloseBoardPicker:
 		return "Close board picker"
 	case CmdMoveIssueUp:
@@ -490,14 +542,15 @@ func AllCommands() []Command {
 		CmdHalfPageDown, CmdHalfPageUp, CmdFullPageDown, CmdFullPageUp,
 		CmdScrollDown, CmdScrollUp, CmdSelect, CmdBack, CmdClose,
 		CmdNavigatePrev, CmdNavigateNext,
-		CmdOpenDetails, CmdOpenStats, CmdOpenHandoffs, CmdSearch, CmdToggleClosed, CmdCycleSortMode, CmdCycleTypeFilter,
+		CmdOpenDetails, CmdOpenStats, CmdOpenHandoffs, CmdToggleStatusHistory, CmdSearch, CmdToggleClosed, CmdCycleSortMode, CmdToggleAgeColumns, CmdToggleExpiredClaims, CmdCycleTypeFilter, CmdOpenLabelFilter, CmdCycleQueryPreset,
 		CmdMarkForReview, CmdApprove, CmdRecordReview, CmdDelete, CmdConfirm, CmdCancel,
-		CmdSearchConfirm, CmdSearchCancel, CmdSearchClear, CmdSearchBackspace, CmdSearchInput,
-		CmdFocusTaskSection, CmdOpenEpicTask, CmdOpenParentEpic, CmdCopyToClipboard, CmdCopyIDToClipboard,
-		CmdNewIssue, CmdEditIssue, CmdFormSubmit, CmdFormCancel, CmdFormToggleExtend, CmdFormOpenEditor,
-		CmdCloseIssue, CmdReopenIssue,
+		CmdCycleIssueStatus, CmdRaiseIssuePriority, CmdLowerIssuePriority,
+		CmdSearchConfirm, CmdSearchCancel, CmdSearchClear, CmdSearchBackspace, CmdSearchInput, CmdToggleSearchContent, CmdToggleSearchHighlight, CmdNextSearchMatch, CmdPrevSearchMatch,
+		CmdFocusTaskSection, CmdOpenEpicTask, CmdOpenParentEpic, CmdOpenDepGraph, CmdCopyToClipboard, CmdCopyIDToClipboard, CmdCopyMarkdownLogs,
+		CmdNewIssue, CmdQuickAdd, CmdEditIssue, CmdFormSubmit, CmdFormCancel, CmdFormToggleExtend, CmdFormOpenEditor,
+		CmdCloseIssue, CmdReopenIssue, CmdToggleMark, CmdGroupMarked, CmdUndo,
 		// Board commands
-		CmdOpenBoardPicker, CmdSelectBoard, CmdCloseBoardPicker,
+		CmdOpenBoardPicker, CmdSelectBoard, CmdApplyBoardQuery, CmdCloseBoardPicker,
 		CmdMoveIssueUp, CmdMoveIssueDown, CmdMoveIssueToTop, CmdMoveIssueToBottom,
 		CmdExitBoardMode, CmdToggleBoardClosed, CmdCycleBoardStatusFilter, CmdToggleBoardView,
 		// Getting started commands
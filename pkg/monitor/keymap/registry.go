@@ -24,6 +24,7 @@ const (
 	ContextParentEpicFocused Context = "parent-epic-focused" // When parent epic row is focused
 	ContextBlockedByFocused  Context = "blocked-by-focused"  // When blocked-by section is focused
 	ContextBlocksFocused     Context = "blocks-focused"      // When blocks section is focused
+	ContextRelatedFocused    Context = "related-focused"     // When related section is focused
 	ContextHandoffs          Context = "handoffs"            // When handoffs modal is open
 	ContextForm              Context = "form"                // When form modal is open
 	ContextHelp              Context = "help"                // When help modal is open
@@ -36,6 +37,7 @@ const (
 	ContextSyncPrompt        Context = "td-sync-prompt"      // When sync prompt modal is open
 	ContextKanban            Context = "kanban"              // When kanban view modal is open
 	ContextNotes             Context = "notes"               // When notes modal is open
+	ContextSearchMatches     Context = "search-matches"      // Layered over main while search matches are highlighted
 )
 
 // Command represents a named command that can be triggered by key bindings
@@ -79,13 +81,29 @@ const (
 	CmdConfirm       Command = "confirm"
 	CmdCancel        Command = "cancel"
 	CmdCycleSortMode Command = "cycle-sort-mode"
+	CmdUndo          Command = "undo"
+
+	// Toggles the age and time-in-status columns in task rows
+	CmdToggleAgeColumns Command = "toggle-age-columns"
+
+	// Filters the task list to in_progress issues whose claim lease expired
+	CmdToggleExpiredClaims Command = "toggle-expired-claims"
+
+	// Quick edit commands (selected issue, no form)
+	CmdCycleIssueStatus   Command = "cycle-issue-status"
+	CmdRaiseIssuePriority Command = "raise-priority"
+	CmdLowerIssuePriority Command = "lower-priority"
 
 	// Search-specific commands
-	CmdSearchConfirm   Command = "search-confirm"
-	CmdSearchCancel    Command = "search-cancel"
-	CmdSearchClear     Command = "search-clear"
-	CmdSearchBackspace Command = "search-backspace"
-	CmdSearchInput     Command = "search-input"
+	CmdSearchConfirm         Command = "search-confirm"
+	CmdSearchCancel          Command = "search-cancel"
+	CmdSearchClear           Command = "search-clear"
+	CmdSearchBackspace       Command = "search-backspace"
+	CmdSearchInput           Command = "search-input"
+	CmdToggleSearchContent   Command = "toggle-search-content"
+	CmdToggleSearchHighlight Command = "toggle-search-highlight"
+	CmdNextSearchMatch       Command = "next-search-match"
+	CmdPrevSearchMatch       Command = "prev-search-match"
 
 	// Epic task navigation commands
 	CmdFocusTaskSection Command = "focus-task-section"
@@ -97,6 +115,7 @@ const (
 	// Blocked-by/blocks navigation
 	CmdOpenBlockedByIssue Command = "open-blocked-by-issue"
 	CmdOpenBlocksIssue    Command = "open-blocks-issue"
+	CmdOpenRelatedIssue   Command = "open-related-issue"
 
 	// Handoffs modal
 	CmdOpenHandoffs Command = "open-handoffs"
@@ -104,12 +123,20 @@ const (
 	// Notes modal
 	CmdOpenNotes Command = "open-notes"
 
+	// Dependency graph overlay
+	CmdOpenDepGraph Command = "open-dep-graph"
+
+	// Status message history pane
+	CmdToggleStatusHistory Command = "toggle-status-history"
+
 	// Clipboard
 	CmdCopyToClipboard   Command = "copy-to-clipboard"
 	CmdCopyIDToClipboard Command = "copy-id-to-clipboard"
+	CmdCopyMarkdownLogs  Command = "copy-markdown-with-logs"
 
 	// Form commands
 	CmdNewIssue         Command = "new-issue"
+	CmdQuickAdd         Command = "quick-add"
 	CmdEditIssue        Command = "edit-issue"
 	CmdFormSubmit       Command = "form-submit"
 	CmdFormCancel       Command = "form-cancel"
@@ -121,7 +148,9 @@ const (
 	CmdReopenIssue Command = "reopen-issue"
 
 	// Filters
-	CmdCycleTypeFilter Command = "cycle-type-filter"
+	CmdCycleTypeFilter  Command = "cycle-type-filter"
+	CmdOpenLabelFilter  Command = "label-filter"
+	CmdCycleQueryPreset Command = "cycle-query-preset"
 
 	// Button navigation (for confirmation dialogs and forms)
 	CmdNextButton Command = "next-button"
@@ -130,6 +159,7 @@ const (
 	// Board commands
 	CmdOpenBoardPicker        Command = "boards"
 	CmdSelectBoard            Command = "select-board"
+	CmdApplyBoardQuery        Command = "apply-board-query"
 	CmdCloseBoardPicker       Command = "close-picker"
 	CmdMoveIssueUp            Command = "move-up"
 	CmdMoveIssueDown          Command = "move-down"
@@ -143,6 +173,14 @@ const (
 	// External integration commands
 	CmdSendToWorktree Command = "send-to-worktree"
 
+	// Export commands
+	CmdExportView    Command = "export-view"
+	CmdExportViewCSV Command = "export-view-csv"
+
+	// Bulk selection commands
+	CmdToggleMark  Command = "toggle-mark"
+	CmdGroupMarked Command = "group-marked"
+
 	// Board editor commands
 	CmdEditBoard         Command = "edit-board"
 	CmdNewBoard          Command = "new-board"
@@ -273,6 +311,24 @@ func (r *Registry) findInContext(key string, context Context) (Command, bool) {
 	return "", false
 }
 
+// LookupLayer looks up a key among the bindings and user overrides of a
+// context layered over the active one. Unlike Lookup it neither falls back
+// to global bindings nor starts sequences, so keys the layer doesn't bind
+// are left to the context below it.
+func (r *Registry) LookupLayer(key tea.KeyMsg, layer Context) (Command, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.pendingKey != "" && time.Since(r.pendingTime) < sequenceTimeout {
+		return "", false
+	}
+	keyStr := KeyToString(key)
+	if cmd, ok := r.userOverrides[string(layer)+":"+keyStr]; ok {
+		return cmd, true
+	}
+	return r.findInContext(keyStr, layer)
+}
+
 // isSequenceStart checks if this key could start a multi-key sequence
 func (r *Registry) isSequenceStart(key string, activeContext Context) bool {
 	prefix := key + " "
@@ -0,0 +1,131 @@
+package monitor
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/marcus/td/internal/config"
+	"github.com/marcus/td/internal/output"
+	"github.com/marcus/td/pkg/monitor/modal"
+)
+
+// labelColorItemPrefix prefixes the color picker's item IDs; the bare
+// prefix is the "default color" entry.
+const labelColorItemPrefix = "label-color:"
+
+// labelStyle returns the style a label renders in: its configured color,
+// or the hash-derived default shared with the CLI.
+func (m Model) labelStyle(label string) lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(output.LabelColor(label, m.LabelColors)))
+}
+
+// renderLabels renders labels comma-separated, each in its color.
+func (m Model) renderLabels(labels []string) string {
+	parts := make([]string, len(labels))
+	for i, label := range labels {
+		parts[i] = m.labelStyle(label).Render(label)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// openLabelColorPicker switches the label picker to choosing a color for
+// the label under the cursor.
+func (m Model) openLabelColorPicker() (tea.Model, tea.Cmd) {
+	idx := *m.LabelPickerCursor - 1
+	if idx < 0 || idx >= len(m.LabelPickerLabels) {
+		m.StatusMessage = "Select a label to color"
+		m.StatusIsError = false
+		return m, tea.Tick(2*time.Second, func(t time.Time) tea.Msg { return ClearStatusMsg{} })
+	}
+	m.LabelColorTarget = m.LabelPickerLabels[idx].Label
+
+	cursor := 0
+	if current, ok := m.LabelColors[m.LabelColorTarget]; ok {
+		for i, name := range output.LabelColorNames() {
+			if code, _ := output.NormalizeLabelColor(name); code == current {
+				cursor = i + 1
+			}
+		}
+	}
+	m.LabelPickerCursor = &cursor
+	m.LabelPickerModal = m.createLabelColorModal()
+	m.LabelPickerModal.Reset()
+	m.LabelPickerModal.SetFocus("label-colors-list")
+	return m, nil
+}
+
+// closeLabelColorPicker returns the picker to the label list, with the
+// cursor back on the label that was being colored.
+func (m *Model) closeLabelColorPicker() {
+	cursor := 0
+	for i, lc := range m.LabelPickerLabels {
+		if lc.Label == m.LabelColorTarget {
+			cursor = i + 1
+		}
+	}
+	m.LabelColorTarget = ""
+	m.LabelPickerCursor = &cursor
+	m.LabelPickerModal = m.createLabelPickerModal()
+	m.LabelPickerModal.Reset()
+	m.LabelPickerModal.SetFocus("labels-list")
+}
+
+// createLabelColorModal builds the color list for LabelColorTarget.
+func (m *Model) createLabelColorModal() *modal.Modal {
+	md := modal.New("Color for "+m.LabelColorTarget,
+		modal.WithWidth(44),
+		modal.WithHints(false),
+	)
+
+	def := output.LabelColor(m.LabelColorTarget, nil)
+	items := []modal.ListItem{{
+		ID:    labelColorItemPrefix,
+		Label: lipgloss.NewStyle().Foreground(lipgloss.Color(def)).Render("●") + " default",
+	}}
+	for _, name := range output.LabelColorNames() {
+		code, _ := output.NormalizeLabelColor(name)
+		items = append(items, modal.ListItem{
+			ID:    labelColorItemPrefix + code,
+			Label: lipgloss.NewStyle().Foreground(lipgloss.Color(code)).Render("●") + " " + name,
+		})
+	}
+	md.AddSection(modal.List("label-colors-list", items, m.LabelPickerCursor, modal.WithMaxVisible(10)))
+	md.AddSection(modal.Spacer())
+	md.AddSection(modal.Text("j/k:move  Enter:set  Esc:back"))
+	return md
+}
+
+// applyLabelColor saves the picked color for LabelColorTarget (empty for the
+// default) and returns to the label list.
+func (m Model) applyLabelColor(color string) (tea.Model, tea.Cmd) {
+	label := m.LabelColorTarget
+	if err := config.SetLabelColor(m.BaseDir, label, color); err != nil {
+		m.closeLabelColorPicker()
+		m.StatusMessage = "Failed to save label color: " + err.Error()
+		m.StatusIsError = true
+		return m, tea.Tick(3*time.Second, func(t time.Time) tea.Msg { return ClearStatusMsg{} })
+	}
+
+	colors := make(map[string]string, len(m.LabelColors)+1)
+	for k, v := range m.LabelColors {
+		colors[k] = v
+	}
+	if color == "" {
+		delete(colors, label)
+	} else {
+		colors[label] = color
+	}
+	m.LabelColors = colors
+	m.closeLabelColorPicker()
+
+	if color == "" {
+		m.StatusMessage = fmt.Sprintf("Label %s: default color", label)
+	} else {
+		m.StatusMessage = fmt.Sprintf("Label %s: color %s", label, color)
+	}
+	m.StatusIsError = false
+	return m, tea.Tick(2*time.Second, func(t time.Time) tea.Msg { return ClearStatusMsg{} })
+}
@@ -0,0 +1,160 @@
+package monitor
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/marcus/td/pkg/monitor/modal"
+	"github.com/marcus/td/pkg/monitor/mouse"
+)
+
+// labelPickerClear is the picker item that removes the label filter.
+const labelPickerClear = "label-clear"
+
+// labelPickerItemPrefix prefixes picker item IDs so label names can't
+// collide with the modal's own actions ("cancel", etc.).
+const labelPickerItemPrefix = "label:"
+
+// labelClausePattern matches the label("...") clause the picker injects,
+// including labels("...") typed by hand.
+var labelClausePattern = regexp.MustCompile(`(?i)\blabels?\("(?:[^"\\]|\\.)*"\)`)
+
+// labelClause returns the TDQ clause that filters on one exact label.
+func labelClause(label string) string {
+	return "label(" + strconv.Quote(label) + ")"
+}
+
+// updateQueryLabel updates or appends the label clause in a query string,
+// removing it when label is empty. Same contract as updateQueryType, so the
+// label, type and sort clauses can be toggled independently.
+func updateQueryLabel(query, label string) string {
+	query = labelClausePattern.ReplaceAllString(query, "")
+	words := strings.Fields(query)
+	if label != "" {
+		words = append(words, labelClause(label))
+	}
+	return strings.Join(words, " ")
+}
+
+// openLabelPicker lists every label in use, plus a "clear" entry, with the
+// active filter preselected.
+func (m Model) openLabelPicker() (tea.Model, tea.Cmd) {
+	labels, err := m.DB.ListAllLabels()
+	if err != nil {
+		m.StatusMessage = "Failed to load labels: " + err.Error()
+		m.StatusIsError = true
+		return m, tea.Tick(3*time.Second, func(t time.Time) tea.Msg { return ClearStatusMsg{} })
+	}
+	if len(labels) == 0 && m.LabelFilter == "" {
+		m.StatusMessage = "No labels in use"
+		m.StatusIsError = false
+		return m, tea.Tick(2*time.Second, func(t time.Time) tea.Msg { return ClearStatusMsg{} })
+	}
+
+	m.LabelPickerOpen = true
+	m.LabelPickerLabels = labels
+	// The cursor is shared by pointer so the list section and every copy of
+	// the model see the same selection (see the board editor inputs).
+	cursor := 0
+	for i, lc := range labels {
+		if lc.Label == m.LabelFilter {
+			cursor = i + 1
+		}
+	}
+	m.LabelPickerCursor = &cursor
+	m.LabelPickerModal = m.createLabelPickerModal()
+	m.LabelPickerModal.Reset()
+	m.LabelPickerModal.SetFocus("labels-list")
+	m.LabelPickerMouseHandler = mouse.NewHandler()
+	return m, nil
+}
+
+// closeLabelPicker closes the label picker without changing the filter.
+func (m *Model) closeLabelPicker() {
+	m.LabelPickerOpen = false
+	m.LabelColorTarget = ""
+	m.LabelPickerLabels = nil
+	m.LabelPickerCursor = nil
+	m.LabelPickerModal = nil
+	m.LabelPickerMouseHandler = nil
+}
+
+// createLabelPickerModal builds the declarative modal for the label picker.
+func (m *Model) createLabelPickerModal() *modal.Modal {
+	md := modal.New("Filter by label",
+		modal.WithWidth(44),
+		modal.WithHints(false),
+	)
+
+	items := make([]modal.ListItem, 0, len(m.LabelPickerLabels)+1)
+	items = append(items, modal.ListItem{ID: labelPickerClear, Label: "(clear label filter)"})
+	for _, lc := range m.LabelPickerLabels {
+		label := fmt.Sprintf("%s %s (%d)", m.labelStyle(lc.Label).Render("●"), lc.Label, lc.Count)
+		if lc.Label == m.LabelFilter {
+			label += " ✓"
+		}
+		items = append(items, modal.ListItem{ID: labelPickerItemPrefix + lc.Label, Label: label})
+	}
+	maxVisible := min(len(items), 10)
+	md.AddSection(modal.List("labels-list", items, m.LabelPickerCursor, modal.WithMaxVisible(maxVisible)))
+	md.AddSection(modal.Spacer())
+	md.AddSection(modal.Text("j/k:move  Enter:apply  c:color  Esc:cancel"))
+	return md
+}
+
+// handleLabelPickerAction applies the picked label (or clears the filter),
+// or in the color picker sets the picked color.
+func (m Model) handleLabelPickerAction(action string) (tea.Model, tea.Cmd) {
+	if m.LabelColorTarget != "" {
+		switch {
+		case action == "cancel":
+			m.closeLabelColorPicker()
+		case strings.HasPrefix(action, labelColorItemPrefix):
+			return m.applyLabelColor(strings.TrimPrefix(action, labelColorItemPrefix))
+		}
+		return m, nil
+	}
+	switch {
+	case action == "cancel":
+		m.closeLabelPicker()
+		return m, nil
+	case action == labelPickerClear:
+		m.closeLabelPicker()
+		return m.applyLabelFilter("")
+	case strings.HasPrefix(action, labelPickerItemPrefix):
+		m.closeLabelPicker()
+		return m.applyLabelFilter(strings.TrimPrefix(action, labelPickerItemPrefix))
+	}
+	return m, nil
+}
+
+// applyLabelFilter sets the label filter, rewrites the search query to match,
+// persists the filter state and refreshes the task list.
+func (m Model) applyLabelFilter(label string) (tea.Model, tea.Cmd) {
+	m.LabelFilter = label
+	oldQuery := m.SearchQuery
+	m.SearchQuery = updateQueryLabel(m.SearchQuery, label)
+	// Recalc bounds if search bar visibility changed
+	if (oldQuery == "") != (m.SearchQuery == "") {
+		m.updatePanelBounds()
+	}
+	if label == "" {
+		m.StatusMessage = "Label filter: all"
+	} else {
+		m.StatusMessage = fmt.Sprintf("Label filter: %s", label)
+	}
+	m.StatusIsError = false
+	cmds := []tea.Cmd{
+		m.fetchData(),
+		m.saveFilterState(),
+		tea.Tick(2*time.Second, func(t time.Time) tea.Msg { return ClearStatusMsg{} }),
+	}
+	if m.TaskListMode == TaskListModeBoard && m.BoardMode.Board != nil {
+		cmds = append(cmds, m.fetchBoardIssues(m.BoardMode.Board.ID))
+	}
+	return m, tea.Batch(cmds...)
+}
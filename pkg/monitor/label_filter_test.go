@@ -0,0 +1,97 @@
+package monitor
+
+import (
+	"testing"
+
+	"github.com/marcus/td/internal/query"
+)
+
+func TestUpdateQueryLabel(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		label    string
+		expected string
+	}{
+		{
+			name:     "empty query gets label clause",
+			query:    "",
+			label:    "backend",
+			expected: `label("backend")`,
+		},
+		{
+			name:     "composes with type and sort clauses",
+			query:    "type=bug sort:-created",
+			label:    "backend",
+			expected: `type=bug sort:-created label("backend")`,
+		},
+		{
+			name:     "existing label gets replaced",
+			query:    `status=open label("backend") sort:priority`,
+			label:    "frontend",
+			expected: `status=open sort:priority label("frontend")`,
+		},
+		{
+			name:     "empty label clears the clause",
+			query:    `type=epic label("backend")`,
+			label:    "",
+			expected: "type=epic",
+		},
+		{
+			name:     "clearing leaves an empty query",
+			query:    `label("backend")`,
+			label:    "",
+			expected: "",
+		},
+		{
+			name:     "quotes in label are escaped",
+			query:    "",
+			label:    `say "hi"`,
+			expected: `label("say \"hi\"")`,
+		},
+		{
+			name:     "escaped label is replaced",
+			query:    `label("say \"hi\"") type=task`,
+			label:    "ui",
+			expected: `type=task label("ui")`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := updateQueryLabel(tt.query, tt.label)
+			if got != tt.expected {
+				t.Errorf("updateQueryLabel(%q, %q) = %q, want %q",
+					tt.query, tt.label, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestUpdateQueryLabelWithTypeAndSort(t *testing.T) {
+	// Toggling type and sort after a label filter must keep the label clause,
+	// and the result must stay valid TDQ.
+	q := updateQueryLabel("", "backend")
+	q = updateQueryType(q, TypeFilterBug)
+	q = updateQuerySort(q, SortByUpdatedDesc)
+
+	want := `label("backend") type=bug sort:-updated`
+	if q != want {
+		t.Fatalf("composed query = %q, want %q", q, want)
+	}
+	if !isTDQQuery(q) {
+		t.Errorf("isTDQQuery(%q) = false, want true", q)
+	}
+	if _, err := query.Parse(q); err != nil {
+		t.Errorf("query.Parse(%q) failed: %v", q, err)
+	}
+	if !isTDQQuery(`label("backend")`) {
+		t.Error("a lone label clause should be treated as TDQ")
+	}
+
+	q = updateQueryType(q, TypeFilterNone)
+	q = updateQueryLabel(q, "")
+	if q != "sort:-updated" {
+		t.Errorf("after clearing filters query = %q, want %q", q, "sort:-updated")
+	}
+}
@@ -0,0 +1,90 @@
+package monitor
+
+import (
+	"fmt"
+
+	"github.com/marcus/td/internal/models"
+)
+
+// Work-session groups in the modal's log section longer than
+// logGroupCollapseAt are collapsed to their last logGroupVisible logs.
+const (
+	logGroupCollapseAt = 5
+	logGroupVisible    = 3
+)
+
+// logGroup is a run of consecutive logs from one work session. Logs written
+// outside a work session form groups with an empty WorkSessionID.
+type logGroup struct {
+	WorkSessionID string
+	Name          string
+	Logs          []models.Log // shown logs, oldest first
+	Hidden        int          // earlier logs collapsed out of Logs
+}
+
+// groupLogsByWorkSession splits chronological logs into runs of consecutive
+// logs sharing a work session, naming each from names (work session ID to
+// name) and collapsing long runs to their most recent logs.
+func groupLogsByWorkSession(logs []models.Log, names map[string]string) []logGroup {
+	var groups []logGroup
+	for _, log := range logs {
+		if n := len(groups); n > 0 && groups[n-1].WorkSessionID == log.WorkSessionID {
+			groups[n-1].Logs = append(groups[n-1].Logs, log)
+			continue
+		}
+		groups = append(groups, logGroup{
+			WorkSessionID: log.WorkSessionID,
+			Name:          names[log.WorkSessionID],
+			Logs:          []models.Log{log},
+		})
+	}
+	for i := range groups {
+		g := &groups[i]
+		if g.WorkSessionID != "" && len(g.Logs) > logGroupCollapseAt {
+			g.Hidden = len(g.Logs) - logGroupVisible
+			g.Logs = g.Logs[g.Hidden:]
+		}
+	}
+	return groups
+}
+
+// workSessionNames looks up the names of the work sessions logs belong to.
+func (m Model) workSessionNames(logs []models.Log) map[string]string {
+	names := make(map[string]string)
+	for _, log := range logs {
+		if log.WorkSessionID == "" {
+			continue
+		}
+		if _, ok := names[log.WorkSessionID]; ok {
+			continue
+		}
+		names[log.WorkSessionID] = ""
+		if ws, err := m.DB.GetWorkSession(log.WorkSessionID); err == nil && ws != nil {
+			names[log.WorkSessionID] = ws.Name
+		}
+	}
+	return names
+}
+
+// renderLogGroups renders the modal's log section body: each work-session
+// group under a subheader, with a note in place of collapsed logs.
+func renderLogGroups(groups []logGroup, contentWidth int) []string {
+	var lines []string
+	for _, g := range groups {
+		if g.WorkSessionID != "" {
+			name := g.Name
+			if name == "" {
+				name = g.WorkSessionID
+			}
+			total := len(g.Logs) + g.Hidden
+			lines = append(lines, subtleStyle.Render(fmt.Sprintf("▸ work session %s (%d)", truncateString(name, contentWidth-24), total)))
+			if g.Hidden > 0 {
+				lines = append(lines, subtleStyle.Render(fmt.Sprintf("  … %d earlier", g.Hidden)))
+			}
+		}
+		for _, log := range g.Logs {
+			lines = append(lines, renderLogLines(log, contentWidth)...)
+		}
+	}
+	return lines
+}
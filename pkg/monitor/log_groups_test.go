@@ -0,0 +1,68 @@
+package monitor
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/marcus/td/internal/models"
+)
+
+func TestGroupLogsByWorkSession(t *testing.T) {
+	base := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	var logs []models.Log
+	add := func(ws string, n int) {
+		for i := 0; i < n; i++ {
+			logs = append(logs, models.Log{
+				ID:            fmt.Sprintf("%d", len(logs)),
+				WorkSessionID: ws,
+				Message:       fmt.Sprintf("%s log %d", ws, i),
+				Timestamp:     base.Add(time.Duration(len(logs)) * time.Minute),
+			})
+		}
+	}
+	add("ws-a", 2)
+	add("", 1)
+	add("ws-b", 7)
+	add("ws-a", 1)
+
+	names := map[string]string{"ws-a": "parser refactor", "ws-b": "sync fixes"}
+	groups := groupLogsByWorkSession(logs, names)
+
+	want := []struct {
+		ws     string
+		name   string
+		shown  int
+		hidden int
+	}{
+		{"ws-a", "parser refactor", 2, 0},
+		{"", "", 1, 0},
+		{"ws-b", "sync fixes", logGroupVisible, 7 - logGroupVisible},
+		{"ws-a", "parser refactor", 1, 0},
+	}
+	if len(groups) != len(want) {
+		t.Fatalf("got %d groups, want %d: %+v", len(groups), len(want), groups)
+	}
+	for i, w := range want {
+		g := groups[i]
+		if g.WorkSessionID != w.ws || g.Name != w.name || len(g.Logs) != w.shown || g.Hidden != w.hidden {
+			t.Errorf("group %d = {%q %q shown=%d hidden=%d}, want {%q %q shown=%d hidden=%d}",
+				i, g.WorkSessionID, g.Name, len(g.Logs), g.Hidden, w.ws, w.name, w.shown, w.hidden)
+		}
+	}
+	// A collapsed group keeps its most recent logs
+	if got := groups[2].Logs[0].Message; got != "ws-b log 4" {
+		t.Errorf("first shown log of collapsed group = %q, want %q", got, "ws-b log 4")
+	}
+
+	rendered := strings.Join(renderLogGroups(groups, 80), "\n")
+	for _, s := range []string{"work session parser refactor (2)", "work session sync fixes (7)", "… 4 earlier"} {
+		if !strings.Contains(rendered, s) {
+			t.Errorf("rendered logs missing %q:\n%s", s, rendered)
+		}
+	}
+	if strings.Contains(rendered, "ws-b log 0") {
+		t.Errorf("collapsed log rendered:\n%s", rendered)
+	}
+}
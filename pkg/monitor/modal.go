@@ -10,6 +10,7 @@ import (
 	tea "charm.land/bubbletea/v2"
 	"charm.land/glamour/v2"
 	"charm.land/lipgloss/v2"
+	"github.com/marcus/td/internal/config"
 	"github.com/marcus/td/internal/models"
 	"github.com/marcus/td/pkg/monitor/modal"
 	"github.com/marcus/td/pkg/monitor/mouse"
@@ -164,8 +165,11 @@ func (m Model) navigateModal(delta int) (tea.Model, tea.Cmd) {
 	modal.Issue = nil
 	modal.Handoff = nil
 	modal.Logs = nil
+	modal.LogGroups = nil
 	modal.BlockedBy = nil
 	modal.Blocks = nil
+	modal.Related = nil
+	modal.RelatedSectionFocused = false
 	modal.EpicTasks = nil
 	modal.EpicTasksCursor = 0
 	modal.TaskSectionFocused = false
@@ -256,6 +260,12 @@ func (m Model) estimateModalContentLines(modal *ModalEntry) int {
 	if len(modal.Blocks) > 0 {
 		lines += 2 // Header + blank
 	}
+	lines += len(modal.Related)
+	if len(modal.Related) > 0 {
+		lines += 2 // Header + blank
+	}
+	lines += len(renderBlockerChain(issue, modal.BlockerChain, m.modalContentWidth()))
+	lines += len(renderFileOverlapHint(modal.FileOverlaps, m.modalContentWidth()))
 
 	// Handoff
 	if modal.Handoff != nil {
@@ -269,10 +279,7 @@ func (m Model) estimateModalContentLines(modal *ModalEntry) int {
 	// Logs
 	if len(modal.Logs) > 0 {
 		lines++ // Header
-		contentWidth := m.modalContentWidth()
-		for _, log := range modal.Logs {
-			lines += len(renderLogLines(log, contentWidth))
-		}
+		lines += len(renderLogGroups(modal.LogGroups, m.modalContentWidth()))
 	}
 
 	// Comments
@@ -668,7 +675,7 @@ func (m *Model) createBoardPickerModal() *modal.Modal {
 
 	// Shortcuts footer
 	md.AddSection(modal.Spacer())
-	md.AddSection(modal.Text("Enter:select  e:edit  n:new  Esc:close"))
+	md.AddSection(modal.Text("Enter:select  f:filter  e:edit  n:new  Esc:close"))
 
 	return md
 }
@@ -679,6 +686,8 @@ func (m Model) openDeleteConfirmModal(issueID, issueTitle string) Model {
 	m.ConfirmAction = "delete"
 	m.ConfirmIssueID = issueID
 	m.ConfirmTitle = issueTitle
+	m.ConfirmImpact = computeDeleteImpact(m.DB, issueID)
+	m.ConfirmDeleteMode = ""
 
 	// Create declarative modal and mouse handler
 	m.DeleteConfirmModal = m.createDeleteConfirmModal()
@@ -694,10 +703,20 @@ func (m *Model) closeDeleteConfirmModal() {
 	m.ConfirmAction = ""
 	m.ConfirmIssueID = ""
 	m.ConfirmTitle = ""
+	m.ConfirmImpact = deleteImpact{}
+	m.ConfirmDeleteMode = ""
 	m.DeleteConfirmModal = nil
 	m.DeleteConfirmMouseHandler = nil
 }
 
+// rebuildDeleteConfirmModal rebuilds the open delete confirmation after its
+// mode or impact changed.
+func (m *Model) rebuildDeleteConfirmModal() {
+	m.DeleteConfirmModal = m.createDeleteConfirmModal()
+	m.DeleteConfirmModal.Reset()
+	m.DeleteConfirmMouseHandler = mouse.NewHandler()
+}
+
 // createDeleteConfirmModal builds the declarative modal for delete confirmation.
 func (m *Model) createDeleteConfirmModal() *modal.Modal {
 	// Calculate width based on issue title length (matches legacy behavior)
@@ -705,6 +724,10 @@ func (m *Model) createDeleteConfirmModal() *modal.Modal {
 	if len(m.ConfirmTitle) > 30 {
 		width = len(m.ConfirmTitle) + 10
 	}
+	impact := m.ConfirmImpact
+	if impact.HasChildren() && width < 50 {
+		width = 50 // room for the three choice buttons
+	}
 	if width > 60 {
 		width = 60
 	}
@@ -715,6 +738,13 @@ func (m *Model) createDeleteConfirmModal() *modal.Modal {
 		action = m.ConfirmAction
 	}
 	title := action + " " + m.ConfirmIssueID + "?"
+	switch m.ConfirmDeleteMode {
+	case deleteModeCascade:
+		title = fmt.Sprintf("Delete %s and %s?", m.ConfirmIssueID,
+			pluralize(len(impact.Descendants), "issue under it", "issues under it"))
+	case deleteModeOrphan:
+		title = fmt.Sprintf("Delete %s, keep its children?", m.ConfirmIssueID)
+	}
 
 	md := modal.New(title,
 		modal.WithWidth(width),
@@ -735,9 +765,36 @@ func (m *Model) createDeleteConfirmModal() *modal.Modal {
 	// Add issue title as text section (with quotes)
 	md.AddSection(modal.Text("\"" + displayTitle + "\""))
 
+	// What else the delete touches
+	if lines := impact.summaryLines(); len(lines) > 0 {
+		md.AddSection(modal.Spacer())
+		for _, line := range lines {
+			md.AddSection(modal.Text(line))
+		}
+	}
+	switch m.ConfirmDeleteMode {
+	case deleteModeCascade:
+		md.AddSection(modal.Text("All of them will be deleted too."))
+	case deleteModeOrphan:
+		md.AddSection(modal.Text(pluralize(len(impact.Children), "child", "children") + " will become top-level issues."))
+	}
+
 	// Add spacer before buttons
 	md.AddSection(modal.Spacer())
 
+	// An issue with children needs a choice of what happens to them, and
+	// then a second confirmation, so a stray Y can't delete a whole epic
+	if impact.HasChildren() && m.ConfirmDeleteMode == "" {
+		md.AddSection(modal.Buttons(
+			modal.Btn(" Delete all ", deleteModeCascade, modal.BtnDanger()),
+			modal.Btn(" Orphan children ", deleteModeOrphan),
+			modal.Btn(" Cancel ", "cancel"),
+		))
+		md.AddSection(modal.Spacer())
+		md.AddSection(modal.Text("Tab:switch  Enter:choose  Esc:cancel"))
+		return md
+	}
+
 	// Add buttons - Yes is danger, No is normal
 	md.AddSection(modal.Buttons(
 		modal.Btn(" Yes ", "yes", modal.BtnDanger()),
@@ -755,7 +812,15 @@ func (m *Model) createDeleteConfirmModal() *modal.Modal {
 func (m Model) handleDeleteConfirmAction(action string) (tea.Model, tea.Cmd) {
 	switch action {
 	case "yes", "delete":
+		if m.ConfirmImpact.HasChildren() && m.ConfirmDeleteMode == "" {
+			return m, nil // choose what happens to the children first
+		}
 		return m.executeDelete()
+	case deleteModeCascade, deleteModeOrphan:
+		// Rebuild as a plain yes/no confirmation of the chosen mode
+		m.ConfirmDeleteMode = action
+		m.rebuildDeleteConfirmModal()
+		return m, nil
 	case "no", "cancel":
 		m.closeDeleteConfirmModal()
 		return m, nil
@@ -768,10 +833,14 @@ func (m Model) openCloseConfirmModal(issueID, issueTitle string) Model {
 	m.CloseConfirmOpen = true
 	m.CloseConfirmIssueID = issueID
 	m.CloseConfirmTitle = issueTitle
+	m.CloseConfirmNeedsReason, _ = config.GetRequireCloseReason(m.BaseDir)
 
 	// Create textinput for reason
 	m.CloseConfirmInput = textinput.New()
 	m.CloseConfirmInput.Placeholder = "Optional: reason for closing"
+	if m.CloseConfirmNeedsReason {
+		m.CloseConfirmInput.Placeholder = "Required: reason for closing"
+	}
 	m.CloseConfirmInput.SetWidth(40)
 
 	// Create declarative modal and mouse handler
@@ -787,6 +856,7 @@ func (m *Model) closeCloseConfirmModal() {
 	m.CloseConfirmOpen = false
 	m.CloseConfirmIssueID = ""
 	m.CloseConfirmTitle = ""
+	m.CloseConfirmNeedsReason = false
 	m.CloseConfirmModal = nil
 	m.CloseConfirmMouseHandler = nil
 }
@@ -828,7 +898,11 @@ func (m *Model) createCloseConfirmModal() *modal.Modal {
 	md.AddSection(modal.Spacer())
 
 	// Add reason input with label
-	md.AddSection(modal.InputWithLabel("reason", "Reason (optional):", &m.CloseConfirmInput,
+	label := "Reason (optional):"
+	if m.CloseConfirmNeedsReason {
+		label = "Reason (required):"
+	}
+	md.AddSection(modal.InputWithLabel("reason", label, &m.CloseConfirmInput,
 		modal.WithSubmitOnEnter(true),
 		modal.WithSubmitAction("confirm"),
 	))
@@ -1133,15 +1207,30 @@ func computeModalSectionLines(modal *ModalEntry) {
 		lineCount += 1 + len(resolvedDeps) + 1 // header + items + blank
 	}
 
+	// Blocked because + blocker chain (width only affects truncation)
+	lineCount += len(renderBlockerChain(issue, modal.BlockerChain, 80))
+	lineCount += len(renderFileOverlapHint(modal.FileOverlaps, 80))
+
 	// Blocks section
 	if len(modal.Blocks) > 0 {
 		modal.BlocksStartLine = lineCount
 		lineCount += 1 + len(modal.Blocks) // header + items
 		modal.BlocksEndLine = lineCount
+		lineCount++ // blank line
 	} else {
 		modal.BlocksStartLine = 0
 		modal.BlocksEndLine = 0
 	}
+
+	// Related section
+	if len(modal.Related) > 0 {
+		modal.RelatedStartLine = lineCount
+		lineCount += 1 + len(modal.Related) // header + items
+		modal.RelatedEndLine = lineCount
+	} else {
+		modal.RelatedStartLine = 0
+		modal.RelatedEndLine = 0
+	}
 }
 
 // handleModalClick handles left-click events within a modal
@@ -1201,6 +1290,7 @@ func (m Model) handleModalClick(x, y int) (tea.Model, tea.Cmd) {
 			modal.ParentEpicFocused = false
 			modal.TaskSectionFocused = false
 			modal.BlocksSectionFocused = false
+			modal.RelatedSectionFocused = false
 			// Focus this section and set cursor
 			modal.BlockedBySectionFocused = true
 			modal.BlockedByCursor = rowInSection
@@ -1217,6 +1307,7 @@ func (m Model) handleModalClick(x, y int) (tea.Model, tea.Cmd) {
 			modal.ParentEpicFocused = false
 			modal.TaskSectionFocused = false
 			modal.BlockedBySectionFocused = false
+			modal.RelatedSectionFocused = false
 			// Focus this section and set cursor
 			modal.BlocksSectionFocused = true
 			modal.BlocksCursor = rowInSection
@@ -1224,11 +1315,26 @@ func (m Model) handleModalClick(x, y int) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	// Check if click is in related section
+	if len(modal.Related) > 0 && clickedLine >= modal.RelatedStartLine && clickedLine < modal.RelatedEndLine {
+		rowInSection := clickedLine - modal.RelatedStartLine - 1 // -1 for header
+		if rowInSection >= 0 && rowInSection < len(modal.Related) {
+			modal.ParentEpicFocused = false
+			modal.TaskSectionFocused = false
+			modal.BlockedBySectionFocused = false
+			modal.BlocksSectionFocused = false
+			modal.RelatedSectionFocused = true
+			modal.RelatedCursor = rowInSection
+		}
+		return m, nil
+	}
+
 	// Click elsewhere in modal - unfocus all sections (return to scroll mode)
 	modal.ParentEpicFocused = false
 	modal.TaskSectionFocused = false
 	modal.BlockedBySectionFocused = false
 	modal.BlocksSectionFocused = false
+	modal.RelatedSectionFocused = false
 	return m, nil
 }
 
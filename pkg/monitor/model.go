@@ -11,9 +11,11 @@ import (
 	"github.com/marcus/td/internal/config"
 	"github.com/marcus/td/internal/db"
 	"github.com/marcus/td/internal/models"
+	"github.com/marcus/td/internal/query"
 	"github.com/marcus/td/internal/session"
 	"github.com/marcus/td/internal/syncclient"
 	"github.com/marcus/td/internal/version"
+	"github.com/marcus/td/internal/workflow"
 	"github.com/marcus/td/pkg/monitor/keymap"
 	"github.com/marcus/td/pkg/monitor/modal"
 	"github.com/marcus/td/pkg/monitor/mouse"
@@ -24,6 +26,11 @@ type Model struct {
 	// Database and session
 	DB        *db.DB
 	SessionID string
+	// QueryCache reuses board query results while the database is unchanged
+	QueryCache *query.Cache
+	// CustomStatuses are the project's custom workflow statuses, from
+	// workflow.ForProject; queries, filters and the edit form accept them
+	CustomStatuses []models.Status
 
 	// Window dimensions
 	Width  int
@@ -52,9 +59,10 @@ type Model struct {
 	TDQHelpModal        *modal.Modal     // Declarative modal instance for TDQ help
 	TDQHelpMouseHandler *mouse.Handler   // Mouse handler for TDQ help modal
 	LastRefresh         time.Time
-	StartedAt           time.Time // When monitor started, to track new handoffs
-	Err                 error     // Last error, if any
-	Embedded            bool      // When true, skip footer (embedded in sidecar)
+	PendingRowChanges   taskListDiff // Polled task list changes held back until manual refresh
+	StartedAt           time.Time    // When monitor started, to track new handoffs
+	Err                 error        // Last error, if any
+	Embedded            bool         // When true, skip footer (embedded in sidecar)
 
 	// Flattened rows for selection
 	TaskListRows    []TaskListRow // Flattened task list for selection
@@ -64,20 +72,32 @@ type Model struct {
 	ModalStack []ModalEntry
 
 	// Search state
-	SearchMode     bool            // Whether search mode is active
-	SearchQuery    string          // Current search query
-	SearchInput    textinput.Model // Text input for search (cursor support)
-	IncludeClosed  bool            // Whether to include closed tasks
-	SortMode       SortMode        // Task list sort order
-	TypeFilterMode TypeFilterMode  // Type filter (epic, task, bug, etc.)
+	SearchMode        bool            // Whether search mode is active
+	SearchQuery       string          // Current search query
+	SearchInput       textinput.Model // Text input for search (cursor support)
+	IncludeClosed     bool            // Whether to include closed tasks
+	SortMode          SortMode        // Task list sort order
+	ShowAgeColumns    bool            // Show age and time-in-status columns in task rows
+	SearchContent     bool            // Plain-text search also matches logs, comments and handoffs
+	SearchHighlight   bool            // Search highlights matches in the full task list instead of filtering it
+	TypeFilterMode    TypeFilterMode  // Type filter (epic, task, bug, etc.)
+	QueryPreset       string          // Active query preset (see QueryPresets), "" for none
+	ExpiredClaimsOnly bool            // Task list shows only issues with expired claim leases
+
+	// Board query applied as a transient search filter (see applyBoardQueryFilter)
+	BoardFilter           string // Name of the board whose query is the search, "" for none
+	BoardFilterPrevQuery  string // Search query to restore when the board filter is cleared
+	BoardFilterPrevPreset string // Query preset to restore when the board filter is cleared
 
 	// Confirmation dialog state (delete confirmation)
 	ConfirmOpen        bool
 	ConfirmAction      string // "delete"
 	ConfirmIssueID     string
 	ConfirmTitle       string
-	ConfirmButtonFocus int // 0=Yes, 1=No (for delete confirmation) - legacy, kept for compatibility
-	ConfirmButtonHover int // 0=none, 1=Yes, 2=No - legacy, kept for compatibility
+	ConfirmImpact      deleteImpact // what deleting ConfirmIssueID would touch
+	ConfirmDeleteMode  string       // deleteModeCascade or deleteModeOrphan, once chosen for an issue with children
+	ConfirmButtonFocus int          // 0=Yes, 1=No (for delete confirmation) - legacy, kept for compatibility
+	ConfirmButtonHover int          // 0=none, 1=Yes, 2=No - legacy, kept for compatibility
 
 	// Declarative delete confirmation modal
 	DeleteConfirmModal        *modal.Modal   // Declarative modal instance
@@ -88,8 +108,16 @@ type Model struct {
 	CloseConfirmIssueID     string
 	CloseConfirmTitle       string
 	CloseConfirmInput       textinput.Model
-	CloseConfirmButtonFocus int // 0=input, 1=Confirm, 2=Cancel - legacy, kept for compatibility
-	CloseConfirmButtonHover int // 0=none, 1=Confirm, 2=Cancel - legacy, kept for compatibility
+	CloseConfirmNeedsReason bool // project sets require_close_reason
+	CloseConfirmButtonFocus int  // 0=input, 1=Confirm, 2=Cancel - legacy, kept for compatibility
+	CloseConfirmButtonHover int  // 0=none, 1=Confirm, 2=Cancel - legacy, kept for compatibility
+
+	// Discard confirmation: shown over the issue form when closing it (or
+	// quitting) with unsaved edits
+	DiscardConfirmOpen         bool
+	DiscardConfirmQuit         bool // quit after discarding, rather than just close the form
+	DiscardConfirmModal        *modal.Modal
+	DiscardConfirmMouseHandler *mouse.Handler
 
 	// Declarative close confirmation modal
 	CloseConfirmModal        *modal.Modal   // Declarative modal instance
@@ -115,6 +143,55 @@ type Model struct {
 	RecordReviewModal        *modal.Modal
 	RecordReviewMouseHandler *mouse.Handler
 
+	// Bulk grouping: issues marked in the task list (space) and the prompt
+	// for the parent they are moved under (P).
+	MarkedIssues      map[string]bool
+	GroupOpen         bool
+	GroupInput        textinput.Model
+	GroupModal        *modal.Modal
+	GroupMouseHandler *mouse.Handler
+
+	// Quick add: one-line prompt (A) that creates an open task with defaults.
+	QuickAddOpen         bool
+	QuickAddInput        textinput.Model
+	QuickAddModal        *modal.Modal
+	QuickAddMouseHandler *mouse.Handler
+
+	// Status history: every status message shown in the footer, kept so the
+	// message pane (M) can show what vanished. Capped at StatusHistoryLimit
+	// entries (0 uses the default).
+	StatusHistory             []StatusHistoryEntry
+	StatusHistoryLimit        int
+	StatusHistoryOpen         bool
+	StatusHistoryModal        *modal.Modal
+	StatusHistoryMouseHandler *mouse.Handler
+
+	// Dependency graph overlay (D in the issue modal). DepGraphCursor is a
+	// pointer for the same reason as LabelPickerCursor.
+	DepGraphOpen         bool
+	DepGraph             *DepGraph
+	DepGraphCursor       *int
+	DepGraphModal        *modal.Modal
+	DepGraphMouseHandler *mouse.Handler
+
+	// Label filter: the active label (L) and the picker used to choose it.
+	// LabelPickerCursor is a pointer so the list section keeps working
+	// across value-receiver copies of the model.
+	LabelFilter             string
+	LabelPickerOpen         bool
+	LabelPickerLabels       []db.LabelCount
+	LabelPickerCursor       *int
+	LabelPickerModal        *modal.Modal
+	LabelPickerMouseHandler *mouse.Handler
+	// LabelColorTarget is the label whose color the picker is choosing
+	// (c in the picker); empty while it is choosing a filter.
+	LabelColorTarget string
+	// LabelColors holds the explicit label colors from config.
+	LabelColors map[string]string
+	// Workflow is the project's status workflow, including custom statuses
+	// and transitions from config; nil means the built-in workflow.
+	Workflow *workflow.StateMachine
+
 	// Stats modal state
 	StatsOpen         bool
 	StatsLoading      bool
@@ -200,6 +277,12 @@ type Model struct {
 	BoardMode         BoardMode          // Active board mode state
 	BoardStatusPreset StatusFilterPreset // Current status filter preset for cycling
 
+	// Undo callback (set by caller; reverts the session's last action the
+	// same way td undo does and returns it, or nil when there is nothing to
+	// undo). The undo key is a no-op while this is nil or CanUndo is false.
+	UndoFunc func(database *db.DB, sessionID string) (*models.ActionLog, error)
+	CanUndo  bool // the session had an undoable action at the last refresh
+
 	// Auto-sync callback (set by caller for periodic background sync)
 	AutoSyncFunc     func() // Called periodically to push/pull in background
 	AutoSyncInterval time.Duration
@@ -246,14 +329,38 @@ type Model struct {
 	MarkdownTheme *MarkdownThemeConfig // Custom markdown/syntax theme (nil = default td colors)
 }
 
+// workflowMachine returns the state machine status changes are validated
+// against.
+func (m Model) workflowMachine() *workflow.StateMachine {
+	if m.Workflow != nil {
+		return m.Workflow
+	}
+	return workflow.DefaultMachine()
+}
+
 // NewModel creates a new monitor model
 func NewModel(database *db.DB, sessionID string, interval time.Duration, ver string, baseDir string) Model {
 	// Initialize keymap with default bindings
 	km := keymap.NewRegistry()
 	keymap.RegisterDefaults(km)
+	// User overrides from .todos/keymap.json; a bad file keeps the defaults
+	// and is reported in the status bar
+	var statusMsg string
+	if err := keymap.LoadAndApply(km, baseDir); err != nil {
+		statusMsg = "Keymap ignored: " + strings.ReplaceAll(err.Error(), "\n", "; ")
+	}
 
 	// Load pane heights from config (or use defaults)
 	paneHeights, _ := config.GetPaneHeights(baseDir)
+	labelColors, _ := config.GetLabelColors(baseDir)
+	// An invalid workflow config keeps the built-in one
+	wf, err := workflow.ForProject(baseDir)
+	if err != nil {
+		if statusMsg != "" {
+			statusMsg += "; "
+		}
+		statusMsg += "Workflow ignored: " + err.Error()
+	}
 
 	// Initialize search input
 	searchInput := textinput.New()
@@ -262,9 +369,11 @@ func NewModel(database *db.DB, sessionID string, interval time.Duration, ver str
 	searchInput.SetWidth(50) // Reasonable width for search queries
 	searchInput.CharLimit = 200
 
-	return Model{
+	m := Model{
 		DB:                database,
 		SessionID:         sessionID,
+		QueryCache:        query.NewCache(),
+		CustomStatuses:    wf.CustomStatuses(),
 		RefreshInterval:   interval,
 		ScrollOffset:      make(map[Panel]int),
 		Cursor:            make(map[Panel]int),
@@ -286,7 +395,15 @@ func NewModel(database *db.DB, sessionID string, interval time.Duration, ver str
 		DraggingDivider:   -1,
 		DividerHover:      -1,
 		BaseDir:           baseDir,
+		LabelColors:       labelColors,
+		Workflow:          wf,
+		StatusMessage:     statusMsg,
+		StatusIsError:     statusMsg != "",
+	}
+	if statusMsg != "" {
+		m.recordStatus(statusMsg, true)
 	}
+	return m
 }
 
 // NewEmbedded creates a monitor model for embedding in external applications.
@@ -429,6 +546,9 @@ func (m Model) Init() tea.Cmd {
 		m.restoreFilterState(),
 		m.checkFirstRun(),
 	}
+	if m.StatusIsError {
+		cmds = append(cmds, tea.Tick(10*time.Second, func(t time.Time) tea.Msg { return ClearStatusMsg{} }))
+	}
 
 	// Start async version check (non-blocking)
 	if m.Version != "" && !version.IsDevelopmentVersion(m.Version) {
@@ -442,7 +562,19 @@ func (m Model) Init() tea.Cmd {
 func (m Model) restoreLastViewedBoard() tea.Cmd {
 	return func() tea.Msg {
 		board, err := m.DB.GetLastViewedBoard()
-		if err != nil || board == nil {
+		if err != nil {
+			return nil
+		}
+		// No board viewed locally yet: open the project's default board, if
+		// one was synced, instead of the builtin fallback.
+		if board == nil || board.LastViewedAt == nil {
+			if _, ref, err := config.GetProjectDefaults(m.BaseDir); err == nil && ref != "" {
+				if def, err := m.DB.ResolveBoardRef(ref); err == nil && def != nil {
+					board = def
+				}
+			}
+		}
+		if board == nil {
 			return nil // No last viewed board, stay in panel mode
 		}
 		return RestoreLastBoardMsg{Board: board}
@@ -456,15 +588,22 @@ func (m Model) restoreFilterState() tea.Cmd {
 		if err != nil || state == nil {
 			return nil
 		}
-		// Only restore if there's actual filter state
-		if state.SearchQuery == "" && state.SortMode == "" && state.TypeFilter == "" && !state.IncludeClosed {
-			return nil
+		// Only restore if there's actual filter state. Without a local
+		// override, fall back to the project's synced default query.
+		if state.SearchQuery == "" && state.SortMode == "" && state.TypeFilter == "" && state.LabelFilter == "" && !state.IncludeClosed && state.QueryPreset == "" {
+			query, _, err := config.GetProjectDefaults(m.BaseDir)
+			if err != nil || query == "" {
+				return nil
+			}
+			return RestoreFilterMsg{SearchQuery: query, SortMode: SortByPriority}
 		}
 		return RestoreFilterMsg{
 			SearchQuery:    state.SearchQuery,
 			SortMode:       SortModeFromString(state.SortMode),
 			TypeFilterMode: TypeFilterModeFromString(state.TypeFilter),
+			LabelFilter:    state.LabelFilter,
 			IncludeClosed:  state.IncludeClosed,
+			QueryPreset:    state.QueryPreset,
 		}
 	}
 }
@@ -479,17 +618,30 @@ type RestoreFilterMsg struct {
 	SearchQuery    string
 	SortMode       SortMode
 	TypeFilterMode TypeFilterMode
+	LabelFilter    string
 	IncludeClosed  bool
+	QueryPreset    string
 }
 
-// Update implements tea.Model
+// Update implements tea.Model. Any new status message set while handling msg
+// is recorded in the status history.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	prevStatus := m.StatusMessage
+	next, cmd := m.update(msg)
+	if nm, ok := next.(Model); ok && nm.StatusMessage != "" && nm.StatusMessage != prevStatus {
+		nm.recordStatus(nm.StatusMessage, nm.StatusIsError)
+		return nm, cmd
+	}
+	return next, cmd
+}
+
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// Handle TickMsg before any UI-mode interceptions to keep the poll chain
 	// alive. Without this, opening a form (or other overlay that intercepts all
 	// messages) would swallow the TickMsg, preventing scheduleTick() from being
 	// called, permanently breaking the periodic refresh cycle.
 	if _, ok := msg.(TickMsg); ok {
-		cmds := []tea.Cmd{m.fetchData(), m.scheduleTick()}
+		cmds := []tea.Cmd{m.fetchPollData(), m.scheduleTick()}
 		if m.TaskListMode == TaskListModeBoard && m.BoardMode.Board != nil {
 			cmds = append(cmds, m.fetchBoardIssues(m.BoardMode.Board.ID))
 		}
@@ -562,6 +714,28 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	}
 
+	// Group prompt: forward non-key messages to textinput (cursor blink).
+	if m.GroupOpen {
+		if _, isKey := msg.(tea.KeyMsg); !isKey {
+			var inputCmd tea.Cmd
+			m.GroupInput, inputCmd = m.GroupInput.Update(msg)
+			if inputCmd != nil {
+				return m, inputCmd
+			}
+		}
+	}
+
+	// Quick-add prompt: forward non-key messages to textinput (cursor blink).
+	if m.QuickAddOpen {
+		if _, isKey := msg.(tea.KeyMsg); !isKey {
+			var inputCmd tea.Cmd
+			m.QuickAddInput, inputCmd = m.QuickAddInput.Update(msg)
+			if inputCmd != nil {
+				return m, inputCmd
+			}
+		}
+	}
+
 	// Search mode: forward non-key messages to textinput (cursor blink, etc.)
 	// Key messages are handled in handleKey() to avoid double-processing
 	if m.SearchMode {
@@ -601,9 +775,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.FocusedIssue = msg.FocusedIssue
 		m.InProgress = msg.InProgress
 		m.Activity = msg.Activity
-		m.TaskList = msg.TaskList
 		m.RecentHandoffs = msg.RecentHandoffs
 		m.ActiveSessions = msg.ActiveSessions
+		m.CanUndo = msg.CanUndo
+
+		// Polled changes to visible task list rows are announced, not applied
+		held, toastCmd := m.deferPolledTaskList(msg)
+		if !held {
+			m.TaskList = msg.TaskList
+			m.PendingRowChanges = taskListDiff{}
+		}
 		m.LastRefresh = msg.Timestamp
 
 		// Build flattened rows for selection
@@ -612,7 +793,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Restore cursor positions from saved issue IDs
 		m.restoreCursors()
-		return m, nil
+		return m, toastCmd
 
 	case IssueDetailsMsg:
 		// Only update if this is for the currently open modal
@@ -625,9 +806,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			modal.Issue = msg.Issue
 			modal.Handoff = msg.Handoff
 			modal.Logs = msg.Logs
+			modal.LogGroups = msg.LogGroups
 			modal.Comments = msg.Comments
 			modal.BlockedBy = msg.BlockedBy
 			modal.Blocks = msg.Blocks
+			modal.Related = msg.Related
+			modal.BlockerChain = msg.Chain
+			modal.FileOverlaps = msg.Overlaps
 			modal.EpicTasks = msg.EpicTasks
 			modal.ParentEpic = msg.ParentEpic
 			if isInitialLoad {
@@ -655,6 +840,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if len(modal.Blocks) > 0 && modal.BlocksCursor >= len(modal.Blocks) {
 					modal.BlocksCursor = len(modal.Blocks) - 1
 				}
+				if len(modal.Related) > 0 && modal.RelatedCursor >= len(modal.Related) {
+					modal.RelatedCursor = len(modal.Related) - 1
+				}
 			}
 
 			// Trigger async markdown rendering (expensive)
@@ -869,7 +1057,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.BoardMode.ScrollOffset = 0
 			m.BoardMode.SwimlaneCursor = 0
 			m.BoardMode.SwimlaneScroll = 0
-			m.BoardMode.StatusFilter = DefaultBoardStatusFilter()
+			m.BoardMode.StatusFilter = DefaultBoardStatusFilter(m.CustomStatuses)
 			m.BoardMode.ViewMode = BoardViewModeFromString(msg.Board.ViewMode)
 			return m, m.fetchBoardIssues(msg.Board.ID)
 		}
@@ -879,7 +1067,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.SearchQuery = msg.SearchQuery
 		m.SortMode = msg.SortMode
 		m.TypeFilterMode = msg.TypeFilterMode
+		m.LabelFilter = msg.LabelFilter
 		m.IncludeClosed = msg.IncludeClosed
+		m.QueryPreset = msg.QueryPreset
 		// Update the search input to show restored query
 		m.SearchInput.SetValue(msg.SearchQuery)
 		// Refresh data with restored filters
@@ -956,7 +1146,37 @@ func (m Model) scheduleTick() tea.Cmd {
 // fetchData returns a command that fetches all data and sends a RefreshDataMsg
 func (m Model) fetchData() tea.Cmd {
 	return func() tea.Msg {
-		data := FetchData(m.DB, m.SessionID, m.StartedAt, m.SearchQuery, m.IncludeClosed, m.SortMode)
+		return m.refreshData()
+	}
+}
+
+// refreshData fetches the monitor data for the current search. In highlight
+// mode the task list is fetched unfiltered and the search's matches are
+// recorded alongside it.
+func (m Model) refreshData() RefreshDataMsg {
+	if !m.highlightingSearch() {
+		return FetchDataWithSearchMode(m.DB, m.SessionID, m.StartedAt, m.SearchQuery, m.searchMode(), m.IncludeClosed, m.SortMode)
+	}
+	data := FetchDataWithSearchMode(m.DB, m.SessionID, m.StartedAt, "", m.searchMode(), m.IncludeClosed, m.SortMode)
+	data.TaskList.Matches = fetchSearchMatches(m.DB, m.SessionID, m.SearchQuery, m.searchMode(), m.IncludeClosed, m.SortMode)
+	return data
+}
+
+// searchMode is the search mode the task list is fetched with: auto, or
+// content when plain-text search should also match issue activity.
+func (m Model) searchMode() string {
+	if m.SearchContent {
+		return "content"
+	}
+	return "auto"
+}
+
+// fetchPollData is fetchData for the periodic tick; its result is marked as
+// polled so changes to visible rows can be held back until the user refreshes.
+func (m Model) fetchPollData() tea.Cmd {
+	return func() tea.Msg {
+		data := m.refreshData()
+		data.Poll = true
 		return data
 	}
 }
@@ -991,6 +1211,7 @@ func (m Model) fetchIssueDetails(issueID string) tea.Cmd {
 		// Fetch recent logs (cap at 20)
 		logs, _ := m.DB.GetLogs(issueID, 20)
 		msg.Logs = logs
+		msg.LogGroups = groupLogsByWorkSession(logs, m.workSessionNames(logs))
 
 		// Fetch comments
 		comments, _ := m.DB.GetComments(issueID)
@@ -1029,6 +1250,11 @@ func (m Model) fetchIssueDetails(issueID string) tea.Cmd {
 				}
 			}
 		}
+		if len(depIDs) > 0 {
+			msg.Chain = buildBlockerChain(m.DB, issueID)
+		}
+		msg.Related = fetchRelatedIssues(m.DB, issueID)
+		msg.Overlaps, _ = m.DB.SuggestRelatedByFiles(issueID)
 
 		// Fetch child tasks if this is an epic
 		if issue.Type == models.TypeEpic {
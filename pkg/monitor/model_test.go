@@ -1026,6 +1026,20 @@ func TestBlockedByContextDetection(t *testing.T) {
 			},
 			expected: keymap.ContextBlocksFocused,
 		},
+		{
+			name: "related focused context",
+			model: Model{
+				Keymap: newTestKeymap(),
+				ModalStack: []ModalEntry{
+					{
+						IssueID:               "td-001",
+						Issue:                 &models.Issue{ID: "td-001"},
+						RelatedSectionFocused: true,
+					},
+				},
+			},
+			expected: keymap.ContextRelatedFocused,
+		},
 		{
 			name: "modal context when not focused",
 			model: Model{
@@ -1939,6 +1953,12 @@ func TestUpdateQuerySort(t *testing.T) {
 			sortMode: SortByCreatedDesc,
 			expected: "sort:-created",
 		},
+		{
+			name:     "stale sort drops the clause",
+			query:    "type=epic sort:-updated",
+			sortMode: SortByStaleness,
+			expected: "type=epic",
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,59 @@
+package monitor
+
+import (
+	"testing"
+
+	"github.com/marcus/td/internal/config"
+	"github.com/marcus/td/internal/db"
+)
+
+func TestRestoreAppliesProjectDefaults(t *testing.T) {
+	baseDir := t.TempDir()
+	database, err := db.Initialize(baseDir)
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	defer database.Close()
+
+	sprint, err := database.CreateBoard("Sprint", "status = open")
+	if err != nil {
+		t.Fatalf("CreateBoard failed: %v", err)
+	}
+	if err := config.SetProjectDefaults(baseDir, "type = bug", "Sprint"); err != nil {
+		t.Fatalf("SetProjectDefaults failed: %v", err)
+	}
+	m := Model{DB: database, BaseDir: baseDir}
+
+	// No local filter state: the project default query applies.
+	msg, ok := m.restoreFilterState()().(RestoreFilterMsg)
+	if !ok || msg.SearchQuery != "type = bug" {
+		t.Fatalf("restoreFilterState = %#v, want project default query", msg)
+	}
+
+	// No board viewed yet: the project default board opens.
+	boardMsg, ok := m.restoreLastViewedBoard()().(RestoreLastBoardMsg)
+	if !ok || boardMsg.Board == nil || boardMsg.Board.ID != sprint.ID {
+		t.Fatalf("restoreLastViewedBoard = %#v, want board %s", boardMsg, sprint.ID)
+	}
+
+	// A local filter and a viewed board override the project defaults.
+	if err := config.SetFilterState(baseDir, &config.FilterState{SearchQuery: "status = closed"}); err != nil {
+		t.Fatalf("SetFilterState failed: %v", err)
+	}
+	all, err := database.GetBoardByName("All Issues")
+	if err != nil || all == nil {
+		t.Fatalf("GetBoardByName: %v", err)
+	}
+	if err := database.UpdateBoardLastViewed(all.ID); err != nil {
+		t.Fatalf("UpdateBoardLastViewed failed: %v", err)
+	}
+
+	msg, _ = m.restoreFilterState()().(RestoreFilterMsg)
+	if msg.SearchQuery != "status = closed" {
+		t.Errorf("restored query = %q, want local override", msg.SearchQuery)
+	}
+	boardMsg, _ = m.restoreLastViewedBoard()().(RestoreLastBoardMsg)
+	if boardMsg.Board == nil || boardMsg.Board.ID != all.ID {
+		t.Errorf("restored board = %#v, want last viewed %s", boardMsg.Board, all.ID)
+	}
+}
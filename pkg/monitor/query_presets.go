@@ -0,0 +1,134 @@
+package monitor
+
+import (
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// QueryPreset is a named search the monitor applies with one key (m).
+type QueryPreset struct {
+	Name  string
+	Query string
+}
+
+// QueryPresets are the presets m steps through, in order; after the last
+// one the search is cleared.
+var QueryPresets = []QueryPreset{
+	{Name: "my queue", Query: "mine() AND is_ready() AND status != closed"},
+	{Name: "needs review", Query: "status = in_review"},
+	{Name: "rework", Query: "rework()"},
+}
+
+// nextQueryPreset returns the preset after the one named current: the
+// first when current is "" or unknown, and the zero value after the last.
+func nextQueryPreset(current string) QueryPreset {
+	for i, p := range QueryPresets {
+		if p.Name == current {
+			if i+1 < len(QueryPresets) {
+				return QueryPresets[i+1]
+			}
+			return QueryPreset{}
+		}
+	}
+	return QueryPresets[0]
+}
+
+// applyQueryPreset makes p the active search, keeping the type filter. The
+// zero preset clears the search.
+func (m *Model) applyQueryPreset(p QueryPreset) {
+	oldQuery := m.SearchQuery
+	m.QueryPreset = p.Name
+	m.SearchQuery = updateQueryType(p.Query, m.TypeFilterMode)
+	m.SearchInput.SetValue(m.SearchQuery)
+	// Recalc bounds if search bar visibility changed
+	if (oldQuery == "") != (m.SearchQuery == "") {
+		m.updatePanelBounds()
+	}
+}
+
+// cycleQueryPreset advances to the next query preset and refreshes the view.
+func (m Model) cycleQueryPreset() (tea.Model, tea.Cmd) {
+	p := nextQueryPreset(m.QueryPreset)
+	m.forgetBoardFilter()
+	m.applyQueryPreset(p)
+	if p.Name == "" {
+		m.StatusMessage = "Preset: off"
+	} else {
+		m.StatusMessage = "Preset: " + p.Name
+	}
+	m.StatusIsError = false
+	m.Cursor[PanelTaskList] = 0
+	m.ScrollOffset[PanelTaskList] = 0
+
+	cmds := []tea.Cmd{
+		m.fetchData(),
+		m.saveFilterState(),
+		tea.Tick(2*time.Second, func(t time.Time) tea.Msg { return ClearStatusMsg{} }),
+	}
+	if m.TaskListMode == TaskListModeBoard && m.BoardMode.Board != nil {
+		cmds = append(cmds, m.fetchBoardIssues(m.BoardMode.Board.ID))
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// applyBoardQueryFilter puts the highlighted board's query into the search
+// filter so its issues show in the categorized list without entering board
+// mode. The filter is transient: clearing the search restores the filter it
+// replaced, and it is never saved as the persisted filter state.
+func (m Model) applyBoardQueryFilter() (tea.Model, tea.Cmd) {
+	if !m.BoardPickerOpen || m.BoardPickerCursor >= len(m.AllBoards) {
+		return m, nil
+	}
+	board := m.AllBoards[m.BoardPickerCursor]
+	m.closeBoardPickerModal()
+	if board.Query == "" {
+		m.StatusMessage = "Board " + board.Name + " has no query"
+		m.StatusIsError = true
+		return m, tea.Tick(2*time.Second, func(t time.Time) tea.Msg { return ClearStatusMsg{} })
+	}
+
+	if m.BoardFilter == "" {
+		m.BoardFilterPrevQuery = m.SearchQuery
+		m.BoardFilterPrevPreset = m.QueryPreset
+	}
+	if m.TaskListMode == TaskListModeBoard {
+		m.leaveBoardMode()
+	}
+	m.BoardFilter = board.Name
+	m.applyQueryPreset(QueryPreset{Query: board.Query})
+	m.ActivePanel = PanelTaskList
+	m.Cursor[PanelTaskList] = 0
+	m.ScrollOffset[PanelTaskList] = 0
+	m.StatusMessage = "Filter: board " + board.Name + " (esc restores)"
+	m.StatusIsError = false
+	return m, tea.Batch(
+		m.fetchData(),
+		tea.Tick(2*time.Second, func(t time.Time) tea.Msg { return ClearStatusMsg{} }),
+	)
+}
+
+// restoreBoardFilter puts back the search the board query filter replaced.
+// It reports false when no board query filter is active.
+func (m *Model) restoreBoardFilter() bool {
+	if m.BoardFilter == "" {
+		return false
+	}
+	oldQuery := m.SearchQuery
+	m.SearchQuery = m.BoardFilterPrevQuery
+	m.QueryPreset = m.BoardFilterPrevPreset
+	m.SearchInput.SetValue(m.SearchQuery)
+	m.forgetBoardFilter()
+	if (oldQuery == "") != (m.SearchQuery == "") {
+		m.updatePanelBounds()
+	}
+	return true
+}
+
+// forgetBoardFilter drops the board query filter state, keeping whatever
+// search is current. Used when another search replaces it.
+func (m *Model) forgetBoardFilter() {
+	m.BoardFilter = ""
+	m.BoardFilterPrevQuery = ""
+	m.BoardFilterPrevPreset = ""
+}
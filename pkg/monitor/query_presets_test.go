@@ -0,0 +1,142 @@
+package monitor
+
+import (
+	"testing"
+
+	"charm.land/bubbles/v2/textinput"
+	"github.com/marcus/td/internal/config"
+	"github.com/marcus/td/internal/models"
+	"github.com/marcus/td/pkg/monitor/keymap"
+)
+
+func newPresetTestModel(t *testing.T) Model {
+	t.Helper()
+	return Model{
+		Width:        80,
+		Height:       30,
+		PaneHeights:  config.DefaultPaneHeights(),
+		PanelBounds:  make(map[Panel]Rect),
+		Cursor:       make(map[Panel]int),
+		ScrollOffset: make(map[Panel]int),
+		SelectedID:   make(map[Panel]string),
+		Keymap:       newTestKeymap(),
+		SearchInput:  textinput.New(),
+		BaseDir:      t.TempDir(),
+	}
+}
+
+func TestCycleQueryPresetAppliesQueries(t *testing.T) {
+	m := newPresetTestModel(t)
+
+	want := []struct{ name, query string }{
+		{"my queue", "mine() AND is_ready() AND status != closed"},
+		{"needs review", "status = in_review"},
+		{"rework", "rework()"},
+		{"", ""},
+		{"my queue", "mine() AND is_ready() AND status != closed"},
+	}
+	for i, w := range want {
+		next, _ := m.executeCommand(keymap.CmdCycleQueryPreset)
+		m = next.(Model)
+		if m.QueryPreset != w.name || m.SearchQuery != w.query {
+			t.Fatalf("step %d: preset %q query %q, want %q %q", i, m.QueryPreset, m.SearchQuery, w.name, w.query)
+		}
+		if m.SearchInput.Value() != w.query {
+			t.Fatalf("step %d: search input %q, want %q", i, m.SearchInput.Value(), w.query)
+		}
+	}
+}
+
+func TestQueryPresetKeepsTypeFilterAndPersists(t *testing.T) {
+	m := newPresetTestModel(t)
+	m.TypeFilterMode = TypeFilterBug
+
+	next, _ := m.executeCommand(keymap.CmdCycleQueryPreset)
+	m = next.(Model)
+	if want := "mine() AND is_ready() AND status != closed type=bug"; m.SearchQuery != want {
+		t.Fatalf("query %q, want %q", m.SearchQuery, want)
+	}
+
+	m.saveFilterState()()
+	state, err := config.GetFilterState(m.BaseDir)
+	if err != nil {
+		t.Fatalf("GetFilterState: %v", err)
+	}
+	if state.QueryPreset != "my queue" || state.SearchQuery != m.SearchQuery {
+		t.Fatalf("saved preset %q query %q", state.QueryPreset, state.SearchQuery)
+	}
+
+	// Clearing the search drops the preset
+	next, _ = m.executeCommand(keymap.CmdSearchClear)
+	m = next.(Model)
+	if m.QueryPreset != "" {
+		t.Fatalf("preset %q survived clearing the search", m.QueryPreset)
+	}
+}
+
+func TestApplyBoardQueryFilterRestoresPreviousFilter(t *testing.T) {
+	m := newPresetTestModel(t)
+	next, _ := m.executeCommand(keymap.CmdCycleQueryPreset)
+	m = next.(Model)
+	prevQuery := m.SearchQuery
+
+	m.BoardPickerOpen = true
+	m.AllBoards = []models.Board{
+		{ID: "bd-1", Name: "Empty"},
+		{ID: "bd-2", Name: "Bugs", Query: "type = bug"},
+	}
+	m.BoardPickerCursor = 1
+
+	next, _ = m.executeCommand(keymap.CmdApplyBoardQuery)
+	m = next.(Model)
+	if m.BoardPickerOpen {
+		t.Fatal("board picker still open")
+	}
+	if m.TaskListMode != TaskListModeCategorized {
+		t.Fatalf("task list mode %v, want categorized list", m.TaskListMode)
+	}
+	if m.SearchQuery != "type = bug" || m.QueryPreset != "" || m.BoardFilter != "Bugs" {
+		t.Fatalf("query %q preset %q board filter %q", m.SearchQuery, m.QueryPreset, m.BoardFilter)
+	}
+
+	// The board query is transient: the saved filter keeps the preset
+	m.saveFilterState()()
+	state, err := config.GetFilterState(m.BaseDir)
+	if err != nil {
+		t.Fatalf("GetFilterState: %v", err)
+	}
+	if state.QueryPreset != "my queue" || state.SearchQuery != prevQuery {
+		t.Fatalf("saved preset %q query %q", state.QueryPreset, state.SearchQuery)
+	}
+
+	next, _ = m.executeCommand(keymap.CmdSearchClear)
+	m = next.(Model)
+	if m.SearchQuery != prevQuery || m.QueryPreset != "my queue" || m.BoardFilter != "" {
+		t.Fatalf("after clear: query %q preset %q board filter %q", m.SearchQuery, m.QueryPreset, m.BoardFilter)
+	}
+	if m.SearchInput.Value() != prevQuery {
+		t.Fatalf("search input %q, want %q", m.SearchInput.Value(), prevQuery)
+	}
+
+	// A second clear clears as usual
+	next, _ = m.executeCommand(keymap.CmdSearchClear)
+	m = next.(Model)
+	if m.SearchQuery != "" || m.QueryPreset != "" {
+		t.Fatalf("after second clear: query %q preset %q", m.SearchQuery, m.QueryPreset)
+	}
+}
+
+func TestApplyBoardQueryFilterWithoutQuery(t *testing.T) {
+	m := newPresetTestModel(t)
+	m.BoardPickerOpen = true
+	m.AllBoards = []models.Board{{ID: "bd-1", Name: "Empty"}}
+
+	next, _ := m.executeCommand(keymap.CmdApplyBoardQuery)
+	m = next.(Model)
+	if m.SearchQuery != "" || m.BoardFilter != "" {
+		t.Fatalf("query %q board filter %q, want no filter", m.SearchQuery, m.BoardFilter)
+	}
+	if !m.StatusIsError {
+		t.Fatal("expected an error status for a board without a query")
+	}
+}
@@ -0,0 +1,112 @@
+package monitor
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"github.com/marcus/td/internal/models"
+	"github.com/marcus/td/pkg/monitor/modal"
+	"github.com/marcus/td/pkg/monitor/mouse"
+)
+
+// openQuickAdd opens the one-line quick-add prompt. Same shape as the group
+// prompt: one text input plus Create / Cancel.
+func (m Model) openQuickAdd() (tea.Model, tea.Cmd) {
+	m.QuickAddOpen = true
+
+	m.QuickAddInput = textinput.New()
+	m.QuickAddInput.Placeholder = "Task title"
+	m.QuickAddInput.SetWidth(48)
+	m.QuickAddInput.Focus()
+
+	m.QuickAddModal = m.createQuickAddModal()
+	m.QuickAddModal.Reset()
+	m.QuickAddMouseHandler = mouse.NewHandler()
+	return m, nil
+}
+
+// closeQuickAdd closes the quick-add prompt.
+func (m *Model) closeQuickAdd() {
+	m.QuickAddOpen = false
+	m.QuickAddModal = nil
+	m.QuickAddMouseHandler = nil
+}
+
+// createQuickAddModal builds the declarative modal for the quick-add prompt.
+func (m *Model) createQuickAddModal() *modal.Modal {
+	md := modal.New("Quick add task",
+		modal.WithWidth(64),
+		modal.WithHints(false),
+		modal.WithPrimaryAction("create"),
+	)
+
+	md.AddSection(modal.InputWithLabel("title", "Title:", &m.QuickAddInput,
+		modal.WithSubmitOnEnter(true),
+		modal.WithSubmitAction("create"),
+	))
+	md.AddSection(modal.Spacer())
+	md.AddSection(modal.Buttons(
+		modal.Btn(" Create ", "create"),
+		modal.Btn(" Cancel ", "cancel"),
+	))
+	md.AddSection(modal.Spacer())
+	md.AddSection(modal.Text("Enter:create  Esc:cancel  (n opens the full form)"))
+	return md
+}
+
+// handleQuickAddAction handles button actions on the quick-add prompt.
+func (m Model) handleQuickAddAction(action string) (tea.Model, tea.Cmd) {
+	switch action {
+	case "create":
+		return m.executeQuickAdd()
+	case "cancel":
+		m.closeQuickAdd()
+		return m, nil
+	}
+	return m, nil
+}
+
+// executeQuickAdd creates an open task with the typed title and default type
+// and priority, then selects it once the task list refreshes. An empty title
+// keeps the prompt open.
+func (m Model) executeQuickAdd() (tea.Model, tea.Cmd) {
+	title := strings.TrimSpace(m.QuickAddInput.Value())
+	if title == "" {
+		m.StatusMessage = "Enter a title"
+		m.StatusIsError = true
+		return m, tea.Tick(2*time.Second, func(t time.Time) tea.Msg { return ClearStatusMsg{} })
+	}
+
+	issue := &models.Issue{
+		Title:  title,
+		Type:   models.TypeTask,
+		Status: models.StatusOpen,
+	}
+	if err := m.DB.CreateIssueLogged(issue, m.SessionID); err != nil {
+		m.StatusMessage = "Create failed: " + err.Error()
+		m.StatusIsError = true
+		return m, tea.Tick(3*time.Second, func(t time.Time) tea.Msg { return ClearStatusMsg{} })
+	}
+
+	m.closeQuickAdd()
+	m.ActivePanel = PanelTaskList
+	if m.SelectedID == nil {
+		m.SelectedID = make(map[Panel]string)
+	}
+	m.SelectedID[PanelTaskList] = issue.ID
+	m.StatusMessage = fmt.Sprintf("Created %s", issue.ID)
+	m.StatusIsError = false
+
+	cmds := []tea.Cmd{
+		m.fetchData(),
+		tea.Tick(2*time.Second, func(t time.Time) tea.Msg { return ClearStatusMsg{} }),
+	}
+	if m.TaskListMode == TaskListModeBoard && m.BoardMode.Board != nil {
+		m.BoardMode.PendingSelectionID = issue.ID
+		cmds = append(cmds, m.fetchBoardIssues(m.BoardMode.Board.ID))
+	}
+	return m, tea.Batch(cmds...)
+}
@@ -0,0 +1,67 @@
+package monitor
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/models"
+	"github.com/marcus/td/pkg/monitor/keymap"
+)
+
+// TestQuickAddCreatesTaskWithDefaults opens the quick-add prompt with A,
+// types a title and submits it.
+func TestQuickAddCreatesTaskWithDefaults(t *testing.T) {
+	database, err := db.Initialize(t.TempDir())
+	if err != nil {
+		t.Fatalf("db init: %v", err)
+	}
+	defer database.Close()
+
+	km := newTestKeymap()
+	if cmd, found := km.Lookup(tea.KeyPressMsg{Code: 'A', Text: "A"}, keymap.ContextMain); !found || cmd != keymap.CmdQuickAdd {
+		t.Fatalf("A in main = %v (found=%v), want CmdQuickAdd", cmd, found)
+	}
+
+	m := Model{
+		DB:          database,
+		SessionID:   "ses-1",
+		Keymap:      km,
+		ActivePanel: PanelCurrentWork,
+		SelectedID:  map[Panel]string{},
+		Cursor:      map[Panel]int{},
+	}
+
+	updated, _ := m.executeCommand(keymap.CmdQuickAdd)
+	m = updated.(Model)
+	if !m.QuickAddOpen {
+		t.Fatal("expected quick-add prompt to open")
+	}
+
+	// An empty title keeps the prompt open and creates nothing
+	updated, _ = m.executeQuickAdd()
+	m = updated.(Model)
+	if !m.QuickAddOpen || !m.StatusIsError {
+		t.Fatalf("empty title: open=%v error=%v", m.QuickAddOpen, m.StatusIsError)
+	}
+
+	m.QuickAddInput.SetValue("  Fix the flaky login test  ")
+	updated, _ = m.handleQuickAddAction("create")
+	m = updated.(Model)
+	if m.QuickAddOpen {
+		t.Fatal("expected prompt to close after create")
+	}
+
+	issues, err := database.ListIssues(db.ListIssuesOptions{})
+	if err != nil || len(issues) != 1 {
+		t.Fatalf("ListIssues = %v, %v; want one issue", issues, err)
+	}
+	got := issues[0]
+	if got.Title != "Fix the flaky login test" || got.Status != models.StatusOpen ||
+		got.Type != models.TypeTask || got.Priority != models.PriorityP2 {
+		t.Errorf("created %+v, want open P2 task with trimmed title", got)
+	}
+	if m.ActivePanel != PanelTaskList || m.SelectedID[PanelTaskList] != got.ID {
+		t.Errorf("selection = panel %v id %q, want task list %q", m.ActivePanel, m.SelectedID[PanelTaskList], got.ID)
+	}
+}
@@ -0,0 +1,141 @@
+package monitor
+
+import (
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/marcus/td/internal/models"
+	"github.com/marcus/td/internal/workflow"
+)
+
+// quickPriorities lists priorities from highest to lowest.
+var quickPriorities = []models.Priority{
+	models.PriorityP0, models.PriorityP1, models.PriorityP2, models.PriorityP3, models.PriorityP4,
+}
+
+// nextQuickStatus returns the status the quick status-cycle key moves an
+// issue to: the next status after its current one, in workflow order, that
+// the state machine lets it enter. in_review and closed are skipped since
+// submitting and closing have their own keys and rules. ok is false when
+// no status qualifies.
+func nextQuickStatus(sm *workflow.StateMachine, issue *models.Issue, sessionID string) (models.Status, bool) {
+	statuses := sm.Statuses()
+	start := 0
+	for i, s := range statuses {
+		if s == issue.Status {
+			start = i + 1
+			break
+		}
+	}
+	for i := 0; i < len(statuses); i++ {
+		to := statuses[(start+i)%len(statuses)]
+		if to == issue.Status || to == models.StatusInReview || to == models.StatusClosed {
+			continue
+		}
+		if _, err := sm.Validate(&workflow.TransitionContext{
+			Issue:      issue,
+			FromStatus: issue.Status,
+			ToStatus:   to,
+			SessionID:  sessionID,
+			Context:    workflow.ContextMonitor,
+		}); err == nil {
+			return to, true
+		}
+	}
+	return "", false
+}
+
+// shiftPriority returns p moved delta steps toward P4 (negative deltas
+// raise it), clamped to P0..P4.
+func shiftPriority(p models.Priority, delta int) models.Priority {
+	idx := 2 // unknown priorities start from the P2 default
+	for i, q := range quickPriorities {
+		if q == p {
+			idx = i
+		}
+	}
+	idx = max(0, min(len(quickPriorities)-1, idx+delta))
+	return quickPriorities[idx]
+}
+
+// quickEditIssue loads the issue selected in the active panel for a quick
+// edit, or nil when nothing is selected.
+func (m Model) quickEditIssue() *models.Issue {
+	issueID := m.SelectedIssueID(m.ActivePanel)
+	if issueID == "" || m.DB == nil {
+		return nil
+	}
+	issue, err := m.DB.GetIssue(issueID)
+	if err != nil {
+		return nil
+	}
+	return issue
+}
+
+// cycleIssueStatus moves the selected issue to its next quick status
+// without opening the edit form.
+func (m Model) cycleIssueStatus() (tea.Model, tea.Cmd) {
+	issue := m.quickEditIssue()
+	if issue == nil {
+		return m, nil
+	}
+	from := issue.Status
+	to, ok := nextQuickStatus(m.workflowMachine(), issue, m.SessionID)
+	if !ok {
+		return m.quickEditStatus(fmt.Sprintf("%s: no status change allowed from %s", issue.ID, from), true)
+	}
+
+	action := models.ActionUpdate
+	switch {
+	case to == models.StatusInProgress:
+		action = models.ActionStart
+		if issue.ImplementerSession == "" {
+			issue.ImplementerSession = m.SessionID
+		}
+	case to == models.StatusBlocked:
+		action = models.ActionBlock
+	case from == models.StatusBlocked:
+		action = models.ActionUnblock
+	case from == models.StatusClosed:
+		action = models.ActionReopen
+	}
+	issue.Status = to
+	if err := m.DB.UpdateIssueLogged(issue, m.SessionID, action); err != nil {
+		return m.quickEditStatus("Error: "+err.Error(), true)
+	}
+	return m.quickEditStatus(fmt.Sprintf("%s: %s → %s", issue.ID, from, to), false)
+}
+
+// shiftIssuePriority raises (delta < 0) or lowers (delta > 0) the selected
+// issue's priority by one step without opening the edit form.
+func (m Model) shiftIssuePriority(delta int) (tea.Model, tea.Cmd) {
+	issue := m.quickEditIssue()
+	if issue == nil {
+		return m, nil
+	}
+	from := issue.Priority
+	to := shiftPriority(from, delta)
+	if to == from {
+		return m.quickEditStatus(fmt.Sprintf("%s: already %s", issue.ID, from), false)
+	}
+	issue.Priority = to
+	if err := m.DB.UpdateIssueLogged(issue, m.SessionID, models.ActionUpdate); err != nil {
+		return m.quickEditStatus("Error: "+err.Error(), true)
+	}
+	return m.quickEditStatus(fmt.Sprintf("%s: priority %s → %s", issue.ID, from, to), false)
+}
+
+// quickEditStatus shows the outcome of a quick edit and refreshes the view.
+func (m Model) quickEditStatus(msg string, isError bool) (tea.Model, tea.Cmd) {
+	m.StatusMessage = msg
+	m.StatusIsError = isError
+	cmds := []tea.Cmd{
+		m.fetchData(),
+		tea.Tick(2*time.Second, func(t time.Time) tea.Msg { return ClearStatusMsg{} }),
+	}
+	if m.TaskListMode == TaskListModeBoard && m.BoardMode.Board != nil {
+		cmds = append(cmds, m.fetchBoardIssues(m.BoardMode.Board.ID))
+	}
+	return m, tea.Batch(cmds...)
+}
@@ -0,0 +1,101 @@
+package monitor
+
+import (
+	"testing"
+
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/models"
+	"github.com/marcus/td/pkg/monitor/keymap"
+)
+
+func newQuickEditModel(t *testing.T) (Model, *models.Issue) {
+	t.Helper()
+	database, err := db.Initialize(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	t.Cleanup(func() { database.Close() })
+
+	issue := &models.Issue{Title: "quick", Type: models.TypeTask, Status: models.StatusOpen, Priority: models.PriorityP2}
+	if err := database.CreateIssue(issue); err != nil {
+		t.Fatalf("create issue: %v", err)
+	}
+
+	m := newTestModel()
+	m.DB = database
+	m.TaskListRows = []TaskListRow{{Issue: *issue}}
+	return m, issue
+}
+
+func TestQuickStatusCycleUpdatesIssue(t *testing.T) {
+	m, issue := newQuickEditModel(t)
+
+	// in_review and closed are left to their own keys
+	for _, want := range []models.Status{models.StatusInProgress, models.StatusBlocked, models.StatusOpen} {
+		next, cmd := m.executeCommand(keymap.CmdCycleIssueStatus)
+		m = next.(Model)
+		if cmd == nil {
+			t.Fatal("expected a refresh command")
+		}
+		got, err := m.DB.GetIssue(issue.ID)
+		if err != nil {
+			t.Fatalf("GetIssue: %v", err)
+		}
+		if got.Status != want {
+			t.Fatalf("status = %s, want %s", got.Status, want)
+		}
+		if m.StatusIsError || m.StatusMessage == "" {
+			t.Fatalf("status bar = %q (error %v)", m.StatusMessage, m.StatusIsError)
+		}
+		m.TaskListRows[0].Issue = *got
+	}
+
+	got, _ := m.DB.GetIssue(issue.ID)
+	if got.ImplementerSession != m.SessionID {
+		t.Errorf("implementer = %q, want %q after starting", got.ImplementerSession, m.SessionID)
+	}
+}
+
+func TestQuickStatusCycleRespectsTransitions(t *testing.T) {
+	m, issue := newQuickEditModel(t)
+	issue.Status = models.StatusClosed
+	if err := m.DB.UpdateIssue(issue); err != nil {
+		t.Fatalf("UpdateIssue: %v", err)
+	}
+
+	// Closed issues only leave closed by reopening
+	if to, ok := nextQuickStatus(m.workflowMachine(), issue, m.SessionID); !ok || to != models.StatusOpen {
+		t.Fatalf("next status from closed = %q, %v; want open", to, ok)
+	}
+
+	issue.Status = models.StatusInReview
+	to, ok := nextQuickStatus(m.workflowMachine(), issue, m.SessionID)
+	if !ok || to == models.StatusClosed || to == models.StatusInReview {
+		t.Fatalf("next status from in_review = %q, %v", to, ok)
+	}
+}
+
+func TestQuickPriorityShift(t *testing.T) {
+	m, issue := newQuickEditModel(t)
+
+	next, _ := m.executeCommand(keymap.CmdRaiseIssuePriority)
+	m = next.(Model)
+	if got, _ := m.DB.GetIssue(issue.ID); got.Priority != models.PriorityP1 {
+		t.Fatalf("priority = %s after raise, want P1", got.Priority)
+	}
+
+	next, _ = m.executeCommand(keymap.CmdLowerIssuePriority)
+	m = next.(Model)
+	next, _ = m.executeCommand(keymap.CmdLowerIssuePriority)
+	m = next.(Model)
+	if got, _ := m.DB.GetIssue(issue.ID); got.Priority != models.PriorityP3 {
+		t.Fatalf("priority = %s after two lowers, want P3", got.Priority)
+	}
+
+	if got := shiftPriority(models.PriorityP0, -1); got != models.PriorityP0 {
+		t.Errorf("raising P0 = %s, want P0", got)
+	}
+	if got := shiftPriority(models.PriorityP4, 1); got != models.PriorityP4 {
+		t.Errorf("lowering P4 = %s, want P4", got)
+	}
+}
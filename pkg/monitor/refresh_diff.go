@@ -0,0 +1,82 @@
+package monitor
+
+import (
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// taskListDiff counts how the visible task list rows differ between two fetches.
+type taskListDiff struct {
+	Added   int
+	Removed int
+	Changed int
+}
+
+// Total returns the number of rows that differ.
+func (d taskListDiff) Total() int {
+	return d.Added + d.Removed + d.Changed
+}
+
+// diffTaskListRows compares the currently displayed rows against freshly
+// fetched rows. A row counts as changed when its issue was updated or moved
+// to another category.
+func diffTaskListRows(current, fetched []TaskListRow) taskListDiff {
+	var d taskListDiff
+	old := make(map[string]TaskListRow, len(current))
+	for _, row := range current {
+		old[row.Issue.ID] = row
+	}
+	seen := make(map[string]bool, len(fetched))
+	for _, row := range fetched {
+		seen[row.Issue.ID] = true
+		prev, ok := old[row.Issue.ID]
+		if !ok {
+			d.Added++
+			continue
+		}
+		if prev.Category != row.Category ||
+			prev.Issue.Status != row.Issue.Status ||
+			!prev.Issue.UpdatedAt.Equal(row.Issue.UpdatedAt) {
+			d.Changed++
+		}
+	}
+	for id := range old {
+		if !seen[id] {
+			d.Removed++
+		}
+	}
+	return d
+}
+
+// deferPolledTaskList holds back a polled task list that differs from the one
+// on screen, so rows don't shift under the cursor while another agent or a
+// sync pull is changing issues. The user is told how many rows changed and
+// applies them with a manual refresh. Returns true if the task list was held.
+func (m *Model) deferPolledTaskList(msg RefreshDataMsg) (bool, tea.Cmd) {
+	if !msg.Poll || m.LastRefresh.IsZero() {
+		return false, nil
+	}
+	diff := diffTaskListRows(m.TaskListRows, taskListRows(msg.TaskList))
+	if diff.Total() == 0 {
+		return false, nil
+	}
+
+	// Only re-announce when the pending change set differs, to avoid
+	// re-arming the toast on every poll.
+	if diff == m.PendingRowChanges {
+		return true, nil
+	}
+	m.PendingRowChanges = diff
+
+	noun := "issues"
+	if diff.Total() == 1 {
+		noun = "issue"
+	}
+	m.StatusMessage = fmt.Sprintf("%d %s updated, ctrl+r to refresh", diff.Total(), noun)
+	m.StatusIsError = false
+	return true, tea.Tick(5*time.Second, func(t time.Time) tea.Msg {
+		return ClearStatusMsg{}
+	})
+}
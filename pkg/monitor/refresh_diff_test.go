@@ -0,0 +1,110 @@
+package monitor
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/marcus/td/internal/models"
+)
+
+func TestDiffTaskListRows(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	row := func(id string, status models.Status, cat TaskListCategory, updated time.Time) TaskListRow {
+		return TaskListRow{Issue: models.Issue{ID: id, Status: status, UpdatedAt: updated}, Category: cat}
+	}
+
+	current := []TaskListRow{
+		row("td-1", models.StatusOpen, CategoryReady, t0),
+		row("td-2", models.StatusOpen, CategoryReady, t0),
+		row("td-3", models.StatusInProgress, CategoryInProgress, t0),
+		row("td-4", models.StatusOpen, CategoryReady, t0),
+	}
+
+	tests := []struct {
+		name    string
+		fetched []TaskListRow
+		want    taskListDiff
+	}{
+		{
+			name:    "unchanged",
+			fetched: current,
+			want:    taskListDiff{},
+		},
+		{
+			name: "added, removed and changed",
+			fetched: []TaskListRow{
+				row("td-1", models.StatusOpen, CategoryReady, t0),
+				row("td-2", models.StatusOpen, CategoryReady, t0.Add(time.Minute)), // edited
+				row("td-3", models.StatusInReview, CategoryReviewable, t0),         // moved
+				row("td-5", models.StatusOpen, CategoryReady, t0),                  // new
+				row("td-6", models.StatusOpen, CategoryReady, t0),                  // new
+			},
+			want: taskListDiff{Added: 2, Removed: 1, Changed: 2},
+		},
+		{
+			name:    "all removed",
+			fetched: nil,
+			want:    taskListDiff{Removed: 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := diffTaskListRows(current, tt.fetched)
+			if got != tt.want {
+				t.Errorf("diffTaskListRows() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolledRefreshHoldsChangedTaskList(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	initial := TaskListData{Ready: []models.Issue{{ID: "td-1", Status: models.StatusOpen, UpdatedAt: t0}}}
+	m := Model{
+		Keymap:     newTestKeymap(),
+		Cursor:     make(map[Panel]int),
+		SelectedID: make(map[Panel]string),
+	}
+
+	updated, _ := m.Update(RefreshDataMsg{TaskList: initial, Timestamp: t0})
+	m = updated.(Model)
+	if len(m.TaskListRows) != 1 {
+		t.Fatalf("initial refresh: got %d rows, want 1", len(m.TaskListRows))
+	}
+
+	changed := TaskListData{Ready: []models.Issue{
+		{ID: "td-1", Status: models.StatusOpen, UpdatedAt: t0.Add(time.Minute)},
+		{ID: "td-2", Status: models.StatusOpen, UpdatedAt: t0},
+	}}
+
+	// Polled refresh: rows stay put, toast announces the change
+	updated, cmd := m.Update(RefreshDataMsg{TaskList: changed, Timestamp: t0.Add(time.Minute), Poll: true})
+	m = updated.(Model)
+	if len(m.TaskListRows) != 1 {
+		t.Errorf("polled refresh applied rows: got %d, want 1", len(m.TaskListRows))
+	}
+	if !strings.Contains(m.StatusMessage, "2 issues updated") {
+		t.Errorf("StatusMessage = %q, want toast about 2 issues", m.StatusMessage)
+	}
+	if cmd == nil {
+		t.Error("expected a command to clear the toast")
+	}
+
+	// Same pending change on the next poll doesn't re-arm the toast
+	_, cmd = m.Update(RefreshDataMsg{TaskList: changed, Timestamp: t0.Add(2 * time.Minute), Poll: true})
+	if cmd != nil {
+		t.Error("expected no new toast for an unchanged pending set")
+	}
+
+	// Manual refresh applies the data
+	updated, _ = m.Update(RefreshDataMsg{TaskList: changed, Timestamp: t0.Add(2 * time.Minute)})
+	m = updated.(Model)
+	if len(m.TaskListRows) != 2 {
+		t.Errorf("manual refresh: got %d rows, want 2", len(m.TaskListRows))
+	}
+	if m.PendingRowChanges.Total() != 0 {
+		t.Errorf("PendingRowChanges = %+v, want cleared", m.PendingRowChanges)
+	}
+}
@@ -0,0 +1,68 @@
+package monitor
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/models"
+)
+
+// fetchRelatedIssues returns the issues linked to issueID by td relate,
+// whichever side the link was made from: open ones first, then closed,
+// each in ID order. Deleted issues are skipped.
+func fetchRelatedIssues(database *db.DB, issueID string) []models.Issue {
+	ids, err := database.GetRelated(issueID)
+	if err != nil || len(ids) == 0 {
+		return nil
+	}
+	issues, err := database.GetIssuesByIDs(ids)
+	if err != nil {
+		return nil
+	}
+
+	related := make([]models.Issue, 0, len(issues))
+	for _, issue := range issues {
+		if issue.DeletedAt == nil {
+			related = append(related, issue)
+		}
+	}
+	sort.Slice(related, func(i, j int) bool {
+		ci, cj := related[i].Status == models.StatusClosed, related[j].Status == models.StatusClosed
+		if ci != cj {
+			return !ci
+		}
+		return related[i].ID < related[j].ID
+	})
+	return related
+}
+
+// renderRelatedSection renders the modal's RELATED section, focusable and
+// navigable like the blocks section.
+func renderRelatedSection(modal *ModalEntry, contentWidth int) []string {
+	if len(modal.Related) == 0 {
+		return nil
+	}
+	header := fmt.Sprintf("RELATED (%d)", len(modal.Related))
+	if modal.RelatedSectionFocused {
+		header = relatedSectionFocusedStyle.Render(header + " [j/k:nav Enter:open Tab:next]")
+	} else {
+		header = sectionHeader.Render(header + " [Tab:focus]")
+	}
+	lines := []string{header}
+
+	for i, issue := range modal.Related {
+		line := fmt.Sprintf("%s %s %s %s",
+			formatTypeIcon(issue.Type),
+			titleStyle.Render(issue.ID),
+			formatStatus(issue.Status),
+			truncateString(issue.Title, contentWidth-24))
+		if modal.RelatedSectionFocused && i == modal.RelatedCursor {
+			line = relatedSelectedStyle.Render("> " + line)
+		} else {
+			line = "  " + line
+		}
+		lines = append(lines, line)
+	}
+	return append(lines, "")
+}
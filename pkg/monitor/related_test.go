@@ -0,0 +1,58 @@
+package monitor
+
+import (
+	"testing"
+
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/models"
+)
+
+func TestFetchRelatedIssues(t *testing.T) {
+	database, err := db.Initialize(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	defer database.Close()
+
+	a := createTestIssue(t, database, "A", models.StatusOpen)
+	b := createTestIssue(t, database, "B", models.StatusClosed)
+	c := createTestIssue(t, database, "C", models.StatusInProgress)
+	d := createTestIssue(t, database, "D (deleted)", models.StatusOpen)
+	blocker := createTestIssue(t, database, "Blocker", models.StatusOpen)
+
+	// Links made from either side show up on both
+	for _, rel := range [][2]string{{a.ID, b.ID}, {c.ID, a.ID}, {a.ID, d.ID}} {
+		if err := database.AddRelation(rel[0], rel[1], "ses_test"); err != nil {
+			t.Fatalf("AddRelation(%s, %s): %v", rel[0], rel[1], err)
+		}
+	}
+	if err := database.AddDependency(a.ID, blocker.ID, "depends_on"); err != nil {
+		t.Fatalf("AddDependency: %v", err)
+	}
+	if err := database.DeleteIssue(d.ID); err != nil {
+		t.Fatalf("DeleteIssue: %v", err)
+	}
+
+	ids := func(issues []models.Issue) []string {
+		var out []string
+		for _, issue := range issues {
+			out = append(out, issue.ID)
+		}
+		return out
+	}
+
+	// Open issues first, the deleted one and the blocker left out
+	got := ids(fetchRelatedIssues(database, a.ID))
+	if len(got) != 2 || got[0] != c.ID || got[1] != b.ID {
+		t.Errorf("related to A = %v, want [%s %s]", got, c.ID, b.ID)
+	}
+	if got := ids(fetchRelatedIssues(database, b.ID)); len(got) != 1 || got[0] != a.ID {
+		t.Errorf("related to B = %v, want [%s]", got, a.ID)
+	}
+	if got := ids(fetchRelatedIssues(database, c.ID)); len(got) != 1 || got[0] != a.ID {
+		t.Errorf("related to C = %v, want [%s]", got, a.ID)
+	}
+	if got := fetchRelatedIssues(database, blocker.ID); len(got) != 0 {
+		t.Errorf("related to blocker = %v, want none", ids(got))
+	}
+}
@@ -0,0 +1,84 @@
+package monitor
+
+import (
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/marcus/td/internal/db"
+	"github.com/marcus/td/internal/session"
+)
+
+// highlightingSearch reports whether the current search highlights matching
+// rows in the full task list rather than filtering the list down to them.
+func (m Model) highlightingSearch() bool {
+	return m.SearchHighlight && m.SearchQuery != ""
+}
+
+// fetchSearchMatches returns the IDs of the task list issues matching
+// searchQuery, fetched the same way a filtering search would list them.
+func fetchSearchMatches(database *db.DB, sessionID, searchQuery, searchMode string, includeClosed bool, sortMode SortMode) map[string]bool {
+	if sess, err := session.GetOrCreate(database); err == nil {
+		sessionID = sess.ID
+	}
+	data := fetchTaskListWithMode(database, sessionID, searchQuery, searchMode, includeClosed, sortMode, resolveMonitorPolicyMode(database.BaseDir()))
+
+	matches := make(map[string]bool)
+	for _, row := range taskListRows(data) {
+		matches[row.Issue.ID] = true
+	}
+	return matches
+}
+
+// searchMatchRows returns the indices into TaskListRows of the rows matching
+// a highlighting search, in list order.
+func (m Model) searchMatchRows() []int {
+	var rows []int
+	for i, row := range m.TaskListRows {
+		if m.TaskList.Matches[row.Issue.ID] {
+			rows = append(rows, i)
+		}
+	}
+	return rows
+}
+
+// nextMatchIndex returns the position in matches (sorted row indices) of the
+// match after cursor, or before it when forward is false, wrapping around at
+// either end. It returns -1 when there are no matches.
+func nextMatchIndex(matches []int, cursor int, forward bool) int {
+	if len(matches) == 0 {
+		return -1
+	}
+	if forward {
+		for i, row := range matches {
+			if row > cursor {
+				return i
+			}
+		}
+		return 0
+	}
+	for i := len(matches) - 1; i >= 0; i-- {
+		if matches[i] < cursor {
+			return i
+		}
+	}
+	return len(matches) - 1
+}
+
+// jumpToSearchMatch moves the task list cursor to the next or previous
+// highlighted search match.
+func (m Model) jumpToSearchMatch(forward bool) (tea.Model, tea.Cmd) {
+	matches := m.searchMatchRows()
+	i := nextMatchIndex(matches, m.Cursor[PanelTaskList], forward)
+	if i < 0 {
+		m.StatusMessage = "No matches"
+	} else {
+		m.ActivePanel = PanelTaskList
+		m.Cursor[PanelTaskList] = matches[i]
+		m.saveSelectedID(PanelTaskList)
+		m.ensureCursorVisible(PanelTaskList)
+		m.StatusMessage = fmt.Sprintf("Match %d of %d", i+1, len(matches))
+	}
+	m.StatusIsError = false
+	return m, tea.Tick(2*time.Second, func(t time.Time) tea.Msg { return ClearStatusMsg{} })
+}
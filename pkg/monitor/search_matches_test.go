@@ -0,0 +1,83 @@
+package monitor
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/marcus/td/internal/models"
+)
+
+func TestNextMatchIndex(t *testing.T) {
+	matches := []int{2, 5, 9}
+	tests := []struct {
+		name    string
+		matches []int
+		cursor  int
+		forward bool
+		want    int
+	}{
+		{"next from before first", matches, 0, true, 0},
+		{"next from a match", matches, 2, true, 1},
+		{"next between matches", matches, 6, true, 2},
+		{"next wraps from last", matches, 9, true, 0},
+		{"next wraps from past last", matches, 12, true, 0},
+		{"prev from a match", matches, 5, false, 0},
+		{"prev between matches", matches, 7, false, 1},
+		{"prev wraps from first", matches, 2, false, 2},
+		{"prev wraps from before first", matches, 0, false, 2},
+		{"single match next", []int{4}, 4, true, 0},
+		{"single match prev", []int{4}, 4, false, 0},
+		{"no matches", nil, 3, true, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextMatchIndex(tt.matches, tt.cursor, tt.forward); got != tt.want {
+				t.Errorf("nextMatchIndex(%v, %d, %v) = %d, want %d", tt.matches, tt.cursor, tt.forward, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchMatchKeysJumpBetweenHighlightedRows(t *testing.T) {
+	m := Model{
+		Keymap:          newTestKeymap(),
+		ActivePanel:     PanelTaskList,
+		Cursor:          make(map[Panel]int),
+		SelectedID:      make(map[Panel]string),
+		ScrollOffset:    make(map[Panel]int),
+		SearchQuery:     "bug",
+		SearchHighlight: true,
+		Height:          40,
+		Width:           120,
+		TaskList: TaskListData{
+			Ready: []models.Issue{
+				{ID: "td-1"}, {ID: "td-2"}, {ID: "td-3"}, {ID: "td-4"},
+			},
+			Matches: map[string]bool{"td-2": true, "td-4": true},
+		},
+	}
+	m.buildTaskListRows()
+
+	press := func(text string) {
+		t.Helper()
+		next, _ := m.handleKey(tea.KeyPressMsg{Code: rune(text[0]), Text: text})
+		m = next.(Model)
+	}
+
+	press("n")
+	if got := m.SelectedIssueID(PanelTaskList); got != "td-2" {
+		t.Fatalf("after n selected %q, want td-2", got)
+	}
+	press("n")
+	press("n")
+	if got := m.SelectedIssueID(PanelTaskList); got != "td-2" {
+		t.Fatalf("n did not wrap: selected %q, want td-2", got)
+	}
+	press("N")
+	if got := m.SelectedIssueID(PanelTaskList); got != "td-4" {
+		t.Fatalf("N did not wrap: selected %q, want td-4", got)
+	}
+	if len(m.TaskListRows) != 4 {
+		t.Errorf("task list has %d rows, want all 4 kept", len(m.TaskListRows))
+	}
+}
@@ -0,0 +1,98 @@
+package monitor
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/marcus/td/pkg/monitor/modal"
+	"github.com/marcus/td/pkg/monitor/mouse"
+)
+
+// defaultStatusHistoryLimit is how many status messages are kept when
+// Model.StatusHistoryLimit is unset.
+const defaultStatusHistoryLimit = 50
+
+// StatusHistoryEntry is one status message as it appeared in the footer toast.
+type StatusHistoryEntry struct {
+	Time    time.Time
+	Message string
+	IsError bool
+}
+
+// statusHistoryLimit returns the configured history cap, or the default.
+func (m *Model) statusHistoryLimit() int {
+	if m.StatusHistoryLimit > 0 {
+		return m.StatusHistoryLimit
+	}
+	return defaultStatusHistoryLimit
+}
+
+// recordStatus appends a status message to the history, dropping the oldest
+// entries beyond the cap.
+func (m *Model) recordStatus(message string, isError bool) {
+	m.StatusHistory = append(m.StatusHistory, StatusHistoryEntry{
+		Time:    time.Now(),
+		Message: message,
+		IsError: isError,
+	})
+	if over := len(m.StatusHistory) - m.statusHistoryLimit(); over > 0 {
+		m.StatusHistory = append([]StatusHistoryEntry(nil), m.StatusHistory[over:]...)
+	}
+}
+
+// openStatusHistory opens the message history pane.
+func (m Model) openStatusHistory() (tea.Model, tea.Cmd) {
+	m.StatusHistoryOpen = true
+	m.StatusHistoryModal = m.createStatusHistoryModal()
+	m.StatusHistoryModal.Reset()
+	m.StatusHistoryMouseHandler = mouse.NewHandler()
+	return m, nil
+}
+
+// closeStatusHistory closes the message history pane.
+func (m *Model) closeStatusHistory() {
+	m.StatusHistoryOpen = false
+	m.StatusHistoryModal = nil
+	m.StatusHistoryMouseHandler = nil
+}
+
+// createStatusHistoryModal builds the message history pane, newest first.
+// The entries are snapshotted when the pane opens.
+func (m *Model) createStatusHistoryModal() *modal.Modal {
+	entries := append([]StatusHistoryEntry(nil), m.StatusHistory...)
+	maxLines := m.Height - 10
+	if maxLines < 5 {
+		maxLines = 5
+	}
+
+	md := modal.New(fmt.Sprintf("Messages (%d)", len(entries)),
+		modal.WithWidth(80),
+		modal.WithHints(false),
+	)
+	md.AddSection(modal.Custom(func(contentWidth int, focusID, hoverID string) modal.RenderedSection {
+		if len(entries) == 0 {
+			return modal.RenderedSection{Content: subtleStyle.Render("No messages yet")}
+		}
+		var lines []string
+		for i := len(entries) - 1; i >= 0 && len(lines) < maxLines; i-- {
+			e := entries[i]
+			stamp := timestampStyle.Render(e.Time.Format("15:04:05"))
+			text := truncateString(e.Message, contentWidth-12)
+			if e.IsError {
+				text = errorStyle.Render("✗ " + text)
+			} else {
+				text = "  " + text
+			}
+			lines = append(lines, stamp+" "+text)
+		}
+		if hidden := len(entries) - len(lines); hidden > 0 {
+			lines = append(lines, subtleStyle.Render(fmt.Sprintf("… %d older", hidden)))
+		}
+		return modal.RenderedSection{Content: strings.Join(lines, "\n")}
+	}, nil))
+	md.AddSection(modal.Spacer())
+	md.AddSection(modal.Text("M/Esc:close"))
+	return md
+}
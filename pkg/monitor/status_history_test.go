@@ -0,0 +1,54 @@
+package monitor
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestStatusHistoryAccumulatesWithCap(t *testing.T) {
+	m := Model{Keymap: newTestKeymap(), StatusHistoryLimit: 3}
+
+	next := m
+	for i := 1; i <= 5; i++ {
+		updated, _ := next.Update(InstallInstructionsResultMsg{
+			Success: i%2 == 1,
+			Message: fmt.Sprintf("message %d", i),
+		})
+		next = updated.(Model)
+	}
+
+	if len(next.StatusHistory) != 3 {
+		t.Fatalf("expected history capped at 3, got %d", len(next.StatusHistory))
+	}
+	for i, want := range []string{"message 3", "message 4", "message 5"} {
+		if got := next.StatusHistory[i].Message; got != want {
+			t.Errorf("entry %d: expected %q, got %q", i, want, got)
+		}
+	}
+	if next.StatusHistory[0].IsError || !next.StatusHistory[1].IsError {
+		t.Error("expected error flags to be recorded per message")
+	}
+	if next.StatusHistory[2].Time.IsZero() {
+		t.Error("expected entries to be timestamped")
+	}
+
+	// Clearing the toast keeps the history
+	updated, _ := next.Update(ClearStatusMsg{})
+	next = updated.(Model)
+	if next.StatusMessage != "" || len(next.StatusHistory) != 3 {
+		t.Errorf("expected cleared toast with history intact, got %q / %d entries", next.StatusMessage, len(next.StatusHistory))
+	}
+}
+
+func TestStatusHistoryDefaultLimit(t *testing.T) {
+	m := Model{}
+	for i := 0; i < defaultStatusHistoryLimit+10; i++ {
+		m.recordStatus(fmt.Sprintf("message %d", i), false)
+	}
+	if len(m.StatusHistory) != defaultStatusHistoryLimit {
+		t.Fatalf("expected %d entries, got %d", defaultStatusHistoryLimit, len(m.StatusHistory))
+	}
+	if got := m.StatusHistory[0].Message; got != "message 10" {
+		t.Errorf("expected oldest kept entry to be message 10, got %q", got)
+	}
+}
@@ -142,6 +142,15 @@ var (
 				Background(lipgloss.Color("237")).
 				Foreground(lipgloss.Color("255"))
 
+	relatedSectionFocusedStyle = lipgloss.NewStyle().
+					Bold(true).
+					Foreground(lipgloss.Color("141")). // Purple when focused
+					MarginTop(1)
+
+	relatedSelectedStyle = lipgloss.NewStyle().
+				Background(lipgloss.Color("237")).
+				Foreground(lipgloss.Color("255"))
+
 	// Breadcrumb style for stacked modals
 	breadcrumbStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("244")).
@@ -357,7 +366,18 @@ func renderButtonPair(leftLabel, rightLabel string, leftFocused, rightFocused, l
 
 // highlightRow applies selection highlight to entire row width, preserving text colors
 func highlightRow(line string, width int) string {
-	bgCode := "\x1b[48;5;237m" // Background color 237
+	return paintRow(line, width, "\x1b[48;5;237m") // Background color 237
+}
+
+// searchMatchRow marks a row matching a highlighting search, with a warmer
+// background than the cursor row so the two stay distinguishable.
+func searchMatchRow(line string, width int) string {
+	return paintRow(line, width, "\x1b[48;5;58m") // Background color 58
+}
+
+// paintRow applies a full-width background to a line that already contains
+// ANSI codes, truncating it to width.
+func paintRow(line string, width int, bgCode string) string {
 	reset := "\x1b[0m"
 
 	// First, truncate if line is too wide (ANSI-aware truncation)
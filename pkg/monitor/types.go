@@ -4,6 +4,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/marcus/td/internal/db"
 	"github.com/marcus/td/internal/models"
 	"github.com/marcus/td/internal/syncclient"
 )
@@ -68,6 +69,7 @@ const (
 	SortByPriority    SortMode = iota // Default: priority ASC
 	SortByCreatedDesc                 // created_at DESC (newest first)
 	SortByUpdatedDesc                 // updated_at DESC (recently changed first)
+	SortByStaleness                   // longest in current status first
 )
 
 // String returns display name for sort mode
@@ -77,6 +79,8 @@ func (s SortMode) String() string {
 		return "created"
 	case SortByUpdatedDesc:
 		return "updated"
+	case SortByStaleness:
+		return "stale"
 	default:
 		return "priority"
 	}
@@ -89,6 +93,8 @@ func SortModeFromString(s string) SortMode {
 		return SortByCreatedDesc
 	case "updated":
 		return SortByUpdatedDesc
+	case "stale":
+		return SortByStaleness
 	default:
 		return SortByPriority
 	}
@@ -101,18 +107,25 @@ func (s SortMode) ToDBOptions() (sortBy string, sortDesc bool) {
 		return "created_at", true
 	case SortByUpdatedDesc:
 		return "updated_at", true
+	case SortByStaleness:
+		// Refined by time in status once the issues are loaded
+		return "updated_at", false
 	default:
 		return "priority", false
 	}
 }
 
-// ToSortClause returns the TDQ sort clause string for this mode
+// ToSortClause returns the TDQ sort clause string for this mode. Staleness
+// has no TDQ field, so it has no clause; the task list is sorted after
+// loading instead.
 func (s SortMode) ToSortClause() string {
 	switch s {
 	case SortByCreatedDesc:
 		return "sort:-created"
 	case SortByUpdatedDesc:
 		return "sort:-updated"
+	case SortByStaleness:
+		return ""
 	default:
 		return "sort:priority"
 	}
@@ -134,6 +147,9 @@ func updateQuerySort(query string, sortMode SortMode) string {
 	}
 
 	// Rebuild query with new sort clause
+	if sortClause == "" {
+		return strings.Join(filtered, " ")
+	}
 	if len(filtered) == 0 {
 		return sortClause
 	}
@@ -291,6 +307,20 @@ type TaskListData struct {
 	PendingOther []models.Issue
 	Blocked      []models.Issue
 	Closed       []models.Issue
+	// ReworkIDs is the set of issues matching rework(): open or in_progress
+	// issues rejected since their last review or approval. In-progress ones
+	// are bucketed under NeedsRework; open ones stay in Ready/Blocked, so the
+	// views use this set to flag them wherever they are listed.
+	ReworkIDs map[string]bool
+	// StatusSince maps each listed issue to when it entered its current
+	// status, for the time-in-status column and the staleness sort.
+	StatusSince map[string]time.Time
+	// ExpiredClaimIDs is the set of in_progress issues whose implementer
+	// session has been idle past the claim lease (see claimLeaseTTL).
+	ExpiredClaimIDs map[string]bool
+	// Matches is the set of listed issues matching the search when it
+	// highlights rows rather than filtering them; nil otherwise.
+	Matches map[string]bool
 }
 
 // TaskListRow represents a single selectable row in the task list panel
@@ -322,9 +352,13 @@ type ModalEntry struct {
 	Issue        *models.Issue
 	Handoff      *models.Handoff
 	Logs         []models.Log
+	LogGroups    []logGroup // Logs grouped by work session for display
 	Comments     []models.Comment
 	BlockedBy    []models.Issue
 	Blocks       []models.Issue
+	Related      []models.Issue
+	BlockerChain []BlockerChainEntry
+	FileOverlaps []db.FileOverlap
 	DescRender   string
 	AcceptRender string
 
@@ -349,11 +383,17 @@ type ModalEntry struct {
 	BlocksSectionFocused bool
 	BlocksCursor         int
 
+	// Related section (relates_to links, which never block)
+	RelatedSectionFocused bool
+	RelatedCursor         int
+
 	// Line tracking for mouse click support (set during render)
 	BlockedByStartLine int // Line index where blocked-by section starts
 	BlockedByEndLine   int // Line index where blocked-by section ends
 	BlocksStartLine    int // Line index where blocks section starts
 	BlocksEndLine      int // Line index where blocks section ends
+	RelatedStartLine   int // Line index where related section starts
+	RelatedEndLine     int // Line index where related section ends
 }
 
 // Minimum dimensions for the monitor
@@ -373,7 +413,9 @@ type RefreshDataMsg struct {
 	TaskList       TaskListData
 	RecentHandoffs []RecentHandoff
 	ActiveSessions []string
+	CanUndo        bool // the session has an action td undo can revert
 	Timestamp      time.Time
+	Poll           bool // true when fetched by the periodic tick rather than a user action
 }
 
 // IssueDetailsMsg carries fetched issue details for the modal
@@ -382,11 +424,15 @@ type IssueDetailsMsg struct {
 	Issue      *models.Issue
 	Handoff    *models.Handoff
 	Logs       []models.Log
+	LogGroups  []logGroup // Logs grouped by work session
 	Comments   []models.Comment
-	BlockedBy  []models.Issue // Dependencies (issues blocking this one)
-	Blocks     []models.Issue // Dependents (issues blocked by this one)
-	EpicTasks  []models.Issue // Child tasks (when issue is an epic)
-	ParentEpic *models.Issue  // Parent epic (when issue.ParentID is set)
+	BlockedBy  []models.Issue      // Dependencies (issues blocking this one)
+	Blocks     []models.Issue      // Dependents (issues blocked by this one)
+	Related    []models.Issue      // Issues linked by td relate
+	Chain      []BlockerChainEntry // Transitive dependencies with depth and status
+	Overlaps   []db.FileOverlap    // Open issues linking the same files
+	EpicTasks  []models.Issue      // Child tasks (when issue is an epic)
+	ParentEpic *models.Issue       // Parent epic (when issue.ParentID is set)
 	Error      error
 }
 
@@ -496,17 +542,30 @@ type BoardMode struct {
 
 	// Selection restoration after move operations
 	PendingSelectionID string // Issue ID to select after refresh (cleared after use)
+
+	// Mouse drag-to-reorder state
+	Drag BoardDrag
 }
 
-// DefaultBoardStatusFilter returns the default status filter (closed hidden)
-func DefaultBoardStatusFilter() map[models.Status]bool {
-	return map[models.Status]bool{
+// DefaultBoardStatusFilter returns the default status filter (closed
+// hidden, the project's custom workflow statuses shown)
+func DefaultBoardStatusFilter(custom []models.Status) map[models.Status]bool {
+	return withCustomStatuses(custom, map[models.Status]bool{
 		models.StatusOpen:       true,
 		models.StatusInProgress: true,
 		models.StatusBlocked:    true,
 		models.StatusInReview:   true,
 		models.StatusClosed:     false,
+	})
+}
+
+// withCustomStatuses marks the custom workflow statuses visible in a status
+// filter.
+func withCustomStatuses(custom []models.Status, filter map[models.Status]bool) map[models.Status]bool {
+	for _, status := range custom {
+		filter[status] = true
 	}
+	return filter
 }
 
 // StatusFilterPreset represents a status filter preset for cycling
@@ -556,17 +615,18 @@ func StatusFilterMapToSlice(filter map[models.Status]bool) []models.Status {
 	return result
 }
 
-// ToFilter converts a preset to a status filter map
-func (p StatusFilterPreset) ToFilter() map[models.Status]bool {
+// ToFilter converts a preset to a status filter map; custom are the
+// project's custom workflow statuses, shown by the default and all presets
+func (p StatusFilterPreset) ToFilter(custom []models.Status) map[models.Status]bool {
 	switch p {
 	case StatusPresetAll:
-		return map[models.Status]bool{
+		return withCustomStatuses(custom, map[models.Status]bool{
 			models.StatusOpen:       true,
 			models.StatusInProgress: true,
 			models.StatusBlocked:    true,
 			models.StatusInReview:   true,
 			models.StatusClosed:     true,
-		}
+		})
 	case StatusPresetOpen:
 		return map[models.Status]bool{
 			models.StatusOpen:       true,
@@ -608,7 +668,7 @@ func (p StatusFilterPreset) ToFilter() map[models.Status]bool {
 			models.StatusClosed:     true,
 		}
 	default:
-		return DefaultBoardStatusFilter()
+		return DefaultBoardStatusFilter(custom)
 	}
 }
 
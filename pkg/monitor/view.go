@@ -11,6 +11,7 @@ import (
 	"github.com/charmbracelet/x/ansi"
 	"github.com/charmbracelet/x/cellbuf"
 	"github.com/marcus/td/internal/models"
+	"github.com/marcus/td/internal/output"
 )
 
 // renderView renders the complete TUI view
@@ -62,7 +63,12 @@ func (m Model) renderView() string {
 	// Overlay form modal if open
 	if m.FormOpen && m.FormState != nil {
 		form := m.renderFormModal()
-		return OverlayModal(base, form, m.Width, m.Height)
+		view := OverlayModal(base, form, m.Width, m.Height)
+		if m.DiscardConfirmOpen && m.DiscardConfirmModal != nil && m.DiscardConfirmMouseHandler != nil {
+			discard := m.DiscardConfirmModal.Render(m.Width, m.Height, m.DiscardConfirmMouseHandler)
+			return OverlayModal(view, discard, m.Width, m.Height)
+		}
+		return view
 	}
 
 	// Overlay delete confirmation dialog if open
@@ -89,6 +95,36 @@ func (m Model) renderView() string {
 		return OverlayModal(base, rr, m.Width, m.Height)
 	}
 
+	// Overlay group prompt if open (declarative modal)
+	if m.GroupOpen && m.GroupModal != nil && m.GroupMouseHandler != nil {
+		gm := m.GroupModal.Render(m.Width, m.Height, m.GroupMouseHandler)
+		return OverlayModal(base, gm, m.Width, m.Height)
+	}
+
+	// Overlay quick-add prompt if open (declarative modal)
+	if m.QuickAddOpen && m.QuickAddModal != nil && m.QuickAddMouseHandler != nil {
+		qa := m.QuickAddModal.Render(m.Width, m.Height, m.QuickAddMouseHandler)
+		return OverlayModal(base, qa, m.Width, m.Height)
+	}
+
+	// Overlay message history pane if open (declarative modal)
+	if m.StatusHistoryOpen && m.StatusHistoryModal != nil && m.StatusHistoryMouseHandler != nil {
+		sh := m.StatusHistoryModal.Render(m.Width, m.Height, m.StatusHistoryMouseHandler)
+		return OverlayModal(base, sh, m.Width, m.Height)
+	}
+
+	// Overlay dependency graph if open (declarative modal)
+	if m.DepGraphOpen && m.DepGraphModal != nil && m.DepGraphMouseHandler != nil {
+		dg := m.DepGraphModal.Render(m.Width, m.Height, m.DepGraphMouseHandler)
+		return OverlayModal(base, dg, m.Width, m.Height)
+	}
+
+	// Overlay label picker if open (declarative modal)
+	if m.LabelPickerOpen && m.LabelPickerModal != nil && m.LabelPickerMouseHandler != nil {
+		lp := m.LabelPickerModal.Render(m.Width, m.Height, m.LabelPickerMouseHandler)
+		return OverlayModal(base, lp, m.Width, m.Height)
+	}
+
 	// Overlay activity detail modal if open
 	if m.ActivityDetailOpen && m.ActivityDetailModal != nil && m.ActivityDetailMouseHandler != nil {
 		detail := m.ActivityDetailModal.Render(m.Width, m.Height, m.ActivityDetailMouseHandler)
@@ -530,14 +566,7 @@ func (m Model) renderTaskListPanel(height int) string {
 
 	totalRows := len(m.TaskListRows)
 
-	// Build sort indicator
-	sortIndicator := ""
-	switch m.SortMode {
-	case SortByCreatedDesc:
-		sortIndicator = " [by:created]"
-	case SortByUpdatedDesc:
-		sortIndicator = " [by:updated]"
-	}
+	sortIndicator := m.sortIndicator()
 
 	if totalRows == 0 {
 		panelTitle := "TASK LIST" + sortIndicator
@@ -639,12 +668,14 @@ func (m Model) renderTaskListPanel(height int) string {
 		}
 
 		// Format row with category tag and selection highlight
-		tag := m.formatCategoryTag(row.Category)
+		tag := m.rowTag(row.Issue.ID, row.Category)
 		issueStr := m.formatIssueShort(&row.Issue)
 		line := fmt.Sprintf("%s %s", tag, issueStr)
 
 		if isActive && cursor == i {
 			line = highlightRow(line, m.Width-4)
+		} else if m.TaskList.Matches[row.Issue.ID] {
+			line = searchMatchRow(line, m.Width-4)
 		}
 
 		content.WriteString(line)
@@ -760,14 +791,22 @@ func (m Model) renderTaskListBoardView(height int) string {
 		}
 
 		// Status tag, type, ID, priority (matching swimlanes format)
-		tag := m.formatCategoryTag(TaskListCategory(biv.Category))
+		tag := m.rowTag(issue.ID, TaskListCategory(biv.Category))
 		typeStr := formatTypeIcon(issue.Type)
 		idStr := subtleStyle.Render(issue.ID)
 		priStr := formatPriority(issue.Priority)
 
+		ageCols := ""
+		if m.ShowAgeColumns {
+			ageCols = " " + m.formatAgeColumns(&issue)
+		}
+
 		// Title (truncated)
 		title := issue.Title
 		maxTitleLen := contentWidth - 38 // Leave room for indicators + ID
+		if m.ShowAgeColumns {
+			maxTitleLen -= ageColumnsWidth
+		}
 		if maxTitleLen < 10 {
 			maxTitleLen = 10
 		}
@@ -775,13 +814,14 @@ func (m Model) renderTaskListBoardView(height int) string {
 			title = title[:maxTitleLen-3] + "..."
 		}
 
-		// Build line: position + tag + type + id + priority + title
-		line := fmt.Sprintf("%s%s %s %s %s %s",
+		// Build line: position + tag + type + id + priority + age columns + title
+		line := fmt.Sprintf("%s%s %s %s %s%s %s",
 			posIndicator,
 			tag,
 			typeStr,
 			idStr,
 			priStr,
+			ageCols,
 			title,
 		)
 
@@ -809,14 +849,7 @@ func (m Model) renderBoardSwimlanesView(height int) string {
 
 	totalRows := len(m.BoardMode.SwimlaneRows)
 
-	// Build sort indicator
-	sortIndicator := ""
-	switch m.SortMode {
-	case SortByCreatedDesc:
-		sortIndicator = " [by:created]"
-	case SortByUpdatedDesc:
-		sortIndicator = " [by:updated]"
-	}
+	sortIndicator := m.sortIndicator()
 
 	// Empty state
 	if totalRows == 0 {
@@ -921,12 +954,14 @@ func (m Model) renderBoardSwimlanesView(height int) string {
 		}
 
 		// Format row with category tag and selection highlight
-		tag := m.formatCategoryTag(row.Category)
+		tag := m.rowTag(row.Issue.ID, row.Category)
 		issueStr := m.formatIssueShort(&row.Issue)
 		line := fmt.Sprintf("%s %s", tag, issueStr)
 
 		if isActive && cursor == i {
 			line = highlightRow(line, m.Width-4)
+		} else if m.TaskList.Matches[row.Issue.ID] {
+			line = searchMatchRow(line, m.Width-4)
 		}
 
 		content.WriteString(line)
@@ -1001,6 +1036,15 @@ func (m Model) formatCategoryHeader(cat TaskListCategory) string {
 	return ""
 }
 
+// isRework reports whether an issue is in the rework set of the data behind
+// the current view: the board's data in board mode, the task list otherwise.
+func (m Model) isRework(issueID string) bool {
+	if m.TaskListMode == TaskListModeBoard {
+		return m.BoardMode.SwimlaneData.ReworkIDs[issueID]
+	}
+	return m.TaskList.ReworkIDs[issueID]
+}
+
 // formatCategoryTag returns a short tag for inline display
 func (m Model) formatCategoryTag(cat TaskListCategory) string {
 	switch cat {
@@ -1107,7 +1151,7 @@ func (m Model) renderModal() string {
 
 	// Labels
 	if len(issue.Labels) > 0 {
-		labelStr := subtleStyle.Render("Labels: ") + strings.Join(issue.Labels, ", ")
+		labelStr := subtleStyle.Render("Labels: ") + m.renderLabels(issue.Labels)
 		lines = append(lines, labelStr)
 	}
 
@@ -1277,6 +1321,10 @@ func (m Model) renderModal() string {
 		}
 	}
 
+	// Blocked because + transitive blocker chain
+	lines = append(lines, renderBlockerChain(issue, modal.BlockerChain, contentWidth)...)
+	lines = append(lines, renderFileOverlapHint(modal.FileOverlaps, contentWidth)...)
+
 	// Blocks (dependents)
 	if len(modal.Blocks) > 0 {
 		header := fmt.Sprintf("BLOCKS (%d)", len(modal.Blocks))
@@ -1303,6 +1351,9 @@ func (m Model) renderModal() string {
 		lines = append(lines, "")
 	}
 
+	// Related (relates_to links)
+	lines = append(lines, renderRelatedSection(modal, contentWidth)...)
+
 	// Latest handoff
 	if modal.Handoff != nil {
 		lines = append(lines, sectionHeader.Render("LATEST HANDOFF"))
@@ -1332,18 +1383,18 @@ func (m Model) renderModal() string {
 	// Recent logs
 	if len(modal.Logs) > 0 {
 		lines = append(lines, sectionHeader.Render(fmt.Sprintf("RECENT LOGS (%d)", len(modal.Logs))))
-		for _, log := range modal.Logs {
-			lines = append(lines, renderLogLines(log, contentWidth)...)
-		}
+		lines = append(lines, renderLogGroups(modal.LogGroups, contentWidth)...)
 	}
 
 	// Comments
 	if len(modal.Comments) > 0 {
 		lines = append(lines, sectionHeader.Render(fmt.Sprintf("COMMENTS (%d)", len(modal.Comments))))
-		for _, c := range modal.Comments {
-			line := timestampStyle.Render(c.CreatedAt.Format("01-02 15:04")) + " " +
+		for _, c := range models.ThreadComments(modal.Comments) {
+			// Replies are indented under the comment they answer
+			indent := strings.Repeat("  ", c.Depth)
+			line := indent + timestampStyle.Render(c.CreatedAt.Format("01-02 15:04")) + " " +
 				subtleStyle.Render(truncateSession(c.SessionID)) + " " +
-				truncateString(c.Text, contentWidth-25)
+				truncateString(c.Text, contentWidth-25-len(indent))
 			lines = append(lines, line)
 		}
 	}
@@ -1528,6 +1579,22 @@ func (m Model) renderStatsContent(contentWidth int) string {
 	lines = append(lines, fmt.Sprintf("%s Created this week: %d", statsTableLabel.Render("  "), stats.CreatedThisWeek))
 	lines = append(lines, "")
 
+	// Velocity
+	if len(m.StatsData.ClosedPerDay) > 0 {
+		lines = append(lines, sectionHeader.Render("VELOCITY"))
+		total := 0
+		for _, n := range m.StatsData.ClosedPerDay {
+			total += n
+		}
+		lines = append(lines, fmt.Sprintf("%s Closed/day (%dd): %s %d", statsTableLabel.Render("  "),
+			len(m.StatsData.ClosedPerDay), sparkline(m.StatsData.ClosedPerDay), total))
+		for _, sa := range m.StatsData.SessionActivity {
+			lines = append(lines, fmt.Sprintf("%s %-10s actions/h (%dh): %s %d", statsTableLabel.Render("  "),
+				truncateSession(sa.SessionID), len(sa.PerHour), sparkline(sa.PerHour), sa.Total))
+		}
+		lines = append(lines, "")
+	}
+
 	// Activity
 	lines = append(lines, sectionHeader.Render("ACTIVITY"))
 	lines = append(lines, fmt.Sprintf("%s Total logs: %d", statsTableLabel.Render("  "), stats.TotalLogs))
@@ -1730,6 +1797,9 @@ func (m Model) renderFormModal() string {
 
 	if dropdownView != "" && m.FormState.Autofill != nil {
 		switch m.FormState.Autofill.FieldKey {
+		case formKeyLabels:
+			// Labels is the last field of its page
+			formView = formView + "\n" + dropdownView
 		case formKeyParent:
 			// Inject dropdown between Parent Epic and Story Points fields
 			formView = insertDropdownAfterField(formView, dropdownView, "Story Points")
@@ -2221,6 +2291,22 @@ func (m Model) renderSearchBar() string {
 		sb.WriteString(searchQueryActiveStyle.Render(m.SearchQuery))
 	}
 
+	// Content search indicator
+	if m.SearchContent {
+		sb.WriteString("  ")
+		sb.WriteString(subtleStyle.Render("[+activity]"))
+	}
+
+	// Highlight mode indicator, with the match count once there is a query
+	if m.SearchHighlight {
+		sb.WriteString("  ")
+		if m.SearchQuery != "" {
+			sb.WriteString(subtleStyle.Render(fmt.Sprintf("[highlight: %d]", len(m.searchMatchRows()))))
+		} else {
+			sb.WriteString(subtleStyle.Render("[highlight]"))
+		}
+	}
+
 	// Closed indicator
 	if m.IncludeClosed {
 		numClosed := len(m.TaskList.Closed)
@@ -2575,12 +2661,64 @@ func (m Model) formatIssueShort(issue *models.Issue) string {
 	//   priorityWidth = visual width of styled priority
 	//   3             = three spaces in issueStr format (after typeIcon, after id, after priority)
 	overhead := 4 + 5 + 1 + lipgloss.Width(typeIcon) + lipgloss.Width(idStr) + lipgloss.Width(priorityStr) + 3
+	ageCols := ""
+	if m.ShowAgeColumns {
+		ageCols = m.formatAgeColumns(issue) + " "
+		overhead += ageColumnsWidth
+	}
 	titleWidth := m.Width - overhead
 	if titleWidth < 20 {
 		titleWidth = 20 // minimum reasonable width
 	}
 
-	return fmt.Sprintf("%s %s %s %s", typeIcon, idStr, priorityStr, truncateString(issue.Title, titleWidth))
+	return fmt.Sprintf("%s %s %s %s%s", typeIcon, idStr, priorityStr, ageCols, truncateString(issue.Title, titleWidth))
+}
+
+// sortIndicator returns the task list title suffix naming a non-default
+// sort mode and the age columns when they are shown.
+func (m Model) sortIndicator() string {
+	indicator := ""
+	switch m.SortMode {
+	case SortByCreatedDesc:
+		indicator = " [by:created]"
+	case SortByUpdatedDesc:
+		indicator = " [by:updated]"
+	case SortByStaleness:
+		indicator = " [by:stale]"
+	}
+	if m.ShowAgeColumns {
+		indicator += " [age · in status]"
+	}
+	if m.ExpiredClaimsOnly {
+		indicator += " [expired claims]"
+	}
+	return indicator
+}
+
+// ageColumnsWidth is the visual width of formatAgeColumns plus its trailing
+// space.
+const ageColumnsWidth = 22
+
+// formatAgeColumns renders an issue's age and how long it has been in its
+// current status.
+func (m Model) formatAgeColumns(issue *models.Issue) string {
+	age := strings.TrimSuffix(output.FormatTimeAgo(issue.CreatedAt), " ago")
+	inStatus := strings.TrimSuffix(output.FormatTimeAgo(m.issueStatusSince(issue)), " ago")
+	return timestampStyle.Render(fmt.Sprintf("%-10s %-10s", age, inStatus))
+}
+
+// issueStatusSince returns when an issue entered its current status, from
+// the data behind the current view: the board's in board mode, the task
+// list's otherwise.
+func (m Model) issueStatusSince(issue *models.Issue) time.Time {
+	since := m.TaskList.StatusSince
+	if m.TaskListMode == TaskListModeBoard {
+		since = m.BoardMode.SwimlaneData.StatusSince
+	}
+	if t, ok := since[issue.ID]; ok {
+		return t
+	}
+	return issue.CreatedAt
 }
 
 // truncateString truncates a string to maxLen with ellipsis (ANSI-aware)
@@ -2595,6 +2733,28 @@ func truncateString(s string, maxLen int) string {
 	return ansi.Truncate(s, maxLen-3, "...")
 }
 
+// sparklineBars are the sparkline levels, lowest first.
+var sparklineBars = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline renders a series as one bar per value, scaled to its maximum.
+// Zero is always the lowest bar and any non-zero value is at least the
+// second, so quiet periods stand apart from slow ones.
+func sparkline(series []int) string {
+	peak := 0
+	for _, n := range series {
+		peak = max(peak, n)
+	}
+	var sb strings.Builder
+	for _, n := range series {
+		level := 0
+		if n > 0 {
+			level = 1 + (n*(len(sparklineBars)-1)-1)/peak
+		}
+		sb.WriteRune(sparklineBars[level])
+	}
+	return sb.String()
+}
+
 // truncateSession shortens a session ID for display
 func truncateSession(sessionID string) string {
 	if len(sessionID) <= 10 {
@@ -2611,6 +2771,7 @@ var (
 	reworkColor        = lipgloss.NewStyle().Foreground(lipgloss.Color("214")) // Orange/warning
 	inProgressColor    = lipgloss.NewStyle().Foreground(lipgloss.Color("45"))  // Cyan
 	pendingReviewColor = lipgloss.NewStyle().Foreground(lipgloss.Color("183")) // Light purple
+	expiredClaimColor  = lipgloss.NewStyle().Foreground(lipgloss.Color("167")) // Muted red: claim lease lapsed
 
 	// Prominent style for review alert in footer
 	reviewAlertStyle = lipgloss.NewStyle().